@@ -2289,6 +2289,9 @@ type DomainInfo struct {
 	HistoryArchivalURI          *string           `json:"historyArchivalURI,omitempty"`
 	VisibilityArchivalStatus    *int16            `json:"visibilityArchivalStatus,omitempty"`
 	VisibilityArchivalURI       *string           `json:"visibilityArchivalURI,omitempty"`
+	SearchAttributes            map[string]string `json:"searchAttributes,omitempty"`
+	WorkflowDefaults            []byte            `json:"workflowDefaults,omitempty"`
+	WorkflowDefaultsEncoding    *string           `json:"workflowDefaultsEncoding,omitempty"`
 }
 
 type _Map_String_String_MapItemList map[string]string
@@ -2343,7 +2346,7 @@ func (_Map_String_String_MapItemList) Close() {}
 //   }
 func (v *DomainInfo) ToWire() (wire.Value, error) {
 	var (
-		fields [21]wire.Field
+		fields [24]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -2517,6 +2520,30 @@ func (v *DomainInfo) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 48, Value: w}
 		i++
 	}
+	if v.SearchAttributes != nil {
+		w, err = wire.NewValueMap(_Map_String_String_MapItemList(v.SearchAttributes)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.WorkflowDefaults != nil {
+		w, err = wire.NewValueBinary(v.WorkflowDefaults), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 52, Value: w}
+		i++
+	}
+	if v.WorkflowDefaultsEncoding != nil {
+		w, err = wire.NewValueString(*(v.WorkflowDefaultsEncoding)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 54, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -2774,6 +2801,32 @@ func (v *DomainInfo) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 50:
+			if field.Value.Type() == wire.TMap {
+				v.SearchAttributes, err = _Map_String_String_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 52:
+			if field.Value.Type() == wire.TBinary {
+				v.WorkflowDefaults, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 54:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.WorkflowDefaultsEncoding = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -2788,7 +2841,7 @@ func (v *DomainInfo) String() string {
 		return "<nil>"
 	}
 
-	var fields [21]string
+	var fields [24]string
 	i := 0
 	if v.Name != nil {
 		fields[i] = fmt.Sprintf("Name: %v", *(v.Name))
@@ -2874,6 +2927,18 @@ func (v *DomainInfo) String() string {
 		fields[i] = fmt.Sprintf("VisibilityArchivalURI: %v", *(v.VisibilityArchivalURI))
 		i++
 	}
+	if v.SearchAttributes != nil {
+		fields[i] = fmt.Sprintf("SearchAttributes: %v", v.SearchAttributes)
+		i++
+	}
+	if v.WorkflowDefaults != nil {
+		fields[i] = fmt.Sprintf("WorkflowDefaults: %v", v.WorkflowDefaults)
+		i++
+	}
+	if v.WorkflowDefaultsEncoding != nil {
+		fields[i] = fmt.Sprintf("WorkflowDefaultsEncoding: %v", *(v.WorkflowDefaultsEncoding))
+		i++
+	}
 
 	return fmt.Sprintf("DomainInfo{%v}", strings.Join(fields[:i], ", "))
 }
@@ -2978,6 +3043,15 @@ func (v *DomainInfo) Equals(rhs *DomainInfo) bool {
 	if !_String_EqualsPtr(v.VisibilityArchivalURI, rhs.VisibilityArchivalURI) {
 		return false
 	}
+	if !((v.SearchAttributes == nil && rhs.SearchAttributes == nil) || (v.SearchAttributes != nil && rhs.SearchAttributes != nil && _Map_String_String_Equals(v.SearchAttributes, rhs.SearchAttributes))) {
+		return false
+	}
+	if !((v.WorkflowDefaults == nil && rhs.WorkflowDefaults == nil) || (v.WorkflowDefaults != nil && rhs.WorkflowDefaults != nil && bytes.Equal(v.WorkflowDefaults, rhs.WorkflowDefaults))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.WorkflowDefaultsEncoding, rhs.WorkflowDefaultsEncoding) {
+		return false
+	}
 
 	return true
 }
@@ -3062,6 +3136,15 @@ func (v *DomainInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v.VisibilityArchivalURI != nil {
 		enc.AddString("visibilityArchivalURI", *v.VisibilityArchivalURI)
 	}
+	if v.SearchAttributes != nil {
+		err = multierr.Append(err, enc.AddObject("searchAttributes", (_Map_String_String_Zapper)(v.SearchAttributes)))
+	}
+	if v.WorkflowDefaults != nil {
+		enc.AddString("workflowDefaults", base64.StdEncoding.EncodeToString(v.WorkflowDefaults))
+	}
+	if v.WorkflowDefaultsEncoding != nil {
+		enc.AddString("workflowDefaultsEncoding", *v.WorkflowDefaultsEncoding)
+	}
 	return err
 }
 
@@ -3380,6 +3463,51 @@ func (v *DomainInfo) IsSetVisibilityArchivalURI() bool {
 	return v != nil && v.VisibilityArchivalURI != nil
 }
 
+// GetSearchAttributes returns the value of SearchAttributes if it is set or its
+// zero value if it is unset.
+func (v *DomainInfo) GetSearchAttributes() (o map[string]string) {
+	if v != nil && v.SearchAttributes != nil {
+		return v.SearchAttributes
+	}
+
+	return
+}
+
+// IsSetSearchAttributes returns true if SearchAttributes is not nil.
+func (v *DomainInfo) IsSetSearchAttributes() bool {
+	return v != nil && v.SearchAttributes != nil
+}
+
+// GetWorkflowDefaults returns the value of WorkflowDefaults if it is set or its
+// zero value if it is unset.
+func (v *DomainInfo) GetWorkflowDefaults() (o []byte) {
+	if v != nil && v.WorkflowDefaults != nil {
+		return v.WorkflowDefaults
+	}
+
+	return
+}
+
+// IsSetWorkflowDefaults returns true if WorkflowDefaults is not nil.
+func (v *DomainInfo) IsSetWorkflowDefaults() bool {
+	return v != nil && v.WorkflowDefaults != nil
+}
+
+// GetWorkflowDefaultsEncoding returns the value of WorkflowDefaultsEncoding if it is set or its
+// zero value if it is unset.
+func (v *DomainInfo) GetWorkflowDefaultsEncoding() (o string) {
+	if v != nil && v.WorkflowDefaultsEncoding != nil {
+		return *v.WorkflowDefaultsEncoding
+	}
+
+	return
+}
+
+// IsSetWorkflowDefaultsEncoding returns true if WorkflowDefaultsEncoding is not nil.
+func (v *DomainInfo) IsSetWorkflowDefaultsEncoding() bool {
+	return v != nil && v.WorkflowDefaultsEncoding != nil
+}
+
 type HistoryTreeInfo struct {
 	CreatedTimeNanos *int64                       `json:"createdTimeNanos,omitempty"`
 	Ancestors        []*shared.HistoryBranchRange `json:"ancestors,omitempty"`
@@ -4902,6 +5030,7 @@ type ShardInfo struct {
 	ClusterTimerAckLevel      map[string]int64 `json:"clusterTimerAckLevel,omitempty"`
 	Owner                     *string          `json:"owner,omitempty"`
 	ClusterReplicationLevel   map[string]int64 `json:"clusterReplicationLevel,omitempty"`
+	OpenWorkflowCounts        map[string]int64 `json:"openWorkflowCounts,omitempty"`
 }
 
 type _Map_String_I64_MapItemList map[string]int64
@@ -4956,7 +5085,7 @@ func (_Map_String_I64_MapItemList) Close() {}
 //   }
 func (v *ShardInfo) ToWire() (wire.Value, error) {
 	var (
-		fields [10]wire.Field
+		fields [11]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -5042,6 +5171,14 @@ func (v *ShardInfo) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 40, Value: w}
 		i++
 	}
+	if v.OpenWorkflowCounts != nil {
+		w, err = wire.NewValueMap(_Map_String_I64_MapItemList(v.OpenWorkflowCounts)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 42, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -5189,6 +5326,14 @@ func (v *ShardInfo) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 42:
+			if field.Value.Type() == wire.TMap {
+				v.OpenWorkflowCounts, err = _Map_String_I64_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -5203,7 +5348,7 @@ func (v *ShardInfo) String() string {
 		return "<nil>"
 	}
 
-	var fields [10]string
+	var fields [11]string
 	i := 0
 	if v.StolenSinceRenew != nil {
 		fields[i] = fmt.Sprintf("StolenSinceRenew: %v", *(v.StolenSinceRenew))
@@ -5245,6 +5390,10 @@ func (v *ShardInfo) String() string {
 		fields[i] = fmt.Sprintf("ClusterReplicationLevel: %v", v.ClusterReplicationLevel)
 		i++
 	}
+	if v.OpenWorkflowCounts != nil {
+		fields[i] = fmt.Sprintf("OpenWorkflowCounts: %v", v.OpenWorkflowCounts)
+		i++
+	}
 
 	return fmt.Sprintf("ShardInfo{%v}", strings.Join(fields[:i], ", "))
 }
@@ -5306,6 +5455,9 @@ func (v *ShardInfo) Equals(rhs *ShardInfo) bool {
 	if !((v.ClusterReplicationLevel == nil && rhs.ClusterReplicationLevel == nil) || (v.ClusterReplicationLevel != nil && rhs.ClusterReplicationLevel != nil && _Map_String_I64_Equals(v.ClusterReplicationLevel, rhs.ClusterReplicationLevel))) {
 		return false
 	}
+	if !((v.OpenWorkflowCounts == nil && rhs.OpenWorkflowCounts == nil) || (v.OpenWorkflowCounts != nil && rhs.OpenWorkflowCounts != nil && _Map_String_I64_Equals(v.OpenWorkflowCounts, rhs.OpenWorkflowCounts))) {
+		return false
+	}
 
 	return true
 }
@@ -5357,6 +5509,9 @@ func (v *ShardInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v.ClusterReplicationLevel != nil {
 		err = multierr.Append(err, enc.AddObject("clusterReplicationLevel", (_Map_String_I64_Zapper)(v.ClusterReplicationLevel)))
 	}
+	if v.OpenWorkflowCounts != nil {
+		err = multierr.Append(err, enc.AddObject("openWorkflowCounts", (_Map_String_I64_Zapper)(v.OpenWorkflowCounts)))
+	}
 	return err
 }
 
@@ -5510,6 +5665,21 @@ func (v *ShardInfo) IsSetClusterReplicationLevel() bool {
 	return v != nil && v.ClusterReplicationLevel != nil
 }
 
+// GetOpenWorkflowCounts returns the value of OpenWorkflowCounts if it is set or its
+// zero value if it is unset.
+func (v *ShardInfo) GetOpenWorkflowCounts() (o map[string]int64) {
+	if v != nil && v.OpenWorkflowCounts != nil {
+		return v.OpenWorkflowCounts
+	}
+
+	return
+}
+
+// IsSetOpenWorkflowCounts returns true if OpenWorkflowCounts is not nil.
+func (v *ShardInfo) IsSetOpenWorkflowCounts() bool {
+	return v != nil && v.OpenWorkflowCounts != nil
+}
+
 type SignalInfo struct {
 	Version               *int64  `json:"version,omitempty"`
 	InitiatedEventBatchID *int64  `json:"initiatedEventBatchID,omitempty"`
@@ -6177,10 +6347,13 @@ func (v *TaskInfo) IsSetCreatedTimeNanos() bool {
 }
 
 type TaskListInfo struct {
-	Kind             *int16 `json:"kind,omitempty"`
-	AckLevel         *int64 `json:"ackLevel,omitempty"`
-	ExpiryTimeNanos  *int64 `json:"expiryTimeNanos,omitempty"`
-	LastUpdatedNanos *int64 `json:"lastUpdatedNanos,omitempty"`
+	Kind               *int16   `json:"kind,omitempty"`
+	AckLevel           *int64   `json:"ackLevel,omitempty"`
+	ExpiryTimeNanos    *int64   `json:"expiryTimeNanos,omitempty"`
+	LastUpdatedNanos   *int64   `json:"lastUpdatedNanos,omitempty"`
+	BuildIdSets        []string `json:"buildIdSets,omitempty"`
+	NumWritePartitions *int32   `json:"numWritePartitions,omitempty"`
+	NumReadPartitions  *int32   `json:"numReadPartitions,omitempty"`
 }
 
 // ToWire translates a TaskListInfo struct into a Thrift-level intermediate
@@ -6200,7 +6373,7 @@ type TaskListInfo struct {
 //   }
 func (v *TaskListInfo) ToWire() (wire.Value, error) {
 	var (
-		fields [4]wire.Field
+		fields [7]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -6238,6 +6411,30 @@ func (v *TaskListInfo) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 16, Value: w}
 		i++
 	}
+	if v.BuildIdSets != nil {
+		w, err = wire.NewValueList(_List_String_ValueList(v.BuildIdSets)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 18, Value: w}
+		i++
+	}
+	if v.NumWritePartitions != nil {
+		w, err = wire.NewValueI32(*(v.NumWritePartitions)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.NumReadPartitions != nil {
+		w, err = wire.NewValueI32(*(v.NumReadPartitions)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 22, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -6303,6 +6500,34 @@ func (v *TaskListInfo) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 18:
+			if field.Value.Type() == wire.TList {
+				v.BuildIdSets, err = _List_String_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.NumWritePartitions = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 22:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.NumReadPartitions = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -6317,7 +6542,7 @@ func (v *TaskListInfo) String() string {
 		return "<nil>"
 	}
 
-	var fields [4]string
+	var fields [7]string
 	i := 0
 	if v.Kind != nil {
 		fields[i] = fmt.Sprintf("Kind: %v", *(v.Kind))
@@ -6335,6 +6560,18 @@ func (v *TaskListInfo) String() string {
 		fields[i] = fmt.Sprintf("LastUpdatedNanos: %v", *(v.LastUpdatedNanos))
 		i++
 	}
+	if v.BuildIdSets != nil {
+		fields[i] = fmt.Sprintf("BuildIdSets: %v", v.BuildIdSets)
+		i++
+	}
+	if v.NumWritePartitions != nil {
+		fields[i] = fmt.Sprintf("NumWritePartitions: %v", *(v.NumWritePartitions))
+		i++
+	}
+	if v.NumReadPartitions != nil {
+		fields[i] = fmt.Sprintf("NumReadPartitions: %v", *(v.NumReadPartitions))
+		i++
+	}
 
 	return fmt.Sprintf("TaskListInfo{%v}", strings.Join(fields[:i], ", "))
 }
@@ -6361,6 +6598,15 @@ func (v *TaskListInfo) Equals(rhs *TaskListInfo) bool {
 	if !_I64_EqualsPtr(v.LastUpdatedNanos, rhs.LastUpdatedNanos) {
 		return false
 	}
+	if !((v.BuildIdSets == nil && rhs.BuildIdSets == nil) || (v.BuildIdSets != nil && rhs.BuildIdSets != nil && _List_String_Equals(v.BuildIdSets, rhs.BuildIdSets))) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.NumWritePartitions, rhs.NumWritePartitions) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.NumReadPartitions, rhs.NumReadPartitions) {
+		return false
+	}
 
 	return true
 }
@@ -6383,6 +6629,15 @@ func (v *TaskListInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v.LastUpdatedNanos != nil {
 		enc.AddInt64("lastUpdatedNanos", *v.LastUpdatedNanos)
 	}
+	if v.BuildIdSets != nil {
+		err = multierr.Append(err, enc.AddArray("buildIdSets", (_List_String_Zapper)(v.BuildIdSets)))
+	}
+	if v.NumWritePartitions != nil {
+		enc.AddInt32("numWritePartitions", *v.NumWritePartitions)
+	}
+	if v.NumReadPartitions != nil {
+		enc.AddInt32("numReadPartitions", *v.NumReadPartitions)
+	}
 	return err
 }
 
@@ -6446,6 +6701,51 @@ func (v *TaskListInfo) IsSetLastUpdatedNanos() bool {
 	return v != nil && v.LastUpdatedNanos != nil
 }
 
+// GetBuildIdSets returns the value of BuildIdSets if it is set or its
+// zero value if it is unset.
+func (v *TaskListInfo) GetBuildIdSets() (o []string) {
+	if v != nil && v.BuildIdSets != nil {
+		return v.BuildIdSets
+	}
+
+	return
+}
+
+// IsSetBuildIdSets returns true if BuildIdSets is not nil.
+func (v *TaskListInfo) IsSetBuildIdSets() bool {
+	return v != nil && v.BuildIdSets != nil
+}
+
+// GetNumWritePartitions returns the value of NumWritePartitions if it is set or its
+// zero value if it is unset.
+func (v *TaskListInfo) GetNumWritePartitions() (o int32) {
+	if v != nil && v.NumWritePartitions != nil {
+		return *v.NumWritePartitions
+	}
+
+	return
+}
+
+// IsSetNumWritePartitions returns true if NumWritePartitions is not nil.
+func (v *TaskListInfo) IsSetNumWritePartitions() bool {
+	return v != nil && v.NumWritePartitions != nil
+}
+
+// GetNumReadPartitions returns the value of NumReadPartitions if it is set or its
+// zero value if it is unset.
+func (v *TaskListInfo) GetNumReadPartitions() (o int32) {
+	if v != nil && v.NumReadPartitions != nil {
+		return *v.NumReadPartitions
+	}
+
+	return
+}
+
+// IsSetNumReadPartitions returns true if NumReadPartitions is not nil.
+func (v *TaskListInfo) IsSetNumReadPartitions() bool {
+	return v != nil && v.NumReadPartitions != nil
+}
+
 type TimerInfo struct {
 	Version         *int64 `json:"version,omitempty"`
 	StartedID       *int64 `json:"startedID,omitempty"`
@@ -7833,6 +8133,7 @@ type WorkflowExecutionInfo struct {
 	Memo                                    map[string][]byte           `json:"memo,omitempty"`
 	VersionHistories                        []byte                      `json:"versionHistories,omitempty"`
 	VersionHistoriesEncoding                *string                     `json:"versionHistoriesEncoding,omitempty"`
+	StartingBuildID *string `json:"startingBuildID,omitempty"`
 }
 
 type _Map_String_Binary_MapItemList map[string][]byte
@@ -7890,7 +8191,7 @@ func (_Map_String_Binary_MapItemList) Close() {}
 //   }
 func (v *WorkflowExecutionInfo) ToWire() (wire.Value, error) {
 	var (
-		fields [60]wire.Field
+		fields [61]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -8376,6 +8677,14 @@ func (v *WorkflowExecutionInfo) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 124, Value: w}
 		i++
 	}
+	if v.StartingBuildID != nil {
+		w, err = wire.NewValueString(*(v.StartingBuildID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 126, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -9007,6 +9316,16 @@ func (v *WorkflowExecutionInfo) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 126:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.StartingBuildID = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -9021,7 +9340,7 @@ func (v *WorkflowExecutionInfo) String() string {
 		return "<nil>"
 	}
 
-	var fields [60]string
+	var fields [61]string
 	i := 0
 	if v.ParentDomainID != nil {
 		fields[i] = fmt.Sprintf("ParentDomainID: %v", v.ParentDomainID)
@@ -9263,6 +9582,10 @@ func (v *WorkflowExecutionInfo) String() string {
 		fields[i] = fmt.Sprintf("VersionHistoriesEncoding: %v", *(v.VersionHistoriesEncoding))
 		i++
 	}
+	if v.StartingBuildID != nil {
+		fields[i] = fmt.Sprintf("StartingBuildID: %v", *(v.StartingBuildID))
+		i++
+	}
 
 	return fmt.Sprintf("WorkflowExecutionInfo{%v}", strings.Join(fields[:i], ", "))
 }
@@ -9474,6 +9797,9 @@ func (v *WorkflowExecutionInfo) Equals(rhs *WorkflowExecutionInfo) bool {
 	if !_String_EqualsPtr(v.VersionHistoriesEncoding, rhs.VersionHistoriesEncoding) {
 		return false
 	}
+	if !_String_EqualsPtr(v.StartingBuildID, rhs.StartingBuildID) {
+		return false
+	}
 
 	return true
 }
@@ -9675,6 +10001,9 @@ func (v *WorkflowExecutionInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err
 	if v.VersionHistoriesEncoding != nil {
 		enc.AddString("versionHistoriesEncoding", *v.VersionHistoriesEncoding)
 	}
+	if v.StartingBuildID != nil {
+		enc.AddString("startingBuildID", *v.StartingBuildID)
+	}
 	return err
 }
 
@@ -10578,16 +10907,31 @@ func (v *WorkflowExecutionInfo) IsSetVersionHistoriesEncoding() bool {
 	return v != nil && v.VersionHistoriesEncoding != nil
 }
 
+// GetStartingBuildID returns the value of StartingBuildID if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionInfo) GetStartingBuildID() (o string) {
+	if v != nil && v.StartingBuildID != nil {
+		return *v.StartingBuildID
+	}
+
+	return
+}
+
+// IsSetStartingBuildID returns true if StartingBuildID is not nil.
+func (v *WorkflowExecutionInfo) IsSetStartingBuildID() bool {
+	return v != nil && v.StartingBuildID != nil
+}
+
 // ThriftModule represents the IDL file used to generate this package.
 var ThriftModule = &thriftreflect.ThriftModule{
 	Name:     "sqlblobs",
 	Package:  "github.com/temporalio/temporal/.gen/go/sqlblobs",
 	FilePath: "sqlblobs.thrift",
-	SHA1:     "6a5c240f8ec7e06ccf7261b69ae1402ce1768541",
+	SHA1:     "ebddff35b227fdbea94ab75c7b50d3a4f6dac47a",
 	Includes: []*thriftreflect.ThriftModule{
 		shared.ThriftModule,
 	},
 	Raw: rawIDL,
 }
 
-const rawIDL = "// Copyright (c) 2017 Uber Technologies, Inc.\n//\n// Permission is hereby granted, free of charge, to any person obtaining a copy\n// of this software and associated documentation files (the \"Software\"), to deal\n// in the Software without restriction, including without limitation the rights\n// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell\n// copies of the Software, and to permit persons to whom the Software is\n// furnished to do so, subject to the following conditions:\n//\n// The above copyright notice and this permission notice shall be included in\n// all copies or substantial portions of the Software.\n//\n// THE SOFTWARE IS PROVIDED \"AS IS\", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR\n// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,\n// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE\n// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER\n// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,\n// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN\n// THE SOFTWARE.\n\nnamespace java com.temporalio.temporal.sqlblobs\n\ninclude \"shared.thrift\"\n\nstruct ShardInfo {\n  10: optional i32 stolenSinceRenew\n  12: optional i64 (js.type = \"Long\") updatedAtNanos\n  14: optional i64 (js.type = \"Long\") replicationAckLevel\n  16: optional i64 (js.type = \"Long\") transferAckLevel\n  18: optional i64 (js.type = \"Long\") timerAckLevelNanos\n  24: optional i64 (js.type = \"Long\") domainNotificationVersion\n  34: optional map<string, i64> clusterTransferAckLevel\n  36: optional map<string, i64> clusterTimerAckLevel\n  38: optional string owner\n  40: optional map<string, i64> clusterReplicationLevel\n}\n\nstruct DomainInfo {\n  10: optional string name\n  12: optional string description\n  14: optional string owner\n  16: optional i32 status\n  18: optional i16 retentionDays\n  20: optional bool emitMetric\n  22: optional string archivalBucket\n  24: optional i16 archivalStatus\n  26: optional i64 (js.type = \"Long\") configVersion\n  28: optional i64 (js.type = \"Long\") notificationVersion\n  30: optional i64 (js.type = \"Long\") failoverNotificationVersion\n  32: optional i64 (js.type = \"Long\") failoverVersion\n  34: optional string activeClusterName\n  36: optional list<string> clusters\n  38: optional map<string, string> data\n  39: optional binary badBinaries\n  40: optional string badBinariesEncoding\n  42: optional i16 historyArchivalStatus\n  44: optional string historyArchivalURI\n  46: optional i16 visibilityArchivalStatus\n  48: optional string visibilityArchivalURI\n}\n\nstruct HistoryTreeInfo {\n  10: optional i64 (js.type = \"Long\") createdTimeNanos // For fork operation to prevent race condition of leaking event data when forking branches fail. Also can be used for clean up leaked data\n  12: optional list<shared.HistoryBranchRange> ancestors\n  14: optional string info // For lookup back to workflow during debugging, also background cleanup when fork operation cannot finish self cleanup due to crash.\n}\n\nstruct ReplicationInfo {\n  10: optional i64 (js.type = \"Long\") version\n  12: optional i64 (js.type = \"Long\") lastEventID\n}\n\nstruct WorkflowExecutionInfo {\n  10: optional binary parentDomainID\n  12: optional string parentWorkflowID\n  14: optional binary parentRunID\n  16: optional i64 (js.type = \"Long\") initiatedID\n  18: optional i64 (js.type = \"Long\") completionEventBatchID\n  20: optional binary completionEvent\n  22: optional string completionEventEncoding\n  24: optional string taskList\n  26: optional string workflowTypeName\n  28: optional i32 workflowTimeoutSeconds\n  30: optional i32 decisionTaskTimeoutSeconds\n  32: optional binary executionContext\n  34: optional i32 state\n  36: optional i32 closeStatus\n  38: optional i64 (js.type = \"Long\") startVersion\n  40: optional i64 (js.type = \"Long\") currentVersion\n  44: optional i64 (js.type = \"Long\") lastWriteEventID\n  46: optional map<string, ReplicationInfo> lastReplicationInfo\n  48: optional i64 (js.type = \"Long\") lastEventTaskID\n  50: optional i64 (js.type = \"Long\") lastFirstEventID\n  52: optional i64 (js.type = \"Long\") lastProcessedEvent\n  54: optional i64 (js.type = \"Long\") startTimeNanos\n  56: optional i64 (js.type = \"Long\") lastUpdatedTimeNanos\n  58: optional i64 (js.type = \"Long\") decisionVersion\n  60: optional i64 (js.type = \"Long\") decisionScheduleID\n  62: optional i64 (js.type = \"Long\") decisionStartedID\n  64: optional i32 decisionTimeout\n  66: optional i64 (js.type = \"Long\") decisionAttempt\n  68: optional i64 (js.type = \"Long\") decisionStartedTimestampNanos\n  69: optional i64 (js.type = \"Long\") decisionScheduledTimestampNanos\n  70: optional bool cancelRequested\n  71: optional i64 (js.type = \"Long\") decisionOriginalScheduledTimestampNanos\n  72: optional string createRequestID\n  74: optional string decisionRequestID\n  76: optional string cancelRequestID\n  78: optional string stickyTaskList\n  80: optional i64 (js.type = \"Long\") stickyScheduleToStartTimeout\n  82: optional i64 (js.type = \"Long\") retryAttempt\n  84: optional i32 retryInitialIntervalSeconds\n  86: optional i32 retryMaximumIntervalSeconds\n  88: optional i32 retryMaximumAttempts\n  90: optional i32 retryExpirationSeconds\n  92: optional double retryBackoffCoefficient\n  94: optional i64 (js.type = \"Long\") retryExpirationTimeNanos\n  96: optional list<string> retryNonRetryableErrors\n  98: optional bool hasRetryPolicy\n  100: optional string cronSchedule\n  102: optional i32 eventStoreVersion\n  104: optional binary eventBranchToken\n  106: optional i64 (js.type = \"Long\") signalCount\n  108: optional i64 (js.type = \"Long\") historySize\n  110: optional string clientLibraryVersion\n  112: optional string clientFeatureVersion\n  114: optional string clientImpl\n  115: optional binary autoResetPoints\n  116: optional string autoResetPointsEncoding\n  118: optional map<string, binary> searchAttributes\n  120: optional map<string, binary> memo\n  122: optional binary versionHistories\n  124: optional string versionHistoriesEncoding\n}\n\nstruct ActivityInfo {\n  10: optional i64 (js.type = \"Long\") version\n  12: optional i64 (js.type = \"Long\") scheduledEventBatchID\n  14: optional binary scheduledEvent\n  16: optional string scheduledEventEncoding\n  18: optional i64 (js.type = \"Long\") scheduledTimeNanos\n  20: optional i64 (js.type = \"Long\") startedID\n  22: optional binary startedEvent\n  24: optional string startedEventEncoding\n  26: optional i64 (js.type = \"Long\") startedTimeNanos\n  28: optional string activityID\n  30: optional string requestID\n  32: optional i32 scheduleToStartTimeoutSeconds\n  34: optional i32 scheduleToCloseTimeoutSeconds\n  36: optional i32 startToCloseTimeoutSeconds\n  38: optional i32 heartbeatTimeoutSeconds\n  40: optional bool cancelRequested\n  42: optional i64 (js.type = \"Long\") cancelRequestID\n  44: optional i32 timerTaskStatus\n  46: optional i32 attempt\n  48: optional string taskList\n  50: optional string startedIdentity\n  52: optional bool hasRetryPolicy\n  54: optional i32 retryInitialIntervalSeconds\n  56: optional i32 retryMaximumIntervalSeconds\n  58: optional i32 retryMaximumAttempts\n  60: optional i64 (js.type = \"Long\") retryExpirationTimeNanos\n  62: optional double retryBackoffCoefficient\n  64: optional list<string> retryNonRetryableErrors\n  66: optional string retryLastFailureReason\n  68: optional string retryLastWorkerIdentity\n  70: optional binary retryLastFailureDetails\n}\n\nstruct ChildExecutionInfo {\n  10: optional i64 (js.type = \"Long\") version\n  12: optional i64 (js.type = \"Long\") initiatedEventBatchID\n  14: optional i64 (js.type = \"Long\") startedID\n  16: optional binary initiatedEvent\n  18: optional string initiatedEventEncoding\n  20: optional string startedWorkflowID\n  22: optional binary startedRunID\n  24: optional binary startedEvent\n  26: optional string startedEventEncoding\n  28: optional string createRequestID\n  30: optional string domainName\n  32: optional string workflowTypeName\n  35: optional i32 parentClosePolicy\n}\n\nstruct SignalInfo {\n  10: optional i64 (js.type = \"Long\") version\n  11: optional i64 (js.type = \"Long\") initiatedEventBatchID\n  12: optional string requestID\n  14: optional string name\n  16: optional binary input\n  18: optional binary control\n}\n\nstruct RequestCancelInfo {\n  10: optional i64 (js.type = \"Long\") version\n  11: optional i64 (js.type = \"Long\") initiatedEventBatchID\n  12: optional string cancelRequestID\n}\n\nstruct TimerInfo {\n  10: optional i64 (js.type = \"Long\") version\n  12: optional i64 (js.type = \"Long\") startedID\n  14: optional i64 (js.type = \"Long\") expiryTimeNanos\n  // TaskID is a misleading variable, it actually serves\n  // the purpose of indicating whether a timer task is\n  // generated for this timer info\n  16: optional i64 (js.type = \"Long\") taskID\n}\n\nstruct TaskInfo {\n  10: optional string workflowID\n  12: optional binary runID\n  13: optional i64 (js.type = \"Long\") scheduleID\n  14: optional i64 (js.type = \"Long\") expiryTimeNanos\n  15: optional i64 (js.type = \"Long\") createdTimeNanos\n}\n\nstruct TaskListInfo {\n  10: optional i16 kind // {Normal, Sticky}\n  12: optional i64 (js.type = \"Long\") ackLevel\n  14: optional i64 (js.type = \"Long\") expiryTimeNanos\n  16: optional i64 (js.type = \"Long\") lastUpdatedNanos\n}\n\nstruct TransferTaskInfo {\n  10: optional binary domainID\n  12: optional string workflowID\n  14: optional binary runID\n  16: optional i16 taskType\n  18: optional binary targetDomainID\n  20: optional string targetWorkflowID\n  22: optional binary targetRunID\n  24: optional string taskList\n  26: optional bool targetChildWorkflowOnly\n  28: optional i64 (js.type = \"Long\") scheduleID\n  30: optional i64 (js.type = \"Long\") version\n  32: optional i64 (js.type = \"Long\") visibilityTimestampNanos\n}\n\nstruct TimerTaskInfo {\n  10: optional binary domainID\n  12: optional string workflowID\n  14: optional binary runID\n  16: optional i16 taskType\n  18: optional i16 timeoutType\n  20: optional i64 (js.type = \"Long\") version\n  22: optional i64 (js.type = \"Long\") scheduleAttempt\n  24: optional i64 (js.type = \"Long\") eventID\n}\n\nstruct ReplicationTaskInfo {\n  10: optional binary domainID\n  12: optional string workflowID\n  14: optional binary runID\n  16: optional i16 taskType\n  18: optional i64 (js.type = \"Long\") version\n  20: optional i64 (js.type = \"Long\") firstEventID\n  22: optional i64 (js.type = \"Long\") nextEventID\n  24: optional i64 (js.type = \"Long\") scheduledID\n  26: optional i32 eventStoreVersion\n  28: optional i32 newRunEventStoreVersion\n  30: optional binary branch_token\n  32: optional map<string, ReplicationInfo> lastReplicationInfo\n  34: optional binary newRunBranchToken\n  36: optional bool resetWorkflow\n}"
+const rawIDL = "// Copyright (c) 2017 Uber Technologies, Inc.\n//\n// Permission is hereby granted, free of charge, to any person obtaining a copy\n// of this software and associated documentation files (the \"Software\"), to deal\n// in the Software without restriction, including without limitation the rights\n// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell\n// copies of the Software, and to permit persons to whom the Software is\n// furnished to do so, subject to the following conditions:\n//\n// The above copyright notice and this permission notice shall be included in\n// all copies or substantial portions of the Software.\n//\n// THE SOFTWARE IS PROVIDED \"AS IS\", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR\n// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,\n// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE\n// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER\n// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,\n// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN\n// THE SOFTWARE.\n\nnamespace java com.temporalio.temporal.sqlblobs\n\ninclude \"shared.thrift\"\n\nstruct ShardInfo {\n  10: optional i32 stolenSinceRenew\n  12: optional i64 (js.type = \"Long\") updatedAtNanos\n  14: optional i64 (js.type = \"Long\") replicationAckLevel\n  16: optional i64 (js.type = \"Long\") transferAckLevel\n  18: optional i64 (js.type = \"Long\") timerAckLevelNanos\n  24: optional i64 (js.type = \"Long\") domainNotificationVersion\n  34: optional map<string, i64> clusterTransferAckLevel\n  36: optional map<string, i64> clusterTimerAckLevel\n  38: optional string owner\n  40: optional map<string, i64> clusterReplicationLevel\n  42: optional map<string, i64> openWorkflowCounts // domainID -> open workflows of the shard\n}\n\nstruct DomainInfo {\n  10: optional string name\n  12: optional string description\n  14: optional string owner\n  16: optional i32 status\n  18: optional i16 retentionDays\n  20: optional bool emitMetric\n  22: optional string archivalBucket\n  24: optional i16 archivalStatus\n  26: optional i64 (js.type = \"Long\") configVersion\n  28: optional i64 (js.type = \"Long\") notificationVersion\n  30: optional i64 (js.type = \"Long\") failoverNotificationVersion\n  32: optional i64 (js.type = \"Long\") failoverVersion\n  34: optional string activeClusterName\n  36: optional list<string> clusters\n  38: optional map<string, string> data\n  39: optional binary badBinaries\n  40: optional string badBinariesEncoding\n  42: optional i16 historyArchivalStatus\n  44: optional string historyArchivalURI\n  46: optional i16 visibilityArchivalStatus\n  48: optional string visibilityArchivalURI\n  50: optional map<string, string> searchAttributes // domain level search attribute name to cluster search attribute\n  52: optional binary workflowDefaults\n  54: optional string workflowDefaultsEncoding\n}\n\nstruct HistoryTreeInfo {\n  10: optional i64 (js.type = \"Long\") createdTimeNanos // For fork operation to prevent race condition of leaking event data when forking branches fail. Also can be used for clean up leaked data\n  12: optional list<shared.HistoryBranchRange> ancestors\n  14: optional string info // For lookup back to workflow during debugging, also background cleanup when fork operation cannot finish self cleanup due to crash.\n}\n\nstruct ReplicationInfo {\n  10: optional i64 (js.type = \"Long\") version\n  12: optional i64 (js.type = \"Long\") lastEventID\n}\n\nstruct WorkflowExecutionInfo {\n  10: optional binary parentDomainID\n  12: optional string parentWorkflowID\n  14: optional binary parentRunID\n  16: optional i64 (js.type = \"Long\") initiatedID\n  18: optional i64 (js.type = \"Long\") completionEventBatchID\n  20: optional binary completionEvent\n  22: optional string completionEventEncoding\n  24: optional string taskList\n  26: optional string workflowTypeName\n  28: optional i32 workflowTimeoutSeconds\n  30: optional i32 decisionTaskTimeoutSeconds\n  32: optional binary executionContext\n  34: optional i32 state\n  36: optional i32 closeStatus\n  38: optional i64 (js.type = \"Long\") startVersion\n  40: optional i64 (js.type = \"Long\") currentVersion\n  44: optional i64 (js.type = \"Long\") lastWriteEventID\n  46: optional map<string, ReplicationInfo> lastReplicationInfo\n  48: optional i64 (js.type = \"Long\") lastEventTaskID\n  50: optional i64 (js.type = \"Long\") lastFirstEventID\n  52: optional i64 (js.type = \"Long\") lastProcessedEvent\n  54: optional i64 (js.type = \"Long\") startTimeNanos\n  56: optional i64 (js.type = \"Long\") lastUpdatedTimeNanos\n  58: optional i64 (js.type = \"Long\") decisionVersion\n  60: optional i64 (js.type = \"Long\") decisionScheduleID\n  62: optional i64 (js.type = \"Long\") decisionStartedID\n  64: optional i32 decisionTimeout\n  66: optional i64 (js.type = \"Long\") decisionAttempt\n  68: optional i64 (js.type = \"Long\") decisionStartedTimestampNanos\n  69: optional i64 (js.type = \"Long\") decisionScheduledTimestampNanos\n  70: optional bool cancelRequested\n  71: optional i64 (js.type = \"Long\") decisionOriginalScheduledTimestampNanos\n  72: optional string createRequestID\n  74: optional string decisionRequestID\n  76: optional string cancelRequestID\n  78: optional string stickyTaskList\n  80: optional i64 (js.type = \"Long\") stickyScheduleToStartTimeout\n  82: optional i64 (js.type = \"Long\") retryAttempt\n  84: optional i32 retryInitialIntervalSeconds\n  86: optional i32 retryMaximumIntervalSeconds\n  88: optional i32 retryMaximumAttempts\n  90: optional i32 retryExpirationSeconds\n  92: optional double retryBackoffCoefficient\n  94: optional i64 (js.type = \"Long\") retryExpirationTimeNanos\n  96: optional list<string> retryNonRetryableErrors\n  98: optional bool hasRetryPolicy\n  100: optional string cronSchedule\n  102: optional i32 eventStoreVersion\n  104: optional binary eventBranchToken\n  106: optional i64 (js.type = \"Long\") signalCount\n  108: optional i64 (js.type = \"Long\") historySize\n  110: optional string clientLibraryVersion\n  112: optional string clientFeatureVersion\n  114: optional string clientImpl\n  115: optional binary autoResetPoints\n  116: optional string autoResetPointsEncoding\n  118: optional map<string, binary> searchAttributes\n  120: optional map<string, binary> memo\n  122: optional binary versionHistories\n  124: optional string versionHistoriesEncoding\n  126: optional string startingBuildID // build ID of the worker that completed the first decision\n}\n\nstruct ActivityInfo {\n  10: optional i64 (js.type = \"Long\") version\n  12: optional i64 (js.type = \"Long\") scheduledEventBatchID\n  14: optional binary scheduledEvent\n  16: optional string scheduledEventEncoding\n  18: optional i64 (js.type = \"Long\") scheduledTimeNanos\n  20: optional i64 (js.type = \"Long\") startedID\n  22: optional binary startedEvent\n  24: optional string startedEventEncoding\n  26: optional i64 (js.type = \"Long\") startedTimeNanos\n  28: optional string activityID\n  30: optional string requestID\n  32: optional i32 scheduleToStartTimeoutSeconds\n  34: optional i32 scheduleToCloseTimeoutSeconds\n  36: optional i32 startToCloseTimeoutSeconds\n  38: optional i32 heartbeatTimeoutSeconds\n  40: optional bool cancelRequested\n  42: optional i64 (js.type = \"Long\") cancelRequestID\n  44: optional i32 timerTaskStatus\n  46: optional i32 attempt\n  48: optional string taskList\n  50: optional string startedIdentity\n  52: optional bool hasRetryPolicy\n  54: optional i32 retryInitialIntervalSeconds\n  56: optional i32 retryMaximumIntervalSeconds\n  58: optional i32 retryMaximumAttempts\n  60: optional i64 (js.type = \"Long\") retryExpirationTimeNanos\n  62: optional double retryBackoffCoefficient\n  64: optional list<string> retryNonRetryableErrors\n  66: optional string retryLastFailureReason\n  68: optional string retryLastWorkerIdentity\n  70: optional binary retryLastFailureDetails\n}\n\nstruct ChildExecutionInfo {\n  10: optional i64 (js.type = \"Long\") version\n  12: optional i64 (js.type = \"Long\") initiatedEventBatchID\n  14: optional i64 (js.type = \"Long\") startedID\n  16: optional binary initiatedEvent\n  18: optional string initiatedEventEncoding\n  20: optional string startedWorkflowID\n  22: optional binary startedRunID\n  24: optional binary startedEvent\n  26: optional string startedEventEncoding\n  28: optional string createRequestID\n  30: optional string domainName\n  32: optional string workflowTypeName\n  35: optional i32 parentClosePolicy\n}\n\nstruct SignalInfo {\n  10: optional i64 (js.type = \"Long\") version\n  11: optional i64 (js.type = \"Long\") initiatedEventBatchID\n  12: optional string requestID\n  14: optional string name\n  16: optional binary input\n  18: optional binary control\n}\n\nstruct RequestCancelInfo {\n  10: optional i64 (js.type = \"Long\") version\n  11: optional i64 (js.type = \"Long\") initiatedEventBatchID\n  12: optional string cancelRequestID\n}\n\nstruct TimerInfo {\n  10: optional i64 (js.type = \"Long\") version\n  12: optional i64 (js.type = \"Long\") startedID\n  14: optional i64 (js.type = \"Long\") expiryTimeNanos\n  // TaskID is a misleading variable, it actually serves\n  // the purpose of indicating whether a timer task is\n  // generated for this timer info\n  16: optional i64 (js.type = \"Long\") taskID\n}\n\nstruct TaskInfo {\n  10: optional string workflowID\n  12: optional binary runID\n  13: optional i64 (js.type = \"Long\") scheduleID\n  14: optional i64 (js.type = \"Long\") expiryTimeNanos\n  15: optional i64 (js.type = \"Long\") createdTimeNanos\n}\n\nstruct TaskListInfo {\n  10: optional i16 kind // {Normal, Sticky}\n  12: optional i64 (js.type = \"Long\") ackLevel\n  14: optional i64 (js.type = \"Long\") expiryTimeNanos\n  16: optional i64 (js.type = \"Long\") lastUpdatedNanos\n  18: optional list<string> buildIdSets // each entry is one compatible set of comma separated build IDs\n  20: optional i32 numWritePartitions\n  22: optional i32 numReadPartitions\n}\n\nstruct TransferTaskInfo {\n  10: optional binary domainID\n  12: optional string workflowID\n  14: optional binary runID\n  16: optional i16 taskType\n  18: optional binary targetDomainID\n  20: optional string targetWorkflowID\n  22: optional binary targetRunID\n  24: optional string taskList\n  26: optional bool targetChildWorkflowOnly\n  28: optional i64 (js.type = \"Long\") scheduleID\n  30: optional i64 (js.type = \"Long\") version\n  32: optional i64 (js.type = \"Long\") visibilityTimestampNanos\n}\n\nstruct TimerTaskInfo {\n  10: optional binary domainID\n  12: optional string workflowID\n  14: optional binary runID\n  16: optional i16 taskType\n  18: optional i16 timeoutType\n  20: optional i64 (js.type = \"Long\") version\n  22: optional i64 (js.type = \"Long\") scheduleAttempt\n  24: optional i64 (js.type = \"Long\") eventID\n}\n\nstruct ReplicationTaskInfo {\n  10: optional binary domainID\n  12: optional string workflowID\n  14: optional binary runID\n  16: optional i16 taskType\n  18: optional i64 (js.type = \"Long\") version\n  20: optional i64 (js.type = \"Long\") firstEventID\n  22: optional i64 (js.type = \"Long\") nextEventID\n  24: optional i64 (js.type = \"Long\") scheduledID\n  26: optional i32 eventStoreVersion\n  28: optional i32 newRunEventStoreVersion\n  30: optional binary branch_token\n  32: optional map<string, ReplicationInfo> lastReplicationInfo\n  34: optional binary newRunBranchToken\n  36: optional bool resetWorkflow\n}"
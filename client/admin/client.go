@@ -239,3 +239,255 @@ func (c *clientImpl) getRandomClient() (adminservice.AdminServiceClient, error)
 
 	return client.(adminservice.AdminServiceClient), nil
 }
+
+func (c *clientImpl) DescribeDynamicConfig(
+	ctx context.Context,
+	request *adminservice.DescribeDynamicConfigRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeDynamicConfigResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeDynamicConfig(ctx, request, opts...)
+}
+
+func (c *clientImpl) ListAuditRecords(
+	ctx context.Context,
+	request *adminservice.ListAuditRecordsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListAuditRecordsResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ListAuditRecords(ctx, request, opts...)
+}
+
+func (c *clientImpl) UpdateTaskListBuildIDs(
+	ctx context.Context,
+	request *adminservice.UpdateTaskListBuildIDsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateTaskListBuildIDsResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UpdateTaskListBuildIDs(ctx, request, opts...)
+}
+
+func (c *clientImpl) GetTaskListBuildIDs(
+	ctx context.Context,
+	request *adminservice.GetTaskListBuildIDsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetTaskListBuildIDsResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.GetTaskListBuildIDs(ctx, request, opts...)
+}
+
+func (c *clientImpl) ListShardDLQTasks(
+	ctx context.Context,
+	request *adminservice.ListShardDLQTasksRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListShardDLQTasksResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ListShardDLQTasks(ctx, request, opts...)
+}
+
+func (c *clientImpl) RetryShardDLQTasks(
+	ctx context.Context,
+	request *adminservice.RetryShardDLQTasksRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.RetryShardDLQTasksResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.RetryShardDLQTasks(ctx, request, opts...)
+}
+
+func (c *clientImpl) PurgeShardDLQTasks(
+	ctx context.Context,
+	request *adminservice.PurgeShardDLQTasksRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.PurgeShardDLQTasksResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.PurgeShardDLQTasks(ctx, request, opts...)
+}
+
+func (c *clientImpl) ListShardTasks(
+	ctx context.Context,
+	request *adminservice.ListShardTasksRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListShardTasksResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ListShardTasks(ctx, request, opts...)
+}
+
+func (c *clientImpl) DescribeTaskList(
+	ctx context.Context,
+	request *adminservice.DescribeTaskListRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeTaskListResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeTaskList(ctx, request, opts...)
+}
+
+func (c *clientImpl) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.DeleteWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DeleteWorkflowExecutionResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DeleteWorkflowExecution(ctx, request, opts...)
+}
+
+func (c *clientImpl) UpsertWorkflowSearchAttributes(
+	ctx context.Context,
+	request *adminservice.UpsertWorkflowSearchAttributesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpsertWorkflowSearchAttributesResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UpsertWorkflowSearchAttributes(ctx, request, opts...)
+}
+
+func (c *clientImpl) UnarchiveWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.UnarchiveWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UnarchiveWorkflowExecutionResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UnarchiveWorkflowExecution(ctx, request, opts...)
+}
+
+func (c *clientImpl) AddSearchAttributes(
+	ctx context.Context,
+	request *adminservice.AddSearchAttributesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.AddSearchAttributesResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.AddSearchAttributes(ctx, request, opts...)
+}
+
+func (c *clientImpl) RemoveSearchAttributes(
+	ctx context.Context,
+	request *adminservice.RemoveSearchAttributesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.RemoveSearchAttributesResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.RemoveSearchAttributes(ctx, request, opts...)
+}
+
+func (c *clientImpl) ListSearchAttributes(
+	ctx context.Context,
+	request *adminservice.ListSearchAttributesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListSearchAttributesResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ListSearchAttributes(ctx, request, opts...)
+}
+
+func (c *clientImpl) DeleteDomain(
+	ctx context.Context,
+	request *adminservice.DeleteDomainRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DeleteDomainResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DeleteDomain(ctx, request, opts...)
+}
+
+func (c *clientImpl) UpdateDomainWorkflowDefaults(
+	ctx context.Context,
+	request *adminservice.UpdateDomainWorkflowDefaultsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateDomainWorkflowDefaultsResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UpdateDomainWorkflowDefaults(ctx, request, opts...)
+}
+
+func (c *clientImpl) DescribeDomainWorkflowDefaults(
+	ctx context.Context,
+	request *adminservice.DescribeDomainWorkflowDefaultsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeDomainWorkflowDefaultsResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeDomainWorkflowDefaults(ctx, request, opts...)
+}
@@ -255,3 +255,327 @@ func (c *metricClient) ReapplyEvents(
 	}
 	return resp, err
 }
+
+func (c *metricClient) DescribeDynamicConfig(
+	ctx context.Context,
+	request *adminservice.DescribeDynamicConfigRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeDynamicConfigResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDescribeDynamicConfigScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDescribeDynamicConfigScope, metrics.CadenceClientLatency)
+	resp, err := c.client.DescribeDynamicConfig(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDescribeDynamicConfigScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) ListAuditRecords(
+	ctx context.Context,
+	request *adminservice.ListAuditRecordsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListAuditRecordsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientListAuditRecordsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientListAuditRecordsScope, metrics.CadenceClientLatency)
+	resp, err := c.client.ListAuditRecords(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientListAuditRecordsScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) UpdateTaskListBuildIDs(
+	ctx context.Context,
+	request *adminservice.UpdateTaskListBuildIDsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateTaskListBuildIDsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientUpdateTaskListBuildIDsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientUpdateTaskListBuildIDsScope, metrics.CadenceClientLatency)
+	resp, err := c.client.UpdateTaskListBuildIDs(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientUpdateTaskListBuildIDsScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) GetTaskListBuildIDs(
+	ctx context.Context,
+	request *adminservice.GetTaskListBuildIDsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetTaskListBuildIDsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientGetTaskListBuildIDsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientGetTaskListBuildIDsScope, metrics.CadenceClientLatency)
+	resp, err := c.client.GetTaskListBuildIDs(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientGetTaskListBuildIDsScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) ListShardDLQTasks(
+	ctx context.Context,
+	request *adminservice.ListShardDLQTasksRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListShardDLQTasksResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientListShardDLQTasksScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientListShardDLQTasksScope, metrics.CadenceClientLatency)
+	resp, err := c.client.ListShardDLQTasks(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientListShardDLQTasksScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) RetryShardDLQTasks(
+	ctx context.Context,
+	request *adminservice.RetryShardDLQTasksRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.RetryShardDLQTasksResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientRetryShardDLQTasksScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientRetryShardDLQTasksScope, metrics.CadenceClientLatency)
+	resp, err := c.client.RetryShardDLQTasks(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientRetryShardDLQTasksScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) PurgeShardDLQTasks(
+	ctx context.Context,
+	request *adminservice.PurgeShardDLQTasksRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.PurgeShardDLQTasksResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientPurgeShardDLQTasksScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientPurgeShardDLQTasksScope, metrics.CadenceClientLatency)
+	resp, err := c.client.PurgeShardDLQTasks(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientPurgeShardDLQTasksScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) ListShardTasks(
+	ctx context.Context,
+	request *adminservice.ListShardTasksRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListShardTasksResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientListShardTasksScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientListShardTasksScope, metrics.CadenceClientLatency)
+	resp, err := c.client.ListShardTasks(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientListShardTasksScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DescribeTaskList(
+	ctx context.Context,
+	request *adminservice.DescribeTaskListRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeTaskListResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDescribeTaskListScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDescribeTaskListScope, metrics.CadenceClientLatency)
+	resp, err := c.client.DescribeTaskList(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDescribeTaskListScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.DeleteWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DeleteWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDeleteWorkflowExecutionScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDeleteWorkflowExecutionScope, metrics.CadenceClientLatency)
+	resp, err := c.client.DeleteWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDeleteWorkflowExecutionScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) UpsertWorkflowSearchAttributes(
+	ctx context.Context,
+	request *adminservice.UpsertWorkflowSearchAttributesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpsertWorkflowSearchAttributesResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientUpsertWorkflowSearchAttributesScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientUpsertWorkflowSearchAttributesScope, metrics.CadenceClientLatency)
+	resp, err := c.client.UpsertWorkflowSearchAttributes(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientUpsertWorkflowSearchAttributesScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) UnarchiveWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.UnarchiveWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UnarchiveWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientUnarchiveWorkflowExecutionScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientUnarchiveWorkflowExecutionScope, metrics.CadenceClientLatency)
+	resp, err := c.client.UnarchiveWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientUnarchiveWorkflowExecutionScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) AddSearchAttributes(
+	ctx context.Context,
+	request *adminservice.AddSearchAttributesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.AddSearchAttributesResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientAddSearchAttributesScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientAddSearchAttributesScope, metrics.CadenceClientLatency)
+	resp, err := c.client.AddSearchAttributes(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientAddSearchAttributesScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) RemoveSearchAttributes(
+	ctx context.Context,
+	request *adminservice.RemoveSearchAttributesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.RemoveSearchAttributesResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientRemoveSearchAttributesScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientRemoveSearchAttributesScope, metrics.CadenceClientLatency)
+	resp, err := c.client.RemoveSearchAttributes(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientRemoveSearchAttributesScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) ListSearchAttributes(
+	ctx context.Context,
+	request *adminservice.ListSearchAttributesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListSearchAttributesResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientListSearchAttributesScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientListSearchAttributesScope, metrics.CadenceClientLatency)
+	resp, err := c.client.ListSearchAttributes(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientListSearchAttributesScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DeleteDomain(
+	ctx context.Context,
+	request *adminservice.DeleteDomainRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DeleteDomainResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDeleteDomainScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDeleteDomainScope, metrics.CadenceClientLatency)
+	resp, err := c.client.DeleteDomain(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDeleteDomainScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) UpdateDomainWorkflowDefaults(
+	ctx context.Context,
+	request *adminservice.UpdateDomainWorkflowDefaultsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateDomainWorkflowDefaultsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientUpdateDomainWorkflowDefaultsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientUpdateDomainWorkflowDefaultsScope, metrics.CadenceClientLatency)
+	resp, err := c.client.UpdateDomainWorkflowDefaults(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientUpdateDomainWorkflowDefaultsScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DescribeDomainWorkflowDefaults(
+	ctx context.Context,
+	request *adminservice.DescribeDomainWorkflowDefaultsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeDomainWorkflowDefaultsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDescribeDomainWorkflowDefaultsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDescribeDomainWorkflowDefaultsScope, metrics.CadenceClientLatency)
+	resp, err := c.client.DescribeDomainWorkflowDefaults(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDescribeDomainWorkflowDefaultsScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
@@ -233,3 +233,291 @@ func (c *retryableClient) ReapplyEvents(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) DescribeDynamicConfig(
+	ctx context.Context,
+	request *adminservice.DescribeDynamicConfigRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeDynamicConfigResponse, error) {
+
+	var resp *adminservice.DescribeDynamicConfigResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeDynamicConfig(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ListAuditRecords(
+	ctx context.Context,
+	request *adminservice.ListAuditRecordsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListAuditRecordsResponse, error) {
+
+	var resp *adminservice.ListAuditRecordsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ListAuditRecords(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UpdateTaskListBuildIDs(
+	ctx context.Context,
+	request *adminservice.UpdateTaskListBuildIDsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateTaskListBuildIDsResponse, error) {
+
+	var resp *adminservice.UpdateTaskListBuildIDsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpdateTaskListBuildIDs(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) GetTaskListBuildIDs(
+	ctx context.Context,
+	request *adminservice.GetTaskListBuildIDsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetTaskListBuildIDsResponse, error) {
+
+	var resp *adminservice.GetTaskListBuildIDsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetTaskListBuildIDs(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ListShardDLQTasks(
+	ctx context.Context,
+	request *adminservice.ListShardDLQTasksRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListShardDLQTasksResponse, error) {
+
+	var resp *adminservice.ListShardDLQTasksResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ListShardDLQTasks(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) RetryShardDLQTasks(
+	ctx context.Context,
+	request *adminservice.RetryShardDLQTasksRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.RetryShardDLQTasksResponse, error) {
+
+	var resp *adminservice.RetryShardDLQTasksResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.RetryShardDLQTasks(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) PurgeShardDLQTasks(
+	ctx context.Context,
+	request *adminservice.PurgeShardDLQTasksRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.PurgeShardDLQTasksResponse, error) {
+
+	var resp *adminservice.PurgeShardDLQTasksResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.PurgeShardDLQTasks(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ListShardTasks(
+	ctx context.Context,
+	request *adminservice.ListShardTasksRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListShardTasksResponse, error) {
+
+	var resp *adminservice.ListShardTasksResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ListShardTasks(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DescribeTaskList(
+	ctx context.Context,
+	request *adminservice.DescribeTaskListRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeTaskListResponse, error) {
+
+	var resp *adminservice.DescribeTaskListResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeTaskList(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.DeleteWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DeleteWorkflowExecutionResponse, error) {
+
+	var resp *adminservice.DeleteWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DeleteWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UpsertWorkflowSearchAttributes(
+	ctx context.Context,
+	request *adminservice.UpsertWorkflowSearchAttributesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpsertWorkflowSearchAttributesResponse, error) {
+
+	var resp *adminservice.UpsertWorkflowSearchAttributesResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpsertWorkflowSearchAttributes(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UnarchiveWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.UnarchiveWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UnarchiveWorkflowExecutionResponse, error) {
+
+	var resp *adminservice.UnarchiveWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UnarchiveWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) AddSearchAttributes(
+	ctx context.Context,
+	request *adminservice.AddSearchAttributesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.AddSearchAttributesResponse, error) {
+
+	var resp *adminservice.AddSearchAttributesResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.AddSearchAttributes(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) RemoveSearchAttributes(
+	ctx context.Context,
+	request *adminservice.RemoveSearchAttributesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.RemoveSearchAttributesResponse, error) {
+
+	var resp *adminservice.RemoveSearchAttributesResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.RemoveSearchAttributes(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ListSearchAttributes(
+	ctx context.Context,
+	request *adminservice.ListSearchAttributesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListSearchAttributesResponse, error) {
+
+	var resp *adminservice.ListSearchAttributesResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ListSearchAttributes(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DeleteDomain(
+	ctx context.Context,
+	request *adminservice.DeleteDomainRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DeleteDomainResponse, error) {
+
+	var resp *adminservice.DeleteDomainResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DeleteDomain(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UpdateDomainWorkflowDefaults(
+	ctx context.Context,
+	request *adminservice.UpdateDomainWorkflowDefaultsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateDomainWorkflowDefaultsResponse, error) {
+
+	var resp *adminservice.UpdateDomainWorkflowDefaultsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpdateDomainWorkflowDefaults(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DescribeDomainWorkflowDefaults(
+	ctx context.Context,
+	request *adminservice.DescribeDomainWorkflowDefaultsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeDomainWorkflowDefaultsResponse, error) {
+
+	var resp *adminservice.DescribeDomainWorkflowDefaultsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeDomainWorkflowDefaults(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
@@ -190,11 +190,17 @@ func (cf *rpcClientFactory) NewMatchingClientWithTimeout(
 		return matchingservice.NewMatchingServiceClient(connection), nil
 	}
 
+	clients := common.NewClientCache(keyResolver, clientProvider)
+	partitionCounts := matching.NewPartitionCountsProvider(
+		timeout,
+		clients,
+		cf.dynConfig.GetDurationProperty(dynamicconfig.MatchingPartitionCountsCacheTTL, 10*time.Second)(),
+	)
 	client := matching.NewClient(
 		timeout,
 		longPollTimeout,
-		common.NewClientCache(keyResolver, clientProvider),
-		matching.NewLoadBalancer(domainIDToName, cf.dynConfig),
+		clients,
+		matching.NewLoadBalancer(domainIDToName, cf.dynConfig, partitionCounts),
 	)
 
 	if cf.metricsClient != nil {
@@ -205,6 +205,102 @@ func (c *clientGRPCImpl) RemoveTask(
 	return response, nil
 }
 
+func (c *clientGRPCImpl) ListShardTasks(
+	ctx context.Context,
+	request *historyservice.ListShardTasksRequest,
+	opts ...grpc.CallOption) (*historyservice.ListShardTasksResponse, error) {
+	client, err := c.getClientForShardID(int(request.GetShardID()))
+	if err != nil {
+		return nil, err
+	}
+	var response *historyservice.ListShardTasksResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.ListShardTasks(ctx, request, opts...)
+		return err
+	}
+
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientGRPCImpl) ListShardDLQTasks(
+	ctx context.Context,
+	request *historyservice.ListShardDLQTasksRequest,
+	opts ...grpc.CallOption) (*historyservice.ListShardDLQTasksResponse, error) {
+	client, err := c.getClientForShardID(int(request.GetShardID()))
+	if err != nil {
+		return nil, err
+	}
+	var response *historyservice.ListShardDLQTasksResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.ListShardDLQTasks(ctx, request, opts...)
+		return err
+	}
+
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientGRPCImpl) RetryShardDLQTasks(
+	ctx context.Context,
+	request *historyservice.RetryShardDLQTasksRequest,
+	opts ...grpc.CallOption) (*historyservice.RetryShardDLQTasksResponse, error) {
+	client, err := c.getClientForShardID(int(request.GetShardID()))
+	if err != nil {
+		return nil, err
+	}
+	var response *historyservice.RetryShardDLQTasksResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.RetryShardDLQTasks(ctx, request, opts...)
+		return err
+	}
+
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientGRPCImpl) PurgeShardDLQTasks(
+	ctx context.Context,
+	request *historyservice.PurgeShardDLQTasksRequest,
+	opts ...grpc.CallOption) (*historyservice.PurgeShardDLQTasksResponse, error) {
+	client, err := c.getClientForShardID(int(request.GetShardID()))
+	if err != nil {
+		return nil, err
+	}
+	var response *historyservice.PurgeShardDLQTasksResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.PurgeShardDLQTasks(ctx, request, opts...)
+		return err
+	}
+
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 func (c *clientGRPCImpl) CloseShard(
 	ctx context.Context,
 	request *historyservice.CloseShardRequest,
@@ -618,6 +714,75 @@ func (c *clientGRPCImpl) RemoveSignalMutableState(
 	return response, err
 }
 
+func (c *clientGRPCImpl) UnarchiveWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.UnarchiveWorkflowExecutionRequest,
+	opts ...grpc.CallOption) (*historyservice.UnarchiveWorkflowExecutionResponse, error) {
+	client, err := c.getClientForWorkflowID(request.WorkflowExecution.GetWorkflowId())
+	if err != nil {
+		return nil, err
+	}
+	var response *historyservice.UnarchiveWorkflowExecutionResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.UnarchiveWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, err
+}
+
+func (c *clientGRPCImpl) UpsertWorkflowSearchAttributes(
+	ctx context.Context,
+	request *historyservice.UpsertWorkflowSearchAttributesRequest,
+	opts ...grpc.CallOption) (*historyservice.UpsertWorkflowSearchAttributesResponse, error) {
+	client, err := c.getClientForWorkflowID(request.WorkflowExecution.GetWorkflowId())
+	if err != nil {
+		return nil, err
+	}
+	var response *historyservice.UpsertWorkflowSearchAttributesResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.UpsertWorkflowSearchAttributes(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, err
+}
+
+func (c *clientGRPCImpl) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.DeleteWorkflowExecutionRequest,
+	opts ...grpc.CallOption) (*historyservice.DeleteWorkflowExecutionResponse, error) {
+	client, err := c.getClientForWorkflowID(request.WorkflowExecution.GetWorkflowId())
+	if err != nil {
+		return nil, err
+	}
+	var response *historyservice.DeleteWorkflowExecutionResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.DeleteWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, err
+}
+
 func (c *clientGRPCImpl) TerminateWorkflowExecution(
 	ctx context.Context,
 	request *historyservice.TerminateWorkflowExecutionRequest,
@@ -79,6 +79,42 @@ func (c *metricClientGRPC) RemoveTask(
 	return resp, err
 }
 
+func (c *metricClientGRPC) ListShardTasks(
+	context context.Context,
+	request *historyservice.ListShardTasksRequest,
+	opts ...grpc.CallOption) (*historyservice.ListShardTasksResponse, error) {
+	resp, err := c.client.ListShardTasks(context, request, opts...)
+
+	return resp, err
+}
+
+func (c *metricClientGRPC) ListShardDLQTasks(
+	context context.Context,
+	request *historyservice.ListShardDLQTasksRequest,
+	opts ...grpc.CallOption) (*historyservice.ListShardDLQTasksResponse, error) {
+	resp, err := c.client.ListShardDLQTasks(context, request, opts...)
+
+	return resp, err
+}
+
+func (c *metricClientGRPC) RetryShardDLQTasks(
+	context context.Context,
+	request *historyservice.RetryShardDLQTasksRequest,
+	opts ...grpc.CallOption) (*historyservice.RetryShardDLQTasksResponse, error) {
+	resp, err := c.client.RetryShardDLQTasks(context, request, opts...)
+
+	return resp, err
+}
+
+func (c *metricClientGRPC) PurgeShardDLQTasks(
+	context context.Context,
+	request *historyservice.PurgeShardDLQTasksRequest,
+	opts ...grpc.CallOption) (*historyservice.PurgeShardDLQTasksResponse, error) {
+	resp, err := c.client.PurgeShardDLQTasks(context, request, opts...)
+
+	return resp, err
+}
+
 func (c *metricClientGRPC) CloseShard(
 	context context.Context,
 	request *historyservice.CloseShardRequest,
@@ -369,6 +405,57 @@ func (c *metricClientGRPC) RemoveSignalMutableState(
 	return resp, err
 }
 
+func (c *metricClientGRPC) UnarchiveWorkflowExecution(
+	context context.Context,
+	request *historyservice.UnarchiveWorkflowExecutionRequest,
+	opts ...grpc.CallOption) (*historyservice.UnarchiveWorkflowExecutionResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientUnarchiveWorkflowExecutionScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientUnarchiveWorkflowExecutionScope, metrics.CadenceClientLatency)
+	resp, err := c.client.UnarchiveWorkflowExecution(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientUnarchiveWorkflowExecutionScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClientGRPC) UpsertWorkflowSearchAttributes(
+	context context.Context,
+	request *historyservice.UpsertWorkflowSearchAttributesRequest,
+	opts ...grpc.CallOption) (*historyservice.UpsertWorkflowSearchAttributesResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientUpsertWorkflowSearchAttributesScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientUpsertWorkflowSearchAttributesScope, metrics.CadenceClientLatency)
+	resp, err := c.client.UpsertWorkflowSearchAttributes(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientUpsertWorkflowSearchAttributesScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClientGRPC) DeleteWorkflowExecution(
+	context context.Context,
+	request *historyservice.DeleteWorkflowExecutionRequest,
+	opts ...grpc.CallOption) (*historyservice.DeleteWorkflowExecutionResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientDeleteWorkflowExecutionScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientDeleteWorkflowExecutionScope, metrics.CadenceClientLatency)
+	resp, err := c.client.DeleteWorkflowExecution(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientDeleteWorkflowExecutionScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
+
 func (c *metricClientGRPC) TerminateWorkflowExecution(
 	context context.Context,
 	request *historyservice.TerminateWorkflowExecutionRequest,
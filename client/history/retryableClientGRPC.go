@@ -110,6 +110,70 @@ func (c *retryableClientGRPC) RemoveTask(
 	return resp, err
 }
 
+func (c *retryableClientGRPC) ListShardTasks(
+	ctx context.Context,
+	request *historyservice.ListShardTasksRequest,
+	opts ...grpc.CallOption) (*historyservice.ListShardTasksResponse, error) {
+
+	var resp *historyservice.ListShardTasksResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ListShardTasks(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClientGRPC) ListShardDLQTasks(
+	ctx context.Context,
+	request *historyservice.ListShardDLQTasksRequest,
+	opts ...grpc.CallOption) (*historyservice.ListShardDLQTasksResponse, error) {
+
+	var resp *historyservice.ListShardDLQTasksResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ListShardDLQTasks(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClientGRPC) RetryShardDLQTasks(
+	ctx context.Context,
+	request *historyservice.RetryShardDLQTasksRequest,
+	opts ...grpc.CallOption) (*historyservice.RetryShardDLQTasksResponse, error) {
+
+	var resp *historyservice.RetryShardDLQTasksResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.RetryShardDLQTasks(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClientGRPC) PurgeShardDLQTasks(
+	ctx context.Context,
+	request *historyservice.PurgeShardDLQTasksRequest,
+	opts ...grpc.CallOption) (*historyservice.PurgeShardDLQTasksResponse, error) {
+
+	var resp *historyservice.PurgeShardDLQTasksResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.PurgeShardDLQTasks(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClientGRPC) DescribeMutableState(
 	ctx context.Context,
 	request *historyservice.DescribeMutableStateRequest,
@@ -382,6 +446,54 @@ func (c *retryableClientGRPC) RemoveSignalMutableState(
 	return resp, err
 }
 
+func (c *retryableClientGRPC) UnarchiveWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.UnarchiveWorkflowExecutionRequest,
+	opts ...grpc.CallOption) (*historyservice.UnarchiveWorkflowExecutionResponse, error) {
+
+	var resp *historyservice.UnarchiveWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UnarchiveWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClientGRPC) UpsertWorkflowSearchAttributes(
+	ctx context.Context,
+	request *historyservice.UpsertWorkflowSearchAttributesRequest,
+	opts ...grpc.CallOption) (*historyservice.UpsertWorkflowSearchAttributesResponse, error) {
+
+	var resp *historyservice.UpsertWorkflowSearchAttributesResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpsertWorkflowSearchAttributes(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClientGRPC) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.DeleteWorkflowExecutionRequest,
+	opts ...grpc.CallOption) (*historyservice.DeleteWorkflowExecutionResponse, error) {
+
+	var resp *historyservice.DeleteWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DeleteWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClientGRPC) TerminateWorkflowExecution(
 	ctx context.Context,
 	request *historyservice.TerminateWorkflowExecutionRequest,
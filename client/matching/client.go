@@ -199,6 +199,36 @@ func (c *clientImpl) ListTaskListPartitions(ctx context.Context, request *matchi
 	return client.ListTaskListPartitions(ctx, request, opts...)
 }
 
+func (c *clientImpl) UpdateTaskListBuildIDs(ctx context.Context, request *matchingservice.UpdateTaskListBuildIDsRequest, opts ...grpc.CallOption) (*matchingservice.UpdateTaskListBuildIDsResponse, error) {
+	client, err := c.getClientForTasklist(request.TaskList.GetName())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UpdateTaskListBuildIDs(ctx, request, opts...)
+}
+
+func (c *clientImpl) GetTaskListBuildIDs(ctx context.Context, request *matchingservice.GetTaskListBuildIDsRequest, opts ...grpc.CallOption) (*matchingservice.GetTaskListBuildIDsResponse, error) {
+	client, err := c.getClientForTasklist(request.TaskList.GetName())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.GetTaskListBuildIDs(ctx, request, opts...)
+}
+
+func (c *clientImpl) GetTaskListPartitionCounts(ctx context.Context, request *matchingservice.GetTaskListPartitionCountsRequest, opts ...grpc.CallOption) (*matchingservice.GetTaskListPartitionCountsResponse, error) {
+	client, err := c.getClientForTasklist(request.TaskList.GetName())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.GetTaskListPartitionCounts(ctx, request, opts...)
+}
+
 func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
 	if parent == nil {
 		return context.WithTimeout(context.Background(), c.timeout)
@@ -61,9 +61,11 @@ type (
 	}
 
 	defaultLoadBalancer struct {
-		nReadPartitions  dynamicconfig.IntPropertyFnWithTaskListInfoFilters
-		nWritePartitions dynamicconfig.IntPropertyFnWithTaskListInfoFilters
-		domainIDToName   func(string) (string, error)
+		nReadPartitions   dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+		nWritePartitions  dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+		enableAutoScaling dynamicconfig.BoolPropertyFnWithTaskListInfoFilters
+		partitionCounts   PartitionCountsProvider
+		domainIDToName    func(string) (string, error)
 	}
 )
 
@@ -72,15 +74,20 @@ const (
 )
 
 // NewLoadBalancer returns an instance of matching load balancer that
-// can help distribute api calls across task list partitions
+// can help distribute api calls across task list partitions. Task lists
+// with partition auto scaling enabled use the partition counts returned
+// by partitionCounts instead of the ones from dynamic config
 func NewLoadBalancer(
 	domainIDToName func(string) (string, error),
 	dc *dynamicconfig.Collection,
+	partitionCounts PartitionCountsProvider,
 ) LoadBalancer {
 	return &defaultLoadBalancer{
-		domainIDToName:   domainIDToName,
-		nReadPartitions:  dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingNumTasklistReadPartitions, 1),
-		nWritePartitions: dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingNumTasklistWritePartitions, 1),
+		domainIDToName:    domainIDToName,
+		nReadPartitions:   dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingNumTasklistReadPartitions, 1),
+		nWritePartitions:  dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingNumTasklistWritePartitions, 1),
+		enableAutoScaling: dc.GetBoolPropertyFilteredByTaskListInfo(dynamicconfig.MatchingEnablePartitionAutoScaling, false),
+		partitionCounts:   partitionCounts,
 	}
 }
 
@@ -90,7 +97,7 @@ func (lb *defaultLoadBalancer) PickWritePartition(
 	taskListType int,
 	forwardedFrom string,
 ) string {
-	return lb.pickPartition(domainID, taskList, taskListType, forwardedFrom, lb.nWritePartitions)
+	return lb.pickPartition(domainID, taskList, taskListType, forwardedFrom, false)
 }
 
 func (lb *defaultLoadBalancer) PickReadPartition(
@@ -99,7 +106,7 @@ func (lb *defaultLoadBalancer) PickReadPartition(
 	taskListType int,
 	forwardedFrom string,
 ) string {
-	return lb.pickPartition(domainID, taskList, taskListType, forwardedFrom, lb.nReadPartitions)
+	return lb.pickPartition(domainID, taskList, taskListType, forwardedFrom, true)
 }
 
 func (lb *defaultLoadBalancer) pickPartition(
@@ -107,7 +114,7 @@ func (lb *defaultLoadBalancer) pickPartition(
 	taskList commonproto.TaskList,
 	taskListType int,
 	forwardedFrom string,
-	nPartitions dynamicconfig.IntPropertyFnWithTaskListInfoFilters,
+	isRead bool,
 ) string {
 
 	if forwardedFrom != "" || taskList.GetKind() == enums.TaskListKindSticky {
@@ -124,7 +131,7 @@ func (lb *defaultLoadBalancer) pickPartition(
 		return taskList.GetName()
 	}
 
-	n := nPartitions(domainName, taskList.GetName(), taskListType)
+	n := lb.numPartitions(domainID, domainName, taskList.GetName(), taskListType, isRead)
 	if n <= 0 {
 		return taskList.GetName()
 	}
@@ -136,3 +143,25 @@ func (lb *defaultLoadBalancer) pickPartition(
 
 	return fmt.Sprintf("%v%v/%v", taskListPartitionPrefix, taskList.GetName(), p)
 }
+
+func (lb *defaultLoadBalancer) numPartitions(
+	domainID string,
+	domainName string,
+	taskList string,
+	taskListType int,
+	isRead bool,
+) int {
+
+	if lb.enableAutoScaling(domainName, taskList, taskListType) {
+		if nRead, nWrite, ok := lb.partitionCounts.GetPartitionCounts(domainID, taskList, taskListType); ok {
+			if isRead {
+				return nRead
+			}
+			return nWrite
+		}
+	}
+	if isRead {
+		return lb.nReadPartitions(domainName, taskList, taskListType)
+	}
+	return lb.nWritePartitions(domainName, taskList, taskListType)
+}
@@ -242,6 +242,60 @@ func (c *metricClient) ListTaskListPartitions(
 	return resp, err
 }
 
+func (c *metricClient) UpdateTaskListBuildIDs(
+	ctx context.Context,
+	request *matchingservice.UpdateTaskListBuildIDsRequest,
+	opts ...grpc.CallOption) (*matchingservice.UpdateTaskListBuildIDsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.MatchingClientUpdateTaskListBuildIDsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientUpdateTaskListBuildIDsScope, metrics.CadenceClientLatency)
+	resp, err := c.client.UpdateTaskListBuildIDs(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientUpdateTaskListBuildIDsScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) GetTaskListBuildIDs(
+	ctx context.Context,
+	request *matchingservice.GetTaskListBuildIDsRequest,
+	opts ...grpc.CallOption) (*matchingservice.GetTaskListBuildIDsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.MatchingClientGetTaskListBuildIDsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientGetTaskListBuildIDsScope, metrics.CadenceClientLatency)
+	resp, err := c.client.GetTaskListBuildIDs(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientGetTaskListBuildIDsScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) GetTaskListPartitionCounts(
+	ctx context.Context,
+	request *matchingservice.GetTaskListPartitionCountsRequest,
+	opts ...grpc.CallOption) (*matchingservice.GetTaskListPartitionCountsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.MatchingClientGetTaskListPartitionCountsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientGetTaskListPartitionCountsScope, metrics.CadenceClientLatency)
+	resp, err := c.client.GetTaskListPartitionCounts(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientGetTaskListPartitionCountsScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
+
 func (c *metricClient) emitForwardedFromStats(scope int, forwardedFrom string, taskList *commonproto.TaskList) {
 	if taskList == nil {
 		return
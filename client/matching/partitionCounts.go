@@ -0,0 +1,129 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"context"
+	"time"
+
+	commonproto "go.temporal.io/temporal-proto/common"
+	"go.temporal.io/temporal-proto/enums"
+
+	"github.com/temporalio/temporal/.gen/proto/matchingservice"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/cache"
+)
+
+type (
+	// PartitionCountsProvider returns the number of task list partitions chosen
+	// by partition auto scaling, which are stored with the root partition
+	PartitionCountsProvider interface {
+		// GetPartitionCounts returns the number of read and write partitions of
+		// the task list. ok is false when the counts could not be looked up, in
+		// which case the counts from dynamic config should be used
+		GetPartitionCounts(
+			domainID string,
+			taskList string,
+			taskListType int,
+		) (numRead int, numWrite int, ok bool)
+	}
+
+	partitionCountsProviderImpl struct {
+		timeout time.Duration
+		clients common.ClientCache
+		cache   cache.Cache
+	}
+
+	partitionCountsKey struct {
+		domainID     string
+		taskList     string
+		taskListType int
+	}
+
+	partitionCounts struct {
+		numRead  int
+		numWrite int
+		ok       bool
+	}
+)
+
+const (
+	partitionCountsCacheMaxSize = 10000
+)
+
+// NewPartitionCountsProvider returns a PartitionCountsProvider that asks the matching
+// host owning the root partition of a task list and caches the result for the given ttl
+func NewPartitionCountsProvider(
+	timeout time.Duration,
+	clients common.ClientCache,
+	ttl time.Duration,
+) PartitionCountsProvider {
+	return &partitionCountsProviderImpl{
+		timeout: timeout,
+		clients: clients,
+		cache:   cache.New(partitionCountsCacheMaxSize, &cache.Options{TTL: ttl}),
+	}
+}
+
+func (p *partitionCountsProviderImpl) GetPartitionCounts(
+	domainID string,
+	taskList string,
+	taskListType int,
+) (int, int, bool) {
+
+	key := partitionCountsKey{domainID: domainID, taskList: taskList, taskListType: taskListType}
+	if counts, ok := p.cache.Get(key).(partitionCounts); ok {
+		return counts.numRead, counts.numWrite, counts.ok
+	}
+
+	// failed lookups are cached as well, so that callers fall back to
+	// dynamic config without calling the owner of the root partition every time
+	counts := partitionCounts{}
+	if resp, err := p.getPartitionCounts(key); err == nil && resp.GetNumWritePartitions() > 0 {
+		counts = partitionCounts{
+			numRead:  int(resp.GetNumReadPartitions()),
+			numWrite: int(resp.GetNumWritePartitions()),
+			ok:       true,
+		}
+	}
+	p.cache.Put(key, counts)
+	return counts.numRead, counts.numWrite, counts.ok
+}
+
+func (p *partitionCountsProviderImpl) getPartitionCounts(
+	key partitionCountsKey,
+) (*matchingservice.GetTaskListPartitionCountsResponse, error) {
+
+	client, err := p.clients.GetClientForKey(key.taskList)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+	return client.(matchingservice.MatchingServiceClient).GetTaskListPartitionCounts(ctx, &matchingservice.GetTaskListPartitionCountsRequest{
+		DomainUUID: key.domainID,
+		TaskList: &commonproto.TaskList{
+			Name: key.taskList,
+			Kind: enums.TaskListKindNormal,
+		},
+		TaskListType: int32(key.taskListType),
+	})
+}
@@ -189,3 +189,51 @@ func (c *retryableClient) ListTaskListPartitions(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) UpdateTaskListBuildIDs(
+	ctx context.Context,
+	request *matchingservice.UpdateTaskListBuildIDsRequest,
+	opts ...grpc.CallOption) (*matchingservice.UpdateTaskListBuildIDsResponse, error) {
+
+	var resp *matchingservice.UpdateTaskListBuildIDsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpdateTaskListBuildIDs(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) GetTaskListBuildIDs(
+	ctx context.Context,
+	request *matchingservice.GetTaskListBuildIDsRequest,
+	opts ...grpc.CallOption) (*matchingservice.GetTaskListBuildIDsResponse, error) {
+
+	var resp *matchingservice.GetTaskListBuildIDsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetTaskListBuildIDs(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) GetTaskListPartitionCounts(
+	ctx context.Context,
+	request *matchingservice.GetTaskListPartitionCountsRequest,
+	opts ...grpc.CallOption) (*matchingservice.GetTaskListPartitionCountsResponse, error) {
+
+	var resp *matchingservice.GetTaskListPartitionCountsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetTaskListPartitionCounts(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
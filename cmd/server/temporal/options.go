@@ -0,0 +1,128 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"github.com/uber-go/tally"
+	"google.golang.org/grpc"
+
+	"github.com/temporalio/temporal/common/archiver/provider"
+	"github.com/temporalio/temporal/common/authorization"
+	"github.com/temporalio/temporal/common/log"
+	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
+	"github.com/temporalio/temporal/common/service/config"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	// ServerOption configures the server built by NewServer
+	ServerOption func(so *serverOptions)
+
+	serverOptions struct {
+		services []string
+
+		config    *config.Config
+		configDir string
+		env       string
+		zone      string
+
+		logger                     log.Logger
+		metricsScope               tally.Scope
+		persistenceFactoryProvider persistenceClient.FactoryProvider
+		authorizer                 authorization.Authorizer
+		dynamicConfigClient        dynamicconfig.Client
+		archiverProvider           provider.ArchiverProvider
+		interceptors               []grpc.UnaryServerInterceptor
+	}
+)
+
+// ForServices sets the services to run in the server, all services are run by default
+func ForServices(names []string) ServerOption {
+	return func(so *serverOptions) {
+		so.services = names
+	}
+}
+
+// WithConfig sets the server config
+func WithConfig(cfg *config.Config) ServerOption {
+	return func(so *serverOptions) {
+		so.config = cfg
+	}
+}
+
+// WithConfigLoader loads the server config from the given directory
+// for the environment and availability zone when the server is started
+func WithConfigLoader(configDir string, env string, zone string) ServerOption {
+	return func(so *serverOptions) {
+		so.configDir, so.env, so.zone = configDir, env, zone
+	}
+}
+
+// WithLogger sets the logger used by the services instead of the one built from the log config
+func WithLogger(logger log.Logger) ServerOption {
+	return func(so *serverOptions) {
+		so.logger = logger
+	}
+}
+
+// WithMetricsScope sets the metrics scope used by the services instead of the one built from the metrics config
+func WithMetricsScope(scope tally.Scope) ServerOption {
+	return func(so *serverOptions) {
+		so.metricsScope = scope
+	}
+}
+
+// WithPersistenceFactoryProvider sets the provider of the persistence factory used by the services
+// instead of the factory backed by the datastores of the persistence config
+func WithPersistenceFactoryProvider(factoryProvider persistenceClient.FactoryProvider) ServerOption {
+	return func(so *serverOptions) {
+		so.persistenceFactoryProvider = factoryProvider
+	}
+}
+
+// WithAuthorizer sets the authorizer of the frontend API, all requests are allowed by default
+func WithAuthorizer(authorizer authorization.Authorizer) ServerOption {
+	return func(so *serverOptions) {
+		so.authorizer = authorizer
+	}
+}
+
+// WithDynamicConfigClient sets the dynamic config client used by the services instead of the file based one
+func WithDynamicConfigClient(client dynamicconfig.Client) ServerOption {
+	return func(so *serverOptions) {
+		so.dynamicConfigClient = client
+	}
+}
+
+// WithArchiverProvider sets the archiver provider used by the services instead of the one built from the archival config
+func WithArchiverProvider(archiverProvider provider.ArchiverProvider) ServerOption {
+	return func(so *serverOptions) {
+		so.archiverProvider = archiverProvider
+	}
+}
+
+// WithInterceptors adds unary interceptors to the gRPC servers of the services,
+// they run after the built-in interceptors in the given order
+func WithInterceptors(interceptors ...grpc.UnaryServerInterceptor) ServerOption {
+	return func(so *serverOptions) {
+		so.interceptors = append(so.interceptors, interceptors...)
+	}
+}
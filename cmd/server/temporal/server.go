@@ -1,4 +1,4 @@
-// Copyright (c) 2017 Uber Technologies, Inc.
+// Copyright (c) 2020 Temporal Technologies, Inc.
 //
 // Permission is hereby granted, free of charge, to any person obtaining a copy
 // of this software and associated documentation files (the "Software"), to deal
@@ -21,317 +21,136 @@
 package temporal
 
 import (
+	"errors"
+	"fmt"
 	"log"
-	"time"
+	"sync/atomic"
 
-	"google.golang.org/grpc"
-
-	persist "github.com/temporalio/temporal/.gen/go/persistenceblobs"
-	"github.com/temporalio/temporal/common/persistence"
-	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
-
-	"github.com/temporalio/temporal/common/authorization"
-
-	"go.uber.org/zap"
-
-	"go.temporal.io/temporal-proto/workflowservice"
-
-	"github.com/temporalio/temporal/client"
 	"github.com/temporalio/temporal/common"
-	"github.com/temporalio/temporal/common/archiver"
-	"github.com/temporalio/temporal/common/archiver/provider"
-	"github.com/temporalio/temporal/common/cluster"
-	"github.com/temporalio/temporal/common/elasticsearch"
-	l "github.com/temporalio/temporal/common/log"
-	"github.com/temporalio/temporal/common/log/loggerimpl"
-	"github.com/temporalio/temporal/common/log/tag"
-	"github.com/temporalio/temporal/common/messaging"
-	"github.com/temporalio/temporal/common/metrics"
-	"github.com/temporalio/temporal/common/service"
 	"github.com/temporalio/temporal/common/service/config"
-	"github.com/temporalio/temporal/common/service/dynamicconfig"
-	"github.com/temporalio/temporal/service/frontend"
-	"github.com/temporalio/temporal/service/history"
-	"github.com/temporalio/temporal/service/matching"
-	"github.com/temporalio/temporal/service/worker"
+	"github.com/temporalio/temporal/tools/cassandra"
+	"github.com/temporalio/temporal/tools/sql"
 )
 
 type (
-	server struct {
-		name   string
-		cfg    *config.Config
-		doneC  chan struct{}
-		daemon common.Daemon
+	// Server runs a set of temporal services in the current process
+	Server struct {
+		status   int32
+		so       *serverOptions
+		services []*serviceServer
 	}
 )
 
-const (
-	frontendService = "frontend"
-	historyService  = "history"
-	matchingService = "matching"
-	workerService   = "worker"
+var (
+	errNoConfig       = errors.New("server config is not set, use WithConfig or WithConfigLoader")
+	errServerStarted  = errors.New("server is already started")
+	errNoServices     = errors.New("list of services is empty")
+	errNoPublicClient = errors.New("need to provide an endpoint config for PublicClient")
 )
 
-// newServer returns a new instance of a daemon
-// that represents a cadence service
-func newServer(service string, cfg *config.Config) common.Daemon {
-	return &server{
-		cfg:   cfg,
-		name:  service,
-		doneC: make(chan struct{}),
+// NewServer returns a new server which runs the services configured by the given options
+func NewServer(opts ...ServerOption) *Server {
+	so := &serverOptions{
+		services: validServices,
 	}
-}
-
-// Start starts the server
-func (s *server) Start() {
-	if _, ok := s.cfg.Services[s.name]; !ok {
-		log.Fatalf("`%v` service missing config", s)
+	for _, opt := range opts {
+		opt(so)
+	}
+	return &Server{
+		status: common.DaemonStatusInitialized,
+		so:     so,
 	}
-	s.daemon = s.startService()
 }
 
-// Stop stops the server
-func (s *server) Stop() {
+// Start loads and validates the config and starts the services, the services
+// already started are stopped when one of them fails to start
+func (s *Server) Start() error {
+	if !atomic.CompareAndSwapInt32(&s.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return errServerStarted
+	}
 
-	if s.daemon == nil {
-		return
+	cfg, err := s.loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := s.validate(cfg); err != nil {
+		return err
 	}
 
-	select {
-	case <-s.doneC:
-	default:
-		s.daemon.Stop()
-		select {
-		case <-s.doneC:
-		case <-time.After(time.Minute):
-			log.Printf("timed out waiting for server %v to exit\n", s.name)
+	for _, name := range s.so.services {
+		svc := newServiceServer(name, cfg, s.so)
+		if err := svc.Start(); err != nil {
+			s.stopServices()
+			return err
 		}
+		s.services = append(s.services, svc)
 	}
+	return nil
 }
 
-// startService starts a service with the given name and config
-func (s *server) startService() common.Daemon {
-
-	var err error
-
-	params := service.BootstrapParams{}
-	params.Name = getServiceName(s.name)
-	params.Logger = loggerimpl.NewLogger(s.cfg.Log.NewZapLogger())
-	params.PersistenceConfig = s.cfg.Persistence
-
-	params.DynamicConfig, err = dynamicconfig.NewFileBasedClient(&s.cfg.DynamicConfigClient, params.Logger.WithTags(tag.Service(params.Name)), s.doneC)
-	if err != nil {
-		log.Printf("error creating file based dynamic config client, use no-op config client instead. error: %v", err)
-		params.DynamicConfig = dynamicconfig.NewNopClient()
+// Stop stops the services of the server
+func (s *Server) Stop() {
+	if !atomic.CompareAndSwapInt32(&s.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
 	}
-	dc := dynamicconfig.NewCollection(params.DynamicConfig, params.Logger)
-
-	svcCfg := s.cfg.Services[s.name]
-	params.MetricScope = svcCfg.Metrics.NewScope(params.Logger)
-	params.RPCFactory = svcCfg.RPC.NewFactory(params.Name, params.Logger)
+	s.stopServices()
+}
 
-	// Ringpop uses a different port to register handlers, this map is needed to resolve
-	// services to correct addresses used by clients through ServiceResolver lookup API
-	servicePortMap := make(map[string]int)
-	for roleName, svcCfg := range s.cfg.Services {
-		serviceName := getServiceName(roleName)
-		if serviceName == common.FrontendServiceName || serviceName == common.MatchingServiceName {
-			servicePortMap[serviceName] = svcCfg.RPC.GRPCPort
-		} else {
-			servicePortMap[serviceName] = svcCfg.RPC.Port
-		}
+func (s *Server) stopServices() {
+	for _, svc := range s.services {
+		svc.Stop()
 	}
+	s.services = nil
+}
 
-	params.MembershipFactory, err = s.cfg.Ringpop.NewFactory(
-		params.RPCFactory.GetRingpopDispatcher(),
-		params.Name,
-		servicePortMap,
-		params.Logger,
-	)
-
-	if err != nil {
-		log.Fatalf("error creating ringpop factory: %v", err)
+func (s *Server) loadConfig() (*config.Config, error) {
+	if s.so.config != nil {
+		return s.so.config, nil
 	}
-
-	params.PProfInitializer = svcCfg.PProf.NewInitializer(params.Logger)
-
-	params.DCRedirectionPolicy = s.cfg.DCRedirectionPolicy
-
-	params.MetricsClient = metrics.NewClient(params.MetricScope, service.GetMetricsServiceIdx(params.Name, params.Logger))
-
-	clusterMetadata := s.cfg.ClusterMetadata
-
-	// This call performs a config check against the configured persistence store for immutable cluster metadata.
-	// If there is a mismatch, the persisted values take precedence and will be written over in the config objects.
-	// This is to keep this check hidden from independent downstream daemons and keep this in a single place.
-	immutableClusterMetadataInitialization(params.Logger, &params.PersistenceConfig, &params.MetricsClient, clusterMetadata)
-
-	params.ClusterMetadata = cluster.NewMetadata(
-		params.Logger,
-		dc.GetBoolProperty(dynamicconfig.EnableGlobalDomain, clusterMetadata.EnableGlobalDomain),
-		clusterMetadata.FailoverVersionIncrement,
-		clusterMetadata.MasterClusterName,
-		clusterMetadata.CurrentClusterName,
-		clusterMetadata.ClusterInformation,
-		clusterMetadata.ReplicationConsumer,
-	)
-
-	if s.cfg.PublicClient.HostPort != "" {
-		params.DispatcherProvider = client.NewDNSYarpcDispatcherProvider(params.Logger, s.cfg.PublicClient.RefreshInterval)
-	} else {
-		log.Fatalf("need to provide an endpoint config for PublicClient")
+	if s.so.configDir == "" {
+		return nil, errNoConfig
 	}
 
-	advancedVisMode := dc.GetStringProperty(
-		dynamicconfig.AdvancedVisibilityWritingMode,
-		common.GetDefaultAdvancedVisibilityWritingMode(params.PersistenceConfig.IsAdvancedVisibilityConfigExist()),
-	)()
-	isAdvancedVisEnabled := advancedVisMode != common.AdvancedVisibilityWritingModeOff
-	if params.ClusterMetadata.IsGlobalDomainEnabled() {
-		params.MessagingClient = messaging.NewKafkaClient(&s.cfg.Kafka, params.MetricsClient, zap.NewNop(), params.Logger, params.MetricScope, true, isAdvancedVisEnabled)
-	} else if isAdvancedVisEnabled {
-		params.MessagingClient = messaging.NewKafkaClient(&s.cfg.Kafka, params.MetricsClient, zap.NewNop(), params.Logger, params.MetricScope, false, isAdvancedVisEnabled)
-	} else {
-		params.MessagingClient = nil
+	var cfg config.Config
+	if err := config.Load(s.so.env, s.so.configDir, s.so.zone, &cfg); err != nil {
+		return nil, fmt.Errorf("config file corrupted: %v", err)
+	}
+	if cfg.Log.Level == "debug" {
+		log.Printf("config=\n%v\n", cfg.String())
 	}
+	return &cfg, nil
+}
 
-	if isAdvancedVisEnabled {
-		// verify config of advanced visibility store
-		advancedVisStoreKey := s.cfg.Persistence.AdvancedVisibilityStore
-		advancedVisStore, ok := s.cfg.Persistence.DataStores[advancedVisStoreKey]
-		if !ok {
-			log.Fatalf("not able to find advanced visibility store in config: %v", advancedVisStoreKey)
+func (s *Server) validate(cfg *config.Config) error {
+	if len(s.so.services) == 0 {
+		return errNoServices
+	}
+	for _, name := range s.so.services {
+		if !isValidService(name) {
+			return fmt.Errorf("invalid service `%v` in service list %v", name, s.so.services)
 		}
-
-		params.ESConfig = advancedVisStore.ElasticSearch
-		esClient, err := elasticsearch.NewClient(params.ESConfig)
-		if err != nil {
-			log.Fatalf("error creating elastic search client: %v", err)
-		}
-		params.ESClient = esClient
-
-		// verify index name
-		indexName, ok := params.ESConfig.Indices[common.VisibilityAppName]
-		if !ok || len(indexName) == 0 {
-			log.Fatalf("elastic search config missing visibility index")
+		if _, ok := cfg.Services[name]; !ok {
+			return fmt.Errorf("`%v` service missing config", name)
 		}
 	}
-
-	connection, err := grpc.Dial(s.cfg.PublicClient.HostPort, grpc.WithInsecure())
-	if err != nil {
-		log.Fatalf("failed to construct connection: %v", err)
+	if cfg.PublicClient.HostPort == "" {
+		return errNoPublicClient
 	}
-	params.PublicClient = workflowservice.NewWorkflowServiceClient(connection)
-
-	params.ArchivalMetadata = archiver.NewArchivalMetadata(
-		dc,
-		s.cfg.Archival.History.Status,
-		s.cfg.Archival.History.EnableRead,
-		s.cfg.Archival.Visibility.Status,
-		s.cfg.Archival.Visibility.EnableRead,
-		&s.cfg.DomainDefaults.Archival,
-	)
-
-	params.ArchiverProvider = provider.NewArchiverProvider(s.cfg.Archival.History.Provider, s.cfg.Archival.Visibility.Provider)
-
-	params.PersistenceConfig.TransactionSizeLimit = dc.GetIntProperty(dynamicconfig.TransactionSizeLimit, common.DefaultTransactionSizeLimit)
-
-	params.Authorizer = authorization.NewNopAuthorizer()
-
-	params.Logger.Info("Starting service " + s.name)
-
-	var daemon common.Daemon
 
-	switch s.name {
-	case frontendService:
-		daemon, err = frontend.NewService(&params)
-	case historyService:
-		daemon, err = history.NewService(&params)
-	case matchingService:
-		daemon, err = matching.NewService(&params)
-	case workerService:
-		daemon, err = worker.NewService(&params)
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("config validation failed: %v", err)
 	}
-	if err != nil {
-		params.Logger.Fatal("Fail to start "+s.name+" service ", tag.Error(err))
-	}
-
-	go execute(daemon, s.doneC)
-
-	return daemon
-}
-
-func immutableClusterMetadataInitialization(
-	logger l.Logger,
-	persistenceConfig *config.Persistence,
-	metricsClient *metrics.Client,
-	clusterMetadata *config.ClusterMetadata) {
-
-	logger = logger.WithTags(tag.ComponentMetadataInitializer)
-	clusterMetadataManager, err := persistenceClient.NewFactory(
-		persistenceConfig,
-		clusterMetadata.CurrentClusterName,
-		*metricsClient,
-		logger,
-	).NewClusterMetadataManager()
-
-	if err != nil {
-		log.Fatalf("Error initializing cluster metadata manager: %v", err)
+	// the schema of the configured datastores is not used when the persistence factory is provided
+	if s.so.persistenceFactoryProvider != nil {
+		return nil
 	}
-
-	defer clusterMetadataManager.Close()
-
-	resp, err := clusterMetadataManager.InitializeImmutableClusterMetadata(
-		&persistence.InitializeImmutableClusterMetadataRequest{
-			ImmutableClusterMetadata: persist.ImmutableClusterMetadata{
-				HistoryShardCount: common.Int32Ptr(int32(persistenceConfig.NumHistoryShards)),
-				ClusterName:       &clusterMetadata.CurrentClusterName,
-			}})
-
-	if err != nil {
-		log.Fatalf("Error while fetching or persisting immutable cluster metadata: %v", err)
+	// cassandra schema version validation
+	if err := cassandra.VerifyCompatibleVersion(cfg.Persistence); err != nil {
+		return fmt.Errorf("incompatible cassandra versions: %v", err)
 	}
-
-	if resp.RequestApplied {
-		logger.Info("Successfully applied immutable cluster metadata.")
-	} else {
-		if clusterMetadata.CurrentClusterName != *resp.PersistedImmutableData.ClusterName {
-			logImmutableMismatch(logger,
-				"ClusterMetadata.CurrentClusterName",
-				clusterMetadata.CurrentClusterName,
-				*resp.PersistedImmutableData.ClusterName)
-
-			clusterMetadata.CurrentClusterName = *resp.PersistedImmutableData.ClusterName
-		}
-
-		var persistedShardCount = int(*resp.PersistedImmutableData.HistoryShardCount)
-		if persistenceConfig.NumHistoryShards != persistedShardCount {
-			logImmutableMismatch(logger,
-				"Persistence.NumHistoryShards",
-				persistenceConfig.NumHistoryShards,
-				persistedShardCount)
-
-			persistenceConfig.NumHistoryShards = persistedShardCount
-		}
+	// sql schema version validation
+	if err := sql.VerifyCompatibleVersion(cfg.Persistence); err != nil {
+		return fmt.Errorf("incompatible sql versions: %v", err)
 	}
-}
-
-func logImmutableMismatch(l l.Logger, key string, ignored interface{}, value interface{}) {
-	l.Error(
-		"Supplied configuration key/value mismatches persisted ImmutableClusterMetadata."+
-			"Continuing with the persisted value as this value cannot be changed once initialized.",
-		tag.Key(key),
-		tag.IgnoredValue(ignored),
-		tag.Value(value))
-}
-
-// execute runs the daemon in a separate go routine
-func execute(d common.Daemon, doneC chan struct{}) {
-	d.Start()
-	close(doneC)
-}
-
-// getServiceName converts the role name used in config to service name used by ringpop ring
-func getServiceName(role string) string {
-	return "cadence-" + role
+	return nil
 }
@@ -0,0 +1,432 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc"
+
+	persist "github.com/temporalio/temporal/.gen/go/persistenceblobs"
+	"github.com/temporalio/temporal/common/persistence"
+	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
+
+	"github.com/temporalio/temporal/common/authorization"
+
+	"go.uber.org/zap"
+
+	"go.temporal.io/temporal-proto/workflowservice"
+
+	"github.com/temporalio/temporal/client"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/archiver"
+	"github.com/temporalio/temporal/common/archiver/provider"
+	"github.com/temporalio/temporal/common/cluster"
+	"github.com/temporalio/temporal/common/elasticsearch"
+	l "github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/membership/dbmembership"
+	"github.com/temporalio/temporal/common/messaging"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/service"
+	"github.com/temporalio/temporal/common/service/config"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+	"github.com/temporalio/temporal/common/tracing"
+	"github.com/temporalio/temporal/service/frontend"
+	"github.com/temporalio/temporal/service/history"
+	"github.com/temporalio/temporal/service/matching"
+	"github.com/temporalio/temporal/service/worker"
+)
+
+type (
+	serviceServer struct {
+		name   string
+		cfg    *config.Config
+		so     *serverOptions
+		doneC  chan struct{}
+		daemon common.Daemon
+	}
+)
+
+const (
+	frontendService = "frontend"
+	historyService  = "history"
+	matchingService = "matching"
+	workerService   = "worker"
+)
+
+// newServiceServer returns a new instance of a daemon
+// that represents a cadence service
+func newServiceServer(service string, cfg *config.Config, so *serverOptions) *serviceServer {
+	return &serviceServer{
+		cfg:   cfg,
+		so:    so,
+		name:  service,
+		doneC: make(chan struct{}),
+	}
+}
+
+// Start starts the service
+func (s *serviceServer) Start() error {
+	if _, ok := s.cfg.Services[s.name]; !ok {
+		return fmt.Errorf("`%v` service missing config", s.name)
+	}
+	daemon, err := s.startService()
+	if err != nil {
+		return err
+	}
+	s.daemon = daemon
+	return nil
+}
+
+// Stop stops the service
+func (s *serviceServer) Stop() {
+
+	if s.daemon == nil {
+		return
+	}
+
+	select {
+	case <-s.doneC:
+	default:
+		s.daemon.Stop()
+		select {
+		case <-s.doneC:
+		case <-time.After(time.Minute):
+			log.Printf("timed out waiting for server %v to exit\n", s.name)
+		}
+	}
+}
+
+// startService starts a service with the given name and config
+func (s *serviceServer) startService() (common.Daemon, error) {
+
+	var err error
+
+	params := service.BootstrapParams{}
+	params.Name = getServiceName(s.name)
+	params.Logger = s.so.logger
+	if params.Logger == nil {
+		params.Logger = loggerimpl.NewLogger(s.cfg.Log.NewZapLogger())
+	}
+	params.PersistenceConfig = s.cfg.Persistence
+	params.PersistenceFactoryProvider = s.so.persistenceFactoryProvider
+	if params.PersistenceFactoryProvider == nil {
+		params.PersistenceFactoryProvider = persistenceClient.NewFactory
+	}
+
+	params.DynamicConfig = s.so.dynamicConfigClient
+	if params.DynamicConfig == nil {
+		params.DynamicConfig, err = dynamicconfig.NewFileBasedClient(&s.cfg.DynamicConfigClient, params.Logger.WithTags(tag.Service(params.Name)), s.doneC)
+		if err != nil {
+			log.Printf("error creating file based dynamic config client, use no-op config client instead. error: %v", err)
+			params.DynamicConfig = dynamicconfig.NewNopClient()
+		}
+	}
+	dc := dynamicconfig.NewCollection(params.DynamicConfig, params.Logger)
+
+	// tracer needs to be set before any transport is created, transports read the global tracer on creation
+	tracer, err := s.cfg.Tracing.NewTracer(params.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("error creating tracer: %v", err)
+	}
+	opentracing.SetGlobalTracer(tracer)
+
+	svcCfg := s.cfg.Services[s.name]
+	params.MetricScope = s.so.metricsScope
+	if params.MetricScope == nil {
+		params.MetricScope = svcCfg.Metrics.NewScope(params.Logger)
+	}
+	params.RPCFactory = svcCfg.RPC.NewFactory(params.Name, params.Logger)
+
+	// Ringpop uses a different port to register handlers, this map is needed to resolve
+	// services to correct addresses used by clients through ServiceResolver lookup API
+	servicePortMap := make(map[string]int)
+	for roleName, svcCfg := range s.cfg.Services {
+		serviceName := getServiceName(roleName)
+		if serviceName == common.FrontendServiceName || serviceName == common.MatchingServiceName {
+			servicePortMap[serviceName] = svcCfg.RPC.GRPCPort
+		} else {
+			servicePortMap[serviceName] = svcCfg.RPC.Port
+		}
+	}
+
+	params.PProfInitializer = svcCfg.PProf.NewInitializer(params.Logger)
+
+	params.DCRedirectionPolicy = s.cfg.DCRedirectionPolicy
+
+	params.MetricsClient = metrics.NewClient(params.MetricScope, service.GetMetricsServiceIdx(params.Name, params.Logger))
+
+	clusterMetadata := s.cfg.ClusterMetadata
+
+	// This call performs a config check against the configured persistence store for immutable cluster metadata.
+	// If there is a mismatch, the persisted values take precedence and will be written over in the config objects.
+	// This is to keep this check hidden from independent downstream daemons and keep this in a single place.
+	if err := immutableClusterMetadataInitialization(&params, clusterMetadata); err != nil {
+		return nil, err
+	}
+
+	params.MembershipFactory, err = s.newMembershipFactory(&params, servicePortMap)
+	if err != nil {
+		return nil, fmt.Errorf("error creating membership factory: %v", err)
+	}
+
+	params.ClusterMetadata = cluster.NewMetadata(
+		params.Logger,
+		dc.GetBoolProperty(dynamicconfig.EnableGlobalDomain, clusterMetadata.EnableGlobalDomain),
+		clusterMetadata.FailoverVersionIncrement,
+		clusterMetadata.MasterClusterName,
+		clusterMetadata.CurrentClusterName,
+		clusterMetadata.ClusterInformation,
+		clusterMetadata.ReplicationConsumer,
+	)
+
+	if s.cfg.PublicClient.HostPort != "" {
+		params.DispatcherProvider = client.NewDNSYarpcDispatcherProvider(params.Logger, s.cfg.PublicClient.RefreshInterval)
+	} else {
+		return nil, errNoPublicClient
+	}
+
+	advancedVisMode := dc.GetStringProperty(
+		dynamicconfig.AdvancedVisibilityWritingMode,
+		common.GetDefaultAdvancedVisibilityWritingMode(params.PersistenceConfig.IsAdvancedVisibilityConfigExist()),
+	)()
+	isAdvancedVisEnabled := advancedVisMode != common.AdvancedVisibilityWritingModeOff
+	if params.ClusterMetadata.IsGlobalDomainEnabled() {
+		params.MessagingClient = messaging.NewKafkaClient(&s.cfg.Kafka, params.MetricsClient, zap.NewNop(), params.Logger, params.MetricScope, true, isAdvancedVisEnabled)
+	} else if isAdvancedVisEnabled {
+		params.MessagingClient = messaging.NewKafkaClient(&s.cfg.Kafka, params.MetricsClient, zap.NewNop(), params.Logger, params.MetricScope, false, isAdvancedVisEnabled)
+	} else {
+		params.MessagingClient = nil
+	}
+
+	if isAdvancedVisEnabled {
+		// verify config of advanced visibility store
+		advancedVisStoreKey := s.cfg.Persistence.AdvancedVisibilityStore
+		advancedVisStore, ok := s.cfg.Persistence.DataStores[advancedVisStoreKey]
+		if !ok {
+			return nil, fmt.Errorf("not able to find advanced visibility store in config: %v", advancedVisStoreKey)
+		}
+
+		params.ESConfig = advancedVisStore.ElasticSearch
+		esClient, err := elasticsearch.NewClient(params.ESConfig)
+		if err != nil {
+			return nil, fmt.Errorf("error creating elastic search client: %v", err)
+		}
+		params.ESClient = esClient
+
+		// verify index name
+		indexName, ok := params.ESConfig.Indices[common.VisibilityAppName]
+		if !ok || len(indexName) == 0 {
+			return nil, errors.New("elastic search config missing visibility index")
+		}
+	}
+
+	connection, err := grpc.Dial(s.cfg.PublicClient.HostPort, grpc.WithInsecure(), grpc.WithUnaryInterceptor(tracing.NewClientInterceptor()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct connection: %v", err)
+	}
+	params.PublicClient = workflowservice.NewWorkflowServiceClient(connection)
+
+	params.ArchivalMetadata = archiver.NewArchivalMetadata(
+		dc,
+		s.cfg.Archival.History.Status,
+		s.cfg.Archival.History.EnableRead,
+		s.cfg.Archival.Visibility.Status,
+		s.cfg.Archival.Visibility.EnableRead,
+		&s.cfg.DomainDefaults.Archival,
+	)
+
+	params.ArchiverProvider = s.so.archiverProvider
+	if params.ArchiverProvider == nil {
+		params.ArchiverProvider = provider.NewArchiverProvider(s.cfg.Archival.History.Provider, s.cfg.Archival.Visibility.Provider)
+	}
+
+	params.PersistenceConfig.TransactionSizeLimit = dc.GetIntProperty(dynamicconfig.TransactionSizeLimit, common.DefaultTransactionSizeLimit)
+	params.PersistenceConfig.HistoryPayloadOffloadThreshold = dc.GetIntProperty(dynamicconfig.HistoryPayloadOffloadThreshold, common.DefaultHistoryPayloadOffloadThreshold)
+	if dc.GetBoolProperty(dynamicconfig.PersistenceFaultInjectionEnabled, false)() {
+		params.PersistenceConfig.FaultInjection = &config.FaultInjectionConfig{
+			Seed:        dc.GetIntProperty(dynamicconfig.PersistenceFaultInjectionSeed, 0),
+			ErrorRate:   dc.GetFloat64Property(dynamicconfig.PersistenceFaultInjectionErrorRate, 0),
+			ErrorType:   dc.GetStringProperty(dynamicconfig.PersistenceFaultInjectionErrorType, persistence.FaultInjectionErrorTimeout),
+			LatencyRate: dc.GetFloat64Property(dynamicconfig.PersistenceFaultInjectionLatencyRate, 0),
+			Latency:     dc.GetDurationProperty(dynamicconfig.PersistenceFaultInjectionLatency, 0),
+		}
+	}
+
+	params.Authorizer = s.so.authorizer
+	if params.Authorizer == nil {
+		params.Authorizer = authorization.NewNopAuthorizer()
+	}
+	params.AuditConfig = s.cfg.Audit
+	params.HealthConfig = svcCfg.Health
+	params.Interceptors = s.so.interceptors
+
+	params.Logger.Info("Starting service " + s.name)
+
+	var daemon common.Daemon
+
+	switch s.name {
+	case frontendService:
+		daemon, err = frontend.NewService(&params)
+	case historyService:
+		daemon, err = history.NewService(&params)
+	case matchingService:
+		daemon, err = matching.NewService(&params)
+	case workerService:
+		daemon, err = worker.NewService(&params)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fail to start %v service: %v", s.name, err)
+	}
+
+	go execute(daemon, s.doneC)
+
+	return daemon, nil
+}
+
+// newMembershipFactory returns the ringpop membership factory, or the database backed one which heartbeats
+// into the cluster membership table when the ringpop bootstrap mode is database
+func (s *serviceServer) newMembershipFactory(
+	params *service.BootstrapParams,
+	servicePortMap map[string]int,
+) (service.MembershipMonitorFactory, error) {
+
+	if s.cfg.Ringpop.BootstrapMode != config.BootstrapModeDatabase {
+		return s.cfg.Ringpop.NewFactory(
+			params.RPCFactory.GetRingpopDispatcher(),
+			params.Name,
+			servicePortMap,
+			params.Logger,
+		)
+	}
+
+	clusterMetadataManager, err := params.PersistenceFactoryProvider(
+		&params.PersistenceConfig,
+		s.cfg.ClusterMetadata.CurrentClusterName,
+		params.MetricsClient,
+		params.Logger,
+	).NewClusterMetadataManager()
+	if err != nil {
+		return nil, err
+	}
+
+	// hosts register the address their gRPC listener is bound to, or the
+	// address of their network interface when bound to all interfaces
+	hostIP := params.RPCFactory.GetGRPCListener().Addr().(*net.TCPAddr).IP
+	if hostIP.IsUnspecified() {
+		if hostIP, err = config.ListenIP(); err != nil {
+			return nil, err
+		}
+	}
+	return dbmembership.NewFactory(
+		params.Name,
+		servicePortMap,
+		hostIP,
+		&dbmembership.Config{
+			HeartbeatInterval: s.cfg.Ringpop.HeartbeatInterval,
+			HeartbeatTimeout:  s.cfg.Ringpop.HeartbeatTimeout,
+		},
+		clusterMetadataManager,
+		params.Logger,
+	)
+}
+
+func immutableClusterMetadataInitialization(
+	params *service.BootstrapParams,
+	clusterMetadata *config.ClusterMetadata) error {
+
+	logger := params.Logger.WithTags(tag.ComponentMetadataInitializer)
+	persistenceConfig := &params.PersistenceConfig
+	clusterMetadataManager, err := params.PersistenceFactoryProvider(
+		persistenceConfig,
+		clusterMetadata.CurrentClusterName,
+		params.MetricsClient,
+		logger,
+	).NewClusterMetadataManager()
+
+	if err != nil {
+		return fmt.Errorf("error initializing cluster metadata manager: %v", err)
+	}
+
+	defer clusterMetadataManager.Close()
+
+	resp, err := clusterMetadataManager.InitializeImmutableClusterMetadata(
+		&persistence.InitializeImmutableClusterMetadataRequest{
+			ImmutableClusterMetadata: persist.ImmutableClusterMetadata{
+				HistoryShardCount: common.Int32Ptr(int32(persistenceConfig.NumHistoryShards)),
+				ClusterName:       &clusterMetadata.CurrentClusterName,
+			}})
+
+	if err != nil {
+		return fmt.Errorf("error while fetching or persisting immutable cluster metadata: %v", err)
+	}
+
+	if resp.RequestApplied {
+		logger.Info("Successfully applied immutable cluster metadata.")
+	} else {
+		if clusterMetadata.CurrentClusterName != *resp.PersistedImmutableData.ClusterName {
+			logImmutableMismatch(logger,
+				"ClusterMetadata.CurrentClusterName",
+				clusterMetadata.CurrentClusterName,
+				*resp.PersistedImmutableData.ClusterName)
+
+			clusterMetadata.CurrentClusterName = *resp.PersistedImmutableData.ClusterName
+		}
+
+		var persistedShardCount = int(*resp.PersistedImmutableData.HistoryShardCount)
+		if persistenceConfig.NumHistoryShards != persistedShardCount {
+			logImmutableMismatch(logger,
+				"Persistence.NumHistoryShards",
+				persistenceConfig.NumHistoryShards,
+				persistedShardCount)
+
+			persistenceConfig.NumHistoryShards = persistedShardCount
+		}
+	}
+	return nil
+}
+
+func logImmutableMismatch(l l.Logger, key string, ignored interface{}, value interface{}) {
+	l.Error(
+		"Supplied configuration key/value mismatches persisted ImmutableClusterMetadata."+
+			"Continuing with the persisted value as this value cannot be changed once initialized.",
+		tag.Key(key),
+		tag.IgnoredValue(ignored),
+		tag.Value(value))
+}
+
+// execute runs the daemon in a separate go routine
+func execute(d common.Daemon, doneC chan struct{}) {
+	d.Start()
+	close(doneC)
+}
+
+// getServiceName converts the role name used in config to service name used by ringpop ring
+func getServiceName(role string) string {
+	return "cadence-" + role
+}
@@ -21,6 +21,7 @@
 package temporal
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -29,10 +30,7 @@ import (
 
 	"github.com/urfave/cli"
 
-	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/service/config"
-	"github.com/temporalio/temporal/tools/cassandra"
-	"github.com/temporalio/temporal/tools/sql"
 )
 
 // validServices is the list of all valid cadence services
@@ -46,52 +44,40 @@ func startHandler(c *cli.Context) {
 
 	log.Printf("Loading config; env=%v,zone=%v,configDir=%v\n", env, zone, configDir)
 
-	var cfg config.Config
-	err := config.Load(env, configDir, zone, &cfg)
-	if err != nil {
-		log.Fatal("Config file corrupted.", err)
-	}
-	if cfg.Log.Level == "debug" {
-		log.Printf("config=\n%v\n", cfg.String())
-	}
-
-	if err := cfg.Validate(); err != nil {
-		log.Fatalf("config validation failed: %v", err)
-	}
-	// cassandra schema version validation
-	if err := cassandra.VerifyCompatibleVersion(cfg.Persistence); err != nil {
-		log.Fatal("Incompatible cassandra versions: ", err)
-	}
-	// sql schema version validation
-	if err := sql.VerifyCompatibleVersion(cfg.Persistence); err != nil {
-		log.Fatal("Incompatible sql versions: ", err)
+	if c.Bool("dump-config") {
+		dumpConfig(env, configDir, zone)
+		return
 	}
 
-	var daemons []common.Daemon
-	services := getServices(c)
+	server := NewServer(
+		ForServices(getServices(c)),
+		WithConfigLoader(configDir, env, zone),
+	)
 	sigc := make(chan os.Signal, 1)
 	signal.Notify(sigc, syscall.SIGTERM)
-	for _, svc := range services {
-		if _, ok := cfg.Services[svc]; !ok {
-			log.Fatalf("`%v` service missing config", svc)
-		}
-		server := newServer(svc, &cfg)
-		daemons = append(daemons, server)
-		server.Start()
+	if err := server.Start(); err != nil {
+		log.Fatalf("Unable to start server: %v", err)
 	}
 
 	select {
 	case <-sigc:
 		{
 			log.Println("Received SIGTERM signal, initiating shutdown.")
-			for _, daemon := range daemons {
-				daemon.Stop()
-			}
+			server.Stop()
 			os.Exit(0)
 		}
 	}
 }
 
+// dumpConfig prints the effective config, with secrets redacted, after the substitution of environment variables
+func dumpConfig(env string, configDir string, zone string) {
+	var cfg config.Config
+	if err := config.Load(env, configDir, zone, &cfg); err != nil {
+		log.Fatal("Config file corrupted.", err)
+	}
+	fmt.Println(cfg.String())
+}
+
 func getEnvironment(c *cli.Context) string {
 	return strings.TrimSpace(c.GlobalString("env"))
 }
@@ -195,6 +181,10 @@ func BuildCLI() *cli.App {
 					Value: strings.Join(validServices, ","),
 					Usage: "list of services to start",
 				},
+				cli.BoolFlag{
+					Name:  "dump-config",
+					Usage: "print the effective config with secrets redacted and exit",
+				},
 			},
 			Action: func(c *cli.Context) {
 				startHandler(c)
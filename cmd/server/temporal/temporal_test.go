@@ -25,6 +25,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common/service/config"
 )
 
 type CadenceSuite struct {
@@ -53,3 +55,33 @@ func (s *CadenceSuite) TestIsValidService() {
 func (s *CadenceSuite) TestPath() {
 	s.Equal("foo/bar", constructPath("foo", "bar"))
 }
+
+func (s *CadenceSuite) TestServerOptions() {
+	server := NewServer(
+		ForServices([]string{"frontend"}),
+		WithConfigLoader("config", "development", "az1"),
+		WithInterceptors(nil, nil),
+	)
+	s.Equal([]string{"frontend"}, server.so.services)
+	s.Equal("config", server.so.configDir)
+	s.Equal("development", server.so.env)
+	s.Equal("az1", server.so.zone)
+	s.Len(server.so.interceptors, 2)
+
+	s.Equal(validServices, NewServer().so.services)
+}
+
+func (s *CadenceSuite) TestServerStartValidation() {
+	s.Equal(errNoConfig, NewServer().Start())
+
+	cfg := &config.Config{
+		Services: map[string]config.Service{"frontend": {}},
+	}
+	s.Error(NewServer(WithConfig(cfg), ForServices([]string{"foobar"})).Start())
+	s.Error(NewServer(WithConfig(cfg), ForServices([]string{"history"})).Start())
+	s.Equal(errNoPublicClient, NewServer(WithConfig(cfg), ForServices([]string{"frontend"})).Start())
+
+	server := NewServer(WithConfig(cfg), ForServices(nil))
+	s.Equal(errNoServices, server.Start())
+	s.Equal(errServerStarted, server.Start())
+}
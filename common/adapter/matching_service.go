@@ -148,6 +148,7 @@ func ToThriftAddDecisionTaskRequest(in *matchingservice.AddDecisionTaskRequest)
 		ScheduleId:                    &in.ScheduleId,
 		ScheduleToStartTimeoutSeconds: &in.ScheduleToStartTimeoutSeconds,
 		ForwardedFrom:                 &in.ForwardedFrom,
+		BuildId:                       &in.BuildId,
 	}
 }
 
@@ -177,6 +178,7 @@ func ToThriftMatchingQueryWorkflowRequest(in *matchingservice.QueryWorkflowReque
 		TaskList:      ToThriftTaskList(in.TaskList),
 		QueryRequest:  ToThriftQueryWorkflowRequest(in.QueryRequest),
 		ForwardedFrom: &in.ForwardedFrom,
+		BuildId:       &in.BuildId,
 	}
 }
 
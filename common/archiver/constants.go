@@ -59,4 +59,12 @@ var (
 	ErrNextPageTokenCorrupted = errors.New("next page token is corrupted")
 	// ErrHistoryNotExist is the error for non-exist history
 	ErrHistoryNotExist = errors.New("requested workflow history does not exist")
+	// ErrHistoryEventIDsNotContiguous is the error for archived history with missing or out of order events
+	ErrHistoryEventIDsNotContiguous = errors.New("archived history event IDs are not contiguous")
+	// ErrHistoryEventCountMismatch is the error for archived history whose event count does not match its integrity metadata
+	ErrHistoryEventCountMismatch = errors.New("archived history event count does not match")
+	// ErrHistoryEventIDRangeMismatch is the error for archived history whose event ID range does not match its integrity metadata
+	ErrHistoryEventIDRangeMismatch = errors.New("archived history event ID range does not match")
+	// ErrHistoryChecksumMismatch is the error for archived history whose checksum does not match its integrity metadata
+	ErrHistoryChecksumMismatch = errors.New("archived history checksum does not match")
 )
@@ -0,0 +1,329 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Columnar visibility compacts the visibility records archived for a domain into one Parquet file
+// per UTC day of the workflow close time, stored under <URI path>/columnar/<domainID>/<yyyy-mm-dd>.parquet.
+// The per record files are kept, as they are still used to serve Query().
+//
+// When enabled in the filestore archiver config, a day is compacted once a record which closed two
+// days later is archived for the domain, and compacted again if a record of that day is archived late.
+// ExportColumnarVisibility() converts the records of existing archives.
+//
+// Each row of a columnar file is a closed workflow execution with the following columns:
+//
+//   DomainID, DomainName, WorkflowID, RunID, WorkflowTypeName   required UTF8 strings
+//   StartTime, ExecutionTime, CloseTime                          required timestamps in microseconds
+//   CloseStatus                                                  required UTF8 string
+//   HistoryLength                                                required int64
+//   HistoryArchivalURI                                           optional UTF8 string
+//   Memo                                                         optional JSON object of memo fields
+//   SearchAttributes.<name>                                      optional JSON value of each search attribute
+//                                                                set by any execution of the day
+
+package filestore
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/pborman/uuid"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/archiver"
+)
+
+const (
+	columnarVisibilityDirName         = "columnar"
+	columnarVisibilityFileExtension   = ".parquet"
+	columnarVisibilityDateFormat      = "2006-01-02"
+	columnarVisibilitySchemaVersion   = "1"
+	columnarVisibilityCompactionDelay = 2 // in days
+
+	columnarVisibilitySchemaVersionKey = "temporal.visibility.schema.version"
+	searchAttributesColumnGroup        = "SearchAttributes"
+
+	columnarExportFileMode os.FileMode = 0644
+	columnarExportDirMode  os.FileMode = 0755
+)
+
+// ExportColumnarVisibility converts the visibility records archived for a domain by the filestore visibility
+// archiver into one columnar file per day in outputDirPath. Days which already have a columnar file are
+// skipped unless overwrite is set. It returns the names of the files written.
+func ExportColumnarVisibility(URI archiver.URI, domainID string, outputDirPath string, overwrite bool) ([]string, error) {
+	if URI.Scheme() != URIScheme {
+		return nil, archiver.ErrURISchemeMismatch
+	}
+
+	dirPath := path.Join(URI.Path(), domainID)
+	filenames, err := listFiles(dirPath)
+	if err != nil {
+		return nil, err
+	}
+	filenamesByDay, err := groupVisibilityFilenamesByDay(filenames)
+	if err != nil {
+		return nil, err
+	}
+
+	var days []int64
+	for day := range filenamesByDay {
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return days[i] < days[j]
+	})
+
+	var written []string
+	for _, day := range days {
+		filename := constructColumnarVisibilityFilename(day)
+		if !overwrite {
+			exists, err := fileExists(path.Join(outputDirPath, filename))
+			if err != nil {
+				return written, err
+			}
+			if exists {
+				continue
+			}
+		}
+		if err := compactVisibilityDay(dirPath, outputDirPath, filenamesByDay[day], day, columnarExportFileMode, columnarExportDirMode); err != nil {
+			return written, err
+		}
+		written = append(written, filename)
+	}
+	return written, nil
+}
+
+// compactVisibility compacts the days of the domain which are due after the record closed at
+// closeTimestamp was archived, the last day checked for each domain is kept so that the directory
+// is listed at most once per day and domain unless records arrive late
+func (v *visibilityArchiver) compactVisibility(URI archiver.URI, domainID string, closeTimestamp int64) error {
+	day := visibilityDay(closeTimestamp)
+	dirPath := path.Join(URI.Path(), domainID)
+	columnarDirPath := path.Join(URI.Path(), columnarVisibilityDirName, domainID)
+
+	lateRecord, err := fileExists(path.Join(columnarDirPath, constructColumnarVisibilityFilename(day)))
+	if err != nil {
+		return err
+	}
+	v.Lock()
+	lastCheckedDay, checked := v.compactionCheckedDays[domainID]
+	v.Unlock()
+	newDay := !checked || day > lastCheckedDay
+	if !lateRecord && !newDay {
+		return nil
+	}
+
+	filenames, err := listFiles(dirPath)
+	if err != nil {
+		return err
+	}
+	filenamesByDay, err := groupVisibilityFilenamesByDay(filenames)
+	if err != nil {
+		return err
+	}
+	for d, dayFilenames := range filenamesByDay {
+		compact := lateRecord && d == day
+		if newDay && d <= day-columnarVisibilityCompactionDelay {
+			exists, err := fileExists(path.Join(columnarDirPath, constructColumnarVisibilityFilename(d)))
+			if err != nil {
+				return err
+			}
+			compact = !exists
+		}
+		if compact {
+			if err := compactVisibilityDay(dirPath, columnarDirPath, dayFilenames, d, v.fileMode, v.dirMode); err != nil {
+				return err
+			}
+		}
+	}
+
+	if newDay {
+		v.Lock()
+		if day > v.compactionCheckedDays[domainID] {
+			v.compactionCheckedDays[domainID] = day
+		}
+		v.Unlock()
+	}
+	return nil
+}
+
+// compactVisibilityDay writes the columnar file of the given visibility record files, the file
+// is written under a temporary name first so that readers never observe a partial file
+func compactVisibilityDay(
+	dirPath string,
+	columnarDirPath string,
+	filenames []string,
+	day int64,
+	fileMode os.FileMode,
+	dirMode os.FileMode,
+) error {
+	records := make([]*visibilityRecord, 0, len(filenames))
+	for _, filename := range filenames {
+		encodedRecord, err := readFile(path.Join(dirPath, filename))
+		if err != nil {
+			return err
+		}
+		record, err := decodeVisibilityRecord(encodedRecord)
+		if err != nil {
+			return err
+		}
+		records = append(records, record)
+	}
+
+	encoded, err := encodeColumnarVisibility(records)
+	if err != nil {
+		return err
+	}
+	if err := mkdirAll(columnarDirPath, dirMode); err != nil {
+		return err
+	}
+	filepath := path.Join(columnarDirPath, constructColumnarVisibilityFilename(day))
+	tmpFilepath := filepath + "." + uuid.New() + ".tmp"
+	if err := writeFile(tmpFilepath, encoded, fileMode); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpFilepath, filepath); err != nil {
+		os.Remove(tmpFilepath) //nolint:errcheck
+		return err
+	}
+	return nil
+}
+
+// encodeColumnarVisibility encodes the visibility records, sorted by close time, into a Parquet file
+func encodeColumnarVisibility(records []*visibilityRecord) ([]byte, error) {
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].CloseTimestamp == records[j].CloseTimestamp {
+			return records[i].RunID < records[j].RunID
+		}
+		return records[i].CloseTimestamp < records[j].CloseTimestamp
+	})
+
+	utf8 := common.Int32Ptr(parquetConvertedTypeUTF8)
+	timestamp := common.Int32Ptr(parquetConvertedTypeTimestampMicros)
+	jsonType := common.Int32Ptr(parquetConvertedTypeJSON)
+	columns := []*parquetColumn{
+		{Path: []string{"DomainID"}, Type: parquetTypeByteArray, ConvertedType: utf8},
+		{Path: []string{"DomainName"}, Type: parquetTypeByteArray, ConvertedType: utf8},
+		{Path: []string{"WorkflowID"}, Type: parquetTypeByteArray, ConvertedType: utf8},
+		{Path: []string{"RunID"}, Type: parquetTypeByteArray, ConvertedType: utf8},
+		{Path: []string{"WorkflowTypeName"}, Type: parquetTypeByteArray, ConvertedType: utf8},
+		{Path: []string{"StartTime"}, Type: parquetTypeInt64, ConvertedType: timestamp},
+		{Path: []string{"ExecutionTime"}, Type: parquetTypeInt64, ConvertedType: timestamp},
+		{Path: []string{"CloseTime"}, Type: parquetTypeInt64, ConvertedType: timestamp},
+		{Path: []string{"CloseStatus"}, Type: parquetTypeByteArray, ConvertedType: utf8},
+		{Path: []string{"HistoryLength"}, Type: parquetTypeInt64},
+		{Path: []string{"HistoryArchivalURI"}, Type: parquetTypeByteArray, ConvertedType: utf8, Optional: true},
+		{Path: []string{"Memo"}, Type: parquetTypeByteArray, ConvertedType: jsonType, Optional: true},
+	}
+
+	searchAttributes := make(map[string]struct{})
+	for _, record := range records {
+		for key := range record.SearchAttributes {
+			searchAttributes[key] = struct{}{}
+		}
+	}
+	var searchAttributeKeys []string
+	for key := range searchAttributes {
+		searchAttributeKeys = append(searchAttributeKeys, key)
+	}
+	sort.Strings(searchAttributeKeys)
+	for _, key := range searchAttributeKeys {
+		columns = append(columns, &parquetColumn{
+			Path:          []string{searchAttributesColumnGroup, key},
+			Type:          parquetTypeByteArray,
+			ConvertedType: jsonType,
+			Optional:      true,
+		})
+	}
+
+	for _, record := range records {
+		memo, err := encodeColumnarMemo(record)
+		if err != nil {
+			return nil, err
+		}
+		var historyArchivalURI interface{}
+		if record.HistoryArchivalURI != "" {
+			historyArchivalURI = []byte(record.HistoryArchivalURI)
+		}
+		values := []interface{}{
+			[]byte(record.DomainID),
+			[]byte(record.DomainName),
+			[]byte(record.WorkflowID),
+			[]byte(record.RunID),
+			[]byte(record.WorkflowTypeName),
+			record.StartTimestamp / int64(time.Microsecond),
+			record.ExecutionTimestamp / int64(time.Microsecond),
+			record.CloseTimestamp / int64(time.Microsecond),
+			[]byte(record.CloseStatus.String()),
+			record.HistoryLength,
+			historyArchivalURI,
+			memo,
+		}
+		for _, key := range searchAttributeKeys {
+			if value, ok := record.SearchAttributes[key]; ok {
+				values = append(values, []byte(value))
+			} else {
+				values = append(values, nil)
+			}
+		}
+		for i, value := range values {
+			columns[i].Values = append(columns[i].Values, value)
+		}
+	}
+
+	return encodeParquet(columns, len(records), []parquetKeyValue{
+		{Key: columnarVisibilitySchemaVersionKey, Value: columnarVisibilitySchemaVersion},
+	})
+}
+
+func encodeColumnarMemo(record *visibilityRecord) (interface{}, error) {
+	if record.Memo == nil || len(record.Memo.Fields) == 0 {
+		return nil, nil
+	}
+	fields := make(map[string]string, len(record.Memo.Fields))
+	for key, value := range record.Memo.Fields {
+		fields[key] = string(value)
+	}
+	return json.Marshal(fields)
+}
+
+func groupVisibilityFilenamesByDay(filenames []string) (map[int64][]string, error) {
+	filenamesByDay := make(map[int64][]string)
+	for _, filename := range filenames {
+		parsedFilename, err := parseVisibilityFilename(filename)
+		if err != nil {
+			return nil, err
+		}
+		day := visibilityDay(parsedFilename.closeTime)
+		filenamesByDay[day] = append(filenamesByDay[day], filename)
+	}
+	return filenamesByDay, nil
+}
+
+// visibilityDay returns the number of UTC days between the unix epoch and the close timestamp in nanoseconds
+func visibilityDay(closeTimestamp int64) int64 {
+	return closeTimestamp / int64(24*time.Hour)
+}
+
+func constructColumnarVisibilityFilename(day int64) string {
+	return time.Unix(0, day*int64(24*time.Hour)).UTC().Format(columnarVisibilityDateFormat) + columnarVisibilityFileExtension
+}
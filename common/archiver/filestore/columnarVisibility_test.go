@@ -0,0 +1,233 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package filestore
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/common/archiver"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+type columnarVisibilitySuite struct {
+	*require.Assertions
+	suite.Suite
+
+	dir string
+	URI archiver.URI
+}
+
+func TestColumnarVisibilitySuite(t *testing.T) {
+	suite.Run(t, new(columnarVisibilitySuite))
+}
+
+func (s *columnarVisibilitySuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	var err error
+	s.dir, err = ioutil.TempDir("", "TestColumnarVisibility")
+	s.NoError(err)
+	s.URI, err = archiver.NewURI("file://" + s.dir)
+	s.NoError(err)
+}
+
+func (s *columnarVisibilitySuite) TearDownTest() {
+	os.RemoveAll(s.dir)
+}
+
+func (s *columnarVisibilitySuite) TestArchive_CompactsDays() {
+	visibilityArchiver := s.newTestVisibilityArchiver(true)
+	columnarDirPath := path.Join(s.dir, columnarVisibilityDirName, testDomainID)
+
+	s.NoError(visibilityArchiver.Archive(context.Background(), s.URI, s.newArchiveRequest("run-1", 0, time.Hour)))
+	s.NoError(visibilityArchiver.Archive(context.Background(), s.URI, s.newArchiveRequest("run-2", 0, 2*time.Hour)))
+	s.NoError(visibilityArchiver.Archive(context.Background(), s.URI, s.newArchiveRequest("run-3", 1, time.Hour)))
+	s.assertColumnarFileExists(columnarDirPath, "1970-01-01.parquet", false)
+
+	s.NoError(visibilityArchiver.Archive(context.Background(), s.URI, s.newArchiveRequest("run-4", 2, time.Hour)))
+	s.Equal([]string{"run-1", "run-2"}, s.readRunIDs(path.Join(columnarDirPath, "1970-01-01.parquet")))
+	s.assertColumnarFileExists(columnarDirPath, "1970-01-02.parquet", false)
+	s.assertColumnarFileExists(columnarDirPath, "1970-01-03.parquet", false)
+
+	// a record archived late recompacts its day
+	s.NoError(visibilityArchiver.Archive(context.Background(), s.URI, s.newArchiveRequest("run-5", 0, 30*time.Minute)))
+	s.Equal([]string{"run-5", "run-1", "run-2"}, s.readRunIDs(path.Join(columnarDirPath, "1970-01-01.parquet")))
+
+	s.NoError(visibilityArchiver.Archive(context.Background(), s.URI, s.newArchiveRequest("run-6", 3, time.Hour)))
+	s.Equal([]string{"run-3"}, s.readRunIDs(path.Join(columnarDirPath, "1970-01-02.parquet")))
+	s.assertColumnarFileExists(columnarDirPath, "1970-01-03.parquet", false)
+
+	filenames, err := listFiles(columnarDirPath)
+	s.NoError(err)
+	s.Len(filenames, 2)
+}
+
+func (s *columnarVisibilitySuite) TestArchive_Disabled() {
+	visibilityArchiver := s.newTestVisibilityArchiver(false)
+	s.NoError(visibilityArchiver.Archive(context.Background(), s.URI, s.newArchiveRequest("run-1", 0, time.Hour)))
+	s.NoError(visibilityArchiver.Archive(context.Background(), s.URI, s.newArchiveRequest("run-2", 3, time.Hour)))
+
+	exists, err := directoryExists(path.Join(s.dir, columnarVisibilityDirName))
+	s.NoError(err)
+	s.False(exists)
+}
+
+func (s *columnarVisibilitySuite) TestExportColumnarVisibility() {
+	visibilityArchiver := s.newTestVisibilityArchiver(false)
+	s.NoError(visibilityArchiver.Archive(context.Background(), s.URI, s.newArchiveRequest("run-1", 0, time.Hour)))
+	s.NoError(visibilityArchiver.Archive(context.Background(), s.URI, s.newArchiveRequest("run-2", 1, time.Hour)))
+	outputDirPath := path.Join(s.dir, "export")
+
+	written, err := ExportColumnarVisibility(s.URI, testDomainID, outputDirPath, false)
+	s.NoError(err)
+	s.Equal([]string{"1970-01-01.parquet", "1970-01-02.parquet"}, written)
+	s.Equal([]string{"run-1"}, s.readRunIDs(path.Join(outputDirPath, "1970-01-01.parquet")))
+
+	s.NoError(visibilityArchiver.Archive(context.Background(), s.URI, s.newArchiveRequest("run-3", 0, 2*time.Hour)))
+	written, err = ExportColumnarVisibility(s.URI, testDomainID, outputDirPath, false)
+	s.NoError(err)
+	s.Empty(written)
+	s.Equal([]string{"run-1"}, s.readRunIDs(path.Join(outputDirPath, "1970-01-01.parquet")))
+
+	written, err = ExportColumnarVisibility(s.URI, testDomainID, outputDirPath, true)
+	s.NoError(err)
+	s.Len(written, 2)
+	s.Equal([]string{"run-1", "run-3"}, s.readRunIDs(path.Join(outputDirPath, "1970-01-01.parquet")))
+}
+
+func (s *columnarVisibilitySuite) TestExportColumnarVisibility_Fail_InvalidURI() {
+	URI, err := archiver.NewURI("wrongscheme:///a/b/c")
+	s.NoError(err)
+	_, err = ExportColumnarVisibility(URI, testDomainID, s.dir, false)
+	s.Equal(archiver.ErrURISchemeMismatch, err)
+}
+
+func (s *columnarVisibilitySuite) TestEncodeColumnarVisibility() {
+	records := []*visibilityRecord{
+		{
+			DomainID:           testDomainID,
+			DomainName:         testDomainName,
+			WorkflowID:         testWorkflowID,
+			RunID:              "run-2",
+			WorkflowTypeName:   testWorkflowTypeName,
+			StartTimestamp:     int64(time.Second),
+			ExecutionTimestamp: int64(2 * time.Second),
+			CloseTimestamp:     int64(3 * time.Second),
+			CloseStatus:        shared.WorkflowExecutionCloseStatusCompleted,
+			HistoryLength:      10,
+			HistoryArchivalURI: "file:///history",
+			SearchAttributes: map[string]string{
+				"CustomKeywordField": `"keyword"`,
+			},
+		},
+		{
+			DomainID:         testDomainID,
+			DomainName:       testDomainName,
+			WorkflowID:       testWorkflowID,
+			RunID:            "run-1",
+			WorkflowTypeName: testWorkflowTypeName,
+			CloseTimestamp:   int64(time.Second),
+			CloseStatus:      shared.WorkflowExecutionCloseStatusFailed,
+			HistoryLength:    20,
+			Memo: &shared.Memo{
+				Fields: map[string][]byte{
+					"memoKey": []byte(`"memoValue"`),
+				},
+			},
+			SearchAttributes: map[string]string{
+				"CustomIntField": "1",
+			},
+		},
+	}
+	data, err := encodeColumnarVisibility(records)
+	s.NoError(err)
+
+	file, err := readParquetForTest(data)
+	s.NoError(err)
+	s.Equal(int64(2), file.numRows)
+	s.Equal(columnarVisibilitySchemaVersion, file.keyValues[columnarVisibilitySchemaVersionKey])
+	s.Equal([]interface{}{[]byte("run-1"), []byte("run-2")}, file.columns["RunID"])
+	s.Equal([]interface{}{int64(0), int64(time.Second / time.Microsecond)}, file.columns["StartTime"])
+	s.Equal([]interface{}{int64(time.Second / time.Microsecond), int64(3 * time.Second / time.Microsecond)}, file.columns["CloseTime"])
+	s.Equal([]interface{}{[]byte("FAILED"), []byte("COMPLETED")}, file.columns["CloseStatus"])
+	s.Equal([]interface{}{int64(20), int64(10)}, file.columns["HistoryLength"])
+	s.Equal([]interface{}{nil, []byte("file:///history")}, file.columns["HistoryArchivalURI"])
+	s.Equal([]interface{}{[]byte(`{"memoKey":"\"memoValue\""}`), nil}, file.columns["Memo"])
+	s.Equal([]interface{}{[]byte("1"), nil}, file.columns["SearchAttributes.CustomIntField"])
+	s.Equal([]interface{}{nil, []byte(`"keyword"`)}, file.columns["SearchAttributes.CustomKeywordField"])
+}
+
+func (s *columnarVisibilitySuite) newTestVisibilityArchiver(columnarVisibility bool) *visibilityArchiver {
+	container := &archiver.VisibilityBootstrapContainer{
+		Logger: loggerimpl.NewLogger(zap.NewNop()),
+	}
+	config := &config.FilestoreArchiver{
+		FileMode:           testFileModeStr,
+		DirMode:            testDirModeStr,
+		ColumnarVisibility: columnarVisibility,
+	}
+	archiver, err := NewVisibilityArchiver(container, config)
+	s.NoError(err)
+	return archiver.(*visibilityArchiver)
+}
+
+func (s *columnarVisibilitySuite) newArchiveRequest(runID string, day int64, offset time.Duration) *archiver.ArchiveVisibilityRequest {
+	closeTimestamp := day*int64(24*time.Hour) + int64(offset)
+	return &archiver.ArchiveVisibilityRequest{
+		DomainID:         testDomainID,
+		DomainName:       testDomainName,
+		WorkflowID:       testWorkflowID,
+		RunID:            runID,
+		WorkflowTypeName: testWorkflowTypeName,
+		StartTimestamp:   closeTimestamp - int64(time.Minute),
+		CloseTimestamp:   closeTimestamp,
+		CloseStatus:      shared.WorkflowExecutionCloseStatusCompleted,
+		HistoryLength:    int64(10),
+	}
+}
+
+func (s *columnarVisibilitySuite) readRunIDs(filepath string) []string {
+	data, err := readFile(filepath)
+	s.NoError(err)
+	file, err := readParquetForTest(data)
+	s.NoError(err)
+	var runIDs []string
+	for _, runID := range file.columns["RunID"] {
+		runIDs = append(runIDs, string(runID.([]byte)))
+	}
+	return runIDs
+}
+
+func (s *columnarVisibilitySuite) assertColumnarFileExists(dirPath string, filename string, expected bool) {
+	exists, err := fileExists(path.Join(dirPath, filename))
+	s.NoError(err)
+	s.Equal(expected, exists)
+}
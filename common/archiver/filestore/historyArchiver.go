@@ -22,7 +22,8 @@
 
 // Each Archive() request results in a file named in the format of
 // hash(domainID, workflowID, runID)_version.history being created in the specified
-// directory. Workflow histories stored in that file are encoded in JSON format.
+// directory. Workflow histories stored in that file are encoded in JSON format, along with
+// the integrity metadata of the history which is returned to the caller of Get().
 
 // The Get() method retrieves the archived histories from the directory specified in the
 // URI. It optionally takes in a NextPageToken which specifies the workflow close failover
@@ -53,9 +54,10 @@ const (
 	// URIScheme is the scheme for the filestore implementation
 	URIScheme = "file"
 
-	errEncodeHistory = "failed to encode history batches"
-	errMakeDirectory = "failed to make directory"
-	errWriteFile     = "failed to write history to file"
+	errEncodeHistory    = "failed to encode history batches"
+	errComputeIntegrity = "failed to compute history integrity"
+	errMakeDirectory    = "failed to make directory"
+	errWriteFile        = "failed to write history to file"
 
 	targetHistoryBlobSize = 2 * 1024 * 1024 // 2MB
 )
@@ -75,6 +77,11 @@ type (
 		historyIterator archiver.HistoryIterator
 	}
 
+	historyFile struct {
+		Integrity      *archiver.HistoryIntegrity
+		HistoryBatches []*shared.History
+	}
+
 	getHistoryToken struct {
 		CloseFailoverVersion int64
 		NextBatchIdx         int
@@ -161,7 +168,16 @@ func (h *historyArchiver) Archive(
 		historyBatches = append(historyBatches, historyBlob.Body...)
 	}
 
-	encodedHistoryBatches, err := encode(historyBatches)
+	integrity, err := archiver.NewHistoryIntegrity(historyBatches)
+	if err != nil {
+		logger.Error(archiver.ArchiveNonRetriableErrorMsg, tag.ArchivalArchiveFailReason(errComputeIntegrity), tag.Error(err))
+		return err
+	}
+
+	encodedHistoryBatches, err := encode(&historyFile{
+		Integrity:      integrity,
+		HistoryBatches: historyBatches,
+	})
 	if err != nil {
 		logger.Error(archiver.ArchiveNonRetriableErrorMsg, tag.ArchivalArchiveFailReason(errEncodeHistory), tag.Error(err))
 		return err
@@ -236,18 +252,20 @@ func (h *historyArchiver) Get(
 		return nil, &shared.EntityNotExistsError{Message: archiver.ErrHistoryNotExist.Error()}
 	}
 
-	encodedHistoryBatches, err := readFile(filepath)
+	encodedHistoryFile, err := readFile(filepath)
 	if err != nil {
 		return nil, &shared.InternalServiceError{Message: err.Error()}
 	}
 
-	historyBatches, err := decodeHistoryBatches(encodedHistoryBatches)
+	file, err := decodeHistoryFile(encodedHistoryFile)
 	if err != nil {
 		return nil, &shared.InternalServiceError{Message: err.Error()}
 	}
-	historyBatches = historyBatches[token.NextBatchIdx:]
+	historyBatches := file.HistoryBatches[token.NextBatchIdx:]
 
-	response := &archiver.GetHistoryResponse{}
+	response := &archiver.GetHistoryResponse{
+		Integrity: file.Integrity,
+	}
 	numOfEvents := 0
 	numOfBatches := 0
 	for _, batch := range historyBatches {
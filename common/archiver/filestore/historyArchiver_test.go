@@ -418,6 +418,7 @@ func (s *historyArchiverSuite) TestGet_Success_PickHighestVersion() {
 	s.NoError(err)
 	s.Nil(response.NextPageToken)
 	s.Equal(s.historyBatchesV100, response.HistoryBatches)
+	s.Nil(response.Integrity)
 }
 
 func (s *historyArchiverSuite) TestGet_Success_UseProvidedVersion() {
@@ -519,6 +520,8 @@ func (s *historyArchiverSuite) TestArchiveAndGet() {
 	s.NotNil(response)
 	s.Nil(response.NextPageToken)
 	s.Equal(s.historyBatchesV100, response.HistoryBatches)
+	s.NotNil(response.Integrity)
+	s.NoError(response.Integrity.Verify(response.HistoryBatches))
 }
 
 func (s *historyArchiverSuite) newTestHistoryArchiver(historyIterator archiver.HistoryIterator) *historyArchiver {
@@ -0,0 +1,404 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package filestore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/apache/thrift/lib/go/thrift"
+)
+
+// The minimal subset of the Parquet file format (https://github.com/apache/parquet-format) needed to
+// write flat, nullable columns: one row group per file, one gzip compressed PLAIN encoded data page
+// per column chunk, and RLE encoded definition levels. Group nodes in the schema are required.
+
+const (
+	parquetMagic = "PAR1"
+
+	parquetTypeInt64     int32 = 2
+	parquetTypeByteArray int32 = 6
+
+	parquetRepetitionRequired int32 = 0
+	parquetRepetitionOptional int32 = 1
+
+	parquetConvertedTypeUTF8            int32 = 0
+	parquetConvertedTypeTimestampMicros int32 = 10
+	parquetConvertedTypeJSON            int32 = 19
+
+	parquetEncodingPlain int32 = 0
+	parquetEncodingRLE   int32 = 3
+
+	parquetCodecGzip      int32 = 2
+	parquetPageTypeData   int32 = 0
+	parquetFileVersion    int32 = 1
+	parquetCreatedBy            = "temporal filestore archiver"
+	parquetSchemaRootName       = "schema"
+)
+
+var (
+	errParquetColumnLength = errors.New("parquet column does not have a value for every row")
+	errParquetNullValue    = errors.New("parquet required column has a null value")
+)
+
+type (
+	// parquetColumn is a leaf column of a parquet file, Values holds one int64 or []byte per row,
+	// nil values are only allowed for optional columns
+	parquetColumn struct {
+		Path          []string
+		Type          int32
+		ConvertedType *int32
+		Optional      bool
+		Values        []interface{}
+	}
+
+	parquetKeyValue struct {
+		Key   string
+		Value string
+	}
+
+	// thriftCompactWriter writes thrift structs field by field with the compact protocol,
+	// it keeps the first error so callers only need to check it once
+	thriftCompactWriter struct {
+		buffer   *thrift.TMemoryBuffer
+		protocol *thrift.TCompactProtocol
+		err      error
+	}
+
+	// parquetSchemaElement is either the schema root, a group or a leaf column
+	parquetSchemaElement struct {
+		name        string
+		root        bool
+		numChildren int32
+		column      *parquetColumn
+	}
+
+	parquetColumnChunk struct {
+		column           *parquetColumn
+		dataPageOffset   int64
+		uncompressedSize int64
+		compressedSize   int64
+	}
+)
+
+// encodeParquet encodes the columns into a parquet file with numRows rows
+func encodeParquet(columns []*parquetColumn, numRows int, keyValues []parquetKeyValue) ([]byte, error) {
+	file := bytes.NewBufferString(parquetMagic)
+	var chunks []*parquetColumnChunk
+	if numRows > 0 {
+		for _, column := range columns {
+			chunk, err := writeParquetColumnChunk(file, column, numRows)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode parquet column %v: %v", column.Path, err)
+			}
+			chunks = append(chunks, chunk)
+		}
+	}
+
+	footer, err := encodeParquetFileMetadata(columns, chunks, numRows, keyValues)
+	if err != nil {
+		return nil, err
+	}
+	file.Write(footer)
+	if err := binary.Write(file, binary.LittleEndian, uint32(len(footer))); err != nil {
+		return nil, err
+	}
+	file.WriteString(parquetMagic)
+	return file.Bytes(), nil
+}
+
+func writeParquetColumnChunk(file *bytes.Buffer, column *parquetColumn, numRows int) (*parquetColumnChunk, error) {
+	if len(column.Values) != numRows {
+		return nil, errParquetColumnLength
+	}
+
+	page := &bytes.Buffer{}
+	if column.Optional {
+		definitionLevels := make([]bool, numRows)
+		for i, value := range column.Values {
+			definitionLevels[i] = value != nil
+		}
+		levels := encodeParquetDefinitionLevels(definitionLevels)
+		if err := binary.Write(page, binary.LittleEndian, uint32(len(levels))); err != nil {
+			return nil, err
+		}
+		page.Write(levels)
+	}
+	for _, value := range column.Values {
+		switch v := value.(type) {
+		case nil:
+			if !column.Optional {
+				return nil, errParquetNullValue
+			}
+		case int64:
+			if err := binary.Write(page, binary.LittleEndian, v); err != nil {
+				return nil, err
+			}
+		case []byte:
+			if err := binary.Write(page, binary.LittleEndian, uint32(len(v))); err != nil {
+				return nil, err
+			}
+			page.Write(v)
+		default:
+			return nil, fmt.Errorf("unsupported parquet value type %T", value)
+		}
+	}
+
+	compressed := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(compressed)
+	if _, err := gzipWriter.Write(page.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	w := newThriftCompactWriter()
+	w.structBegin()
+	w.i32Field(1, parquetPageTypeData)
+	w.i32Field(2, int32(page.Len()))
+	w.i32Field(3, int32(compressed.Len()))
+	w.structFieldBegin(5)
+	w.i32Field(1, int32(numRows))
+	w.i32Field(2, parquetEncodingPlain)
+	w.i32Field(3, parquetEncodingRLE)
+	w.i32Field(4, parquetEncodingRLE)
+	w.structEnd()
+	w.structEnd()
+	header, err := w.bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	chunk := &parquetColumnChunk{
+		column:           column,
+		dataPageOffset:   int64(file.Len()),
+		uncompressedSize: int64(len(header) + page.Len()),
+		compressedSize:   int64(len(header) + compressed.Len()),
+	}
+	file.Write(header)
+	file.Write(compressed.Bytes())
+	return chunk, nil
+}
+
+// encodeParquetDefinitionLevels encodes definition levels with a max level of 1 using RLE runs of the
+// RLE/bit-packing hybrid encoding, the 4 bytes length prefix is added by the caller
+func encodeParquetDefinitionLevels(definitionLevels []bool) []byte {
+	encoded := &bytes.Buffer{}
+	varint := make([]byte, binary.MaxVarintLen64)
+	for start := 0; start < len(definitionLevels); {
+		end := start + 1
+		for end < len(definitionLevels) && definitionLevels[end] == definitionLevels[start] {
+			end++
+		}
+		n := binary.PutUvarint(varint, uint64(end-start)<<1)
+		encoded.Write(varint[:n])
+		if definitionLevels[start] {
+			encoded.WriteByte(1)
+		} else {
+			encoded.WriteByte(0)
+		}
+		start = end
+	}
+	return encoded.Bytes()
+}
+
+func encodeParquetFileMetadata(
+	columns []*parquetColumn,
+	chunks []*parquetColumnChunk,
+	numRows int,
+	keyValues []parquetKeyValue,
+) ([]byte, error) {
+	w := newThriftCompactWriter()
+	w.structBegin()
+	w.i32Field(1, parquetFileVersion)
+
+	schema := newParquetSchema(columns)
+	w.listFieldBegin(2, thrift.STRUCT, len(schema))
+	for _, element := range schema {
+		w.structBegin()
+		if element.column != nil {
+			w.i32Field(1, element.column.Type)
+		}
+		switch {
+		case element.column != nil && element.column.Optional:
+			w.i32Field(3, parquetRepetitionOptional)
+		case !element.root:
+			w.i32Field(3, parquetRepetitionRequired)
+		}
+		w.stringField(4, element.name)
+		if element.column == nil {
+			w.i32Field(5, element.numChildren)
+		}
+		if element.column != nil && element.column.ConvertedType != nil {
+			w.i32Field(6, *element.column.ConvertedType)
+		}
+		w.structEnd()
+	}
+
+	w.i64Field(3, int64(numRows))
+
+	// all columns are written as a single row group, an empty file has no row group
+	numRowGroups := 0
+	if len(chunks) != 0 {
+		numRowGroups = 1
+	}
+	w.listFieldBegin(4, thrift.STRUCT, numRowGroups)
+	if numRowGroups != 0 {
+		w.structBegin()
+		var totalByteSize int64
+		w.listFieldBegin(1, thrift.STRUCT, len(chunks))
+		for _, chunk := range chunks {
+			totalByteSize += chunk.uncompressedSize
+			w.structBegin()
+			w.i64Field(2, chunk.dataPageOffset)
+			w.structFieldBegin(3)
+			w.i32Field(1, chunk.column.Type)
+			w.listFieldBegin(2, thrift.I32, 2)
+			w.i32(parquetEncodingPlain)
+			w.i32(parquetEncodingRLE)
+			w.listFieldBegin(3, thrift.STRING, len(chunk.column.Path))
+			for _, name := range chunk.column.Path {
+				w.string(name)
+			}
+			w.i32Field(4, parquetCodecGzip)
+			w.i64Field(5, int64(numRows))
+			w.i64Field(6, chunk.uncompressedSize)
+			w.i64Field(7, chunk.compressedSize)
+			w.i64Field(9, chunk.dataPageOffset)
+			w.structEnd()
+			w.structEnd()
+		}
+		w.i64Field(2, totalByteSize)
+		w.i64Field(3, int64(numRows))
+		w.structEnd()
+	}
+
+	if len(keyValues) != 0 {
+		w.listFieldBegin(5, thrift.STRUCT, len(keyValues))
+		for _, keyValue := range keyValues {
+			w.structBegin()
+			w.stringField(1, keyValue.Key)
+			w.stringField(2, keyValue.Value)
+			w.structEnd()
+		}
+	}
+	w.stringField(6, parquetCreatedBy)
+	w.structEnd()
+	return w.bytes()
+}
+
+// newParquetSchema flattens the column paths into the depth first list of schema elements,
+// columns sharing the first path element are grouped under a single group element
+func newParquetSchema(columns []*parquetColumn) []*parquetSchemaElement {
+	root := &parquetSchemaElement{name: parquetSchemaRootName, root: true}
+	schema := []*parquetSchemaElement{root}
+	for i := 0; i < len(columns); {
+		column := columns[i]
+		root.numChildren++
+		if len(column.Path) == 1 {
+			schema = append(schema, &parquetSchemaElement{name: column.Path[0], column: column})
+			i++
+			continue
+		}
+
+		group := &parquetSchemaElement{name: column.Path[0]}
+		schema = append(schema, group)
+		for ; i < len(columns) && len(columns[i].Path) == 2 && columns[i].Path[0] == group.name; i++ {
+			group.numChildren++
+			schema = append(schema, &parquetSchemaElement{name: columns[i].Path[1], column: columns[i]})
+		}
+	}
+	return schema
+}
+
+func newThriftCompactWriter() *thriftCompactWriter {
+	buffer := thrift.NewTMemoryBuffer()
+	return &thriftCompactWriter{
+		buffer:   buffer,
+		protocol: thrift.NewTCompactProtocol(buffer),
+	}
+}
+
+func (w *thriftCompactWriter) bytes() ([]byte, error) {
+	if w.err == nil {
+		w.err = w.protocol.Flush()
+	}
+	if w.err != nil {
+		return nil, w.err
+	}
+	return w.buffer.Bytes(), nil
+}
+
+func (w *thriftCompactWriter) do(fn func() error) {
+	if w.err == nil {
+		w.err = fn()
+	}
+}
+
+func (w *thriftCompactWriter) structBegin() {
+	w.do(func() error { return w.protocol.WriteStructBegin("") })
+}
+
+func (w *thriftCompactWriter) structEnd() {
+	w.do(w.protocol.WriteFieldStop)
+	w.do(w.protocol.WriteStructEnd)
+}
+
+func (w *thriftCompactWriter) fieldBegin(id int16, fieldType thrift.TType) {
+	w.do(func() error { return w.protocol.WriteFieldBegin("", fieldType, id) })
+}
+
+func (w *thriftCompactWriter) structFieldBegin(id int16) {
+	w.fieldBegin(id, thrift.STRUCT)
+	w.structBegin()
+}
+
+func (w *thriftCompactWriter) listFieldBegin(id int16, elemType thrift.TType, size int) {
+	w.fieldBegin(id, thrift.LIST)
+	w.do(func() error { return w.protocol.WriteListBegin(elemType, size) })
+}
+
+func (w *thriftCompactWriter) i32Field(id int16, value int32) {
+	w.fieldBegin(id, thrift.I32)
+	w.i32(value)
+}
+
+func (w *thriftCompactWriter) i64Field(id int16, value int64) {
+	w.fieldBegin(id, thrift.I64)
+	w.do(func() error { return w.protocol.WriteI64(value) })
+}
+
+func (w *thriftCompactWriter) stringField(id int16, value string) {
+	w.fieldBegin(id, thrift.STRING)
+	w.string(value)
+}
+
+func (w *thriftCompactWriter) i32(value int32) {
+	w.do(func() error { return w.protocol.WriteI32(value) })
+}
+
+func (w *thriftCompactWriter) string(value string) {
+	w.do(func() error { return w.protocol.WriteString(value) })
+}
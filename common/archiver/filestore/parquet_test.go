@@ -0,0 +1,306 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package filestore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common"
+)
+
+type (
+	ParquetSuite struct {
+		*require.Assertions
+		suite.Suite
+	}
+
+	// testParquetFile is the content of a parquet file decoded by the minimal reader used in tests
+	testParquetFile struct {
+		numRows   int64
+		schema    []map[int16]interface{}
+		keyValues map[string]string
+		columns   map[string][]interface{}
+	}
+)
+
+func TestParquetSuite(t *testing.T) {
+	suite.Run(t, new(ParquetSuite))
+}
+
+func (s *ParquetSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *ParquetSuite) TestEncodeParquet() {
+	columns := []*parquetColumn{
+		{Path: []string{"ID"}, Type: parquetTypeInt64, Values: []interface{}{int64(1), int64(2), int64(3)}},
+		{Path: []string{"Name"}, Type: parquetTypeByteArray, ConvertedType: common.Int32Ptr(parquetConvertedTypeUTF8), Values: []interface{}{[]byte("a"), []byte("b"), []byte("c")}},
+		{Path: []string{"Attrs", "A"}, Type: parquetTypeByteArray, Optional: true, Values: []interface{}{nil, []byte("1"), nil}},
+		{Path: []string{"Attrs", "B"}, Type: parquetTypeInt64, Optional: true, Values: []interface{}{int64(4), int64(5), int64(6)}},
+	}
+	data, err := encodeParquet(columns, 3, []parquetKeyValue{{Key: "key", Value: "value"}})
+	s.NoError(err)
+
+	file, err := readParquetForTest(data)
+	s.NoError(err)
+	s.Equal(int64(3), file.numRows)
+	s.Equal(map[string]string{"key": "value"}, file.keyValues)
+
+	var names []string
+	for _, element := range file.schema {
+		names = append(names, string(element[4].([]byte)))
+	}
+	s.Equal([]string{parquetSchemaRootName, "ID", "Name", "Attrs", "A", "B"}, names)
+	s.Equal(int32(3), file.schema[0][5])
+	s.Nil(file.schema[0][3])
+	s.Equal(int32(2), file.schema[3][5])
+	s.Equal(parquetRepetitionRequired, file.schema[3][3])
+	s.Equal(parquetRepetitionOptional, file.schema[4][3])
+	s.Equal(parquetConvertedTypeUTF8, file.schema[2][6])
+
+	s.Equal(columns[0].Values, file.columns["ID"])
+	s.Equal(columns[1].Values, file.columns["Name"])
+	s.Equal(columns[2].Values, file.columns["Attrs.A"])
+	s.Equal(columns[3].Values, file.columns["Attrs.B"])
+}
+
+func (s *ParquetSuite) TestEncodeParquet_NoRows() {
+	columns := []*parquetColumn{
+		{Path: []string{"ID"}, Type: parquetTypeInt64},
+	}
+	data, err := encodeParquet(columns, 0, nil)
+	s.NoError(err)
+
+	file, err := readParquetForTest(data)
+	s.NoError(err)
+	s.Equal(int64(0), file.numRows)
+	s.Len(file.schema, 2)
+	s.Empty(file.columns)
+}
+
+func (s *ParquetSuite) TestEncodeParquet_Fail() {
+	_, err := encodeParquet([]*parquetColumn{
+		{Path: []string{"ID"}, Type: parquetTypeInt64, Values: []interface{}{int64(1), nil}},
+	}, 2, nil)
+	s.Error(err)
+
+	_, err = encodeParquet([]*parquetColumn{
+		{Path: []string{"ID"}, Type: parquetTypeInt64, Values: []interface{}{int64(1)}},
+	}, 2, nil)
+	s.Error(err)
+}
+
+func (s *ParquetSuite) TestEncodeParquetDefinitionLevels() {
+	s.Equal([]byte{4, 1, 2, 0, 2, 1}, encodeParquetDefinitionLevels([]bool{true, true, false, true}))
+	s.Empty(encodeParquetDefinitionLevels(nil))
+}
+
+// readParquetForTest decodes the subset of the parquet format written by encodeParquet
+func readParquetForTest(data []byte) (*testParquetFile, error) {
+	if len(data) < 12 || string(data[:4]) != parquetMagic || string(data[len(data)-4:]) != parquetMagic {
+		return nil, errors.New("invalid parquet magic")
+	}
+	footerLength := int(binary.LittleEndian.Uint32(data[len(data)-8 : len(data)-4]))
+	metadata, _, err := readThriftStructForTest(data[len(data)-8-footerLength : len(data)-8])
+	if err != nil {
+		return nil, err
+	}
+
+	file := &testParquetFile{
+		numRows:   metadata[3].(int64),
+		keyValues: make(map[string]string),
+		columns:   make(map[string][]interface{}),
+	}
+	optional := make(map[string]bool)
+	var path []string
+	var remainingChildren []int32
+	for _, element := range metadata[2].([]interface{}) {
+		fields := element.(map[int16]interface{})
+		file.schema = append(file.schema, fields)
+		name := string(fields[4].([]byte))
+		if len(file.schema) == 1 {
+			remainingChildren = append(remainingChildren, fields[5].(int32))
+			continue
+		}
+		remainingChildren[len(remainingChildren)-1]--
+		if numChildren, ok := fields[5]; ok {
+			path = append(path, name)
+			remainingChildren = append(remainingChildren, numChildren.(int32))
+			continue
+		}
+		optional[strings.Join(append(path, name), ".")] = fields[3] == parquetRepetitionOptional
+		for len(path) != 0 && remainingChildren[len(remainingChildren)-1] == 0 {
+			path = path[:len(path)-1]
+			remainingChildren = remainingChildren[:len(remainingChildren)-1]
+		}
+	}
+	if keyValues, ok := metadata[5]; ok {
+		for _, keyValue := range keyValues.([]interface{}) {
+			fields := keyValue.(map[int16]interface{})
+			file.keyValues[string(fields[1].([]byte))] = string(fields[2].([]byte))
+		}
+	}
+
+	for _, rowGroup := range metadata[4].([]interface{}) {
+		for _, chunk := range rowGroup.(map[int16]interface{})[1].([]interface{}) {
+			columnMetadata := chunk.(map[int16]interface{})[3].(map[int16]interface{})
+			var names []string
+			for _, name := range columnMetadata[3].([]interface{}) {
+				names = append(names, string(name.([]byte)))
+			}
+			columnPath := strings.Join(names, ".")
+			values, err := readParquetColumnForTest(data[columnMetadata[9].(int64):], columnMetadata[1].(int32), optional[columnPath])
+			if err != nil {
+				return nil, err
+			}
+			file.columns[columnPath] = values
+		}
+	}
+	return file, nil
+}
+
+func readParquetColumnForTest(data []byte, columnType int32, optional bool) ([]interface{}, error) {
+	header, headerLength, err := readThriftStructForTest(data)
+	if err != nil {
+		return nil, err
+	}
+	numValues := int(header[5].(map[int16]interface{})[1].(int32))
+	compressed := data[headerLength : headerLength+int(header[3].(int32))]
+	gzipReader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	page, err := ioutil.ReadAll(gzipReader)
+	if err != nil {
+		return nil, err
+	}
+
+	defined := make([]bool, 0, numValues)
+	if optional {
+		levelsLength := int(binary.LittleEndian.Uint32(page))
+		levels := bytes.NewReader(page[4 : 4+levelsLength])
+		for levels.Len() != 0 {
+			runHeader, err := binary.ReadUvarint(levels)
+			if err != nil {
+				return nil, err
+			}
+			if runHeader&1 != 0 {
+				return nil, errors.New("bit packed runs are not supported")
+			}
+			value, err := levels.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			for i := uint64(0); i < runHeader>>1; i++ {
+				defined = append(defined, value == 1)
+			}
+		}
+		page = page[4+levelsLength:]
+	} else {
+		for i := 0; i < numValues; i++ {
+			defined = append(defined, true)
+		}
+	}
+
+	var values []interface{}
+	for _, isDefined := range defined {
+		switch {
+		case !isDefined:
+			values = append(values, nil)
+		case columnType == parquetTypeInt64:
+			values = append(values, int64(binary.LittleEndian.Uint64(page)))
+			page = page[8:]
+		default:
+			length := int(binary.LittleEndian.Uint32(page))
+			values = append(values, page[4:4+length])
+			page = page[4+length:]
+		}
+	}
+	return values, nil
+}
+
+// readThriftStructForTest decodes a compact protocol struct into a map of field ID to value,
+// it returns the number of bytes read
+func readThriftStructForTest(data []byte) (map[int16]interface{}, int, error) {
+	buffer := thrift.NewTMemoryBuffer()
+	buffer.Write(data)
+	protocol := thrift.NewTCompactProtocol(buffer)
+	value, err := readThriftValueForTest(protocol, thrift.STRUCT)
+	if err != nil {
+		return nil, 0, err
+	}
+	return value.(map[int16]interface{}), len(data) - buffer.Len(), nil
+}
+
+func readThriftValueForTest(protocol *thrift.TCompactProtocol, valueType thrift.TType) (interface{}, error) {
+	switch valueType {
+	case thrift.I32:
+		return protocol.ReadI32()
+	case thrift.I64:
+		return protocol.ReadI64()
+	case thrift.STRING:
+		return protocol.ReadBinary()
+	case thrift.LIST:
+		elemType, size, err := protocol.ReadListBegin()
+		if err != nil {
+			return nil, err
+		}
+		values := []interface{}{}
+		for i := 0; i < size; i++ {
+			value, err := readThriftValueForTest(protocol, elemType)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, protocol.ReadListEnd()
+	case thrift.STRUCT:
+		if _, err := protocol.ReadStructBegin(); err != nil {
+			return nil, err
+		}
+		fields := make(map[int16]interface{})
+		for {
+			_, fieldType, id, err := protocol.ReadFieldBegin()
+			if err != nil {
+				return nil, err
+			}
+			if fieldType == thrift.STOP {
+				break
+			}
+			if fields[id], err = readThriftValueForTest(protocol, fieldType); err != nil {
+				return nil, err
+			}
+		}
+		return fields, protocol.ReadStructEnd()
+	default:
+		return nil, errors.New("unsupported thrift type")
+	}
+}
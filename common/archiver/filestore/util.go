@@ -21,6 +21,7 @@
 package filestore
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -143,6 +144,25 @@ func encode(v interface{}) ([]byte, error) {
 	return json.Marshal(v)
 }
 
+// decodeHistoryFile decodes an archived history file, files written before integrity metadata
+// was recorded only contain the encoded history batches and are decoded without one
+func decodeHistoryFile(data []byte) (*historyFile, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) != 0 && trimmed[0] == '[' {
+		historyBatches, err := decodeHistoryBatches(data)
+		if err != nil {
+			return nil, err
+		}
+		return &historyFile{HistoryBatches: historyBatches}, nil
+	}
+
+	file := &historyFile{}
+	err := json.Unmarshal(data, file)
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
 func decodeHistoryBatches(data []byte) ([]*shared.History, error) {
 	historyBatches := []*shared.History{}
 	err := json.Unmarshal(data, &historyBatches)
@@ -214,6 +214,24 @@ func (s *UtilSuite) TestEncodeDecodeHistoryBatches() {
 	decodedHistoryBatches, err := decodeHistoryBatches(encodedHistoryBatches)
 	s.NoError(err)
 	s.Equal(historyBatches, decodedHistoryBatches)
+
+	integrity, err := archiver.NewHistoryIntegrity(historyBatches)
+	s.NoError(err)
+	encodedHistoryFile, err := encode(&historyFile{
+		Integrity:      integrity,
+		HistoryBatches: historyBatches,
+	})
+	s.NoError(err)
+
+	decodedHistoryFile, err := decodeHistoryFile(encodedHistoryFile)
+	s.NoError(err)
+	s.Equal(integrity, decodedHistoryFile.Integrity)
+	s.Equal(historyBatches, decodedHistoryFile.HistoryBatches)
+
+	decodedHistoryFile, err = decodeHistoryFile(encodedHistoryBatches)
+	s.NoError(err)
+	s.Nil(decodedHistoryFile.Integrity)
+	s.Equal(historyBatches, decodedHistoryFile.HistoryBatches)
 }
 
 func (s *UtilSuite) TestValidateDirPath() {
@@ -28,6 +28,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/temporalio/temporal/.gen/go/shared"
 	"github.com/temporalio/temporal/common"
@@ -38,14 +39,20 @@ import (
 
 const (
 	errEncodeVisibilityRecord = "failed to encode visibility record"
+	errCompactVisibility      = "failed to compact visibility records"
 )
 
 type (
 	visibilityArchiver struct {
-		container   *archiver.VisibilityBootstrapContainer
-		fileMode    os.FileMode
-		dirMode     os.FileMode
-		queryParser QueryParser
+		sync.Mutex
+		container          *archiver.VisibilityBootstrapContainer
+		fileMode           os.FileMode
+		dirMode            os.FileMode
+		queryParser        QueryParser
+		columnarVisibility bool
+
+		// the latest close day checked for columnar compaction of each domain
+		compactionCheckedDays map[string]int64
 	}
 
 	queryVisibilityToken struct {
@@ -77,10 +84,12 @@ func NewVisibilityArchiver(
 		return nil, errInvalidDirMode
 	}
 	return &visibilityArchiver{
-		container:   container,
-		fileMode:    os.FileMode(fileMode),
-		dirMode:     os.FileMode(dirMode),
-		queryParser: NewQueryParser(),
+		container:             container,
+		fileMode:              os.FileMode(fileMode),
+		dirMode:               os.FileMode(dirMode),
+		queryParser:           NewQueryParser(),
+		columnarVisibility:    config.ColumnarVisibility,
+		compactionCheckedDays: make(map[string]int64),
 	}, nil
 }
 
@@ -129,6 +138,13 @@ func (v *visibilityArchiver) Archive(
 		return err
 	}
 
+	if v.columnarVisibility {
+		// the record itself is archived, compaction is attempted again when the next record of the domain is archived
+		if err := v.compactVisibility(URI, request.DomainID, request.CloseTimestamp); err != nil {
+			logger.Warn(errCompactVisibility, tag.Error(err))
+		}
+	}
+
 	return nil
 }
 
@@ -255,22 +271,11 @@ type parsedVisFilename struct {
 func sortAndFilterFiles(filenames []string, token *queryVisibilityToken) ([]string, error) {
 	var parsedFilenames []*parsedVisFilename
 	for _, name := range filenames {
-		pieces := strings.FieldsFunc(name, func(r rune) bool {
-			return r == '_' || r == '.'
-		})
-		if len(pieces) != 3 {
-			return nil, fmt.Errorf("failed to parse visibility filename %s", name)
-		}
-
-		closeTime, err := strconv.ParseInt(pieces[0], 10, 64)
+		parsedFilename, err := parseVisibilityFilename(name)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse visibility filename %s", name)
+			return nil, err
 		}
-		parsedFilenames = append(parsedFilenames, &parsedVisFilename{
-			name:        name,
-			closeTime:   closeTime,
-			hashedRunID: pieces[1],
-		})
+		parsedFilenames = append(parsedFilenames, parsedFilename)
 	}
 
 	sort.Slice(parsedFilenames, func(i, j int) bool {
@@ -302,6 +307,25 @@ func sortAndFilterFiles(filenames []string, token *queryVisibilityToken) ([]stri
 	return filteredFilenames, nil
 }
 
+func parseVisibilityFilename(name string) (*parsedVisFilename, error) {
+	pieces := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '.'
+	})
+	if len(pieces) != 3 {
+		return nil, fmt.Errorf("failed to parse visibility filename %s", name)
+	}
+
+	closeTime, err := strconv.ParseInt(pieces[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse visibility filename %s", name)
+	}
+	return &parsedVisFilename{
+		name:        name,
+		closeTime:   closeTime,
+		hashedRunID: pieces[1],
+	}, nil
+}
+
 func matchQuery(record *visibilityRecord, query *parsedQuery) bool {
 	if record.CloseTimestamp < query.earliestCloseTime || record.CloseTimestamp > query.latestCloseTime {
 		return false
@@ -0,0 +1,97 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package archiver
+
+import (
+	"encoding/json"
+	"hash/crc32"
+
+	"github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/common"
+)
+
+type (
+	// HistoryIntegrity is the integrity metadata recorded along with an archived workflow history,
+	// it allows readers to detect archived histories which are truncated or corrupted
+	HistoryIntegrity struct {
+		FirstEventID int64
+		LastEventID  int64
+		EventCount   int64
+		Checksum     uint32
+	}
+)
+
+// NewHistoryIntegrity computes the integrity metadata of the given history batches
+func NewHistoryIntegrity(historyBatches []*shared.History) (*HistoryIntegrity, error) {
+	integrity := &HistoryIntegrity{}
+	hash := crc32.NewIEEE()
+	for _, batch := range historyBatches {
+		for _, event := range batch.Events {
+			if integrity.EventCount == 0 {
+				integrity.FirstEventID = event.GetEventId()
+			}
+			integrity.LastEventID = event.GetEventId()
+			integrity.EventCount++
+		}
+
+		data, err := json.Marshal(batch)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := hash.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	integrity.Checksum = hash.Sum32()
+	return integrity, nil
+}
+
+// Verify checks that the given history batches match the integrity metadata
+func (i *HistoryIntegrity) Verify(historyBatches []*shared.History) error {
+	actual, err := NewHistoryIntegrity(historyBatches)
+	if err != nil {
+		return err
+	}
+	if actual.EventCount != i.EventCount {
+		return ErrHistoryEventCountMismatch
+	}
+	if actual.FirstEventID != i.FirstEventID || actual.LastEventID != i.LastEventID {
+		return ErrHistoryEventIDRangeMismatch
+	}
+	if actual.Checksum != i.Checksum {
+		return ErrHistoryChecksumMismatch
+	}
+	return nil
+}
+
+// ValidateHistoryEventIDs checks that the event IDs of the given history batches are contiguous
+func ValidateHistoryEventIDs(historyBatches []*shared.History) error {
+	var lastEventID *int64
+	for _, batch := range historyBatches {
+		for _, event := range batch.Events {
+			if lastEventID != nil && event.GetEventId() != *lastEventID+1 {
+				return ErrHistoryEventIDsNotContiguous
+			}
+			lastEventID = common.Int64Ptr(event.GetEventId())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package archiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/common"
+)
+
+type (
+	IntegritySuite struct {
+		*require.Assertions
+		suite.Suite
+	}
+)
+
+func TestIntegritySuite(t *testing.T) {
+	suite.Run(t, new(IntegritySuite))
+}
+
+func (s *IntegritySuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *IntegritySuite) TestNewHistoryIntegrity() {
+	integrity, err := NewHistoryIntegrity(s.newHistoryBatches(1, 3, 2))
+	s.NoError(err)
+	s.Equal(int64(1), integrity.FirstEventID)
+	s.Equal(int64(5), integrity.LastEventID)
+	s.Equal(int64(5), integrity.EventCount)
+	s.NotZero(integrity.Checksum)
+
+	sameIntegrity, err := NewHistoryIntegrity(s.newHistoryBatches(1, 3, 2))
+	s.NoError(err)
+	s.Equal(integrity, sameIntegrity)
+}
+
+func (s *IntegritySuite) TestValidateHistoryEventIDs() {
+	s.NoError(ValidateHistoryEventIDs(s.newHistoryBatches(1, 3, 2)))
+
+	historyBatches := s.newHistoryBatches(1, 3, 2)
+	historyBatches[1].Events[0].EventId = common.Int64Ptr(5)
+	s.Equal(ErrHistoryEventIDsNotContiguous, ValidateHistoryEventIDs(historyBatches))
+}
+
+func (s *IntegritySuite) TestVerify_Success() {
+	integrity, err := NewHistoryIntegrity(s.newHistoryBatches(1, 3, 2))
+	s.NoError(err)
+	s.NoError(integrity.Verify(s.newHistoryBatches(1, 3, 2)))
+}
+
+func (s *IntegritySuite) TestVerify_Truncated() {
+	integrity, err := NewHistoryIntegrity(s.newHistoryBatches(1, 3, 2))
+	s.NoError(err)
+	s.Equal(ErrHistoryEventCountMismatch, integrity.Verify(s.newHistoryBatches(1, 3)))
+}
+
+func (s *IntegritySuite) TestVerify_EventIDRangeMismatch() {
+	integrity, err := NewHistoryIntegrity(s.newHistoryBatches(1, 3, 2))
+	s.NoError(err)
+	s.Equal(ErrHistoryEventIDRangeMismatch, integrity.Verify(s.newHistoryBatches(2, 3, 2)))
+}
+
+func (s *IntegritySuite) TestVerify_ChecksumMismatch() {
+	integrity, err := NewHistoryIntegrity(s.newHistoryBatches(1, 3, 2))
+	s.NoError(err)
+	historyBatches := s.newHistoryBatches(1, 3, 2)
+	historyBatches[0].Events[1].Version = common.Int64Ptr(testCloseFailoverVersion + 1)
+	s.Equal(ErrHistoryChecksumMismatch, integrity.Verify(historyBatches))
+}
+
+func (s *IntegritySuite) newHistoryBatches(firstEventID int64, batchSizes ...int) []*shared.History {
+	var historyBatches []*shared.History
+	eventID := firstEventID
+	for _, batchSize := range batchSizes {
+		batch := &shared.History{}
+		for i := 0; i < batchSize; i++ {
+			batch.Events = append(batch.Events, &shared.HistoryEvent{
+				EventId:   common.Int64Ptr(eventID),
+				Version:   common.Int64Ptr(testCloseFailoverVersion),
+				EventType: shared.EventTypeDecisionTaskCompleted.Ptr(),
+			})
+			eventID++
+		}
+		historyBatches = append(historyBatches, batch)
+	}
+	return historyBatches
+}
@@ -58,6 +58,9 @@ type (
 	GetHistoryResponse struct {
 		HistoryBatches []*shared.History
 		NextPageToken  []byte
+		// Integrity is the integrity metadata of the entire archived history,
+		// nil if the history was archived without one
+		Integrity *HistoryIntegrity
 	}
 
 	// HistoryBootstrapContainer contains components needed by all history Archiver implementations
@@ -0,0 +1,58 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"fmt"
+
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+type (
+	// QueueProvider creates the persistence queue used by the persistence sink
+	QueueProvider func() (persistence.Queue, error)
+)
+
+// NewSink creates the sink configured by the given config
+func NewSink(
+	config *config.Audit,
+	queueProvider QueueProvider,
+	metricsClient metrics.Client,
+	logger log.Logger,
+) (Sink, error) {
+	switch config.Sink {
+	case SinkTypeNoop:
+		return NewNoopSink(), nil
+	case SinkTypeFile:
+		return NewFileSink(&config.File)
+	case SinkTypePersistence:
+		queue, err := queueProvider()
+		if err != nil {
+			return nil, err
+		}
+		return NewPersistenceSink(queue, metricsClient, logger), nil
+	default:
+		return nil, fmt.Errorf("unknown audit sink type: %v", config.Sink)
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+const (
+	defaultMaxFileSizeMB   = 100
+	defaultMaxBackups      = 5
+	defaultListPageSize    = 100
+	fileSinkFilePermission = 0640
+	bytesInMB              = 1024 * 1024
+)
+
+type (
+	fileSink struct {
+		sync.Mutex
+		path       string
+		maxSize    int64
+		maxBackups int
+
+		file *os.File
+		size int64
+	}
+
+	// fileSinkPageToken points right after the last record returned, records written in the same
+	// nanosecond are told apart by their position among the matching records of that timestamp
+	fileSinkPageToken struct {
+		LastTimestamp time.Time
+		Skip          int
+	}
+)
+
+var _ Sink = (*fileSink)(nil)
+
+// NewFileSink creates a sink which appends records as json lines to a local file. Every host has
+// its own file, List only returns the records written by the local host.
+func NewFileSink(config *config.AuditFileSink) (Sink, error) {
+	if config.Path == "" {
+		return nil, fmt.Errorf("audit file sink path is not set")
+	}
+
+	sink := &fileSink{
+		path:       config.Path,
+		maxSize:    int64(config.MaxSizeMB) * bytesInMB,
+		maxBackups: config.MaxBackups,
+	}
+	if sink.maxSize <= 0 {
+		sink.maxSize = defaultMaxFileSizeMB * bytesInMB
+	}
+	if sink.maxBackups <= 0 {
+		sink.maxBackups = defaultMaxBackups
+	}
+	if err := sink.open(); err != nil {
+		return nil, err
+	}
+	return sink, nil
+}
+
+func (s *fileSink) Write(record *Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	s.Lock()
+	defer s.Unlock()
+
+	if s.file == nil {
+		return fmt.Errorf("audit file sink is closed")
+	}
+	if s.size > 0 && s.size+int64(len(data)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.file.Write(data)
+	s.size += int64(n)
+	return err
+}
+
+func (s *fileSink) List(request *ListRequest) (*ListResponse, error) {
+	token := &fileSinkPageToken{}
+	if len(request.NextPageToken) != 0 {
+		if err := json.Unmarshal(request.NextPageToken, token); err != nil {
+			return nil, ErrInvalidNextPageToken
+		}
+	}
+	pageSize := request.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultListPageSize
+	}
+
+	// rotation renames files, hold the lock so that no file is missed or read twice
+	s.Lock()
+	defer s.Unlock()
+
+	response := &ListResponse{}
+	skipped := 0
+	for i := s.maxBackups; i >= 0; i-- {
+		file, err := os.Open(s.fileName(i))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		next, err := s.listFile(file, request, token, &skipped, pageSize, response)
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+		if next != nil {
+			response.NextPageToken = next
+			return response, nil
+		}
+	}
+	return response, nil
+}
+
+// listFile appends the matching records of the file to the response
+// and returns the next page token once the page is full
+func (s *fileSink) listFile(
+	file *os.File,
+	request *ListRequest,
+	token *fileSinkPageToken,
+	skipped *int,
+	pageSize int,
+	response *ListResponse,
+) ([]byte, error) {
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		record := &Record{}
+		if err := json.Unmarshal(line, record); err != nil {
+			// skip records which were partially written
+			continue
+		}
+		if record.Timestamp.Before(token.LastTimestamp) || !request.Matches(record) {
+			continue
+		}
+		if record.Timestamp.Equal(token.LastTimestamp) && *skipped < token.Skip {
+			*skipped++
+			continue
+		}
+
+		if len(response.Records) == pageSize {
+			last := response.Records[len(response.Records)-1].Timestamp
+			next := &fileSinkPageToken{LastTimestamp: last}
+			for _, r := range response.Records {
+				if r.Timestamp.Equal(last) {
+					next.Skip++
+				}
+			}
+			if last.Equal(token.LastTimestamp) {
+				next.Skip += token.Skip
+			}
+			return json.Marshal(next)
+		}
+		response.Records = append(response.Records, record)
+	}
+}
+
+func (s *fileSink) Close() {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.file != nil {
+		s.file.Close()
+		s.file = nil
+	}
+}
+
+func (s *fileSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, fileSinkFilePermission)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+func (s *fileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	s.file = nil
+
+	if err := os.Remove(s.fileName(s.maxBackups)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for i := s.maxBackups - 1; i >= 0; i-- {
+		if err := os.Rename(s.fileName(i), s.fileName(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return s.open()
+}
+
+// fileName returns the name of the current file for index 0 and of the rotated files otherwise,
+// higher indexes hold older records
+func (s *fileSink) fileName(index int) string {
+	if index == 0 {
+		return s.path
+	}
+	return fmt.Sprintf("%v.%v", s.path, index)
+}
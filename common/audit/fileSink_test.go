@@ -0,0 +1,164 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+type (
+	fileSinkSuite struct {
+		suite.Suite
+
+		dir string
+	}
+)
+
+func TestFileSinkSuite(t *testing.T) {
+	suite.Run(t, new(fileSinkSuite))
+}
+
+func (s *fileSinkSuite) SetupTest() {
+	var err error
+	s.dir, err = ioutil.TempDir("", "audit")
+	s.NoError(err)
+}
+
+func (s *fileSinkSuite) TearDownTest() {
+	os.RemoveAll(s.dir)
+}
+
+func (s *fileSinkSuite) TestWriteAndList() {
+	sink, err := NewFileSink(&config.AuditFileSink{Path: filepath.Join(s.dir, "audit.log")})
+	s.NoError(err)
+	defer sink.Close()
+
+	now := time.Now().UTC()
+	records := []*Record{
+		{Timestamp: now, API: "TerminateWorkflowExecution", Domain: "domain1", Identity: "alice", Outcome: OutcomeSuccess},
+		{Timestamp: now.Add(time.Second), API: "SignalWorkflowExecution", Domain: "domain2", Identity: "bob", Outcome: OutcomeSuccess},
+		{Timestamp: now.Add(2 * time.Second), API: "UpdateDomain", Domain: "domain1", Identity: "bob", Outcome: OutcomeFailure},
+	}
+	for _, record := range records {
+		s.NoError(sink.Write(record))
+	}
+
+	resp, err := sink.List(&ListRequest{})
+	s.NoError(err)
+	s.Equal(records, resp.Records)
+	s.Nil(resp.NextPageToken)
+
+	resp, err = sink.List(&ListRequest{Domain: "domain1"})
+	s.NoError(err)
+	s.Equal([]*Record{records[0], records[2]}, resp.Records)
+
+	resp, err = sink.List(&ListRequest{Identity: "bob", EndTime: now.Add(time.Second)})
+	s.NoError(err)
+	s.Equal([]*Record{records[1]}, resp.Records)
+
+	resp, err = sink.List(&ListRequest{StartTime: now.Add(time.Second)})
+	s.NoError(err)
+	s.Equal(records[1:], resp.Records)
+}
+
+func (s *fileSinkSuite) TestList_Pagination() {
+	sink, err := NewFileSink(&config.AuditFileSink{Path: filepath.Join(s.dir, "audit.log")})
+	s.NoError(err)
+	defer sink.Close()
+
+	// records with the same timestamp must not be skipped or returned twice across pages
+	now := time.Now().UTC()
+	var records []*Record
+	for i := 0; i < 5; i++ {
+		record := &Record{Timestamp: now.Add(time.Duration(i/3) * time.Second), API: "API", RunID: strconv.Itoa(i)}
+		records = append(records, record)
+		s.NoError(sink.Write(record))
+	}
+
+	var result []*Record
+	var token []byte
+	for {
+		resp, err := sink.List(&ListRequest{PageSize: 2, NextPageToken: token})
+		s.NoError(err)
+		result = append(result, resp.Records...)
+		token = resp.NextPageToken
+		if len(token) == 0 {
+			break
+		}
+	}
+	s.Equal(records, result)
+}
+
+func (s *fileSinkSuite) TestRotation() {
+	path := filepath.Join(s.dir, "audit.log")
+	sink, err := NewFileSink(&config.AuditFileSink{Path: path, MaxBackups: 2})
+	s.NoError(err)
+	defer sink.Close()
+	// rotate after every record
+	sink.(*fileSink).maxSize = 1
+
+	now := time.Now().UTC()
+	var records []*Record
+	for i := 0; i < 5; i++ {
+		record := &Record{Timestamp: now.Add(time.Duration(i) * time.Second), API: "API"}
+		records = append(records, record)
+		s.NoError(sink.Write(record))
+	}
+
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		_, err := os.Stat(name)
+		s.NoError(err)
+	}
+	_, err = os.Stat(path + ".3")
+	s.True(os.IsNotExist(err))
+
+	resp, err := sink.List(&ListRequest{})
+	s.NoError(err)
+	s.Equal(records[2:], resp.Records)
+}
+
+func (s *fileSinkSuite) TestReopen() {
+	path := filepath.Join(s.dir, "audit.log")
+	sink, err := NewFileSink(&config.AuditFileSink{Path: path})
+	s.NoError(err)
+	record := &Record{Timestamp: time.Now().UTC(), API: "API"}
+	s.NoError(sink.Write(record))
+	sink.Close()
+	s.Error(sink.Write(record))
+
+	sink, err = NewFileSink(&config.AuditFileSink{Path: path})
+	s.NoError(err)
+	defer sink.Close()
+	s.NoError(sink.Write(record))
+
+	resp, err := sink.List(&ListRequest{})
+	s.NoError(err)
+	s.Equal([]*Record{record, record}, resp.Records)
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// OutcomeSuccess means the audited call succeeded
+	OutcomeSuccess = "success"
+	// OutcomeFailure means the audited call returned an error
+	OutcomeFailure = "failure"
+)
+
+const (
+	// SinkTypeNoop disables the audit log
+	SinkTypeNoop = ""
+	// SinkTypeFile writes audit records to a rotating local file
+	SinkTypeFile = "file"
+	// SinkTypePersistence writes audit records to the queue table of the default persistence store
+	SinkTypePersistence = "persistence"
+)
+
+// ErrInvalidNextPageToken is returned by List if the next page token can't be decoded
+var ErrInvalidNextPageToken = errors.New("invalid next page token")
+
+type (
+	// Record describes a single mutating API call
+	Record struct {
+		Timestamp     time.Time `json:"timestamp"`
+		Service       string    `json:"service"`
+		API           string    `json:"api"`
+		Identity      string    `json:"identity,omitempty"`
+		RemoteAddress string    `json:"remoteAddress,omitempty"`
+		Domain        string    `json:"domain,omitempty"`
+		WorkflowID    string    `json:"workflowID,omitempty"`
+		RunID         string    `json:"runID,omitempty"`
+		Reason        string    `json:"reason,omitempty"`
+		Outcome       string    `json:"outcome"`
+		Error         string    `json:"error,omitempty"`
+	}
+
+	// ListRequest is used to query audit records, empty fields match every record
+	ListRequest struct {
+		Domain        string
+		Identity      string
+		StartTime     time.Time
+		EndTime       time.Time
+		PageSize      int
+		NextPageToken []byte
+	}
+
+	// ListResponse is the response to ListRequest
+	ListResponse struct {
+		Records       []*Record
+		NextPageToken []byte
+	}
+
+	// Sink stores audit records and allows to query them back
+	Sink interface {
+		Write(record *Record) error
+		List(request *ListRequest) (*ListResponse, error)
+		Close()
+	}
+)
+
+// Matches returns true if the record satisfies all the filters of the request
+func (r *ListRequest) Matches(record *Record) bool {
+	if r.Domain != "" && r.Domain != record.Domain {
+		return false
+	}
+	if r.Identity != "" && r.Identity != record.Identity {
+		return false
+	}
+	if !r.StartTime.IsZero() && record.Timestamp.Before(r.StartTime) {
+		return false
+	}
+	if !r.EndTime.IsZero() && record.Timestamp.After(r.EndTime) {
+		return false
+	}
+	return true
+}
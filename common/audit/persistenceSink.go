@@ -0,0 +1,326 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/backoff"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+const (
+	// records are filtered after they are read, so read more than a page from the queue at once
+	persistenceSinkReadBatchSize = 1000
+	// every enqueue is a read of the last message ID followed by a conditional insert into a single
+	// partition, so records are buffered and written in batches by a single goroutine per host
+	persistenceSinkBufferSize    = 10000
+	persistenceSinkMaxBatchSize  = 100
+	persistenceSinkFlushInterval = time.Second
+
+	persistenceSinkRetryInitialInterval = 50 * time.Millisecond
+	persistenceSinkRetryMaxInterval     = 5 * time.Second
+	persistenceSinkRetryExpiration      = time.Minute
+
+	// message IDs follow the enqueue order, but a message may be stored after messages stamped
+	// later as long as its enqueue call is still in flight
+	persistenceSinkEnqueueTimeSkew = time.Minute
+)
+
+var errBufferFull = errors.New("audit record buffer is full")
+
+type (
+	persistenceSink struct {
+		status       int32
+		queue        persistence.Queue
+		metricsScope metrics.Scope
+		logger       log.Logger
+		retryPolicy  backoff.RetryPolicy
+		recordsCh    chan *Record
+		shutdownCh   chan struct{}
+		shutdownWG   sync.WaitGroup
+	}
+
+	// persistenceSinkBatch is the payload of a queue message, messages written before records were
+	// batched hold a single record instead
+	persistenceSinkBatch struct {
+		EnqueueTime time.Time `json:"enqueueTime"`
+		Records     []*Record `json:"records"`
+	}
+
+	// persistenceSinkPageToken points at the first record not returned yet, Skip is its index
+	// within the message following LastMessageID
+	persistenceSinkPageToken struct {
+		LastMessageID int
+		Skip          int
+	}
+)
+
+var _ Sink = (*persistenceSink)(nil)
+
+// NewPersistenceSink creates a sink which stores records in the given persistence queue. Records
+// are written asynchronously, records which can't be written are counted by the
+// audit_records_dropped metric and logged.
+func NewPersistenceSink(
+	queue persistence.Queue,
+	metricsClient metrics.Client,
+	logger log.Logger,
+) Sink {
+	retryPolicy := backoff.NewExponentialRetryPolicy(persistenceSinkRetryInitialInterval)
+	retryPolicy.SetMaximumInterval(persistenceSinkRetryMaxInterval)
+	retryPolicy.SetExpirationInterval(persistenceSinkRetryExpiration)
+
+	sink := &persistenceSink{
+		status:       common.DaemonStatusStarted,
+		queue:        queue,
+		metricsScope: metricsClient.Scope(metrics.AuditLogScope),
+		logger:       logger,
+		retryPolicy:  retryPolicy,
+		recordsCh:    make(chan *Record, persistenceSinkBufferSize),
+		shutdownCh:   make(chan struct{}),
+	}
+	sink.shutdownWG.Add(1)
+	go sink.writeLoop()
+	return sink
+}
+
+// Write buffers the record without blocking the audited call, it fails if the buffer is full and
+// leaves logging the dropped record to the caller
+func (s *persistenceSink) Write(record *Record) error {
+	select {
+	case s.recordsCh <- record:
+		return nil
+	default:
+		s.metricsScope.IncCounter(metrics.AuditRecordsDroppedCount)
+		return errBufferFull
+	}
+}
+
+func (s *persistenceSink) List(request *ListRequest) (*ListResponse, error) {
+	token := &persistenceSinkPageToken{LastMessageID: -1}
+	if len(request.NextPageToken) != 0 {
+		if err := json.Unmarshal(request.NextPageToken, token); err != nil {
+			return nil, ErrInvalidNextPageToken
+		}
+	} else if !request.StartTime.IsZero() {
+		lastMessageID, err := s.seekBefore(request.StartTime.Add(-persistenceSinkEnqueueTimeSkew))
+		if err != nil {
+			return nil, err
+		}
+		token.LastMessageID = lastMessageID
+	}
+	pageSize := request.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultListPageSize
+	}
+
+	response := &ListResponse{}
+	lastMessageID, skip := token.LastMessageID, token.Skip
+	for {
+		messages, err := s.queue.ReadMessages(lastMessageID, persistenceSinkReadBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, message := range messages {
+			batch, err := decodeBatch(message)
+			if err != nil {
+				return nil, err
+			}
+			for i := skip; i < len(batch.Records); i++ {
+				record := batch.Records[i]
+				if !request.Matches(record) {
+					continue
+				}
+				if len(response.Records) == pageSize {
+					nextPageToken, err := json.Marshal(&persistenceSinkPageToken{LastMessageID: lastMessageID, Skip: i})
+					if err != nil {
+						return nil, err
+					}
+					response.NextPageToken = nextPageToken
+					return response, nil
+				}
+				response.Records = append(response.Records, record)
+			}
+			skip = 0
+			lastMessageID = message.ID
+		}
+
+		if len(messages) < persistenceSinkReadBatchSize {
+			return response, nil
+		}
+	}
+}
+
+// Close writes the buffered records and closes the queue
+func (s *persistenceSink) Close() {
+	if !atomic.CompareAndSwapInt32(&s.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+	close(s.shutdownCh)
+	s.shutdownWG.Wait()
+	s.queue.Close()
+}
+
+func (s *persistenceSink) writeLoop() {
+	defer s.shutdownWG.Done()
+
+	ticker := time.NewTicker(persistenceSinkFlushInterval)
+	defer ticker.Stop()
+
+	var batch []*Record
+	for {
+		select {
+		case record := <-s.recordsCh:
+			batch = append(batch, record)
+			if len(batch) >= persistenceSinkMaxBatchSize {
+				s.flush(batch)
+				batch = nil
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				s.flush(batch)
+				batch = nil
+			}
+		case <-s.shutdownCh:
+			for {
+				select {
+				case record := <-s.recordsCh:
+					batch = append(batch, record)
+					if len(batch) >= persistenceSinkMaxBatchSize {
+						s.flush(batch)
+						batch = nil
+					}
+				default:
+					if len(batch) > 0 {
+						s.flush(batch)
+					}
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *persistenceSink) flush(records []*Record) {
+	sw := s.metricsScope.StartTimer(metrics.AuditWriteLatency)
+	defer sw.Stop()
+
+	op := func() error {
+		data, err := json.Marshal(&persistenceSinkBatch{EnqueueTime: time.Now().UTC(), Records: records})
+		if err != nil {
+			return err
+		}
+		return s.queue.EnqueueMessage(data)
+	}
+	if err := backoff.Retry(op, s.retryPolicy, isRetryableEnqueueError); err != nil {
+		s.drop(records, err)
+		return
+	}
+	s.metricsScope.AddCounter(metrics.AuditRecordsWrittenCount, int64(len(records)))
+}
+
+func (s *persistenceSink) drop(records []*Record, err error) {
+	s.metricsScope.AddCounter(metrics.AuditRecordsDroppedCount, int64(len(records)))
+	s.logger.Error("Dropped audit records.", tag.Counter(len(records)), tag.Error(err))
+}
+
+// seekBefore returns the ID of the last message enqueued before the given time, or -1 if there is
+// none. Message IDs follow the enqueue order, so the queue is bisected by reading single messages.
+func (s *persistenceSink) seekBefore(t time.Time) (int, error) {
+	// next returns the ID of the message following the given ID if it was enqueued before t, -1 otherwise
+	next := func(messageID int) (int, error) {
+		messages, err := s.queue.ReadMessages(messageID, 1)
+		if err != nil || len(messages) == 0 {
+			return -1, err
+		}
+		batch, err := decodeBatch(messages[0])
+		if err != nil || !batch.EnqueueTime.Before(t) {
+			return -1, err
+		}
+		return messages[0].ID, nil
+	}
+
+	low := -1
+	result, err := next(low)
+	if err != nil || result == -1 {
+		return -1, err
+	}
+	// next(low) is always a message enqueued before t, find a high for which it is not
+	high := low + 1
+	for step := 1; ; step *= 2 {
+		messageID, err := next(high)
+		if err != nil {
+			return -1, err
+		}
+		if messageID == -1 {
+			break
+		}
+		low, result = high, messageID
+		high = low + step
+	}
+	for high-low > 1 {
+		mid := low + (high-low)/2
+		messageID, err := next(mid)
+		if err != nil {
+			return -1, err
+		}
+		if messageID == -1 {
+			high = mid
+		} else {
+			low, result = mid, messageID
+		}
+	}
+	return result, nil
+}
+
+func isRetryableEnqueueError(err error) bool {
+	// another host enqueued a message with the same ID
+	if _, ok := err.(*persistence.ConditionFailedError); ok {
+		return true
+	}
+	return common.IsPersistenceTransientError(err)
+}
+
+func decodeBatch(message *persistence.QueueMessage) (*persistenceSinkBatch, error) {
+	batch := &persistenceSinkBatch{}
+	if err := json.Unmarshal(message.Payload, batch); err != nil {
+		return nil, fmt.Errorf("failed to decode audit records %v: %v", message.ID, err)
+	}
+	if batch.Records == nil {
+		record := &Record{}
+		if err := json.Unmarshal(message.Payload, record); err != nil {
+			return nil, fmt.Errorf("failed to decode audit record %v: %v", message.ID, err)
+		}
+		batch.EnqueueTime = record.Timestamp
+		batch.Records = []*Record{record}
+	}
+	return batch, nil
+}
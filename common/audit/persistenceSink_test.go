@@ -0,0 +1,232 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+
+	workflow "github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/common/backoff"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	persistenceSinkSuite struct {
+		suite.Suite
+		metricsScope tally.TestScope
+	}
+
+	// memoryQueue implements the subset of persistence.Queue used by the persistence sink
+	memoryQueue struct {
+		persistence.Queue
+		sync.Mutex
+		messages    []*persistence.QueueMessage
+		reads       int
+		enqueueErrs []error
+	}
+)
+
+func TestPersistenceSinkSuite(t *testing.T) {
+	suite.Run(t, new(persistenceSinkSuite))
+}
+
+func (s *persistenceSinkSuite) SetupTest() {
+	s.metricsScope = tally.NewTestScope("test", nil)
+}
+
+func (q *memoryQueue) EnqueueMessage(payload []byte) error {
+	q.Lock()
+	defer q.Unlock()
+
+	if len(q.enqueueErrs) != 0 {
+		err := q.enqueueErrs[0]
+		q.enqueueErrs = q.enqueueErrs[1:]
+		return err
+	}
+	q.messages = append(q.messages, &persistence.QueueMessage{ID: len(q.messages), Payload: payload})
+	return nil
+}
+
+func (q *memoryQueue) ReadMessages(lastMessageID int, maxCount int) ([]*persistence.QueueMessage, error) {
+	q.Lock()
+	defer q.Unlock()
+
+	q.reads++
+	var result []*persistence.QueueMessage
+	for _, message := range q.messages {
+		if message.ID > lastMessageID && len(result) < maxCount {
+			result = append(result, message)
+		}
+	}
+	return result, nil
+}
+
+func (q *memoryQueue) Close() {}
+
+func (s *persistenceSinkSuite) TestWriteAndList() {
+	queue := &memoryQueue{}
+	sink := s.newSink(queue)
+
+	now := time.Now().UTC()
+	var records []*Record
+	for i := 0; i < persistenceSinkMaxBatchSize*2+10; i++ {
+		record := &Record{Timestamp: now.Add(time.Duration(i) * time.Second), API: "API", Domain: "domain" + strconv.Itoa(i%2)}
+		records = append(records, record)
+		s.NoError(sink.Write(record))
+	}
+	sink.Close()
+	s.Equal(int64(len(records)), s.counter("test.audit_records_written"))
+
+	result := s.listAll(s.newSink(queue), &ListRequest{Domain: "domain1", PageSize: 7})
+	s.Len(result, len(records)/2)
+	for i, record := range result {
+		s.Equal(records[2*i+1].Timestamp.UnixNano(), record.Timestamp.UnixNano())
+		s.Equal(records[2*i+1].Domain, record.Domain)
+	}
+}
+
+func (s *persistenceSinkSuite) TestList_SingleRecordMessages() {
+	queue := &memoryQueue{}
+	now := time.Now().UTC()
+	var records []*Record
+	for i := 0; i < persistenceSinkReadBatchSize+10; i++ {
+		record := &Record{Timestamp: now.Add(time.Duration(i) * time.Second), API: "API"}
+		records = append(records, record)
+		data, err := json.Marshal(record)
+		s.NoError(err)
+		s.NoError(queue.EnqueueMessage(data))
+	}
+
+	result := s.listAll(s.newSink(queue), &ListRequest{PageSize: 100})
+	s.Len(result, len(records))
+	s.True(records[len(records)-1].Timestamp.Equal(result[len(result)-1].Timestamp))
+}
+
+func (s *persistenceSinkSuite) TestList_StartTimeSeeksQueue() {
+	queue := &memoryQueue{}
+	start := time.Now().UTC()
+	for i := 0; i < 1000; i++ {
+		enqueueTime := start.Add(time.Duration(i) * time.Hour)
+		data, err := json.Marshal(&persistenceSinkBatch{
+			EnqueueTime: enqueueTime,
+			Records:     []*Record{{Timestamp: enqueueTime, API: strconv.Itoa(i)}},
+		})
+		s.NoError(err)
+		s.NoError(queue.EnqueueMessage(data))
+	}
+	sink := s.newSink(queue)
+
+	messageID, err := sink.(*persistenceSink).seekBefore(start.Add(500 * time.Hour))
+	s.NoError(err)
+	s.Equal(499, messageID)
+	messageID, err = sink.(*persistenceSink).seekBefore(start)
+	s.NoError(err)
+	s.Equal(-1, messageID)
+	messageID, err = sink.(*persistenceSink).seekBefore(start.Add(5000 * time.Hour))
+	s.NoError(err)
+	s.Equal(999, messageID)
+
+	queue.reads = 0
+	result := s.listAll(sink, &ListRequest{StartTime: start.Add(990 * time.Hour), PageSize: 100})
+	s.Len(result, 10)
+	s.Equal("990", result[0].API)
+	s.True(queue.reads < 30)
+}
+
+func (s *persistenceSinkSuite) TestWrite_RetriesConflicts() {
+	queue := &memoryQueue{enqueueErrs: []error{
+		&persistence.ConditionFailedError{Msg: "message ID exists"},
+		&workflow.ServiceBusyError{},
+	}}
+	sink := s.newSink(queue)
+	s.NoError(sink.Write(&Record{API: "API"}))
+	sink.Close()
+
+	s.Len(queue.messages, 1)
+	s.Equal(int64(0), s.counter("test.audit_records_dropped"))
+}
+
+func (s *persistenceSinkSuite) TestWrite_CountsDroppedRecords() {
+	queue := &memoryQueue{enqueueErrs: []error{&workflow.BadRequestError{}}}
+	sink := s.newSink(queue)
+	s.NoError(sink.Write(&Record{API: "API"}))
+	s.NoError(sink.Write(&Record{API: "API"}))
+	sink.Close()
+
+	s.Empty(queue.messages)
+	s.Equal(int64(2), s.counter("test.audit_records_dropped"))
+}
+
+func (s *persistenceSinkSuite) TestWrite_BufferFull() {
+	sink := &persistenceSink{
+		metricsScope: metrics.NewClient(s.metricsScope, metrics.Common).Scope(metrics.AuditLogScope),
+		logger:       loggerimpl.NewNopLogger(),
+		recordsCh:    make(chan *Record, 1),
+	}
+	s.NoError(sink.Write(&Record{API: "API"}))
+	s.Equal(errBufferFull, sink.Write(&Record{API: "API"}))
+	s.Equal(int64(1), s.counter("test.audit_records_dropped"))
+}
+
+func (s *persistenceSinkSuite) TestList_InvalidToken() {
+	sink := s.newSink(&memoryQueue{})
+	defer sink.Close()
+	_, err := sink.List(&ListRequest{NextPageToken: []byte("invalid")})
+	s.Equal(ErrInvalidNextPageToken, err)
+}
+
+func (s *persistenceSinkSuite) newSink(queue *memoryQueue) Sink {
+	sink := NewPersistenceSink(queue, metrics.NewClient(s.metricsScope, metrics.Common), loggerimpl.NewNopLogger())
+	sink.(*persistenceSink).retryPolicy = backoff.NewExponentialRetryPolicy(time.Millisecond)
+	sink.(*persistenceSink).retryPolicy.(*backoff.ExponentialRetryPolicy).SetMaximumAttempts(3)
+	return sink
+}
+
+func (s *persistenceSinkSuite) listAll(sink Sink, request *ListRequest) []*Record {
+	var result []*Record
+	for {
+		resp, err := sink.List(request)
+		s.NoError(err)
+		result = append(result, resp.Records...)
+		request.NextPageToken = resp.NextPageToken
+		if len(request.NextPageToken) == 0 {
+			return result
+		}
+	}
+}
+
+func (s *persistenceSinkSuite) counter(name string) int64 {
+	for _, counter := range s.metricsScope.Snapshot().Counters() {
+		if counter.Name() == name {
+			return counter.Value()
+		}
+	}
+	return 0
+}
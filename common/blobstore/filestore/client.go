@@ -0,0 +1,158 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package filestore
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/temporalio/temporal/common/blobstore"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+type (
+	client struct {
+		directory string
+		fileMode  os.FileMode
+		dirMode   os.FileMode
+	}
+)
+
+var (
+	errEmptyDirectory  = errors.New("blob store directory is empty")
+	errInvalidFileMode = errors.New("invalid file mode")
+	errInvalidDirMode  = errors.New("invalid directory mode")
+)
+
+var _ blobstore.Client = (*client)(nil)
+
+// NewClient creates a blob store client that keeps every blob in a file under the configured directory
+func NewClient(
+	config *config.FilestoreBlobStore,
+) (blobstore.Client, error) {
+
+	if config.Directory == "" {
+		return nil, errEmptyDirectory
+	}
+	fileMode, err := strconv.ParseUint(config.FileMode, 0, 32)
+	if err != nil {
+		return nil, errInvalidFileMode
+	}
+	dirMode, err := strconv.ParseUint(config.DirMode, 0, 32)
+	if err != nil {
+		return nil, errInvalidDirMode
+	}
+	return &client{
+		directory: config.Directory,
+		fileMode:  os.FileMode(fileMode),
+		dirMode:   os.FileMode(dirMode),
+	}, nil
+}
+
+func (c *client) Put(
+	_ context.Context,
+	key string,
+	blob []byte,
+) error {
+
+	if err := blobstore.ValidateKey(key); err != nil {
+		return err
+	}
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), c.dirMode); err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that readers never observe a partially written blob
+	tmpFile, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name()) // nolint:errcheck
+
+	if _, err := tmpFile.Write(blob); err != nil {
+		tmpFile.Close() // nolint:errcheck
+		return err
+	}
+	if err := tmpFile.Chmod(c.fileMode); err != nil {
+		tmpFile.Close() // nolint:errcheck
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), path)
+}
+
+func (c *client) Get(
+	_ context.Context,
+	key string,
+) ([]byte, error) {
+
+	if err := blobstore.ValidateKey(key); err != nil {
+		return nil, err
+	}
+	// #nosec
+	blob, err := ioutil.ReadFile(c.path(key))
+	if os.IsNotExist(err) {
+		return nil, blobstore.ErrBlobNotFound
+	}
+	return blob, err
+}
+
+func (c *client) DeletePrefix(
+	_ context.Context,
+	prefix string,
+) error {
+
+	if err := blobstore.ValidatePrefix(prefix); err != nil {
+		return err
+	}
+
+	// a prefix ending with a slash is a directory, otherwise it is a file name prefix within its directory
+	if strings.HasSuffix(prefix, "/") {
+		return os.RemoveAll(c.path(prefix))
+	}
+	matches, err := filepath.Glob(escapeGlob(c.path(prefix)) + "*")
+	if err != nil {
+		return err
+	}
+	for _, match := range matches {
+		if err := os.RemoveAll(match); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *client) path(key string) string {
+	return filepath.Join(c.directory, filepath.FromSlash(key))
+}
+
+func escapeGlob(path string) string {
+	replacer := strings.NewReplacer("*", `\*`, "?", `\?`, "[", `\[`)
+	return replacer.Replace(path)
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package filestore
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common/blobstore"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+type clientSuite struct {
+	*require.Assertions
+	suite.Suite
+
+	directory string
+	client    blobstore.Client
+}
+
+func TestClientSuite(t *testing.T) {
+	suite.Run(t, new(clientSuite))
+}
+
+func (s *clientSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	directory, err := ioutil.TempDir("", "TestBlobStore")
+	s.NoError(err)
+	s.directory = directory
+	s.client, err = NewClient(&config.FilestoreBlobStore{
+		Directory: directory,
+		FileMode:  "0600",
+		DirMode:   "0700",
+	})
+	s.NoError(err)
+}
+
+func (s *clientSuite) TearDownTest() {
+	os.RemoveAll(s.directory) // nolint:errcheck
+}
+
+func (s *clientSuite) TestNewClient_InvalidConfig() {
+	_, err := NewClient(&config.FilestoreBlobStore{FileMode: "0600", DirMode: "0700"})
+	s.Equal(errEmptyDirectory, err)
+	_, err = NewClient(&config.FilestoreBlobStore{Directory: s.directory, FileMode: "rw", DirMode: "0700"})
+	s.Equal(errInvalidFileMode, err)
+	_, err = NewClient(&config.FilestoreBlobStore{Directory: s.directory, FileMode: "0600", DirMode: "rwx"})
+	s.Equal(errInvalidDirMode, err)
+}
+
+func (s *clientSuite) TestPutGet() {
+	ctx := context.Background()
+	_, err := s.client.Get(ctx, "tree/branch/1/Input")
+	s.Equal(blobstore.ErrBlobNotFound, err)
+
+	s.NoError(s.client.Put(ctx, "tree/branch/1/Input", []byte("first")))
+	s.NoError(s.client.Put(ctx, "tree/branch/1/Input", []byte("second")))
+	blob, err := s.client.Get(ctx, "tree/branch/1/Input")
+	s.NoError(err)
+	s.Equal([]byte("second"), blob)
+
+	info, err := os.Stat(filepath.Join(s.directory, "tree", "branch", "1", "Input"))
+	s.NoError(err)
+	s.Equal(os.FileMode(0600), info.Mode())
+	files, err := ioutil.ReadDir(filepath.Join(s.directory, "tree", "branch", "1"))
+	s.NoError(err)
+	s.Len(files, 1)
+}
+
+func (s *clientSuite) TestInvalidKey() {
+	ctx := context.Background()
+	s.Equal(blobstore.ErrInvalidKey, s.client.Put(ctx, "../escape", []byte("blob")))
+	_, err := s.client.Get(ctx, "/etc/passwd")
+	s.Equal(blobstore.ErrInvalidKey, err)
+	s.Equal(blobstore.ErrInvalidKey, s.client.DeletePrefix(ctx, ""))
+}
+
+func (s *clientSuite) TestDeletePrefix() {
+	ctx := context.Background()
+	keys := []string{"tree-1/branch/1/Input", "tree-1/branch/2/Result", "tree-10/branch/1/Input", "tree-2/branch/1/Input"}
+	for _, key := range keys {
+		s.NoError(s.client.Put(ctx, key, []byte(key)))
+	}
+
+	s.NoError(s.client.DeletePrefix(ctx, "tree-1/"))
+	s.assertExists(map[string]bool{keys[0]: false, keys[1]: false, keys[2]: true, keys[3]: true})
+
+	s.NoError(s.client.DeletePrefix(ctx, "tree-"))
+	s.assertExists(map[string]bool{keys[2]: false, keys[3]: false})
+
+	s.NoError(s.client.DeletePrefix(ctx, "missing/"))
+}
+
+func (s *clientSuite) assertExists(expected map[string]bool) {
+	for key, exists := range expected {
+		_, err := s.client.Get(context.Background(), key)
+		if exists {
+			s.NoError(err, key)
+		} else {
+			s.Equal(blobstore.ErrBlobNotFound, err, key)
+		}
+	}
+}
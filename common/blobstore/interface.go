@@ -0,0 +1,45 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package blobstore
+
+import (
+	"context"
+	"errors"
+)
+
+type (
+	// Client is used to keep blobs in an external store, blobs are addressed by slash separated keys
+	Client interface {
+		// Put creates or overwrites the blob with the given key
+		Put(ctx context.Context, key string, blob []byte) error
+		// Get returns the blob with the given key, or ErrBlobNotFound
+		Get(ctx context.Context, key string) ([]byte, error)
+		// DeletePrefix deletes every blob whose key starts with the given prefix
+		DeletePrefix(ctx context.Context, prefix string) error
+	}
+)
+
+var (
+	// ErrBlobNotFound is returned when the requested blob does not exist
+	ErrBlobNotFound = errors.New("blob not found")
+	// ErrInvalidKey is returned when a key is empty or is not a relative slash separated path
+	ErrInvalidKey = errors.New("invalid blob key")
+)
@@ -0,0 +1,49 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package provider
+
+import (
+	"errors"
+
+	"github.com/temporalio/temporal/common/blobstore"
+	"github.com/temporalio/temporal/common/blobstore/filestore"
+	"github.com/temporalio/temporal/common/blobstore/s3store"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+var (
+	errInvalidBlobStoreConfig = errors.New("exactly one of filestore and s3 must be configured for a blob store")
+)
+
+// NewClient creates the blob store client selected by the config
+func NewClient(
+	config *config.BlobStore,
+) (blobstore.Client, error) {
+
+	switch {
+	case config.Filestore != nil && config.S3 == nil:
+		return filestore.NewClient(config.Filestore)
+	case config.S3 != nil && config.Filestore == nil:
+		return s3store.NewClient(config.S3)
+	default:
+		return nil, errInvalidBlobStoreConfig
+	}
+}
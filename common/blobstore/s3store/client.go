@@ -0,0 +1,283 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package s3store
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/temporalio/temporal/common/blobstore"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+const (
+	// emptyPayloadHash is the SHA256 of an empty body
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	maxErrorBodySize = 1024
+)
+
+type (
+	client struct {
+		endpoint   *url.URL
+		bucket     string
+		keyPrefix  string
+		signer     *signer
+		httpClient *http.Client
+		now        func() time.Time
+	}
+
+	listBucketResult struct {
+		Contents []struct {
+			Key string `xml:"Key"`
+		} `xml:"Contents"`
+		IsTruncated           bool   `xml:"IsTruncated"`
+		NextContinuationToken string `xml:"NextContinuationToken"`
+	}
+)
+
+var (
+	errEmptyEndpoint    = errors.New("blob store endpoint is empty")
+	errEmptyBucket      = errors.New("blob store bucket is empty")
+	errEmptyRegion      = errors.New("blob store region is empty")
+	errEmptyCredentials = errors.New("blob store credentials are not set")
+)
+
+var _ blobstore.Client = (*client)(nil)
+
+// NewClient creates a blob store client for an S3 compatible service,
+// objects are addressed path style, i.e. <endpoint>/<bucket>/<key prefix><key>
+func NewClient(
+	config *config.S3BlobStore,
+) (blobstore.Client, error) {
+
+	return newClient(config, http.DefaultClient)
+}
+
+func newClient(
+	config *config.S3BlobStore,
+	httpClient *http.Client,
+) (*client, error) {
+
+	if config.Endpoint == "" {
+		return nil, errEmptyEndpoint
+	}
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if config.Bucket == "" {
+		return nil, errEmptyBucket
+	}
+	if config.Region == "" {
+		return nil, errEmptyRegion
+	}
+	accessKeyID := config.AccessKeyID
+	if accessKeyID == "" {
+		accessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+	}
+	secretAccessKey := config.SecretAccessKey
+	if secretAccessKey == "" {
+		secretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+	}
+	if accessKeyID == "" || secretAccessKey == "" {
+		return nil, errEmptyCredentials
+	}
+
+	return &client{
+		endpoint:  endpoint,
+		bucket:    config.Bucket,
+		keyPrefix: config.KeyPrefix,
+		signer: &signer{
+			accessKeyID:     accessKeyID,
+			secretAccessKey: secretAccessKey,
+			region:          config.Region,
+		},
+		httpClient: httpClient,
+		now:        time.Now,
+	}, nil
+}
+
+func (c *client) Put(
+	ctx context.Context,
+	key string,
+	blob []byte,
+) error {
+
+	if err := blobstore.ValidateKey(key); err != nil {
+		return err
+	}
+	response, err := c.do(ctx, http.MethodPut, c.keyPrefix+key, nil, blob)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close() // nolint:errcheck
+
+	if response.StatusCode != http.StatusOK {
+		return responseError(http.MethodPut, response)
+	}
+	return nil
+}
+
+func (c *client) Get(
+	ctx context.Context,
+	key string,
+) ([]byte, error) {
+
+	if err := blobstore.ValidateKey(key); err != nil {
+		return nil, err
+	}
+	response, err := c.do(ctx, http.MethodGet, c.keyPrefix+key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close() // nolint:errcheck
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return ioutil.ReadAll(response.Body)
+	case http.StatusNotFound:
+		return nil, blobstore.ErrBlobNotFound
+	default:
+		return nil, responseError(http.MethodGet, response)
+	}
+}
+
+func (c *client) DeletePrefix(
+	ctx context.Context,
+	prefix string,
+) error {
+
+	if err := blobstore.ValidatePrefix(prefix); err != nil {
+		return err
+	}
+
+	continuationToken := ""
+	for {
+		result, err := c.list(ctx, c.keyPrefix+prefix, continuationToken)
+		if err != nil {
+			return err
+		}
+		for _, object := range result.Contents {
+			if err := c.delete(ctx, object.Key); err != nil {
+				return err
+			}
+		}
+		if !result.IsTruncated {
+			return nil
+		}
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+func (c *client) list(
+	ctx context.Context,
+	prefix string,
+	continuationToken string,
+) (*listBucketResult, error) {
+
+	query := url.Values{}
+	query.Set("list-type", "2")
+	query.Set("prefix", prefix)
+	if continuationToken != "" {
+		query.Set("continuation-token", continuationToken)
+	}
+	response, err := c.do(ctx, http.MethodGet, "", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close() // nolint:errcheck
+
+	if response.StatusCode != http.StatusOK {
+		return nil, responseError("List", response)
+	}
+	result := &listBucketResult{}
+	if err := xml.NewDecoder(response.Body).Decode(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *client) delete(
+	ctx context.Context,
+	objectKey string,
+) error {
+
+	response, err := c.do(ctx, http.MethodDelete, objectKey, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close() // nolint:errcheck
+
+	// deleting a missing object is not an error
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNotFound {
+		return responseError(http.MethodDelete, response)
+	}
+	return nil
+}
+
+func (c *client) do(
+	ctx context.Context,
+	method string,
+	objectKey string,
+	query url.Values,
+	body []byte,
+) (*http.Response, error) {
+
+	requestURL := *c.endpoint
+	requestURL.Path = strings.TrimSuffix(requestURL.Path, "/") + "/" + c.bucket
+	if objectKey != "" {
+		requestURL.Path += "/" + objectKey
+	}
+	requestURL.RawPath = escapePath(requestURL.Path)
+	requestURL.RawQuery = canonicalQuery(query)
+
+	var bodyReader io.Reader
+	payloadHash := emptyPayloadHash
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+		payloadHash = hashSHA256(body)
+	}
+	request, err := http.NewRequest(method, requestURL.String(), bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	c.signer.sign(request, payloadHash, c.now())
+	return c.httpClient.Do(request.WithContext(ctx))
+}
+
+func responseError(
+	operation string,
+	response *http.Response,
+) error {
+
+	message, _ := ioutil.ReadAll(io.LimitReader(response.Body, maxErrorBodySize))
+	return fmt.Errorf("blob store %v failed with status %v: %s", operation, response.Status, message)
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package s3store
+
+import (
+	"context"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common/blobstore"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+type (
+	clientSuite struct {
+		*require.Assertions
+		suite.Suite
+
+		server  *httptest.Server
+		objects map[string][]byte
+		client  blobstore.Client
+	}
+)
+
+const (
+	testBucket    = "test-bucket"
+	testKeyPrefix = "payloads/"
+)
+
+func TestClientSuite(t *testing.T) {
+	suite.Run(t, new(clientSuite))
+}
+
+func (s *clientSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.objects = make(map[string][]byte)
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveS3))
+	client, err := newClient(&config.S3BlobStore{
+		Endpoint:        s.server.URL,
+		Region:          "us-east-1",
+		Bucket:          testBucket,
+		KeyPrefix:       testKeyPrefix,
+		AccessKeyID:     "access-key",
+		SecretAccessKey: "secret-key",
+	}, s.server.Client())
+	s.NoError(err)
+	s.client = client
+}
+
+func (s *clientSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *clientSuite) TestNewClient_InvalidConfig() {
+	_, err := NewClient(&config.S3BlobStore{Region: "us-east-1", Bucket: testBucket})
+	s.Equal(errEmptyEndpoint, err)
+	_, err = NewClient(&config.S3BlobStore{Endpoint: s.server.URL, Region: "us-east-1"})
+	s.Equal(errEmptyBucket, err)
+	_, err = NewClient(&config.S3BlobStore{Endpoint: s.server.URL, Bucket: testBucket})
+	s.Equal(errEmptyRegion, err)
+}
+
+func (s *clientSuite) TestPutGet() {
+	ctx := context.Background()
+	_, err := s.client.Get(ctx, "tree/branch/1/Input")
+	s.Equal(blobstore.ErrBlobNotFound, err)
+
+	s.NoError(s.client.Put(ctx, "tree/branch/1/Input", []byte("payload")))
+	s.Equal([]byte("payload"), s.objects[testKeyPrefix+"tree/branch/1/Input"])
+	blob, err := s.client.Get(ctx, "tree/branch/1/Input")
+	s.NoError(err)
+	s.Equal([]byte("payload"), blob)
+
+	s.Equal(blobstore.ErrInvalidKey, s.client.Put(ctx, "../escape", nil))
+}
+
+func (s *clientSuite) TestDeletePrefix() {
+	ctx := context.Background()
+	keys := []string{"tree-1/branch/1/Input", "tree-1/branch/2/Result", "tree-1/branch/3/Details", "tree-2/branch/1/Input"}
+	for _, key := range keys {
+		s.NoError(s.client.Put(ctx, key, []byte(key)))
+	}
+
+	s.NoError(s.client.DeletePrefix(ctx, "tree-1/"))
+	s.Len(s.objects, 1)
+	s.Contains(s.objects, testKeyPrefix+keys[3])
+}
+
+func (s *clientSuite) TestErrorResponse() {
+	s.server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("AccessDenied")) // nolint:errcheck
+	})
+	err := s.client.Put(context.Background(), "key", []byte("payload"))
+	s.Error(err)
+	s.Contains(err.Error(), "AccessDenied")
+}
+
+// serveS3 is a minimal in memory S3, listing returns at most two keys per page to exercise continuation
+func (s *clientSuite) serveS3(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get(headerAuthorization), signingAlgorithm+" Credential=access-key/") ||
+		r.Header.Get(headerAmzContentSHA256) == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	bucketPrefix := "/" + testBucket
+	if !strings.HasPrefix(r.URL.Path, bucketPrefix) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, bucketPrefix), "/")
+
+	switch {
+	case r.Method == http.MethodPut:
+		body, err := ioutil.ReadAll(r.Body)
+		s.NoError(err)
+		s.Equal(hashSHA256(body), r.Header.Get(headerAmzContentSHA256))
+		s.objects[key] = body
+	case r.Method == http.MethodGet && key == "":
+		s.Equal("2", r.URL.Query().Get("list-type"))
+		var keys []string
+		for objectKey := range s.objects {
+			if strings.HasPrefix(objectKey, r.URL.Query().Get("prefix")) {
+				keys = append(keys, objectKey)
+			}
+		}
+		sort.Strings(keys)
+		// like S3 the continuation token is the last listed key, so deleting listed keys does not skip any
+		start := sort.SearchStrings(keys, r.URL.Query().Get("continuation-token")+"\x00")
+		result := listBucketResult{}
+		for i := start; i < len(keys) && i < start+2; i++ {
+			result.Contents = append(result.Contents, struct {
+				Key string `xml:"Key"`
+			}{Key: keys[i]})
+		}
+		if start+2 < len(keys) {
+			result.IsTruncated = true
+			result.NextContinuationToken = keys[start+1]
+		}
+		s.NoError(xml.NewEncoder(w).Encode(result))
+	case r.Method == http.MethodGet:
+		object, ok := s.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(object) // nolint:errcheck
+	case r.Method == http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
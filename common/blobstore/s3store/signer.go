@@ -0,0 +1,171 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package s3store
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	signingAlgorithm   = "AWS4-HMAC-SHA256"
+	signingService     = "s3"
+	amzDateFormat      = "20060102T150405Z"
+	amzShortDateFormat = "20060102"
+
+	headerAmzDate          = "X-Amz-Date"
+	headerAmzContentSHA256 = "X-Amz-Content-Sha256"
+	headerAuthorization    = "Authorization"
+)
+
+type (
+	// signer signs requests with AWS signature version 4
+	signer struct {
+		accessKeyID     string
+		secretAccessKey string
+		region          string
+	}
+)
+
+// sign adds the date, payload hash and authorization headers to the request,
+// every other header already set on the request is signed as well
+func (s *signer) sign(
+	request *http.Request,
+	payloadHash string,
+	now time.Time,
+) {
+
+	now = now.UTC()
+	request.Header.Set(headerAmzDate, now.Format(amzDateFormat))
+	request.Header.Set(headerAmzContentSHA256, payloadHash)
+
+	headerNames := []string{"host"}
+	canonicalHeaders := map[string]string{"host": request.URL.Host}
+	for name, values := range request.Header {
+		name = strings.ToLower(name)
+		if name == "authorization" {
+			continue
+		}
+		headerNames = append(headerNames, name)
+		canonicalHeaders[name] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	sort.Strings(headerNames)
+
+	var headers strings.Builder
+	for _, name := range headerNames {
+		headers.WriteString(name + ":" + canonicalHeaders[name] + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		escapePath(request.URL.Path),
+		canonicalQuery(request.URL.Query()),
+		headers.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{now.Format(amzShortDateFormat), s.region, signingService, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		signingAlgorithm,
+		now.Format(amzDateFormat),
+		scope,
+		hashSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.secretAccessKey), now.Format(amzShortDateFormat))
+	signingKey = hmacSHA256(signingKey, s.region)
+	signingKey = hmacSHA256(signingKey, signingService)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	request.Header.Set(headerAuthorization, fmt.Sprintf(
+		"%v Credential=%v/%v, SignedHeaders=%v, Signature=%v",
+		signingAlgorithm,
+		s.accessKeyID,
+		scope,
+		signedHeaders,
+		signature,
+	))
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var params []string
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			params = append(params, escape(key)+"="+escape(value))
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// escapePath URI encodes every path segment, keeping the slashes
+func escapePath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = escape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// escape URI encodes everything but the unreserved characters, as required by signature version 4
+func escape(value string) string {
+	var escaped strings.Builder
+	for _, b := range []byte(value) {
+		if ('A' <= b && b <= 'Z') || ('a' <= b && b <= 'z') || ('0' <= b && b <= '9') ||
+			b == '-' || b == '_' || b == '.' || b == '~' {
+			escaped.WriteByte(b)
+		} else {
+			fmt.Fprintf(&escaped, "%%%02X", b)
+		}
+	}
+	return escaped.String()
+}
+
+func hashSHA256(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data)) // nolint:errcheck
+	return mac.Sum(nil)
+}
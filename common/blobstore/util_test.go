@@ -0,0 +1,44 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package blobstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateKey(t *testing.T) {
+	for _, key := range []string{"a", "a/b", "tree-id/branch-id/12/Input"} {
+		require.NoError(t, ValidateKey(key), key)
+	}
+	for _, key := range []string{"", "/a", "a/", "a//b", "../a", "a/./b", "a/..", `a\b`} {
+		require.Equal(t, ErrInvalidKey, ValidateKey(key), key)
+	}
+}
+
+func TestValidatePrefix(t *testing.T) {
+	require.NoError(t, ValidatePrefix("a/"))
+	require.NoError(t, ValidatePrefix("a/b"))
+	require.Equal(t, ErrInvalidKey, ValidatePrefix(""))
+	require.Equal(t, ErrInvalidKey, ValidatePrefix("/"))
+	require.Equal(t, ErrInvalidKey, ValidatePrefix("../"))
+}
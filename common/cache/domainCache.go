@@ -692,6 +692,16 @@ func (entry *DomainCacheEntry) duplicate() *DomainCacheEntry {
 		VisibilityArchivalURI:    entry.config.VisibilityArchivalURI,
 		BadBinaries:              copyResetBinary(entry.config.BadBinaries),
 	}
+	if entry.config.SearchAttributes != nil {
+		result.config.SearchAttributes = make(map[string]string, len(entry.config.SearchAttributes))
+		for k, v := range entry.config.SearchAttributes {
+			result.config.SearchAttributes[k] = v
+		}
+	}
+	if entry.config.WorkflowDefaults != nil {
+		workflowDefaults := *entry.config.WorkflowDefaults
+		result.config.WorkflowDefaults = &workflowDefaults
+	}
 	result.replicationConfig = &persistence.DomainReplicationConfig{
 		ActiveClusterName: entry.replicationConfig.ActiveClusterName,
 	}
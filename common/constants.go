@@ -118,6 +118,8 @@ const (
 const (
 	// DefaultTransactionSizeLimit is the largest allowed transaction size to persistence
 	DefaultTransactionSizeLimit = 14 * 1024 * 1024
+	// DefaultHistoryPayloadOffloadThreshold is the default size above which history event payloads are offloaded
+	DefaultHistoryPayloadOffloadThreshold = 256 * 1024
 )
 
 const (
@@ -134,6 +136,7 @@ const (
 // Negative numbers are reserved for DLQ
 const (
 	DomainReplicationQueueType QueueType = 1
+	AuditLogQueueType          QueueType = 2
 )
 
 // enum for dynamic config AdvancedVisibilityWritingMode
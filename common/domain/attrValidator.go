@@ -22,12 +22,17 @@ package domain
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cluster"
+	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/persistence"
 )
 
+var domainSearchAttributeNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
 type (
 	// AttrValidatorImpl is domain attr validator
 	AttrValidatorImpl struct {
@@ -156,3 +161,82 @@ func (d *AttrValidatorImpl) validateClusterName(
 	}
 	return nil
 }
+
+// validateDomainSearchAttributes validates the domain search attributes, mapping
+// domain level names to the cluster search attributes they are stored in
+func (d *AttrValidatorImpl) validateDomainSearchAttributes(
+	searchAttributes map[string]string,
+	validSearchAttributes map[string]interface{},
+) error {
+
+	fieldsInUse := make(map[string]string, len(searchAttributes))
+	for name, field := range searchAttributes {
+		if !domainSearchAttributeNameRegex.MatchString(name) {
+			return &shared.BadRequestError{Message: fmt.Sprintf(
+				"Invalid domain search attribute name: %v",
+				name,
+			)}
+		}
+		if _, ok := validSearchAttributes[name]; ok {
+			return &shared.BadRequestError{Message: fmt.Sprintf(
+				"Domain search attribute %v conflicts with a cluster search attribute",
+				name,
+			)}
+		}
+		if _, ok := validSearchAttributes[field]; !ok || definition.IsSystemIndexedKey(field) {
+			return &shared.BadRequestError{Message: fmt.Sprintf(
+				"Domain search attribute %v is not mapped to a custom cluster search attribute: %v",
+				name,
+				field,
+			)}
+		}
+		if other, ok := fieldsInUse[field]; ok {
+			return &shared.BadRequestError{Message: fmt.Sprintf(
+				"Domain search attributes %v and %v are mapped to the same cluster search attribute: %v",
+				other,
+				name,
+				field,
+			)}
+		}
+		fieldsInUse[field] = name
+	}
+	return nil
+}
+
+// validateDomainWorkflowDefaults validates the workflow defaults of the domain, the defaults
+// must not exceed the maximums and the retry policies must be valid
+func (d *AttrValidatorImpl) validateDomainWorkflowDefaults(
+	defaults *persistence.DomainWorkflowDefaults,
+) error {
+
+	if defaults == nil {
+		return nil
+	}
+	if defaults.DefaultExecutionStartToCloseTimeoutSeconds < 0 ||
+		defaults.MaxExecutionStartToCloseTimeoutSeconds < 0 ||
+		defaults.DefaultTaskStartToCloseTimeoutSeconds < 0 ||
+		defaults.DefaultActivityScheduleToCloseTimeoutSeconds < 0 ||
+		defaults.MaxActivityScheduleToCloseTimeoutSeconds < 0 {
+		return &shared.BadRequestError{Message: "A workflow default timeout may not be negative."}
+	}
+	if defaults.MaxExecutionStartToCloseTimeoutSeconds > 0 &&
+		defaults.DefaultExecutionStartToCloseTimeoutSeconds > defaults.MaxExecutionStartToCloseTimeoutSeconds {
+		return &shared.BadRequestError{Message: fmt.Sprintf(
+			"Default execution timeout %v exceeds the maximum %v.",
+			defaults.DefaultExecutionStartToCloseTimeoutSeconds,
+			defaults.MaxExecutionStartToCloseTimeoutSeconds,
+		)}
+	}
+	if defaults.MaxActivityScheduleToCloseTimeoutSeconds > 0 &&
+		defaults.DefaultActivityScheduleToCloseTimeoutSeconds > defaults.MaxActivityScheduleToCloseTimeoutSeconds {
+		return &shared.BadRequestError{Message: fmt.Sprintf(
+			"Default activity timeout %v exceeds the maximum %v.",
+			defaults.DefaultActivityScheduleToCloseTimeoutSeconds,
+			defaults.MaxActivityScheduleToCloseTimeoutSeconds,
+		)}
+	}
+	if err := common.ValidateRetryPolicy(defaults.DefaultWorkflowRetryPolicy); err != nil {
+		return err
+	}
+	return common.ValidateRetryPolicy(defaults.DefaultActivityRetryPolicy)
+}
@@ -25,7 +25,9 @@ import (
 
 	"github.com/temporalio/temporal/.gen/go/shared"
 
+	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cluster"
+	"github.com/temporalio/temporal/common/definition"
 
 	"github.com/temporalio/temporal/common/persistence"
 
@@ -252,3 +254,88 @@ func (s *attrValidatorSuite) TestValidateDomainReplicationConfigClustersDoesNotR
 	)
 	s.IsType(&shared.BadRequestError{}, err)
 }
+
+func (s *attrValidatorSuite) TestValidateDomainSearchAttributes() {
+	validSearchAttributes := definition.GetDefaultIndexedKeys()
+
+	err := s.validator.validateDomainSearchAttributes(
+		map[string]string{"OrderID": "CustomKeywordField", "Priority": "CustomIntField"},
+		validSearchAttributes,
+	)
+	s.NoError(err)
+
+	err = s.validator.validateDomainSearchAttributes(map[string]string{}, validSearchAttributes)
+	s.NoError(err)
+
+	err = s.validator.validateDomainSearchAttributes(
+		map[string]string{"Order.ID": "CustomKeywordField"},
+		validSearchAttributes,
+	)
+	s.IsType(&shared.BadRequestError{}, err)
+
+	err = s.validator.validateDomainSearchAttributes(
+		map[string]string{"CustomIntField": "CustomKeywordField"},
+		validSearchAttributes,
+	)
+	s.IsType(&shared.BadRequestError{}, err)
+
+	err = s.validator.validateDomainSearchAttributes(
+		map[string]string{"OrderID": "UnknownField"},
+		validSearchAttributes,
+	)
+	s.IsType(&shared.BadRequestError{}, err)
+
+	err = s.validator.validateDomainSearchAttributes(
+		map[string]string{"Type": definition.WorkflowType},
+		validSearchAttributes,
+	)
+	s.IsType(&shared.BadRequestError{}, err)
+
+	err = s.validator.validateDomainSearchAttributes(
+		map[string]string{"OrderID": "CustomKeywordField", "CustomerID": "CustomKeywordField"},
+		validSearchAttributes,
+	)
+	s.IsType(&shared.BadRequestError{}, err)
+}
+
+func (s *attrValidatorSuite) TestValidateDomainWorkflowDefaults() {
+	err := s.validator.validateDomainWorkflowDefaults(nil)
+	s.NoError(err)
+
+	err = s.validator.validateDomainWorkflowDefaults(&persistence.DomainWorkflowDefaults{
+		DefaultExecutionStartToCloseTimeoutSeconds:   3600,
+		MaxExecutionStartToCloseTimeoutSeconds:       86400,
+		DefaultActivityScheduleToCloseTimeoutSeconds: 60,
+		DefaultWorkflowIDReusePolicy:                 shared.WorkflowIdReusePolicyRejectDuplicate.Ptr(),
+		DefaultActivityRetryPolicy: &shared.RetryPolicy{
+			InitialIntervalInSeconds: common.Int32Ptr(1),
+			BackoffCoefficient:       common.Float64Ptr(2),
+		},
+	})
+	s.NoError(err)
+
+	err = s.validator.validateDomainWorkflowDefaults(&persistence.DomainWorkflowDefaults{
+		DefaultTaskStartToCloseTimeoutSeconds: -1,
+	})
+	s.IsType(&shared.BadRequestError{}, err)
+
+	err = s.validator.validateDomainWorkflowDefaults(&persistence.DomainWorkflowDefaults{
+		DefaultExecutionStartToCloseTimeoutSeconds: 86400,
+		MaxExecutionStartToCloseTimeoutSeconds:     3600,
+	})
+	s.IsType(&shared.BadRequestError{}, err)
+
+	err = s.validator.validateDomainWorkflowDefaults(&persistence.DomainWorkflowDefaults{
+		DefaultActivityScheduleToCloseTimeoutSeconds: 600,
+		MaxActivityScheduleToCloseTimeoutSeconds:     60,
+	})
+	s.IsType(&shared.BadRequestError{}, err)
+
+	err = s.validator.validateDomainWorkflowDefaults(&persistence.DomainWorkflowDefaults{
+		DefaultWorkflowRetryPolicy: &shared.RetryPolicy{
+			InitialIntervalInSeconds: common.Int32Ptr(1),
+			BackoffCoefficient:       common.Float64Ptr(0.5),
+		},
+	})
+	s.IsType(&shared.BadRequestError{}, err)
+}
@@ -33,4 +33,9 @@ var (
 
 	errInvalidRetentionPeriod = &workflow.BadRequestError{Message: "A valid retention period is not set on request."}
 	errInvalidArchivalConfig  = &workflow.BadRequestError{Message: "Invalid to enable archival without specifying a uri."}
+	errSearchAttributesNotSet = &workflow.BadRequestError{Message: "Search attributes are not set on request."}
+
+	errDomainNotSet             = &workflow.BadRequestError{Message: "Domain not set on request."}
+	errDomainDeleted            = &workflow.BadRequestError{Message: "Domain is deleted."}
+	errCannotDeleteSystemDomain = &workflow.BadRequestError{Message: "Cannot delete a system domain."}
 )
@@ -34,6 +34,7 @@ import (
 
 	"github.com/temporalio/temporal/.gen/go/replicator"
 	"github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/adapter"
 	"github.com/temporalio/temporal/common/archiver"
@@ -68,18 +69,43 @@ type (
 			ctx context.Context,
 			updateRequest *workflowservice.UpdateDomainRequest,
 		) (*workflowservice.UpdateDomainResponse, error)
+		AddSearchAttributes(
+			ctx context.Context,
+			addRequest *adminservice.AddSearchAttributesRequest,
+		) (*adminservice.AddSearchAttributesResponse, error)
+		RemoveSearchAttributes(
+			ctx context.Context,
+			removeRequest *adminservice.RemoveSearchAttributesRequest,
+		) (*adminservice.RemoveSearchAttributesResponse, error)
+		ListSearchAttributes(
+			ctx context.Context,
+			listRequest *adminservice.ListSearchAttributesRequest,
+		) (*adminservice.ListSearchAttributesResponse, error)
+		DeleteDomain(
+			ctx context.Context,
+			deleteRequest *adminservice.DeleteDomainRequest,
+		) (*adminservice.DeleteDomainResponse, error)
+		UpdateDomainWorkflowDefaults(
+			ctx context.Context,
+			updateRequest *adminservice.UpdateDomainWorkflowDefaultsRequest,
+		) (*adminservice.UpdateDomainWorkflowDefaultsResponse, error)
+		DescribeDomainWorkflowDefaults(
+			ctx context.Context,
+			describeRequest *adminservice.DescribeDomainWorkflowDefaultsRequest,
+		) (*adminservice.DescribeDomainWorkflowDefaultsResponse, error)
 	}
 
 	// HandlerImpl is the domain operation handler implementation
 	HandlerImpl struct {
-		maxBadBinaryCount   dynamicconfig.IntPropertyFnWithDomainFilter
-		logger              log.Logger
-		metadataMgr         persistence.MetadataManager
-		clusterMetadata     cluster.Metadata
-		domainReplicator    Replicator
-		domainAttrValidator *AttrValidatorImpl
-		archivalMetadata    archiver.ArchivalMetadata
-		archiverProvider    provider.ArchiverProvider
+		maxBadBinaryCount     dynamicconfig.IntPropertyFnWithDomainFilter
+		validSearchAttributes dynamicconfig.MapPropertyFn
+		logger                log.Logger
+		metadataMgr           persistence.MetadataManager
+		clusterMetadata       cluster.Metadata
+		domainReplicator      Replicator
+		domainAttrValidator   *AttrValidatorImpl
+		archivalMetadata      archiver.ArchivalMetadata
+		archiverProvider      provider.ArchiverProvider
 	}
 )
 
@@ -89,6 +115,7 @@ var _ Handler = (*HandlerImpl)(nil)
 func NewHandler(
 	minRetentionDays int,
 	maxBadBinaryCount dynamicconfig.IntPropertyFnWithDomainFilter,
+	validSearchAttributes dynamicconfig.MapPropertyFn,
 	logger log.Logger,
 	metadataMgr persistence.MetadataManager,
 	clusterMetadata cluster.Metadata,
@@ -97,14 +124,15 @@ func NewHandler(
 	archiverProvider provider.ArchiverProvider,
 ) *HandlerImpl {
 	return &HandlerImpl{
-		maxBadBinaryCount:   maxBadBinaryCount,
-		logger:              logger,
-		metadataMgr:         metadataMgr,
-		clusterMetadata:     clusterMetadata,
-		domainReplicator:    domainReplicator,
-		domainAttrValidator: newAttrValidator(clusterMetadata, int32(minRetentionDays)),
-		archivalMetadata:    archivalMetadata,
-		archiverProvider:    archiverProvider,
+		maxBadBinaryCount:     maxBadBinaryCount,
+		validSearchAttributes: validSearchAttributes,
+		logger:                logger,
+		metadataMgr:           metadataMgr,
+		clusterMetadata:       clusterMetadata,
+		domainReplicator:      domainReplicator,
+		domainAttrValidator:   newAttrValidator(clusterMetadata, int32(minRetentionDays)),
+		archivalMetadata:      archivalMetadata,
+		archiverProvider:      archiverProvider,
 	}
 }
 
@@ -358,6 +386,10 @@ func (d *HandlerImpl) UpdateDomain(
 		return nil, err
 	}
 
+	if getResponse.Info.Status == persistence.DomainStatusDeleted {
+		return nil, errDomainDeleted
+	}
+
 	info := getResponse.Info
 	config := getResponse.Config
 	replicationConfig := getResponse.ReplicationConfig
@@ -597,6 +629,10 @@ func (d *HandlerImpl) DeprecateDomain(
 		return nil, err
 	}
 
+	if getResponse.Info.Status == persistence.DomainStatusDeleted {
+		return nil, errDomainDeleted
+	}
+
 	getResponse.ConfigVersion = getResponse.ConfigVersion + 1
 	getResponse.Info.Status = persistence.DomainStatusDeprecated
 	updateReq := &persistence.UpdateDomainRequest{
@@ -615,6 +651,319 @@ func (d *HandlerImpl) DeprecateDomain(
 	return nil, nil
 }
 
+// DeleteDomain marks the domain deleted, the data of the domain is then purged by the domain deleter.
+// A global domain can only be deleted from the master cluster while it is active in it, the deletion is
+// replicated to the other clusters, which purge the standby data of the domain on their own.
+func (d *HandlerImpl) DeleteDomain(
+	_ context.Context,
+	deleteRequest *adminservice.DeleteDomainRequest,
+) (*adminservice.DeleteDomainResponse, error) {
+
+	domainName := deleteRequest.GetDomain()
+	if domainName == "" {
+		return nil, errDomainNotSet
+	}
+	if domainName == common.SystemLocalDomainName || domainName == common.SystemGlobalDomainName {
+		return nil, errCannotDeleteSystemDomain
+	}
+
+	// must get the metadata (notificationVersion) first
+	// this version can be regarded as the lock on the v2 domain table
+	// and since we do not know which table will return the domain afterwards
+	// this call has to be made
+	metadata, err := d.metadataMgr.GetMetadata()
+	if err != nil {
+		return nil, err
+	}
+	notificationVersion := metadata.NotificationVersion
+	getResponse, err := d.metadataMgr.GetDomain(&persistence.GetDomainRequest{Name: domainName})
+	if err != nil {
+		return nil, err
+	}
+
+	info := getResponse.Info
+	response := &adminservice.DeleteDomainResponse{DomainId: info.ID}
+	if info.Status == persistence.DomainStatusDeleted {
+		// the domain is already marked deleted, the caller may resume the purge of its data
+		return response, nil
+	}
+
+	isGlobalDomain := getResponse.IsGlobalDomain
+	if isGlobalDomain {
+		if !d.clusterMetadata.IsMasterCluster() {
+			return nil, errNotMasterCluster
+		}
+		activeClusterName := getResponse.ReplicationConfig.ActiveClusterName
+		if activeClusterName != d.clusterMetadata.GetCurrentClusterName() {
+			return nil, &shared.BadRequestError{
+				Message: fmt.Sprintf("Domain %v is active in cluster %v, it can only be deleted there.", domainName, activeClusterName),
+			}
+		}
+	}
+
+	configVersion := getResponse.ConfigVersion + 1
+	info.Status = persistence.DomainStatusDeleted
+	updateReq := &persistence.UpdateDomainRequest{
+		Info:                        info,
+		Config:                      getResponse.Config,
+		ReplicationConfig:           getResponse.ReplicationConfig,
+		ConfigVersion:               configVersion,
+		FailoverVersion:             getResponse.FailoverVersion,
+		FailoverNotificationVersion: getResponse.FailoverNotificationVersion,
+		NotificationVersion:         notificationVersion,
+	}
+	if err := d.metadataMgr.UpdateDomain(updateReq); err != nil {
+		return nil, err
+	}
+
+	if isGlobalDomain {
+		err = d.domainReplicator.HandleTransmissionTask(replicator.DomainOperationUpdate,
+			info, getResponse.Config, getResponse.ReplicationConfig, configVersion, getResponse.FailoverVersion, isGlobalDomain)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	d.logger.Info("Delete domain succeeded",
+		tag.WorkflowDomainName(info.Name),
+		tag.WorkflowDomainID(info.ID),
+	)
+	return response, nil
+}
+
+// AddSearchAttributes adds search attributes to the domain, each one stored in a cluster search attribute
+func (d *HandlerImpl) AddSearchAttributes(
+	_ context.Context,
+	addRequest *adminservice.AddSearchAttributesRequest,
+) (*adminservice.AddSearchAttributesResponse, error) {
+
+	searchAttributes := addRequest.GetSearchAttributes()
+	if len(searchAttributes) == 0 {
+		return nil, errSearchAttributesNotSet
+	}
+
+	err := d.updateSearchAttributes(addRequest.GetDomain(), func(current map[string]string) error {
+		for name, field := range searchAttributes {
+			if _, ok := current[name]; ok {
+				return &shared.BadRequestError{
+					Message: fmt.Sprintf("Domain search attribute %v already exists.", name),
+				}
+			}
+			current[name] = field
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &adminservice.AddSearchAttributesResponse{}, nil
+}
+
+// RemoveSearchAttributes removes search attributes from the domain
+func (d *HandlerImpl) RemoveSearchAttributes(
+	_ context.Context,
+	removeRequest *adminservice.RemoveSearchAttributesRequest,
+) (*adminservice.RemoveSearchAttributesResponse, error) {
+
+	searchAttributes := removeRequest.GetSearchAttributes()
+	if len(searchAttributes) == 0 {
+		return nil, errSearchAttributesNotSet
+	}
+
+	err := d.updateSearchAttributes(removeRequest.GetDomain(), func(current map[string]string) error {
+		for _, name := range searchAttributes {
+			if _, ok := current[name]; !ok {
+				return &shared.BadRequestError{
+					Message: fmt.Sprintf("Domain search attribute %v doesn't exist.", name),
+				}
+			}
+			delete(current, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &adminservice.RemoveSearchAttributesResponse{}, nil
+}
+
+// ListSearchAttributes lists the search attributes of the domain
+func (d *HandlerImpl) ListSearchAttributes(
+	_ context.Context,
+	listRequest *adminservice.ListSearchAttributesRequest,
+) (*adminservice.ListSearchAttributesResponse, error) {
+
+	resp, err := d.metadataMgr.GetDomain(&persistence.GetDomainRequest{Name: listRequest.GetDomain()})
+	if err != nil {
+		return nil, err
+	}
+
+	validSearchAttributes := d.validSearchAttributes()
+	searchAttributes := make(map[string]string, len(resp.Config.SearchAttributes))
+	keys := make(map[string]shared.IndexedValueType, len(resp.Config.SearchAttributes))
+	for name, field := range resp.Config.SearchAttributes {
+		searchAttributes[name] = field
+		if fieldType, ok := validSearchAttributes[field]; ok {
+			keys[name] = common.ConvertIndexedValueTypeToThriftType(fieldType, d.logger)
+		}
+	}
+	return &adminservice.ListSearchAttributesResponse{
+		SearchAttributes: searchAttributes,
+		Keys:             adapter.ToProtoIndexedValueTypes(keys),
+	}, nil
+}
+
+// UpdateDomainWorkflowDefaults replaces the workflow defaults of the domain
+func (d *HandlerImpl) UpdateDomainWorkflowDefaults(
+	_ context.Context,
+	updateRequest *adminservice.UpdateDomainWorkflowDefaultsRequest,
+) (*adminservice.UpdateDomainWorkflowDefaultsResponse, error) {
+
+	workflowDefaults := toPersistenceWorkflowDefaults(updateRequest.GetWorkflowDefaults())
+	if err := d.domainAttrValidator.validateDomainWorkflowDefaults(workflowDefaults); err != nil {
+		return nil, err
+	}
+
+	// workflow defaults are part of the replicated domain config, like UpdateDomain
+	// they count as a config change and are only updated on the master cluster
+	metadata, err := d.metadataMgr.GetMetadata()
+	if err != nil {
+		return nil, err
+	}
+	notificationVersion := metadata.NotificationVersion
+	getResponse, err := d.metadataMgr.GetDomain(&persistence.GetDomainRequest{Name: updateRequest.GetDomain()})
+	if err != nil {
+		return nil, err
+	}
+	if getResponse.Info.Status == persistence.DomainStatusDeleted {
+		return nil, errDomainDeleted
+	}
+	isGlobalDomain := getResponse.IsGlobalDomain
+	if isGlobalDomain && !d.clusterMetadata.IsMasterCluster() {
+		return nil, errNotMasterCluster
+	}
+
+	info := getResponse.Info
+	config := getResponse.Config
+	config.WorkflowDefaults = workflowDefaults
+	configVersion := getResponse.ConfigVersion + 1
+	err = d.metadataMgr.UpdateDomain(&persistence.UpdateDomainRequest{
+		Info:                        info,
+		Config:                      config,
+		ReplicationConfig:           getResponse.ReplicationConfig,
+		ConfigVersion:               configVersion,
+		FailoverVersion:             getResponse.FailoverVersion,
+		FailoverNotificationVersion: getResponse.FailoverNotificationVersion,
+		NotificationVersion:         notificationVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if isGlobalDomain {
+		err = d.domainReplicator.HandleTransmissionTask(replicator.DomainOperationUpdate,
+			info, config, getResponse.ReplicationConfig, configVersion, getResponse.FailoverVersion, isGlobalDomain)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	d.logger.Info("Update domain workflow defaults succeeded",
+		tag.WorkflowDomainName(info.Name),
+		tag.WorkflowDomainID(info.ID),
+	)
+	return &adminservice.UpdateDomainWorkflowDefaultsResponse{}, nil
+}
+
+// DescribeDomainWorkflowDefaults returns the workflow defaults of the domain
+func (d *HandlerImpl) DescribeDomainWorkflowDefaults(
+	_ context.Context,
+	describeRequest *adminservice.DescribeDomainWorkflowDefaultsRequest,
+) (*adminservice.DescribeDomainWorkflowDefaultsResponse, error) {
+
+	resp, err := d.metadataMgr.GetDomain(&persistence.GetDomainRequest{Name: describeRequest.GetDomain()})
+	if err != nil {
+		return nil, err
+	}
+	return &adminservice.DescribeDomainWorkflowDefaultsResponse{
+		WorkflowDefaults: toProtoWorkflowDefaults(resp.Config.WorkflowDefaults),
+	}, nil
+}
+
+// updateSearchAttributes applies the update to the search attributes of the domain.
+// Domain search attributes point into the elasticsearch mapping of the current cluster,
+// so they are neither replicated nor counted in the domain config version.
+func (d *HandlerImpl) updateSearchAttributes(
+	domainName string,
+	update func(map[string]string) error,
+) error {
+
+	return d.updateLocalConfig(domainName, "search attributes", func(config *persistence.DomainConfig) error {
+		searchAttributes := make(map[string]string, len(config.SearchAttributes))
+		for name, field := range config.SearchAttributes {
+			searchAttributes[name] = field
+		}
+		if err := update(searchAttributes); err != nil {
+			return err
+		}
+		if err := d.domainAttrValidator.validateDomainSearchAttributes(
+			searchAttributes,
+			d.validSearchAttributes(),
+		); err != nil {
+			return err
+		}
+		config.SearchAttributes = searchAttributes
+		return nil
+	})
+}
+
+// updateLocalConfig applies the update to the parts of the domain config which are local
+// to the current cluster, these are neither replicated nor counted in the domain config version.
+func (d *HandlerImpl) updateLocalConfig(
+	domainName string,
+	updated string,
+	update func(*persistence.DomainConfig) error,
+) error {
+
+	// must get the metadata (notificationVersion) first
+	// this version can be regarded as the lock on the v2 domain table
+	// and since we do not know which table will return the domain afterwards
+	// this call has to be made
+	metadata, err := d.metadataMgr.GetMetadata()
+	if err != nil {
+		return err
+	}
+	notificationVersion := metadata.NotificationVersion
+	getResponse, err := d.metadataMgr.GetDomain(&persistence.GetDomainRequest{Name: domainName})
+	if err != nil {
+		return err
+	}
+
+	config := getResponse.Config
+	if err := update(config); err != nil {
+		return err
+	}
+
+	updateReq := &persistence.UpdateDomainRequest{
+		Info:                        getResponse.Info,
+		Config:                      config,
+		ReplicationConfig:           getResponse.ReplicationConfig,
+		ConfigVersion:               getResponse.ConfigVersion,
+		FailoverVersion:             getResponse.FailoverVersion,
+		FailoverNotificationVersion: getResponse.FailoverNotificationVersion,
+		NotificationVersion:         notificationVersion,
+	}
+	if err := d.metadataMgr.UpdateDomain(updateReq); err != nil {
+		return err
+	}
+
+	d.logger.Info(fmt.Sprintf("Update domain %v succeeded", updated),
+		tag.WorkflowDomainName(getResponse.Info.Name),
+		tag.WorkflowDomainID(getResponse.Info.ID),
+	)
+	return nil
+}
+
 func (d *HandlerImpl) createResponse(
 	ctx context.Context,
 	info *persistence.DomainInfo,
@@ -767,3 +1116,48 @@ func getDomainStatus(info *persistence.DomainInfo) enums.DomainStatus {
 	// TODO: panic, log, ...?
 	return enums.DomainStatusRegistered
 }
+
+func toPersistenceWorkflowDefaults(
+	in *adminservice.DomainWorkflowDefaults,
+) *persistence.DomainWorkflowDefaults {
+
+	if in == nil {
+		return nil
+	}
+	out := &persistence.DomainWorkflowDefaults{
+		DefaultExecutionStartToCloseTimeoutSeconds:   in.GetDefaultExecutionStartToCloseTimeoutSeconds(),
+		MaxExecutionStartToCloseTimeoutSeconds:       in.GetMaxExecutionStartToCloseTimeoutSeconds(),
+		DefaultTaskStartToCloseTimeoutSeconds:        in.GetDefaultTaskStartToCloseTimeoutSeconds(),
+		DefaultActivityScheduleToCloseTimeoutSeconds: in.GetDefaultActivityScheduleToCloseTimeoutSeconds(),
+		MaxActivityScheduleToCloseTimeoutSeconds:     in.GetMaxActivityScheduleToCloseTimeoutSeconds(),
+		DefaultWorkflowRetryPolicy:                   adapter.ToThriftRetryPolicy(in.GetDefaultWorkflowRetryPolicy()),
+		DefaultActivityRetryPolicy:                   adapter.ToThriftRetryPolicy(in.GetDefaultActivityRetryPolicy()),
+	}
+	// AllowDuplicateFailedOnly is the zero value and can't be told apart from unset
+	if in.GetDefaultWorkflowIdReusePolicy() != enums.WorkflowIdReusePolicyAllowDuplicateFailedOnly {
+		out.DefaultWorkflowIDReusePolicy = adapter.ToThriftWorkflowIDReusePolicy(in.GetDefaultWorkflowIdReusePolicy())
+	}
+	return out
+}
+
+func toProtoWorkflowDefaults(
+	in *persistence.DomainWorkflowDefaults,
+) *adminservice.DomainWorkflowDefaults {
+
+	if in == nil {
+		return nil
+	}
+	out := &adminservice.DomainWorkflowDefaults{
+		DefaultExecutionStartToCloseTimeoutSeconds:   in.DefaultExecutionStartToCloseTimeoutSeconds,
+		MaxExecutionStartToCloseTimeoutSeconds:       in.MaxExecutionStartToCloseTimeoutSeconds,
+		DefaultTaskStartToCloseTimeoutSeconds:        in.DefaultTaskStartToCloseTimeoutSeconds,
+		DefaultActivityScheduleToCloseTimeoutSeconds: in.DefaultActivityScheduleToCloseTimeoutSeconds,
+		MaxActivityScheduleToCloseTimeoutSeconds:     in.MaxActivityScheduleToCloseTimeoutSeconds,
+		DefaultWorkflowRetryPolicy:                   adapter.ToProtoRetryPolicy(in.DefaultWorkflowRetryPolicy),
+		DefaultActivityRetryPolicy:                   adapter.ToProtoRetryPolicy(in.DefaultActivityRetryPolicy),
+	}
+	if in.DefaultWorkflowIDReusePolicy != nil {
+		out.DefaultWorkflowIdReusePolicy = enums.WorkflowIdReusePolicy(*in.DefaultWorkflowIDReusePolicy)
+	}
+	return out
+}
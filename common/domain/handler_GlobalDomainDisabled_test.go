@@ -37,6 +37,7 @@ import (
 	"github.com/temporalio/temporal/common/archiver"
 	"github.com/temporalio/temporal/common/archiver/provider"
 	"github.com/temporalio/temporal/common/cluster"
+	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/log/loggerimpl"
 	"github.com/temporalio/temporal/common/mocks"
 	"github.com/temporalio/temporal/common/persistence"
@@ -102,6 +103,7 @@ func (s *domainHandlerGlobalDomainDisabledSuite) SetupTest() {
 	s.handler = NewHandler(
 		s.minRetentionDays,
 		dc.GetIntPropertyFilteredByDomain(s.maxBadBinaryCount),
+		dc.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
 		logger,
 		s.metadataMgr,
 		s.ClusterMetadata,
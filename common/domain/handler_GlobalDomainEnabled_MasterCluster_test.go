@@ -34,10 +34,12 @@ import (
 	"go.temporal.io/temporal-proto/workflowservice"
 
 	"github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/archiver"
 	"github.com/temporalio/temporal/common/archiver/provider"
 	"github.com/temporalio/temporal/common/cluster"
+	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/log/loggerimpl"
 	"github.com/temporalio/temporal/common/mocks"
 	"github.com/temporalio/temporal/common/persistence"
@@ -103,6 +105,7 @@ func (s *domainHandlerGlobalDomainEnabledMasterClusterSuite) SetupTest() {
 	s.handler = NewHandler(
 		s.minRetentionDays,
 		dc.GetIntPropertyFilteredByDomain(s.maxBadBinaryCount),
+		dc.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
 		logger,
 		s.metadataMgr,
 		s.ClusterMetadata,
@@ -862,6 +865,99 @@ func (s *domainHandlerGlobalDomainEnabledMasterClusterSuite) TestUpdateGetDomain
 	)
 }
 
+func (s *domainHandlerGlobalDomainEnabledMasterClusterSuite) TestUpdateDomainWorkflowDefaults_GlobalDomain() {
+	clusters := []*commonproto.ClusterReplicationConfiguration{}
+	for clusterName := range s.ClusterMetadata.GetAllClusterInfo() {
+		clusters = append(clusters, &commonproto.ClusterReplicationConfiguration{
+			ClusterName: clusterName,
+		})
+	}
+
+	// both the registration and the update of the workflow defaults are replicated
+	s.mockProducer.On("Publish", mock.Anything).Return(nil).Twice()
+
+	domainName := s.getRandomDomainName()
+	_, err := s.handler.RegisterDomain(context.Background(), &workflowservice.RegisterDomainRequest{
+		Name:                                   domainName,
+		IsGlobalDomain:                         true,
+		Clusters:                               clusters,
+		ActiveClusterName:                      s.ClusterMetadata.GetCurrentClusterName(),
+		WorkflowExecutionRetentionPeriodInDays: 1,
+	})
+	s.NoError(err)
+
+	workflowDefaults := &adminservice.DomainWorkflowDefaults{
+		DefaultExecutionStartToCloseTimeoutSeconds: 3600,
+		DefaultWorkflowIdReusePolicy:               enums.WorkflowIdReusePolicyRejectDuplicate,
+	}
+	_, err = s.handler.UpdateDomainWorkflowDefaults(context.Background(), &adminservice.UpdateDomainWorkflowDefaultsRequest{
+		Domain:           domainName,
+		WorkflowDefaults: workflowDefaults,
+	})
+	s.NoError(err)
+
+	describeResp, err := s.handler.DescribeDomainWorkflowDefaults(context.Background(), &adminservice.DescribeDomainWorkflowDefaultsRequest{
+		Domain: domainName,
+	})
+	s.NoError(err)
+	s.Equal(workflowDefaults, describeResp.WorkflowDefaults)
+
+	resp, err := s.metadataMgr.GetDomain(&persistence.GetDomainRequest{Name: domainName})
+	s.NoError(err)
+	s.Equal(int64(1), resp.ConfigVersion)
+}
+
+func (s *domainHandlerGlobalDomainEnabledMasterClusterSuite) TestDeleteDomain_GlobalDomain() {
+	clusters := []*commonproto.ClusterReplicationConfiguration{}
+	standbyClusterName := ""
+	for clusterName := range s.ClusterMetadata.GetAllClusterInfo() {
+		if clusterName != s.ClusterMetadata.GetCurrentClusterName() {
+			standbyClusterName = clusterName
+		}
+		clusters = append(clusters, &commonproto.ClusterReplicationConfiguration{
+			ClusterName: clusterName,
+		})
+	}
+	s.True(len(standbyClusterName) > 0)
+
+	// registration and deletion of the domain active in this cluster are replicated
+	s.mockProducer.On("Publish", mock.Anything).Return(nil).Times(3)
+
+	activeDomainName := s.getRandomDomainName()
+	_, err := s.handler.RegisterDomain(context.Background(), &workflowservice.RegisterDomainRequest{
+		Name:                                   activeDomainName,
+		IsGlobalDomain:                         true,
+		Clusters:                               clusters,
+		ActiveClusterName:                      s.ClusterMetadata.GetCurrentClusterName(),
+		WorkflowExecutionRetentionPeriodInDays: 1,
+	})
+	s.NoError(err)
+	_, err = s.handler.DeleteDomain(context.Background(), &adminservice.DeleteDomainRequest{
+		Domain: activeDomainName,
+	})
+	s.NoError(err)
+
+	standbyDomainName := s.getRandomDomainName()
+	_, err = s.handler.RegisterDomain(context.Background(), &workflowservice.RegisterDomainRequest{
+		Name:                                   standbyDomainName,
+		IsGlobalDomain:                         true,
+		Clusters:                               clusters,
+		ActiveClusterName:                      standbyClusterName,
+		WorkflowExecutionRetentionPeriodInDays: 1,
+	})
+	s.NoError(err)
+	_, err = s.handler.DeleteDomain(context.Background(), &adminservice.DeleteDomainRequest{
+		Domain: standbyDomainName,
+	})
+	s.IsType(&shared.BadRequestError{}, err)
+
+	resp, err := s.handler.DescribeDomain(context.Background(), &workflowservice.DescribeDomainRequest{
+		Name: standbyDomainName,
+	})
+	s.NoError(err)
+	s.Equal(enums.DomainStatusRegistered, resp.DomainInfo.GetStatus())
+}
+
 func (s *domainHandlerGlobalDomainEnabledMasterClusterSuite) getRandomDomainName() string {
 	return "domain" + uuid.New()
 }
@@ -34,6 +34,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 
 	shared "github.com/temporalio/temporal/.gen/go/shared"
+	adminservice "github.com/temporalio/temporal/.gen/proto/adminservice"
 )
 
 // MockHandler is a mock of Handler interface
@@ -131,3 +132,93 @@ func (mr *MockHandlerMockRecorder) UpdateDomain(ctx, updateRequest interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDomain", reflect.TypeOf((*MockHandler)(nil).UpdateDomain), ctx, updateRequest)
 }
+
+// AddSearchAttributes mocks base method
+func (m *MockHandler) AddSearchAttributes(ctx context.Context, addRequest *adminservice.AddSearchAttributesRequest) (*adminservice.AddSearchAttributesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSearchAttributes", ctx, addRequest)
+	ret0, _ := ret[0].(*adminservice.AddSearchAttributesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSearchAttributes indicates an expected call of AddSearchAttributes
+func (mr *MockHandlerMockRecorder) AddSearchAttributes(ctx, addRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSearchAttributes", reflect.TypeOf((*MockHandler)(nil).AddSearchAttributes), ctx, addRequest)
+}
+
+// RemoveSearchAttributes mocks base method
+func (m *MockHandler) RemoveSearchAttributes(ctx context.Context, removeRequest *adminservice.RemoveSearchAttributesRequest) (*adminservice.RemoveSearchAttributesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSearchAttributes", ctx, removeRequest)
+	ret0, _ := ret[0].(*adminservice.RemoveSearchAttributesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveSearchAttributes indicates an expected call of RemoveSearchAttributes
+func (mr *MockHandlerMockRecorder) RemoveSearchAttributes(ctx, removeRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSearchAttributes", reflect.TypeOf((*MockHandler)(nil).RemoveSearchAttributes), ctx, removeRequest)
+}
+
+// ListSearchAttributes mocks base method
+func (m *MockHandler) ListSearchAttributes(ctx context.Context, listRequest *adminservice.ListSearchAttributesRequest) (*adminservice.ListSearchAttributesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSearchAttributes", ctx, listRequest)
+	ret0, _ := ret[0].(*adminservice.ListSearchAttributesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSearchAttributes indicates an expected call of ListSearchAttributes
+func (mr *MockHandlerMockRecorder) ListSearchAttributes(ctx, listRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSearchAttributes", reflect.TypeOf((*MockHandler)(nil).ListSearchAttributes), ctx, listRequest)
+}
+
+// DeleteDomain mocks base method
+func (m *MockHandler) DeleteDomain(ctx context.Context, deleteRequest *adminservice.DeleteDomainRequest) (*adminservice.DeleteDomainResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDomain", ctx, deleteRequest)
+	ret0, _ := ret[0].(*adminservice.DeleteDomainResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDomain indicates an expected call of DeleteDomain
+func (mr *MockHandlerMockRecorder) DeleteDomain(ctx, deleteRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDomain", reflect.TypeOf((*MockHandler)(nil).DeleteDomain), ctx, deleteRequest)
+}
+
+// UpdateDomainWorkflowDefaults mocks base method
+func (m *MockHandler) UpdateDomainWorkflowDefaults(ctx context.Context, updateRequest *adminservice.UpdateDomainWorkflowDefaultsRequest) (*adminservice.UpdateDomainWorkflowDefaultsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDomainWorkflowDefaults", ctx, updateRequest)
+	ret0, _ := ret[0].(*adminservice.UpdateDomainWorkflowDefaultsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDomainWorkflowDefaults indicates an expected call of UpdateDomainWorkflowDefaults
+func (mr *MockHandlerMockRecorder) UpdateDomainWorkflowDefaults(ctx, updateRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDomainWorkflowDefaults", reflect.TypeOf((*MockHandler)(nil).UpdateDomainWorkflowDefaults), ctx, updateRequest)
+}
+
+// DescribeDomainWorkflowDefaults mocks base method
+func (m *MockHandler) DescribeDomainWorkflowDefaults(ctx context.Context, describeRequest *adminservice.DescribeDomainWorkflowDefaultsRequest) (*adminservice.DescribeDomainWorkflowDefaultsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeDomainWorkflowDefaults", ctx, describeRequest)
+	ret0, _ := ret[0].(*adminservice.DescribeDomainWorkflowDefaultsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeDomainWorkflowDefaults indicates an expected call of DescribeDomainWorkflowDefaults
+func (mr *MockHandlerMockRecorder) DescribeDomainWorkflowDefaults(ctx, describeRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeDomainWorkflowDefaults", reflect.TypeOf((*MockHandler)(nil).DescribeDomainWorkflowDefaults), ctx, describeRequest)
+}
@@ -35,10 +35,12 @@ import (
 	"go.temporal.io/temporal-proto/enums"
 	"go.temporal.io/temporal-proto/workflowservice"
 
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/archiver"
 	"github.com/temporalio/temporal/common/archiver/provider"
 	"github.com/temporalio/temporal/common/cluster"
+	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/log/loggerimpl"
 	"github.com/temporalio/temporal/common/mocks"
 	"github.com/temporalio/temporal/common/persistence"
@@ -106,6 +108,7 @@ func (s *domainHandlerCommonSuite) SetupTest() {
 	s.handler = NewHandler(
 		s.minRetentionDays,
 		dc.GetIntPropertyFilteredByDomain(s.maxBadBinaryCount),
+		dc.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
 		logger,
 		s.metadataMgr,
 		s.ClusterMetadata,
@@ -422,6 +425,182 @@ func (s *domainHandlerCommonSuite) TestUpdateDomain_InvalidRetentionPeriod() {
 	s.Nil(resp)
 }
 
+func (s *domainHandlerCommonSuite) TestSearchAttributes() {
+	domain := s.getRandomDomainName()
+	registerRequest := &workflowservice.RegisterDomainRequest{
+		Name:                                   domain,
+		Description:                            domain,
+		WorkflowExecutionRetentionPeriodInDays: int32(10),
+		IsGlobalDomain:                         false,
+	}
+	registerResp, err := s.handler.RegisterDomain(context.Background(), registerRequest)
+	s.NoError(err)
+	s.Nil(registerResp)
+
+	addResp, err := s.handler.AddSearchAttributes(context.Background(), &adminservice.AddSearchAttributesRequest{
+		Domain: domain,
+		SearchAttributes: map[string]string{
+			"OrderID":  definition.CustomKeywordField,
+			"Priority": definition.CustomIntField,
+		},
+	})
+	s.NoError(err)
+	s.NotNil(addResp)
+
+	_, err = s.handler.AddSearchAttributes(context.Background(), &adminservice.AddSearchAttributesRequest{
+		Domain:           domain,
+		SearchAttributes: map[string]string{"OrderID": definition.CustomStringField},
+	})
+	s.Error(err)
+
+	_, err = s.handler.AddSearchAttributes(context.Background(), &adminservice.AddSearchAttributesRequest{
+		Domain:           domain,
+		SearchAttributes: map[string]string{"CustomerID": definition.CustomKeywordField},
+	})
+	s.Error(err)
+
+	listResp, err := s.handler.ListSearchAttributes(context.Background(), &adminservice.ListSearchAttributesRequest{
+		Domain: domain,
+	})
+	s.NoError(err)
+	s.Equal(map[string]string{
+		"OrderID":  definition.CustomKeywordField,
+		"Priority": definition.CustomIntField,
+	}, listResp.SearchAttributes)
+	s.Equal(map[string]enums.IndexedValueType{
+		"OrderID":  enums.IndexedValueTypeKeyword,
+		"Priority": enums.IndexedValueTypeInt,
+	}, listResp.Keys)
+
+	removeResp, err := s.handler.RemoveSearchAttributes(context.Background(), &adminservice.RemoveSearchAttributesRequest{
+		Domain:           domain,
+		SearchAttributes: []string{"Priority"},
+	})
+	s.NoError(err)
+	s.NotNil(removeResp)
+
+	_, err = s.handler.RemoveSearchAttributes(context.Background(), &adminservice.RemoveSearchAttributesRequest{
+		Domain:           domain,
+		SearchAttributes: []string{"Priority"},
+	})
+	s.Error(err)
+
+	listResp, err = s.handler.ListSearchAttributes(context.Background(), &adminservice.ListSearchAttributesRequest{
+		Domain: domain,
+	})
+	s.NoError(err)
+	s.Equal(map[string]string{"OrderID": definition.CustomKeywordField}, listResp.SearchAttributes)
+}
+
+func (s *domainHandlerCommonSuite) TestDeleteDomain() {
+	domain := s.getRandomDomainName()
+	registerRequest := &workflowservice.RegisterDomainRequest{
+		Name:                                   domain,
+		Description:                            domain,
+		WorkflowExecutionRetentionPeriodInDays: int32(10),
+		IsGlobalDomain:                         false,
+	}
+	_, err := s.handler.RegisterDomain(context.Background(), registerRequest)
+	s.NoError(err)
+
+	_, err = s.handler.DeleteDomain(context.Background(), &adminservice.DeleteDomainRequest{
+		Domain: common.SystemLocalDomainName,
+	})
+	s.Equal(errCannotDeleteSystemDomain, err)
+
+	deleteResp, err := s.handler.DeleteDomain(context.Background(), &adminservice.DeleteDomainRequest{
+		Domain: domain,
+	})
+	s.NoError(err)
+
+	describeResp, err := s.handler.DescribeDomain(context.Background(), &workflowservice.DescribeDomainRequest{
+		Name: domain,
+	})
+	s.NoError(err)
+	s.Equal(describeResp.DomainInfo.GetUuid(), deleteResp.GetDomainId())
+	s.Equal(enums.DomainStatusDeleted, describeResp.DomainInfo.GetStatus())
+
+	// deleting the domain again resumes its deletion
+	deleteResp2, err := s.handler.DeleteDomain(context.Background(), &adminservice.DeleteDomainRequest{
+		Domain: domain,
+	})
+	s.NoError(err)
+	s.Equal(deleteResp.GetDomainId(), deleteResp2.GetDomainId())
+
+	_, err = s.handler.UpdateDomain(context.Background(), &workflowservice.UpdateDomainRequest{
+		Name:        domain,
+		UpdatedInfo: &commonproto.UpdateDomainInfo{Description: "updated"},
+	})
+	s.Equal(errDomainDeleted, err)
+
+	_, err = s.handler.DeprecateDomain(context.Background(), &workflowservice.DeprecateDomainRequest{
+		Name: domain,
+	})
+	s.Equal(errDomainDeleted, err)
+}
+
+func (s *domainHandlerCommonSuite) TestWorkflowDefaults() {
+	domain := s.getRandomDomainName()
+	registerRequest := &workflowservice.RegisterDomainRequest{
+		Name:                                   domain,
+		Description:                            domain,
+		WorkflowExecutionRetentionPeriodInDays: int32(10),
+		IsGlobalDomain:                         false,
+	}
+	registerResp, err := s.handler.RegisterDomain(context.Background(), registerRequest)
+	s.NoError(err)
+	s.Nil(registerResp)
+
+	describeResp, err := s.handler.DescribeDomainWorkflowDefaults(context.Background(), &adminservice.DescribeDomainWorkflowDefaultsRequest{
+		Domain: domain,
+	})
+	s.NoError(err)
+	s.Nil(describeResp.WorkflowDefaults)
+
+	workflowDefaults := &adminservice.DomainWorkflowDefaults{
+		DefaultExecutionStartToCloseTimeoutSeconds: 3600,
+		MaxExecutionStartToCloseTimeoutSeconds:     86400,
+		DefaultWorkflowIdReusePolicy:               enums.WorkflowIdReusePolicyRejectDuplicate,
+		DefaultActivityRetryPolicy: &commonproto.RetryPolicy{
+			InitialIntervalInSeconds: 1,
+			BackoffCoefficient:       2,
+			MaximumAttempts:          3,
+		},
+	}
+	updateResp, err := s.handler.UpdateDomainWorkflowDefaults(context.Background(), &adminservice.UpdateDomainWorkflowDefaultsRequest{
+		Domain:           domain,
+		WorkflowDefaults: workflowDefaults,
+	})
+	s.NoError(err)
+	s.NotNil(updateResp)
+
+	_, err = s.handler.UpdateDomainWorkflowDefaults(context.Background(), &adminservice.UpdateDomainWorkflowDefaultsRequest{
+		Domain: domain,
+		WorkflowDefaults: &adminservice.DomainWorkflowDefaults{
+			DefaultExecutionStartToCloseTimeoutSeconds: 86400,
+			MaxExecutionStartToCloseTimeoutSeconds:     3600,
+		},
+	})
+	s.Error(err)
+
+	describeResp, err = s.handler.DescribeDomainWorkflowDefaults(context.Background(), &adminservice.DescribeDomainWorkflowDefaultsRequest{
+		Domain: domain,
+	})
+	s.NoError(err)
+	s.Equal(workflowDefaults, describeResp.WorkflowDefaults)
+
+	_, err = s.handler.UpdateDomainWorkflowDefaults(context.Background(), &adminservice.UpdateDomainWorkflowDefaultsRequest{
+		Domain: domain,
+	})
+	s.NoError(err)
+
+	describeResp, err = s.handler.DescribeDomainWorkflowDefaults(context.Background(), &adminservice.DescribeDomainWorkflowDefaultsRequest{
+		Domain: domain,
+	})
+	s.NoError(err)
+	s.Nil(describeResp.WorkflowDefaults)
+}
+
 func (s *domainHandlerCommonSuite) getRandomDomainName() string {
 	return "domain" + uuid.New()
 }
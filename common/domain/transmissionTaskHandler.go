@@ -95,6 +95,7 @@ func (domainReplicator *domainReplicatorImpl) HandleTransmissionTask(domainOpera
 			VisibilityArchivalStatus:               common.ArchivalStatusPtr(config.VisibilityArchivalStatus),
 			VisibilityArchivalURI:                  common.StringPtr(config.VisibilityArchivalURI),
 			BadBinaries:                            &config.BadBinaries,
+			WorkflowDefaults:                       config.WorkflowDefaults.ToThrift(),
 		},
 		ReplicationConfig: &shared.DomainReplicationConfiguration{
 			ActiveClusterName: common.StringPtr(replicationConfig.ActiveClusterName),
@@ -130,6 +131,9 @@ func (domainReplicator *domainReplicatorImpl) convertDomainStatusToThrift(input
 	case persistence.DomainStatusDeprecated:
 		output := shared.DomainStatusDeprecated
 		return &output, nil
+	case persistence.DomainStatusDeleted:
+		output := shared.DomainStatusDeleted
+		return &output, nil
 	default:
 		return nil, ErrInvalidDomainStatus
 	}
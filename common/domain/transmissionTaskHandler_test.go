@@ -110,6 +110,10 @@ func (s *domainReplicatorSuite) TestHandleTransmissionTask_RegisterDomainTask_Is
 		VisibilityArchivalStatus: visibilityArchivalStatus,
 		VisibilityArchivalURI:    visibilityArchivalURI,
 		BadBinaries:              shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+		WorkflowDefaults: &p.DomainWorkflowDefaults{
+			DefaultExecutionStartToCloseTimeoutSeconds: 3600,
+			DefaultWorkflowIDReusePolicy:               shared.WorkflowIdReusePolicyRejectDuplicate.Ptr(),
+		},
 	}
 	replicationConfig := &p.DomainReplicationConfig{
 		ActiveClusterName: clusterActive,
@@ -137,6 +141,14 @@ func (s *domainReplicatorSuite) TestHandleTransmissionTask_RegisterDomainTask_Is
 				VisibilityArchivalStatus:               common.ArchivalStatusPtr(visibilityArchivalStatus),
 				VisibilityArchivalURI:                  common.StringPtr(visibilityArchivalURI),
 				BadBinaries:                            &shared.BadBinaries{Binaries: map[string]*shared.BadBinaryInfo{}},
+				WorkflowDefaults: &shared.DomainWorkflowDefaults{
+					DefaultExecutionStartToCloseTimeoutSeconds:   common.Int32Ptr(3600),
+					MaxExecutionStartToCloseTimeoutSeconds:       common.Int32Ptr(0),
+					DefaultTaskStartToCloseTimeoutSeconds:        common.Int32Ptr(0),
+					DefaultActivityScheduleToCloseTimeoutSeconds: common.Int32Ptr(0),
+					MaxActivityScheduleToCloseTimeoutSeconds:     common.Int32Ptr(0),
+					DefaultWorkflowIdReusePolicy:                 shared.WorkflowIdReusePolicyRejectDuplicate.Ptr(),
+				},
 			},
 			ReplicationConfig: &shared.DomainReplicationConfiguration{
 				ActiveClusterName: common.StringPtr(clusterActive),
@@ -0,0 +1,95 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package validator
+
+import (
+	"github.com/temporalio/temporal/common/cache"
+)
+
+type (
+	// DomainSearchAttributesFn returns the search attributes registered on a domain, keyed by
+	// their domain level name with the cluster search attribute they are stored in as value
+	DomainSearchAttributesFn func(domain string) (map[string]string, error)
+)
+
+// NewDomainSearchAttributesFn returns a DomainSearchAttributesFn which reads the search attributes from the domain cache
+func NewDomainSearchAttributesFn(domainCache cache.DomainCache) DomainSearchAttributesFn {
+	return func(domain string) (map[string]string, error) {
+		entry, err := domainCache.GetDomain(domain)
+		if err != nil {
+			return nil, err
+		}
+		return entry.GetConfig().SearchAttributes, nil
+	}
+}
+
+// GetEmptyDomainSearchAttributesFn returns a DomainSearchAttributesFn for domains without search attributes
+func GetEmptyDomainSearchAttributesFn() DomainSearchAttributesFn {
+	return func(domain string) (map[string]string, error) {
+		return nil, nil
+	}
+}
+
+// isClusterKeyOfDomain returns true if the cluster search attribute stores a search attribute registered on the domain
+func isClusterKeyOfDomain(clusterKey string, domainSearchAttributes map[string]string) bool {
+	for _, key := range domainSearchAttributes {
+		if key == clusterKey {
+			return true
+		}
+	}
+	return false
+}
+
+// ToClusterSearchAttributes replaces the search attributes registered on the domain by the cluster search attributes
+// they are stored in, the fields are copied so the input is not modified
+func ToClusterSearchAttributes(fields map[string][]byte, domainSearchAttributes map[string]string) map[string][]byte {
+	if fields == nil {
+		return nil
+	}
+	result := make(map[string][]byte, len(fields))
+	for key, value := range fields {
+		if clusterKey, ok := domainSearchAttributes[key]; ok {
+			key = clusterKey
+		}
+		result[key] = value
+	}
+	return result
+}
+
+// ToDomainSearchAttributes replaces the cluster search attributes registered on the domain by their domain level name,
+// it is the reverse of the mapping done when the search attributes are validated for writing
+func ToDomainSearchAttributes(fields map[string][]byte, domainSearchAttributes map[string]string) map[string][]byte {
+	if len(fields) == 0 || len(domainSearchAttributes) == 0 {
+		return fields
+	}
+	names := make(map[string]string, len(domainSearchAttributes))
+	for name, clusterName := range domainSearchAttributes {
+		names[clusterName] = name
+	}
+	result := make(map[string][]byte, len(fields))
+	for key, value := range fields {
+		if name, ok := names[key]; ok {
+			key = name
+		}
+		result[key] = value
+	}
+	return result
+}
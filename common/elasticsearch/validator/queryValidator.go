@@ -35,13 +35,18 @@ import (
 
 // VisibilityQueryValidator for sql query validation
 type VisibilityQueryValidator struct {
-	validSearchAttributes dynamicconfig.MapPropertyFn
+	validSearchAttributes  dynamicconfig.MapPropertyFn
+	domainSearchAttributes DomainSearchAttributesFn
 }
 
 // NewQueryValidator create VisibilityQueryValidator
-func NewQueryValidator(validSearchAttributes dynamicconfig.MapPropertyFn) *VisibilityQueryValidator {
+func NewQueryValidator(
+	validSearchAttributes dynamicconfig.MapPropertyFn,
+	domainSearchAttributes DomainSearchAttributesFn,
+) *VisibilityQueryValidator {
 	return &VisibilityQueryValidator{
-		validSearchAttributes: validSearchAttributes,
+		validSearchAttributes:  validSearchAttributes,
+		domainSearchAttributes: domainSearchAttributes,
 	}
 }
 
@@ -49,7 +54,7 @@ func NewQueryValidator(validSearchAttributes dynamicconfig.MapPropertyFn) *Visib
 // and add prefix for custom keys
 func (qv *VisibilityQueryValidator) ValidateListRequestForQuery(listRequest *workflow.ListWorkflowExecutionsRequest) error {
 	whereClause := listRequest.GetQuery()
-	newQuery, err := qv.validateListOrCountRequestForQuery(whereClause)
+	newQuery, err := qv.validateListOrCountRequestForQuery(whereClause, listRequest.GetDomain())
 	if err != nil {
 		return err
 	}
@@ -61,7 +66,7 @@ func (qv *VisibilityQueryValidator) ValidateListRequestForQuery(listRequest *wor
 // and add prefix for custom keys
 func (qv *VisibilityQueryValidator) ValidateCountRequestForQuery(countRequest *workflow.CountWorkflowExecutionsRequest) error {
 	whereClause := countRequest.GetQuery()
-	newQuery, err := qv.validateListOrCountRequestForQuery(whereClause)
+	newQuery, err := qv.validateListOrCountRequestForQuery(whereClause, countRequest.GetDomain())
 	if err != nil {
 		return err
 	}
@@ -70,9 +75,15 @@ func (qv *VisibilityQueryValidator) ValidateCountRequestForQuery(countRequest *w
 }
 
 // validateListOrCountRequestForQuery valid sql for visibility API
-// it also adds attr prefix for customized fields
-func (qv *VisibilityQueryValidator) validateListOrCountRequestForQuery(whereClause string) (string, error) {
+// it also adds attr prefix for customized fields and replaces the search attributes
+// registered on the domain by the cluster search attributes they are stored in
+func (qv *VisibilityQueryValidator) validateListOrCountRequestForQuery(whereClause string, domain string) (string, error) {
 	if len(whereClause) != 0 {
+		domainSearchAttributes, err := qv.domainSearchAttributes(domain)
+		if err != nil {
+			return "", err
+		}
+
 		// Build a placeholder query that allows us to easily parse the contents of the where clause.
 		// IMPORTANT: This query is never executed, it is just used to parse and validate whereClause
 		var placeholderQuery string
@@ -96,14 +107,14 @@ func (qv *VisibilityQueryValidator) validateListOrCountRequestForQuery(whereClau
 		buf := sqlparser.NewTrackedBuffer(nil)
 		// validate where expr
 		if sel.Where != nil {
-			err = qv.validateWhereExpr(sel.Where.Expr)
+			err = qv.validateWhereExpr(sel.Where.Expr, domainSearchAttributes)
 			if err != nil {
 				return "", &workflow.BadRequestError{Message: err.Error()}
 			}
 			sel.Where.Expr.Format(buf)
 		}
 		// validate order by
-		err = qv.validateOrderByExpr(sel.OrderBy)
+		err = qv.validateOrderByExpr(sel.OrderBy, domainSearchAttributes)
 		if err != nil {
 			return "", &workflow.BadRequestError{Message: err.Error()}
 		}
@@ -114,27 +125,27 @@ func (qv *VisibilityQueryValidator) validateListOrCountRequestForQuery(whereClau
 	return whereClause, nil
 }
 
-func (qv *VisibilityQueryValidator) validateWhereExpr(expr sqlparser.Expr) error {
+func (qv *VisibilityQueryValidator) validateWhereExpr(expr sqlparser.Expr, domainSearchAttributes map[string]string) error {
 	if expr == nil {
 		return nil
 	}
 
 	switch expr := expr.(type) {
 	case *sqlparser.AndExpr, *sqlparser.OrExpr:
-		return qv.validateAndOrExpr(expr)
+		return qv.validateAndOrExpr(expr, domainSearchAttributes)
 	case *sqlparser.ComparisonExpr:
-		return qv.validateComparisonExpr(expr)
+		return qv.validateComparisonExpr(expr, domainSearchAttributes)
 	case *sqlparser.RangeCond:
-		return qv.validateRangeExpr(expr)
+		return qv.validateRangeExpr(expr, domainSearchAttributes)
 	case *sqlparser.ParenExpr:
-		return qv.validateWhereExpr(expr.Expr)
+		return qv.validateWhereExpr(expr.Expr, domainSearchAttributes)
 	default:
 		return errors.New("invalid where clause")
 	}
 
 }
 
-func (qv *VisibilityQueryValidator) validateAndOrExpr(expr sqlparser.Expr) error {
+func (qv *VisibilityQueryValidator) validateAndOrExpr(expr sqlparser.Expr, domainSearchAttributes map[string]string) error {
 	var leftExpr sqlparser.Expr
 	var rightExpr sqlparser.Expr
 
@@ -147,20 +158,20 @@ func (qv *VisibilityQueryValidator) validateAndOrExpr(expr sqlparser.Expr) error
 		rightExpr = expr.Right
 	}
 
-	if err := qv.validateWhereExpr(leftExpr); err != nil {
+	if err := qv.validateWhereExpr(leftExpr, domainSearchAttributes); err != nil {
 		return err
 	}
-	return qv.validateWhereExpr(rightExpr)
+	return qv.validateWhereExpr(rightExpr, domainSearchAttributes)
 }
 
-func (qv *VisibilityQueryValidator) validateComparisonExpr(expr sqlparser.Expr) error {
+func (qv *VisibilityQueryValidator) validateComparisonExpr(expr sqlparser.Expr, domainSearchAttributes map[string]string) error {
 	comparisonExpr := expr.(*sqlparser.ComparisonExpr)
 	colName, ok := comparisonExpr.Left.(*sqlparser.ColName)
 	if !ok {
 		return errors.New("invalid comparison expression")
 	}
-	colNameStr := colName.Name.String()
-	if qv.isValidSearchAttributes(colNameStr) {
+	colNameStr, ok := qv.resolveSearchAttribute(colName.Name.String(), domainSearchAttributes)
+	if ok {
 		if !definition.IsSystemIndexedKey(colNameStr) { // add search attribute prefix
 			comparisonExpr.Left = &sqlparser.ColName{
 				Metadata:  colName.Metadata,
@@ -173,14 +184,14 @@ func (qv *VisibilityQueryValidator) validateComparisonExpr(expr sqlparser.Expr)
 	return errors.New("invalid search attribute")
 }
 
-func (qv *VisibilityQueryValidator) validateRangeExpr(expr sqlparser.Expr) error {
+func (qv *VisibilityQueryValidator) validateRangeExpr(expr sqlparser.Expr, domainSearchAttributes map[string]string) error {
 	rangeCond := expr.(*sqlparser.RangeCond)
 	colName, ok := rangeCond.Left.(*sqlparser.ColName)
 	if !ok {
 		return errors.New("invalid range expression")
 	}
-	colNameStr := colName.Name.String()
-	if qv.isValidSearchAttributes(colNameStr) {
+	colNameStr, ok := qv.resolveSearchAttribute(colName.Name.String(), domainSearchAttributes)
+	if ok {
 		if !definition.IsSystemIndexedKey(colNameStr) { // add search attribute prefix
 			rangeCond.Left = &sqlparser.ColName{
 				Metadata:  colName.Metadata,
@@ -193,14 +204,14 @@ func (qv *VisibilityQueryValidator) validateRangeExpr(expr sqlparser.Expr) error
 	return errors.New("invalid search attribute")
 }
 
-func (qv *VisibilityQueryValidator) validateOrderByExpr(orderBy sqlparser.OrderBy) error {
+func (qv *VisibilityQueryValidator) validateOrderByExpr(orderBy sqlparser.OrderBy, domainSearchAttributes map[string]string) error {
 	for _, orderByExpr := range orderBy {
 		colName, ok := orderByExpr.Expr.(*sqlparser.ColName)
 		if !ok {
 			return errors.New("invalid order by expression")
 		}
-		colNameStr := colName.Name.String()
-		if qv.isValidSearchAttributes(colNameStr) {
+		colNameStr, ok := qv.resolveSearchAttribute(colName.Name.String(), domainSearchAttributes)
+		if ok {
 			if !definition.IsSystemIndexedKey(colNameStr) { // add search attribute prefix
 				orderByExpr.Expr = &sqlparser.ColName{
 					Metadata:  colName.Metadata,
@@ -215,6 +226,19 @@ func (qv *VisibilityQueryValidator) validateOrderByExpr(orderBy sqlparser.OrderB
 	return nil
 }
 
+// resolveSearchAttribute returns the cluster search attribute of key, which is either
+// registered on the domain or whitelisted, and false if key is not a valid search attribute
+func (qv *VisibilityQueryValidator) resolveSearchAttribute(key string, domainSearchAttributes map[string]string) (string, bool) {
+	if clusterKey, ok := domainSearchAttributes[key]; ok {
+		return clusterKey, true
+	}
+	// the cluster search attributes storing the search attributes of the domain can only be used through those
+	if isClusterKeyOfDomain(key, domainSearchAttributes) {
+		return key, false
+	}
+	return key, qv.isValidSearchAttributes(key)
+}
+
 // isValidSearchAttributes return true if key is registered
 func (qv *VisibilityQueryValidator) isValidSearchAttributes(key string) bool {
 	validAttr := qv.validSearchAttributes()
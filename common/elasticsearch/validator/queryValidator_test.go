@@ -21,6 +21,7 @@
 package validator
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -42,7 +43,7 @@ func TestQueryValidatorSuite(t *testing.T) {
 
 func (s *queryValidatorSuite) TestValidateListRequestForQuery() {
 	validSearchAttr := dynamicconfig.GetMapPropertyFn(definition.GetDefaultIndexedKeys())
-	qv := NewQueryValidator(validSearchAttr)
+	qv := NewQueryValidator(validSearchAttr, GetEmptyDomainSearchAttributesFn())
 
 	listRequest := &shared.ListWorkflowExecutionsRequest{}
 	s.Nil(qv.ValidateListRequestForQuery(listRequest))
@@ -132,3 +133,37 @@ func (s *queryValidatorSuite) TestValidateListRequestForQuery() {
 	listRequest.Query = common.StringPtr(query)
 	s.NotNil(qv.ValidateListRequestForQuery(listRequest))
 }
+
+func (s *queryValidatorSuite) TestValidateListRequestForQuery_DomainSearchAttributes() {
+	validSearchAttr := dynamicconfig.GetMapPropertyFn(definition.GetDefaultIndexedKeys())
+	qv := NewQueryValidator(validSearchAttr, func(domain string) (map[string]string, error) {
+		s.Equal("domain", domain)
+		return map[string]string{"OrderID": "CustomKeywordField"}, nil
+	})
+
+	listRequest := &shared.ListWorkflowExecutionsRequest{Domain: common.StringPtr("domain")}
+	query := "OrderID = 'order' and CustomIntField between 1 and 10 order by OrderID desc"
+	listRequest.Query = common.StringPtr(query)
+	s.Nil(qv.ValidateListRequestForQuery(listRequest))
+	s.Equal("`Attr.CustomKeywordField` = 'order' and `Attr.CustomIntField` between 1 and 10 order by `Attr.CustomKeywordField` desc", listRequest.GetQuery())
+
+	countRequest := &shared.CountWorkflowExecutionsRequest{Domain: common.StringPtr("domain")}
+	countRequest.Query = common.StringPtr("OrderID between 'a' and 'b'")
+	s.Nil(qv.ValidateCountRequestForQuery(countRequest))
+	s.Equal("`Attr.CustomKeywordField` between 'a' and 'b'", countRequest.GetQuery())
+
+	listRequest.Query = common.StringPtr("CustomerID = 'customer'")
+	s.Equal("BadRequestError{Message: invalid search attribute}", qv.ValidateListRequestForQuery(listRequest).Error())
+
+	listRequest.Query = common.StringPtr("CustomKeywordField = 'order'")
+	s.Equal("BadRequestError{Message: invalid search attribute}", qv.ValidateListRequestForQuery(listRequest).Error())
+
+	listRequest.Query = common.StringPtr("order by CustomKeywordField")
+	s.Equal("BadRequestError{Message: invalid order by attribute}", qv.ValidateListRequestForQuery(listRequest).Error())
+
+	qv = NewQueryValidator(validSearchAttr, func(domain string) (map[string]string, error) {
+		return nil, errors.New("domain not found")
+	})
+	listRequest.Query = common.StringPtr("WorkflowID = 'wid'")
+	s.EqualError(qv.ValidateListRequestForQuery(listRequest), "domain not found")
+}
@@ -35,6 +35,7 @@ type SearchAttributesValidator struct {
 	logger log.Logger
 
 	validSearchAttributes             dynamicconfig.MapPropertyFn
+	domainSearchAttributes            DomainSearchAttributesFn
 	searchAttributesNumberOfKeysLimit dynamicconfig.IntPropertyFnWithDomainFilter
 	searchAttributesSizeOfValueLimit  dynamicconfig.IntPropertyFnWithDomainFilter
 	searchAttributesTotalSizeLimit    dynamicconfig.IntPropertyFnWithDomainFilter
@@ -44,6 +45,7 @@ type SearchAttributesValidator struct {
 func NewSearchAttributesValidator(
 	logger log.Logger,
 	validSearchAttributes dynamicconfig.MapPropertyFn,
+	domainSearchAttributes DomainSearchAttributesFn,
 	searchAttributesNumberOfKeysLimit dynamicconfig.IntPropertyFnWithDomainFilter,
 	searchAttributesSizeOfValueLimit dynamicconfig.IntPropertyFnWithDomainFilter,
 	searchAttributesTotalSizeLimit dynamicconfig.IntPropertyFnWithDomainFilter,
@@ -51,18 +53,25 @@ func NewSearchAttributesValidator(
 	return &SearchAttributesValidator{
 		logger:                            logger,
 		validSearchAttributes:             validSearchAttributes,
+		domainSearchAttributes:            domainSearchAttributes,
 		searchAttributesNumberOfKeysLimit: searchAttributesNumberOfKeysLimit,
 		searchAttributesSizeOfValueLimit:  searchAttributesSizeOfValueLimit,
 		searchAttributesTotalSizeLimit:    searchAttributesTotalSizeLimit,
 	}
 }
 
-// ValidateSearchAttributes validate search attributes are valid for writing and not exceed limits
+// ValidateSearchAttributes validate search attributes are valid for writing and not exceed limits,
+// the cluster search attributes storing the search attributes registered on the domain can only be used through those
 func (sv *SearchAttributesValidator) ValidateSearchAttributes(input *gen.SearchAttributes, domain string) error {
 	if input == nil {
 		return nil
 	}
 
+	domainSearchAttributes, err := sv.domainSearchAttributes(domain)
+	if err != nil {
+		return err
+	}
+
 	// verify: number of keys <= limit
 	fields := input.GetIndexedFields()
 	lengthOfFields := len(fields)
@@ -74,8 +83,8 @@ func (sv *SearchAttributesValidator) ValidateSearchAttributes(input *gen.SearchA
 
 	totalSize := 0
 	for key, val := range fields {
-		// verify: key is whitelisted
-		if !sv.isValidSearchAttributes(key) {
+		// verify: key is registered on the domain, or whitelisted and not storing a search attribute of the domain
+		if !sv.isValidDomainSearchAttributes(key, domainSearchAttributes) {
 			sv.logger.WithTags(tag.ESKey(key), tag.WorkflowDomainName(domain)).
 				Error("invalid search attribute")
 			return &gen.BadRequestError{Message: fmt.Sprintf("%s is not valid search attribute", key)}
@@ -105,6 +114,31 @@ func (sv *SearchAttributesValidator) ValidateSearchAttributes(input *gen.SearchA
 	return nil
 }
 
+// ResolveSearchAttributes returns a copy of validated search attributes in which the search attributes
+// registered on the domain are replaced by the cluster search attributes they are stored in
+func (sv *SearchAttributesValidator) ResolveSearchAttributes(input *gen.SearchAttributes, domain string) (*gen.SearchAttributes, error) {
+	if input == nil {
+		return nil, nil
+	}
+
+	domainSearchAttributes, err := sv.domainSearchAttributes(domain)
+	if err != nil {
+		return nil, err
+	}
+	return &gen.SearchAttributes{
+		IndexedFields: ToClusterSearchAttributes(input.GetIndexedFields(), domainSearchAttributes),
+	}, nil
+}
+
+// isValidDomainSearchAttributes return true if key is registered on the domain,
+// or if key is whitelisted and does not store a search attribute registered on the domain
+func (sv *SearchAttributesValidator) isValidDomainSearchAttributes(key string, domainSearchAttributes map[string]string) bool {
+	if _, ok := domainSearchAttributes[key]; ok {
+		return true
+	}
+	return !isClusterKeyOfDomain(key, domainSearchAttributes) && sv.isValidSearchAttributes(key)
+}
+
 // isValidSearchAttributes return true if key is registered
 func (sv *SearchAttributesValidator) isValidSearchAttributes(key string) bool {
 	validAttr := sv.validSearchAttributes()
@@ -47,6 +47,7 @@ func (s *searchAttributesValidatorSuite) TestValidateSearchAttributes() {
 
 	validator := NewSearchAttributesValidator(log.NewNoop(),
 		dynamicconfig.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+		GetEmptyDomainSearchAttributesFn(),
 		dynamicconfig.GetIntPropertyFilteredByDomain(numOfKeysLimit),
 		dynamicconfig.GetIntPropertyFilteredByDomain(sizeOfValueLimit),
 		dynamicconfig.GetIntPropertyFilteredByDomain(sizeOfTotalLimit))
@@ -104,3 +105,70 @@ func (s *searchAttributesValidatorSuite) TestValidateSearchAttributes() {
 	err = validator.ValidateSearchAttributes(attr, domain)
 	s.Equal(`BadRequestError{Message: total size 40 exceed limit}`, err.Error())
 }
+
+func (s *searchAttributesValidatorSuite) TestValidateSearchAttributes_DomainSearchAttributes() {
+	validator := NewSearchAttributesValidator(log.NewNoop(),
+		dynamicconfig.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+		func(domain string) (map[string]string, error) {
+			return map[string]string{
+				"OrderID":  "CustomKeywordField",
+				"Priority": "CustomIntField",
+			}, nil
+		},
+		dynamicconfig.GetIntPropertyFilteredByDomain(10),
+		dynamicconfig.GetIntPropertyFilteredByDomain(100),
+		dynamicconfig.GetIntPropertyFilteredByDomain(1000))
+
+	domain := "domain"
+	attr := &gen.SearchAttributes{
+		IndexedFields: map[string][]byte{
+			"OrderID":           []byte(`"order"`),
+			"CustomStringField": []byte(`"string"`),
+		},
+	}
+	s.Nil(validator.ValidateSearchAttributes(attr, domain))
+	s.Equal(map[string][]byte{
+		"OrderID":           []byte(`"order"`),
+		"CustomStringField": []byte(`"string"`),
+	}, attr.IndexedFields)
+
+	resolved, err := validator.ResolveSearchAttributes(attr, domain)
+	s.NoError(err)
+	s.Equal(map[string][]byte{
+		"CustomKeywordField": []byte(`"order"`),
+		"CustomStringField":  []byte(`"string"`),
+	}, resolved.IndexedFields)
+	s.Equal(map[string][]byte{
+		"OrderID":           []byte(`"order"`),
+		"CustomStringField": []byte(`"string"`),
+	}, attr.IndexedFields)
+
+	attr.IndexedFields = map[string][]byte{
+		"Priority":       []byte(`1`),
+		"CustomIntField": []byte(`2`),
+	}
+	s.EqualError(validator.ValidateSearchAttributes(attr, domain), `BadRequestError{Message: CustomIntField is not valid search attribute}`)
+
+	attr.IndexedFields = map[string][]byte{
+		"CustomKeywordField": []byte(`"order"`),
+	}
+	s.EqualError(validator.ValidateSearchAttributes(attr, domain), `BadRequestError{Message: CustomKeywordField is not valid search attribute}`)
+
+	attr.IndexedFields = map[string][]byte{
+		"CustomerID": []byte(`"customer"`),
+	}
+	s.EqualError(validator.ValidateSearchAttributes(attr, domain), `BadRequestError{Message: CustomerID is not valid search attribute}`)
+}
+
+func (s *searchAttributesValidatorSuite) TestToDomainSearchAttributes() {
+	domainSearchAttributes := map[string]string{"OrderID": "CustomKeywordField"}
+	fields := map[string][]byte{
+		"CustomKeywordField": []byte(`"order"`),
+		"CustomIntField":     []byte(`1`),
+	}
+	s.Equal(map[string][]byte{
+		"OrderID":        []byte(`"order"`),
+		"CustomIntField": []byte(`1`),
+	}, ToDomainSearchAttributes(fields, domainSearchAttributes))
+	s.Equal(fields, ToDomainSearchAttributes(fields, nil))
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/temporalio/temporal/common/membership"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+const (
+	serviceProbeTimeout = time.Second
+	maxServiceProbes    = 3
+)
+
+type (
+	// Probe returns an error when the given host of a downstream service does not respond to requests
+	Probe func(ctx context.Context, host *membership.HostInfo) error
+)
+
+var errNotInMembership = errors.New("host has not joined the membership ring yet")
+
+// PersistenceCheck checks that the persistence store can be read
+func PersistenceCheck(metadataManager persistence.MetadataManager) Check {
+	return func() error {
+		_, err := metadataManager.GetMetadata()
+		return err
+	}
+}
+
+// MembershipCheck checks that the host is part of the membership ring of its service
+func MembershipCheck(monitor membership.Monitor, service string) Check {
+	return func() error {
+		self, err := monitor.WhoAmI()
+		if err != nil {
+			return err
+		}
+		resolver, err := monitor.GetResolver(service)
+		if err != nil {
+			return err
+		}
+		for _, member := range resolver.Members() {
+			if member.Identity() == self.Identity() {
+				return nil
+			}
+		}
+		return errNotInMembership
+	}
+}
+
+// ServiceCheck checks that at least one host of a downstream service is in the membership ring and
+// responds to the probe, a few hosts are probed before the service is considered unreachable
+func ServiceCheck(resolver membership.ServiceResolver, service string, probe Probe) Check {
+	return func() error {
+		members := resolver.Members()
+		if len(members) == 0 {
+			return fmt.Errorf("no %v hosts in the membership ring", service)
+		}
+
+		var err error
+		for i := 0; i < len(members) && i < maxServiceProbes; i++ {
+			ctx, cancel := context.WithTimeout(context.Background(), serviceProbeTimeout)
+			err = probe(ctx, members[i])
+			cancel()
+			if err == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("no %v hosts are reachable: %v", service, err)
+	}
+}
+
+// GRPCProbe probes a host through the standard gRPC health protocol of the given gRPC service
+func GRPCProbe(connect func(address string) *grpc.ClientConn, grpcService string) Probe {
+	return func(ctx context.Context, host *membership.HostInfo) error {
+		connection := connect(host.GetAddress())
+		defer connection.Close()
+
+		resp, err := healthpb.NewHealthClient(connection).Check(ctx, &healthpb.HealthCheckRequest{Service: grpcService})
+		if err != nil {
+			return err
+		}
+		if resp.Status != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("host %v is %v", host.GetAddress(), resp.Status)
+		}
+		return nil
+	}
+}
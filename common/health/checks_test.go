@@ -0,0 +1,129 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/membership"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+type (
+	checksSuite struct {
+		*require.Assertions
+		suite.Suite
+
+		controller   *gomock.Controller
+		mockResolver *membership.MockServiceResolver
+	}
+)
+
+func TestChecksSuite(t *testing.T) {
+	suite.Run(t, new(checksSuite))
+}
+
+func (s *checksSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.controller = gomock.NewController(s.T())
+	s.mockResolver = membership.NewMockServiceResolver(s.controller)
+}
+
+func (s *checksSuite) TearDownTest() {
+	s.controller.Finish()
+}
+
+func (s *checksSuite) TestServiceCheck_NoMembers() {
+	s.mockResolver.EXPECT().Members().Return(nil)
+	check := ServiceCheck(s.mockResolver, "history", func(context.Context, *membership.HostInfo) error {
+		s.Fail("no host to probe")
+		return nil
+	})
+
+	s.EqualError(check(), "no history hosts in the membership ring")
+}
+
+func (s *checksSuite) TestServiceCheck_ReachableMember() {
+	s.mockResolver.EXPECT().Members().Return([]*membership.HostInfo{
+		membership.NewHostInfo("down:7934", nil),
+		membership.NewHostInfo("up:7934", nil),
+	})
+	var probed []string
+	check := ServiceCheck(s.mockResolver, "history", func(_ context.Context, host *membership.HostInfo) error {
+		probed = append(probed, host.GetAddress())
+		if host.GetAddress() == "down:7934" {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+
+	s.NoError(check())
+	s.Equal([]string{"down:7934", "up:7934"}, probed)
+}
+
+func (s *checksSuite) TestServiceCheck_UnreachableMembers() {
+	var members []*membership.HostInfo
+	for _, address := range []string{"a:7934", "b:7934", "c:7934", "d:7934"} {
+		members = append(members, membership.NewHostInfo(address, nil))
+	}
+	s.mockResolver.EXPECT().Members().Return(members)
+	probes := 0
+	check := ServiceCheck(s.mockResolver, "matching", func(context.Context, *membership.HostInfo) error {
+		probes++
+		return errors.New("connection refused")
+	})
+
+	s.EqualError(check(), "no matching hosts are reachable: connection refused")
+	s.Equal(maxServiceProbes, probes)
+}
+
+func (s *checksSuite) TestGRPCProbe() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.NoError(err)
+	grpcServer := grpc.NewServer()
+	healthServer := NewServer(testGRPCService, config.Health{}, loggerimpl.NewNopLogger())
+	healthServer.RegisterGRPC(grpcServer)
+	go func() { _ = grpcServer.Serve(listener) }()
+	defer grpcServer.Stop()
+
+	connect := func(address string) *grpc.ClientConn {
+		connection, err := grpc.Dial(address, grpc.WithInsecure())
+		s.NoError(err)
+		return connection
+	}
+	probe := GRPCProbe(connect, testGRPCService)
+	host := membership.NewHostInfo(listener.Addr().String(), nil)
+
+	s.Error(probe(context.Background(), host))
+
+	healthServer.Start()
+	defer healthServer.Stop()
+	s.NoError(probe(context.Background(), host))
+}
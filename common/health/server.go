@@ -0,0 +1,219 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+const (
+	defaultCheckInterval = 5 * time.Second
+	httpShutdownTimeout  = 5 * time.Second
+)
+
+type (
+	// Check returns an error when the state it checks is not ready to serve requests yet
+	Check func() error
+
+	// Server reports the liveness and readiness of a service through the standard gRPC health
+	// protocol and the HTTP /health and /ready endpoints. A running service is always live,
+	// it is ready once all of its checks pass
+	Server struct {
+		status         int32
+		grpcService    string
+		config         config.Health
+		logger         log.Logger
+		grpcHealth     *health.Server
+		httpServer     *http.Server
+		checks         map[string]Check
+		shutdownCh     chan struct{}
+		shutdownWG     sync.WaitGroup
+		checkLock      sync.RWMutex
+		lastCheckError error // nil when all checks passed on their last run
+	}
+)
+
+var errNotChecked = errors.New("readiness not checked yet")
+
+// NewServer returns a health server for the given gRPC service name, the service is not
+// serving until the checks added to the server pass
+func NewServer(grpcService string, cfg config.Health, logger log.Logger) *Server {
+	if cfg.CheckInterval == 0 {
+		cfg.CheckInterval = defaultCheckInterval
+	}
+	grpcHealth := health.NewServer()
+	grpcHealth.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	grpcHealth.SetServingStatus(grpcService, healthpb.HealthCheckResponse_NOT_SERVING)
+	return &Server{
+		status:         common.DaemonStatusInitialized,
+		grpcService:    grpcService,
+		config:         cfg,
+		logger:         logger,
+		grpcHealth:     grpcHealth,
+		checks:         make(map[string]Check),
+		shutdownCh:     make(chan struct{}),
+		lastCheckError: errNotChecked,
+	}
+}
+
+// AddCheck adds a readiness check, checks must be added before the server is started
+func (s *Server) AddCheck(name string, check Check) {
+	s.checks[name] = check
+}
+
+// RegisterGRPC registers the standard gRPC health service on the given server
+func (s *Server) RegisterGRPC(server *grpc.Server) {
+	healthpb.RegisterHealthServer(server, s.grpcHealth)
+}
+
+// Start runs the readiness checks periodically and serves the HTTP endpoints when a port is configured
+func (s *Server) Start() {
+	if !atomic.CompareAndSwapInt32(&s.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+
+	s.runChecks()
+	s.shutdownWG.Add(1)
+	go s.checkLoop()
+
+	if s.config.Port == 0 {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", s.handleHealth)
+	mux.HandleFunc("/ready", s.handleReady)
+	s.httpServer = &http.Server{Addr: fmt.Sprintf(":%v", s.config.Port), Handler: mux}
+	go func() {
+		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			s.logger.Error("Failed to serve health endpoints", tag.Error(err), tag.Port(s.config.Port))
+		}
+	}()
+}
+
+// Stop marks the service as not serving and stops the HTTP endpoints
+func (s *Server) Stop() {
+	if !atomic.CompareAndSwapInt32(&s.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+
+	s.grpcHealth.Shutdown()
+	close(s.shutdownCh)
+	if s.httpServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+		defer cancel()
+		if err := s.httpServer.Shutdown(ctx); err != nil {
+			s.logger.Warn("Failed to stop health endpoints", tag.Error(err))
+		}
+	}
+	if success := common.AwaitWaitGroup(&s.shutdownWG, time.Minute); !success {
+		s.logger.Warn("health server timed out on shutdown.")
+	}
+}
+
+// Ready returns nil when all checks passed on their last run, or an error listing the failed checks
+func (s *Server) Ready() error {
+	s.checkLock.RLock()
+	defer s.checkLock.RUnlock()
+	return s.lastCheckError
+}
+
+func (s *Server) checkLoop() {
+	defer s.shutdownWG.Done()
+
+	ticker := time.NewTicker(s.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.shutdownCh:
+			return
+		case <-ticker.C:
+			s.runChecks()
+		}
+	}
+}
+
+func (s *Server) runChecks() {
+	var failures []string
+	for name, check := range s.checks {
+		if err := check(); err != nil {
+			failures = append(failures, name+": "+err.Error())
+		}
+	}
+
+	var checkErr error
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		checkErr = fmt.Errorf("not ready, %v", strings.Join(failures, "; "))
+	}
+
+	s.checkLock.Lock()
+	wasReady := s.lastCheckError == nil
+	s.lastCheckError = checkErr
+	s.checkLock.Unlock()
+
+	if atomic.LoadInt32(&s.status) == common.DaemonStatusStopped {
+		return
+	}
+	servingStatus := healthpb.HealthCheckResponse_SERVING
+	if checkErr != nil {
+		servingStatus = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	s.grpcHealth.SetServingStatus("", servingStatus)
+	s.grpcHealth.SetServingStatus(s.grpcService, servingStatus)
+	if wasReady && checkErr != nil {
+		s.logger.Warn("Service is no longer ready", tag.Error(checkErr))
+	} else if !wasReady && checkErr == nil {
+		s.logger.Info("Service is ready")
+	}
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("OK\n"))
+}
+
+func (s *Server) handleReady(w http.ResponseWriter, _ *http.Request) {
+	if err := s.Ready(); err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(err.Error() + "\n"))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("OK\n"))
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+type (
+	healthServerSuite struct {
+		*require.Assertions
+		suite.Suite
+	}
+)
+
+const testGRPCService = "test.TestService"
+
+func TestHealthServerSuite(t *testing.T) {
+	suite.Run(t, new(healthServerSuite))
+}
+
+func (s *healthServerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *healthServerSuite) newServer() *Server {
+	return NewServer(testGRPCService, config.Health{}, loggerimpl.NewNopLogger())
+}
+
+func (s *healthServerSuite) servingStatus(server *Server) healthpb.HealthCheckResponse_ServingStatus {
+	resp, err := server.grpcHealth.Check(context.Background(), &healthpb.HealthCheckRequest{Service: testGRPCService})
+	s.NoError(err)
+	return resp.Status
+}
+
+func (s *healthServerSuite) TestNotReadyBeforeStart() {
+	server := s.newServer()
+	server.AddCheck("ok", func() error { return nil })
+
+	s.Error(server.Ready())
+	s.Equal(healthpb.HealthCheckResponse_NOT_SERVING, s.servingStatus(server))
+}
+
+func (s *healthServerSuite) TestReadyWhenChecksPass() {
+	server := s.newServer()
+	server.AddCheck("ok", func() error { return nil })
+	server.Start()
+	defer server.Stop()
+
+	s.NoError(server.Ready())
+	s.Equal(healthpb.HealthCheckResponse_SERVING, s.servingStatus(server))
+}
+
+func (s *healthServerSuite) TestReadinessFollowsChecks() {
+	var checkErr error
+	server := s.newServer()
+	server.AddCheck("ok", func() error { return nil })
+	server.AddCheck("flaky", func() error { return checkErr })
+	server.Start()
+	defer server.Stop()
+	s.NoError(server.Ready())
+
+	checkErr = errors.New("connection refused")
+	server.runChecks()
+	s.EqualError(server.Ready(), "not ready, flaky: connection refused")
+	s.Equal(healthpb.HealthCheckResponse_NOT_SERVING, s.servingStatus(server))
+
+	checkErr = nil
+	server.runChecks()
+	s.NoError(server.Ready())
+	s.Equal(healthpb.HealthCheckResponse_SERVING, s.servingStatus(server))
+}
+
+func (s *healthServerSuite) TestNotServingAfterStop() {
+	server := s.newServer()
+	server.Start()
+	s.Equal(healthpb.HealthCheckResponse_SERVING, s.servingStatus(server))
+
+	server.Stop()
+	s.Equal(healthpb.HealthCheckResponse_NOT_SERVING, s.servingStatus(server))
+}
+
+func (s *healthServerSuite) TestHTTPEndpoints() {
+	server := s.newServer()
+	server.AddCheck("persistence", func() error { return errors.New("timeout") })
+	server.Start()
+	defer server.Stop()
+
+	recorder := httptest.NewRecorder()
+	server.handleHealth(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+	s.Equal(http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	server.handleReady(recorder, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	s.Equal(http.StatusServiceUnavailable, recorder.Code)
+	s.Equal("not ready, persistence: timeout\n", recorder.Body.String())
+}
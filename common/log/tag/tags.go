@@ -671,6 +671,38 @@ func TokenLastEventVersion(version int64) Tag {
 	return newInt64("xdc-token-last-event-version", version)
 }
 
+///////////////////  Tracing tags defined here: trace- ///////////////////
+
+// TraceID returns tag for TraceID
+func TraceID(traceID string) Tag {
+	return newStringTag("trace-id", traceID)
+}
+
+// SpanID returns tag for SpanID
+func SpanID(spanID string) Tag {
+	return newStringTag("trace-span-id", spanID)
+}
+
+// ParentSpanID returns tag for ParentSpanID
+func ParentSpanID(parentSpanID string) Tag {
+	return newStringTag("trace-parent-span-id", parentSpanID)
+}
+
+// SpanOperation returns tag for SpanOperation
+func SpanOperation(operation string) Tag {
+	return newStringTag("trace-operation", operation)
+}
+
+// SpanDuration returns tag for SpanDuration
+func SpanDuration(duration time.Duration) Tag {
+	return newDurationTag("trace-duration", duration)
+}
+
+// SpanTags returns tag for SpanTags
+func SpanTags(tags interface{}) Tag {
+	return newObjectTag("trace-tags", tags)
+}
+
 ///////////////////  Archival tags defined here: archival- ///////////////////
 // archival request tags
 
@@ -0,0 +1,94 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dbmembership
+
+import (
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/membership"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+var errHeartbeatTimeoutTooShort = errors.New("membership heartbeat timeout must be larger than the heartbeat interval")
+
+// Factory creates the database backed membership monitor of a service
+type Factory struct {
+	serviceName    string
+	servicePortMap map[string]int
+	hostIP         net.IP
+	config         *Config
+	manager        persistence.ClusterMetadataManager
+	logger         log.Logger
+
+	sync.Mutex
+	membershipMonitor membership.Monitor
+}
+
+// NewFactory builds a factory of database backed membership monitors, the host is registered
+// with the given IP and the port of its service from the service port map
+func NewFactory(
+	serviceName string,
+	servicePortMap map[string]int,
+	hostIP net.IP,
+	config *Config,
+	manager persistence.ClusterMetadataManager,
+	logger log.Logger,
+) (*Factory, error) {
+
+	if config.HeartbeatInterval == 0 {
+		config.HeartbeatInterval = DefaultHeartbeatInterval
+	}
+	if config.HeartbeatTimeout == 0 {
+		config.HeartbeatTimeout = DefaultHeartbeatTimeout
+	}
+	if config.HeartbeatTimeout <= config.HeartbeatInterval {
+		return nil, errHeartbeatTimeoutTooShort
+	}
+	return &Factory{
+		serviceName:    serviceName,
+		servicePortMap: servicePortMap,
+		hostIP:         hostIP,
+		config:         config,
+		manager:        manager,
+		logger:         logger,
+	}, nil
+}
+
+// GetMembershipMonitor return a membership monitor
+func (factory *Factory) GetMembershipMonitor() (membership.Monitor, error) {
+	factory.Lock()
+	defer factory.Unlock()
+
+	if factory.membershipMonitor == nil {
+		factory.membershipMonitor = NewMonitor(
+			factory.serviceName,
+			factory.servicePortMap,
+			factory.hostIP,
+			factory.config,
+			factory.manager,
+			factory.logger,
+		)
+	}
+	return factory.membershipMonitor, nil
+}
@@ -0,0 +1,325 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dbmembership
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pborman/uuid"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/membership"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+const (
+	// DefaultHeartbeatInterval is how often a host heartbeats into the cluster membership table by default
+	DefaultHeartbeatInterval = 5 * time.Second
+	// DefaultHeartbeatTimeout is how long a host stays in the ring without heartbeat by default
+	DefaultHeartbeatTimeout = 30 * time.Second
+
+	// membershipRecordExpiry keeps the records of dead hosts around for debugging until they are pruned
+	membershipRecordExpiry = 48 * time.Hour
+	// membershipPruneInterval is how often the expired records are pruned by a single host of the cluster
+	membershipPruneInterval = time.Minute
+	membershipPageSize      = 1000
+	maxRecordsPruned        = 100
+)
+
+type (
+	// Config is the config of the database backed membership monitor
+	Config struct {
+		HeartbeatInterval time.Duration
+		HeartbeatTimeout  time.Duration
+	}
+
+	dbMonitor struct {
+		status int32
+
+		serviceName  string
+		services     map[string]int
+		hostID       uuid.UUID
+		hostIP       net.IP
+		sessionStart time.Time
+		config       *Config
+		manager      persistence.ClusterMetadataManager
+		rings        map[string]*dbServiceResolver
+		logger       log.Logger
+
+		shutdownCh chan struct{}
+		shutdownWG sync.WaitGroup
+	}
+)
+
+var _ membership.Monitor = (*dbMonitor)(nil)
+
+// NewMonitor returns a membership monitor which heartbeats the host into the cluster membership table
+// and builds the rings of all services from the hosts which heartbeat recently
+func NewMonitor(
+	serviceName string,
+	services map[string]int,
+	hostIP net.IP,
+	config *Config,
+	manager persistence.ClusterMetadataManager,
+	logger log.Logger,
+) membership.Monitor {
+
+	m := &dbMonitor{
+		status:       common.DaemonStatusInitialized,
+		serviceName:  serviceName,
+		services:     services,
+		hostID:       uuid.NewRandom(),
+		hostIP:       hostIP,
+		sessionStart: time.Now().UTC(),
+		config:       config,
+		manager:      manager,
+		rings:        make(map[string]*dbServiceResolver),
+		logger:       logger.WithTags(tag.ComponentServiceResolver),
+		shutdownCh:   make(chan struct{}),
+	}
+	for service, port := range services {
+		m.rings[service] = newDBServiceResolver(service, port, config, manager, logger)
+	}
+	return m
+}
+
+func (m *dbMonitor) Start() {
+	if !atomic.CompareAndSwapInt32(
+		&m.status,
+		common.DaemonStatusInitialized,
+		common.DaemonStatusStarted,
+	) {
+		return
+	}
+
+	if err := m.heartbeat(); err != nil {
+		m.logger.Fatal("unable to join the cluster membership", tag.Error(err))
+	}
+
+	for _, ring := range m.rings {
+		ring.Start()
+	}
+
+	m.shutdownWG.Add(1)
+	go m.heartbeatWorker()
+}
+
+func (m *dbMonitor) Stop() {
+	if !atomic.CompareAndSwapInt32(
+		&m.status,
+		common.DaemonStatusStarted,
+		common.DaemonStatusStopped,
+	) {
+		return
+	}
+
+	close(m.shutdownCh)
+	for _, ring := range m.rings {
+		ring.Stop()
+	}
+
+	if success := common.AwaitWaitGroup(&m.shutdownWG, time.Minute); !success {
+		m.logger.Warn("membership monitor timed out on shutdown.")
+	}
+
+	// leave the rings right away instead of waiting for the heartbeat to time out
+	if err := m.leave(); err != nil {
+		m.logger.Warn("error leaving the cluster membership", tag.Error(err))
+	}
+}
+
+// WhoAmI returns the address (host:port) and labels of this host for its service
+func (m *dbMonitor) WhoAmI() (*membership.HostInfo, error) {
+	port, ok := m.services[m.serviceName]
+	if !ok {
+		return nil, membership.ErrUnknownService
+	}
+	return membership.NewHostInfo(hostAddress(m.hostIP, port), roleLabels(m.serviceName)), nil
+}
+
+func (m *dbMonitor) GetResolver(service string) (membership.ServiceResolver, error) {
+	ring, found := m.rings[service]
+	if !found {
+		return nil, membership.ErrUnknownService
+	}
+	return ring, nil
+}
+
+func (m *dbMonitor) Lookup(service string, key string) (*membership.HostInfo, error) {
+	ring, err := m.GetResolver(service)
+	if err != nil {
+		return nil, err
+	}
+	return ring.Lookup(key)
+}
+
+func (m *dbMonitor) AddListener(service string, name string, notifyChannel chan<- *membership.ChangedEvent) error {
+	ring, err := m.GetResolver(service)
+	if err != nil {
+		return err
+	}
+	return ring.AddListener(name, notifyChannel)
+}
+
+func (m *dbMonitor) RemoveListener(service string, name string) error {
+	ring, err := m.GetResolver(service)
+	if err != nil {
+		return err
+	}
+	return ring.RemoveListener(name)
+}
+
+// GetReachableMembers returns the addresses of all hosts which heartbeat recently
+func (m *dbMonitor) GetReachableMembers() ([]string, error) {
+	members, err := getActiveMembers(m.manager, persistence.All, m.config.HeartbeatTimeout)
+	if err != nil {
+		return nil, err
+	}
+	var addresses []string
+	for _, member := range members {
+		addresses = append(addresses, hostAddress(member.RPCAddress, int(member.RPCPort)))
+	}
+	return addresses, nil
+}
+
+func (m *dbMonitor) heartbeatWorker() {
+	defer m.shutdownWG.Done()
+
+	heartbeatTicker := time.NewTicker(m.config.HeartbeatInterval)
+	defer heartbeatTicker.Stop()
+	pruneTicker := time.NewTicker(membershipPruneInterval)
+	defer pruneTicker.Stop()
+
+	for {
+		select {
+		case <-m.shutdownCh:
+			return
+		case <-heartbeatTicker.C:
+			if err := m.heartbeat(); err != nil {
+				m.logger.Error("error heartbeating into the cluster membership", tag.Error(err))
+			}
+		case <-pruneTicker.C:
+			if err := m.prune(); err != nil {
+				m.logger.Warn("error pruning expired cluster membership records", tag.Error(err))
+			}
+		}
+	}
+}
+
+// prune removes the expired records from the cluster membership table. Only the active host with
+// the lowest host ID prunes, so that the hosts of the cluster don't all delete the same records
+func (m *dbMonitor) prune() error {
+	members, err := getActiveMembers(m.manager, persistence.All, m.config.HeartbeatTimeout)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		if bytes.Compare(member.HostID, m.hostID) < 0 {
+			return nil
+		}
+	}
+	return m.manager.PruneClusterMembership(&persistence.PruneClusterMembershipRequest{
+		MaxRecordsPruned: maxRecordsPruned,
+	})
+}
+
+func (m *dbMonitor) leave() error {
+	role, err := serviceType(m.serviceName)
+	if err != nil {
+		return err
+	}
+	return m.manager.DeleteClusterMembership(&persistence.DeleteClusterMembershipRequest{
+		Role:   role,
+		HostID: m.hostID,
+	})
+}
+
+func (m *dbMonitor) heartbeat() error {
+	role, err := serviceType(m.serviceName)
+	if err != nil {
+		return err
+	}
+	return m.manager.UpsertClusterMembership(&persistence.UpsertClusterMembershipRequest{
+		Role:         role,
+		HostID:       m.hostID,
+		RPCAddress:   m.hostIP,
+		RPCPort:      uint16(m.services[m.serviceName]),
+		SessionStart: m.sessionStart,
+		RecordExpiry: membershipRecordExpiry,
+	})
+}
+
+// getActiveMembers returns the hosts of the given role which heartbeat within the given duration
+func getActiveMembers(
+	manager persistence.ClusterMetadataManager,
+	role persistence.ServiceType,
+	heartbeatWithin time.Duration,
+) ([]*persistence.ClusterMember, error) {
+
+	var members []*persistence.ClusterMember
+	var nextPageToken []byte
+	for {
+		resp, err := manager.GetClusterMembers(&persistence.GetClusterMembersRequest{
+			LastHeartbeatWithin: heartbeatWithin,
+			RoleEquals:          role,
+			PageSize:            membershipPageSize,
+			NextPageToken:       nextPageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, resp.ActiveMembers...)
+		if len(resp.NextPageToken) == 0 {
+			return members, nil
+		}
+		nextPageToken = resp.NextPageToken
+	}
+}
+
+func serviceType(service string) (persistence.ServiceType, error) {
+	switch service {
+	case common.FrontendServiceName:
+		return persistence.Frontend, nil
+	case common.HistoryServiceName:
+		return persistence.History, nil
+	case common.MatchingServiceName:
+		return persistence.Matching, nil
+	case common.WorkerServiceName:
+		return persistence.Worker, nil
+	}
+	return persistence.All, fmt.Errorf("unknown service %v", service)
+}
+
+func hostAddress(ip net.IP, port int) string {
+	return net.JoinHostPort(ip.String(), strconv.Itoa(port))
+}
+
+func roleLabels(service string) map[string]string {
+	return map[string]string{membership.RoleKey: service}
+}
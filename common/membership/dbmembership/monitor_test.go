@@ -0,0 +1,236 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dbmembership
+
+import (
+	"bytes"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/membership"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	dbMembershipSuite struct {
+		*require.Assertions
+		suite.Suite
+
+		manager *testClusterMetadataManager
+		config  *Config
+	}
+
+	// testClusterMetadataManager keeps the cluster membership in memory
+	testClusterMetadataManager struct {
+		persistence.ClusterMetadataManager
+
+		sync.Mutex
+		members map[string]*persistence.ClusterMember
+		prunes  int
+	}
+)
+
+func TestDBMembershipSuite(t *testing.T) {
+	suite.Run(t, new(dbMembershipSuite))
+}
+
+func (s *dbMembershipSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.manager = &testClusterMetadataManager{members: make(map[string]*persistence.ClusterMember)}
+	s.config = &Config{
+		HeartbeatInterval: DefaultHeartbeatInterval,
+		HeartbeatTimeout:  DefaultHeartbeatTimeout,
+	}
+}
+
+func (s *dbMembershipSuite) TestMonitor_Heartbeat() {
+	services := map[string]int{
+		common.FrontendServiceName: 7233,
+		common.HistoryServiceName:  7234,
+	}
+	monitor := NewMonitor(common.HistoryServiceName, services, net.ParseIP("10.0.0.1"), s.config, s.manager, loggerimpl.NewNopLogger())
+	monitor.Start()
+	defer monitor.Stop()
+
+	s.Len(s.manager.members, 1)
+	for _, member := range s.manager.members {
+		s.Equal(persistence.History, member.Role)
+		s.Equal("10.0.0.1", member.RPCAddress.String())
+		s.Equal(uint16(7234), member.RPCPort)
+	}
+
+	host, err := monitor.WhoAmI()
+	s.NoError(err)
+	s.Equal("10.0.0.1:7234", host.GetAddress())
+
+	host, err = monitor.Lookup(common.HistoryServiceName, "key")
+	s.NoError(err)
+	s.Equal("10.0.0.1:7234", host.GetAddress())
+	_, err = monitor.Lookup(common.FrontendServiceName, "key")
+	s.Equal(membership.ErrInsufficientHosts, err)
+	_, err = monitor.Lookup(common.MatchingServiceName, "key")
+	s.Equal(membership.ErrUnknownService, err)
+
+	members, err := monitor.GetReachableMembers()
+	s.NoError(err)
+	s.Equal([]string{"10.0.0.1:7234"}, members)
+}
+
+func (s *dbMembershipSuite) TestMonitor_StopLeavesCluster() {
+	services := map[string]int{common.HistoryServiceName: 7234}
+	monitor := NewMonitor(common.HistoryServiceName, services, net.ParseIP("10.0.0.1"), s.config, s.manager, loggerimpl.NewNopLogger())
+	monitor.Start()
+	s.Len(s.manager.members, 1)
+
+	monitor.Stop()
+	s.Empty(s.manager.members)
+}
+
+func (s *dbMembershipSuite) TestMonitor_SingleHostPrunes() {
+	services := map[string]int{common.HistoryServiceName: 7234}
+	monitors := []*dbMonitor{
+		NewMonitor(common.HistoryServiceName, services, net.ParseIP("10.0.0.1"), s.config, s.manager, loggerimpl.NewNopLogger()).(*dbMonitor),
+		NewMonitor(common.HistoryServiceName, services, net.ParseIP("10.0.0.2"), s.config, s.manager, loggerimpl.NewNopLogger()).(*dbMonitor),
+		NewMonitor(common.HistoryServiceName, services, net.ParseIP("10.0.0.3"), s.config, s.manager, loggerimpl.NewNopLogger()).(*dbMonitor),
+	}
+	for _, monitor := range monitors {
+		s.NoError(monitor.heartbeat())
+	}
+
+	for _, monitor := range monitors {
+		s.NoError(monitor.prune())
+	}
+	s.Equal(1, s.manager.prunes)
+}
+
+func (s *dbMembershipSuite) TestServiceResolver_Refresh() {
+	resolver := newDBServiceResolver(common.MatchingServiceName, 7235, s.config, s.manager, loggerimpl.NewNopLogger())
+	listenCh := make(chan *membership.ChangedEvent, 5)
+	s.NoError(resolver.AddListener("test-listener", listenCh))
+	s.Equal(membership.ErrListenerAlreadyExist, resolver.AddListener("test-listener", listenCh))
+
+	s.manager.heartbeat(persistence.Matching, "10.0.0.1", 7235, time.Now())
+	s.manager.heartbeat(persistence.Matching, "10.0.0.2", 7235, time.Now())
+	s.manager.heartbeat(persistence.History, "10.0.0.3", 7234, time.Now())
+	s.NoError(resolver.refresh())
+
+	event := <-listenCh
+	s.Len(event.HostsAdded, 2)
+	s.Equal("10.0.0.1:7235", event.HostsAdded[0].GetAddress())
+	s.Equal("10.0.0.2:7235", event.HostsAdded[1].GetAddress())
+	s.Empty(event.HostsRemoved)
+	s.Equal(2, resolver.MemberCount())
+
+	// hosts which stopped heartbeating leave the ring
+	s.manager.heartbeat(persistence.Matching, "10.0.0.2", 7235, time.Now().Add(-2*s.config.HeartbeatTimeout))
+	resolver.ringLastRefreshTime = time.Time{}
+	s.NoError(resolver.refresh())
+
+	event = <-listenCh
+	s.Empty(event.HostsAdded)
+	s.Len(event.HostsRemoved, 1)
+	s.Equal("10.0.0.2:7235", event.HostsRemoved[0].GetAddress())
+	host, err := resolver.Lookup("key")
+	s.NoError(err)
+	s.Equal("10.0.0.1:7235", host.GetAddress())
+
+	// unchanged members do not notify the listeners
+	resolver.ringLastRefreshTime = time.Time{}
+	s.NoError(resolver.refresh())
+	s.Empty(listenCh)
+}
+
+func (s *dbMembershipSuite) TestNewFactory_InvalidConfig() {
+	_, err := NewFactory(common.FrontendServiceName, nil, net.ParseIP("10.0.0.1"), &Config{
+		HeartbeatInterval: time.Minute,
+		HeartbeatTimeout:  time.Second,
+	}, s.manager, loggerimpl.NewNopLogger())
+	s.Equal(errHeartbeatTimeoutTooShort, err)
+}
+
+func (m *testClusterMetadataManager) heartbeat(role persistence.ServiceType, ip string, port uint16, lastHeartbeat time.Time) {
+	m.Lock()
+	defer m.Unlock()
+	m.members[ip] = &persistence.ClusterMember{
+		Role:          role,
+		HostID:        uuid.NewRandom(),
+		RPCAddress:    net.ParseIP(ip),
+		RPCPort:       port,
+		LastHeartbeat: lastHeartbeat,
+	}
+}
+
+func (m *testClusterMetadataManager) GetClusterMembers(request *persistence.GetClusterMembersRequest) (*persistence.GetClusterMembersResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+	resp := &persistence.GetClusterMembersResponse{}
+	for _, member := range m.members {
+		if request.RoleEquals != persistence.All && request.RoleEquals != member.Role {
+			continue
+		}
+		if time.Since(member.LastHeartbeat) > request.LastHeartbeatWithin {
+			continue
+		}
+		resp.ActiveMembers = append(resp.ActiveMembers, member)
+	}
+	return resp, nil
+}
+
+func (m *testClusterMetadataManager) UpsertClusterMembership(request *persistence.UpsertClusterMembershipRequest) error {
+	m.Lock()
+	defer m.Unlock()
+	m.members[request.RPCAddress.String()] = &persistence.ClusterMember{
+		Role:          request.Role,
+		HostID:        request.HostID,
+		RPCAddress:    request.RPCAddress,
+		RPCPort:       request.RPCPort,
+		SessionStart:  request.SessionStart,
+		LastHeartbeat: time.Now(),
+		RecordExpiry:  time.Now().Add(request.RecordExpiry),
+	}
+	return nil
+}
+
+func (m *testClusterMetadataManager) PruneClusterMembership(request *persistence.PruneClusterMembershipRequest) error {
+	m.Lock()
+	defer m.Unlock()
+	m.prunes++
+	return nil
+}
+
+func (m *testClusterMetadataManager) DeleteClusterMembership(request *persistence.DeleteClusterMembershipRequest) error {
+	m.Lock()
+	defer m.Unlock()
+	for ip, member := range m.members {
+		if member.Role == request.Role && bytes.Equal(member.HostID, request.HostID) {
+			delete(m.members, ip)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dbmembership
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dgryski/go-farm"
+	"github.com/uber/ringpop-go/hashring"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/membership"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+const (
+	minRefreshInterval = time.Second
+	replicaPoints      = 100
+)
+
+type dbServiceResolver struct {
+	status      int32
+	service     string
+	port        int
+	config      *Config
+	manager     persistence.ClusterMetadataManager
+	refreshChan chan struct{}
+	shutdownCh  chan struct{}
+	shutdownWG  sync.WaitGroup
+	logger      log.Logger
+
+	ringLock            sync.RWMutex
+	ringLastRefreshTime time.Time
+	ring                *hashring.HashRing
+	members             map[string]struct{}
+
+	listenerLock sync.RWMutex
+	listeners    map[string]chan<- *membership.ChangedEvent
+}
+
+var _ membership.ServiceResolver = (*dbServiceResolver)(nil)
+
+func newDBServiceResolver(
+	service string,
+	port int,
+	config *Config,
+	manager persistence.ClusterMetadataManager,
+	logger log.Logger,
+) *dbServiceResolver {
+
+	return &dbServiceResolver{
+		status:      common.DaemonStatusInitialized,
+		service:     service,
+		port:        port,
+		config:      config,
+		manager:     manager,
+		refreshChan: make(chan struct{}),
+		shutdownCh:  make(chan struct{}),
+		logger:      logger.WithTags(tag.ComponentServiceResolver, tag.Service(service)),
+
+		ring:    hashring.New(farm.Fingerprint32, replicaPoints),
+		members: make(map[string]struct{}),
+
+		listeners: make(map[string]chan<- *membership.ChangedEvent),
+	}
+}
+
+// Start starts the resolver
+func (r *dbServiceResolver) Start() {
+	if !atomic.CompareAndSwapInt32(
+		&r.status,
+		common.DaemonStatusInitialized,
+		common.DaemonStatusStarted,
+	) {
+		return
+	}
+
+	if err := r.refresh(); err != nil {
+		r.logger.Fatal("unable to start database service resolver", tag.Error(err))
+	}
+
+	r.shutdownWG.Add(1)
+	go r.refreshRingWorker()
+}
+
+// Stop stops the resolver
+func (r *dbServiceResolver) Stop() {
+	if !atomic.CompareAndSwapInt32(
+		&r.status,
+		common.DaemonStatusStarted,
+		common.DaemonStatusStopped,
+	) {
+		return
+	}
+
+	close(r.shutdownCh)
+	if success := common.AwaitWaitGroup(&r.shutdownWG, time.Minute); !success {
+		r.logger.Warn("service resolver timed out on shutdown.")
+	}
+
+	r.ringLock.Lock()
+	defer r.ringLock.Unlock()
+	r.listenerLock.Lock()
+	defer r.listenerLock.Unlock()
+	r.ring = hashring.New(farm.Fingerprint32, replicaPoints)
+	r.members = make(map[string]struct{})
+	r.listeners = make(map[string]chan<- *membership.ChangedEvent)
+}
+
+// Lookup finds the host in the ring responsible for serving the given key
+func (r *dbServiceResolver) Lookup(
+	key string,
+) (*membership.HostInfo, error) {
+
+	r.ringLock.RLock()
+	defer r.ringLock.RUnlock()
+	addr, found := r.ring.Lookup(key)
+	if !found {
+		select {
+		case r.refreshChan <- struct{}{}:
+		default:
+		}
+		return nil, membership.ErrInsufficientHosts
+	}
+	return membership.NewHostInfo(addr, roleLabels(r.service)), nil
+}
+
+func (r *dbServiceResolver) AddListener(
+	name string,
+	notifyChannel chan<- *membership.ChangedEvent,
+) error {
+
+	r.listenerLock.Lock()
+	defer r.listenerLock.Unlock()
+	_, ok := r.listeners[name]
+	if ok {
+		return membership.ErrListenerAlreadyExist
+	}
+	r.listeners[name] = notifyChannel
+	return nil
+}
+
+func (r *dbServiceResolver) RemoveListener(
+	name string,
+) error {
+
+	r.listenerLock.Lock()
+	defer r.listenerLock.Unlock()
+	delete(r.listeners, name)
+	return nil
+}
+
+func (r *dbServiceResolver) MemberCount() int {
+	r.ringLock.RLock()
+	defer r.ringLock.RUnlock()
+	return r.ring.ServerCount()
+}
+
+func (r *dbServiceResolver) Members() []*membership.HostInfo {
+	r.ringLock.RLock()
+	defer r.ringLock.RUnlock()
+	var servers []*membership.HostInfo
+	for _, s := range r.ring.Servers() {
+		servers = append(servers, membership.NewHostInfo(s, roleLabels(r.service)))
+	}
+	return servers
+}
+
+// refresh rebuilds the ring from the hosts which heartbeat recently and notifies
+// the listeners about the hosts which joined or left since the last refresh
+func (r *dbServiceResolver) refresh() error {
+	role, err := serviceType(r.service)
+	if err != nil {
+		return err
+	}
+
+	r.ringLock.RLock()
+	lastRefreshTime := r.ringLastRefreshTime
+	r.ringLock.RUnlock()
+	if lastRefreshTime.After(time.Now().Add(-minRefreshInterval)) {
+		// refresh too frequently
+		return nil
+	}
+
+	// refresh is only called by the refresh worker after start, so the members
+	// can be read without holding the lock and blocking lookups
+	activeMembers, err := getActiveMembers(r.manager, role, r.config.HeartbeatTimeout)
+	if err != nil {
+		return err
+	}
+
+	r.ringLock.Lock()
+	members := make(map[string]struct{})
+	for _, member := range activeMembers {
+		members[hostAddress(member.RPCAddress, int(member.RPCPort))] = struct{}{}
+	}
+	event := &membership.ChangedEvent{}
+	for _, addr := range sortedAddresses(members) {
+		if _, ok := r.members[addr]; !ok {
+			event.HostsAdded = append(event.HostsAdded, membership.NewHostInfo(addr, roleLabels(r.service)))
+		}
+	}
+	for _, addr := range sortedAddresses(r.members) {
+		if _, ok := members[addr]; !ok {
+			event.HostsRemoved = append(event.HostsRemoved, membership.NewHostInfo(addr, roleLabels(r.service)))
+		}
+	}
+
+	if len(event.HostsAdded) > 0 || len(event.HostsRemoved) > 0 {
+		r.ring = hashring.New(farm.Fingerprint32, replicaPoints)
+		for addr := range members {
+			r.ring.AddMembers(membership.NewHostInfo(addr, roleLabels(r.service)))
+		}
+		r.members = members
+	}
+	r.ringLastRefreshTime = time.Now()
+	r.ringLock.Unlock()
+
+	if len(event.HostsAdded) > 0 || len(event.HostsRemoved) > 0 {
+		r.logger.Info("Current reachable members", tag.Addresses(sortedAddresses(members)))
+		r.emitEvent(event)
+	}
+	return nil
+}
+
+func (r *dbServiceResolver) emitEvent(
+	event *membership.ChangedEvent,
+) {
+
+	r.listenerLock.RLock()
+	defer r.listenerLock.RUnlock()
+
+	for name, ch := range r.listeners {
+		select {
+		case ch <- event:
+		default:
+			r.logger.Error("Failed to send listener notification, channel full", tag.ListenerName(name))
+		}
+	}
+}
+
+func (r *dbServiceResolver) refreshRingWorker() {
+	defer r.shutdownWG.Done()
+
+	refreshTicker := time.NewTicker(r.config.HeartbeatInterval)
+	defer refreshTicker.Stop()
+
+	for {
+		select {
+		case <-r.shutdownCh:
+			return
+		case <-r.refreshChan:
+			if err := r.refresh(); err != nil {
+				r.logger.Error("error refreshing ring on lookup failure", tag.Error(err))
+			}
+		case <-refreshTicker.C:
+			if err := r.refresh(); err != nil {
+				r.logger.Error("error periodically refreshing ring", tag.Error(err))
+			}
+		}
+	}
+}
+
+func sortedAddresses(members map[string]struct{}) []string {
+	addresses := make([]string, 0, len(members))
+	for addr := range members {
+		addresses = append(addresses, addr)
+	}
+	sort.Strings(addresses)
+	return addresses
+}
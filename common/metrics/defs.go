@@ -152,6 +152,8 @@ const (
 	PersistenceDeleteTaskScope
 	// PersistenceGetCurrentExecutionScope tracks GetCurrentExecution calls made by service to persistence layer
 	PersistenceGetCurrentExecutionScope
+	// PersistenceListConcreteExecutionsScope tracks ListConcreteExecutions calls made by service to persistence layer
+	PersistenceListConcreteExecutionsScope
 	// PersistenceGetTransferTasksScope tracks GetTransferTasks calls made by service to persistence layer
 	PersistenceGetTransferTasksScope
 	// PersistenceCompleteTransferTaskScope tracks CompleteTransferTasks calls made by service to persistence layer
@@ -168,6 +170,18 @@ const (
 	PersistencePutReplicationTaskToDLQScope
 	// PersistenceGetReplicationTasksFromDLQScope tracks PersistenceGetReplicationTasksFromDLQScope calls made by service to persistence layer
 	PersistenceGetReplicationTasksFromDLQScope
+	// PersistencePutTransferTaskToDLQScope tracks PutTransferTaskToDLQ calls made by service to persistence layer
+	PersistencePutTransferTaskToDLQScope
+	// PersistenceGetTransferTasksFromDLQScope tracks GetTransferTasksFromDLQ calls made by service to persistence layer
+	PersistenceGetTransferTasksFromDLQScope
+	// PersistenceDeleteTransferTaskFromDLQScope tracks DeleteTransferTaskFromDLQ calls made by service to persistence layer
+	PersistenceDeleteTransferTaskFromDLQScope
+	// PersistencePutTimerTaskToDLQScope tracks PutTimerTaskToDLQ calls made by service to persistence layer
+	PersistencePutTimerTaskToDLQScope
+	// PersistenceGetTimerTasksFromDLQScope tracks GetTimerTasksFromDLQ calls made by service to persistence layer
+	PersistenceGetTimerTasksFromDLQScope
+	// PersistenceDeleteTimerTaskFromDLQScope tracks DeleteTimerTaskFromDLQ calls made by service to persistence layer
+	PersistenceDeleteTimerTaskFromDLQScope
 	// PersistenceGetTimerIndexTasksScope tracks GetTimerIndexTasks calls made by service to persistence layer
 	PersistenceGetTimerIndexTasksScope
 	// PersistenceCompleteTimerTaskScope tracks CompleteTimerTasks calls made by service to persistence layer
@@ -268,6 +282,8 @@ const (
 	PersistenceUpsertClusterMembershipScope
 	// PersistencePruneClusterMembershipScope tracks PruneClusterMembership calls made by service to persistence layer
 	PersistencePruneClusterMembershipScope
+	// PersistenceDeleteClusterMembershipScope tracks DeleteClusterMembership calls made by service to persistence layer
+	PersistenceDeleteClusterMembershipScope
 	// PersistenceGetClusterMembersScope tracks GetClusterMembers calls made by service to persistence layer
 	PersistenceGetClusterMembersScope
 	// HistoryClientStartWorkflowExecutionScope tracks RPC calls to history service
@@ -304,6 +320,12 @@ const (
 	HistoryClientSignalWithStartWorkflowExecutionScope
 	// HistoryClientRemoveSignalMutableStateScope tracks RPC calls to history service
 	HistoryClientRemoveSignalMutableStateScope
+	// HistoryClientUnarchiveWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientUnarchiveWorkflowExecutionScope
+	// HistoryClientUpsertWorkflowSearchAttributesScope tracks RPC calls to history service
+	HistoryClientUpsertWorkflowSearchAttributesScope
+	// HistoryClientDeleteWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientDeleteWorkflowExecutionScope
 	// HistoryClientTerminateWorkflowExecutionScope tracks RPC calls to history service
 	HistoryClientTerminateWorkflowExecutionScope
 	// HistoryClientResetWorkflowExecutionScope tracks RPC calls to history service
@@ -348,6 +370,12 @@ const (
 	MatchingClientDescribeTaskListScope
 	// MatchingClientListTaskListPartitionsScope tracks RPC calls to matching service
 	MatchingClientListTaskListPartitionsScope
+	// MatchingClientUpdateTaskListBuildIDsScope tracks RPC calls to matching service
+	MatchingClientUpdateTaskListBuildIDsScope
+	// MatchingClientGetTaskListBuildIDsScope tracks RPC calls to matching service
+	MatchingClientGetTaskListBuildIDsScope
+	// MatchingClientGetTaskListPartitionCountsScope tracks RPC calls to matching service
+	MatchingClientGetTaskListPartitionCountsScope
 	// FrontendClientDeprecateDomainScope tracks RPC calls to frontend service
 	FrontendClientDeprecateDomainScope
 	// FrontendClientDescribeDomainScope tracks RPC calls to frontend service
@@ -448,6 +476,42 @@ const (
 	AdminClientGetWorkflowExecutionRawHistoryV2Scope
 	// AdminClientDescribeClusterScope tracks RPC calls to admin service
 	AdminClientDescribeClusterScope
+	// AdminClientDescribeDynamicConfigScope tracks RPC calls to admin service
+	AdminClientDescribeDynamicConfigScope
+	// AdminClientListAuditRecordsScope tracks RPC calls to admin service
+	AdminClientListAuditRecordsScope
+	// AdminClientUpdateTaskListBuildIDsScope tracks RPC calls to admin service
+	AdminClientUpdateTaskListBuildIDsScope
+	// AdminClientGetTaskListBuildIDsScope tracks RPC calls to admin service
+	AdminClientGetTaskListBuildIDsScope
+	// AdminClientListShardDLQTasksScope tracks RPC calls to admin service
+	AdminClientListShardDLQTasksScope
+	// AdminClientRetryShardDLQTasksScope tracks RPC calls to admin service
+	AdminClientRetryShardDLQTasksScope
+	// AdminClientPurgeShardDLQTasksScope tracks RPC calls to admin service
+	AdminClientPurgeShardDLQTasksScope
+	// AdminClientListShardTasksScope tracks RPC calls to admin service
+	AdminClientListShardTasksScope
+	// AdminClientDescribeTaskListScope tracks RPC calls to admin service
+	AdminClientDescribeTaskListScope
+	// AdminClientDeleteWorkflowExecutionScope tracks RPC calls to admin service
+	AdminClientDeleteWorkflowExecutionScope
+	// AdminClientUpsertWorkflowSearchAttributesScope tracks RPC calls to admin service
+	AdminClientUpsertWorkflowSearchAttributesScope
+	// AdminClientUnarchiveWorkflowExecutionScope tracks RPC calls to admin service
+	AdminClientUnarchiveWorkflowExecutionScope
+	// AdminClientAddSearchAttributesScope tracks RPC calls to admin service
+	AdminClientAddSearchAttributesScope
+	// AdminClientRemoveSearchAttributesScope tracks RPC calls to admin service
+	AdminClientRemoveSearchAttributesScope
+	// AdminClientListSearchAttributesScope tracks RPC calls to admin service
+	AdminClientListSearchAttributesScope
+	// AdminClientDeleteDomainScope tracks RPC calls to admin service
+	AdminClientDeleteDomainScope
+	// AdminClientUpdateDomainWorkflowDefaultsScope tracks RPC calls to admin service
+	AdminClientUpdateDomainWorkflowDefaultsScope
+	// AdminClientDescribeDomainWorkflowDefaultsScope tracks RPC calls to admin service
+	AdminClientDescribeDomainWorkflowDefaultsScope
 	// DCRedirectionDeprecateDomainScope tracks RPC calls for dc redirection
 	DCRedirectionDeprecateDomainScope
 	// DCRedirectionDescribeDomainScope tracks RPC calls for dc redirection
@@ -597,6 +661,9 @@ const (
 	// SequentialTaskProcessingScope is used by sequential task processing logic
 	SequentialTaskProcessingScope
 
+	// AuditLogScope is used by the audit log writer
+	AuditLogScope
+
 	// HistoryArchiverScope is used by history archivers
 	HistoryArchiverScope
 	// VisibilityArchiverScope is used by visibility archivers
@@ -645,6 +712,42 @@ const (
 	AdminRemoveTaskScope
 	//AdminCloseShardTaskScope is the metric scope for admin.AdminRemoveTaskScope
 	AdminCloseShardTaskScope
+	// AdminDescribeDynamicConfigScope is the metric scope for admin.DescribeDynamicConfig
+	AdminDescribeDynamicConfigScope
+	// AdminListAuditRecordsScope is the metric scope for admin.ListAuditRecords
+	AdminListAuditRecordsScope
+	// AdminUpdateTaskListBuildIDsScope is the metric scope for admin.UpdateTaskListBuildIDs
+	AdminUpdateTaskListBuildIDsScope
+	// AdminGetTaskListBuildIDsScope is the metric scope for admin.GetTaskListBuildIDs
+	AdminGetTaskListBuildIDsScope
+	// AdminListShardDLQTasksScope is the metric scope for admin.ListShardDLQTasks
+	AdminListShardDLQTasksScope
+	// AdminRetryShardDLQTasksScope is the metric scope for admin.RetryShardDLQTasks
+	AdminRetryShardDLQTasksScope
+	// AdminPurgeShardDLQTasksScope is the metric scope for admin.PurgeShardDLQTasks
+	AdminPurgeShardDLQTasksScope
+	// AdminListShardTasksScope is the metric scope for admin.ListShardTasks
+	AdminListShardTasksScope
+	// AdminDescribeTaskListScope is the metric scope for admin.DescribeTaskList
+	AdminDescribeTaskListScope
+	// AdminDeleteWorkflowExecutionScope is the metric scope for admin.DeleteWorkflowExecution
+	AdminDeleteWorkflowExecutionScope
+	// AdminUpsertWorkflowSearchAttributesScope is the metric scope for admin.UpsertWorkflowSearchAttributes
+	AdminUpsertWorkflowSearchAttributesScope
+	// AdminUnarchiveWorkflowExecutionScope is the metric scope for admin.UnarchiveWorkflowExecution
+	AdminUnarchiveWorkflowExecutionScope
+	// AdminAddSearchAttributesScope is the metric scope for admin.AddSearchAttributes
+	AdminAddSearchAttributesScope
+	// AdminRemoveSearchAttributesScope is the metric scope for admin.RemoveSearchAttributes
+	AdminRemoveSearchAttributesScope
+	// AdminListSearchAttributesScope is the metric scope for admin.ListSearchAttributes
+	AdminListSearchAttributesScope
+	// AdminDeleteDomainScope is the metric scope for admin.DeleteDomain
+	AdminDeleteDomainScope
+	// AdminUpdateDomainWorkflowDefaultsScope is the metric scope for admin.UpdateDomainWorkflowDefaults
+	AdminUpdateDomainWorkflowDefaultsScope
+	// AdminDescribeDomainWorkflowDefaultsScope is the metric scope for admin.DescribeDomainWorkflowDefaults
+	AdminDescribeDomainWorkflowDefaultsScope
 
 	NumAdminScopes
 )
@@ -765,6 +868,12 @@ const (
 	HistorySignalWithStartWorkflowExecutionScope
 	// HistoryRemoveSignalMutableStateScope tracks RemoveSignalMutableState API calls received by service
 	HistoryRemoveSignalMutableStateScope
+	// HistoryUnarchiveWorkflowExecutionScope tracks UnarchiveWorkflowExecution API calls received by service
+	HistoryUnarchiveWorkflowExecutionScope
+	// HistoryUpsertWorkflowSearchAttributesScope tracks UpsertWorkflowSearchAttributes API calls received by service
+	HistoryUpsertWorkflowSearchAttributesScope
+	// HistoryDeleteWorkflowExecutionScope tracks DeleteWorkflowExecution API calls received by service
+	HistoryDeleteWorkflowExecutionScope
 	// HistoryTerminateWorkflowExecutionScope tracks TerminateWorkflowExecution API calls received by service
 	HistoryTerminateWorkflowExecutionScope
 	// HistoryScheduleDecisionTaskScope tracks ScheduleDecisionTask API calls received by service
@@ -947,6 +1056,12 @@ const (
 	MatchingDescribeTaskListScope
 	// MatchingListTaskListPartitionsScope tracks ListTaskListPartitions API calls received by service
 	MatchingListTaskListPartitionsScope
+	// MatchingUpdateTaskListBuildIDsScope tracks UpdateTaskListBuildIDs API calls received by service
+	MatchingUpdateTaskListBuildIDsScope
+	// MatchingGetTaskListBuildIDsScope tracks GetTaskListBuildIDs API calls received by service
+	MatchingGetTaskListBuildIDsScope
+	// MatchingGetTaskListPartitionCountsScope tracks GetTaskListPartitionCounts API calls received by service
+	MatchingGetTaskListPartitionCountsScope
 
 	NumMatchingScopes
 )
@@ -983,14 +1098,22 @@ const (
 	ArchiverPumpScope
 	// ArchiverArchivalWorkflowScope is scope used by all metrics emitted by archiver.ArchivalWorkflow
 	ArchiverArchivalWorkflowScope
+	// ArchiverVerifyHistoryActivityScope is scope used by all metrics emitted by archiver.VerifyHistoryActivity
+	ArchiverVerifyHistoryActivityScope
+	// ArchiverVerificationWorkflowScope is scope used by all metrics emitted by archiver.VerificationWorkflow
+	ArchiverVerificationWorkflowScope
 	// TaskListScavengerScope is scope used by all metrics emitted by worker.tasklist.Scavenger module
 	TaskListScavengerScope
 	// BatcherScope is scope used by all metrics emitted by worker.Batcher module
 	BatcherScope
 	// HistoryScavengerScope is scope used by all metrics emitted by worker.history.Scavenger module
 	HistoryScavengerScope
+	// ExecutionsScannerScope is scope used by all metrics emitted by worker.executions.Scanner module
+	ExecutionsScannerScope
 	// ParentClosePolicyProcessorScope is scope used by all metrics emitted by worker.ParentClosePolicyProcessor
 	ParentClosePolicyProcessorScope
+	// DomainDeleterScope is scope used by all metrics emitted by worker.domaindeleter module
+	DomainDeleterScope
 
 	NumWorkerScopes
 )
@@ -1011,6 +1134,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceDeleteCurrentWorkflowExecutionScope:           {operation: "DeleteCurrentWorkflowExecution"},
 		PersistenceDeleteTaskScope:                               {operation: "PersistenceDelete"},
 		PersistenceGetCurrentExecutionScope:                      {operation: "GetCurrentExecution"},
+		PersistenceListConcreteExecutionsScope:                   {operation: "ListConcreteExecutions"},
 		PersistenceGetTransferTasksScope:                         {operation: "GetTransferTasks"},
 		PersistenceCompleteTransferTaskScope:                     {operation: "CompleteTransferTask"},
 		PersistenceRangeCompleteTransferTaskScope:                {operation: "RangeCompleteTransferTask"},
@@ -1019,6 +1143,12 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceRangeCompleteReplicationTaskScope:             {operation: "RangeCompleteReplicationTask"},
 		PersistencePutReplicationTaskToDLQScope:                  {operation: "PersistencePutReplicationTaskToDLQ"},
 		PersistenceGetReplicationTasksFromDLQScope:               {operation: "PersistenceGetReplicationTasksFromDLQ"},
+		PersistencePutTransferTaskToDLQScope:                     {operation: "PutTransferTaskToDLQ"},
+		PersistenceGetTransferTasksFromDLQScope:                  {operation: "GetTransferTasksFromDLQ"},
+		PersistenceDeleteTransferTaskFromDLQScope:                {operation: "DeleteTransferTaskFromDLQ"},
+		PersistencePutTimerTaskToDLQScope:                        {operation: "PutTimerTaskToDLQ"},
+		PersistenceGetTimerTasksFromDLQScope:                     {operation: "GetTimerTasksFromDLQ"},
+		PersistenceDeleteTimerTaskFromDLQScope:                   {operation: "DeleteTimerTaskFromDLQ"},
 		PersistenceGetTimerIndexTasksScope:                       {operation: "GetTimerIndexTasks"},
 		PersistenceCompleteTimerTaskScope:                        {operation: "CompleteTimerTask"},
 		PersistenceRangeCompleteTimerTaskScope:                   {operation: "RangeCompleteTimerTask"},
@@ -1076,6 +1206,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceInitImmutableClusterMetadataScope:             {operation: "InitializeImmutableClusterMetadata"},
 		PersistenceGetImmutableClusterMetadataScope:              {operation: "GetImmutableClusterMetadata"},
 		PersistencePruneClusterMembershipScope:                   {operation: "PruneClusterMembership"},
+		PersistenceDeleteClusterMembershipScope:                  {operation: "DeleteClusterMembership"},
 		PersistenceGetClusterMembersScope:                        {operation: "GetClusterMembership"},
 		PersistenceUpsertClusterMembershipScope:                  {operation: "UpsertClusterMembership"},
 
@@ -1098,6 +1229,9 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryClientSignalWorkflowExecutionScope:           {operation: "HistoryClientSignalWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientSignalWithStartWorkflowExecutionScope:  {operation: "HistoryClientSignalWithStartWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientRemoveSignalMutableStateScope:          {operation: "HistoryClientRemoveSignalMutableStateScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientUnarchiveWorkflowExecutionScope:        {operation: "HistoryClientUnarchiveWorkflowExecutionScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientUpsertWorkflowSearchAttributesScope:    {operation: "HistoryClientUpsertWorkflowSearchAttributesScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientDeleteWorkflowExecutionScope:           {operation: "HistoryClientDeleteWorkflowExecutionScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientTerminateWorkflowExecutionScope:        {operation: "HistoryClientTerminateWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientResetWorkflowExecutionScope:            {operation: "HistoryClientResetWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientScheduleDecisionTaskScope:              {operation: "HistoryClientScheduleDecisionTask", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
@@ -1120,6 +1254,9 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		MatchingClientCancelOutstandingPollScope:            {operation: "MatchingClientCancelOutstandingPoll", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientDescribeTaskListScope:                 {operation: "MatchingClientDescribeTaskList", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientListTaskListPartitionsScope:           {operation: "MatchingClientListTaskListPartitions", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientUpdateTaskListBuildIDsScope:           {operation: "MatchingClientUpdateTaskListBuildIDs", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientGetTaskListBuildIDsScope:              {operation: "MatchingClientGetTaskListBuildIDs", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientGetTaskListPartitionCountsScope:       {operation: "MatchingClientGetTaskListPartitionCounts", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
 		FrontendClientDeprecateDomainScope:                  {operation: "FrontendClientDeprecateDomain", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientDescribeDomainScope:                   {operation: "FrontendClientDescribeDomain", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientDescribeTaskListScope:                 {operation: "FrontendClientDescribeTaskList", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
@@ -1170,6 +1307,24 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		AdminClientGetWorkflowExecutionRawHistoryV2Scope:    {operation: "AdminClientGetWorkflowExecutionRawHistoryV2", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
 		AdminClientDescribeClusterScope:                     {operation: "AdminClientDescribeCluster", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
 		AdminClientCloseShardScope:                          {operation: "AdminClientCloseShard", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientDescribeDynamicConfigScope:               {operation: "AdminClientDescribeDynamicConfig", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientListAuditRecordsScope:                    {operation: "AdminClientListAuditRecords", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientUpdateTaskListBuildIDsScope:              {operation: "AdminClientUpdateTaskListBuildIDs", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientGetTaskListBuildIDsScope:                 {operation: "AdminClientGetTaskListBuildIDs", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientListShardDLQTasksScope:                   {operation: "AdminClientListShardDLQTasks", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientRetryShardDLQTasksScope:                  {operation: "AdminClientRetryShardDLQTasks", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientPurgeShardDLQTasksScope:                  {operation: "AdminClientPurgeShardDLQTasks", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientListShardTasksScope:                      {operation: "AdminClientListShardTasks", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientDescribeTaskListScope:                    {operation: "AdminClientDescribeTaskList", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientDeleteWorkflowExecutionScope:             {operation: "AdminClientDeleteWorkflowExecution", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientUpsertWorkflowSearchAttributesScope:      {operation: "AdminClientUpsertWorkflowSearchAttributes", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientUnarchiveWorkflowExecutionScope:          {operation: "AdminClientUnarchiveWorkflowExecution", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientAddSearchAttributesScope:                 {operation: "AdminClientAddSearchAttributes", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientRemoveSearchAttributesScope:              {operation: "AdminClientRemoveSearchAttributes", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientListSearchAttributesScope:                {operation: "AdminClientListSearchAttributes", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientDeleteDomainScope:                        {operation: "AdminClientDeleteDomain", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientUpdateDomainWorkflowDefaultsScope:        {operation: "AdminClientUpdateDomainWorkflowDefaults", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientDescribeDomainWorkflowDefaultsScope:      {operation: "AdminClientDescribeDomainWorkflowDefaults", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
 		DCRedirectionDeprecateDomainScope:                   {operation: "DCRedirectionDeprecateDomain", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
 		DCRedirectionDescribeDomainScope:                    {operation: "DCRedirectionDescribeDomain", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
 		DCRedirectionDescribeTaskListScope:                  {operation: "DCRedirectionDescribeTaskList", tags: map[string]string{CadenceRoleTagName: DCRedirectionRoleTagValue}},
@@ -1235,6 +1390,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		ElasticsearchCountWorkflowExecutionsScope:                  {operation: "CountWorkflowExecutions"},
 		ElasticsearchDeleteWorkflowExecutionsScope:                 {operation: "DeleteWorkflowExecution"},
 		SequentialTaskProcessingScope:                              {operation: "SequentialTaskProcessing"},
+		AuditLogScope:                                              {operation: "AuditLog"},
 
 		HistoryArchiverScope:    {operation: "HistoryArchiver"},
 		VisibilityArchiverScope: {operation: "VisibilityArchiver"},
@@ -1260,6 +1416,24 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		AdminGetDomainReplicationMessagesScope:     {operation: "GetDomainReplicationMessages"},
 		AdminGetDLQReplicationMessagesScope:        {operation: "AdminGetDLQReplicationMessages"},
 		AdminReapplyEventsScope:                    {operation: "ReapplyEvents"},
+		AdminDescribeDynamicConfigScope:            {operation: "DescribeDynamicConfig"},
+		AdminListAuditRecordsScope:                 {operation: "ListAuditRecords"},
+		AdminUpdateTaskListBuildIDsScope:           {operation: "UpdateTaskListBuildIDs"},
+		AdminGetTaskListBuildIDsScope:              {operation: "GetTaskListBuildIDs"},
+		AdminListShardDLQTasksScope:                {operation: "ListShardDLQTasks"},
+		AdminRetryShardDLQTasksScope:               {operation: "RetryShardDLQTasks"},
+		AdminPurgeShardDLQTasksScope:               {operation: "PurgeShardDLQTasks"},
+		AdminListShardTasksScope:                   {operation: "ListShardTasks"},
+		AdminDescribeTaskListScope:                 {operation: "DescribeTaskList"},
+		AdminDeleteWorkflowExecutionScope:          {operation: "DeleteWorkflowExecution"},
+		AdminUpsertWorkflowSearchAttributesScope:   {operation: "UpsertWorkflowSearchAttributes"},
+		AdminUnarchiveWorkflowExecutionScope:       {operation: "UnarchiveWorkflowExecution"},
+		AdminAddSearchAttributesScope:              {operation: "AddSearchAttributes"},
+		AdminRemoveSearchAttributesScope:           {operation: "RemoveSearchAttributes"},
+		AdminListSearchAttributesScope:             {operation: "ListSearchAttributes"},
+		AdminDeleteDomainScope:                     {operation: "DeleteDomain"},
+		AdminUpdateDomainWorkflowDefaultsScope:     {operation: "UpdateDomainWorkflowDefaults"},
+		AdminDescribeDomainWorkflowDefaultsScope:   {operation: "DescribeDomainWorkflowDefaults"},
 
 		FrontendStartWorkflowExecutionScope:           {operation: "StartWorkflowExecution"},
 		FrontendPollForDecisionTaskScope:              {operation: "PollForDecisionTask"},
@@ -1318,6 +1492,9 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistorySignalWorkflowExecutionScope:                    {operation: "SignalWorkflowExecution"},
 		HistorySignalWithStartWorkflowExecutionScope:           {operation: "SignalWithStartWorkflowExecution"},
 		HistoryRemoveSignalMutableStateScope:                   {operation: "RemoveSignalMutableState"},
+		HistoryUnarchiveWorkflowExecutionScope:                 {operation: "UnarchiveWorkflowExecution"},
+		HistoryUpsertWorkflowSearchAttributesScope:             {operation: "UpsertWorkflowSearchAttributes"},
+		HistoryDeleteWorkflowExecutionScope:                    {operation: "DeleteWorkflowExecution"},
 		HistoryTerminateWorkflowExecutionScope:                 {operation: "TerminateWorkflowExecution"},
 		HistoryResetWorkflowExecutionScope:                     {operation: "ResetWorkflowExecution"},
 		HistoryQueryWorkflowScope:                              {operation: "QueryWorkflow"},
@@ -1399,16 +1576,19 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 	},
 	// Matching Scope Names
 	Matching: {
-		MatchingPollForDecisionTaskScope:       {operation: "PollForDecisionTask"},
-		MatchingPollForActivityTaskScope:       {operation: "PollForActivityTask"},
-		MatchingAddActivityTaskScope:           {operation: "AddActivityTask"},
-		MatchingAddDecisionTaskScope:           {operation: "AddDecisionTask"},
-		MatchingTaskListMgrScope:               {operation: "TaskListMgr"},
-		MatchingQueryWorkflowScope:             {operation: "QueryWorkflow"},
-		MatchingRespondQueryTaskCompletedScope: {operation: "RespondQueryTaskCompleted"},
-		MatchingCancelOutstandingPollScope:     {operation: "CancelOutstandingPoll"},
-		MatchingDescribeTaskListScope:          {operation: "DescribeTaskList"},
-		MatchingListTaskListPartitionsScope:    {operation: "ListTaskListPartitions"},
+		MatchingPollForDecisionTaskScope:        {operation: "PollForDecisionTask"},
+		MatchingPollForActivityTaskScope:        {operation: "PollForActivityTask"},
+		MatchingAddActivityTaskScope:            {operation: "AddActivityTask"},
+		MatchingAddDecisionTaskScope:            {operation: "AddDecisionTask"},
+		MatchingTaskListMgrScope:                {operation: "TaskListMgr"},
+		MatchingQueryWorkflowScope:              {operation: "QueryWorkflow"},
+		MatchingRespondQueryTaskCompletedScope:  {operation: "RespondQueryTaskCompleted"},
+		MatchingCancelOutstandingPollScope:      {operation: "CancelOutstandingPoll"},
+		MatchingDescribeTaskListScope:           {operation: "DescribeTaskList"},
+		MatchingListTaskListPartitionsScope:     {operation: "ListTaskListPartitions"},
+		MatchingUpdateTaskListBuildIDsScope:     {operation: "UpdateTaskListBuildIDs"},
+		MatchingGetTaskListBuildIDsScope:        {operation: "GetTaskListBuildIDs"},
+		MatchingGetTaskListPartitionCountsScope: {operation: "GetTaskListPartitionCounts"},
 	},
 	// Worker Scope Names
 	Worker: {
@@ -1427,10 +1607,14 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		ArchiverScope:                          {operation: "Archiver"},
 		ArchiverPumpScope:                      {operation: "ArchiverPump"},
 		ArchiverArchivalWorkflowScope:          {operation: "ArchiverArchivalWorkflow"},
+		ArchiverVerifyHistoryActivityScope:     {operation: "ArchiverVerifyHistoryActivity"},
+		ArchiverVerificationWorkflowScope:      {operation: "ArchiverVerificationWorkflow"},
 		TaskListScavengerScope:                 {operation: "tasklistscavenger"},
 		HistoryScavengerScope:                  {operation: "historyscavenger"},
+		ExecutionsScannerScope:                 {operation: "executionsscanner"},
 		BatcherScope:                           {operation: "batcher"},
 		ParentClosePolicyProcessorScope:        {operation: "ParentClosePolicyProcessor"},
+		DomainDeleterScope:                     {operation: "domaindeleter"},
 	},
 }
 
@@ -1526,6 +1710,10 @@ const (
 
 	DomainReplicationTaskAckLevel
 
+	AuditRecordsWrittenCount
+	AuditRecordsDroppedCount
+	AuditWriteLatency
+
 	NumCommonMetrics // Needs to be last on this list for iota numbering
 )
 
@@ -1542,6 +1730,9 @@ const (
 	TaskBatchCompleteCounter
 	TaskProcessingLatency
 	TaskQueueLatency
+	TaskSchedulerQueueLatency
+	TaskDLQCounter
+	TaskDLQFailures
 
 	AckLevelUpdateCounter
 	AckLevelUpdateFailedCounter
@@ -1564,6 +1755,8 @@ const (
 	StaleMutableStateCounter
 	AutoResetPointsLimitExceededCounter
 	AutoResetPointCorruptionCounter
+	PendingLimitExceededCounter
+	OpenWorkflowsLimitExceededCounter
 	ConcurrencyUpdateFailureCounter
 	CadenceErrEventAlreadyStartedCounter
 	CadenceErrShardOwnershipLostCounter
@@ -1764,6 +1957,11 @@ const (
 	ArchiverPumpedNotEqualHandledCount
 	ArchiverHandleAllRequestsLatency
 	ArchiverWorkflowStoppingCount
+	ArchiverVerificationSampledCount
+	ArchiverVerificationSignalFailureCount
+	ArchiverVerificationSuccessCount
+	ArchiverVerificationFailedCount
+	ArchiverVerificationErrorCount
 	TaskProcessedCount
 	TaskDeletedCount
 	TaskListProcessedCount
@@ -1778,8 +1976,15 @@ const (
 	HistoryScavengerSuccessCount
 	HistoryScavengerErrorCount
 	HistoryScavengerSkipCount
+	ExecutionsScannerScannedCount
+	ExecutionsScannerCorruptedCount
+	ExecutionsScannerFixedCount
+	ExecutionsScannerErrorCount
 	ParentClosePolicyProcessorSuccess
 	ParentClosePolicyProcessorFailures
+	DomainDeleterExecutionsDeletedCount
+	DomainDeleterTaskListsDeletedCount
+	DomainDeleterErrorCount
 
 	NumWorkerMetrics
 )
@@ -1864,6 +2069,10 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		MatchingClientInvalidTaskListName:                         {metricName: "invalid_task_list_name", metricType: Counter},
 
 		DomainReplicationTaskAckLevel: {metricName: "domain_replication_task_ack_level", metricType: Gauge},
+
+		AuditRecordsWrittenCount: {metricName: "audit_records_written", metricType: Counter},
+		AuditRecordsDroppedCount: {metricName: "audit_records_dropped", metricType: Counter},
+		AuditWriteLatency:        {metricName: "audit_write_latency", metricType: Timer},
 	},
 	History: {
 		TaskRequests:                                      {metricName: "task_requests", metricType: Counter},
@@ -1876,6 +2085,9 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		TaskLimitExceededCounter:                          {metricName: "task_errors_limit_exceeded_counter", metricType: Counter},
 		TaskProcessingLatency:                             {metricName: "task_latency_processing", metricType: Timer},
 		TaskQueueLatency:                                  {metricName: "task_latency_queue", metricType: Timer},
+		TaskSchedulerQueueLatency:                         {metricName: "task_latency_scheduler_queue", metricType: Timer},
+		TaskDLQCounter:                                    {metricName: "task_dlq", metricType: Counter},
+		TaskDLQFailures:                                   {metricName: "task_errors_dlq", metricType: Counter},
 		TaskBatchCompleteCounter:                          {metricName: "task_batch_complete_counter", metricType: Counter},
 		AckLevelUpdateCounter:                             {metricName: "ack_level_update", metricType: Counter},
 		AckLevelUpdateFailedCounter:                       {metricName: "ack_level_update_failed", metricType: Counter},
@@ -1898,6 +2110,8 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		StaleMutableStateCounter:                          {metricName: "stale_mutable_state", metricType: Counter},
 		AutoResetPointsLimitExceededCounter:               {metricName: "auto_reset_points_exceed_limit", metricType: Counter},
 		AutoResetPointCorruptionCounter:                   {metricName: "auto_reset_point_corruption", metricType: Counter},
+		PendingLimitExceededCounter:                       {metricName: "pending_limit_exceeded", metricType: Counter},
+		OpenWorkflowsLimitExceededCounter:                 {metricName: "open_workflows_limit_exceeded", metricType: Counter},
 		ConcurrencyUpdateFailureCounter:                   {metricName: "concurrency_update_failure", metricType: Counter},
 		CadenceErrShardOwnershipLostCounter:               {metricName: "cadence_errors_shard_ownership_lost", metricType: Counter},
 		CadenceErrEventAlreadyStartedCounter:              {metricName: "cadence_errors_event_already_started", metricType: Counter},
@@ -2090,6 +2304,11 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ArchiverPumpedNotEqualHandledCount:            {metricName: "archiver_pumped_not_equal_handled"},
 		ArchiverHandleAllRequestsLatency:              {metricName: "archiver_handle_all_requests_latency"},
 		ArchiverWorkflowStoppingCount:                 {metricName: "archiver_workflow_stopping"},
+		ArchiverVerificationSampledCount:              {metricName: "archiver_verification_sampled"},
+		ArchiverVerificationSignalFailureCount:        {metricName: "archiver_verification_signal_failure"},
+		ArchiverVerificationSuccessCount:              {metricName: "archiver_verification_success"},
+		ArchiverVerificationFailedCount:               {metricName: "archiver_verification_failed"},
+		ArchiverVerificationErrorCount:                {metricName: "archiver_verification_errors"},
 		TaskProcessedCount:                            {metricName: "task_processed", metricType: Gauge},
 		TaskDeletedCount:                              {metricName: "task_deleted", metricType: Gauge},
 		TaskListProcessedCount:                        {metricName: "tasklist_processed", metricType: Gauge},
@@ -2104,8 +2323,15 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		HistoryScavengerSuccessCount:                  {metricName: "scavenger_success", metricType: Counter},
 		HistoryScavengerErrorCount:                    {metricName: "scavenger_errors", metricType: Counter},
 		HistoryScavengerSkipCount:                     {metricName: "scavenger_skips", metricType: Counter},
+		ExecutionsScannerScannedCount:                 {metricName: "executions_scanner_scanned", metricType: Counter},
+		ExecutionsScannerCorruptedCount:               {metricName: "executions_scanner_corrupted", metricType: Counter},
+		ExecutionsScannerFixedCount:                   {metricName: "executions_scanner_fixed", metricType: Counter},
+		ExecutionsScannerErrorCount:                   {metricName: "executions_scanner_errors", metricType: Counter},
 		ParentClosePolicyProcessorSuccess:             {metricName: "parent_close_policy_processor_requests", metricType: Counter},
 		ParentClosePolicyProcessorFailures:            {metricName: "parent_close_policy_processor_errors", metricType: Counter},
+		DomainDeleterExecutionsDeletedCount:           {metricName: "domain_deleter_executions_deleted", metricType: Counter},
+		DomainDeleterTaskListsDeletedCount:            {metricName: "domain_deleter_task_lists_deleted", metricType: Counter},
+		DomainDeleterErrorCount:                       {metricName: "domain_deleter_errors", metricType: Counter},
 	},
 }
 
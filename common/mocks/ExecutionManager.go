@@ -221,6 +221,29 @@ func (_m *ExecutionManager) GetCurrentExecution(request *persistence.GetCurrentE
 	return r0, r1
 }
 
+// ListConcreteExecutions provides a mock function with given fields: request
+func (_m *ExecutionManager) ListConcreteExecutions(request *persistence.ListConcreteExecutionsRequest) (*persistence.ListConcreteExecutionsResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.ListConcreteExecutionsResponse
+	if rf, ok := ret.Get(0).(func(*persistence.ListConcreteExecutionsRequest) *persistence.ListConcreteExecutionsResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListConcreteExecutionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.ListConcreteExecutionsRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTransferTasks provides a mock function with given fields: request
 func (_m *ExecutionManager) GetTransferTasks(request *persistence.GetTransferTasksRequest) (*persistence.GetTransferTasksResponse, error) {
 	ret := _m.Called(request)
@@ -272,6 +295,57 @@ func (_m *ExecutionManager) RangeCompleteTransferTask(request *persistence.Range
 	return r0
 }
 
+// PutTransferTaskToDLQ provides a mock function with given fields: request
+func (_m *ExecutionManager) PutTransferTaskToDLQ(request *persistence.PutTransferTaskToDLQRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.PutTransferTaskToDLQRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetTransferTasksFromDLQ provides a mock function with given fields: request
+func (_m *ExecutionManager) GetTransferTasksFromDLQ(request *persistence.GetTransferTasksFromDLQRequest) (*persistence.GetTransferTasksFromDLQResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.GetTransferTasksFromDLQResponse
+	if rf, ok := ret.Get(0).(func(*persistence.GetTransferTasksFromDLQRequest) *persistence.GetTransferTasksFromDLQResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.GetTransferTasksFromDLQResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.GetTransferTasksFromDLQRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteTransferTaskFromDLQ provides a mock function with given fields: request
+func (_m *ExecutionManager) DeleteTransferTaskFromDLQ(request *persistence.DeleteTransferTaskFromDLQRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.DeleteTransferTaskFromDLQRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetReplicationTasks provides a mock function with given fields: request
 func (_m *ExecutionManager) GetReplicationTasks(request *persistence.GetReplicationTasksRequest) (*persistence.GetReplicationTasksResponse, error) {
 	ret := _m.Called(request)
@@ -411,6 +485,57 @@ func (_m *ExecutionManager) RangeCompleteTimerTask(request *persistence.RangeCom
 	return r0
 }
 
+// PutTimerTaskToDLQ provides a mock function with given fields: request
+func (_m *ExecutionManager) PutTimerTaskToDLQ(request *persistence.PutTimerTaskToDLQRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.PutTimerTaskToDLQRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetTimerTasksFromDLQ provides a mock function with given fields: request
+func (_m *ExecutionManager) GetTimerTasksFromDLQ(request *persistence.GetTimerTasksFromDLQRequest) (*persistence.GetTimerTasksFromDLQResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.GetTimerTasksFromDLQResponse
+	if rf, ok := ret.Get(0).(func(*persistence.GetTimerTasksFromDLQRequest) *persistence.GetTimerTasksFromDLQResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.GetTimerTasksFromDLQResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.GetTimerTasksFromDLQRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteTimerTaskFromDLQ provides a mock function with given fields: request
+func (_m *ExecutionManager) DeleteTimerTaskFromDLQ(request *persistence.DeleteTimerTaskFromDLQRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.DeleteTimerTaskFromDLQRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Close provides a mock function with given fields:
 func (_m *ExecutionManager) Close() {
 	_m.Called()
@@ -62,6 +62,10 @@ WHERE metadata_partition = ?`
 cluster_membership (membership_partition, host_id, rpc_address, rpc_port, role, session_start, last_heartbeat) 
 VALUES (?, ?, ?, ?, ?, ?, ?) USING TTL ?`
 
+	templateDeleteClusterMembership = `DELETE FROM
+cluster_membership 
+WHERE membership_partition = ? AND role = ? AND host_id = ?`
+
 	templateGetClusterMembership = `SELECT host_id, rpc_address, rpc_port, role, session_start, last_heartbeat, toTimestamp(now()) as now, TTL(session_start) as ttl_sec FROM
 cluster_membership 
 WHERE membership_partition = ?`
@@ -268,3 +272,12 @@ func (m *cassandraClusterMetadata) UpsertClusterMembership(request *p.UpsertClus
 func (m *cassandraClusterMetadata) PruneClusterMembership(request *p.PruneClusterMembershipRequest) error {
 	return nil
 }
+
+func (m *cassandraClusterMetadata) DeleteClusterMembership(request *p.DeleteClusterMembershipRequest) error {
+	query := m.session.Query(templateDeleteClusterMembership, constMembershipPartition, request.Role, request.HostID)
+	if err := query.Exec(); err != nil {
+		return convertCommonErrors("DeleteClusterMembership", err)
+	}
+
+	return nil
+}
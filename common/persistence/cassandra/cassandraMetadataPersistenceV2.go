@@ -59,7 +59,10 @@ const (
 		`visibility_archival_status: ?, ` +
 		`visibility_archival_uri: ?, ` +
 		`bad_binaries: ?,` +
-		`bad_binaries_encoding: ?` +
+		`bad_binaries_encoding: ?, ` +
+		`search_attributes: ?, ` +
+		`workflow_defaults: ?, ` +
+		`workflow_defaults_encoding: ?` +
 		`}`
 
 	templateDomainReplicationConfigType = `{` +
@@ -87,7 +90,8 @@ const (
 		`config.archival_bucket, config.archival_status, ` +
 		`config.history_archival_status, config.history_archival_uri, ` +
 		`config.visibility_archival_status, config.visibility_archival_uri, ` +
-		`config.bad_binaries, config.bad_binaries_encoding, ` +
+		`config.bad_binaries, config.bad_binaries_encoding, config.search_attributes, ` +
+		`config.workflow_defaults, config.workflow_defaults_encoding, ` +
 		`replication_config.active_cluster_name, replication_config.clusters, ` +
 		`is_global_domain, ` +
 		`config_version, ` +
@@ -129,7 +133,8 @@ const (
 		`config.archival_bucket, config.archival_status, ` +
 		`config.history_archival_status, config.history_archival_uri, ` +
 		`config.visibility_archival_status, config.visibility_archival_uri, ` +
-		`config.bad_binaries, config.bad_binaries_encoding, ` +
+		`config.bad_binaries, config.bad_binaries_encoding, config.search_attributes, ` +
+		`config.workflow_defaults, config.workflow_defaults_encoding, ` +
 		`replication_config.active_cluster_name, replication_config.clusters, ` +
 		`is_global_domain, ` +
 		`config_version, ` +
@@ -202,6 +207,7 @@ func (m *cassandraMetadataPersistenceV2) CreateDomainInV2Table(request *p.Intern
 		return nil, err
 	}
 
+	workflowDefaultsData, workflowDefaultsEncoding := p.FromDataBlob(request.Config.WorkflowDefaults)
 	batch := m.session.NewBatch(gocql.LoggedBatch)
 	batch.Query(templateCreateDomainByNameQueryWithinBatchV2,
 		constDomainPartition,
@@ -222,6 +228,9 @@ func (m *cassandraMetadataPersistenceV2) CreateDomainInV2Table(request *p.Intern
 		request.Config.VisibilityArchivalURI,
 		request.Config.BadBinaries.Data,
 		string(request.Config.BadBinaries.GetEncoding()),
+		request.Config.SearchAttributes,
+		workflowDefaultsData,
+		workflowDefaultsEncoding,
 		request.ReplicationConfig.ActiveClusterName,
 		p.SerializeClusterConfigs(request.ReplicationConfig.Clusters),
 		request.IsGlobalDomain,
@@ -268,6 +277,7 @@ func (m *cassandraMetadataPersistenceV2) CreateDomainInV2Table(request *p.Intern
 }
 
 func (m *cassandraMetadataPersistenceV2) UpdateDomain(request *p.InternalUpdateDomainRequest) error {
+	workflowDefaultsData, workflowDefaultsEncoding := p.FromDataBlob(request.Config.WorkflowDefaults)
 	batch := m.session.NewBatch(gocql.LoggedBatch)
 	batch.Query(templateUpdateDomainByNameQueryWithinBatchV2,
 		request.Info.ID,
@@ -286,6 +296,9 @@ func (m *cassandraMetadataPersistenceV2) UpdateDomain(request *p.InternalUpdateD
 		request.Config.VisibilityArchivalURI,
 		request.Config.BadBinaries.Data,
 		string(request.Config.BadBinaries.GetEncoding()),
+		request.Config.SearchAttributes,
+		workflowDefaultsData,
+		workflowDefaultsEncoding,
 		request.ReplicationConfig.ActiveClusterName,
 		p.SerializeClusterConfigs(request.ReplicationConfig.Clusters),
 		request.ConfigVersion,
@@ -368,6 +381,8 @@ func (m *cassandraMetadataPersistenceV2) GetDomain(request *p.GetDomainRequest)
 
 	var badBinariesData []byte
 	var badBinariesDataEncoding string
+	var workflowDefaultsData []byte
+	var workflowDefaultsDataEncoding string
 
 	query = m.session.Query(templateGetDomainByNameQueryV2, constDomainPartition, domainName)
 	err = query.Scan(
@@ -387,6 +402,9 @@ func (m *cassandraMetadataPersistenceV2) GetDomain(request *p.GetDomainRequest)
 		&config.VisibilityArchivalURI,
 		&badBinariesData,
 		&badBinariesDataEncoding,
+		&config.SearchAttributes,
+		&workflowDefaultsData,
+		&workflowDefaultsDataEncoding,
 		&replicationConfig.ActiveClusterName,
 		&replicationClusters,
 		&isGlobalDomain,
@@ -404,6 +422,7 @@ func (m *cassandraMetadataPersistenceV2) GetDomain(request *p.GetDomainRequest)
 		info.Data = map[string]string{}
 	}
 	config.BadBinaries = p.NewDataBlob(badBinariesData, common.EncodingType(badBinariesDataEncoding))
+	config.WorkflowDefaults = p.NewDataBlob(workflowDefaultsData, common.EncodingType(workflowDefaultsDataEncoding))
 	replicationConfig.ActiveClusterName = p.GetOrUseDefaultActiveCluster(m.currentClusterName, replicationConfig.ActiveClusterName)
 	replicationConfig.Clusters = p.DeserializeClusterConfigs(replicationClusters)
 	replicationConfig.Clusters = p.GetOrUseDefaultClusters(m.currentClusterName, replicationConfig.Clusters)
@@ -440,6 +459,8 @@ func (m *cassandraMetadataPersistenceV2) ListDomains(request *p.ListDomainsReque
 	var replicationClusters []map[string]interface{}
 	var badBinariesData []byte
 	var badBinariesDataEncoding string
+	var workflowDefaultsData []byte
+	var workflowDefaultsDataEncoding string
 	response := &p.InternalListDomainsResponse{}
 	for iter.Scan(
 		&name,
@@ -459,6 +480,9 @@ func (m *cassandraMetadataPersistenceV2) ListDomains(request *p.ListDomainsReque
 		&domain.Config.VisibilityArchivalURI,
 		&badBinariesData,
 		&badBinariesDataEncoding,
+		&domain.Config.SearchAttributes,
+		&workflowDefaultsData,
+		&workflowDefaultsDataEncoding,
 		&domain.ReplicationConfig.ActiveClusterName,
 		&replicationClusters,
 		&domain.IsGlobalDomain,
@@ -475,6 +499,9 @@ func (m *cassandraMetadataPersistenceV2) ListDomains(request *p.ListDomainsReque
 			domain.Config.BadBinaries = p.NewDataBlob(badBinariesData, common.EncodingType(badBinariesDataEncoding))
 			badBinariesData = []byte("")
 			badBinariesDataEncoding = ""
+			domain.Config.WorkflowDefaults = p.NewDataBlob(workflowDefaultsData, common.EncodingType(workflowDefaultsDataEncoding))
+			workflowDefaultsData = []byte("")
+			workflowDefaultsDataEncoding = ""
 			domain.ReplicationConfig.ActiveClusterName = p.GetOrUseDefaultActiveCluster(m.currentClusterName, domain.ReplicationConfig.ActiveClusterName)
 			domain.ReplicationConfig.Clusters = p.DeserializeClusterConfigs(replicationClusters)
 			domain.ReplicationConfig.Clusters = p.GetOrUseDefaultClusters(m.currentClusterName, domain.ReplicationConfig.Clusters)
@@ -516,7 +543,7 @@ func (m *cassandraMetadataPersistenceV2) DeleteDomain(request *p.DeleteDomainReq
 func (m *cassandraMetadataPersistenceV2) DeleteDomainByName(request *p.DeleteDomainByNameRequest) error {
 	var ID string
 	query := m.session.Query(templateGetDomainByNameQueryV2, constDomainPartition, request.Name)
-	err := query.Scan(&ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	err := query.Scan(&ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	if err != nil {
 		if err == gocql.ErrNotFound {
 			return nil
@@ -69,12 +69,23 @@ const (
 	// Row Constants for Replication Task DLQ Row. Source cluster name will be used as WorkflowID.
 	rowTypeDLQDomainID = "10000000-6000-f000-f000-000000000000"
 	rowTypeDLQRunID    = "30000000-6000-f000-f000-000000000000"
+	// Row Constants for Transfer Task DLQ Row
+	rowTypeTransferDLQDomainID   = "10000000-7000-f000-f000-000000000000"
+	rowTypeTransferDLQWorkflowID = "20000000-7000-f000-f000-000000000000"
+	rowTypeTransferDLQRunID      = "30000000-7000-f000-f000-000000000000"
+	// Row Constants for Timer Task DLQ Row
+	rowTypeTimerDLQDomainID   = "10000000-8000-f000-f000-000000000000"
+	rowTypeTimerDLQWorkflowID = "20000000-8000-f000-f000-000000000000"
+	rowTypeTimerDLQRunID      = "30000000-8000-f000-f000-000000000000"
 	// Special TaskId constants
 	rowTypeExecutionTaskID = int64(-10)
 	rowTypeShardTaskID     = int64(-11)
 	emptyInitiatedID       = int64(-7)
 
 	stickyTaskListTTL = int32(24 * time.Hour / time.Second) // if sticky task_list stopped being updated, remove it in one day
+
+	// maxListTaskListPageSize bounds the task lists returned by a page of ListTaskList, each page is a table scan
+	maxListTaskListPageSize = 1000
 )
 
 const (
@@ -85,6 +96,8 @@ const (
 	rowTypeTimerTask
 	rowTypeReplicationTask
 	rowTypeDLQ
+	rowTypeTransferDLQ
+	rowTypeTimerDLQ
 )
 
 const (
@@ -111,7 +124,8 @@ const (
 		`cluster_transfer_ack_level: ?, ` +
 		`cluster_timer_ack_level: ?, ` +
 		`domain_notification_version: ?, ` +
-		`cluster_replication_level: ? ` +
+		`cluster_replication_level: ?, ` +
+		`open_workflow_counts: ? ` +
 		`}`
 
 	templateWorkflowExecutionType = `{` +
@@ -172,7 +186,8 @@ const (
 		`cron_schedule: ?, ` +
 		`expiration_seconds: ?, ` +
 		`search_attributes: ?, ` +
-		`memo: ? ` +
+		`memo: ?, ` +
+		`starting_build_id: ? ` +
 		`}`
 
 	templateReplicationStateType = `{` +
@@ -314,7 +329,10 @@ const (
 		`type: ?, ` +
 		`ack_level: ?, ` +
 		`kind: ?, ` +
-		`last_updated: ? ` +
+		`last_updated: ?, ` +
+		`build_id_sets: ?, ` +
+		`num_write_partitions: ?, ` +
+		`num_read_partitions: ? ` +
 		`}`
 
 	templateTaskType = `{` +
@@ -437,6 +455,11 @@ workflow_state = ? ` +
 		`and visibility_ts = ? ` +
 		`and task_id = ?`
 
+	templateListConcreteExecutionsQuery = `SELECT domain_id, workflow_id, run_id ` +
+		`FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ?`
+
 	templateCheckWorkflowExecutionQuery = `UPDATE executions ` +
 		`SET next_event_id = ? ` +
 		`WHERE shard_id = ? ` +
@@ -712,6 +735,14 @@ workflow_state = ? ` +
 		`and task_id > ? ` +
 		`and task_id <= ?`
 
+	templateGetTransferDLQTasksQuery = `SELECT transfer ` +
+		`FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ?`
+
 	templateGetReplicationTasksQuery = `SELECT replication ` +
 		`FROM executions ` +
 		`WHERE shard_id = ? ` +
@@ -761,6 +792,14 @@ workflow_state = ? ` +
 		`and visibility_ts >= ? ` +
 		`and visibility_ts < ?`
 
+	templateGetTimerDLQTasksQuery = `SELECT timer ` +
+		`FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ?`
+
 	templateCompleteTimerTaskQuery = `DELETE FROM executions ` +
 		`WHERE shard_id = ? ` +
 		`and type = ? ` +
@@ -810,6 +849,18 @@ workflow_state = ? ` +
 		`AND type = ? ` +
 		`AND task_id <= ? `
 
+	// templateListTaskListQuery has no partition key, it is served by a scan of the whole tasks table
+	// which skips the task rows, so it is only meant for background jobs and is always paged
+	templateListTaskListQuery = `SELECT ` +
+		`domain_id, ` +
+		`task_list_name, ` +
+		`task_list_type, ` +
+		`range_id, ` +
+		`task_list ` +
+		`FROM tasks ` +
+		`WHERE type = ? ` +
+		`ALLOW FILTERING`
+
 	templateGetTaskList = `SELECT ` +
 		`range_id, ` +
 		`task_list ` +
@@ -961,6 +1012,7 @@ func (d *cassandraPersistence) CreateShard(request *p.CreateShardRequest) error
 		shardInfo.ClusterTimerAckLevel,
 		shardInfo.DomainNotificationVersion,
 		shardInfo.ClusterReplicationLevel,
+		shardInfo.OpenWorkflowCounts,
 		shardInfo.RangeID)
 
 	previous := make(map[string]interface{})
@@ -1037,6 +1089,7 @@ func (d *cassandraPersistence) UpdateShard(request *p.UpdateShardRequest) error
 		shardInfo.ClusterTimerAckLevel,
 		shardInfo.DomainNotificationVersion,
 		shardInfo.ClusterReplicationLevel,
+		shardInfo.OpenWorkflowCounts,
 		shardInfo.RangeID,
 		shardInfo.ShardID,
 		rowTypeShard,
@@ -2077,6 +2130,55 @@ func (d *cassandraPersistence) GetCurrentExecution(request *p.GetCurrentExecutio
 	}, nil
 }
 
+func (d *cassandraPersistence) ListConcreteExecutions(
+	request *p.ListConcreteExecutionsRequest,
+) (*p.ListConcreteExecutionsResponse, error) {
+
+	query := d.session.Query(
+		templateListConcreteExecutionsQuery,
+		d.shardID,
+		rowTypeExecution,
+	).PageSize(request.PageSize).PageState(request.PageToken)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListConcreteExecutions operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &p.ListConcreteExecutionsResponse{}
+	var domainID, runID gocql.UUID
+	var workflowID string
+	for iter.Scan(&domainID, &workflowID, &runID) {
+		// the current execution rows share the row type of executions
+		if runID.String() == permanentRunID {
+			continue
+		}
+		response.Executions = append(response.Executions, &p.ConcreteExecution{
+			DomainID:   domainID.String(),
+			WorkflowID: workflowID,
+			RunID:      runID.String(),
+		})
+	}
+	nextPageToken := iter.PageState()
+	response.PageToken = make([]byte, len(nextPageToken))
+	copy(response.PageToken, nextPageToken)
+
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("ListConcreteExecutions operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListConcreteExecutions operation failed. Error: %v", err),
+		}
+	}
+
+	return response, nil
+}
+
 func (d *cassandraPersistence) GetTransferTasks(request *p.GetTransferTasksRequest) (*p.GetTransferTasksResponse, error) {
 
 	// Reading transfer tasks need to be quorum level consistent, otherwise we could loose task
@@ -2347,6 +2449,8 @@ func (d *cassandraPersistence) LeaseTaskList(request *p.LeaseTaskListRequest) (*
 		taskListTaskID,
 	)
 	var rangeID, ackLevel int64
+	var buildIDSets [][]string
+	var numWritePartitions, numReadPartitions int
 	var tlDB map[string]interface{}
 	err := query.Scan(&rangeID, &tlDB)
 	if err != nil {
@@ -2364,6 +2468,9 @@ func (d *cassandraPersistence) LeaseTaskList(request *p.LeaseTaskListRequest) (*
 				0,
 				request.TaskListKind,
 				now,
+				nil,
+				0,
+				0,
 			)
 		} else if isThrottlingError(err) {
 			return nil, &workflow.ServiceBusyError{
@@ -2388,6 +2495,9 @@ func (d *cassandraPersistence) LeaseTaskList(request *p.LeaseTaskListRequest) (*
 		}
 		ackLevel = tlDB["ack_level"].(int64)
 		taskListKind := tlDB["kind"].(int)
+		buildIDSets, _ = tlDB["build_id_sets"].([][]string)
+		numWritePartitions, _ = tlDB["num_write_partitions"].(int)
+		numReadPartitions, _ = tlDB["num_read_partitions"].(int)
 		query = d.session.Query(templateUpdateTaskListQuery,
 			rangeID+1,
 			request.DomainID,
@@ -2396,6 +2506,9 @@ func (d *cassandraPersistence) LeaseTaskList(request *p.LeaseTaskListRequest) (*
 			ackLevel,
 			taskListKind,
 			now,
+			buildIDSets,
+			numWritePartitions,
+			numReadPartitions,
 			request.DomainID,
 			&request.TaskList,
 			request.TaskType,
@@ -2424,13 +2537,16 @@ func (d *cassandraPersistence) LeaseTaskList(request *p.LeaseTaskListRequest) (*
 		}
 	}
 	tli := &p.TaskListInfo{
-		DomainID:    request.DomainID,
-		Name:        request.TaskList,
-		TaskType:    request.TaskType,
-		RangeID:     rangeID + 1,
-		AckLevel:    ackLevel,
-		Kind:        request.TaskListKind,
-		LastUpdated: now,
+		DomainID:           request.DomainID,
+		Name:               request.TaskList,
+		TaskType:           request.TaskType,
+		RangeID:            rangeID + 1,
+		AckLevel:           ackLevel,
+		Kind:               request.TaskListKind,
+		LastUpdated:        now,
+		BuildIDSets:        buildIDSets,
+		NumWritePartitions: numWritePartitions,
+		NumReadPartitions:  numReadPartitions,
 	}
 	return &p.LeaseTaskListResponse{TaskListInfo: tli}, nil
 }
@@ -2453,6 +2569,9 @@ func (d *cassandraPersistence) UpdateTaskList(request *p.UpdateTaskListRequest)
 			tli.AckLevel,
 			tli.Kind,
 			time.Now(),
+			tli.BuildIDSets,
+			tli.NumWritePartitions,
+			tli.NumReadPartitions,
 			stickyTaskListTTL,
 		)
 		err := query.Exec()
@@ -2477,6 +2596,9 @@ func (d *cassandraPersistence) UpdateTaskList(request *p.UpdateTaskListRequest)
 		tli.AckLevel,
 		tli.Kind,
 		time.Now(),
+		tli.BuildIDSets,
+		tli.NumWritePartitions,
+		tli.NumReadPartitions,
 		tli.DomainID,
 		&tli.Name,
 		tli.TaskType,
@@ -2513,10 +2635,66 @@ func (d *cassandraPersistence) UpdateTaskList(request *p.UpdateTaskListRequest)
 	return &p.UpdateTaskListResponse{}, nil
 }
 
+// ListTaskList scans the task list rows of all partitions of the tasks table
+// ListTaskList lists the task lists of all domains. Cassandra has no index on the task list rows,
+// every page scans the tasks table, task rows included, until it finds a page worth of task lists,
+// so the cost of listing all task lists is a full scan of the table. Pages are bounded by
+// maxListTaskListPageSize and a page is never fetched beyond the requested one.
 func (d *cassandraPersistence) ListTaskList(request *p.ListTaskListRequest) (*p.ListTaskListResponse, error) {
-	return nil, &workflow.InternalServiceError{
-		Message: fmt.Sprintf("unsupported operation"),
+	pageSize := request.PageSize
+	if pageSize <= 0 || pageSize > maxListTaskListPageSize {
+		pageSize = maxListTaskListPageSize
+	}
+	query := d.session.Query(
+		templateListTaskListQuery,
+		rowTypeTaskList,
+	).PageSize(pageSize).PageState(request.PageToken)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListTaskList operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &p.ListTaskListResponse{}
+	var domainID gocql.UUID
+	var name string
+	var taskType int
+	var rangeID int64
+	var tlDB map[string]interface{}
+	for iter.Scan(&domainID, &name, &taskType, &rangeID, &tlDB) {
+		info := p.TaskListInfo{
+			DomainID: domainID.String(),
+			Name:     name,
+			TaskType: taskType,
+			RangeID:  rangeID,
+		}
+		info.AckLevel, _ = tlDB["ack_level"].(int64)
+		info.Kind, _ = tlDB["kind"].(int)
+		info.LastUpdated, _ = tlDB["last_updated"].(time.Time)
+		info.BuildIDSets, _ = tlDB["build_id_sets"].([][]string)
+		info.NumWritePartitions, _ = tlDB["num_write_partitions"].(int)
+		info.NumReadPartitions, _ = tlDB["num_read_partitions"].(int)
+		response.Items = append(response.Items, info)
+		tlDB = nil
+	}
+	if nextPageToken := iter.PageState(); len(nextPageToken) > 0 {
+		response.NextPageToken = make([]byte, len(nextPageToken))
+		copy(response.NextPageToken, nextPageToken)
+	}
+
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("ListTaskList operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListTaskList operation failed. Error: %v", err),
+		}
 	}
+	return response, nil
 }
 
 func (d *cassandraPersistence) DeleteTaskList(request *p.DeleteTaskListRequest) error {
@@ -2595,6 +2773,9 @@ func (d *cassandraPersistence) CreateTasks(request *p.CreateTasksRequest) (*p.Cr
 		ackLevel,
 		taskListKind,
 		time.Now(),
+		request.TaskListInfo.BuildIDSets,
+		request.TaskListInfo.NumWritePartitions,
+		request.TaskListInfo.NumReadPartitions,
 		domainID,
 		taskList,
 		taskListType,
@@ -2832,3 +3013,225 @@ func (d *cassandraPersistence) GetReplicationTasksFromDLQ(
 
 	return d.populateGetReplicationTasksResponse(query)
 }
+
+func (d *cassandraPersistence) PutTransferTaskToDLQ(request *p.PutTransferTaskToDLQRequest) error {
+	task := request.TaskInfo
+	targetRunID := task.TargetRunID
+	if targetRunID == "" {
+		targetRunID = p.TransferTaskTransferTargetRunID
+	}
+	query := d.session.Query(templateCreateTransferTaskQuery,
+		d.shardID,
+		rowTypeTransferDLQ,
+		rowTypeTransferDLQDomainID,
+		rowTypeTransferDLQWorkflowID,
+		rowTypeTransferDLQRunID,
+		task.DomainID,
+		task.WorkflowID,
+		task.RunID,
+		task.VisibilityTimestamp,
+		task.TaskID,
+		task.TargetDomainID,
+		task.TargetWorkflowID,
+		targetRunID,
+		task.TargetChildWorkflowOnly,
+		task.TaskList,
+		task.TaskType,
+		task.ScheduleID,
+		task.RecordVisibility,
+		task.Version,
+		defaultVisibilityTimestamp,
+		task.TaskID)
+
+	err := query.Exec()
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("PutTransferTaskToDLQ operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("PutTransferTaskToDLQ operation failed. Error: %v", err),
+		}
+	}
+
+	return nil
+}
+
+func (d *cassandraPersistence) GetTransferTasksFromDLQ(
+	request *p.GetTransferTasksFromDLQRequest,
+) (*p.GetTransferTasksFromDLQResponse, error) {
+	query := d.session.Query(templateGetTransferDLQTasksQuery,
+		d.shardID,
+		rowTypeTransferDLQ,
+		rowTypeTransferDLQDomainID,
+		rowTypeTransferDLQWorkflowID,
+		rowTypeTransferDLQRunID,
+	).PageSize(request.BatchSize).PageState(request.NextPageToken)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "GetTransferTasksFromDLQ operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &p.GetTransferTasksFromDLQResponse{}
+	task := make(map[string]interface{})
+	for iter.MapScan(task) {
+		t := createTransferTaskInfo(task["transfer"].(map[string]interface{}))
+		// Reset task map to get it ready for next scan
+		task = make(map[string]interface{})
+
+		response.Tasks = append(response.Tasks, t)
+	}
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("GetTransferTasksFromDLQ operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetTransferTasksFromDLQ operation failed. Error: %v", err),
+		}
+	}
+
+	return response, nil
+}
+
+func (d *cassandraPersistence) DeleteTransferTaskFromDLQ(request *p.DeleteTransferTaskFromDLQRequest) error {
+	query := d.session.Query(templateCompleteTransferTaskQuery,
+		d.shardID,
+		rowTypeTransferDLQ,
+		rowTypeTransferDLQDomainID,
+		rowTypeTransferDLQWorkflowID,
+		rowTypeTransferDLQRunID,
+		defaultVisibilityTimestamp,
+		request.TaskID)
+
+	err := query.Exec()
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("DeleteTransferTaskFromDLQ operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteTransferTaskFromDLQ operation failed. Error: %v", err),
+		}
+	}
+
+	return nil
+}
+
+func (d *cassandraPersistence) PutTimerTaskToDLQ(request *p.PutTimerTaskToDLQRequest) error {
+	task := request.TaskInfo
+	ts := p.UnixNanoToDBTimestamp(task.VisibilityTimestamp.UnixNano())
+	query := d.session.Query(templateCreateTimerTaskQuery,
+		d.shardID,
+		rowTypeTimerDLQ,
+		rowTypeTimerDLQDomainID,
+		rowTypeTimerDLQWorkflowID,
+		rowTypeTimerDLQRunID,
+		task.DomainID,
+		task.WorkflowID,
+		task.RunID,
+		ts,
+		task.TaskID,
+		task.TaskType,
+		task.TimeoutType,
+		task.EventID,
+		task.ScheduleAttempt,
+		task.Version,
+		ts,
+		task.TaskID)
+
+	err := query.Exec()
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("PutTimerTaskToDLQ operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("PutTimerTaskToDLQ operation failed. Error: %v", err),
+		}
+	}
+
+	return nil
+}
+
+func (d *cassandraPersistence) GetTimerTasksFromDLQ(
+	request *p.GetTimerTasksFromDLQRequest,
+) (*p.GetTimerTasksFromDLQResponse, error) {
+	query := d.session.Query(templateGetTimerDLQTasksQuery,
+		d.shardID,
+		rowTypeTimerDLQ,
+		rowTypeTimerDLQDomainID,
+		rowTypeTimerDLQWorkflowID,
+		rowTypeTimerDLQRunID,
+	).PageSize(request.BatchSize).PageState(request.NextPageToken)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "GetTimerTasksFromDLQ operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &p.GetTimerTasksFromDLQResponse{}
+	task := make(map[string]interface{})
+	for iter.MapScan(task) {
+		t := createTimerTaskInfo(task["timer"].(map[string]interface{}))
+		// Reset task map to get it ready for next scan
+		task = make(map[string]interface{})
+
+		response.Timers = append(response.Timers, t)
+	}
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("GetTimerTasksFromDLQ operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetTimerTasksFromDLQ operation failed. Error: %v", err),
+		}
+	}
+
+	return response, nil
+}
+
+func (d *cassandraPersistence) DeleteTimerTaskFromDLQ(request *p.DeleteTimerTaskFromDLQRequest) error {
+	ts := p.UnixNanoToDBTimestamp(request.VisibilityTimestamp.UnixNano())
+	query := d.session.Query(templateCompleteTimerTaskQuery,
+		d.shardID,
+		rowTypeTimerDLQ,
+		rowTypeTimerDLQDomainID,
+		rowTypeTimerDLQWorkflowID,
+		rowTypeTimerDLQRunID,
+		ts,
+		request.TaskID)
+
+	err := query.Exec()
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("DeleteTimerTaskFromDLQ operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteTimerTaskFromDLQ operation failed. Error: %v", err),
+		}
+	}
+
+	return nil
+}
@@ -463,6 +463,7 @@ func createExecution(
 			executionInfo.ExpirationSeconds,
 			executionInfo.SearchAttributes,
 			executionInfo.Memo,
+			executionInfo.StartingBuildID,
 			executionInfo.NextEventID,
 			defaultVisibilityTimestamp,
 			rowTypeExecutionTaskID,
@@ -536,6 +537,7 @@ func createExecution(
 			executionInfo.ExpirationSeconds,
 			executionInfo.SearchAttributes,
 			executionInfo.Memo,
+			executionInfo.StartingBuildID,
 			executionInfo.NextEventID,
 			defaultVisibilityTimestamp,
 			rowTypeExecutionTaskID,
@@ -614,6 +616,7 @@ func createExecution(
 			executionInfo.ExpirationSeconds,
 			executionInfo.SearchAttributes,
 			executionInfo.Memo,
+			executionInfo.StartingBuildID,
 			replicationState.CurrentVersion,
 			replicationState.StartVersion,
 			replicationState.LastWriteVersion,
@@ -731,6 +734,7 @@ func updateExecution(
 			executionInfo.ExpirationSeconds,
 			executionInfo.SearchAttributes,
 			executionInfo.Memo,
+			executionInfo.StartingBuildID,
 			executionInfo.NextEventID,
 			checksum.Version,
 			checksum.Flavor,
@@ -805,6 +809,7 @@ func updateExecution(
 			executionInfo.ExpirationSeconds,
 			executionInfo.SearchAttributes,
 			executionInfo.Memo,
+			executionInfo.StartingBuildID,
 			executionInfo.NextEventID,
 			versionHistoriesData,
 			versionHistoriesEncoding,
@@ -884,6 +889,7 @@ func updateExecution(
 			executionInfo.ExpirationSeconds,
 			executionInfo.SearchAttributes,
 			executionInfo.Memo,
+			executionInfo.StartingBuildID,
 			replicationState.CurrentVersion,
 			replicationState.StartVersion,
 			replicationState.LastWriteVersion,
@@ -1796,6 +1802,8 @@ func createShardInfo(
 			info.DomainNotificationVersion = v.(int64)
 		case "cluster_replication_level":
 			info.ClusterReplicationLevel = v.(map[string]int64)
+		case "open_workflow_counts":
+			info.OpenWorkflowCounts = v.(map[string]int64)
 		}
 	}
 
@@ -1812,6 +1820,9 @@ func createShardInfo(
 	if info.ClusterReplicationLevel == nil {
 		info.ClusterReplicationLevel = make(map[string]int64)
 	}
+	if info.OpenWorkflowCounts == nil {
+		info.OpenWorkflowCounts = make(map[string]int64)
+	}
 
 	return info
 }
@@ -1948,6 +1959,8 @@ func createWorkflowExecutionInfo(
 			info.SearchAttributes = v.(map[string][]byte)
 		case "memo":
 			info.Memo = v.(map[string][]byte)
+		case "starting_build_id":
+			info.StartingBuildID = v.(string)
 		}
 	}
 	info.CompletionEvent = p.NewDataBlob(completionEventData, completionEventEncoding)
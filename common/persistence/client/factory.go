@@ -24,6 +24,8 @@ import (
 	"sync"
 
 	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/blobstore"
+	"github.com/temporalio/temporal/common/blobstore/provider"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/metrics"
 	p "github.com/temporalio/temporal/common/persistence"
@@ -54,9 +56,19 @@ type (
 		NewVisibilityManager() (p.VisibilityManager, error)
 		// NewDomainReplicationQueue returns a new queue for domain replication
 		NewDomainReplicationQueue() (p.DomainReplicationQueue, error)
+		// NewAuditLogQueue returns a new queue for audit records
+		NewAuditLogQueue() (p.Queue, error)
 		// NewClusterMetadata returns a new manager for cluster specific metadata
 		NewClusterMetadataManager() (p.ClusterMetadataManager, error)
 	}
+	// FactoryProvider returns the persistence factory used by a service, NewFactory is
+	// the provider of the factory backed by the datastores configured in persistence config
+	FactoryProvider func(
+		cfg *config.Persistence,
+		clusterName string,
+		metricsClient metrics.Client,
+		logger log.Logger,
+	) Factory
 	// DataStoreFactory is a low level interface to be implemented by a datastore
 	// Examples of datastores are cassandra, mysql etc
 	DataStoreFactory interface {
@@ -90,6 +102,8 @@ type (
 		logger        log.Logger
 		datastores    map[storeType]Datastore
 		clusterName   string
+		faultInjector *p.FaultInjector
+		payloadStore  blobstore.Client
 	}
 
 	storeType int
@@ -123,7 +137,8 @@ var storeTypes = []storeType{
 // also contains config for individual datastores themselves.
 //
 // The objects returned by this factory enforce ratelimit and maxconns according to
-// given configuration. In addition, all objects will emit metrics automatically
+// given configuration. In addition, all objects will emit metrics automatically and
+// inject faults when fault injection is configured
 func NewFactory(
 	cfg *config.Persistence,
 	clusterName string,
@@ -138,6 +153,9 @@ func NewFactory(
 	}
 	limiters := buildRatelimiters(cfg)
 	factory.init(clusterName, limiters)
+	if cfg.FaultInjection != nil {
+		factory.faultInjector = p.NewFaultInjector(cfg.FaultInjection, logger)
+	}
 	return factory
 }
 
@@ -148,6 +166,9 @@ func (f *factoryImpl) NewTaskManager() (p.TaskManager, error) {
 	if err != nil {
 		return nil, err
 	}
+	if f.faultInjector != nil {
+		result = p.NewTaskPersistenceFaultInjectionClient(result, f.faultInjector, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewTaskPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -164,6 +185,9 @@ func (f *factoryImpl) NewShardManager() (p.ShardManager, error) {
 	if err != nil {
 		return nil, err
 	}
+	if f.faultInjector != nil {
+		result = p.NewShardPersistenceFaultInjectionClient(result, f.faultInjector, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewShardPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -181,6 +205,16 @@ func (f *factoryImpl) NewHistoryManager() (p.HistoryManager, error) {
 		return nil, err
 	}
 	result := p.NewHistoryV2ManagerImpl(store, f.logger, f.config.TransactionSizeLimit)
+	if f.config.HistoryPayloadStore != nil {
+		payloadStore, err := f.getPayloadStore()
+		if err != nil {
+			return nil, err
+		}
+		result = p.NewHistoryV2PayloadOffloadingClient(result, payloadStore, f.config.GetHistoryPayloadOffloadThreshold(), f.logger)
+	}
+	if f.faultInjector != nil {
+		result = p.NewHistoryV2PersistenceFaultInjectionClient(result, f.faultInjector, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewHistoryV2PersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -190,6 +224,20 @@ func (f *factoryImpl) NewHistoryManager() (p.HistoryManager, error) {
 	return result, nil
 }
 
+// getPayloadStore returns the history payload store shared by the history and execution managers
+func (f *factoryImpl) getPayloadStore() (blobstore.Client, error) {
+	f.Lock()
+	defer f.Unlock()
+	if f.payloadStore == nil {
+		payloadStore, err := provider.NewClient(f.config.HistoryPayloadStore)
+		if err != nil {
+			return nil, err
+		}
+		f.payloadStore = payloadStore
+	}
+	return f.payloadStore, nil
+}
+
 // NewMetadataManager returns a new metadata manager
 func (f *factoryImpl) NewMetadataManager() (p.MetadataManager, error) {
 	var err error
@@ -201,6 +249,9 @@ func (f *factoryImpl) NewMetadataManager() (p.MetadataManager, error) {
 	}
 
 	result := p.NewMetadataManagerImpl(store, f.logger)
+	if f.faultInjector != nil {
+		result = p.NewMetadataPersistenceFaultInjectionClient(result, f.faultInjector, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewMetadataPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -221,6 +272,9 @@ func (f *factoryImpl) NewClusterMetadataManager() (p.ClusterMetadataManager, err
 	}
 
 	result := p.NewClusterMetadataManagerImpl(store, f.logger)
+	if f.faultInjector != nil {
+		result = p.NewClusterMetadataPersistenceFaultInjectionClient(result, f.faultInjector, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewClusterMetadataPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -238,6 +292,16 @@ func (f *factoryImpl) NewExecutionManager(shardID int) (p.ExecutionManager, erro
 		return nil, err
 	}
 	result := p.NewExecutionManagerImpl(store, f.logger)
+	if f.config.HistoryPayloadStore != nil {
+		payloadStore, err := f.getPayloadStore()
+		if err != nil {
+			return nil, err
+		}
+		result = p.NewExecutionPayloadOffloadingClient(result, payloadStore, f.config.GetHistoryPayloadOffloadThreshold(), f.logger)
+	}
+	if f.faultInjector != nil {
+		result = p.NewWorkflowExecutionPersistenceFaultInjectionClient(result, f.faultInjector, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewWorkflowExecutionPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -260,6 +324,9 @@ func (f *factoryImpl) NewVisibilityManager() (p.VisibilityManager, error) {
 	}
 
 	result := p.NewVisibilityManagerImpl(store, f.logger)
+	if f.faultInjector != nil {
+		result = p.NewVisibilityPersistenceFaultInjectionClient(result, f.faultInjector, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewVisibilityPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -279,6 +346,9 @@ func (f *factoryImpl) NewDomainReplicationQueue() (p.DomainReplicationQueue, err
 	if err != nil {
 		return nil, err
 	}
+	if f.faultInjector != nil {
+		result = p.NewQueuePersistenceFaultInjectionClient(result, f.faultInjector, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewQueuePersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -289,6 +359,25 @@ func (f *factoryImpl) NewDomainReplicationQueue() (p.DomainReplicationQueue, err
 	return p.NewDomainReplicationQueue(result, f.clusterName, f.metricsClient, f.logger), nil
 }
 
+func (f *factoryImpl) NewAuditLogQueue() (p.Queue, error) {
+	ds := f.datastores[storeTypeQueue]
+	result, err := ds.factory.NewQueue(common.AuditLogQueueType)
+	if err != nil {
+		return nil, err
+	}
+	if f.faultInjector != nil {
+		result = p.NewQueuePersistenceFaultInjectionClient(result, f.faultInjector, f.logger)
+	}
+	if ds.ratelimit != nil {
+		result = p.NewQueuePersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
+	}
+	if f.metricsClient != nil {
+		result = p.NewQueuePersistenceMetricsClient(result, f.metricsClient, f.logger)
+	}
+
+	return result, nil
+}
+
 // Close closes this factory
 func (f *factoryImpl) Close() {
 	ds := f.datastores[storeTypeExecution]
@@ -133,3 +133,7 @@ func (m *clusterMetadataManagerImpl) UpsertClusterMembership(request *UpsertClus
 func (m *clusterMetadataManagerImpl) PruneClusterMembership(request *PruneClusterMembershipRequest) error {
 	return m.persistence.PruneClusterMembership(request)
 }
+
+func (m *clusterMetadataManagerImpl) DeleteClusterMembership(request *DeleteClusterMembershipRequest) error {
+	return m.persistence.DeleteClusterMembership(request)
+}
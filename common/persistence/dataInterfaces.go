@@ -249,6 +249,7 @@ type (
 		TimerFailoverLevels       map[string]TimerFailoverLevel    // uuid -> TimerFailoverLevel
 		ClusterReplicationLevel   map[string]int64                 // cluster -> last replicated taskID
 		DomainNotificationVersion int64
+		OpenWorkflowCounts        map[string]int64 // domainID -> open workflows of the shard
 	}
 
 	// TransferFailoverLevel contains corresponding start / end level
@@ -327,6 +328,8 @@ type (
 		// Cron
 		CronSchedule      string
 		ExpirationSeconds int32
+		// build ID of the worker that completed the first decision
+		StartingBuildID string
 	}
 
 	// ExecutionStats is the statistics about workflow execution
@@ -405,6 +408,12 @@ type (
 		Kind        int
 		Expiry      time.Time
 		LastUpdated time.Time
+		// BuildIDSets lists the sets of mutually compatible worker build IDs, ordered from oldest to latest
+		BuildIDSets [][]string
+		// NumWritePartitions and NumReadPartitions are the partition counts chosen by partition
+		// auto scaling on the root partition, zero if the counts from dynamic config apply
+		NumWritePartitions int
+		NumReadPartitions  int
 	}
 
 	// TaskInfo describes either activity or decision task
@@ -786,6 +795,26 @@ type (
 		LastWriteVersion int64
 	}
 
+	// ListConcreteExecutionsRequest is used to list the workflow executions of a shard
+	ListConcreteExecutionsRequest struct {
+		PageSize  int
+		PageToken []byte
+	}
+
+	// ListConcreteExecutionsResponse is the response to ListConcreteExecutions
+	ListConcreteExecutionsResponse struct {
+		Executions []*ConcreteExecution
+		PageToken  []byte
+	}
+
+	// ConcreteExecution identifies a workflow execution stored in a shard, as opposed
+	// to the current execution row which points to the current run of a workflow
+	ConcreteExecution struct {
+		DomainID   string
+		WorkflowID string
+		RunID      string
+	}
+
 	// UpdateWorkflowExecutionRequest is used to update a workflow execution
 	UpdateWorkflowExecutionRequest struct {
 		RangeID int64
@@ -997,6 +1026,45 @@ type (
 	// GetReplicationTasksFromDLQResponse is the response for GetReplicationTasksFromDLQ
 	GetReplicationTasksFromDLQResponse = GetReplicationTasksResponse
 
+	// PutTransferTaskToDLQRequest is used to put a transfer task to dlq
+	PutTransferTaskToDLQRequest struct {
+		TaskInfo *TransferTaskInfo
+	}
+
+	// GetTransferTasksFromDLQRequest is used to get transfer tasks from dlq
+	GetTransferTasksFromDLQRequest struct {
+		BatchSize     int
+		NextPageToken []byte
+	}
+
+	// GetTransferTasksFromDLQResponse is the response for GetTransferTasksFromDLQ
+	GetTransferTasksFromDLQResponse = GetTransferTasksResponse
+
+	// DeleteTransferTaskFromDLQRequest is used to delete a transfer task from dlq
+	DeleteTransferTaskFromDLQRequest struct {
+		TaskID int64
+	}
+
+	// PutTimerTaskToDLQRequest is used to put a timer task to dlq
+	PutTimerTaskToDLQRequest struct {
+		TaskInfo *TimerTaskInfo
+	}
+
+	// GetTimerTasksFromDLQRequest is used to get timer tasks from dlq
+	GetTimerTasksFromDLQRequest struct {
+		BatchSize     int
+		NextPageToken []byte
+	}
+
+	// GetTimerTasksFromDLQResponse is the response for GetTimerTasksFromDLQ
+	GetTimerTasksFromDLQResponse = GetTimerIndexTasksResponse
+
+	// DeleteTimerTaskFromDLQRequest is used to delete a timer task from dlq
+	DeleteTimerTaskFromDLQRequest struct {
+		VisibilityTimestamp time.Time
+		TaskID              int64
+	}
+
 	// RangeCompleteTimerTaskRequest is used to complete a range of tasks in the timer task queue
 	RangeCompleteTimerTaskRequest struct {
 		InclusiveBeginTimestamp time.Time
@@ -1134,6 +1202,24 @@ type (
 		VisibilityArchivalStatus workflow.ArchivalStatus
 		VisibilityArchivalURI    string
 		BadBinaries              workflow.BadBinaries
+		// SearchAttributes maps the search attributes registered on the domain
+		// to the cluster search attributes (ES fields) they are stored in
+		SearchAttributes map[string]string
+		// WorkflowDefaults is nil when the domain has no workflow defaults
+		WorkflowDefaults *DomainWorkflowDefaults
+	}
+
+	// DomainWorkflowDefaults describes the defaults and maximums applied to the workflows
+	// and activities of a domain, zero values and nil fields are not applied
+	DomainWorkflowDefaults struct {
+		DefaultExecutionStartToCloseTimeoutSeconds   int32                           `json:"defaultExecutionStartToCloseTimeoutSeconds,omitempty"`
+		MaxExecutionStartToCloseTimeoutSeconds       int32                           `json:"maxExecutionStartToCloseTimeoutSeconds,omitempty"`
+		DefaultTaskStartToCloseTimeoutSeconds        int32                           `json:"defaultTaskStartToCloseTimeoutSeconds,omitempty"`
+		DefaultActivityScheduleToCloseTimeoutSeconds int32                           `json:"defaultActivityScheduleToCloseTimeoutSeconds,omitempty"`
+		MaxActivityScheduleToCloseTimeoutSeconds     int32                           `json:"maxActivityScheduleToCloseTimeoutSeconds,omitempty"`
+		DefaultWorkflowIDReusePolicy                 *workflow.WorkflowIdReusePolicy `json:"defaultWorkflowIdReusePolicy,omitempty"`
+		DefaultWorkflowRetryPolicy                   *workflow.RetryPolicy           `json:"defaultWorkflowRetryPolicy,omitempty"`
+		DefaultActivityRetryPolicy                   *workflow.RetryPolicy           `json:"defaultActivityRetryPolicy,omitempty"`
 	}
 
 	// DomainReplicationConfig describes the cross DC domain replication configuration
@@ -1493,6 +1579,12 @@ type (
 		MaxRecordsPruned int
 	}
 
+	// DeleteClusterMembershipRequest is the request to DeleteClusterMembership
+	DeleteClusterMembershipRequest struct {
+		Role   ServiceType
+		HostID []byte
+	}
+
 	// Closeable is an interface for any entity that supports a close operation to release resources
 	Closeable interface {
 		Close()
@@ -1521,11 +1613,16 @@ type (
 		DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error
 		DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error
 		GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error)
+		// ListConcreteExecutions lists the workflow executions of the shard, they are not in any particular order
+		ListConcreteExecutions(request *ListConcreteExecutionsRequest) (*ListConcreteExecutionsResponse, error)
 
 		// Transfer task related methods
 		GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error)
 		CompleteTransferTask(request *CompleteTransferTaskRequest) error
 		RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error
+		PutTransferTaskToDLQ(request *PutTransferTaskToDLQRequest) error
+		GetTransferTasksFromDLQ(request *GetTransferTasksFromDLQRequest) (*GetTransferTasksFromDLQResponse, error)
+		DeleteTransferTaskFromDLQ(request *DeleteTransferTaskFromDLQRequest) error
 
 		// Replication task related methods
 		GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error)
@@ -1538,6 +1635,9 @@ type (
 		GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error)
 		CompleteTimerTask(request *CompleteTimerTaskRequest) error
 		RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error
+		PutTimerTaskToDLQ(request *PutTimerTaskToDLQRequest) error
+		GetTimerTasksFromDLQ(request *GetTimerTasksFromDLQRequest) (*GetTimerTasksFromDLQResponse, error)
+		DeleteTimerTaskFromDLQ(request *DeleteTimerTaskFromDLQRequest) error
 
 		// Remove Task due to corrupted data
 		DeleteTask(request *DeleteTaskRequest) error
@@ -1623,6 +1723,7 @@ type (
 		GetClusterMembers(request *GetClusterMembersRequest) (*GetClusterMembersResponse, error)
 		UpsertClusterMembership(request *UpsertClusterMembershipRequest) error
 		PruneClusterMembership(request *PruneClusterMembershipRequest) error
+		DeleteClusterMembership(request *DeleteClusterMembershipRequest) error
 	}
 )
 
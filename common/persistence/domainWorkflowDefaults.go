@@ -0,0 +1,145 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	workflow "github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/common"
+)
+
+// ExecutionStartToCloseTimeout returns the workflow execution timeout to use for the requested one.
+// The domain default replaces an unset timeout and the domain maximum caps it.
+func (d *DomainWorkflowDefaults) ExecutionStartToCloseTimeout(requested int32) int32 {
+	if d == nil {
+		return requested
+	}
+	return capTimeout(defaultTimeout(requested, d.DefaultExecutionStartToCloseTimeoutSeconds), d.MaxExecutionStartToCloseTimeoutSeconds)
+}
+
+// TaskStartToCloseTimeout returns the decision task timeout to use for the requested one
+func (d *DomainWorkflowDefaults) TaskStartToCloseTimeout(requested int32) int32 {
+	if d == nil {
+		return requested
+	}
+	return defaultTimeout(requested, d.DefaultTaskStartToCloseTimeoutSeconds)
+}
+
+// ActivityScheduleToCloseTimeout returns the default activity schedule to close timeout, zero if not set
+func (d *DomainWorkflowDefaults) ActivityScheduleToCloseTimeout() int32 {
+	if d == nil {
+		return 0
+	}
+	return d.DefaultActivityScheduleToCloseTimeoutSeconds
+}
+
+// MaxActivityTimeout returns the maximum of the activity timeouts of a workflow with the given timeout
+func (d *DomainWorkflowDefaults) MaxActivityTimeout(workflowTimeout int32) int32 {
+	if d == nil {
+		return workflowTimeout
+	}
+	return capTimeout(workflowTimeout, d.MaxActivityScheduleToCloseTimeoutSeconds)
+}
+
+// WorkflowIDReusePolicy returns the workflow ID reuse policy to use for the requested one,
+// the domain default only replaces an unset policy
+func (d *DomainWorkflowDefaults) WorkflowIDReusePolicy(
+	requested *workflow.WorkflowIdReusePolicy,
+) *workflow.WorkflowIdReusePolicy {
+
+	if d == nil || d.DefaultWorkflowIDReusePolicy == nil || requested != nil {
+		return requested
+	}
+	return d.DefaultWorkflowIDReusePolicy.Ptr()
+}
+
+// WorkflowRetryPolicy returns the workflow retry policy to use for the requested one
+func (d *DomainWorkflowDefaults) WorkflowRetryPolicy(requested *workflow.RetryPolicy) *workflow.RetryPolicy {
+	if d == nil {
+		return requested
+	}
+	return defaultRetryPolicy(requested, d.DefaultWorkflowRetryPolicy)
+}
+
+// ActivityRetryPolicy returns the activity retry policy to use for the requested one
+func (d *DomainWorkflowDefaults) ActivityRetryPolicy(requested *workflow.RetryPolicy) *workflow.RetryPolicy {
+	if d == nil {
+		return requested
+	}
+	return defaultRetryPolicy(requested, d.DefaultActivityRetryPolicy)
+}
+
+// NewDomainWorkflowDefaultsFromThrift creates the domain workflow defaults from the thrift representation
+func NewDomainWorkflowDefaultsFromThrift(in *workflow.DomainWorkflowDefaults) *DomainWorkflowDefaults {
+	if in == nil {
+		return nil
+	}
+	return &DomainWorkflowDefaults{
+		DefaultExecutionStartToCloseTimeoutSeconds:   in.GetDefaultExecutionStartToCloseTimeoutSeconds(),
+		MaxExecutionStartToCloseTimeoutSeconds:       in.GetMaxExecutionStartToCloseTimeoutSeconds(),
+		DefaultTaskStartToCloseTimeoutSeconds:        in.GetDefaultTaskStartToCloseTimeoutSeconds(),
+		DefaultActivityScheduleToCloseTimeoutSeconds: in.GetDefaultActivityScheduleToCloseTimeoutSeconds(),
+		MaxActivityScheduleToCloseTimeoutSeconds:     in.GetMaxActivityScheduleToCloseTimeoutSeconds(),
+		DefaultWorkflowIDReusePolicy:                 in.DefaultWorkflowIdReusePolicy,
+		DefaultWorkflowRetryPolicy:                   in.DefaultWorkflowRetryPolicy,
+		DefaultActivityRetryPolicy:                   in.DefaultActivityRetryPolicy,
+	}
+}
+
+// ToThrift returns the thrift representation of the domain workflow defaults
+func (d *DomainWorkflowDefaults) ToThrift() *workflow.DomainWorkflowDefaults {
+	if d == nil {
+		return nil
+	}
+	return &workflow.DomainWorkflowDefaults{
+		DefaultExecutionStartToCloseTimeoutSeconds:   common.Int32Ptr(d.DefaultExecutionStartToCloseTimeoutSeconds),
+		MaxExecutionStartToCloseTimeoutSeconds:       common.Int32Ptr(d.MaxExecutionStartToCloseTimeoutSeconds),
+		DefaultTaskStartToCloseTimeoutSeconds:        common.Int32Ptr(d.DefaultTaskStartToCloseTimeoutSeconds),
+		DefaultActivityScheduleToCloseTimeoutSeconds: common.Int32Ptr(d.DefaultActivityScheduleToCloseTimeoutSeconds),
+		MaxActivityScheduleToCloseTimeoutSeconds:     common.Int32Ptr(d.MaxActivityScheduleToCloseTimeoutSeconds),
+		DefaultWorkflowIdReusePolicy:                 d.DefaultWorkflowIDReusePolicy,
+		DefaultWorkflowRetryPolicy:                   d.DefaultWorkflowRetryPolicy,
+		DefaultActivityRetryPolicy:                   d.DefaultActivityRetryPolicy,
+	}
+}
+
+func defaultTimeout(requested int32, defaultValue int32) int32 {
+	if requested <= 0 {
+		return defaultValue
+	}
+	return requested
+}
+
+func capTimeout(timeout int32, maxValue int32) int32 {
+	if maxValue > 0 && timeout > maxValue {
+		return maxValue
+	}
+	return timeout
+}
+
+func defaultRetryPolicy(requested *workflow.RetryPolicy, defaultValue *workflow.RetryPolicy) *workflow.RetryPolicy {
+	if requested != nil || defaultValue == nil {
+		return requested
+	}
+	// the retry policy ends up in history events, hand out a copy of the cached domain config
+	policy := *defaultValue
+	policy.NonRetriableErrorReasons = append([]string(nil), defaultValue.NonRetriableErrorReasons...)
+	return &policy
+}
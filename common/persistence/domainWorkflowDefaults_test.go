@@ -0,0 +1,134 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	workflow "github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/common"
+)
+
+type (
+	domainWorkflowDefaultsSuite struct {
+		suite.Suite
+	}
+)
+
+func TestDomainWorkflowDefaultsSuite(t *testing.T) {
+	s := new(domainWorkflowDefaultsSuite)
+	suite.Run(t, s)
+}
+
+func (s *domainWorkflowDefaultsSuite) TestNilDefaults() {
+	var defaults *DomainWorkflowDefaults
+	retryPolicy := &workflow.RetryPolicy{InitialIntervalInSeconds: common.Int32Ptr(1)}
+
+	s.Equal(int32(0), defaults.ExecutionStartToCloseTimeout(0))
+	s.Equal(int32(100), defaults.ExecutionStartToCloseTimeout(100))
+	s.Equal(int32(10), defaults.TaskStartToCloseTimeout(10))
+	s.Equal(int32(0), defaults.ActivityScheduleToCloseTimeout())
+	s.Equal(int32(100), defaults.MaxActivityTimeout(100))
+	s.Nil(defaults.WorkflowIDReusePolicy(nil))
+	s.Nil(defaults.WorkflowRetryPolicy(nil))
+	s.Equal(retryPolicy, defaults.ActivityRetryPolicy(retryPolicy))
+}
+
+func (s *domainWorkflowDefaultsSuite) TestTimeouts() {
+	defaults := &DomainWorkflowDefaults{
+		DefaultExecutionStartToCloseTimeoutSeconds:   3600,
+		MaxExecutionStartToCloseTimeoutSeconds:       86400,
+		DefaultTaskStartToCloseTimeoutSeconds:        10,
+		DefaultActivityScheduleToCloseTimeoutSeconds: 60,
+		MaxActivityScheduleToCloseTimeoutSeconds:     600,
+	}
+
+	s.Equal(int32(3600), defaults.ExecutionStartToCloseTimeout(0))
+	s.Equal(int32(100), defaults.ExecutionStartToCloseTimeout(100))
+	s.Equal(int32(86400), defaults.ExecutionStartToCloseTimeout(864000))
+	s.Equal(int32(10), defaults.TaskStartToCloseTimeout(0))
+	s.Equal(int32(5), defaults.TaskStartToCloseTimeout(5))
+	s.Equal(int32(60), defaults.ActivityScheduleToCloseTimeout())
+	s.Equal(int32(100), defaults.MaxActivityTimeout(100))
+	s.Equal(int32(600), defaults.MaxActivityTimeout(3600))
+
+	defaults = &DomainWorkflowDefaults{MaxExecutionStartToCloseTimeoutSeconds: 86400}
+	s.Equal(int32(0), defaults.ExecutionStartToCloseTimeout(0))
+	s.Equal(int32(3600), defaults.MaxActivityTimeout(3600))
+}
+
+func (s *domainWorkflowDefaultsSuite) TestWorkflowIDReusePolicy() {
+	defaults := &DomainWorkflowDefaults{}
+	s.Nil(defaults.WorkflowIDReusePolicy(nil))
+
+	defaults.DefaultWorkflowIDReusePolicy = workflow.WorkflowIdReusePolicyRejectDuplicate.Ptr()
+	s.Equal(workflow.WorkflowIdReusePolicyRejectDuplicate.Ptr(), defaults.WorkflowIDReusePolicy(nil))
+	s.Equal(
+		workflow.WorkflowIdReusePolicyAllowDuplicateFailedOnly.Ptr(),
+		defaults.WorkflowIDReusePolicy(workflow.WorkflowIdReusePolicyAllowDuplicateFailedOnly.Ptr()),
+	)
+	s.Equal(
+		workflow.WorkflowIdReusePolicyAllowDuplicate.Ptr(),
+		defaults.WorkflowIDReusePolicy(workflow.WorkflowIdReusePolicyAllowDuplicate.Ptr()),
+	)
+}
+
+func (s *domainWorkflowDefaultsSuite) TestRetryPolicies() {
+	workflowRetryPolicy := &workflow.RetryPolicy{
+		InitialIntervalInSeconds: common.Int32Ptr(1),
+		BackoffCoefficient:       common.Float64Ptr(2),
+		NonRetriableErrorReasons: []string{"bad-request"},
+	}
+	requested := &workflow.RetryPolicy{
+		InitialIntervalInSeconds: common.Int32Ptr(5),
+		BackoffCoefficient:       common.Float64Ptr(1),
+	}
+	defaults := &DomainWorkflowDefaults{DefaultWorkflowRetryPolicy: workflowRetryPolicy}
+
+	retryPolicy := defaults.WorkflowRetryPolicy(nil)
+	s.Equal(workflowRetryPolicy, retryPolicy)
+	retryPolicy.NonRetriableErrorReasons[0] = "timeout"
+	s.Equal("bad-request", workflowRetryPolicy.NonRetriableErrorReasons[0])
+
+	s.Equal(requested, defaults.WorkflowRetryPolicy(requested))
+	s.Nil(defaults.ActivityRetryPolicy(nil))
+}
+
+func (s *domainWorkflowDefaultsSuite) TestThriftConversion() {
+	s.Nil(NewDomainWorkflowDefaultsFromThrift(nil))
+	s.Nil((*DomainWorkflowDefaults)(nil).ToThrift())
+
+	defaults := &DomainWorkflowDefaults{
+		DefaultExecutionStartToCloseTimeoutSeconds:   3600,
+		MaxExecutionStartToCloseTimeoutSeconds:       86400,
+		DefaultTaskStartToCloseTimeoutSeconds:        10,
+		DefaultActivityScheduleToCloseTimeoutSeconds: 60,
+		MaxActivityScheduleToCloseTimeoutSeconds:     600,
+		DefaultWorkflowIDReusePolicy:                 workflow.WorkflowIdReusePolicyRejectDuplicate.Ptr(),
+		DefaultWorkflowRetryPolicy: &workflow.RetryPolicy{
+			InitialIntervalInSeconds: common.Int32Ptr(1),
+			BackoffCoefficient:       common.Float64Ptr(2),
+		},
+	}
+	s.Equal(defaults, NewDomainWorkflowDefaultsFromThrift(defaults.ToThrift()))
+}
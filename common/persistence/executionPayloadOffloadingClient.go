@@ -0,0 +1,678 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	workflow "github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/common/blobstore"
+	"github.com/temporalio/temporal/common/codec"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	// executionPayloadOffloadingClient keeps the large payloads of the events and signals recorded in mutable state,
+	// e.g. the scheduled events of pending activities, in the history payload store and resolves them when mutable
+	// state is read. Payloads are keyed by their content under the history tree of the execution, so rewriting
+	// an unchanged payload, as done on every update of a pending activity, never changes what a key refers to.
+	executionPayloadOffloadingClient struct {
+		persistence      ExecutionManager
+		payloadStore     blobstore.Client
+		offloadThreshold dynamicconfig.IntPropertyFn
+		thriftEncoder    codec.BinaryEncoder
+		logger           log.Logger
+
+		sync.Mutex
+		// storedKeys are the keys of the payloads stored recently, they are not stored again
+		storedKeys map[string]struct{}
+	}
+
+	// mutableStateOffloader offloads the payloads of the mutable state of one workflow execution
+	mutableStateOffloader struct {
+		ctx       context.Context
+		client    *executionPayloadOffloadingClient
+		keyPrefix string
+		threshold int
+	}
+)
+
+const (
+	maxStoredPayloadKeys = 10000
+)
+
+var _ ExecutionManager = (*executionPayloadOffloadingClient)(nil)
+
+// NewExecutionPayloadOffloadingClient creates an ExecutionManager client which offloads the payloads of
+// mutable state larger than the offload threshold to the payload store, a threshold of 0 disables offloading
+func NewExecutionPayloadOffloadingClient(
+	persistence ExecutionManager,
+	payloadStore blobstore.Client,
+	offloadThreshold dynamicconfig.IntPropertyFn,
+	logger log.Logger,
+) ExecutionManager {
+
+	return &executionPayloadOffloadingClient{
+		persistence:      persistence,
+		payloadStore:     payloadStore,
+		offloadThreshold: offloadThreshold,
+		thriftEncoder:    codec.NewThriftRWEncoder(),
+		logger:           logger,
+		storedKeys:       make(map[string]struct{}),
+	}
+}
+
+func (p *executionPayloadOffloadingClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *executionPayloadOffloadingClient) GetShardID() int {
+	return p.persistence.GetShardID()
+}
+
+func (p *executionPayloadOffloadingClient) CreateWorkflowExecution(
+	request *CreateWorkflowExecutionRequest,
+) (*CreateWorkflowExecutionResponse, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), payloadStoreTimeout)
+	defer cancel()
+
+	newSnapshot, err := p.offloadSnapshot(ctx, &request.NewWorkflowSnapshot)
+	if err != nil {
+		return nil, err
+	}
+	offloadedRequest := *request
+	offloadedRequest.NewWorkflowSnapshot = *newSnapshot
+	return p.persistence.CreateWorkflowExecution(&offloadedRequest)
+}
+
+func (p *executionPayloadOffloadingClient) GetWorkflowExecution(
+	request *GetWorkflowExecutionRequest,
+) (*GetWorkflowExecutionResponse, error) {
+
+	response, err := p.persistence.GetWorkflowExecution(request)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.resolveMutableState(response.State); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (p *executionPayloadOffloadingClient) UpdateWorkflowExecution(
+	request *UpdateWorkflowExecutionRequest,
+) (*UpdateWorkflowExecutionResponse, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), payloadStoreTimeout)
+	defer cancel()
+
+	offloadedRequest := *request
+	mutation, err := p.offloadMutation(ctx, &request.UpdateWorkflowMutation)
+	if err != nil {
+		return nil, err
+	}
+	offloadedRequest.UpdateWorkflowMutation = *mutation
+	if request.NewWorkflowSnapshot != nil {
+		if offloadedRequest.NewWorkflowSnapshot, err = p.offloadSnapshot(ctx, request.NewWorkflowSnapshot); err != nil {
+			return nil, err
+		}
+	}
+	return p.persistence.UpdateWorkflowExecution(&offloadedRequest)
+}
+
+func (p *executionPayloadOffloadingClient) ConflictResolveWorkflowExecution(
+	request *ConflictResolveWorkflowExecutionRequest,
+) error {
+
+	ctx, cancel := context.WithTimeout(context.Background(), payloadStoreTimeout)
+	defer cancel()
+
+	offloadedRequest := *request
+	resetSnapshot, err := p.offloadSnapshot(ctx, &request.ResetWorkflowSnapshot)
+	if err != nil {
+		return err
+	}
+	offloadedRequest.ResetWorkflowSnapshot = *resetSnapshot
+	if request.NewWorkflowSnapshot != nil {
+		if offloadedRequest.NewWorkflowSnapshot, err = p.offloadSnapshot(ctx, request.NewWorkflowSnapshot); err != nil {
+			return err
+		}
+	}
+	if request.CurrentWorkflowMutation != nil {
+		if offloadedRequest.CurrentWorkflowMutation, err = p.offloadMutation(ctx, request.CurrentWorkflowMutation); err != nil {
+			return err
+		}
+	}
+	return p.persistence.ConflictResolveWorkflowExecution(&offloadedRequest)
+}
+
+func (p *executionPayloadOffloadingClient) ResetWorkflowExecution(
+	request *ResetWorkflowExecutionRequest,
+) error {
+
+	ctx, cancel := context.WithTimeout(context.Background(), payloadStoreTimeout)
+	defer cancel()
+
+	offloadedRequest := *request
+	newSnapshot, err := p.offloadSnapshot(ctx, &request.NewWorkflowSnapshot)
+	if err != nil {
+		return err
+	}
+	offloadedRequest.NewWorkflowSnapshot = *newSnapshot
+	if request.CurrentWorkflowMutation != nil {
+		if offloadedRequest.CurrentWorkflowMutation, err = p.offloadMutation(ctx, request.CurrentWorkflowMutation); err != nil {
+			return err
+		}
+	}
+	return p.persistence.ResetWorkflowExecution(&offloadedRequest)
+}
+
+func (p *executionPayloadOffloadingClient) DeleteWorkflowExecution(
+	request *DeleteWorkflowExecutionRequest,
+) error {
+	return p.persistence.DeleteWorkflowExecution(request)
+}
+
+func (p *executionPayloadOffloadingClient) DeleteCurrentWorkflowExecution(
+	request *DeleteCurrentWorkflowExecutionRequest,
+) error {
+	return p.persistence.DeleteCurrentWorkflowExecution(request)
+}
+
+func (p *executionPayloadOffloadingClient) GetCurrentExecution(
+	request *GetCurrentExecutionRequest,
+) (*GetCurrentExecutionResponse, error) {
+	return p.persistence.GetCurrentExecution(request)
+}
+
+// ListConcreteExecutions does not resolve offloaded payloads, the listed executions are only
+// used to look up their history and state
+func (p *executionPayloadOffloadingClient) ListConcreteExecutions(
+	request *ListConcreteExecutionsRequest,
+) (*ListConcreteExecutionsResponse, error) {
+	return p.persistence.ListConcreteExecutions(request)
+}
+
+func (p *executionPayloadOffloadingClient) GetTransferTasks(
+	request *GetTransferTasksRequest,
+) (*GetTransferTasksResponse, error) {
+	return p.persistence.GetTransferTasks(request)
+}
+
+func (p *executionPayloadOffloadingClient) CompleteTransferTask(
+	request *CompleteTransferTaskRequest,
+) error {
+	return p.persistence.CompleteTransferTask(request)
+}
+
+func (p *executionPayloadOffloadingClient) RangeCompleteTransferTask(
+	request *RangeCompleteTransferTaskRequest,
+) error {
+	return p.persistence.RangeCompleteTransferTask(request)
+}
+
+func (p *executionPayloadOffloadingClient) PutTransferTaskToDLQ(
+	request *PutTransferTaskToDLQRequest,
+) error {
+	return p.persistence.PutTransferTaskToDLQ(request)
+}
+
+func (p *executionPayloadOffloadingClient) GetTransferTasksFromDLQ(
+	request *GetTransferTasksFromDLQRequest,
+) (*GetTransferTasksFromDLQResponse, error) {
+	return p.persistence.GetTransferTasksFromDLQ(request)
+}
+
+func (p *executionPayloadOffloadingClient) DeleteTransferTaskFromDLQ(
+	request *DeleteTransferTaskFromDLQRequest,
+) error {
+	return p.persistence.DeleteTransferTaskFromDLQ(request)
+}
+
+func (p *executionPayloadOffloadingClient) GetReplicationTasks(
+	request *GetReplicationTasksRequest,
+) (*GetReplicationTasksResponse, error) {
+	return p.persistence.GetReplicationTasks(request)
+}
+
+func (p *executionPayloadOffloadingClient) CompleteReplicationTask(
+	request *CompleteReplicationTaskRequest,
+) error {
+	return p.persistence.CompleteReplicationTask(request)
+}
+
+func (p *executionPayloadOffloadingClient) RangeCompleteReplicationTask(
+	request *RangeCompleteReplicationTaskRequest,
+) error {
+	return p.persistence.RangeCompleteReplicationTask(request)
+}
+
+func (p *executionPayloadOffloadingClient) PutReplicationTaskToDLQ(
+	request *PutReplicationTaskToDLQRequest,
+) error {
+	return p.persistence.PutReplicationTaskToDLQ(request)
+}
+
+func (p *executionPayloadOffloadingClient) GetReplicationTasksFromDLQ(
+	request *GetReplicationTasksFromDLQRequest,
+) (*GetReplicationTasksFromDLQResponse, error) {
+	return p.persistence.GetReplicationTasksFromDLQ(request)
+}
+
+func (p *executionPayloadOffloadingClient) GetTimerIndexTasks(
+	request *GetTimerIndexTasksRequest,
+) (*GetTimerIndexTasksResponse, error) {
+	return p.persistence.GetTimerIndexTasks(request)
+}
+
+func (p *executionPayloadOffloadingClient) CompleteTimerTask(
+	request *CompleteTimerTaskRequest,
+) error {
+	return p.persistence.CompleteTimerTask(request)
+}
+
+func (p *executionPayloadOffloadingClient) RangeCompleteTimerTask(
+	request *RangeCompleteTimerTaskRequest,
+) error {
+	return p.persistence.RangeCompleteTimerTask(request)
+}
+
+func (p *executionPayloadOffloadingClient) PutTimerTaskToDLQ(
+	request *PutTimerTaskToDLQRequest,
+) error {
+	return p.persistence.PutTimerTaskToDLQ(request)
+}
+
+func (p *executionPayloadOffloadingClient) GetTimerTasksFromDLQ(
+	request *GetTimerTasksFromDLQRequest,
+) (*GetTimerTasksFromDLQResponse, error) {
+	return p.persistence.GetTimerTasksFromDLQ(request)
+}
+
+func (p *executionPayloadOffloadingClient) DeleteTimerTaskFromDLQ(
+	request *DeleteTimerTaskFromDLQRequest,
+) error {
+	return p.persistence.DeleteTimerTaskFromDLQ(request)
+}
+
+func (p *executionPayloadOffloadingClient) DeleteTask(
+	request *DeleteTaskRequest,
+) error {
+	return p.persistence.DeleteTask(request)
+}
+
+func (p *executionPayloadOffloadingClient) Close() {
+	p.persistence.Close()
+}
+
+// offloadSnapshot returns the snapshot with its large payloads offloaded, the infos of the snapshot
+// are copied on write as they are shared with the mutable state of the caller
+func (p *executionPayloadOffloadingClient) offloadSnapshot(
+	ctx context.Context,
+	snapshot *WorkflowSnapshot,
+) (*WorkflowSnapshot, error) {
+
+	offloader, err := p.newOffloader(ctx, snapshot.ExecutionInfo, snapshot.VersionHistories)
+	if err != nil {
+		return nil, err
+	}
+
+	offloadedSnapshot := *snapshot
+	if offloadedSnapshot.ExecutionInfo, err = offloader.offloadExecutionInfo(snapshot.ExecutionInfo); err != nil {
+		return nil, err
+	}
+	if offloadedSnapshot.ActivityInfos, err = offloader.offloadActivityInfos(snapshot.ActivityInfos); err != nil {
+		return nil, err
+	}
+	if offloadedSnapshot.ChildExecutionInfos, err = offloader.offloadChildExecutionInfos(snapshot.ChildExecutionInfos); err != nil {
+		return nil, err
+	}
+	if offloadedSnapshot.SignalInfos, err = offloader.offloadSignalInfos(snapshot.SignalInfos); err != nil {
+		return nil, err
+	}
+	return &offloadedSnapshot, nil
+}
+
+// offloadMutation returns the mutation with its large payloads offloaded, the infos and events of the
+// mutation are copied on write as they are shared with the mutable state of the caller
+func (p *executionPayloadOffloadingClient) offloadMutation(
+	ctx context.Context,
+	mutation *WorkflowMutation,
+) (*WorkflowMutation, error) {
+
+	offloader, err := p.newOffloader(ctx, mutation.ExecutionInfo, mutation.VersionHistories)
+	if err != nil {
+		return nil, err
+	}
+
+	offloadedMutation := *mutation
+	if offloadedMutation.ExecutionInfo, err = offloader.offloadExecutionInfo(mutation.ExecutionInfo); err != nil {
+		return nil, err
+	}
+	if offloadedMutation.UpsertActivityInfos, err = offloader.offloadActivityInfos(mutation.UpsertActivityInfos); err != nil {
+		return nil, err
+	}
+	if offloadedMutation.UpsertChildExecutionInfos, err = offloader.offloadChildExecutionInfos(mutation.UpsertChildExecutionInfos); err != nil {
+		return nil, err
+	}
+	if offloadedMutation.UpsertSignalInfos, err = offloader.offloadSignalInfos(mutation.UpsertSignalInfos); err != nil {
+		return nil, err
+	}
+	if offloadedMutation.NewBufferedEvents, err = offloader.offloadEvents(mutation.NewBufferedEvents); err != nil {
+		return nil, err
+	}
+	return &offloadedMutation, nil
+}
+
+func (p *executionPayloadOffloadingClient) newOffloader(
+	ctx context.Context,
+	executionInfo *WorkflowExecutionInfo,
+	versionHistories *VersionHistories,
+) (*mutableStateOffloader, error) {
+
+	treeID, err := p.getTreeID(executionInfo, versionHistories)
+	if err != nil {
+		return nil, err
+	}
+	return &mutableStateOffloader{
+		ctx:       ctx,
+		client:    p,
+		keyPrefix: fmt.Sprintf("%v/%v/mutable-state/", treeID, executionInfo.RunID),
+		threshold: p.offloadThreshold(),
+	}, nil
+}
+
+// resolveMutableState replaces the offloaded payload references of the mutable state with the payloads
+func (p *executionPayloadOffloadingClient) resolveMutableState(
+	state *WorkflowMutableState,
+) error {
+
+	treeID, err := p.getTreeID(state.ExecutionInfo, state.VersionHistories)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), payloadStoreTimeout)
+	defer cancel()
+
+	resolveEvent := func(event *workflow.HistoryEvent) (*workflow.HistoryEvent, error) {
+		if event == nil {
+			return nil, nil
+		}
+		return mapEventPayloads(event, func(_ string, payload []byte) ([]byte, bool, error) {
+			return resolvePayload(ctx, p.payloadStore, treeID, payload)
+		})
+	}
+	resolveBlob := func(blob []byte) ([]byte, error) {
+		resolved, replace, err := resolvePayload(ctx, p.payloadStore, treeID, blob)
+		if err != nil || !replace {
+			return blob, err
+		}
+		return resolved, nil
+	}
+
+	if state.ExecutionInfo.CompletionEvent, err = resolveEvent(state.ExecutionInfo.CompletionEvent); err != nil {
+		return err
+	}
+	for _, ai := range state.ActivityInfos {
+		if ai.ScheduledEvent, err = resolveEvent(ai.ScheduledEvent); err != nil {
+			return err
+		}
+		if ai.StartedEvent, err = resolveEvent(ai.StartedEvent); err != nil {
+			return err
+		}
+	}
+	for _, ci := range state.ChildExecutionInfos {
+		if ci.InitiatedEvent, err = resolveEvent(ci.InitiatedEvent); err != nil {
+			return err
+		}
+		if ci.StartedEvent, err = resolveEvent(ci.StartedEvent); err != nil {
+			return err
+		}
+	}
+	for _, si := range state.SignalInfos {
+		if si.Input, err = resolveBlob(si.Input); err != nil {
+			return err
+		}
+		if si.Control, err = resolveBlob(si.Control); err != nil {
+			return err
+		}
+	}
+	for i, event := range state.BufferedEvents {
+		if state.BufferedEvents[i], err = resolveEvent(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getTreeID returns the history tree of the execution, the branches of a workflow execution
+// always belong to the same tree
+func (p *executionPayloadOffloadingClient) getTreeID(
+	executionInfo *WorkflowExecutionInfo,
+	versionHistories *VersionHistories,
+) (string, error) {
+
+	branchToken := executionInfo.BranchToken
+	if versionHistories != nil {
+		currentVersionHistory, err := versionHistories.GetCurrentVersionHistory()
+		if err != nil {
+			return "", err
+		}
+		branchToken = currentVersionHistory.GetBranchToken()
+	}
+	var branch workflow.HistoryBranch
+	if err := p.thriftEncoder.Decode(branchToken, &branch); err != nil {
+		return "", err
+	}
+	return branch.GetTreeID(), nil
+}
+
+// putPayload stores the payload unless it was stored recently
+func (p *executionPayloadOffloadingClient) putPayload(
+	ctx context.Context,
+	key string,
+	payload []byte,
+) error {
+
+	p.Lock()
+	_, stored := p.storedKeys[key]
+	p.Unlock()
+	if stored {
+		return nil
+	}
+
+	if err := p.payloadStore.Put(ctx, key, payload); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("failed to offload mutable state payload %v. Error: %v", key, err),
+		}
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	if len(p.storedKeys) >= maxStoredPayloadKeys {
+		p.storedKeys = make(map[string]struct{})
+	}
+	p.storedKeys[key] = struct{}{}
+	return nil
+}
+
+func (o *mutableStateOffloader) offloadPayload(
+	payload []byte,
+) ([]byte, bool, error) {
+
+	if bytes.HasPrefix(payload, []byte(offloadedPayloadPrefix)) {
+		return nil, false, errOffloadedPayloadPrefix
+	}
+	if o.threshold <= 0 || len(payload) <= o.threshold {
+		return nil, false, nil
+	}
+	hash := sha256.Sum256(payload)
+	key := o.keyPrefix + hex.EncodeToString(hash[:])
+	if err := o.client.putPayload(o.ctx, key, payload); err != nil {
+		return nil, false, err
+	}
+	return []byte(offloadedPayloadPrefix + key), true, nil
+}
+
+func (o *mutableStateOffloader) offloadBlob(
+	blob []byte,
+) ([]byte, bool, error) {
+
+	if len(blob) == 0 {
+		return blob, false, nil
+	}
+	replacement, replace, err := o.offloadPayload(blob)
+	if err != nil || !replace {
+		return blob, false, err
+	}
+	return replacement, true, nil
+}
+
+func (o *mutableStateOffloader) offloadEvent(
+	event *workflow.HistoryEvent,
+) (*workflow.HistoryEvent, error) {
+
+	if event == nil {
+		return nil, nil
+	}
+	return mapEventPayloads(event, func(_ string, payload []byte) ([]byte, bool, error) {
+		return o.offloadPayload(payload)
+	})
+}
+
+func (o *mutableStateOffloader) offloadEvents(
+	events []*workflow.HistoryEvent,
+) ([]*workflow.HistoryEvent, error) {
+
+	var offloadedEvents []*workflow.HistoryEvent
+	for i, event := range events {
+		offloadedEvent, err := o.offloadEvent(event)
+		if err != nil {
+			return nil, err
+		}
+		if offloadedEvent != event && offloadedEvents == nil {
+			offloadedEvents = make([]*workflow.HistoryEvent, len(events))
+			copy(offloadedEvents, events)
+		}
+		if offloadedEvents != nil {
+			offloadedEvents[i] = offloadedEvent
+		}
+	}
+	if offloadedEvents == nil {
+		return events, nil
+	}
+	return offloadedEvents, nil
+}
+
+func (o *mutableStateOffloader) offloadExecutionInfo(
+	executionInfo *WorkflowExecutionInfo,
+) (*WorkflowExecutionInfo, error) {
+
+	completionEvent, err := o.offloadEvent(executionInfo.CompletionEvent)
+	if err != nil || completionEvent == executionInfo.CompletionEvent {
+		return executionInfo, err
+	}
+	offloadedInfo := *executionInfo
+	offloadedInfo.CompletionEvent = completionEvent
+	return &offloadedInfo, nil
+}
+
+func (o *mutableStateOffloader) offloadActivityInfos(
+	activityInfos []*ActivityInfo,
+) ([]*ActivityInfo, error) {
+
+	offloadedInfos := make([]*ActivityInfo, len(activityInfos))
+	for i, ai := range activityInfos {
+		scheduledEvent, err := o.offloadEvent(ai.ScheduledEvent)
+		if err != nil {
+			return nil, err
+		}
+		startedEvent, err := o.offloadEvent(ai.StartedEvent)
+		if err != nil {
+			return nil, err
+		}
+		offloadedInfos[i] = ai
+		if scheduledEvent != ai.ScheduledEvent || startedEvent != ai.StartedEvent {
+			offloadedInfo := *ai
+			offloadedInfo.ScheduledEvent = scheduledEvent
+			offloadedInfo.StartedEvent = startedEvent
+			offloadedInfos[i] = &offloadedInfo
+		}
+	}
+	return offloadedInfos, nil
+}
+
+func (o *mutableStateOffloader) offloadChildExecutionInfos(
+	childExecutionInfos []*ChildExecutionInfo,
+) ([]*ChildExecutionInfo, error) {
+
+	offloadedInfos := make([]*ChildExecutionInfo, len(childExecutionInfos))
+	for i, ci := range childExecutionInfos {
+		initiatedEvent, err := o.offloadEvent(ci.InitiatedEvent)
+		if err != nil {
+			return nil, err
+		}
+		startedEvent, err := o.offloadEvent(ci.StartedEvent)
+		if err != nil {
+			return nil, err
+		}
+		offloadedInfos[i] = ci
+		if initiatedEvent != ci.InitiatedEvent || startedEvent != ci.StartedEvent {
+			offloadedInfo := *ci
+			offloadedInfo.InitiatedEvent = initiatedEvent
+			offloadedInfo.StartedEvent = startedEvent
+			offloadedInfos[i] = &offloadedInfo
+		}
+	}
+	return offloadedInfos, nil
+}
+
+func (o *mutableStateOffloader) offloadSignalInfos(
+	signalInfos []*SignalInfo,
+) ([]*SignalInfo, error) {
+
+	offloadedInfos := make([]*SignalInfo, len(signalInfos))
+	for i, si := range signalInfos {
+		input, replaceInput, err := o.offloadBlob(si.Input)
+		if err != nil {
+			return nil, err
+		}
+		control, replaceControl, err := o.offloadBlob(si.Control)
+		if err != nil {
+			return nil, err
+		}
+		offloadedInfos[i] = si
+		if replaceInput || replaceControl {
+			offloadedInfo := *si
+			offloadedInfo.Input = input
+			offloadedInfo.Control = control
+			offloadedInfos[i] = &offloadedInfo
+		}
+	}
+	return offloadedInfos, nil
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	workflow "github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/codec"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	executionPayloadOffloadingSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		execution    *offloadingExecutionManagerStub
+		payloadStore *blobStoreStub
+		threshold    int
+		client       ExecutionManager
+		branchToken  []byte
+	}
+
+	offloadingExecutionManagerStub struct {
+		ExecutionManager
+		createRequest *CreateWorkflowExecutionRequest
+		updateRequest *UpdateWorkflowExecutionRequest
+		state         *WorkflowMutableState
+	}
+)
+
+func TestExecutionPayloadOffloadingSuite(t *testing.T) {
+	s := new(executionPayloadOffloadingSuite)
+	suite.Run(t, s)
+}
+
+func (s *executionPayloadOffloadingSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	branch := &workflow.HistoryBranch{TreeID: common.StringPtr("tree"), BranchID: common.StringPtr("branch")}
+	branchToken, err := codec.NewThriftRWEncoder().Encode(branch)
+	s.NoError(err)
+	s.branchToken = branchToken
+
+	s.execution = &offloadingExecutionManagerStub{}
+	s.payloadStore = &blobStoreStub{blobs: make(map[string][]byte)}
+	s.threshold = 4
+	s.client = NewExecutionPayloadOffloadingClient(
+		s.execution,
+		s.payloadStore,
+		func(opts ...dynamicconfig.FilterOption) int { return s.threshold },
+		loggerimpl.NewNopLogger(),
+	)
+}
+
+func (s *executionPayloadOffloadingSuite) TestCreateWorkflowExecution_OffloadsLargePayloads() {
+	snapshot := s.newSnapshot()
+	_, err := s.client.CreateWorkflowExecution(&CreateWorkflowExecutionRequest{NewWorkflowSnapshot: *snapshot})
+	s.NoError(err)
+
+	// infos of the caller are untouched
+	s.Equal([]byte("large input"), snapshot.ActivityInfos[0].ScheduledEvent.ActivityTaskScheduledEventAttributes.Input)
+	s.Equal([]byte("large signal"), snapshot.SignalInfos[0].Input)
+
+	stored := s.execution.createRequest.NewWorkflowSnapshot
+	s.Equal(offloadedPayloadPrefix+s.payloadKey("large input"),
+		string(stored.ActivityInfos[0].ScheduledEvent.ActivityTaskScheduledEventAttributes.Input))
+	s.Equal(offloadedPayloadPrefix+s.payloadKey("large child input"),
+		string(stored.ChildExecutionInfos[0].InitiatedEvent.StartChildWorkflowExecutionInitiatedEventAttributes.Input))
+	s.Equal(offloadedPayloadPrefix+s.payloadKey("large signal"), string(stored.SignalInfos[0].Input))
+	s.Equal([]byte("ctl"), stored.SignalInfos[0].Control)
+	s.True(snapshot.ExecutionInfo == stored.ExecutionInfo, "infos without large payloads are not copied")
+	s.Equal(map[string][]byte{
+		s.payloadKey("large input"):       []byte("large input"),
+		s.payloadKey("large child input"): []byte("large child input"),
+		s.payloadKey("large signal"):      []byte("large signal"),
+	}, s.payloadStore.blobs)
+}
+
+func (s *executionPayloadOffloadingSuite) TestCreateWorkflowExecution_Disabled() {
+	s.threshold = 0
+	snapshot := s.newSnapshot()
+	_, err := s.client.CreateWorkflowExecution(&CreateWorkflowExecutionRequest{NewWorkflowSnapshot: *snapshot})
+	s.NoError(err)
+	s.Equal(*snapshot, s.execution.createRequest.NewWorkflowSnapshot)
+	s.Empty(s.payloadStore.blobs)
+}
+
+func (s *executionPayloadOffloadingSuite) TestUpdateWorkflowExecution_OffloadsBufferedEvents() {
+	snapshot := s.newSnapshot()
+	_, err := s.client.UpdateWorkflowExecution(&UpdateWorkflowExecutionRequest{
+		UpdateWorkflowMutation: WorkflowMutation{
+			ExecutionInfo:       snapshot.ExecutionInfo,
+			VersionHistories:    snapshot.VersionHistories,
+			UpsertActivityInfos: snapshot.ActivityInfos,
+			NewBufferedEvents: []*workflow.HistoryEvent{{
+				EventType: common.EventTypePtr(workflow.EventTypeWorkflowExecutionSignaled),
+				WorkflowExecutionSignaledEventAttributes: &workflow.WorkflowExecutionSignaledEventAttributes{
+					Input: []byte("large buffered signal"),
+				},
+			}},
+		},
+	})
+	s.NoError(err)
+
+	stored := s.execution.updateRequest.UpdateWorkflowMutation
+	s.Equal(offloadedPayloadPrefix+s.payloadKey("large input"),
+		string(stored.UpsertActivityInfos[0].ScheduledEvent.ActivityTaskScheduledEventAttributes.Input))
+	s.Equal(offloadedPayloadPrefix+s.payloadKey("large buffered signal"),
+		string(stored.NewBufferedEvents[0].WorkflowExecutionSignaledEventAttributes.Input))
+}
+
+func (s *executionPayloadOffloadingSuite) TestGetWorkflowExecution_ResolvesPayloads() {
+	snapshot := s.newSnapshot()
+	_, err := s.client.CreateWorkflowExecution(&CreateWorkflowExecutionRequest{NewWorkflowSnapshot: *snapshot})
+	s.NoError(err)
+
+	response, err := s.client.GetWorkflowExecution(&GetWorkflowExecutionRequest{})
+	s.NoError(err)
+	s.Equal(snapshot.ActivityInfos[0], response.State.ActivityInfos[5])
+	s.Equal(snapshot.ChildExecutionInfos[0], response.State.ChildExecutionInfos[6])
+	s.Equal(snapshot.SignalInfos[0], response.State.SignalInfos[7])
+}
+
+func (s *executionPayloadOffloadingSuite) TestGetWorkflowExecution_RejectsPayloadOfOtherTree() {
+	snapshot := s.newSnapshot()
+	snapshot.SignalInfos[0].Input = []byte(offloadedPayloadPrefix + "other-tree/run/mutable-state/key")
+	s.execution.state = &WorkflowMutableState{
+		ExecutionInfo:    snapshot.ExecutionInfo,
+		VersionHistories: snapshot.VersionHistories,
+		SignalInfos:      map[int64]*SignalInfo{7: snapshot.SignalInfos[0]},
+	}
+
+	_, err := s.client.GetWorkflowExecution(&GetWorkflowExecutionRequest{})
+	s.IsType(&workflow.InternalServiceError{}, err)
+}
+
+func (s *executionPayloadOffloadingSuite) TestCreateWorkflowExecution_RejectsOffloadedPayloadPrefix() {
+	snapshot := s.newSnapshot()
+	snapshot.SignalInfos[0].Input = []byte(offloadedPayloadPrefix + "tree/run/mutable-state/key")
+
+	_, err := s.client.CreateWorkflowExecution(&CreateWorkflowExecutionRequest{NewWorkflowSnapshot: *snapshot})
+	s.Equal(errOffloadedPayloadPrefix, err)
+	s.Nil(s.execution.createRequest)
+}
+
+func (s *executionPayloadOffloadingSuite) newSnapshot() *WorkflowSnapshot {
+	return &WorkflowSnapshot{
+		ExecutionInfo: &WorkflowExecutionInfo{RunID: "run"},
+		VersionHistories: NewVersionHistories(NewVersionHistory(s.branchToken, []*VersionHistoryItem{
+			NewVersionHistoryItem(10, 1),
+		})),
+		ActivityInfos: []*ActivityInfo{{
+			ScheduleID: 5,
+			ScheduledEvent: &workflow.HistoryEvent{
+				EventType: common.EventTypePtr(workflow.EventTypeActivityTaskScheduled),
+				ActivityTaskScheduledEventAttributes: &workflow.ActivityTaskScheduledEventAttributes{
+					Input: []byte("large input"),
+				},
+			},
+		}},
+		ChildExecutionInfos: []*ChildExecutionInfo{{
+			InitiatedID: 6,
+			InitiatedEvent: &workflow.HistoryEvent{
+				EventType: common.EventTypePtr(workflow.EventTypeStartChildWorkflowExecutionInitiated),
+				StartChildWorkflowExecutionInitiatedEventAttributes: &workflow.StartChildWorkflowExecutionInitiatedEventAttributes{
+					Input: []byte("large child input"),
+				},
+			},
+		}},
+		SignalInfos: []*SignalInfo{{
+			InitiatedID: 7,
+			Input:       []byte("large signal"),
+			Control:     []byte("ctl"),
+		}},
+	}
+}
+
+func (s *executionPayloadOffloadingSuite) payloadKey(payload string) string {
+	hash := sha256.Sum256([]byte(payload))
+	return "tree/run/mutable-state/" + hex.EncodeToString(hash[:])
+}
+
+func (e *offloadingExecutionManagerStub) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
+	e.createRequest = request
+	snapshot := request.NewWorkflowSnapshot
+	e.state = &WorkflowMutableState{
+		ExecutionInfo:       snapshot.ExecutionInfo,
+		VersionHistories:    snapshot.VersionHistories,
+		ActivityInfos:       make(map[int64]*ActivityInfo),
+		ChildExecutionInfos: make(map[int64]*ChildExecutionInfo),
+		SignalInfos:         make(map[int64]*SignalInfo),
+	}
+	for _, ai := range snapshot.ActivityInfos {
+		copied := *ai
+		e.state.ActivityInfos[ai.ScheduleID] = &copied
+	}
+	for _, ci := range snapshot.ChildExecutionInfos {
+		copied := *ci
+		e.state.ChildExecutionInfos[ci.InitiatedID] = &copied
+	}
+	for _, si := range snapshot.SignalInfos {
+		copied := *si
+		e.state.SignalInfos[si.InitiatedID] = &copied
+	}
+	return &CreateWorkflowExecutionResponse{}, nil
+}
+
+func (e *offloadingExecutionManagerStub) UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error) {
+	e.updateRequest = request
+	return &UpdateWorkflowExecutionResponse{}, nil
+}
+
+func (e *offloadingExecutionManagerStub) GetWorkflowExecution(_ *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
+	return &GetWorkflowExecutionResponse{State: e.state}, nil
+}
@@ -176,6 +176,7 @@ func (m *executionManagerImpl) DeserializeExecutionInfo(
 		AutoResetPoints:                    autoResetPoints,
 		SearchAttributes:                   info.SearchAttributes,
 		Memo:                               info.Memo,
+		StartingBuildID:                    info.StartingBuildID,
 	}
 	newStats := &ExecutionStats{
 		HistorySize: info.HistorySize,
@@ -494,6 +495,7 @@ func (m *executionManagerImpl) SerializeExecutionInfo(
 		ExpirationSeconds:                  info.ExpirationSeconds,
 		Memo:                               info.Memo,
 		SearchAttributes:                   info.SearchAttributes,
+		StartingBuildID:                    info.StartingBuildID,
 
 		// attributes which are not related to mutable state
 		HistorySize: stats.HistorySize,
@@ -784,6 +786,12 @@ func (m *executionManagerImpl) GetCurrentExecution(
 	return m.persistence.GetCurrentExecution(request)
 }
 
+func (m *executionManagerImpl) ListConcreteExecutions(
+	request *ListConcreteExecutionsRequest,
+) (*ListConcreteExecutionsResponse, error) {
+	return m.persistence.ListConcreteExecutions(request)
+}
+
 // Transfer task related methods
 func (m *executionManagerImpl) GetTransferTasks(
 	request *GetTransferTasksRequest,
@@ -803,6 +811,24 @@ func (m *executionManagerImpl) RangeCompleteTransferTask(
 	return m.persistence.RangeCompleteTransferTask(request)
 }
 
+func (m *executionManagerImpl) PutTransferTaskToDLQ(
+	request *PutTransferTaskToDLQRequest,
+) error {
+	return m.persistence.PutTransferTaskToDLQ(request)
+}
+
+func (m *executionManagerImpl) GetTransferTasksFromDLQ(
+	request *GetTransferTasksFromDLQRequest,
+) (*GetTransferTasksFromDLQResponse, error) {
+	return m.persistence.GetTransferTasksFromDLQ(request)
+}
+
+func (m *executionManagerImpl) DeleteTransferTaskFromDLQ(
+	request *DeleteTransferTaskFromDLQRequest,
+) error {
+	return m.persistence.DeleteTransferTaskFromDLQ(request)
+}
+
 // Replication task related methods
 func (m *executionManagerImpl) GetReplicationTasks(
 	request *GetReplicationTasksRequest,
@@ -853,6 +879,24 @@ func (m *executionManagerImpl) RangeCompleteTimerTask(
 	return m.persistence.RangeCompleteTimerTask(request)
 }
 
+func (m *executionManagerImpl) PutTimerTaskToDLQ(
+	request *PutTimerTaskToDLQRequest,
+) error {
+	return m.persistence.PutTimerTaskToDLQ(request)
+}
+
+func (m *executionManagerImpl) GetTimerTasksFromDLQ(
+	request *GetTimerTasksFromDLQRequest,
+) (*GetTimerTasksFromDLQResponse, error) {
+	return m.persistence.GetTimerTasksFromDLQ(request)
+}
+
+func (m *executionManagerImpl) DeleteTimerTaskFromDLQ(
+	request *DeleteTimerTaskFromDLQRequest,
+) error {
+	return m.persistence.DeleteTimerTaskFromDLQ(request)
+}
+
 func (m *executionManagerImpl) Close() {
 	m.persistence.Close()
 }
@@ -0,0 +1,409 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	workflow "github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/blobstore"
+	"github.com/temporalio/temporal/common/codec"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	// historyV2PayloadOffloadingClient keeps large event payloads in a blob store, with only a reference to them
+	// stored in the history events, and resolves the references when history is read
+	historyV2PayloadOffloadingClient struct {
+		persistence      HistoryManager
+		payloadStore     blobstore.Client
+		offloadThreshold dynamicconfig.IntPropertyFn
+		serializer       PayloadSerializer
+		thriftEncoder    codec.BinaryEncoder
+		logger           log.Logger
+	}
+
+	// payloadMapper returns the replacement of a binary payload of event attributes, if it should be replaced
+	payloadMapper func(fieldName string, payload []byte) (replacement []byte, replace bool, err error)
+)
+
+const (
+	// offloadedPayloadPrefix marks a payload that has been offloaded to the payload store,
+	// the rest of the payload is the key of the offloaded payload
+	offloadedPayloadPrefix = "\x00temporal-offloaded-payload:"
+
+	payloadStoreTimeout = 30 * time.Second
+)
+
+var (
+	errOffloadedPayloadPrefix = &workflow.BadRequestError{Message: "Payload cannot start with the offloaded payload prefix."}
+)
+
+var _ HistoryManager = (*historyV2PayloadOffloadingClient)(nil)
+
+var bytesType = reflect.TypeOf([]byte(nil))
+
+// NewHistoryV2PayloadOffloadingClient creates a HistoryManager client which offloads the event payloads
+// larger than the offload threshold to the payload store, a threshold of 0 disables offloading
+func NewHistoryV2PayloadOffloadingClient(
+	persistence HistoryManager,
+	payloadStore blobstore.Client,
+	offloadThreshold dynamicconfig.IntPropertyFn,
+	logger log.Logger,
+) HistoryManager {
+
+	return &historyV2PayloadOffloadingClient{
+		persistence:      persistence,
+		payloadStore:     payloadStore,
+		offloadThreshold: offloadThreshold,
+		serializer:       NewPayloadSerializer(),
+		thriftEncoder:    codec.NewThriftRWEncoder(),
+		logger:           logger,
+	}
+}
+
+// NewBlobSizeLimitWithOffloading returns the per event blob size error limit given the payload offload threshold.
+// Payloads larger than the threshold are kept in the payload store instead of history, so if every payload above
+// the limit is offloaded, i.e. the threshold is enabled and not larger than the limit, the limit is raised to the
+// offloaded limit. Payloads which are never offloaded, such as memo and headers, are checked against the error limit.
+func NewBlobSizeLimitWithOffloading(
+	errorLimit dynamicconfig.IntPropertyFnWithDomainFilter,
+	offloadedErrorLimit dynamicconfig.IntPropertyFnWithDomainFilter,
+	offloadThreshold dynamicconfig.IntPropertyFn,
+) dynamicconfig.IntPropertyFnWithDomainFilter {
+
+	return func(domain string) int {
+		limit := errorLimit(domain)
+		if threshold := offloadThreshold(); threshold > 0 && threshold <= limit {
+			if offloadedLimit := offloadedErrorLimit(domain); offloadedLimit > limit {
+				return offloadedLimit
+			}
+		}
+		return limit
+	}
+}
+
+func (p *historyV2PayloadOffloadingClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *historyV2PayloadOffloadingClient) Close() {
+	p.persistence.Close()
+}
+
+// AppendHistoryNodes offloads the large payloads of the events before appending them,
+// the events of the request are not modified as they are shared with the events cache of the caller.
+// Payloads which look like a reference are rejected even if offloading is disabled, as they would be
+// resolved when history is read.
+func (p *historyV2PayloadOffloadingClient) AppendHistoryNodes(
+	request *AppendHistoryNodesRequest,
+) (*AppendHistoryNodesResponse, error) {
+
+	var branch workflow.HistoryBranch
+	if err := p.thriftEncoder.Decode(request.BranchToken, &branch); err != nil {
+		return nil, err
+	}
+	threshold := p.offloadThreshold()
+	ctx, cancel := context.WithTimeout(context.Background(), payloadStoreTimeout)
+	defer cancel()
+
+	events := make([]*workflow.HistoryEvent, len(request.Events))
+	for i, event := range request.Events {
+		// payloads are keyed by tree so that they can be deleted along with the last branch of the tree,
+		// and by transaction so that a stale append never overwrites the payload of an appended node
+		keyPrefix := fmt.Sprintf(
+			"%v/%v/%v/%v/",
+			branch.GetTreeID(),
+			branch.GetBranchID(),
+			event.GetEventId(),
+			request.TransactionID,
+		)
+		offloadedEvent, err := mapEventPayloads(event, func(fieldName string, payload []byte) ([]byte, bool, error) {
+			if bytes.HasPrefix(payload, []byte(offloadedPayloadPrefix)) {
+				return nil, false, errOffloadedPayloadPrefix
+			}
+			if threshold <= 0 || len(payload) <= threshold {
+				return nil, false, nil
+			}
+			key := keyPrefix + fieldName
+			if err := p.payloadStore.Put(ctx, key, payload); err != nil {
+				return nil, false, &workflow.InternalServiceError{
+					Message: fmt.Sprintf("AppendHistoryNodes failed to offload payload %v. Error: %v", key, err),
+				}
+			}
+			return []byte(offloadedPayloadPrefix + key), true, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		events[i] = offloadedEvent
+	}
+
+	offloadedRequest := *request
+	offloadedRequest.Events = events
+	return p.persistence.AppendHistoryNodes(&offloadedRequest)
+}
+
+func (p *historyV2PayloadOffloadingClient) ReadHistoryBranch(
+	request *ReadHistoryBranchRequest,
+) (*ReadHistoryBranchResponse, error) {
+
+	treeID, err := p.getTreeID(request.BranchToken)
+	if err != nil {
+		return nil, err
+	}
+	response, err := p.persistence.ReadHistoryBranch(request)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.resolvePayloads(treeID, response.HistoryEvents); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (p *historyV2PayloadOffloadingClient) ReadHistoryBranchByBatch(
+	request *ReadHistoryBranchRequest,
+) (*ReadHistoryBranchByBatchResponse, error) {
+
+	treeID, err := p.getTreeID(request.BranchToken)
+	if err != nil {
+		return nil, err
+	}
+	response, err := p.persistence.ReadHistoryBranchByBatch(request)
+	if err != nil {
+		return nil, err
+	}
+	for _, batch := range response.History {
+		if err := p.resolvePayloads(treeID, batch.Events); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+// ReadRawHistoryBranch resolves offloaded payloads as well, raw history is replicated to
+// other clusters which do not necessarily share the payload store
+func (p *historyV2PayloadOffloadingClient) ReadRawHistoryBranch(
+	request *ReadHistoryBranchRequest,
+) (*ReadRawHistoryBranchResponse, error) {
+
+	treeID, err := p.getTreeID(request.BranchToken)
+	if err != nil {
+		return nil, err
+	}
+	response, err := p.persistence.ReadRawHistoryBranch(request)
+	if err != nil {
+		return nil, err
+	}
+	for i, blob := range response.HistoryEventBlobs {
+		// payloads are stored verbatim with thriftrw, so batches without a reference can be skipped cheaply
+		if blob.GetEncoding() == common.EncodingTypeThriftRW && !bytes.Contains(blob.Data, []byte(offloadedPayloadPrefix)) {
+			continue
+		}
+		events, err := p.serializer.DeserializeBatchEvents(blob)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.resolvePayloads(treeID, events); err != nil {
+			return nil, err
+		}
+		resolvedBlob, err := p.serializer.SerializeBatchEvents(events, blob.GetEncoding())
+		if err != nil {
+			return nil, err
+		}
+		response.HistoryEventBlobs[i] = resolvedBlob
+	}
+	return response, nil
+}
+
+func (p *historyV2PayloadOffloadingClient) ForkHistoryBranch(
+	request *ForkHistoryBranchRequest,
+) (*ForkHistoryBranchResponse, error) {
+	return p.persistence.ForkHistoryBranch(request)
+}
+
+// DeleteHistoryBranch deletes the offloaded payloads of the tree once its last branch is deleted,
+// the payloads of the nodes of a branch may be referred by the branches forked from it
+func (p *historyV2PayloadOffloadingClient) DeleteHistoryBranch(
+	request *DeleteHistoryBranchRequest,
+) error {
+
+	if err := p.persistence.DeleteHistoryBranch(request); err != nil {
+		return err
+	}
+
+	treeID, err := p.getTreeID(request.BranchToken)
+	if err != nil {
+		return err
+	}
+	response, err := p.persistence.GetHistoryTree(&GetHistoryTreeRequest{
+		TreeID:  treeID,
+		ShardID: request.ShardID,
+	})
+	if err != nil {
+		return err
+	}
+	if len(response.Branches) > 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), payloadStoreTimeout)
+	defer cancel()
+	if err := p.payloadStore.DeletePrefix(ctx, treeID+"/"); err != nil {
+		p.logger.Error("failed to delete offloaded history payloads", tag.Error(err))
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteHistoryBranch failed to delete offloaded payloads. Error: %v", err),
+		}
+	}
+	return nil
+}
+
+func (p *historyV2PayloadOffloadingClient) GetHistoryTree(
+	request *GetHistoryTreeRequest,
+) (*GetHistoryTreeResponse, error) {
+	return p.persistence.GetHistoryTree(request)
+}
+
+func (p *historyV2PayloadOffloadingClient) GetAllHistoryTreeBranches(
+	request *GetAllHistoryTreeBranchesRequest,
+) (*GetAllHistoryTreeBranchesResponse, error) {
+	return p.persistence.GetAllHistoryTreeBranches(request)
+}
+
+func (p *historyV2PayloadOffloadingClient) getTreeID(
+	branchToken []byte,
+) (string, error) {
+
+	var branch workflow.HistoryBranch
+	if err := p.thriftEncoder.Decode(branchToken, &branch); err != nil {
+		return "", err
+	}
+	return branch.GetTreeID(), nil
+}
+
+// resolvePayloads replaces the offloaded payload references of the events with the payloads,
+// only payloads of the tree of the events are resolved so that history never refers to another tree
+func (p *historyV2PayloadOffloadingClient) resolvePayloads(
+	treeID string,
+	events []*workflow.HistoryEvent,
+) error {
+
+	ctx, cancel := context.WithTimeout(context.Background(), payloadStoreTimeout)
+	defer cancel()
+
+	for i, event := range events {
+		resolvedEvent, err := mapEventPayloads(event, func(_ string, payload []byte) ([]byte, bool, error) {
+			return resolvePayload(ctx, p.payloadStore, treeID, payload)
+		})
+		if err != nil {
+			return err
+		}
+		events[i] = resolvedEvent
+	}
+	return nil
+}
+
+// resolvePayload returns the offloaded payload if the given payload is a reference to it, references
+// are only resolved within the given history tree
+func resolvePayload(
+	ctx context.Context,
+	payloadStore blobstore.Client,
+	treeID string,
+	payload []byte,
+) ([]byte, bool, error) {
+
+	if !bytes.HasPrefix(payload, []byte(offloadedPayloadPrefix)) {
+		return nil, false, nil
+	}
+	key := strings.TrimPrefix(string(payload), offloadedPayloadPrefix)
+	if !strings.HasPrefix(key, treeID+"/") {
+		return nil, false, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("offloaded payload %v does not belong to history tree %v", key, treeID),
+		}
+	}
+	resolved, err := payloadStore.Get(ctx, key)
+	if err != nil {
+		return nil, false, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("failed to resolve offloaded payload %v. Error: %v", key, err),
+		}
+	}
+	return resolved, true, nil
+}
+
+// mapEventPayloads applies the mapper to every non empty binary field of the event attributes, e.g. the
+// input of a workflow or the result of an activity, nested payloads such as headers and memo are not mapped.
+// The event is copied on write, the returned event is the given one if no payload was replaced.
+func mapEventPayloads(
+	event *workflow.HistoryEvent,
+	mapper payloadMapper,
+) (*workflow.HistoryEvent, error) {
+
+	eventValue := reflect.ValueOf(event).Elem()
+	var eventCopy reflect.Value
+	for i := 0; i < eventValue.NumField(); i++ {
+		attributes := eventValue.Field(i)
+		if attributes.Kind() != reflect.Ptr || attributes.IsNil() || attributes.Elem().Kind() != reflect.Struct {
+			continue
+		}
+
+		var attributesCopy reflect.Value
+		for j := 0; j < attributes.Elem().NumField(); j++ {
+			field := attributes.Elem().Field(j)
+			if field.Type() != bytesType || field.Len() == 0 {
+				continue
+			}
+			replacement, replace, err := mapper(attributes.Elem().Type().Field(j).Name, field.Bytes())
+			if err != nil {
+				return nil, err
+			}
+			if !replace {
+				continue
+			}
+			if !attributesCopy.IsValid() {
+				attributesCopy = reflect.New(attributes.Elem().Type())
+				attributesCopy.Elem().Set(attributes.Elem())
+			}
+			attributesCopy.Elem().Field(j).SetBytes(replacement)
+		}
+
+		if attributesCopy.IsValid() {
+			if !eventCopy.IsValid() {
+				eventCopy = reflect.New(eventValue.Type())
+				eventCopy.Elem().Set(eventValue)
+			}
+			eventCopy.Elem().Field(i).Set(attributesCopy)
+		}
+	}
+
+	if !eventCopy.IsValid() {
+		return event, nil
+	}
+	return eventCopy.Interface().(*workflow.HistoryEvent), nil
+}
@@ -0,0 +1,325 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	workflow "github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/blobstore"
+	"github.com/temporalio/temporal/common/codec"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	payloadOffloadingSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		history      *historyManagerStub
+		payloadStore *blobStoreStub
+		threshold    int
+		client       HistoryManager
+		branchToken  []byte
+	}
+
+	historyManagerStub struct {
+		HistoryManager
+		serializer PayloadSerializer
+		events     []*workflow.HistoryEvent
+		branches   []*workflow.HistoryBranch
+	}
+
+	blobStoreStub struct {
+		blobs map[string][]byte
+	}
+)
+
+func TestPayloadOffloadingSuite(t *testing.T) {
+	s := new(payloadOffloadingSuite)
+	suite.Run(t, s)
+}
+
+func (s *payloadOffloadingSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	branch := &workflow.HistoryBranch{TreeID: common.StringPtr("tree"), BranchID: common.StringPtr("branch")}
+	branchToken, err := codec.NewThriftRWEncoder().Encode(branch)
+	s.NoError(err)
+	s.branchToken = branchToken
+
+	s.history = &historyManagerStub{serializer: NewPayloadSerializer(), branches: []*workflow.HistoryBranch{branch}}
+	s.payloadStore = &blobStoreStub{blobs: make(map[string][]byte)}
+	s.threshold = 4
+	s.client = NewHistoryV2PayloadOffloadingClient(
+		s.history,
+		s.payloadStore,
+		func(opts ...dynamicconfig.FilterOption) int { return s.threshold },
+		loggerimpl.NewNopLogger(),
+	)
+}
+
+func (s *payloadOffloadingSuite) TestAppendHistoryNodes_OffloadsLargePayloads() {
+	events := s.appendEvents()
+
+	// events of the caller are untouched
+	s.Equal([]byte("large input"), events[0].WorkflowExecutionStartedEventAttributes.Input)
+	s.Equal([]byte("large result"), events[1].ActivityTaskCompletedEventAttributes.Result)
+
+	stored := s.history.events
+	s.Equal(offloadedPayloadPrefix+"tree/branch/1/10/Input", string(stored[0].WorkflowExecutionStartedEventAttributes.Input))
+	s.Equal(offloadedPayloadPrefix+"tree/branch/2/10/Result", string(stored[1].ActivityTaskCompletedEventAttributes.Result))
+	s.True(events[2] == stored[2], "events without large payloads are not copied")
+	s.Equal(map[string][]byte{
+		"tree/branch/1/10/Input":  []byte("large input"),
+		"tree/branch/2/10/Result": []byte("large result"),
+	}, s.payloadStore.blobs)
+}
+
+func (s *payloadOffloadingSuite) TestAppendHistoryNodes_Disabled() {
+	s.threshold = 0
+	events := s.appendEvents()
+	s.Equal(events, s.history.events)
+	s.Empty(s.payloadStore.blobs)
+}
+
+func (s *payloadOffloadingSuite) TestReadHistoryBranch_ResolvesPayloads() {
+	events := s.appendEvents()
+
+	response, err := s.client.ReadHistoryBranch(&ReadHistoryBranchRequest{BranchToken: s.branchToken})
+	s.NoError(err)
+	s.Equal(events, response.HistoryEvents)
+
+	batchResponse, err := s.client.ReadHistoryBranchByBatch(&ReadHistoryBranchRequest{BranchToken: s.branchToken})
+	s.NoError(err)
+	s.Equal(events, batchResponse.History[0].Events)
+
+	rawResponse, err := s.client.ReadRawHistoryBranch(&ReadHistoryBranchRequest{BranchToken: s.branchToken})
+	s.NoError(err)
+	rawEvents, err := NewPayloadSerializer().DeserializeBatchEvents(rawResponse.HistoryEventBlobs[0])
+	s.NoError(err)
+	s.Equal(events, rawEvents)
+}
+
+func (s *payloadOffloadingSuite) TestReadHistoryBranch_MissingPayload() {
+	s.appendEvents()
+	delete(s.payloadStore.blobs, "tree/branch/1/10/Input")
+
+	_, err := s.client.ReadHistoryBranch(&ReadHistoryBranchRequest{BranchToken: s.branchToken})
+	s.IsType(&workflow.InternalServiceError{}, err)
+}
+
+func (s *payloadOffloadingSuite) TestDeleteHistoryBranch_DeletesPayloadsWithLastBranch() {
+	s.appendEvents()
+	s.payloadStore.blobs["other-tree/branch/1/10/Input"] = []byte("other")
+
+	// a branch forked from the deleted one may still refer to its payloads
+	s.history.branches = []*workflow.HistoryBranch{{TreeID: common.StringPtr("tree"), BranchID: common.StringPtr("forked")}}
+	s.NoError(s.client.DeleteHistoryBranch(&DeleteHistoryBranchRequest{BranchToken: s.branchToken}))
+	s.Len(s.payloadStore.blobs, 3)
+
+	s.history.branches = nil
+	s.NoError(s.client.DeleteHistoryBranch(&DeleteHistoryBranchRequest{BranchToken: s.branchToken}))
+	s.Equal(map[string][]byte{"other-tree/branch/1/10/Input": []byte("other")}, s.payloadStore.blobs)
+}
+
+func (s *payloadOffloadingSuite) TestAppendHistoryNodes_KeysPayloadsByTransaction() {
+	s.appendEvents()
+	_, err := s.client.AppendHistoryNodes(&AppendHistoryNodesRequest{
+		BranchToken: s.branchToken,
+		Events: []*workflow.HistoryEvent{
+			{
+				EventId:   common.Int64Ptr(1),
+				EventType: common.EventTypePtr(workflow.EventTypeWorkflowExecutionStarted),
+				WorkflowExecutionStartedEventAttributes: &workflow.WorkflowExecutionStartedEventAttributes{
+					Input: []byte("stale input"),
+				},
+			},
+		},
+		TransactionID: 9,
+	})
+	s.NoError(err)
+
+	s.Equal([]byte("large input"), s.payloadStore.blobs["tree/branch/1/10/Input"])
+	s.Equal([]byte("stale input"), s.payloadStore.blobs["tree/branch/1/9/Input"])
+}
+
+func (s *payloadOffloadingSuite) TestAppendHistoryNodes_RejectsOffloadedPayloadPrefix() {
+	for _, threshold := range []int{4, 0} {
+		s.threshold = threshold
+		_, err := s.client.AppendHistoryNodes(&AppendHistoryNodesRequest{
+			BranchToken: s.branchToken,
+			Events: []*workflow.HistoryEvent{
+				{
+					EventId:   common.Int64Ptr(1),
+					EventType: common.EventTypePtr(workflow.EventTypeWorkflowExecutionSignaled),
+					WorkflowExecutionSignaledEventAttributes: &workflow.WorkflowExecutionSignaledEventAttributes{
+						Input: []byte(offloadedPayloadPrefix + "tree/branch/1/10/Input"),
+					},
+				},
+			},
+			TransactionID: 10,
+		})
+		s.Equal(errOffloadedPayloadPrefix, err)
+	}
+	s.Empty(s.history.events)
+	s.Empty(s.payloadStore.blobs)
+}
+
+func (s *payloadOffloadingSuite) TestReadHistoryBranch_RejectsPayloadOfOtherTree() {
+	s.payloadStore.blobs["other-tree/branch/1/10/Input"] = []byte("other")
+	s.history.events = []*workflow.HistoryEvent{
+		{
+			EventId:   common.Int64Ptr(1),
+			EventType: common.EventTypePtr(workflow.EventTypeWorkflowExecutionStarted),
+			WorkflowExecutionStartedEventAttributes: &workflow.WorkflowExecutionStartedEventAttributes{
+				Input: []byte(offloadedPayloadPrefix + "other-tree/branch/1/10/Input"),
+			},
+		},
+	}
+
+	_, err := s.client.ReadHistoryBranch(&ReadHistoryBranchRequest{BranchToken: s.branchToken})
+	s.IsType(&workflow.InternalServiceError{}, err)
+}
+
+func (s *payloadOffloadingSuite) TestNewBlobSizeLimitWithOffloading() {
+	errorLimit := func(domain string) int { return 100 }
+	offloadedErrorLimit := func(domain string) int { return 1000 }
+
+	limit := NewBlobSizeLimitWithOffloading(errorLimit, offloadedErrorLimit, dynamicconfig.GetIntPropertyFn(0))
+	s.Equal(100, limit("domain"))
+
+	limit = NewBlobSizeLimitWithOffloading(errorLimit, offloadedErrorLimit, dynamicconfig.GetIntPropertyFn(200))
+	s.Equal(100, limit("domain"))
+
+	limit = NewBlobSizeLimitWithOffloading(errorLimit, offloadedErrorLimit, dynamicconfig.GetIntPropertyFn(50))
+	s.Equal(1000, limit("domain"))
+
+	// the offloaded limit never lowers the limit
+	limit = NewBlobSizeLimitWithOffloading(errorLimit, func(domain string) int { return 10 }, dynamicconfig.GetIntPropertyFn(50))
+	s.Equal(100, limit("domain"))
+}
+
+func (s *payloadOffloadingSuite) appendEvents() []*workflow.HistoryEvent {
+	events := []*workflow.HistoryEvent{
+		{
+			EventId:   common.Int64Ptr(1),
+			EventType: common.EventTypePtr(workflow.EventTypeWorkflowExecutionStarted),
+			WorkflowExecutionStartedEventAttributes: &workflow.WorkflowExecutionStartedEventAttributes{
+				Input: []byte("large input"),
+			},
+		},
+		{
+			EventId:   common.Int64Ptr(2),
+			EventType: common.EventTypePtr(workflow.EventTypeActivityTaskCompleted),
+			ActivityTaskCompletedEventAttributes: &workflow.ActivityTaskCompletedEventAttributes{
+				Result:   []byte("large result"),
+				Identity: common.StringPtr("large identity"),
+			},
+		},
+		{
+			EventId:   common.Int64Ptr(3),
+			EventType: common.EventTypePtr(workflow.EventTypeWorkflowExecutionSignaled),
+			WorkflowExecutionSignaledEventAttributes: &workflow.WorkflowExecutionSignaledEventAttributes{
+				SignalName: common.StringPtr("large signal name"),
+				Input:      []byte("ok"),
+			},
+		},
+	}
+	_, err := s.client.AppendHistoryNodes(&AppendHistoryNodesRequest{
+		BranchToken:   s.branchToken,
+		Events:        events,
+		TransactionID: 10,
+	})
+	s.NoError(err)
+	return events
+}
+
+func (h *historyManagerStub) AppendHistoryNodes(request *AppendHistoryNodesRequest) (*AppendHistoryNodesResponse, error) {
+	h.events = append(h.events, request.Events...)
+	return &AppendHistoryNodesResponse{}, nil
+}
+
+func (h *historyManagerStub) ReadHistoryBranch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchResponse, error) {
+	return &ReadHistoryBranchResponse{HistoryEvents: h.copyEvents()}, nil
+}
+
+func (h *historyManagerStub) ReadHistoryBranchByBatch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchByBatchResponse, error) {
+	return &ReadHistoryBranchByBatchResponse{History: []*workflow.History{{Events: h.copyEvents()}}}, nil
+}
+
+func (h *historyManagerStub) ReadRawHistoryBranch(request *ReadHistoryBranchRequest) (*ReadRawHistoryBranchResponse, error) {
+	blob, err := h.serializer.SerializeBatchEvents(h.events, common.EncodingTypeThriftRW)
+	if err != nil {
+		return nil, err
+	}
+	return &ReadRawHistoryBranchResponse{HistoryEventBlobs: []*DataBlob{blob}}, nil
+}
+
+func (h *historyManagerStub) DeleteHistoryBranch(request *DeleteHistoryBranchRequest) error {
+	return nil
+}
+
+func (h *historyManagerStub) GetHistoryTree(request *GetHistoryTreeRequest) (*GetHistoryTreeResponse, error) {
+	return &GetHistoryTreeResponse{Branches: h.branches}, nil
+}
+
+// copyEvents mimics reading events back from the database
+func (h *historyManagerStub) copyEvents() []*workflow.HistoryEvent {
+	blob, err := h.serializer.SerializeBatchEvents(h.events, common.EncodingTypeThriftRW)
+	if err != nil {
+		panic(err)
+	}
+	events, err := h.serializer.DeserializeBatchEvents(blob)
+	if err != nil {
+		panic(err)
+	}
+	return events
+}
+
+func (b *blobStoreStub) Put(_ context.Context, key string, blob []byte) error {
+	b.blobs[key] = blob
+	return nil
+}
+
+func (b *blobStoreStub) Get(_ context.Context, key string) ([]byte, error) {
+	blob, ok := b.blobs[key]
+	if !ok {
+		return nil, blobstore.ErrBlobNotFound
+	}
+	return blob, nil
+}
+
+func (b *blobStoreStub) DeletePrefix(_ context.Context, prefix string) error {
+	for key := range b.blobs {
+		if strings.HasPrefix(key, prefix) {
+			delete(b.blobs, key)
+		}
+	}
+	return nil
+}
@@ -152,6 +152,10 @@ func (m *metadataManagerImpl) serializeDomainConfig(c *DomainConfig) (InternalDo
 	if err != nil {
 		return InternalDomainConfig{}, err
 	}
+	workflowDefaults, err := m.serializer.SerializeDomainWorkflowDefaults(c.WorkflowDefaults, common.EncodingTypeJSON)
+	if err != nil {
+		return InternalDomainConfig{}, err
+	}
 	return InternalDomainConfig{
 		Retention:                c.Retention,
 		EmitMetric:               c.EmitMetric,
@@ -160,6 +164,8 @@ func (m *metadataManagerImpl) serializeDomainConfig(c *DomainConfig) (InternalDo
 		VisibilityArchivalStatus: c.VisibilityArchivalStatus,
 		VisibilityArchivalURI:    c.VisibilityArchivalURI,
 		BadBinaries:              badBinaries,
+		SearchAttributes:         c.SearchAttributes,
+		WorkflowDefaults:         workflowDefaults,
 	}, nil
 }
 
@@ -174,6 +180,14 @@ func (m *metadataManagerImpl) deserializeDomainConfig(ic *InternalDomainConfig)
 	if badBinaries.Binaries == nil {
 		badBinaries.Binaries = map[string]*shared.BadBinaryInfo{}
 	}
+	searchAttributes := ic.SearchAttributes
+	if searchAttributes == nil {
+		searchAttributes = map[string]string{}
+	}
+	workflowDefaults, err := m.serializer.DeserializeDomainWorkflowDefaults(ic.WorkflowDefaults)
+	if err != nil {
+		return DomainConfig{}, err
+	}
 	return DomainConfig{
 		Retention:                ic.Retention,
 		EmitMetric:               ic.EmitMetric,
@@ -182,6 +196,8 @@ func (m *metadataManagerImpl) deserializeDomainConfig(ic *InternalDomainConfig)
 		VisibilityArchivalStatus: ic.VisibilityArchivalStatus,
 		VisibilityArchivalURI:    ic.VisibilityArchivalURI,
 		BadBinaries:              *badBinaries,
+		SearchAttributes:         searchAttributes,
+		WorkflowDefaults:         workflowDefaults,
 	}, nil
 }
 
@@ -0,0 +1,112 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"fmt"
+
+	checksumgen "github.com/temporalio/temporal/.gen/go/checksum"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/checksum"
+)
+
+const (
+	// MutableStateChecksumPayloadV1 is the version of the mutable state checksum payload
+	MutableStateChecksumPayloadV1 = 1
+)
+
+// VerifyMutableStateChecksum verifies the checksum of the mutable state
+func VerifyMutableStateChecksum(
+	ms *WorkflowMutableState,
+	csum checksum.Checksum,
+) error {
+	if csum.Version != MutableStateChecksumPayloadV1 {
+		return fmt.Errorf("invalid checksum payload version %v", csum.Version)
+	}
+	payload := NewMutableStateChecksumPayload(ms)
+	return checksum.Verify(payload, csum)
+}
+
+// NewMutableStateChecksumPayload returns the payload from which the checksum of the mutable state is generated
+func NewMutableStateChecksumPayload(ms *WorkflowMutableState) *checksumgen.MutableStateChecksumPayload {
+	executionInfo := ms.ExecutionInfo
+	replicationState := ms.ReplicationState
+	payload := &checksumgen.MutableStateChecksumPayload{
+		CancelRequested:      common.BoolPtr(executionInfo.CancelRequested),
+		State:                common.Int16Ptr(int16(executionInfo.State)),
+		LastFirstEventID:     common.Int64Ptr(executionInfo.LastFirstEventID),
+		NextEventID:          common.Int64Ptr(executionInfo.NextEventID),
+		LastProcessedEventID: common.Int64Ptr(executionInfo.LastProcessedEvent),
+		SignalCount:          common.Int64Ptr(int64(executionInfo.SignalCount)),
+		DecisionAttempt:      common.Int32Ptr(int32(executionInfo.DecisionAttempt)),
+		DecisionScheduledID:  common.Int64Ptr(executionInfo.DecisionScheduleID),
+		DecisionStartedID:    common.Int64Ptr(executionInfo.DecisionStartedID),
+		DecisionVersion:      common.Int64Ptr(executionInfo.DecisionVersion),
+		StickyTaskListName:   common.StringPtr(executionInfo.StickyTaskList),
+	}
+
+	if replicationState != nil {
+		payload.LastWriteVersion = common.Int64Ptr(replicationState.LastWriteVersion)
+		payload.LastWriteEventID = common.Int64Ptr(replicationState.LastWriteEventID)
+	}
+
+	if ms.VersionHistories != nil {
+		payload.VersionHistories = ms.VersionHistories.ToThrift()
+	}
+
+	// for each of the pendingXXX ids below, sorting is needed to guarantee that
+	// same serialized bytes can be generated during verification
+	pendingTimerIDs := make([]int64, 0, len(ms.TimerInfos))
+	for _, ti := range ms.TimerInfos {
+		pendingTimerIDs = append(pendingTimerIDs, ti.StartedID)
+	}
+	common.SortInt64Slice(pendingTimerIDs)
+	payload.PendingTimerStartedIDs = pendingTimerIDs
+
+	pendingActivityIDs := make([]int64, 0, len(ms.ActivityInfos))
+	for id := range ms.ActivityInfos {
+		pendingActivityIDs = append(pendingActivityIDs, id)
+	}
+	common.SortInt64Slice(pendingActivityIDs)
+	payload.PendingActivityScheduledIDs = pendingActivityIDs
+
+	pendingChildIDs := make([]int64, 0, len(ms.ChildExecutionInfos))
+	for id := range ms.ChildExecutionInfos {
+		pendingChildIDs = append(pendingChildIDs, id)
+	}
+	common.SortInt64Slice(pendingChildIDs)
+	payload.PendingChildInitiatedIDs = pendingChildIDs
+
+	signalIDs := make([]int64, 0, len(ms.SignalInfos))
+	for id := range ms.SignalInfos {
+		signalIDs = append(signalIDs, id)
+	}
+	common.SortInt64Slice(signalIDs)
+	payload.PendingSignalInitiatedIDs = signalIDs
+
+	requestCancelIDs := make([]int64, 0, len(ms.RequestCancelInfos))
+	for id := range ms.RequestCancelInfos {
+		requestCancelIDs = append(requestCancelIDs, id)
+	}
+	common.SortInt64Slice(requestCancelIDs)
+	payload.PendingReqCancelInitiatedIDs = requestCancelIDs
+	return payload
+}
@@ -133,6 +133,29 @@ func (s *ClusterMetadataManagerSuite) TestClusterMembershipUpsertCanPageRead() {
 	s.NoError(err)
 }
 
+// TestClusterMembershipDelete verifies that a host deleted by DeleteClusterMembership is no longer returned
+func (s *ClusterMetadataManagerSuite) TestClusterMembershipDelete() {
+	hostID := uuid.NewUUID()
+	req := &p.UpsertClusterMembershipRequest{
+		HostID:       hostID,
+		RPCAddress:   net.ParseIP("127.0.0.2"),
+		RPCPort:      123,
+		Role:         p.Matching,
+		SessionStart: time.Now().UTC(),
+		RecordExpiry: time.Minute,
+	}
+	s.NoError(s.ClusterMetadataManager.UpsertClusterMembership(req))
+
+	resp, err := s.ClusterMetadataManager.GetClusterMembers(&p.GetClusterMembersRequest{HostIDEquals: hostID})
+	s.NoError(err)
+	s.Len(resp.ActiveMembers, 1)
+
+	s.NoError(s.ClusterMetadataManager.DeleteClusterMembership(&p.DeleteClusterMembershipRequest{Role: p.Matching, HostID: hostID}))
+	resp, err = s.ClusterMetadataManager.GetClusterMembers(&p.GetClusterMembersRequest{HostIDEquals: hostID})
+	s.NoError(err)
+	s.Empty(resp.ActiveMembers)
+}
+
 func (s *ClusterMetadataManagerSuite) validateUpsert(req *p.UpsertClusterMembershipRequest, resp *p.GetClusterMembersResponse, err error) {
 	s.Nil(err)
 	s.NotNil(resp)
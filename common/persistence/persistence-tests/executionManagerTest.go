@@ -1255,6 +1255,7 @@ func (s *ExecutionManagerSuite) TestGetWorkflow() {
 				AutoResetPoints:             &testResetPoints,
 				SearchAttributes:            testSearchAttr,
 				Memo:                        testMemo,
+				StartingBuildID:             "1.0",
 			},
 			ExecutionStats: &p.ExecutionStats{
 				HistorySize: int64(rand.Int31()),
@@ -1319,6 +1320,7 @@ func (s *ExecutionManagerSuite) TestGetWorkflow() {
 	s.Equal(createReq.NewWorkflowSnapshot.ExecutionInfo.CronSchedule, info.CronSchedule)
 	s.Equal(createReq.NewWorkflowSnapshot.ExecutionInfo.NonRetriableErrors, info.NonRetriableErrors)
 	s.Equal(testResetPoints.String(), info.AutoResetPoints.String())
+	s.Equal(createReq.NewWorkflowSnapshot.ExecutionInfo.StartingBuildID, info.StartingBuildID)
 	s.Equal(createReq.NewWorkflowSnapshot.ExecutionStats.HistorySize, state.ExecutionStats.HistorySize)
 	val, ok := info.SearchAttributes[testSearchAttrKey]
 	s.True(ok)
@@ -1890,6 +1892,46 @@ func (s *ExecutionManagerSuite) TestGetCurrentWorkflow() {
 	s.Empty(task1, "Expected empty task identifier.")
 }
 
+// TestListConcreteExecutions test
+func (s *ExecutionManagerSuite) TestListConcreteExecutions() {
+	domainID := "9f7a6c1e-3b5d-4f4e-8c2a-1d0e5b7c9a31"
+	expected := make(map[string]string)
+	for _, runID := range []string{
+		"0d0a5c9e-7f3b-4c1a-9e2d-6b8f4a3c2e10",
+		"5e1b7d2f-8a4c-4d3b-a1f0-7c9e6b5d4a21",
+		"a2c4e6f8-1b3d-4f5a-8c7e-9d0b2a4c6e32",
+	} {
+		workflowExecution := gen.WorkflowExecution{
+			WorkflowId: common.StringPtr("list-concrete-executions-test-" + runID),
+			RunId:      common.StringPtr(runID),
+		}
+		_, err := s.CreateWorkflowExecution(domainID, workflowExecution, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+		s.NoError(err)
+		expected[runID] = workflowExecution.GetWorkflowId()
+	}
+
+	var pageToken []byte
+	actual := make(map[string]string)
+	for {
+		response, err := s.ExecutionManager.ListConcreteExecutions(&p.ListConcreteExecutionsRequest{
+			PageSize:  2,
+			PageToken: pageToken,
+		})
+		s.NoError(err)
+		s.True(len(response.Executions) <= 2)
+		for _, execution := range response.Executions {
+			if execution.DomainID == domainID {
+				actual[execution.RunID] = execution.WorkflowID
+			}
+		}
+		if len(response.PageToken) == 0 {
+			break
+		}
+		pageToken = response.PageToken
+	}
+	s.Equal(expected, actual)
+}
+
 // TestTransferTasksThroughUpdate test
 func (s *ExecutionManagerSuite) TestTransferTasksThroughUpdate() {
 	domainID := "b785a8ba-bd7d-4760-bb05-41b115f3e10a"
@@ -2578,6 +2620,111 @@ func (s *ExecutionManagerSuite) TestTimerTasksRangeComplete() {
 }
 
 // TestWorkflowMutableStateActivities test
+// TestQueueTaskDLQ test
+func (s *ExecutionManagerSuite) TestQueueTaskDLQ() {
+	domainID := "8bfb47be-5b57-4d66-9109-5fb35e20b1d8"
+	workflowID := "queue-task-dlq-test"
+	runID := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaab"
+	now := time.Now().Truncate(time.Millisecond)
+
+	transferTasks := []*p.TransferTaskInfo{
+		{
+			DomainID:            domainID,
+			WorkflowID:          workflowID,
+			RunID:               runID,
+			VisibilityTimestamp: now,
+			TaskID:              100,
+			TargetDomainID:      domainID,
+			TargetWorkflowID:    p.TransferTaskTransferTargetWorkflowID,
+			TaskList:            "some random tasklist",
+			TaskType:            p.TransferTaskTypeDecisionTask,
+			ScheduleID:          2,
+			Version:             11,
+		},
+		{
+			DomainID:            domainID,
+			WorkflowID:          workflowID,
+			RunID:               runID,
+			VisibilityTimestamp: now,
+			TaskID:              101,
+			TargetDomainID:      domainID,
+			TargetWorkflowID:    p.TransferTaskTransferTargetWorkflowID,
+			TaskType:            p.TransferTaskTypeCloseExecution,
+			Version:             12,
+		},
+	}
+	for _, task := range transferTasks {
+		err := s.ExecutionManager.PutTransferTaskToDLQ(&p.PutTransferTaskToDLQRequest{TaskInfo: task})
+		s.NoError(err)
+	}
+
+	var dlqTransferTasks []*p.TransferTaskInfo
+	var token []byte
+	for {
+		// use page size one to force pagination
+		resp, err := s.ExecutionManager.GetTransferTasksFromDLQ(&p.GetTransferTasksFromDLQRequest{
+			BatchSize:     1,
+			NextPageToken: token,
+		})
+		s.NoError(err)
+		dlqTransferTasks = append(dlqTransferTasks, resp.Tasks...)
+		token = resp.NextPageToken
+		if len(token) == 0 {
+			break
+		}
+	}
+	s.Equal(len(transferTasks), len(dlqTransferTasks))
+	for i, task := range dlqTransferTasks {
+		s.Equal(transferTasks[i].TaskID, task.TaskID)
+		s.Equal(transferTasks[i].TaskType, task.TaskType)
+		s.Equal(transferTasks[i].WorkflowID, task.WorkflowID)
+		s.Equal(transferTasks[i].ScheduleID, task.ScheduleID)
+		s.Equal(transferTasks[i].Version, task.Version)
+		s.Equal(now.UnixNano(), task.VisibilityTimestamp.UnixNano())
+	}
+
+	timerTask := &p.TimerTaskInfo{
+		DomainID:            domainID,
+		WorkflowID:          workflowID,
+		RunID:               runID,
+		VisibilityTimestamp: now,
+		TaskID:              102,
+		TaskType:            p.TaskTypeActivityTimeout,
+		TimeoutType:         int(gen.TimeoutTypeStartToClose),
+		EventID:             7,
+		ScheduleAttempt:     3,
+		Version:             13,
+	}
+	err := s.ExecutionManager.PutTimerTaskToDLQ(&p.PutTimerTaskToDLQRequest{TaskInfo: timerTask})
+	s.NoError(err)
+
+	timerResp, err := s.ExecutionManager.GetTimerTasksFromDLQ(&p.GetTimerTasksFromDLQRequest{BatchSize: 10})
+	s.NoError(err)
+	s.Equal(1, len(timerResp.Timers))
+	s.Equal(timerTask.TaskID, timerResp.Timers[0].TaskID)
+	s.Equal(timerTask.TaskType, timerResp.Timers[0].TaskType)
+	s.Equal(timerTask.EventID, timerResp.Timers[0].EventID)
+	s.Equal(timerTask.ScheduleAttempt, timerResp.Timers[0].ScheduleAttempt)
+	s.Equal(now.UnixNano(), timerResp.Timers[0].VisibilityTimestamp.UnixNano())
+
+	for _, task := range dlqTransferTasks {
+		err := s.ExecutionManager.DeleteTransferTaskFromDLQ(&p.DeleteTransferTaskFromDLQRequest{TaskID: task.TaskID})
+		s.NoError(err)
+	}
+	err = s.ExecutionManager.DeleteTimerTaskFromDLQ(&p.DeleteTimerTaskFromDLQRequest{
+		VisibilityTimestamp: timerResp.Timers[0].VisibilityTimestamp,
+		TaskID:              timerResp.Timers[0].TaskID,
+	})
+	s.NoError(err)
+
+	transferResp, err := s.ExecutionManager.GetTransferTasksFromDLQ(&p.GetTransferTasksFromDLQRequest{BatchSize: 10})
+	s.NoError(err)
+	s.Empty(transferResp.Tasks)
+	timerResp, err = s.ExecutionManager.GetTimerTasksFromDLQ(&p.GetTimerTasksFromDLQRequest{BatchSize: 10})
+	s.NoError(err)
+	s.Empty(timerResp.Timers)
+}
+
 func (s *ExecutionManagerSuite) TestWorkflowMutableStateActivities() {
 	domainID := "7fcf0aa9-e121-4292-bdad-0a75181b4aa3"
 	workflowExecution := gen.WorkflowExecution{
@@ -5115,6 +5262,7 @@ func (s *ExecutionManagerSuite) TestCreateGetShardBackfill() {
 		TransferAckLevel:        currentClusterTransferAck,
 		TimerAckLevel:           currentClusterTimerAck,
 		ClusterReplicationLevel: map[string]int64{},
+		OpenWorkflowCounts:      map[string]int64{},
 	}
 	createRequest := &p.CreateShardRequest{
 		ShardInfo: shardInfo,
@@ -5170,6 +5318,7 @@ func (s *ExecutionManagerSuite) TestCreateGetUpdateGetShard() {
 		},
 		DomainNotificationVersion: domainNotificationVersion,
 		ClusterReplicationLevel:   map[string]int64{},
+		OpenWorkflowCounts:        map[string]int64{},
 	}
 	createRequest := &p.CreateShardRequest{
 		ShardInfo: shardInfo,
@@ -5212,6 +5361,7 @@ func (s *ExecutionManagerSuite) TestCreateGetUpdateGetShard() {
 		},
 		DomainNotificationVersion: domainNotificationVersion,
 		ClusterReplicationLevel:   map[string]int64{cluster.TestAlternativeClusterName: 12345},
+		OpenWorkflowCounts:        map[string]int64{"domain-id": 3},
 	}
 	updateRequest := &p.UpdateShardRequest{
 		ShardInfo:       shardInfo,
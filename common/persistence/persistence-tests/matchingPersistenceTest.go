@@ -345,6 +345,80 @@ func (s *MatchingPersistenceSuite) TestLeaseAndUpdateTaskList() {
 	s.Error(err)
 }
 
+// TestLeaseAndUpdateTaskListBuildIDSets test
+func (s *MatchingPersistenceSuite) TestLeaseAndUpdateTaskListBuildIDSets() {
+	domainID := uuid.New()
+	taskList := "aaaaaaa"
+	response, err := s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		DomainID: domainID,
+		TaskList: taskList,
+		TaskType: p.TaskListTypeDecision,
+	})
+	s.NoError(err)
+	s.Empty(response.TaskListInfo.BuildIDSets)
+
+	buildIDSets := [][]string{{"1.0"}, {"2.0", "2.1"}}
+	_, err = s.TaskMgr.UpdateTaskList(&p.UpdateTaskListRequest{
+		TaskListInfo: &p.TaskListInfo{
+			DomainID:    domainID,
+			Name:        taskList,
+			TaskType:    p.TaskListTypeDecision,
+			RangeID:     1,
+			AckLevel:    0,
+			Kind:        p.TaskListKindNormal,
+			BuildIDSets: buildIDSets,
+		},
+	})
+	s.NoError(err)
+
+	response, err = s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		DomainID: domainID,
+		TaskList: taskList,
+		TaskType: p.TaskListTypeDecision,
+	})
+	s.NoError(err)
+	s.EqualValues(2, response.TaskListInfo.RangeID)
+	s.Equal(buildIDSets, response.TaskListInfo.BuildIDSets)
+}
+
+// TestLeaseAndUpdateTaskListPartitions test
+func (s *MatchingPersistenceSuite) TestLeaseAndUpdateTaskListPartitions() {
+	domainID := uuid.New()
+	taskList := "aaaaaaa"
+	response, err := s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		DomainID: domainID,
+		TaskList: taskList,
+		TaskType: p.TaskListTypeActivity,
+	})
+	s.NoError(err)
+	s.Zero(response.TaskListInfo.NumWritePartitions)
+	s.Zero(response.TaskListInfo.NumReadPartitions)
+
+	_, err = s.TaskMgr.UpdateTaskList(&p.UpdateTaskListRequest{
+		TaskListInfo: &p.TaskListInfo{
+			DomainID:           domainID,
+			Name:               taskList,
+			TaskType:           p.TaskListTypeActivity,
+			RangeID:            1,
+			AckLevel:           0,
+			Kind:               p.TaskListKindNormal,
+			NumWritePartitions: 2,
+			NumReadPartitions:  4,
+		},
+	})
+	s.NoError(err)
+
+	response, err = s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		DomainID: domainID,
+		TaskList: taskList,
+		TaskType: p.TaskListTypeActivity,
+	})
+	s.NoError(err)
+	s.EqualValues(2, response.TaskListInfo.RangeID)
+	s.Equal(2, response.TaskListInfo.NumWritePartitions)
+	s.Equal(4, response.TaskListInfo.NumReadPartitions)
+}
+
 // TestLeaseAndUpdateTaskListSticky test
 func (s *MatchingPersistenceSuite) TestLeaseAndUpdateTaskListSticky() {
 	domainID := uuid.New()
@@ -398,9 +472,6 @@ func (s *MatchingPersistenceSuite) deleteAllTaskList() {
 
 // TestListWithOneTaskList test
 func (s *MatchingPersistenceSuite) TestListWithOneTaskList() {
-	if s.TaskMgr.GetName() == "cassandra" {
-		s.T().Skip("ListTaskList API is currently not supported in cassandra")
-	}
 	s.deleteAllTaskList()
 	resp, err := s.TaskMgr.ListTaskList(&p.ListTaskListRequest{PageSize: 10})
 	s.NoError(err)
@@ -457,9 +528,6 @@ func (s *MatchingPersistenceSuite) TestListWithOneTaskList() {
 
 // TestListWithMultipleTaskList test
 func (s *MatchingPersistenceSuite) TestListWithMultipleTaskList() {
-	if s.TaskMgr.GetName() == "cassandra" {
-		s.T().Skip("ListTaskList API is currently not supported in cassandra")
-	}
 	s.deleteAllTaskList()
 	domainID := uuid.New()
 	tlNames := make(map[string]struct{})
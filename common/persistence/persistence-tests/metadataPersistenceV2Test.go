@@ -657,6 +657,17 @@ func (m *MetadataPersistenceSuiteV2) TestUpdateDomain() {
 	updatedHistoryArchivalURI := ""
 	updatedVisibilityArchivalStatus := gen.ArchivalStatusDisabled
 	updatedVisibilityArchivalURI := ""
+	updatedSearchAttributes := map[string]string{"OrderID": "CustomKeywordField"}
+	updatedWorkflowDefaults := &p.DomainWorkflowDefaults{
+		DefaultExecutionStartToCloseTimeoutSeconds: 3600,
+		MaxExecutionStartToCloseTimeoutSeconds:     86400,
+		DefaultWorkflowIDReusePolicy:               gen.WorkflowIdReusePolicyRejectDuplicate.Ptr(),
+		DefaultActivityRetryPolicy: &gen.RetryPolicy{
+			InitialIntervalInSeconds: common.Int32Ptr(1),
+			BackoffCoefficient:       common.Float64Ptr(2),
+			MaximumAttempts:          common.Int32Ptr(3),
+		},
+	}
 
 	updateClusterActive := "other random active cluster name"
 	updateClusterStandby := "other random standby cluster name"
@@ -698,6 +709,8 @@ func (m *MetadataPersistenceSuiteV2) TestUpdateDomain() {
 			VisibilityArchivalStatus: updatedVisibilityArchivalStatus,
 			VisibilityArchivalURI:    updatedVisibilityArchivalURI,
 			BadBinaries:              testBinaries,
+			SearchAttributes:         updatedSearchAttributes,
+			WorkflowDefaults:         updatedWorkflowDefaults,
 		},
 		&p.DomainReplicationConfig{
 			ActiveClusterName: updateClusterActive,
@@ -727,6 +740,8 @@ func (m *MetadataPersistenceSuiteV2) TestUpdateDomain() {
 	m.Equal(updatedVisibilityArchivalStatus, resp4.Config.VisibilityArchivalStatus)
 	m.Equal(updatedVisibilityArchivalURI, resp4.Config.VisibilityArchivalURI)
 	m.True(testBinaries.Equals(&resp4.Config.BadBinaries))
+	m.Equal(updatedSearchAttributes, resp4.Config.SearchAttributes)
+	m.Equal(updatedWorkflowDefaults, resp4.Config.WorkflowDefaults)
 	m.Equal(updateClusterActive, resp4.ReplicationConfig.ActiveClusterName)
 	m.Equal(len(updateClusters), len(resp4.ReplicationConfig.Clusters))
 	for index := range clusters {
@@ -0,0 +1,128 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	workflow "github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/service/config"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	faultInjectionSuite struct {
+		suite.Suite
+		*require.Assertions
+	}
+
+	queueStub struct {
+		Queue
+		enqueued int
+	}
+)
+
+func TestFaultInjectionSuite(t *testing.T) {
+	s := new(faultInjectionSuite)
+	suite.Run(t, s)
+}
+
+func (s *faultInjectionSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (q *queueStub) EnqueueMessage(messagePayload []byte) error {
+	q.enqueued++
+	return nil
+}
+
+func (s *faultInjectionSuite) newInjector(seed int, errorRate float64, errorType string) *FaultInjector {
+	return NewFaultInjector(&config.FaultInjectionConfig{
+		Seed:        dynamicconfig.GetIntPropertyFn(seed),
+		ErrorRate:   dynamicconfig.GetFloatPropertyFn(errorRate),
+		ErrorType:   dynamicconfig.GetStringPropertyFn(errorType),
+		LatencyRate: dynamicconfig.GetFloatPropertyFn(0),
+		Latency:     dynamicconfig.GetDurationPropertyFn(time.Hour),
+	}, loggerimpl.NewNopLogger())
+}
+
+func (s *faultInjectionSuite) TestErrorTypes() {
+	s.IsType(&TimeoutError{}, s.newInjector(1, 1, FaultInjectionErrorTimeout).inject("GetShard"))
+	s.IsType(&ConditionFailedError{}, s.newInjector(1, 1, FaultInjectionErrorConditionFailed).inject("GetShard"))
+	s.IsType(&ShardOwnershipLostError{}, s.newInjector(1, 1, FaultInjectionErrorShardOwnershipLost).inject("GetShard"))
+	s.IsType(&workflow.ServiceBusyError{}, s.newInjector(1, 1, FaultInjectionErrorServiceBusy).inject("GetShard"))
+	s.IsType(&workflow.InternalServiceError{}, s.newInjector(1, 1, FaultInjectionErrorInternal).inject("GetShard"))
+	s.NoError(s.newInjector(1, 0, FaultInjectionErrorTimeout).inject("GetShard"))
+}
+
+func (s *faultInjectionSuite) TestSeedIsReproducible() {
+	sequence := func(injector *FaultInjector) []bool {
+		result := make([]bool, 100)
+		for i := range result {
+			result[i] = injector.inject("UpdateWorkflowExecution") != nil
+		}
+		return result
+	}
+
+	first := sequence(s.newInjector(42, 0.5, FaultInjectionErrorTimeout))
+	s.Equal(first, sequence(s.newInjector(42, 0.5, FaultInjectionErrorTimeout)))
+	s.Contains(first, true)
+	s.Contains(first, false)
+}
+
+func (s *faultInjectionSuite) TestRatesByAPI() {
+	errorRate := func(opts ...dynamicconfig.FilterOption) float64 {
+		filters := make(map[dynamicconfig.Filter]interface{})
+		for _, opt := range opts {
+			opt(filters)
+		}
+		if filters[dynamicconfig.PersistenceAPI] == "EnqueueMessage" {
+			return 1
+		}
+		return 0
+	}
+	injector := s.newInjector(1, 0, FaultInjectionErrorTimeout)
+	injector.config.ErrorRate = errorRate
+
+	queue := &queueStub{}
+	client := NewQueuePersistenceFaultInjectionClient(queue, injector, loggerimpl.NewNopLogger())
+	s.IsType(&TimeoutError{}, client.EnqueueMessage(nil))
+	s.Equal(0, queue.enqueued)
+	s.NoError(injector.inject("ReadMessages"))
+}
+
+func (s *faultInjectionSuite) TestLatency() {
+	injector := s.newInjector(1, 0, FaultInjectionErrorTimeout)
+	injector.config.LatencyRate = dynamicconfig.GetFloatPropertyFn(1)
+	injector.config.Latency = dynamicconfig.GetDurationPropertyFn(10 * time.Millisecond)
+
+	queue := &queueStub{}
+	client := NewQueuePersistenceFaultInjectionClient(queue, injector, loggerimpl.NewNopLogger())
+	start := time.Now()
+	s.NoError(client.EnqueueMessage(nil))
+	s.True(time.Since(start) >= 10*time.Millisecond)
+	s.Equal(1, queue.enqueued)
+}
@@ -70,6 +70,7 @@ type (
 		GetClusterMembers(request *GetClusterMembersRequest) (*GetClusterMembersResponse, error)
 		UpsertClusterMembership(request *UpsertClusterMembershipRequest) error
 		PruneClusterMembership(request *PruneClusterMembershipRequest) error
+		DeleteClusterMembership(request *DeleteClusterMembershipRequest) error
 	}
 
 	// ExecutionStore is used to manage workflow executions for Persistence layer
@@ -87,11 +88,15 @@ type (
 		DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error
 		DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error
 		GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error)
+		ListConcreteExecutions(request *ListConcreteExecutionsRequest) (*ListConcreteExecutionsResponse, error)
 
 		// Transfer task related methods
 		GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error)
 		CompleteTransferTask(request *CompleteTransferTaskRequest) error
 		RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error
+		PutTransferTaskToDLQ(request *PutTransferTaskToDLQRequest) error
+		GetTransferTasksFromDLQ(request *GetTransferTasksFromDLQRequest) (*GetTransferTasksFromDLQResponse, error)
+		DeleteTransferTaskFromDLQ(request *DeleteTransferTaskFromDLQRequest) error
 
 		// Replication task related methods
 		GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error)
@@ -104,6 +109,9 @@ type (
 		GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error)
 		CompleteTimerTask(request *CompleteTimerTaskRequest) error
 		RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error
+		PutTimerTaskToDLQ(request *PutTimerTaskToDLQRequest) error
+		GetTimerTasksFromDLQ(request *GetTimerTasksFromDLQRequest) (*GetTimerTasksFromDLQResponse, error)
+		DeleteTimerTaskFromDLQ(request *DeleteTimerTaskFromDLQRequest) error
 
 		// Remove corrupted task
 		DeleteTask(request *DeleteTaskRequest) error
@@ -250,6 +258,7 @@ type (
 		ExpirationSeconds  int32
 		Memo               map[string][]byte
 		SearchAttributes   map[string][]byte
+		StartingBuildID    string
 
 		// attributes which are not related to mutable state at all
 		HistorySize int64
@@ -625,6 +634,8 @@ type (
 		VisibilityArchivalStatus workflow.ArchivalStatus
 		VisibilityArchivalURI    string
 		BadBinaries              *DataBlob
+		SearchAttributes         map[string]string
+		WorkflowDefaults         *DataBlob
 	}
 
 	// InternalCreateDomainRequest is used to create the domain
@@ -21,12 +21,20 @@
 package persistence
 
 import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+
 	workflow "github.com/temporalio/temporal/.gen/go/shared"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/tracing"
 )
 
+const persistenceComponentName = "persistence"
+
 type (
 	shardPersistenceClient struct {
 		metricClient metrics.Client
@@ -38,6 +46,8 @@ type (
 		metricClient metrics.Client
 		persistence  ExecutionManager
 		logger       log.Logger
+		// ctx carries the span the operations are traced under
+		ctx context.Context
 	}
 
 	taskPersistenceClient struct {
@@ -50,6 +60,8 @@ type (
 		metricClient metrics.Client
 		persistence  HistoryManager
 		logger       log.Logger
+		// ctx carries the span the operations are traced under
+		ctx context.Context
 	}
 
 	metadataPersistenceClient struct {
@@ -101,7 +113,20 @@ func NewWorkflowExecutionPersistenceMetricsClient(persistence ExecutionManager,
 		persistence:  persistence,
 		metricClient: metricClient,
 		logger:       logger,
+		ctx:          context.Background(),
+	}
+}
+
+// ExecutionManagerWithContext returns a view of the execution manager which traces its operations
+// as children of the span carried by the context. The persistence interfaces don't take a context,
+// callers which have one use this to link the persistence spans to the request they serve.
+func ExecutionManagerWithContext(manager ExecutionManager, ctx context.Context) ExecutionManager {
+	if client, ok := manager.(*workflowExecutionPersistenceClient); ok {
+		withContext := *client
+		withContext.ctx = ctx
+		return &withContext
 	}
+	return manager
 }
 
 // NewTaskPersistenceMetricsClient creates a client to manage tasks
@@ -119,9 +144,21 @@ func NewHistoryV2PersistenceMetricsClient(persistence HistoryManager, metricClie
 		persistence:  persistence,
 		metricClient: metricClient,
 		logger:       logger,
+		ctx:          context.Background(),
 	}
 }
 
+// HistoryManagerWithContext returns a view of the history manager which traces its operations
+// as children of the span carried by the context
+func HistoryManagerWithContext(manager HistoryManager, ctx context.Context) HistoryManager {
+	if client, ok := manager.(*historyV2PersistenceClient); ok {
+		withContext := *client
+		withContext.ctx = ctx
+		return &withContext
+	}
+	return manager
+}
+
 // NewMetadataPersistenceMetricsClient creates a MetadataManager client to manage metadata
 func NewMetadataPersistenceMetricsClient(persistence MetadataManager, metricClient metrics.Client, logger log.Logger) MetadataManager {
 	return &metadataPersistenceClient{
@@ -165,9 +202,11 @@ func (p *shardPersistenceClient) GetName() string {
 func (p *shardPersistenceClient) CreateShard(request *CreateShardRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceCreateShardScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "CreateShard")
 	sw := p.metricClient.StartTimer(metrics.PersistenceCreateShardScope, metrics.PersistenceLatency)
 	err := p.persistence.CreateShard(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceCreateShardScope, err)
@@ -180,9 +219,11 @@ func (p *shardPersistenceClient) GetShard(
 	request *GetShardRequest) (*GetShardResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetShardScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "GetShard")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetShardScope, metrics.PersistenceLatency)
 	response, err := p.persistence.GetShard(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetShardScope, err)
@@ -194,9 +235,11 @@ func (p *shardPersistenceClient) GetShard(
 func (p *shardPersistenceClient) UpdateShard(request *UpdateShardRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceUpdateShardScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "UpdateShard")
 	sw := p.metricClient.StartTimer(metrics.PersistenceUpdateShardScope, metrics.PersistenceLatency)
 	err := p.persistence.UpdateShard(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceUpdateShardScope, err)
@@ -237,9 +280,11 @@ func (p *workflowExecutionPersistenceClient) GetShardID() int {
 func (p *workflowExecutionPersistenceClient) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceCreateWorkflowExecutionScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(p.ctx, "CreateWorkflowExecution")
 	sw := p.metricClient.StartTimer(metrics.PersistenceCreateWorkflowExecutionScope, metrics.PersistenceLatency)
 	response, err := p.persistence.CreateWorkflowExecution(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceCreateWorkflowExecutionScope, err)
@@ -251,9 +296,11 @@ func (p *workflowExecutionPersistenceClient) CreateWorkflowExecution(request *Cr
 func (p *workflowExecutionPersistenceClient) GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetWorkflowExecutionScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(p.ctx, "GetWorkflowExecution")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetWorkflowExecutionScope, metrics.PersistenceLatency)
 	response, err := p.persistence.GetWorkflowExecution(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetWorkflowExecutionScope, err)
@@ -265,9 +312,11 @@ func (p *workflowExecutionPersistenceClient) GetWorkflowExecution(request *GetWo
 func (p *workflowExecutionPersistenceClient) UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceUpdateWorkflowExecutionScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(p.ctx, "UpdateWorkflowExecution")
 	sw := p.metricClient.StartTimer(metrics.PersistenceUpdateWorkflowExecutionScope, metrics.PersistenceLatency)
 	resp, err := p.persistence.UpdateWorkflowExecution(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceUpdateWorkflowExecutionScope, err)
@@ -279,9 +328,11 @@ func (p *workflowExecutionPersistenceClient) UpdateWorkflowExecution(request *Up
 func (p *workflowExecutionPersistenceClient) ConflictResolveWorkflowExecution(request *ConflictResolveWorkflowExecutionRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceConflictResolveWorkflowExecutionScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(p.ctx, "ConflictResolveWorkflowExecution")
 	sw := p.metricClient.StartTimer(metrics.PersistenceConflictResolveWorkflowExecutionScope, metrics.PersistenceLatency)
 	err := p.persistence.ConflictResolveWorkflowExecution(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceConflictResolveWorkflowExecutionScope, err)
@@ -293,9 +344,11 @@ func (p *workflowExecutionPersistenceClient) ConflictResolveWorkflowExecution(re
 func (p *workflowExecutionPersistenceClient) ResetWorkflowExecution(request *ResetWorkflowExecutionRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceResetWorkflowExecutionScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(p.ctx, "ResetWorkflowExecution")
 	sw := p.metricClient.StartTimer(metrics.PersistenceResetWorkflowExecutionScope, metrics.PersistenceLatency)
 	err := p.persistence.ResetWorkflowExecution(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceResetWorkflowExecutionScope, err)
@@ -307,9 +360,11 @@ func (p *workflowExecutionPersistenceClient) ResetWorkflowExecution(request *Res
 func (p *workflowExecutionPersistenceClient) DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceDeleteWorkflowExecutionScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(p.ctx, "DeleteWorkflowExecution")
 	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteWorkflowExecutionScope, metrics.PersistenceLatency)
 	err := p.persistence.DeleteWorkflowExecution(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceDeleteWorkflowExecutionScope, err)
@@ -321,9 +376,11 @@ func (p *workflowExecutionPersistenceClient) DeleteWorkflowExecution(request *De
 func (p *workflowExecutionPersistenceClient) DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceDeleteCurrentWorkflowExecutionScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(p.ctx, "DeleteCurrentWorkflowExecution")
 	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteCurrentWorkflowExecutionScope, metrics.PersistenceLatency)
 	err := p.persistence.DeleteCurrentWorkflowExecution(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceDeleteCurrentWorkflowExecutionScope, err)
@@ -335,9 +392,11 @@ func (p *workflowExecutionPersistenceClient) DeleteCurrentWorkflowExecution(requ
 func (p *workflowExecutionPersistenceClient) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetCurrentExecutionScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(p.ctx, "GetCurrentExecution")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetCurrentExecutionScope, metrics.PersistenceLatency)
 	response, err := p.persistence.GetCurrentExecution(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetCurrentExecutionScope, err)
@@ -346,12 +405,30 @@ func (p *workflowExecutionPersistenceClient) GetCurrentExecution(request *GetCur
 	return response, err
 }
 
+func (p *workflowExecutionPersistenceClient) ListConcreteExecutions(request *ListConcreteExecutionsRequest) (*ListConcreteExecutionsResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceListConcreteExecutionsScope, metrics.PersistenceRequests)
+
+	span := startPersistenceSpan(p.ctx, "ListConcreteExecutions")
+	sw := p.metricClient.StartTimer(metrics.PersistenceListConcreteExecutionsScope, metrics.PersistenceLatency)
+	response, err := p.persistence.ListConcreteExecutions(request)
+	sw.Stop()
+	finishPersistenceSpan(span, err)
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceListConcreteExecutionsScope, err)
+	}
+
+	return response, err
+}
+
 func (p *workflowExecutionPersistenceClient) GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetTransferTasksScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(p.ctx, "GetTransferTasks")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetTransferTasksScope, metrics.PersistenceLatency)
 	response, err := p.persistence.GetTransferTasks(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetTransferTasksScope, err)
@@ -363,9 +440,11 @@ func (p *workflowExecutionPersistenceClient) GetTransferTasks(request *GetTransf
 func (p *workflowExecutionPersistenceClient) GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetReplicationTasksScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(p.ctx, "GetReplicationTasks")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetReplicationTasksScope, metrics.PersistenceLatency)
 	response, err := p.persistence.GetReplicationTasks(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetReplicationTasksScope, err)
@@ -377,9 +456,11 @@ func (p *workflowExecutionPersistenceClient) GetReplicationTasks(request *GetRep
 func (p *workflowExecutionPersistenceClient) CompleteTransferTask(request *CompleteTransferTaskRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceCompleteTransferTaskScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(p.ctx, "CompleteTransferTask")
 	sw := p.metricClient.StartTimer(metrics.PersistenceCompleteTransferTaskScope, metrics.PersistenceLatency)
 	err := p.persistence.CompleteTransferTask(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceCompleteTransferTaskScope, err)
@@ -391,9 +472,11 @@ func (p *workflowExecutionPersistenceClient) CompleteTransferTask(request *Compl
 func (p *workflowExecutionPersistenceClient) RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceRangeCompleteTransferTaskScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(p.ctx, "RangeCompleteTransferTask")
 	sw := p.metricClient.StartTimer(metrics.PersistenceRangeCompleteTransferTaskScope, metrics.PersistenceLatency)
 	err := p.persistence.RangeCompleteTransferTask(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceRangeCompleteTransferTaskScope, err)
@@ -402,12 +485,68 @@ func (p *workflowExecutionPersistenceClient) RangeCompleteTransferTask(request *
 	return err
 }
 
+func (p *workflowExecutionPersistenceClient) PutTransferTaskToDLQ(
+	request *PutTransferTaskToDLQRequest,
+) error {
+	p.metricClient.IncCounter(metrics.PersistencePutTransferTaskToDLQScope, metrics.PersistenceRequests)
+
+	span := startPersistenceSpan(p.ctx, "PutTransferTaskToDLQ")
+	sw := p.metricClient.StartTimer(metrics.PersistencePutTransferTaskToDLQScope, metrics.PersistenceLatency)
+	err := p.persistence.PutTransferTaskToDLQ(request)
+	sw.Stop()
+	finishPersistenceSpan(span, err)
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistencePutTransferTaskToDLQScope, err)
+	}
+
+	return err
+}
+
+func (p *workflowExecutionPersistenceClient) GetTransferTasksFromDLQ(
+	request *GetTransferTasksFromDLQRequest,
+) (*GetTransferTasksFromDLQResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceGetTransferTasksFromDLQScope, metrics.PersistenceRequests)
+
+	span := startPersistenceSpan(p.ctx, "GetTransferTasksFromDLQ")
+	sw := p.metricClient.StartTimer(metrics.PersistenceGetTransferTasksFromDLQScope, metrics.PersistenceLatency)
+	response, err := p.persistence.GetTransferTasksFromDLQ(request)
+	sw.Stop()
+	finishPersistenceSpan(span, err)
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceGetTransferTasksFromDLQScope, err)
+	}
+
+	return response, err
+}
+
+func (p *workflowExecutionPersistenceClient) DeleteTransferTaskFromDLQ(
+	request *DeleteTransferTaskFromDLQRequest,
+) error {
+	p.metricClient.IncCounter(metrics.PersistenceDeleteTransferTaskFromDLQScope, metrics.PersistenceRequests)
+
+	span := startPersistenceSpan(p.ctx, "DeleteTransferTaskFromDLQ")
+	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteTransferTaskFromDLQScope, metrics.PersistenceLatency)
+	err := p.persistence.DeleteTransferTaskFromDLQ(request)
+	sw.Stop()
+	finishPersistenceSpan(span, err)
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceDeleteTransferTaskFromDLQScope, err)
+	}
+
+	return err
+}
+
 func (p *workflowExecutionPersistenceClient) CompleteReplicationTask(request *CompleteReplicationTaskRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceCompleteReplicationTaskScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(p.ctx, "CompleteReplicationTask")
 	sw := p.metricClient.StartTimer(metrics.PersistenceCompleteReplicationTaskScope, metrics.PersistenceLatency)
 	err := p.persistence.CompleteReplicationTask(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceCompleteReplicationTaskScope, err)
@@ -419,9 +558,11 @@ func (p *workflowExecutionPersistenceClient) CompleteReplicationTask(request *Co
 func (p *workflowExecutionPersistenceClient) RangeCompleteReplicationTask(request *RangeCompleteReplicationTaskRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceRangeCompleteReplicationTaskScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(p.ctx, "RangeCompleteReplicationTask")
 	sw := p.metricClient.StartTimer(metrics.PersistenceRangeCompleteReplicationTaskScope, metrics.PersistenceLatency)
 	err := p.persistence.RangeCompleteReplicationTask(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceRangeCompleteReplicationTaskScope, err)
@@ -435,9 +576,11 @@ func (p *workflowExecutionPersistenceClient) PutReplicationTaskToDLQ(
 ) error {
 	p.metricClient.IncCounter(metrics.PersistencePutReplicationTaskToDLQScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(p.ctx, "PutReplicationTaskToDLQ")
 	sw := p.metricClient.StartTimer(metrics.PersistencePutReplicationTaskToDLQScope, metrics.PersistenceLatency)
 	err := p.persistence.PutReplicationTaskToDLQ(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistencePutReplicationTaskToDLQScope, err)
@@ -451,9 +594,11 @@ func (p *workflowExecutionPersistenceClient) GetReplicationTasksFromDLQ(
 ) (*GetReplicationTasksFromDLQResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetReplicationTasksFromDLQScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(p.ctx, "GetReplicationTasksFromDLQ")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetReplicationTasksFromDLQScope, metrics.PersistenceLatency)
 	response, err := p.persistence.GetReplicationTasksFromDLQ(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetReplicationTasksFromDLQScope, err)
@@ -465,9 +610,11 @@ func (p *workflowExecutionPersistenceClient) GetReplicationTasksFromDLQ(
 func (p *workflowExecutionPersistenceClient) GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetTimerIndexTasksScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(p.ctx, "GetTimerIndexTasks")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetTimerIndexTasksScope, metrics.PersistenceLatency)
 	response, err := p.persistence.GetTimerIndexTasks(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetTimerIndexTasksScope, err)
@@ -479,9 +626,11 @@ func (p *workflowExecutionPersistenceClient) GetTimerIndexTasks(request *GetTime
 func (p *workflowExecutionPersistenceClient) CompleteTimerTask(request *CompleteTimerTaskRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceCompleteTimerTaskScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(p.ctx, "CompleteTimerTask")
 	sw := p.metricClient.StartTimer(metrics.PersistenceCompleteTimerTaskScope, metrics.PersistenceLatency)
 	err := p.persistence.CompleteTimerTask(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceCompleteTimerTaskScope, err)
@@ -493,9 +642,11 @@ func (p *workflowExecutionPersistenceClient) CompleteTimerTask(request *Complete
 func (p *workflowExecutionPersistenceClient) RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceRangeCompleteTimerTaskScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(p.ctx, "RangeCompleteTimerTask")
 	sw := p.metricClient.StartTimer(metrics.PersistenceRangeCompleteTimerTaskScope, metrics.PersistenceLatency)
 	err := p.persistence.RangeCompleteTimerTask(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceRangeCompleteTimerTaskScope, err)
@@ -504,11 +655,67 @@ func (p *workflowExecutionPersistenceClient) RangeCompleteTimerTask(request *Ran
 	return err
 }
 
+func (p *workflowExecutionPersistenceClient) PutTimerTaskToDLQ(
+	request *PutTimerTaskToDLQRequest,
+) error {
+	p.metricClient.IncCounter(metrics.PersistencePutTimerTaskToDLQScope, metrics.PersistenceRequests)
+
+	span := startPersistenceSpan(p.ctx, "PutTimerTaskToDLQ")
+	sw := p.metricClient.StartTimer(metrics.PersistencePutTimerTaskToDLQScope, metrics.PersistenceLatency)
+	err := p.persistence.PutTimerTaskToDLQ(request)
+	sw.Stop()
+	finishPersistenceSpan(span, err)
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistencePutTimerTaskToDLQScope, err)
+	}
+
+	return err
+}
+
+func (p *workflowExecutionPersistenceClient) GetTimerTasksFromDLQ(
+	request *GetTimerTasksFromDLQRequest,
+) (*GetTimerTasksFromDLQResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceGetTimerTasksFromDLQScope, metrics.PersistenceRequests)
+
+	span := startPersistenceSpan(p.ctx, "GetTimerTasksFromDLQ")
+	sw := p.metricClient.StartTimer(metrics.PersistenceGetTimerTasksFromDLQScope, metrics.PersistenceLatency)
+	response, err := p.persistence.GetTimerTasksFromDLQ(request)
+	sw.Stop()
+	finishPersistenceSpan(span, err)
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceGetTimerTasksFromDLQScope, err)
+	}
+
+	return response, err
+}
+
+func (p *workflowExecutionPersistenceClient) DeleteTimerTaskFromDLQ(
+	request *DeleteTimerTaskFromDLQRequest,
+) error {
+	p.metricClient.IncCounter(metrics.PersistenceDeleteTimerTaskFromDLQScope, metrics.PersistenceRequests)
+
+	span := startPersistenceSpan(p.ctx, "DeleteTimerTaskFromDLQ")
+	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteTimerTaskFromDLQScope, metrics.PersistenceLatency)
+	err := p.persistence.DeleteTimerTaskFromDLQ(request)
+	sw.Stop()
+	finishPersistenceSpan(span, err)
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceDeleteTimerTaskFromDLQScope, err)
+	}
+
+	return err
+}
+
 func (p *workflowExecutionPersistenceClient) DeleteTask(request *DeleteTaskRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceDeleteTaskScope, metrics.PersistenceRequests)
+	span := startPersistenceSpan(p.ctx, "DeleteTask")
 	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteTaskScope, metrics.PersistenceLatency)
 	err := p.persistence.DeleteTask(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceRangeCompleteTimerTaskScope, err)
@@ -553,9 +760,11 @@ func (p *taskPersistenceClient) GetName() string {
 func (p *taskPersistenceClient) CreateTasks(request *CreateTasksRequest) (*CreateTasksResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceCreateTaskScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "CreateTasks")
 	sw := p.metricClient.StartTimer(metrics.PersistenceCreateTaskScope, metrics.PersistenceLatency)
 	response, err := p.persistence.CreateTasks(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceCreateTaskScope, err)
@@ -567,9 +776,11 @@ func (p *taskPersistenceClient) CreateTasks(request *CreateTasksRequest) (*Creat
 func (p *taskPersistenceClient) GetTasks(request *GetTasksRequest) (*GetTasksResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetTasksScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "GetTasks")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetTasksScope, metrics.PersistenceLatency)
 	response, err := p.persistence.GetTasks(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetTasksScope, err)
@@ -581,9 +792,11 @@ func (p *taskPersistenceClient) GetTasks(request *GetTasksRequest) (*GetTasksRes
 func (p *taskPersistenceClient) CompleteTask(request *CompleteTaskRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceCompleteTaskScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "CompleteTask")
 	sw := p.metricClient.StartTimer(metrics.PersistenceCompleteTaskScope, metrics.PersistenceLatency)
 	err := p.persistence.CompleteTask(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceCompleteTaskScope, err)
@@ -594,9 +807,11 @@ func (p *taskPersistenceClient) CompleteTask(request *CompleteTaskRequest) error
 
 func (p *taskPersistenceClient) CompleteTasksLessThan(request *CompleteTasksLessThanRequest) (int, error) {
 	p.metricClient.IncCounter(metrics.PersistenceCompleteTasksLessThanScope, metrics.PersistenceRequests)
+	span := startPersistenceSpan(context.Background(), "CompleteTasksLessThan")
 	sw := p.metricClient.StartTimer(metrics.PersistenceCompleteTasksLessThanScope, metrics.PersistenceLatency)
 	result, err := p.persistence.CompleteTasksLessThan(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceCompleteTasksLessThanScope, err)
 	}
@@ -606,9 +821,11 @@ func (p *taskPersistenceClient) CompleteTasksLessThan(request *CompleteTasksLess
 func (p *taskPersistenceClient) LeaseTaskList(request *LeaseTaskListRequest) (*LeaseTaskListResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceLeaseTaskListScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "LeaseTaskList")
 	sw := p.metricClient.StartTimer(metrics.PersistenceLeaseTaskListScope, metrics.PersistenceLatency)
 	response, err := p.persistence.LeaseTaskList(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceLeaseTaskListScope, err)
@@ -619,9 +836,11 @@ func (p *taskPersistenceClient) LeaseTaskList(request *LeaseTaskListRequest) (*L
 
 func (p *taskPersistenceClient) ListTaskList(request *ListTaskListRequest) (*ListTaskListResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceListTaskListScope, metrics.PersistenceRequests)
+	span := startPersistenceSpan(context.Background(), "ListTaskList")
 	sw := p.metricClient.StartTimer(metrics.PersistenceListTaskListScope, metrics.PersistenceLatency)
 	response, err := p.persistence.ListTaskList(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceListTaskListScope, err)
 	}
@@ -630,9 +849,11 @@ func (p *taskPersistenceClient) ListTaskList(request *ListTaskListRequest) (*Lis
 
 func (p *taskPersistenceClient) DeleteTaskList(request *DeleteTaskListRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceDeleteTaskListScope, metrics.PersistenceRequests)
+	span := startPersistenceSpan(context.Background(), "DeleteTaskList")
 	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteTaskListScope, metrics.PersistenceLatency)
 	err := p.persistence.DeleteTaskList(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceDeleteTaskListScope, err)
 	}
@@ -642,9 +863,11 @@ func (p *taskPersistenceClient) DeleteTaskList(request *DeleteTaskListRequest) e
 func (p *taskPersistenceClient) UpdateTaskList(request *UpdateTaskListRequest) (*UpdateTaskListResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceUpdateTaskListScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "UpdateTaskList")
 	sw := p.metricClient.StartTimer(metrics.PersistenceUpdateTaskListScope, metrics.PersistenceLatency)
 	response, err := p.persistence.UpdateTaskList(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceUpdateTaskListScope, err)
@@ -681,9 +904,11 @@ func (p *metadataPersistenceClient) GetName() string {
 func (p *metadataPersistenceClient) CreateDomain(request *CreateDomainRequest) (*CreateDomainResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceCreateDomainScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "CreateDomain")
 	sw := p.metricClient.StartTimer(metrics.PersistenceCreateDomainScope, metrics.PersistenceLatency)
 	response, err := p.persistence.CreateDomain(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceCreateDomainScope, err)
@@ -695,9 +920,11 @@ func (p *metadataPersistenceClient) CreateDomain(request *CreateDomainRequest) (
 func (p *metadataPersistenceClient) GetDomain(request *GetDomainRequest) (*GetDomainResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetDomainScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "GetDomain")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetDomainScope, metrics.PersistenceLatency)
 	response, err := p.persistence.GetDomain(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetDomainScope, err)
@@ -709,9 +936,11 @@ func (p *metadataPersistenceClient) GetDomain(request *GetDomainRequest) (*GetDo
 func (p *metadataPersistenceClient) UpdateDomain(request *UpdateDomainRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceUpdateDomainScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "UpdateDomain")
 	sw := p.metricClient.StartTimer(metrics.PersistenceUpdateDomainScope, metrics.PersistenceLatency)
 	err := p.persistence.UpdateDomain(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceUpdateDomainScope, err)
@@ -723,9 +952,11 @@ func (p *metadataPersistenceClient) UpdateDomain(request *UpdateDomainRequest) e
 func (p *metadataPersistenceClient) DeleteDomain(request *DeleteDomainRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceDeleteDomainScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "DeleteDomain")
 	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteDomainScope, metrics.PersistenceLatency)
 	err := p.persistence.DeleteDomain(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceDeleteDomainScope, err)
@@ -737,9 +968,11 @@ func (p *metadataPersistenceClient) DeleteDomain(request *DeleteDomainRequest) e
 func (p *metadataPersistenceClient) DeleteDomainByName(request *DeleteDomainByNameRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceDeleteDomainByNameScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "DeleteDomainByName")
 	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteDomainByNameScope, metrics.PersistenceLatency)
 	err := p.persistence.DeleteDomainByName(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceDeleteDomainByNameScope, err)
@@ -751,9 +984,11 @@ func (p *metadataPersistenceClient) DeleteDomainByName(request *DeleteDomainByNa
 func (p *metadataPersistenceClient) ListDomains(request *ListDomainsRequest) (*ListDomainsResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceListDomainScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "ListDomains")
 	sw := p.metricClient.StartTimer(metrics.PersistenceListDomainScope, metrics.PersistenceLatency)
 	response, err := p.persistence.ListDomains(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceListDomainScope, err)
@@ -765,9 +1000,11 @@ func (p *metadataPersistenceClient) ListDomains(request *ListDomainsRequest) (*L
 func (p *metadataPersistenceClient) GetMetadata() (*GetMetadataResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetMetadataScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "GetMetadata")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetMetadataScope, metrics.PersistenceLatency)
 	response, err := p.persistence.GetMetadata()
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetMetadataScope, err)
@@ -805,9 +1042,11 @@ func (p *visibilityPersistenceClient) GetName() string {
 func (p *visibilityPersistenceClient) RecordWorkflowExecutionStarted(request *RecordWorkflowExecutionStartedRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceRecordWorkflowExecutionStartedScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "RecordWorkflowExecutionStarted")
 	sw := p.metricClient.StartTimer(metrics.PersistenceRecordWorkflowExecutionStartedScope, metrics.PersistenceLatency)
 	err := p.persistence.RecordWorkflowExecutionStarted(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceRecordWorkflowExecutionStartedScope, err)
@@ -819,9 +1058,11 @@ func (p *visibilityPersistenceClient) RecordWorkflowExecutionStarted(request *Re
 func (p *visibilityPersistenceClient) RecordWorkflowExecutionClosed(request *RecordWorkflowExecutionClosedRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceRecordWorkflowExecutionClosedScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "RecordWorkflowExecutionClosed")
 	sw := p.metricClient.StartTimer(metrics.PersistenceRecordWorkflowExecutionClosedScope, metrics.PersistenceLatency)
 	err := p.persistence.RecordWorkflowExecutionClosed(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceRecordWorkflowExecutionClosedScope, err)
@@ -833,9 +1074,11 @@ func (p *visibilityPersistenceClient) RecordWorkflowExecutionClosed(request *Rec
 func (p *visibilityPersistenceClient) UpsertWorkflowExecution(request *UpsertWorkflowExecutionRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceUpsertWorkflowExecutionScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "UpsertWorkflowExecution")
 	sw := p.metricClient.StartTimer(metrics.PersistenceUpsertWorkflowExecutionScope, metrics.PersistenceLatency)
 	err := p.persistence.UpsertWorkflowExecution(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceUpsertWorkflowExecutionScope, err)
@@ -847,9 +1090,11 @@ func (p *visibilityPersistenceClient) UpsertWorkflowExecution(request *UpsertWor
 func (p *visibilityPersistenceClient) ListOpenWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceListOpenWorkflowExecutionsScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "ListOpenWorkflowExecutions")
 	sw := p.metricClient.StartTimer(metrics.PersistenceListOpenWorkflowExecutionsScope, metrics.PersistenceLatency)
 	response, err := p.persistence.ListOpenWorkflowExecutions(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceListOpenWorkflowExecutionsScope, err)
@@ -861,9 +1106,11 @@ func (p *visibilityPersistenceClient) ListOpenWorkflowExecutions(request *ListWo
 func (p *visibilityPersistenceClient) ListClosedWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceListClosedWorkflowExecutionsScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "ListClosedWorkflowExecutions")
 	sw := p.metricClient.StartTimer(metrics.PersistenceListClosedWorkflowExecutionsScope, metrics.PersistenceLatency)
 	response, err := p.persistence.ListClosedWorkflowExecutions(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceListClosedWorkflowExecutionsScope, err)
@@ -875,9 +1122,11 @@ func (p *visibilityPersistenceClient) ListClosedWorkflowExecutions(request *List
 func (p *visibilityPersistenceClient) ListOpenWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceListOpenWorkflowExecutionsByTypeScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "ListOpenWorkflowExecutionsByType")
 	sw := p.metricClient.StartTimer(metrics.PersistenceListOpenWorkflowExecutionsByTypeScope, metrics.PersistenceLatency)
 	response, err := p.persistence.ListOpenWorkflowExecutionsByType(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceListOpenWorkflowExecutionsByTypeScope, err)
@@ -889,9 +1138,11 @@ func (p *visibilityPersistenceClient) ListOpenWorkflowExecutionsByType(request *
 func (p *visibilityPersistenceClient) ListClosedWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceListClosedWorkflowExecutionsByTypeScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "ListClosedWorkflowExecutionsByType")
 	sw := p.metricClient.StartTimer(metrics.PersistenceListClosedWorkflowExecutionsByTypeScope, metrics.PersistenceLatency)
 	response, err := p.persistence.ListClosedWorkflowExecutionsByType(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceListClosedWorkflowExecutionsByTypeScope, err)
@@ -903,9 +1154,11 @@ func (p *visibilityPersistenceClient) ListClosedWorkflowExecutionsByType(request
 func (p *visibilityPersistenceClient) ListOpenWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceListOpenWorkflowExecutionsByWorkflowIDScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "ListOpenWorkflowExecutionsByWorkflowID")
 	sw := p.metricClient.StartTimer(metrics.PersistenceListOpenWorkflowExecutionsByWorkflowIDScope, metrics.PersistenceLatency)
 	response, err := p.persistence.ListOpenWorkflowExecutionsByWorkflowID(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceListOpenWorkflowExecutionsByWorkflowIDScope, err)
@@ -917,9 +1170,11 @@ func (p *visibilityPersistenceClient) ListOpenWorkflowExecutionsByWorkflowID(req
 func (p *visibilityPersistenceClient) ListClosedWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceListClosedWorkflowExecutionsByWorkflowIDScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "ListClosedWorkflowExecutionsByWorkflowID")
 	sw := p.metricClient.StartTimer(metrics.PersistenceListClosedWorkflowExecutionsByWorkflowIDScope, metrics.PersistenceLatency)
 	response, err := p.persistence.ListClosedWorkflowExecutionsByWorkflowID(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceListClosedWorkflowExecutionsByWorkflowIDScope, err)
@@ -931,9 +1186,11 @@ func (p *visibilityPersistenceClient) ListClosedWorkflowExecutionsByWorkflowID(r
 func (p *visibilityPersistenceClient) ListClosedWorkflowExecutionsByStatus(request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceListClosedWorkflowExecutionsByStatusScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "ListClosedWorkflowExecutionsByStatus")
 	sw := p.metricClient.StartTimer(metrics.PersistenceListClosedWorkflowExecutionsByStatusScope, metrics.PersistenceLatency)
 	response, err := p.persistence.ListClosedWorkflowExecutionsByStatus(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceListClosedWorkflowExecutionsByStatusScope, err)
@@ -945,9 +1202,11 @@ func (p *visibilityPersistenceClient) ListClosedWorkflowExecutionsByStatus(reque
 func (p *visibilityPersistenceClient) GetClosedWorkflowExecution(request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetClosedWorkflowExecutionScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "GetClosedWorkflowExecution")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetClosedWorkflowExecutionScope, metrics.PersistenceLatency)
 	response, err := p.persistence.GetClosedWorkflowExecution(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetClosedWorkflowExecutionScope, err)
@@ -959,9 +1218,11 @@ func (p *visibilityPersistenceClient) GetClosedWorkflowExecution(request *GetClo
 func (p *visibilityPersistenceClient) DeleteWorkflowExecution(request *VisibilityDeleteWorkflowExecutionRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceVisibilityDeleteWorkflowExecutionScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "DeleteWorkflowExecution")
 	sw := p.metricClient.StartTimer(metrics.PersistenceVisibilityDeleteWorkflowExecutionScope, metrics.PersistenceLatency)
 	err := p.persistence.DeleteWorkflowExecution(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceVisibilityDeleteWorkflowExecutionScope, err)
@@ -973,9 +1234,11 @@ func (p *visibilityPersistenceClient) DeleteWorkflowExecution(request *Visibilit
 func (p *visibilityPersistenceClient) ListWorkflowExecutions(request *ListWorkflowExecutionsRequestV2) (*ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceListWorkflowExecutionsScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "ListWorkflowExecutions")
 	sw := p.metricClient.StartTimer(metrics.PersistenceListWorkflowExecutionsScope, metrics.PersistenceLatency)
 	response, err := p.persistence.ListWorkflowExecutions(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceListWorkflowExecutionsScope, err)
@@ -987,9 +1250,11 @@ func (p *visibilityPersistenceClient) ListWorkflowExecutions(request *ListWorkfl
 func (p *visibilityPersistenceClient) ScanWorkflowExecutions(request *ListWorkflowExecutionsRequestV2) (*ListWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceScanWorkflowExecutionsScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "ScanWorkflowExecutions")
 	sw := p.metricClient.StartTimer(metrics.PersistenceScanWorkflowExecutionsScope, metrics.PersistenceLatency)
 	response, err := p.persistence.ScanWorkflowExecutions(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceScanWorkflowExecutionsScope, err)
@@ -1001,9 +1266,11 @@ func (p *visibilityPersistenceClient) ScanWorkflowExecutions(request *ListWorkfl
 func (p *visibilityPersistenceClient) CountWorkflowExecutions(request *CountWorkflowExecutionsRequest) (*CountWorkflowExecutionsResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceCountWorkflowExecutionsScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "CountWorkflowExecutions")
 	sw := p.metricClient.StartTimer(metrics.PersistenceCountWorkflowExecutionsScope, metrics.PersistenceLatency)
 	response, err := p.persistence.CountWorkflowExecutions(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceCountWorkflowExecutionsScope, err)
@@ -1046,9 +1313,11 @@ func (p *historyV2PersistenceClient) Close() {
 // AppendHistoryNodes add(or override) a node to a history branch
 func (p *historyV2PersistenceClient) AppendHistoryNodes(request *AppendHistoryNodesRequest) (*AppendHistoryNodesResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceAppendHistoryNodesScope, metrics.PersistenceRequests)
+	span := startPersistenceSpan(p.ctx, "AppendHistoryNodes")
 	sw := p.metricClient.StartTimer(metrics.PersistenceAppendHistoryNodesScope, metrics.PersistenceLatency)
 	resp, err := p.persistence.AppendHistoryNodes(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceAppendHistoryNodesScope, err)
 	}
@@ -1058,9 +1327,11 @@ func (p *historyV2PersistenceClient) AppendHistoryNodes(request *AppendHistoryNo
 // ReadHistoryBranch returns history node data for a branch
 func (p *historyV2PersistenceClient) ReadHistoryBranch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceReadHistoryBranchScope, metrics.PersistenceRequests)
+	span := startPersistenceSpan(p.ctx, "ReadHistoryBranch")
 	sw := p.metricClient.StartTimer(metrics.PersistenceReadHistoryBranchScope, metrics.PersistenceLatency)
 	response, err := p.persistence.ReadHistoryBranch(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceReadHistoryBranchScope, err)
 	}
@@ -1070,9 +1341,11 @@ func (p *historyV2PersistenceClient) ReadHistoryBranch(request *ReadHistoryBranc
 // ReadHistoryBranchByBatch returns history node data for a branch ByBatch
 func (p *historyV2PersistenceClient) ReadHistoryBranchByBatch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchByBatchResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceReadHistoryBranchScope, metrics.PersistenceRequests)
+	span := startPersistenceSpan(p.ctx, "ReadHistoryBranchByBatch")
 	sw := p.metricClient.StartTimer(metrics.PersistenceReadHistoryBranchScope, metrics.PersistenceLatency)
 	response, err := p.persistence.ReadHistoryBranchByBatch(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceReadHistoryBranchScope, err)
 	}
@@ -1082,9 +1355,11 @@ func (p *historyV2PersistenceClient) ReadHistoryBranchByBatch(request *ReadHisto
 // ReadRawHistoryBranch returns history node raw data for a branch ByBatch
 func (p *historyV2PersistenceClient) ReadRawHistoryBranch(request *ReadHistoryBranchRequest) (*ReadRawHistoryBranchResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceReadHistoryBranchScope, metrics.PersistenceRequests)
+	span := startPersistenceSpan(p.ctx, "ReadRawHistoryBranch")
 	sw := p.metricClient.StartTimer(metrics.PersistenceReadHistoryBranchScope, metrics.PersistenceLatency)
 	response, err := p.persistence.ReadRawHistoryBranch(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceReadHistoryBranchScope, err)
 	}
@@ -1094,9 +1369,11 @@ func (p *historyV2PersistenceClient) ReadRawHistoryBranch(request *ReadHistoryBr
 // ForkHistoryBranch forks a new branch from a old branch
 func (p *historyV2PersistenceClient) ForkHistoryBranch(request *ForkHistoryBranchRequest) (*ForkHistoryBranchResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceForkHistoryBranchScope, metrics.PersistenceRequests)
+	span := startPersistenceSpan(p.ctx, "ForkHistoryBranch")
 	sw := p.metricClient.StartTimer(metrics.PersistenceForkHistoryBranchScope, metrics.PersistenceLatency)
 	response, err := p.persistence.ForkHistoryBranch(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceForkHistoryBranchScope, err)
 	}
@@ -1106,9 +1383,11 @@ func (p *historyV2PersistenceClient) ForkHistoryBranch(request *ForkHistoryBranc
 // DeleteHistoryBranch removes a branch
 func (p *historyV2PersistenceClient) DeleteHistoryBranch(request *DeleteHistoryBranchRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceDeleteHistoryBranchScope, metrics.PersistenceRequests)
+	span := startPersistenceSpan(p.ctx, "DeleteHistoryBranch")
 	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteHistoryBranchScope, metrics.PersistenceLatency)
 	err := p.persistence.DeleteHistoryBranch(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceDeleteHistoryBranchScope, err)
 	}
@@ -1117,9 +1396,11 @@ func (p *historyV2PersistenceClient) DeleteHistoryBranch(request *DeleteHistoryB
 
 func (p *historyV2PersistenceClient) GetAllHistoryTreeBranches(request *GetAllHistoryTreeBranchesRequest) (*GetAllHistoryTreeBranchesResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetAllHistoryTreeBranchesScope, metrics.PersistenceRequests)
+	span := startPersistenceSpan(p.ctx, "GetAllHistoryTreeBranches")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetAllHistoryTreeBranchesScope, metrics.PersistenceLatency)
 	response, err := p.persistence.GetAllHistoryTreeBranches(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetAllHistoryTreeBranchesScope, err)
 	}
@@ -1129,9 +1410,11 @@ func (p *historyV2PersistenceClient) GetAllHistoryTreeBranches(request *GetAllHi
 // GetHistoryTree returns all branch information of a tree
 func (p *historyV2PersistenceClient) GetHistoryTree(request *GetHistoryTreeRequest) (*GetHistoryTreeResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetHistoryTreeScope, metrics.PersistenceRequests)
+	span := startPersistenceSpan(p.ctx, "GetHistoryTree")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetHistoryTreeScope, metrics.PersistenceLatency)
 	response, err := p.persistence.GetHistoryTree(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceGetHistoryTreeScope, err)
 	}
@@ -1160,9 +1443,11 @@ func (p *historyV2PersistenceClient) updateErrorMetric(scope int, err error) {
 func (p *queuePersistenceClient) EnqueueMessage(message []byte) error {
 	p.metricClient.IncCounter(metrics.PersistenceEnqueueMessageScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "EnqueueMessage")
 	sw := p.metricClient.StartTimer(metrics.PersistenceEnqueueMessageScope, metrics.PersistenceLatency)
 	err := p.persistence.EnqueueMessage(message)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.metricClient.IncCounter(metrics.PersistenceEnqueueMessageScope, metrics.PersistenceFailures)
@@ -1174,9 +1459,11 @@ func (p *queuePersistenceClient) EnqueueMessage(message []byte) error {
 func (p *queuePersistenceClient) ReadMessages(lastMessageID int, maxCount int) ([]*QueueMessage, error) {
 	p.metricClient.IncCounter(metrics.PersistenceReadQueueMessagesScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "ReadMessages")
 	sw := p.metricClient.StartTimer(metrics.PersistenceReadQueueMessagesScope, metrics.PersistenceLatency)
 	result, err := p.persistence.ReadMessages(lastMessageID, maxCount)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.metricClient.IncCounter(metrics.PersistenceReadQueueMessagesScope, metrics.PersistenceFailures)
@@ -1188,9 +1475,11 @@ func (p *queuePersistenceClient) ReadMessages(lastMessageID int, maxCount int) (
 func (p *queuePersistenceClient) UpdateAckLevel(messageID int, clusterName string) error {
 	p.metricClient.IncCounter(metrics.PersistenceUpdateAckLevelScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "UpdateAckLevel")
 	sw := p.metricClient.StartTimer(metrics.PersistenceUpdateAckLevelScope, metrics.PersistenceLatency)
 	err := p.persistence.UpdateAckLevel(messageID, clusterName)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.metricClient.IncCounter(metrics.PersistenceUpdateAckLevelScope, metrics.PersistenceFailures)
@@ -1202,9 +1491,11 @@ func (p *queuePersistenceClient) UpdateAckLevel(messageID int, clusterName strin
 func (p *queuePersistenceClient) GetAckLevels() (map[string]int, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetAckLevelScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "GetAckLevels")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetAckLevelScope, metrics.PersistenceLatency)
 	result, err := p.persistence.GetAckLevels()
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.metricClient.IncCounter(metrics.PersistenceGetAckLevelScope, metrics.PersistenceFailures)
@@ -1216,9 +1507,11 @@ func (p *queuePersistenceClient) GetAckLevels() (map[string]int, error) {
 func (p *queuePersistenceClient) DeleteMessagesBefore(messageID int) error {
 	p.metricClient.IncCounter(metrics.PersistenceDeleteQueueMessagesScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "DeleteMessagesBefore")
 	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteQueueMessagesScope, metrics.PersistenceLatency)
 	err := p.persistence.DeleteMessagesBefore(messageID)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.metricClient.IncCounter(metrics.PersistenceDeleteQueueMessagesScope, metrics.PersistenceFailures)
@@ -1230,9 +1523,11 @@ func (p *queuePersistenceClient) DeleteMessagesBefore(messageID int) error {
 func (p *queuePersistenceClient) EnqueueMessageToDLQ(message []byte) error {
 	p.metricClient.IncCounter(metrics.PersistenceEnqueueMessageToDLQScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "EnqueueMessageToDLQ")
 	sw := p.metricClient.StartTimer(metrics.PersistenceEnqueueMessageToDLQScope, metrics.PersistenceLatency)
 	err := p.persistence.EnqueueMessageToDLQ(message)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.metricClient.IncCounter(metrics.PersistenceEnqueueMessageToDLQScope, metrics.PersistenceFailures)
@@ -1244,9 +1539,11 @@ func (p *queuePersistenceClient) EnqueueMessageToDLQ(message []byte) error {
 func (p *queuePersistenceClient) ReadMessagesFromDLQ(firstMessageID int, lastMessageID int, maxCount int) ([]*QueueMessage, error) {
 	p.metricClient.IncCounter(metrics.PersistenceReadQueueMessagesFromDLQScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "ReadMessagesFromDLQ")
 	sw := p.metricClient.StartTimer(metrics.PersistenceReadQueueMessagesFromDLQScope, metrics.PersistenceLatency)
 	result, err := p.persistence.ReadMessagesFromDLQ(firstMessageID, lastMessageID, maxCount)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.metricClient.IncCounter(metrics.PersistenceReadQueueMessagesFromDLQScope, metrics.PersistenceFailures)
@@ -1258,9 +1555,11 @@ func (p *queuePersistenceClient) ReadMessagesFromDLQ(firstMessageID int, lastMes
 func (p *queuePersistenceClient) DeleteMessageFromDLQ(messageID int) error {
 	p.metricClient.IncCounter(metrics.PersistenceDeleteQueueMessageFromDLQScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "DeleteMessageFromDLQ")
 	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteQueueMessageFromDLQScope, metrics.PersistenceLatency)
 	err := p.persistence.DeleteMessageFromDLQ(messageID)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.metricClient.IncCounter(metrics.PersistenceDeleteQueueMessageFromDLQScope, metrics.PersistenceFailures)
@@ -1272,9 +1571,11 @@ func (p *queuePersistenceClient) DeleteMessageFromDLQ(messageID int) error {
 func (p *queuePersistenceClient) DeleteDLQMessagesBefore(messageID int) error {
 	p.metricClient.IncCounter(metrics.PersistenceDeleteDLQMessageBeforeScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "DeleteDLQMessagesBefore")
 	sw := p.metricClient.StartTimer(metrics.PersistenceDeleteDLQMessageBeforeScope, metrics.PersistenceLatency)
 	err := p.persistence.DeleteDLQMessagesBefore(messageID)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.metricClient.IncCounter(metrics.PersistenceDeleteDLQMessageBeforeScope, metrics.PersistenceFailures)
@@ -1286,9 +1587,11 @@ func (p *queuePersistenceClient) DeleteDLQMessagesBefore(messageID int) error {
 func (p *queuePersistenceClient) GetLastMessageIDFromDLQ() (int, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetLastMessageIDFromDLQScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "GetLastMessageIDFromDLQ")
 	sw := p.metricClient.StartTimer(metrics.PersistenceGetLastMessageIDFromDLQScope, metrics.PersistenceLatency)
 	lastMessageID, err := p.persistence.GetLastMessageIDFromDLQ()
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		p.metricClient.IncCounter(metrics.PersistenceGetLastMessageIDFromDLQScope, metrics.PersistenceFailures)
@@ -1308,9 +1611,11 @@ func (c *clusterMetadataPersistenceClient) Close() {
 func (c *clusterMetadataPersistenceClient) GetImmutableClusterMetadata() (*GetImmutableClusterMetadataResponse, error) {
 	c.metricClient.IncCounter(metrics.PersistenceGetImmutableClusterMetadataScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "GetImmutableClusterMetadata")
 	sw := c.metricClient.StartTimer(metrics.PersistenceGetImmutableClusterMetadataScope, metrics.PersistenceLatency)
 	result, err := c.persistence.GetImmutableClusterMetadata()
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		c.metricClient.IncCounter(metrics.PersistenceGetImmutableClusterMetadataScope, metrics.PersistenceFailures)
@@ -1326,9 +1631,11 @@ func (c *clusterMetadataPersistenceClient) GetName() string {
 func (c *clusterMetadataPersistenceClient) InitializeImmutableClusterMetadata(request *InitializeImmutableClusterMetadataRequest) (*InitializeImmutableClusterMetadataResponse, error) {
 	c.metricClient.IncCounter(metrics.PersistenceInitImmutableClusterMetadataScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "InitializeImmutableClusterMetadata")
 	sw := c.metricClient.StartTimer(metrics.PersistenceInitImmutableClusterMetadataScope, metrics.PersistenceLatency)
 	res, err := c.persistence.InitializeImmutableClusterMetadata(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		c.metricClient.IncCounter(metrics.PersistenceInitImmutableClusterMetadataScope, metrics.PersistenceFailures)
@@ -1340,9 +1647,11 @@ func (c *clusterMetadataPersistenceClient) InitializeImmutableClusterMetadata(re
 func (c *clusterMetadataPersistenceClient) GetClusterMembers(request *GetClusterMembersRequest) (*GetClusterMembersResponse, error) {
 	c.metricClient.IncCounter(metrics.PersistenceGetClusterMembersScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "GetClusterMembers")
 	sw := c.metricClient.StartTimer(metrics.PersistenceGetClusterMembersScope, metrics.PersistenceLatency)
 	res, err := c.persistence.GetClusterMembers(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		c.metricClient.IncCounter(metrics.PersistenceGetClusterMembersScope, metrics.PersistenceFailures)
@@ -1354,9 +1663,11 @@ func (c *clusterMetadataPersistenceClient) GetClusterMembers(request *GetCluster
 func (c *clusterMetadataPersistenceClient) UpsertClusterMembership(request *UpsertClusterMembershipRequest) error {
 	c.metricClient.IncCounter(metrics.PersistenceUpsertClusterMembershipScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "UpsertClusterMembership")
 	sw := c.metricClient.StartTimer(metrics.PersistenceUpsertClusterMembershipScope, metrics.PersistenceLatency)
 	err := c.persistence.UpsertClusterMembership(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		c.metricClient.IncCounter(metrics.PersistenceUpsertClusterMembershipScope, metrics.PersistenceFailures)
@@ -1368,9 +1679,11 @@ func (c *clusterMetadataPersistenceClient) UpsertClusterMembership(request *Upse
 func (c *clusterMetadataPersistenceClient) PruneClusterMembership(request *PruneClusterMembershipRequest) error {
 	c.metricClient.IncCounter(metrics.PersistencePruneClusterMembershipScope, metrics.PersistenceRequests)
 
+	span := startPersistenceSpan(context.Background(), "PruneClusterMembership")
 	sw := c.metricClient.StartTimer(metrics.PersistencePruneClusterMembershipScope, metrics.PersistenceLatency)
 	err := c.persistence.PruneClusterMembership(request)
 	sw.Stop()
+	finishPersistenceSpan(span, err)
 
 	if err != nil {
 		c.metricClient.IncCounter(metrics.PersistencePruneClusterMembershipScope, metrics.PersistenceFailures)
@@ -1378,3 +1691,37 @@ func (c *clusterMetadataPersistenceClient) PruneClusterMembership(request *Prune
 
 	return err
 }
+
+func (c *clusterMetadataPersistenceClient) DeleteClusterMembership(request *DeleteClusterMembershipRequest) error {
+	c.metricClient.IncCounter(metrics.PersistenceDeleteClusterMembershipScope, metrics.PersistenceRequests)
+
+	span := startPersistenceSpan(context.Background(), "DeleteClusterMembership")
+	sw := c.metricClient.StartTimer(metrics.PersistenceDeleteClusterMembershipScope, metrics.PersistenceLatency)
+	err := c.persistence.DeleteClusterMembership(request)
+	sw.Stop()
+	finishPersistenceSpan(span, err)
+
+	if err != nil {
+		c.metricClient.IncCounter(metrics.PersistenceDeleteClusterMembershipScope, metrics.PersistenceFailures)
+	}
+
+	return err
+}
+
+// startPersistenceSpan starts a root span for a persistence operation, persistence APIs don't take
+// a context so the span can't be linked to the request which issued the operation
+func startPersistenceSpan(ctx context.Context, operation string) opentracing.Span {
+	opts := []opentracing.StartSpanOption{
+		ext.SpanKindRPCClient,
+		opentracing.Tag{Key: string(ext.Component), Value: persistenceComponentName},
+	}
+	if parent := opentracing.SpanFromContext(ctx); parent != nil {
+		opts = append(opts, opentracing.ChildOf(parent.Context()))
+	}
+	return opentracing.StartSpan(persistenceComponentName+"."+operation, opts...)
+}
+
+func finishPersistenceSpan(span opentracing.Span, err error) {
+	tracing.SetError(span, err)
+	span.Finish()
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/metrics"
+)
+
+type executionManagerStub struct {
+	ExecutionManager
+}
+
+func (m *executionManagerStub) GetWorkflowExecution(
+	request *GetWorkflowExecutionRequest,
+) (*GetWorkflowExecutionResponse, error) {
+	return &GetWorkflowExecutionResponse{}, nil
+}
+
+func TestExecutionManagerWithContext(t *testing.T) {
+	globalTracer := opentracing.GlobalTracer()
+	defer opentracing.SetGlobalTracer(globalTracer)
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+
+	manager := NewWorkflowExecutionPersistenceMetricsClient(
+		&executionManagerStub{},
+		metrics.NewClient(tally.NoopScope, metrics.History),
+		loggerimpl.NewNopLogger(),
+	)
+
+	// without a context the operation is traced as a root span
+	_, err := manager.GetWorkflowExecution(&GetWorkflowExecutionRequest{})
+	require.NoError(t, err)
+
+	parent := tracer.StartSpan("parent")
+	ctx := opentracing.ContextWithSpan(context.Background(), parent)
+	_, err = ExecutionManagerWithContext(manager, ctx).GetWorkflowExecution(&GetWorkflowExecutionRequest{})
+	require.NoError(t, err)
+	parent.Finish()
+
+	spans := tracer.FinishedSpans()
+	require.Len(t, spans, 3)
+	require.Equal(t, "persistence.GetWorkflowExecution", spans[0].OperationName)
+	require.Equal(t, 0, spans[0].ParentID)
+	require.Equal(t, "persistence.GetWorkflowExecution", spans[1].OperationName)
+	require.Equal(t, parent.Context().(mocktracer.MockSpanContext).SpanID, spans[1].ParentID)
+
+	// managers which are not wrapped by the metrics client are returned as is
+	stub := &executionManagerStub{}
+	require.Equal(t, stub, ExecutionManagerWithContext(stub, ctx))
+}
@@ -279,6 +279,15 @@ func (p *workflowExecutionRateLimitedPersistenceClient) GetCurrentExecution(requ
 	return response, err
 }
 
+func (p *workflowExecutionRateLimitedPersistenceClient) ListConcreteExecutions(request *ListConcreteExecutionsRequest) (*ListConcreteExecutionsResponse, error) {
+	if ok := p.rateLimiter.Allow(); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	response, err := p.persistence.ListConcreteExecutions(request)
+	return response, err
+}
+
 func (p *workflowExecutionRateLimitedPersistenceClient) GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
 	if ok := p.rateLimiter.Allow(); !ok {
 		return nil, ErrPersistenceLimitExceeded
@@ -315,6 +324,36 @@ func (p *workflowExecutionRateLimitedPersistenceClient) RangeCompleteTransferTas
 	return err
 }
 
+func (p *workflowExecutionRateLimitedPersistenceClient) PutTransferTaskToDLQ(
+	request *PutTransferTaskToDLQRequest,
+) error {
+	if ok := p.rateLimiter.Allow(); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	return p.persistence.PutTransferTaskToDLQ(request)
+}
+
+func (p *workflowExecutionRateLimitedPersistenceClient) GetTransferTasksFromDLQ(
+	request *GetTransferTasksFromDLQRequest,
+) (*GetTransferTasksFromDLQResponse, error) {
+	if ok := p.rateLimiter.Allow(); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	return p.persistence.GetTransferTasksFromDLQ(request)
+}
+
+func (p *workflowExecutionRateLimitedPersistenceClient) DeleteTransferTaskFromDLQ(
+	request *DeleteTransferTaskFromDLQRequest,
+) error {
+	if ok := p.rateLimiter.Allow(); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	return p.persistence.DeleteTransferTaskFromDLQ(request)
+}
+
 func (p *workflowExecutionRateLimitedPersistenceClient) CompleteReplicationTask(request *CompleteReplicationTaskRequest) error {
 	if ok := p.rateLimiter.Allow(); !ok {
 		return ErrPersistenceLimitExceeded
@@ -380,6 +419,36 @@ func (p *workflowExecutionRateLimitedPersistenceClient) RangeCompleteTimerTask(r
 	return err
 }
 
+func (p *workflowExecutionRateLimitedPersistenceClient) PutTimerTaskToDLQ(
+	request *PutTimerTaskToDLQRequest,
+) error {
+	if ok := p.rateLimiter.Allow(); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	return p.persistence.PutTimerTaskToDLQ(request)
+}
+
+func (p *workflowExecutionRateLimitedPersistenceClient) GetTimerTasksFromDLQ(
+	request *GetTimerTasksFromDLQRequest,
+) (*GetTimerTasksFromDLQResponse, error) {
+	if ok := p.rateLimiter.Allow(); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	return p.persistence.GetTimerTasksFromDLQ(request)
+}
+
+func (p *workflowExecutionRateLimitedPersistenceClient) DeleteTimerTaskFromDLQ(
+	request *DeleteTimerTaskFromDLQRequest,
+) error {
+	if ok := p.rateLimiter.Allow(); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	return p.persistence.DeleteTimerTaskFromDLQ(request)
+}
+
 func (p *workflowExecutionRateLimitedPersistenceClient) DeleteTask(request *DeleteTaskRequest) error {
 	if ok := p.rateLimiter.Allow(); !ok {
 		return ErrPersistenceLimitExceeded
@@ -877,3 +946,10 @@ func (c *clusterMetadataRateLimitedPersistenceClient) PruneClusterMembership(req
 	}
 	return c.persistence.PruneClusterMembership(request)
 }
+
+func (c *clusterMetadataRateLimitedPersistenceClient) DeleteClusterMembership(request *DeleteClusterMembershipRequest) error {
+	if ok := c.rateLimiter.Allow(); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+	return c.persistence.DeleteClusterMembership(request)
+}
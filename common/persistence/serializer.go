@@ -61,6 +61,10 @@ type (
 		// serialize/deserialize immutable cluster metadata
 		SerializeImmutableClusterMetadata(icm *persist.ImmutableClusterMetadata, encodingType common.EncodingType) (*DataBlob, error)
 		DeserializeImmutableClusterMetadata(data *DataBlob) (*persist.ImmutableClusterMetadata, error)
+
+		// serialize/deserialize domain workflow defaults
+		SerializeDomainWorkflowDefaults(defaults *DomainWorkflowDefaults, encodingType common.EncodingType) (*DataBlob, error)
+		DeserializeDomainWorkflowDefaults(data *DataBlob) (*DomainWorkflowDefaults, error)
 	}
 
 	// CadenceSerializationError is an error type for cadence serialization
@@ -189,6 +193,22 @@ func (t *serializerImpl) DeserializeImmutableClusterMetadata(data *DataBlob) (*p
 	return &icm, err
 }
 
+func (t *serializerImpl) SerializeDomainWorkflowDefaults(defaults *DomainWorkflowDefaults, encodingType common.EncodingType) (*DataBlob, error) {
+	if defaults == nil {
+		return nil, nil
+	}
+	return t.serialize(defaults, encodingType)
+}
+
+func (t *serializerImpl) DeserializeDomainWorkflowDefaults(data *DataBlob) (*DomainWorkflowDefaults, error) {
+	if data == nil {
+		return nil, nil
+	}
+	var defaults DomainWorkflowDefaults
+	err := t.deserialize(data, &defaults)
+	return &defaults, err
+}
+
 func (t *serializerImpl) serialize(input interface{}, encodingType common.EncodingType) (*DataBlob, error) {
 	if input == nil {
 		return nil, nil
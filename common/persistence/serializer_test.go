@@ -110,6 +110,17 @@ func (s *cadenceSerializerSuite) TestSerializer() {
 		},
 	}
 
+	workflowDefaults0 := &DomainWorkflowDefaults{
+		DefaultExecutionStartToCloseTimeoutSeconds: 3600,
+		MaxExecutionStartToCloseTimeoutSeconds:     86400,
+		DefaultWorkflowIDReusePolicy:               workflow.WorkflowIdReusePolicyAllowDuplicate.Ptr(),
+		DefaultWorkflowRetryPolicy: &workflow.RetryPolicy{
+			InitialIntervalInSeconds: common.Int32Ptr(1),
+			BackoffCoefficient:       common.Float64Ptr(2),
+			NonRetriableErrorReasons: []string{"bad-request"},
+		},
+	}
+
 	histories := &workflow.VersionHistories{
 		Histories: []*workflow.VersionHistory{
 			{
@@ -402,6 +413,34 @@ func (s *cadenceSerializerSuite) TestSerializer() {
 			dHistoriesEmpty, err := serializer.DeserializeVersionHistories(historiesEmpty)
 			s.Nil(err)
 			s.True(dHistoriesEmpty.Equals(histories))
+
+			// serialize domain workflow defaults
+
+			nilWorkflowDefaults, err := serializer.SerializeDomainWorkflowDefaults(nil, common.EncodingTypeJSON)
+			s.Nil(err)
+			s.Nil(nilWorkflowDefaults)
+
+			workflowDefaultsJSON, err := serializer.SerializeDomainWorkflowDefaults(workflowDefaults0, common.EncodingTypeJSON)
+			s.Nil(err)
+			s.NotNil(workflowDefaultsJSON)
+
+			workflowDefaultsEmpty, err := serializer.SerializeDomainWorkflowDefaults(workflowDefaults0, common.EncodingType(""))
+			s.Nil(err)
+			s.NotNil(workflowDefaultsEmpty)
+
+			// deserialize domain workflow defaults
+
+			dNilWorkflowDefaults, err := serializer.DeserializeDomainWorkflowDefaults(nil)
+			s.Nil(err)
+			s.Nil(dNilWorkflowDefaults)
+
+			workflowDefaults1, err := serializer.DeserializeDomainWorkflowDefaults(workflowDefaultsJSON)
+			s.Nil(err)
+			s.Equal(workflowDefaults0, workflowDefaults1)
+
+			workflowDefaults2, err := serializer.DeserializeDomainWorkflowDefaults(workflowDefaultsEmpty)
+			s.Nil(err)
+			s.Equal(workflowDefaults0, workflowDefaults2)
 		}()
 	}
 
@@ -174,6 +174,14 @@ func (s *sqlClusterMetadataManager) PruneClusterMembership(request *p.PruneClust
 	return nil
 }
 
+func (s *sqlClusterMetadataManager) DeleteClusterMembership(request *p.DeleteClusterMembershipRequest) error {
+	if _, err := s.db.DeleteClusterMembership(request.HostID); err != nil {
+		return convertCommonErrors("DeleteClusterMembership", err)
+	}
+
+	return nil
+}
+
 func newClusterMetadataPersistence(db sqlplugin.DB,
 	logger log.Logger) (p.ClusterMetadataStore, error) {
 	return &sqlClusterMetadataManager{
@@ -282,6 +282,7 @@ func (m *sqlExecutionManager) GetWorkflowExecution(
 		NonRetriableErrors:                 info.GetRetryNonRetryableErrors(),
 		SearchAttributes:                   info.GetSearchAttributes(),
 		Memo:                               info.GetMemo(),
+		StartingBuildID:                    info.GetStartingBuildID(),
 	}
 
 	if info.LastWriteEventID != nil {
@@ -836,6 +837,72 @@ func (m *sqlExecutionManager) GetCurrentExecution(
 	}, nil
 }
 
+type listExecutionsPageToken struct {
+	DomainID   sqlplugin.UUID
+	WorkflowID string
+	RunID      sqlplugin.UUID
+}
+
+func (t *listExecutionsPageToken) serialize() ([]byte, error) {
+	return json.Marshal(t)
+}
+
+func (t *listExecutionsPageToken) deserialize(payload []byte) error {
+	return json.Unmarshal(payload, t)
+}
+
+func (m *sqlExecutionManager) ListConcreteExecutions(
+	request *p.ListConcreteExecutionsRequest,
+) (*p.ListConcreteExecutionsResponse, error) {
+
+	pageToken := &listExecutionsPageToken{DomainID: sqlplugin.UUID{}, RunID: sqlplugin.UUID{}}
+	if len(request.PageToken) > 0 {
+		if err := pageToken.deserialize(request.PageToken); err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("error deserializing listExecutionsPageToken: %v", err),
+			}
+		}
+	}
+
+	rows, err := m.db.ListFromExecutions(&sqlplugin.ExecutionsFilter{
+		ShardID:    m.shardID,
+		DomainID:   pageToken.DomainID,
+		WorkflowID: pageToken.WorkflowID,
+		RunID:      pageToken.RunID,
+		PageSize:   common.IntPtr(request.PageSize),
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListConcreteExecutions operation failed. Select failed. Error: %v", err),
+		}
+	}
+
+	resp := &p.ListConcreteExecutionsResponse{Executions: make([]*p.ConcreteExecution, len(rows))}
+	for i, row := range rows {
+		resp.Executions[i] = &p.ConcreteExecution{
+			DomainID:   row.DomainID.String(),
+			WorkflowID: row.WorkflowID,
+			RunID:      row.RunID.String(),
+		}
+	}
+
+	// a full page means there may be more executions after the last one
+	if len(rows) > 0 && len(rows) == request.PageSize {
+		lastRow := rows[len(rows)-1]
+		pageToken = &listExecutionsPageToken{
+			DomainID:   lastRow.DomainID,
+			WorkflowID: lastRow.WorkflowID,
+			RunID:      lastRow.RunID,
+		}
+		if resp.PageToken, err = pageToken.serialize(); err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("ListConcreteExecutions: error serializing page token: %v", err),
+			}
+		}
+	}
+	return resp, nil
+}
+
 func (m *sqlExecutionManager) GetTransferTasks(
 	request *p.GetTransferTasksRequest,
 ) (*p.GetTransferTasksResponse, error) {
@@ -904,6 +971,113 @@ func (m *sqlExecutionManager) RangeCompleteTransferTask(
 	return nil
 }
 
+func (m *sqlExecutionManager) PutTransferTaskToDLQ(
+	request *p.PutTransferTaskToDLQRequest,
+) error {
+
+	task := request.TaskInfo
+	blob, err := transferTaskInfoToBlob(&sqlblobs.TransferTaskInfo{
+		DomainID:                 sqlplugin.MustParseUUID(task.DomainID),
+		WorkflowID:               &task.WorkflowID,
+		RunID:                    sqlplugin.MustParseUUID(task.RunID),
+		TaskType:                 common.Int16Ptr(int16(task.TaskType)),
+		TargetDomainID:           sqlplugin.MustParseUUID(task.TargetDomainID),
+		TargetWorkflowID:         &task.TargetWorkflowID,
+		TargetRunID:              sqlplugin.MustParseUUID(task.TargetRunID),
+		TargetChildWorkflowOnly:  &task.TargetChildWorkflowOnly,
+		TaskList:                 &task.TaskList,
+		ScheduleID:               &task.ScheduleID,
+		Version:                  &task.Version,
+		VisibilityTimestampNanos: common.Int64Ptr(task.VisibilityTimestamp.UnixNano()),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = m.db.InsertIntoTransferTasksDLQ(&sqlplugin.TransferTasksRow{
+		ShardID:      m.shardID,
+		TaskID:       task.TaskID,
+		Data:         blob.Data,
+		DataEncoding: string(blob.Encoding),
+	})
+
+	// Tasks are immutable. So it's fine if we already persisted it before.
+	if err != nil && !m.db.IsDupEntryError(err) {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("PutTransferTaskToDLQ operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
+func (m *sqlExecutionManager) GetTransferTasksFromDLQ(
+	request *p.GetTransferTasksFromDLQRequest,
+) (*p.GetTransferTasksFromDLQResponse, error) {
+
+	readLevel := int64(math.MinInt64)
+	if len(request.NextPageToken) > 0 {
+		var err error
+		if readLevel, err = deserializePageToken(request.NextPageToken); err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("GetTransferTasksFromDLQ operation failed. Error: %v", err),
+			}
+		}
+	}
+
+	rows, err := m.db.SelectFromTransferTasksDLQ(&sqlplugin.TransferTasksFilter{
+		ShardID:   m.shardID,
+		MinTaskID: &readLevel,
+		PageSize:  &request.BatchSize,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetTransferTasksFromDLQ operation failed. Select failed. Error: %v", err),
+		}
+	}
+
+	resp := &p.GetTransferTasksFromDLQResponse{Tasks: make([]*p.TransferTaskInfo, len(rows))}
+	for i, row := range rows {
+		info, err := transferTaskInfoFromBlob(row.Data, row.DataEncoding)
+		if err != nil {
+			return nil, err
+		}
+		resp.Tasks[i] = &p.TransferTaskInfo{
+			TaskID:                  row.TaskID,
+			DomainID:                sqlplugin.UUID(info.DomainID).String(),
+			WorkflowID:              info.GetWorkflowID(),
+			RunID:                   sqlplugin.UUID(info.RunID).String(),
+			VisibilityTimestamp:     time.Unix(0, info.GetVisibilityTimestampNanos()),
+			TargetDomainID:          sqlplugin.UUID(info.TargetDomainID).String(),
+			TargetWorkflowID:        info.GetTargetWorkflowID(),
+			TargetRunID:             sqlplugin.UUID(info.TargetRunID).String(),
+			TargetChildWorkflowOnly: info.GetTargetChildWorkflowOnly(),
+			TaskList:                info.GetTaskList(),
+			TaskType:                int(info.GetTaskType()),
+			ScheduleID:              info.GetScheduleID(),
+			Version:                 info.GetVersion(),
+		}
+	}
+	if len(rows) == request.BatchSize {
+		resp.NextPageToken = serializePageToken(rows[len(rows)-1].TaskID)
+	}
+	return resp, nil
+}
+
+func (m *sqlExecutionManager) DeleteTransferTaskFromDLQ(
+	request *p.DeleteTransferTaskFromDLQRequest,
+) error {
+
+	if _, err := m.db.DeleteFromTransferTasksDLQ(&sqlplugin.TransferTasksFilter{
+		ShardID: m.shardID,
+		TaskID:  &request.TaskID,
+	}); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteTransferTaskFromDLQ operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
 func (m *sqlExecutionManager) GetReplicationTasks(
 	request *p.GetReplicationTasksRequest,
 ) (*p.GetReplicationTasksResponse, error) {
@@ -1170,6 +1344,121 @@ func (m *sqlExecutionManager) RangeCompleteTimerTask(
 	return nil
 }
 
+func (m *sqlExecutionManager) PutTimerTaskToDLQ(
+	request *p.PutTimerTaskToDLQRequest,
+) error {
+
+	task := request.TaskInfo
+	blob, err := timerTaskInfoToBlob(&sqlblobs.TimerTaskInfo{
+		DomainID:        sqlplugin.MustParseUUID(task.DomainID),
+		WorkflowID:      &task.WorkflowID,
+		RunID:           sqlplugin.MustParseUUID(task.RunID),
+		TaskType:        common.Int16Ptr(int16(task.TaskType)),
+		TimeoutType:     common.Int16Ptr(int16(task.TimeoutType)),
+		EventID:         &task.EventID,
+		ScheduleAttempt: &task.ScheduleAttempt,
+		Version:         &task.Version,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = m.db.InsertIntoTimerTasksDLQ(&sqlplugin.TimerTasksRow{
+		ShardID:             m.shardID,
+		VisibilityTimestamp: task.VisibilityTimestamp,
+		TaskID:              task.TaskID,
+		Data:                blob.Data,
+		DataEncoding:        string(blob.Encoding),
+	})
+
+	// Tasks are immutable. So it's fine if we already persisted it before.
+	if err != nil && !m.db.IsDupEntryError(err) {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("PutTimerTaskToDLQ operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
+func (m *sqlExecutionManager) GetTimerTasksFromDLQ(
+	request *p.GetTimerTasksFromDLQRequest,
+) (*p.GetTimerTasksFromDLQResponse, error) {
+
+	pageToken := &timerTaskPageToken{TaskID: math.MinInt64, Timestamp: time.Unix(0, 0)}
+	if len(request.NextPageToken) > 0 {
+		if err := pageToken.deserialize(request.NextPageToken); err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("error deserializing timerTaskPageToken: %v", err),
+			}
+		}
+	}
+
+	rows, err := m.db.SelectFromTimerTasksDLQ(&sqlplugin.TimerTasksFilter{
+		ShardID:                m.shardID,
+		MinVisibilityTimestamp: &pageToken.Timestamp,
+		TaskID:                 pageToken.TaskID,
+		PageSize:               common.IntPtr(request.BatchSize + 1),
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetTimerTasksFromDLQ operation failed. Select failed. Error: %v", err),
+		}
+	}
+
+	resp := &p.GetTimerTasksFromDLQResponse{Timers: make([]*p.TimerTaskInfo, len(rows))}
+	for i, row := range rows {
+		info, err := timerTaskInfoFromBlob(row.Data, row.DataEncoding)
+		if err != nil {
+			return nil, err
+		}
+		resp.Timers[i] = &p.TimerTaskInfo{
+			VisibilityTimestamp: row.VisibilityTimestamp,
+			TaskID:              row.TaskID,
+			DomainID:            sqlplugin.UUID(info.DomainID).String(),
+			WorkflowID:          info.GetWorkflowID(),
+			RunID:               sqlplugin.UUID(info.RunID).String(),
+			TaskType:            int(info.GetTaskType()),
+			TimeoutType:         int(info.GetTimeoutType()),
+			EventID:             info.GetEventID(),
+			ScheduleAttempt:     info.GetScheduleAttempt(),
+			Version:             info.GetVersion(),
+		}
+	}
+
+	if len(resp.Timers) > request.BatchSize {
+		pageToken = &timerTaskPageToken{
+			TaskID:    resp.Timers[request.BatchSize].TaskID,
+			Timestamp: resp.Timers[request.BatchSize].VisibilityTimestamp,
+		}
+		resp.Timers = resp.Timers[:request.BatchSize]
+		nextToken, err := pageToken.serialize()
+		if err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("GetTimerTasksFromDLQ: error serializing page token: %v", err),
+			}
+		}
+		resp.NextPageToken = nextToken
+	}
+
+	return resp, nil
+}
+
+func (m *sqlExecutionManager) DeleteTimerTaskFromDLQ(
+	request *p.DeleteTimerTaskFromDLQRequest,
+) error {
+
+	if _, err := m.db.DeleteFromTimerTasksDLQ(&sqlplugin.TimerTasksFilter{
+		ShardID:             m.shardID,
+		VisibilityTimestamp: &request.VisibilityTimestamp,
+		TaskID:              request.TaskID,
+	}); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteTimerTaskFromDLQ operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
 func (m *sqlExecutionManager) PutReplicationTaskToDLQ(request *p.PutReplicationTaskToDLQRequest) error {
 	replicationTask := request.TaskInfo
 	blob, err := replicationTaskInfoToBlob(&sqlblobs.ReplicationTaskInfo{
@@ -1258,6 +1258,7 @@ func buildExecutionRow(
 		AutoResetPointsEncoding:                 common.StringPtr(string(executionInfo.AutoResetPoints.GetEncoding())),
 		SearchAttributes:                        executionInfo.SearchAttributes,
 		Memo:                                    executionInfo.Memo,
+		StartingBuildID:                         &executionInfo.StartingBuildID,
 	}
 
 	completionEvent := executionInfo.CompletionEvent
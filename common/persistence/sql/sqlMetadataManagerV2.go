@@ -98,6 +98,12 @@ func (m *sqlMetadataManagerV2) CreateDomain(request *persistence.InternalCreateD
 		badBinaries = request.Config.BadBinaries.Data
 		badBinariesEncoding = common.StringPtr(string(request.Config.BadBinaries.GetEncoding()))
 	}
+	var workflowDefaults []byte
+	var workflowDefaultsEncoding *string
+	if request.Config.WorkflowDefaults != nil {
+		workflowDefaults = request.Config.WorkflowDefaults.Data
+		workflowDefaultsEncoding = common.StringPtr(string(request.Config.WorkflowDefaults.GetEncoding()))
+	}
 	domainInfo := &sqlblobs.DomainInfo{
 		Status:                      common.Int32Ptr(int32(request.Info.Status)),
 		Description:                 &request.Info.Description,
@@ -119,6 +125,9 @@ func (m *sqlMetadataManagerV2) CreateDomain(request *persistence.InternalCreateD
 		FailoverNotificationVersion: common.Int64Ptr(persistence.InitialFailoverNotificationVersion),
 		BadBinaries:                 badBinaries,
 		BadBinariesEncoding:         badBinariesEncoding,
+		SearchAttributes:            request.Config.SearchAttributes,
+		WorkflowDefaults:            workflowDefaults,
+		WorkflowDefaultsEncoding:    workflowDefaultsEncoding,
 	}
 
 	blob, err := domainInfoToBlob(domainInfo)
@@ -214,6 +223,10 @@ func (m *sqlMetadataManagerV2) domainRowToGetDomainResponse(row *sqlplugin.Domai
 	if domainInfo.BadBinaries != nil {
 		badBinaries = persistence.NewDataBlob(domainInfo.BadBinaries, common.EncodingType(*domainInfo.BadBinariesEncoding))
 	}
+	var workflowDefaults *persistence.DataBlob
+	if domainInfo.WorkflowDefaults != nil {
+		workflowDefaults = persistence.NewDataBlob(domainInfo.WorkflowDefaults, common.EncodingType(domainInfo.GetWorkflowDefaultsEncoding()))
+	}
 
 	return &persistence.InternalGetDomainResponse{
 		Info: &persistence.DomainInfo{
@@ -234,6 +247,8 @@ func (m *sqlMetadataManagerV2) domainRowToGetDomainResponse(row *sqlplugin.Domai
 			VisibilityArchivalStatus: workflow.ArchivalStatus(domainInfo.GetVisibilityArchivalStatus()),
 			VisibilityArchivalURI:    domainInfo.GetVisibilityArchivalURI(),
 			BadBinaries:              badBinaries,
+			SearchAttributes:         domainInfo.GetSearchAttributes(),
+			WorkflowDefaults:         workflowDefaults,
 		},
 		ReplicationConfig: &persistence.DomainReplicationConfig{
 			ActiveClusterName: persistence.GetOrUseDefaultActiveCluster(m.activeClusterName, domainInfo.GetActiveClusterName()),
@@ -259,6 +274,12 @@ func (m *sqlMetadataManagerV2) UpdateDomain(request *persistence.InternalUpdateD
 		badBinaries = request.Config.BadBinaries.Data
 		badBinariesEncoding = common.StringPtr(string(request.Config.BadBinaries.GetEncoding()))
 	}
+	var workflowDefaults []byte
+	var workflowDefaultsEncoding *string
+	if request.Config.WorkflowDefaults != nil {
+		workflowDefaults = request.Config.WorkflowDefaults.Data
+		workflowDefaultsEncoding = common.StringPtr(string(request.Config.WorkflowDefaults.GetEncoding()))
+	}
 	domainInfo := &sqlblobs.DomainInfo{
 		Status:                      common.Int32Ptr(int32(request.Info.Status)),
 		Description:                 &request.Info.Description,
@@ -280,6 +301,9 @@ func (m *sqlMetadataManagerV2) UpdateDomain(request *persistence.InternalUpdateD
 		FailoverNotificationVersion: common.Int64Ptr(request.FailoverNotificationVersion),
 		BadBinaries:                 badBinaries,
 		BadBinariesEncoding:         badBinariesEncoding,
+		SearchAttributes:            request.Config.SearchAttributes,
+		WorkflowDefaults:            workflowDefaults,
+		WorkflowDefaultsEncoding:    workflowDefaultsEncoding,
 	}
 
 	blob, err := domainInfoToBlob(domainInfo)
@@ -112,6 +112,9 @@ func (m *sqlShardManager) GetShard(request *persistence.GetShardRequest) (*persi
 	if shardInfo.ClusterReplicationLevel == nil {
 		shardInfo.ClusterReplicationLevel = make(map[string]int64)
 	}
+	if shardInfo.OpenWorkflowCounts == nil {
+		shardInfo.OpenWorkflowCounts = make(map[string]int64)
+	}
 
 	resp := &persistence.GetShardResponse{ShardInfo: &persistence.ShardInfo{
 		ShardID:                   int(row.ShardID),
@@ -126,6 +129,7 @@ func (m *sqlShardManager) GetShard(request *persistence.GetShardRequest) (*persi
 		ClusterTimerAckLevel:      timerAckLevel,
 		DomainNotificationVersion: shardInfo.GetDomainNotificationVersion(),
 		ClusterReplicationLevel:   shardInfo.ClusterReplicationLevel,
+		OpenWorkflowCounts:        shardInfo.OpenWorkflowCounts,
 	}}
 
 	return resp, nil
@@ -221,6 +225,7 @@ func shardInfoToShardsRow(s persistence.ShardInfo) (*sqlplugin.ShardsRow, error)
 		DomainNotificationVersion: common.Int64Ptr(s.DomainNotificationVersion),
 		Owner:                     &s.Owner,
 		ClusterReplicationLevel:   s.ClusterReplicationLevel,
+		OpenWorkflowCounts:        s.OpenWorkflowCounts,
 	}
 
 	blob, err := shardInfoToBlob(shardInfo)
@@ -24,6 +24,7 @@ import (
 	"database/sql"
 	"fmt"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/dgryski/go-farm"
@@ -45,6 +46,8 @@ var (
 	minUUID = "00000000-0000-0000-0000-000000000000"
 )
 
+const buildIDSeparator = ","
+
 // newTaskPersistence creates a new instance of TaskManager
 func newTaskPersistence(db sqlplugin.DB, nShards int, log log.Logger) (persistence.TaskManager, error) {
 	return &sqlTaskManager{
@@ -149,13 +152,16 @@ func (m *sqlTaskManager) LeaseTaskList(request *persistence.LeaseTaskListRequest
 			return fmt.Errorf("%v rows affected instead of 1", rowsAffected)
 		}
 		resp = &persistence.LeaseTaskListResponse{TaskListInfo: &persistence.TaskListInfo{
-			DomainID:    request.DomainID,
-			Name:        request.TaskList,
-			TaskType:    request.TaskType,
-			RangeID:     rangeID + 1,
-			AckLevel:    ackLevel,
-			Kind:        request.TaskListKind,
-			LastUpdated: now,
+			DomainID:           request.DomainID,
+			Name:               request.TaskList,
+			TaskType:           request.TaskType,
+			RangeID:            rangeID + 1,
+			AckLevel:           ackLevel,
+			Kind:               request.TaskListKind,
+			LastUpdated:        now,
+			BuildIDSets:        buildIDSetsFromBlob(tlInfo.GetBuildIdSets()),
+			NumWritePartitions: int(tlInfo.GetNumWritePartitions()),
+			NumReadPartitions:  int(tlInfo.GetNumReadPartitions()),
 		}}
 		return nil
 	})
@@ -166,10 +172,13 @@ func (m *sqlTaskManager) UpdateTaskList(request *persistence.UpdateTaskListReque
 	shardID := m.shardID(request.TaskListInfo.DomainID, request.TaskListInfo.Name)
 	domainID := sqlplugin.MustParseUUID(request.TaskListInfo.DomainID)
 	tlInfo := &sqlblobs.TaskListInfo{
-		AckLevel:         common.Int64Ptr(request.TaskListInfo.AckLevel),
-		Kind:             common.Int16Ptr(int16(request.TaskListInfo.Kind)),
-		ExpiryTimeNanos:  common.Int64Ptr(0),
-		LastUpdatedNanos: common.TimeNowNanosPtr(),
+		AckLevel:           common.Int64Ptr(request.TaskListInfo.AckLevel),
+		Kind:               common.Int16Ptr(int16(request.TaskListInfo.Kind)),
+		ExpiryTimeNanos:    common.Int64Ptr(0),
+		LastUpdatedNanos:   common.TimeNowNanosPtr(),
+		BuildIdSets:        buildIDSetsToBlob(request.TaskListInfo.BuildIDSets),
+		NumWritePartitions: common.Int32Ptr(int32(request.TaskListInfo.NumWritePartitions)),
+		NumReadPartitions:  common.Int32Ptr(int32(request.TaskListInfo.NumReadPartitions)),
 	}
 	if request.TaskListInfo.Kind == persistence.TaskListKindSticky {
 		tlInfo.ExpiryTimeNanos = common.Int64Ptr(stickyTaskListTTL().UnixNano())
@@ -297,6 +306,9 @@ func (m *sqlTaskManager) ListTaskList(request *persistence.ListTaskListRequest)
 		resp.Items[i].AckLevel = info.GetAckLevel()
 		resp.Items[i].Expiry = time.Unix(0, info.GetExpiryTimeNanos())
 		resp.Items[i].LastUpdated = time.Unix(0, info.GetLastUpdatedNanos())
+		resp.Items[i].BuildIDSets = buildIDSetsFromBlob(info.GetBuildIdSets())
+		resp.Items[i].NumWritePartitions = int(info.GetNumWritePartitions())
+		resp.Items[i].NumReadPartitions = int(info.GetNumReadPartitions())
 	}
 
 	return resp, nil
@@ -463,3 +475,27 @@ func lockTaskList(tx sqlplugin.Tx, shardID int, domainID sqlplugin.UUID, name st
 func stickyTaskListTTL() time.Time {
 	return time.Now().Add(24 * time.Hour)
 }
+
+// buildIDSetsToBlob flattens the compatible build ID sets of a task list into
+// one comma separated entry per set
+func buildIDSetsToBlob(sets [][]string) []string {
+	if len(sets) == 0 {
+		return nil
+	}
+	result := make([]string, len(sets))
+	for i, set := range sets {
+		result[i] = strings.Join(set, buildIDSeparator)
+	}
+	return result
+}
+
+func buildIDSetsFromBlob(sets []string) [][]string {
+	if len(sets) == 0 {
+		return nil
+	}
+	result := make([][]string, len(sets))
+	for i, set := range sets {
+		result[i] = strings.Split(set, buildIDSeparator)
+	}
+	return result
+}
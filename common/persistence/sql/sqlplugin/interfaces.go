@@ -128,6 +128,7 @@ type (
 		TaskID    *int64
 		MinTaskID *int64
 		MaxTaskID *int64
+		PageSize  *int
 	}
 
 	// ExecutionsRow represents a row in executions table
@@ -151,6 +152,7 @@ type (
 		DomainID   UUID
 		WorkflowID string
 		RunID      UUID
+		PageSize   *int
 	}
 
 	// CurrentExecutionsRow represents a row in current_executions table
@@ -539,6 +541,7 @@ type (
 		GetClusterMembers(filter *ClusterMembershipFilter) ([]ClusterMembershipRow, error)
 		UpsertClusterMembership(row *ClusterMembershipRow) (sql.Result, error)
 		PruneClusterMembership(filter *PruneClusterMembershipFilter) (sql.Result, error)
+		DeleteClusterMembership(hostID []byte) (sql.Result, error)
 
 		InsertIntoDomain(rows *DomainRow) (sql.Result, error)
 		UpdateDomain(row *DomainRow) (sql.Result, error)
@@ -595,6 +598,9 @@ type (
 		UpdateExecutions(row *ExecutionsRow) (sql.Result, error)
 		SelectFromExecutions(filter *ExecutionsFilter) (*ExecutionsRow, error)
 		DeleteFromExecutions(filter *ExecutionsFilter) (sql.Result, error)
+		// ListFromExecutions returns up to PageSize rows of the shard, ordered by domain_id, workflow_id and run_id,
+		// that come after the row with the domain_id, workflow_id and run_id of the filter. Only the key columns are read
+		ListFromExecutions(filter *ExecutionsFilter) ([]ExecutionsRow, error)
 		ReadLockExecutions(filter *ExecutionsFilter) (int, error)
 		WriteLockExecutions(filter *ExecutionsFilter) (int, error)
 
@@ -621,6 +627,14 @@ type (
 		// Filter params - shardID is required. If TaskID is not nil, a single row is deleted.
 		// When MinTaskID and MaxTaskID are not-nil, a range of rows are deleted.
 		DeleteFromTransferTasks(filter *TransferTasksFilter) (sql.Result, error)
+		// InsertIntoTransferTasksDLQ puts the transfer task into DLQ
+		InsertIntoTransferTasksDLQ(row *TransferTasksRow) (sql.Result, error)
+		// SelectFromTransferTasksDLQ returns one or more rows from transfer_tasks_dlq table
+		// Required filter params - {shardID, minTaskID, pageSize}
+		SelectFromTransferTasksDLQ(filter *TransferTasksFilter) ([]TransferTasksRow, error)
+		// DeleteFromTransferTasksDLQ deletes a row from transfer_tasks_dlq table
+		// Required filter params - {shardID, taskID}
+		DeleteFromTransferTasksDLQ(filter *TransferTasksFilter) (sql.Result, error)
 
 		InsertIntoTimerTasks(rows []TimerTasksRow) (sql.Result, error)
 		// SelectFromTimerTasks returns one or more rows from timer_tasks table
@@ -631,6 +645,14 @@ type (
 		//  - to delete one row - {shardID, visibilityTimestamp, taskID}
 		//  - to delete multiple rows - {shardID, minVisibilityTimestamp, maxVisibilityTimestamp}
 		DeleteFromTimerTasks(filter *TimerTasksFilter) (sql.Result, error)
+		// InsertIntoTimerTasksDLQ puts the timer task into DLQ
+		InsertIntoTimerTasksDLQ(row *TimerTasksRow) (sql.Result, error)
+		// SelectFromTimerTasksDLQ returns one or more rows from timer_tasks_dlq table
+		// Required filter params - {shardID, taskID, minVisibilityTimestamp, pageSize}
+		SelectFromTimerTasksDLQ(filter *TimerTasksFilter) ([]TimerTasksRow, error)
+		// DeleteFromTimerTasksDLQ deletes a row from timer_tasks_dlq table
+		// Required filter params - {shardID, visibilityTimestamp, taskID}
+		DeleteFromTimerTasksDLQ(filter *TimerTasksFilter) (sql.Result, error)
 
 		InsertIntoBufferedEvents(rows []BufferedEventsRow) (sql.Result, error)
 		SelectFromBufferedEvents(filter *BufferedEventsFilter) ([]BufferedEventsRow, error)
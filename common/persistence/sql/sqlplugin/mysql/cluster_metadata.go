@@ -49,6 +49,10 @@ VALUES(?, ?, ?, ?, ?, ?, ?)`
 cluster_membership 
 WHERE record_expiry < ? LIMIT ?`
 
+	templateDeleteClusterMembership = `DELETE FROM
+cluster_membership 
+WHERE host_id = ?`
+
 	templateGetClusterMembership = `SELECT host_id, rpc_address, rpc_port, role, session_start, last_heartbeat, record_expiry, insertion_order FROM
 cluster_membership`
 
@@ -159,3 +163,7 @@ func (mdb *db) PruneClusterMembership(filter *sqlplugin.PruneClusterMembershipFi
 		mdb.converter.ToMySQLDateTime(filter.PruneRecordsBefore),
 		filter.MaxRecordsAffected)
 }
+
+func (mdb *db) DeleteClusterMembership(hostID []byte) (sql.Result, error) {
+	return mdb.conn.Exec(templateDeleteClusterMembership, hostID)
+}
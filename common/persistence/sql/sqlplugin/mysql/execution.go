@@ -39,6 +39,10 @@ const (
 	getExecutionQuery = `SELECT ` + executionsColumns + ` FROM executions
  WHERE shard_id = ? AND domain_id = ? AND workflow_id = ? AND run_id = ?`
 
+	listExecutionsQuery = `SELECT shard_id, domain_id, workflow_id, run_id FROM executions
+ WHERE shard_id = ? AND (domain_id, workflow_id, run_id) > (?, ?, ?)
+ ORDER BY domain_id, workflow_id, run_id LIMIT ?`
+
 	deleteExecutionQuery = `DELETE FROM executions 
  WHERE shard_id = ? AND domain_id = ? AND workflow_id = ? AND run_id = ?`
 
@@ -100,6 +104,24 @@ workflow_id = :workflow_id
 	deleteTimerTaskQuery      = `DELETE FROM timer_tasks WHERE shard_id = ? AND visibility_timestamp = ? AND task_id = ?`
 	rangeDeleteTimerTaskQuery = `DELETE FROM timer_tasks WHERE shard_id = ? AND visibility_timestamp >= ? AND visibility_timestamp < ?`
 
+	createTransferTasksDLQQuery = `INSERT INTO transfer_tasks_dlq(shard_id, task_id, data, data_encoding) 
+ VALUES(:shard_id, :task_id, :data, :data_encoding)`
+
+	getTransferTasksDLQQuery = `SELECT task_id, data, data_encoding 
+ FROM transfer_tasks_dlq WHERE shard_id = ? AND task_id > ? ORDER BY task_id LIMIT ?`
+
+	deleteTransferTaskDLQQuery = `DELETE FROM transfer_tasks_dlq WHERE shard_id = ? AND task_id = ?`
+
+	createTimerTasksDLQQuery = `INSERT INTO timer_tasks_dlq (shard_id, visibility_timestamp, task_id, data, data_encoding)
+  VALUES (:shard_id, :visibility_timestamp, :task_id, :data, :data_encoding)`
+
+	getTimerTasksDLQQuery = `SELECT visibility_timestamp, task_id, data, data_encoding FROM timer_tasks_dlq 
+  WHERE shard_id = ? 
+  AND ((visibility_timestamp >= ? AND task_id >= ?) OR visibility_timestamp > ?) 
+  ORDER BY visibility_timestamp,task_id LIMIT ?`
+
+	deleteTimerTaskDLQQuery = `DELETE FROM timer_tasks_dlq WHERE shard_id = ? AND visibility_timestamp = ? AND task_id = ?`
+
 	createReplicationTasksQuery = `INSERT INTO replication_tasks (shard_id, task_id, data, data_encoding) 
   VALUES(:shard_id, :task_id, :data, :data_encoding)`
 
@@ -162,6 +184,13 @@ func (mdb *db) SelectFromExecutions(filter *sqlplugin.ExecutionsFilter) (*sqlplu
 	return &row, err
 }
 
+// ListFromExecutions reads a page of rows of a shard from executions table
+func (mdb *db) ListFromExecutions(filter *sqlplugin.ExecutionsFilter) ([]sqlplugin.ExecutionsRow, error) {
+	var rows []sqlplugin.ExecutionsRow
+	err := mdb.conn.Select(&rows, listExecutionsQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID, *filter.PageSize)
+	return rows, err
+}
+
 // DeleteFromExecutions deletes a single row from executions table
 func (mdb *db) DeleteFromExecutions(filter *sqlplugin.ExecutionsFilter) (sql.Result, error) {
 	return mdb.conn.Exec(deleteExecutionQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
@@ -241,6 +270,26 @@ func (mdb *db) DeleteFromTransferTasks(filter *sqlplugin.TransferTasksFilter) (s
 	return mdb.conn.Exec(deleteTransferTaskQuery, filter.ShardID, *filter.TaskID)
 }
 
+// InsertIntoTransferTasksDLQ inserts a row into transfer_tasks_dlq table
+func (mdb *db) InsertIntoTransferTasksDLQ(row *sqlplugin.TransferTasksRow) (sql.Result, error) {
+	return mdb.conn.NamedExec(createTransferTasksDLQQuery, row)
+}
+
+// SelectFromTransferTasksDLQ reads one or more rows from transfer_tasks_dlq table
+func (mdb *db) SelectFromTransferTasksDLQ(filter *sqlplugin.TransferTasksFilter) ([]sqlplugin.TransferTasksRow, error) {
+	var rows []sqlplugin.TransferTasksRow
+	err := mdb.conn.Select(&rows, getTransferTasksDLQQuery, filter.ShardID, *filter.MinTaskID, *filter.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	return rows, err
+}
+
+// DeleteFromTransferTasksDLQ deletes a row from transfer_tasks_dlq table
+func (mdb *db) DeleteFromTransferTasksDLQ(filter *sqlplugin.TransferTasksFilter) (sql.Result, error) {
+	return mdb.conn.Exec(deleteTransferTaskDLQQuery, filter.ShardID, *filter.TaskID)
+}
+
 // InsertIntoTimerTasks inserts one or more rows into timer_tasks table
 func (mdb *db) InsertIntoTimerTasks(rows []sqlplugin.TimerTasksRow) (sql.Result, error) {
 	for i := range rows {
@@ -276,6 +325,33 @@ func (mdb *db) DeleteFromTimerTasks(filter *sqlplugin.TimerTasksFilter) (sql.Res
 	return mdb.conn.Exec(deleteTimerTaskQuery, filter.ShardID, *filter.VisibilityTimestamp, filter.TaskID)
 }
 
+// InsertIntoTimerTasksDLQ inserts a row into timer_tasks_dlq table
+func (mdb *db) InsertIntoTimerTasksDLQ(row *sqlplugin.TimerTasksRow) (sql.Result, error) {
+	row.VisibilityTimestamp = mdb.converter.ToMySQLDateTime(row.VisibilityTimestamp)
+	return mdb.conn.NamedExec(createTimerTasksDLQQuery, row)
+}
+
+// SelectFromTimerTasksDLQ reads one or more rows from timer_tasks_dlq table
+func (mdb *db) SelectFromTimerTasksDLQ(filter *sqlplugin.TimerTasksFilter) ([]sqlplugin.TimerTasksRow, error) {
+	var rows []sqlplugin.TimerTasksRow
+	*filter.MinVisibilityTimestamp = mdb.converter.ToMySQLDateTime(*filter.MinVisibilityTimestamp)
+	err := mdb.conn.Select(&rows, getTimerTasksDLQQuery, filter.ShardID, *filter.MinVisibilityTimestamp,
+		filter.TaskID, *filter.MinVisibilityTimestamp, *filter.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		rows[i].VisibilityTimestamp = mdb.converter.FromMySQLDateTime(rows[i].VisibilityTimestamp)
+	}
+	return rows, err
+}
+
+// DeleteFromTimerTasksDLQ deletes a row from timer_tasks_dlq table
+func (mdb *db) DeleteFromTimerTasksDLQ(filter *sqlplugin.TimerTasksFilter) (sql.Result, error) {
+	*filter.VisibilityTimestamp = mdb.converter.ToMySQLDateTime(*filter.VisibilityTimestamp)
+	return mdb.conn.Exec(deleteTimerTaskDLQQuery, filter.ShardID, *filter.VisibilityTimestamp, filter.TaskID)
+}
+
 // InsertIntoBufferedEvents inserts one or more rows into buffered_events table
 func (mdb *db) InsertIntoBufferedEvents(rows []sqlplugin.BufferedEventsRow) (sql.Result, error) {
 	return mdb.conn.NamedExec(createBufferedEventsQuery, rows)
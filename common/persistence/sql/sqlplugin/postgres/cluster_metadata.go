@@ -56,6 +56,10 @@ cluster_membership
 WHERE host_id = ANY(ARRAY(
 SELECT host_id FROM cluster_membership WHERE record_expiry < $1 LIMIT $2))`
 
+	templateDeleteClusterMembership = `DELETE FROM
+cluster_membership 
+WHERE host_id = $1`
+
 	templateGetClusterMembership = `SELECT host_id, rpc_address, rpc_port, role, session_start, last_heartbeat, record_expiry, insertion_order FROM
 cluster_membership`
 
@@ -181,3 +185,7 @@ func (pdb *db) PruneClusterMembership(filter *sqlplugin.PruneClusterMembershipFi
 		filter.PruneRecordsBefore,
 		filter.MaxRecordsAffected)
 }
+
+func (pdb *db) DeleteClusterMembership(hostID []byte) (sql.Result, error) {
+	return pdb.conn.Exec(templateDeleteClusterMembership, hostID)
+}
@@ -39,6 +39,10 @@ const (
 	getExecutionQuery = `SELECT ` + executionsColumns + ` FROM executions
  WHERE shard_id = $1 AND domain_id = $2 AND workflow_id = $3 AND run_id = $4`
 
+	listExecutionsQuery = `SELECT shard_id, domain_id, workflow_id, run_id FROM executions
+ WHERE shard_id = $1 AND (domain_id, workflow_id, run_id) > ($2, $3, $4)
+ ORDER BY domain_id, workflow_id, run_id LIMIT $5`
+
 	deleteExecutionQuery = `DELETE FROM executions 
  WHERE shard_id = $1 AND domain_id = $2 AND workflow_id = $3 AND run_id = $4`
 
@@ -100,6 +104,24 @@ workflow_id = :workflow_id
 	deleteTimerTaskQuery      = `DELETE FROM timer_tasks WHERE shard_id = $1 AND visibility_timestamp = $2 AND task_id = $3`
 	rangeDeleteTimerTaskQuery = `DELETE FROM timer_tasks WHERE shard_id = $1 AND visibility_timestamp >= $2 AND visibility_timestamp < $3`
 
+	createTransferTasksDLQQuery = `INSERT INTO transfer_tasks_dlq(shard_id, task_id, data, data_encoding) 
+ VALUES(:shard_id, :task_id, :data, :data_encoding)`
+
+	getTransferTasksDLQQuery = `SELECT task_id, data, data_encoding 
+ FROM transfer_tasks_dlq WHERE shard_id = $1 AND task_id > $2 ORDER BY task_id LIMIT $3`
+
+	deleteTransferTaskDLQQuery = `DELETE FROM transfer_tasks_dlq WHERE shard_id = $1 AND task_id = $2`
+
+	createTimerTasksDLQQuery = `INSERT INTO timer_tasks_dlq (shard_id, visibility_timestamp, task_id, data, data_encoding)
+  VALUES (:shard_id, :visibility_timestamp, :task_id, :data, :data_encoding)`
+
+	getTimerTasksDLQQuery = `SELECT visibility_timestamp, task_id, data, data_encoding FROM timer_tasks_dlq 
+  WHERE shard_id = $1 
+  AND ((visibility_timestamp >= $2 AND task_id >= $3) OR visibility_timestamp > $4) 
+  ORDER BY visibility_timestamp,task_id LIMIT $5`
+
+	deleteTimerTaskDLQQuery = `DELETE FROM timer_tasks_dlq WHERE shard_id = $1 AND visibility_timestamp = $2 AND task_id = $3`
+
 	createReplicationTasksQuery = `INSERT INTO replication_tasks (shard_id, task_id, data, data_encoding) 
   VALUES(:shard_id, :task_id, :data, :data_encoding)`
 
@@ -161,6 +183,13 @@ func (pdb *db) SelectFromExecutions(filter *sqlplugin.ExecutionsFilter) (*sqlplu
 	return &row, err
 }
 
+// ListFromExecutions reads a page of rows of a shard from executions table
+func (pdb *db) ListFromExecutions(filter *sqlplugin.ExecutionsFilter) ([]sqlplugin.ExecutionsRow, error) {
+	var rows []sqlplugin.ExecutionsRow
+	err := pdb.conn.Select(&rows, listExecutionsQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID, *filter.PageSize)
+	return rows, err
+}
+
 // DeleteFromExecutions deletes a single row from executions table
 func (pdb *db) DeleteFromExecutions(filter *sqlplugin.ExecutionsFilter) (sql.Result, error) {
 	return pdb.conn.Exec(deleteExecutionQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
@@ -240,6 +269,26 @@ func (pdb *db) DeleteFromTransferTasks(filter *sqlplugin.TransferTasksFilter) (s
 	return pdb.conn.Exec(deleteTransferTaskQuery, filter.ShardID, *filter.TaskID)
 }
 
+// InsertIntoTransferTasksDLQ inserts a row into transfer_tasks_dlq table
+func (pdb *db) InsertIntoTransferTasksDLQ(row *sqlplugin.TransferTasksRow) (sql.Result, error) {
+	return pdb.conn.NamedExec(createTransferTasksDLQQuery, row)
+}
+
+// SelectFromTransferTasksDLQ reads one or more rows from transfer_tasks_dlq table
+func (pdb *db) SelectFromTransferTasksDLQ(filter *sqlplugin.TransferTasksFilter) ([]sqlplugin.TransferTasksRow, error) {
+	var rows []sqlplugin.TransferTasksRow
+	err := pdb.conn.Select(&rows, getTransferTasksDLQQuery, filter.ShardID, *filter.MinTaskID, *filter.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	return rows, err
+}
+
+// DeleteFromTransferTasksDLQ deletes a row from transfer_tasks_dlq table
+func (pdb *db) DeleteFromTransferTasksDLQ(filter *sqlplugin.TransferTasksFilter) (sql.Result, error) {
+	return pdb.conn.Exec(deleteTransferTaskDLQQuery, filter.ShardID, *filter.TaskID)
+}
+
 // InsertIntoTimerTasks inserts one or more rows into timer_tasks table
 func (pdb *db) InsertIntoTimerTasks(rows []sqlplugin.TimerTasksRow) (sql.Result, error) {
 	for i := range rows {
@@ -275,6 +324,33 @@ func (pdb *db) DeleteFromTimerTasks(filter *sqlplugin.TimerTasksFilter) (sql.Res
 	return pdb.conn.Exec(deleteTimerTaskQuery, filter.ShardID, *filter.VisibilityTimestamp, filter.TaskID)
 }
 
+// InsertIntoTimerTasksDLQ inserts a row into timer_tasks_dlq table
+func (pdb *db) InsertIntoTimerTasksDLQ(row *sqlplugin.TimerTasksRow) (sql.Result, error) {
+	row.VisibilityTimestamp = pdb.converter.ToPostgresDateTime(row.VisibilityTimestamp)
+	return pdb.conn.NamedExec(createTimerTasksDLQQuery, row)
+}
+
+// SelectFromTimerTasksDLQ reads one or more rows from timer_tasks_dlq table
+func (pdb *db) SelectFromTimerTasksDLQ(filter *sqlplugin.TimerTasksFilter) ([]sqlplugin.TimerTasksRow, error) {
+	var rows []sqlplugin.TimerTasksRow
+	*filter.MinVisibilityTimestamp = pdb.converter.ToPostgresDateTime(*filter.MinVisibilityTimestamp)
+	err := pdb.conn.Select(&rows, getTimerTasksDLQQuery, filter.ShardID, *filter.MinVisibilityTimestamp,
+		filter.TaskID, *filter.MinVisibilityTimestamp, *filter.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		rows[i].VisibilityTimestamp = pdb.converter.FromPostgresDateTime(rows[i].VisibilityTimestamp)
+	}
+	return rows, err
+}
+
+// DeleteFromTimerTasksDLQ deletes a row from timer_tasks_dlq table
+func (pdb *db) DeleteFromTimerTasksDLQ(filter *sqlplugin.TimerTasksFilter) (sql.Result, error) {
+	*filter.VisibilityTimestamp = pdb.converter.ToPostgresDateTime(*filter.VisibilityTimestamp)
+	return pdb.conn.Exec(deleteTimerTaskDLQQuery, filter.ShardID, *filter.VisibilityTimestamp, filter.TaskID)
+}
+
 // InsertIntoBufferedEvents inserts one or more rows into buffered_events table
 func (pdb *db) InsertIntoBufferedEvents(rows []sqlplugin.BufferedEventsRow) (sql.Result, error) {
 	return pdb.conn.NamedExec(createBufferedEventsQuery, rows)
@@ -162,6 +162,7 @@ func New(
 	}
 
 	dynamicCollection := dynamicconfig.NewCollection(params.DynamicConfig, logger)
+	dynamicconfig.RegisterDebugHandler(serviceName, dynamicCollection)
 	clientBean, err := client.NewClientBean(
 		client.NewRPCClientFactory(
 			params.RPCFactory,
@@ -198,7 +199,11 @@ func New(
 		return nil, err
 	}
 
-	persistenceBean, err := persistenceClient.NewBeanFromFactory(persistenceClient.NewFactory(
+	persistenceFactoryProvider := params.PersistenceFactoryProvider
+	if persistenceFactoryProvider == nil {
+		persistenceFactoryProvider = persistenceClient.NewFactory
+	}
+	persistenceBean, err := persistenceClient.NewBeanFromFactory(persistenceFactoryProvider(
 		&params.PersistenceConfig,
 		params.ClusterMetadata.GetCurrentClusterName(),
 		params.MetricsClient,
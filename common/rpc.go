@@ -46,6 +46,10 @@ const (
 	// ClientImplHeaderName refers to the name of the
 	// header that contains the client implementation
 	ClientImplHeaderName = "cadence-client-name"
+	// DelayStartSecondsHeaderName refers to the name of the
+	// header that contains the number of seconds the first
+	// decision of a started workflow is delayed by
+	DelayStartSecondsHeaderName = "cadence-delay-start-seconds"
 	// EnforceDCRedirection refers to a boolean string of whether
 	// to enforce DCRedirection(auto-forwarding)
 	// Will be removed in the future: https://github.com/uber/cadence/issues/2304
@@ -76,3 +80,22 @@ func AggregateYarpcOptions(ctx context.Context, opts ...yarpc.CallOption) []yarp
 	result = append(result, opts...)
 	return result
 }
+
+// ChainUnaryServerInterceptors combines the interceptors into one, the first interceptor is the outermost one
+func ChainUnaryServerInterceptors(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+		return chained(ctx, req)
+	}
+}
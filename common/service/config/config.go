@@ -39,6 +39,9 @@ const (
 	ReplicationConsumerTypeKafka = "kafka"
 	// ReplicationConsumerTypeRPC means pulling source DC for replication tasks.
 	ReplicationConsumerTypeRPC = "rpc"
+
+	// TracingExporterLog means finished spans are written to the service log.
+	TracingExporterLog = "log"
 )
 
 type (
@@ -67,6 +70,13 @@ type (
 		DynamicConfigClient dynamicconfig.FileBasedClientConfig `yaml:"dynamicConfigClient"`
 		// DomainDefaults is the default config for every domain
 		DomainDefaults DomainDefaults `yaml:"domainDefaults"`
+		// Tracing is the config for distributed tracing
+		Tracing Tracing `yaml:"tracing"`
+		// Audit is the config for the audit log of mutating frontend API calls
+		Audit Audit `yaml:"audit"`
+
+		// secrets are the values read from secret files, they are redacted when the config is printed
+		secrets map[string]struct{}
 	}
 
 	// Service contains the service specific config items
@@ -77,6 +87,16 @@ type (
 		Metrics Metrics `yaml:"metrics"`
 		// PProf is the PProf configuration
 		PProf PProf `yaml:"pprof"`
+		// Health is the configuration of the health and readiness endpoints
+		Health Health `yaml:"health"`
+	}
+
+	// Health contains the config items of the health and readiness endpoints
+	Health struct {
+		// Port is the port on which the HTTP /health and /ready endpoints bind to, they are disabled when not set
+		Port int `yaml:"port"`
+		// CheckInterval is how often the readiness of the service is checked
+		CheckInterval time.Duration `yaml:"checkInterval"`
 	}
 
 	// PProf contains the rpc config items
@@ -85,6 +105,34 @@ type (
 		Port int `yaml:"port"`
 	}
 
+	// Tracing contains the distributed tracing config items
+	Tracing struct {
+		// Exporter is where finished spans are sent to, tracing is disabled if empty.
+		// Only TracingExporterLog is supported at the moment.
+		Exporter string `yaml:"exporter"`
+	}
+
+	// Audit contains the config items of the audit log
+	Audit struct {
+		// Sink is where audit records are written to, the audit log is disabled if empty.
+		// Supported sinks are "file" and "persistence". The file sink is local to each frontend host,
+		// so listing audit records only returns the calls served by the host handling the list call;
+		// use the persistence sink for a cluster wide audit log.
+		Sink string `yaml:"sink"`
+		// File is the config of the file sink
+		File AuditFileSink `yaml:"file"`
+	}
+
+	// AuditFileSink contains the config items of the rotating audit file
+	AuditFileSink struct {
+		// Path is the file audit records are appended to, rotated files get a numeric suffix
+		Path string `yaml:"path"`
+		// MaxSizeMB is the size after which the file is rotated
+		MaxSizeMB int `yaml:"maxSizeMB"`
+		// MaxBackups is the number of rotated files to keep
+		MaxBackups int `yaml:"maxBackups"`
+	}
+
 	// RPC contains the rpc config items
 	RPC struct {
 		// Port is the port  on which the channel will bind to
@@ -117,6 +165,10 @@ type (
 		BootstrapFile string `yaml:"bootstrapFile"`
 		// MaxJoinDuration is the max wait time to join the ring
 		MaxJoinDuration time.Duration `yaml:"maxJoinDuration"`
+		// HeartbeatInterval is how often hosts heartbeat into the cluster membership table when BootstrapMode is database
+		HeartbeatInterval time.Duration `yaml:"heartbeatInterval"`
+		// HeartbeatTimeout is how long hosts stay in the ring without heartbeat when BootstrapMode is database
+		HeartbeatTimeout time.Duration `yaml:"heartbeatTimeout"`
 		// Custom discovery provider, cannot be specified through yaml
 		DiscoveryProvider discovery.DiscoverProvider `yaml:"-"`
 		// broadcastAddress is used as the address that is communicated to remote nodes to connect on.
@@ -145,6 +197,12 @@ type (
 		VisibilityConfig *VisibilityConfig `yaml:"-" json:"-"`
 		// TransactionSizeLimit is the largest allowed transaction size
 		TransactionSizeLimit dynamicconfig.IntPropertyFn `yaml:"-" json:"-"`
+		// FaultInjection is config for injecting faults into persistence calls, nil disables it
+		FaultInjection *FaultInjectionConfig `yaml:"-" json:"-"`
+		// HistoryPayloadStore is the blob store large history event payloads are offloaded to, nil disables offloading
+		HistoryPayloadStore *BlobStore `yaml:"historyPayloadStore"`
+		// HistoryPayloadOffloadThreshold is the size above which history event payloads are offloaded
+		HistoryPayloadOffloadThreshold dynamicconfig.IntPropertyFn `yaml:"-" json:"-"`
 	}
 
 	// DataStore is the configuration for a single datastore
@@ -177,6 +235,20 @@ type (
 		ValidSearchAttributes dynamicconfig.MapPropertyFn `yaml:"-" json:"-"`
 	}
 
+	// FaultInjectionConfig is config for injecting faults into persistence calls
+	FaultInjectionConfig struct {
+		// Seed is the seed of the injected faults, 0 means a random seed
+		Seed dynamicconfig.IntPropertyFn `yaml:"-" json:"-"`
+		// ErrorRate is the rate of calls failing with an injected error
+		ErrorRate dynamicconfig.FloatPropertyFn `yaml:"-" json:"-"`
+		// ErrorType is the type of the injected error
+		ErrorType dynamicconfig.StringPropertyFn `yaml:"-" json:"-"`
+		// LatencyRate is the rate of calls delayed by the injected latency
+		LatencyRate dynamicconfig.FloatPropertyFn `yaml:"-" json:"-"`
+		// Latency is the injected latency
+		Latency dynamicconfig.DurationPropertyFn `yaml:"-" json:"-"`
+	}
+
 	// Cassandra contains configuration to connect to Cassandra cluster
 	Cassandra struct {
 		// Hosts is a csv of cassandra endpoints
@@ -358,6 +430,40 @@ type (
 	FilestoreArchiver struct {
 		FileMode string `yaml:"fileMode"`
 		DirMode  string `yaml:"dirMode"`
+		// ColumnarVisibility enables compacting the archived visibility records of each domain into daily Parquet files
+		ColumnarVisibility bool `yaml:"columnarVisibility"`
+	}
+
+	// BlobStore contains the config for an external blob store, exactly one of the stores must be set
+	BlobStore struct {
+		// Filestore contains the config for a blob store on the local or a mounted filesystem
+		Filestore *FilestoreBlobStore `yaml:"filestore"`
+		// S3 contains the config for an S3 compatible blob store
+		S3 *S3BlobStore `yaml:"s3"`
+	}
+
+	// FilestoreBlobStore contains the config for filestore blob store
+	FilestoreBlobStore struct {
+		// Directory is the root directory of the blobs
+		Directory string `yaml:"directory"`
+		FileMode  string `yaml:"fileMode"`
+		DirMode   string `yaml:"dirMode"`
+	}
+
+	// S3BlobStore contains the config for S3 compatible blob store
+	S3BlobStore struct {
+		// Endpoint is the URL of the S3 compatible service, e.g. https://s3.us-east-1.amazonaws.com
+		Endpoint string `yaml:"endpoint"`
+		// Region is the region used to sign requests
+		Region string `yaml:"region"`
+		// Bucket is the bucket the blobs are stored in, it must exist
+		Bucket string `yaml:"bucket"`
+		// KeyPrefix is prepended to the key of every blob
+		KeyPrefix string `yaml:"keyPrefix"`
+		// AccessKeyID and SecretAccessKey are the credentials, they default to
+		// the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables
+		AccessKeyID     string `yaml:"accessKeyID"`
+		SecretAccessKey string `yaml:"secretAccessKey"`
 	}
 
 	// PublicClient is config for connecting to cadence frontend
@@ -410,8 +516,8 @@ func (c *Config) Validate() error {
 	return c.Archival.Validate(&c.DomainDefaults.Archival)
 }
 
-// String converts the config object into a string
+// String converts the config object into a string, passwords and secrets are redacted
 func (c *Config) String() string {
-	out, _ := json.MarshalIndent(c, "", "    ")
+	out, _ := json.MarshalIndent(c.redact(), "", "    ")
 	return string(out)
 }
@@ -63,6 +63,9 @@ const (
 //       env.yaml   -- environment is one of the input params ex-development
 //         env_az.yaml -- zone is another input param
 //
+// When loading the server Config, ${ENV_VAR:default} variables and file:// secret
+// references in the persistence, kafka, archival and rpc sections are then resolved,
+// an error is returned if a variable is neither set nor has a default value
 func Load(env string, configDir string, zone string, config interface{}) error {
 
 	if len(env) == 0 {
@@ -93,6 +96,12 @@ func Load(env string, configDir string, zone string, config interface{}) error {
 		}
 	}
 
+	if cfg, ok := config.(*Config); ok {
+		if err := cfg.substitute(os.LookupEnv); err != nil {
+			return err
+		}
+	}
+
 	return validator.Validate(config)
 }
 
@@ -20,7 +20,12 @@
 
 package config
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
 
 const (
 	// StoreTypeSQL refers to sql based storage as persistence store
@@ -75,3 +80,15 @@ func (c *Persistence) Validate() error {
 func (c *Persistence) IsAdvancedVisibilityConfigExist() bool {
 	return len(c.AdvancedVisibilityStore) != 0
 }
+
+// GetHistoryPayloadOffloadThreshold returns the size above which history event payloads are offloaded,
+// it is always 0 if no history payload store is configured
+func (c *Persistence) GetHistoryPayloadOffloadThreshold() dynamicconfig.IntPropertyFn {
+	if c.HistoryPayloadStore == nil {
+		return dynamicconfig.GetIntPropertyFn(0)
+	}
+	if c.HistoryPayloadOffloadThreshold == nil {
+		return dynamicconfig.GetIntPropertyFn(common.DefaultHistoryPayloadOffloadThreshold)
+	}
+	return c.HistoryPayloadOffloadThreshold
+}
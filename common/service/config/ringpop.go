@@ -56,6 +56,9 @@ const (
 	// BootstrapModeDNS represents a list of hosts passed in the configuration
 	// to be resolved, and the resulting addresses are used for bootstrap
 	BootstrapModeDNS
+	// BootstrapModeDatabase represents membership based on the heartbeats of hosts
+	// in the cluster membership table instead of ringpop gossip
+	BootstrapModeDatabase
 )
 
 const (
@@ -123,6 +126,8 @@ func parseBootstrapMode(
 		return BootstrapModeCustom, nil
 	case "dns":
 		return BootstrapModeDNS, nil
+	case "database":
+		return BootstrapModeDatabase, nil
 	}
 	return BootstrapModeNone, errors.New("invalid or no ringpop bootstrap mode")
 }
@@ -144,6 +149,7 @@ func validateBootstrapMode(
 		if rpConfig.DiscoveryProvider == nil {
 			return fmt.Errorf("ringpop bootstrapMode is set to custom but discoveryProvider is nil")
 		}
+	case BootstrapModeDatabase:
 	default:
 		return fmt.Errorf("ringpop config with unknown boostrap mode")
 	}
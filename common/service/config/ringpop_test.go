@@ -91,6 +91,17 @@ func (s *RingpopSuite) TestCustomMode() {
 	s.NotNil(f)
 }
 
+func (s *RingpopSuite) TestDatabaseMode() {
+	var cfg Ringpop
+	err := yaml.Unmarshal([]byte(getDatabaseConfig()), &cfg)
+	s.Nil(err)
+	s.Equal("test", cfg.Name)
+	s.Equal(BootstrapModeDatabase, cfg.BootstrapMode)
+	s.Equal(time.Second*5, cfg.HeartbeatInterval)
+	s.Equal(time.Second*30, cfg.HeartbeatTimeout)
+	s.Nil(cfg.validate())
+}
+
 type mockResolver struct {
 	Hosts map[string][]string
 }
@@ -196,6 +207,13 @@ bootstrapMode: "custom"
 maxJoinDuration: 30s`
 }
 
+func getDatabaseConfig() string {
+	return `name: "test"
+bootstrapMode: "database"
+heartbeatInterval: 5s
+heartbeatTimeout: 30s`
+}
+
 func getDNSConfig() string {
 	return `name: "test"
 bootstrapMode: "dns"
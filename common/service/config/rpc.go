@@ -32,6 +32,7 @@ import (
 
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/tracing"
 )
 
 // RPCFactory is an implementation of service.RPCFactory interface
@@ -171,7 +172,7 @@ func (d *RPCFactory) getListenIP() net.IP {
 
 // CreateGRPCConnection creates connection for gRPC calls
 func (d *RPCFactory) CreateGRPCConnection(hostName string) *grpc.ClientConn {
-	connection, err := grpc.Dial(hostName, grpc.WithInsecure())
+	connection, err := grpc.Dial(hostName, grpc.WithInsecure(), grpc.WithUnaryInterceptor(tracing.NewClientInterceptor()))
 	if err != nil {
 		d.logger.Fatal("Failed to create gRPC connection", tag.Error(err))
 	}
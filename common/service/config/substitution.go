@@ -0,0 +1,179 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// secretFilePrefix is the prefix of the config values read from a file, ex- file:///etc/secrets/db-password
+	secretFilePrefix = "file://"
+	// redactedValue replaces the secret values when the config is printed
+	redactedValue = "******"
+)
+
+type (
+	// substitutor expands the environment variables and secret file references of config values
+	substitutor struct {
+		lookupEnv  func(string) (string, bool)
+		unresolved map[string]struct{}
+		secrets    map[string]struct{}
+		err        error
+	}
+)
+
+// envVarPattern matches ${ENV_VAR} and ${ENV_VAR:default}
+var envVarPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:([^}]*))?\}`)
+
+// substitute expands the ${ENV_VAR:default} variables and resolves the file:// secret references
+// of the string values in the persistence, kafka, archival and rpc sections of the config, the
+// other sections are left verbatim as they may legitimately contain such values, ex- archival URIs
+func (c *Config) substitute(lookupEnv func(string) (string, bool)) error {
+	s := &substitutor{
+		lookupEnv:  lookupEnv,
+		unresolved: make(map[string]struct{}),
+		secrets:    make(map[string]struct{}),
+	}
+	s.walk(reflect.ValueOf(&c.Persistence))
+	s.walk(reflect.ValueOf(&c.Kafka))
+	s.walk(reflect.ValueOf(&c.Archival))
+	for name, svc := range c.Services {
+		s.walk(reflect.ValueOf(&svc.RPC))
+		c.Services[name] = svc
+	}
+	if s.err != nil {
+		return s.err
+	}
+	if len(s.unresolved) > 0 {
+		var names []string
+		for name := range s.unresolved {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unresolved config variables: %v", strings.Join(names, ", "))
+	}
+	c.secrets = s.secrets
+	return nil
+}
+
+func (s *substitutor) walk(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			s.walk(v.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				s.walk(v.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			s.walk(v.Index(i))
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			// map values are not addressable, they are expanded on a copy which is stored back
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(key))
+			s.walk(elem)
+			v.SetMapIndex(key, elem)
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(s.expand(v.String()))
+		}
+	}
+}
+
+func (s *substitutor) expand(value string) string {
+	value = envVarPattern.ReplaceAllStringFunc(value, func(match string) string {
+		groups := envVarPattern.FindStringSubmatch(match)
+		if envValue, ok := s.lookupEnv(groups[1]); ok {
+			return envValue
+		}
+		if groups[2] != "" {
+			return groups[3]
+		}
+		s.unresolved[groups[1]] = struct{}{}
+		return match
+	})
+
+	if !strings.HasPrefix(value, secretFilePrefix) {
+		return value
+	}
+	// This is tagged nosec because the secret files are referenced by the operator supplied config
+	// #nosec
+	data, err := ioutil.ReadFile(strings.TrimPrefix(value, secretFilePrefix))
+	if err != nil {
+		if s.err == nil {
+			s.err = fmt.Errorf("unable to read config secret: %v", err)
+		}
+		return value
+	}
+	secret := strings.TrimRight(string(data), "\r\n")
+	if secret != "" {
+		s.secrets[secret] = struct{}{}
+	}
+	return secret
+}
+
+// redact returns a copy of the config values with the passwords and the values read from secret files replaced
+func (c *Config) redact() interface{} {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil
+	}
+	var values interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil
+	}
+	return redactValue("", values, c.secrets)
+}
+
+func redactValue(key string, value interface{}, secrets map[string]struct{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, elem := range v {
+			v[k] = redactValue(k, elem, secrets)
+		}
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = redactValue(key, elem, secrets)
+		}
+	case string:
+		if _, ok := secrets[v]; ok {
+			return redactedValue
+		}
+		if v != "" && strings.Contains(strings.ToLower(key), "password") {
+			return redactedValue
+		}
+	}
+	return value
+}
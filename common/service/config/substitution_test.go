@@ -0,0 +1,152 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common/auth"
+	"github.com/temporalio/temporal/common/messaging"
+)
+
+type (
+	substitutionSuite struct {
+		*require.Assertions
+		suite.Suite
+	}
+)
+
+func TestSubstitutionSuite(t *testing.T) {
+	suite.Run(t, new(substitutionSuite))
+}
+
+func (s *substitutionSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *substitutionSuite) lookupEnv(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
+
+func (s *substitutionSuite) TestExpandEnvVariables() {
+	cfg := &Config{
+		Persistence: Persistence{
+			DataStores: map[string]DataStore{
+				"default": {SQL: &SQL{
+					User:              "${DB_USER}",
+					Password:          "${DB_PASSWORD:secret}",
+					ConnectAddr:       "${DB_HOST:127.0.0.1}:${DB_PORT:3306}",
+					ConnectAttributes: map[string]string{"tx_isolation": "${TX_ISOLATION:READ-COMMITTED}"},
+				}},
+			},
+		},
+		Kafka: messaging.KafkaConfig{
+			Clusters: map[string]messaging.ClusterConfig{"test": {Brokers: []string{"${KAFKA_BROKER}"}}},
+		},
+		Services: map[string]Service{
+			"frontend": {RPC: RPC{BindOnIP: "${BIND_IP}"}},
+		},
+		DomainDefaults: DomainDefaults{
+			Archival: ArchivalDomainDefaults{History: HistoryArchivalDomainDefaults{URI: "${NOT_SUBSTITUTED}"}},
+		},
+	}
+	err := cfg.substitute(s.lookupEnv(map[string]string{
+		"DB_USER":      "temporal",
+		"DB_PORT":      "3307",
+		"KAFKA_BROKER": "kafka:9092",
+		"BIND_IP":      "0.0.0.0",
+	}))
+	s.NoError(err)
+
+	sql := cfg.Persistence.DataStores["default"].SQL
+	s.Equal("temporal", sql.User)
+	s.Equal("secret", sql.Password)
+	s.Equal("127.0.0.1:3307", sql.ConnectAddr)
+	s.Equal("READ-COMMITTED", sql.ConnectAttributes["tx_isolation"])
+	s.Equal([]string{"kafka:9092"}, cfg.Kafka.Clusters["test"].Brokers)
+	s.Equal("0.0.0.0", cfg.Services["frontend"].RPC.BindOnIP)
+	s.Equal("${NOT_SUBSTITUTED}", cfg.DomainDefaults.Archival.History.URI)
+}
+
+func (s *substitutionSuite) TestUnresolvedVariables() {
+	cfg := &Config{
+		Persistence: Persistence{
+			DataStores: map[string]DataStore{
+				"default": {Cassandra: &Cassandra{User: "${DB_USER}", Password: "${DB_PASSWORD}"}},
+			},
+		},
+		Archival: Archival{History: HistoryArchival{Status: "${ARCHIVAL_STATUS:}"}},
+	}
+	err := cfg.substitute(s.lookupEnv(nil))
+	s.EqualError(err, "unresolved config variables: DB_PASSWORD, DB_USER")
+}
+
+func (s *substitutionSuite) TestSecretFiles() {
+	dir, err := ioutil.TempDir("", "substitution.testSecretFiles")
+	s.NoError(err)
+	defer os.RemoveAll(dir)
+	s.NoError(ioutil.WriteFile(path(dir, "password"), []byte("s3cr3t\n"), fileMode))
+
+	cfg := &Config{
+		Persistence: Persistence{
+			DataStores: map[string]DataStore{
+				"default": {Cassandra: &Cassandra{
+					Password: "file://${SECRETS_DIR}/password",
+					TLS:      &auth.TLS{KeyFile: "/etc/certs/key.pem"},
+				}},
+			},
+		},
+	}
+	s.NoError(cfg.substitute(s.lookupEnv(map[string]string{"SECRETS_DIR": dir})))
+	s.Equal("s3cr3t", cfg.Persistence.DataStores["default"].Cassandra.Password)
+	s.Equal("/etc/certs/key.pem", cfg.Persistence.DataStores["default"].Cassandra.TLS.KeyFile)
+
+	cfg.Persistence.DataStores["default"].Cassandra.Password = "file://" + path(dir, "missing")
+	s.Error(cfg.substitute(s.lookupEnv(nil)))
+}
+
+func (s *substitutionSuite) TestStringRedactsSecrets() {
+	cfg := &Config{
+		Persistence: Persistence{
+			DataStores: map[string]DataStore{
+				"default": {SQL: &SQL{User: "temporal", Password: "plaintext"}},
+			},
+		},
+		Kafka: messaging.KafkaConfig{
+			TLS: auth.TLS{CertFile: "file-secret"},
+		},
+		secrets: map[string]struct{}{"file-secret": {}},
+	}
+	out := cfg.String()
+	s.Contains(out, "temporal")
+	s.NotContains(out, "plaintext")
+	s.NotContains(out, "file-secret")
+	s.Contains(out, redactedValue)
+	s.Equal("plaintext", cfg.Persistence.DataStores["default"].SQL.Password)
+}
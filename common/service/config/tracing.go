@@ -0,0 +1,42 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/tracing"
+)
+
+// NewTracer creates the tracer for the configured exporter, a noop tracer is returned if tracing is disabled
+func (t *Tracing) NewTracer(logger log.Logger) (opentracing.Tracer, error) {
+	switch t.Exporter {
+	case "":
+		return opentracing.NoopTracer{}, nil
+	case TracingExporterLog:
+		return tracing.NewLogTracer(logger), nil
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter: %v", t.Exporter)
+	}
+}
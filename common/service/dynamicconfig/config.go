@@ -32,7 +32,7 @@ import (
 
 // NewCollection creates a new collection
 func NewCollection(client Client, logger log.Logger) *Collection {
-	return &Collection{client, logger, &sync.Map{}}
+	return &Collection{client, logger, &sync.Map{}, getKeyUsage(client)}
 }
 
 // Collection wraps dynamic config client with a closure so that across the code, the config values
@@ -42,12 +42,17 @@ type Collection struct {
 	client Client
 	logger log.Logger
 	keys   *sync.Map
+	usage  *keyUsageSet
 }
 
 func (c *Collection) logNoValue(key Key, err error) {
 	_, loaded := c.keys.LoadOrStore(key, struct{}{})
 	if !loaded {
-		c.logger.Debug("Failed to fetch key from dynamic config", tag.Key(key.String()), tag.Error(err))
+		if err == errKeyNotFound {
+			c.logger.Debug("Failed to fetch key from dynamic config", tag.Key(key.String()), tag.Error(err))
+		} else {
+			c.logger.Warn("Invalid dynamic config value, fallback to default", tag.Key(key.String()), tag.Error(err))
+		}
 	}
 }
 
@@ -110,6 +115,7 @@ func (c *Collection) GetProperty(key Key, defaultValue interface{}) PropertyFn {
 			c.logNoValue(key, err)
 		}
 		c.logValue(key, val, defaultValue)
+		c.recordValue(key, nil, defaultValue)
 		return val
 	}
 }
@@ -125,128 +131,150 @@ func getFilterMap(opts ...FilterOption) map[Filter]interface{} {
 
 // GetIntProperty gets property and asserts that it's an integer
 func (c *Collection) GetIntProperty(key Key, defaultValue int) IntPropertyFn {
+	c.registerKeyType(key, defaultValue)
 	return func(opts ...FilterOption) int {
-		val, err := c.client.GetIntValue(key, getFilterMap(opts...), defaultValue)
+		filters := getFilterMap(opts...)
+		val, err := c.client.GetIntValue(key, filters, defaultValue)
 		if err != nil {
 			c.logNoValue(key, err)
 		}
 		c.logValue(key, val, defaultValue)
+		c.recordValue(key, filters, defaultValue)
 		return val
 	}
 }
 
 // GetIntPropertyFilteredByDomain gets property with domain filter and asserts that it's an integer
 func (c *Collection) GetIntPropertyFilteredByDomain(key Key, defaultValue int) IntPropertyFnWithDomainFilter {
+	c.registerKeyType(key, defaultValue)
 	return func(domain string) int {
-		val, err := c.client.GetIntValue(key, getFilterMap(DomainFilter(domain)), defaultValue)
+		filters := getFilterMap(DomainFilter(domain))
+		val, err := c.client.GetIntValue(key, filters, defaultValue)
 		if err != nil {
 			c.logNoValue(key, err)
 		}
 		c.logValue(key, val, defaultValue)
+		c.recordValue(key, filters, defaultValue)
 		return val
 	}
 }
 
 // GetIntPropertyFilteredByTaskListInfo gets property with taskListInfo as filters and asserts that it's an integer
 func (c *Collection) GetIntPropertyFilteredByTaskListInfo(key Key, defaultValue int) IntPropertyFnWithTaskListInfoFilters {
+	c.registerKeyType(key, defaultValue)
 	return func(domain string, taskList string, taskType int) int {
-		val, err := c.client.GetIntValue(
-			key,
-			getFilterMap(DomainFilter(domain), TaskListFilter(taskList), TaskTypeFilter(taskType)),
-			defaultValue,
-		)
+		filters := getFilterMap(DomainFilter(domain), TaskListFilter(taskList), TaskTypeFilter(taskType))
+		val, err := c.client.GetIntValue(key, filters, defaultValue)
 		if err != nil {
 			c.logNoValue(key, err)
 		}
 		c.logValue(key, val, defaultValue)
+		c.recordValue(key, filters, defaultValue)
 		return val
 	}
 }
 
 // GetFloat64Property gets property and asserts that it's a float64
 func (c *Collection) GetFloat64Property(key Key, defaultValue float64) FloatPropertyFn {
+	c.registerKeyType(key, defaultValue)
 	return func(opts ...FilterOption) float64 {
-		val, err := c.client.GetFloatValue(key, getFilterMap(opts...), defaultValue)
+		filters := getFilterMap(opts...)
+		val, err := c.client.GetFloatValue(key, filters, defaultValue)
 		if err != nil {
 			c.logNoValue(key, err)
 		}
 		c.logValue(key, val, defaultValue)
+		c.recordValue(key, filters, defaultValue)
 		return val
 	}
 }
 
 // GetDurationProperty gets property and asserts that it's a duration
 func (c *Collection) GetDurationProperty(key Key, defaultValue time.Duration) DurationPropertyFn {
+	c.registerKeyType(key, defaultValue)
 	return func(opts ...FilterOption) time.Duration {
-		val, err := c.client.GetDurationValue(key, getFilterMap(opts...), defaultValue)
+		filters := getFilterMap(opts...)
+		val, err := c.client.GetDurationValue(key, filters, defaultValue)
 		if err != nil {
 			c.logNoValue(key, err)
 		}
 		c.logValue(key, val, defaultValue)
+		c.recordValue(key, filters, defaultValue)
 		return val
 	}
 }
 
 // GetDurationPropertyFilteredByDomain gets property with domain filter and asserts that it's a duration
 func (c *Collection) GetDurationPropertyFilteredByDomain(key Key, defaultValue time.Duration) DurationPropertyFnWithDomainFilter {
+	c.registerKeyType(key, defaultValue)
 	return func(domain string) time.Duration {
-		val, err := c.client.GetDurationValue(key, getFilterMap(DomainFilter(domain)), defaultValue)
+		filters := getFilterMap(DomainFilter(domain))
+		val, err := c.client.GetDurationValue(key, filters, defaultValue)
 		if err != nil {
 			c.logNoValue(key, err)
 		}
 		c.logValue(key, val, defaultValue)
+		c.recordValue(key, filters, defaultValue)
 		return val
 	}
 }
 
 // GetDurationPropertyFilteredByTaskListInfo gets property with taskListInfo as filters and asserts that it's a duration
 func (c *Collection) GetDurationPropertyFilteredByTaskListInfo(key Key, defaultValue time.Duration) DurationPropertyFnWithTaskListInfoFilters {
+	c.registerKeyType(key, defaultValue)
 	return func(domain string, taskList string, taskType int) time.Duration {
-		val, err := c.client.GetDurationValue(
-			key,
-			getFilterMap(DomainFilter(domain), TaskListFilter(taskList), TaskTypeFilter(taskType)),
-			defaultValue,
-		)
+		filters := getFilterMap(DomainFilter(domain), TaskListFilter(taskList), TaskTypeFilter(taskType))
+		val, err := c.client.GetDurationValue(key, filters, defaultValue)
 		if err != nil {
 			c.logNoValue(key, err)
 		}
 		c.logValue(key, val, defaultValue)
+		c.recordValue(key, filters, defaultValue)
 		return val
 	}
 }
 
 // GetBoolProperty gets property and asserts that it's an bool
 func (c *Collection) GetBoolProperty(key Key, defaultValue bool) BoolPropertyFn {
+	c.registerKeyType(key, defaultValue)
 	return func(opts ...FilterOption) bool {
-		val, err := c.client.GetBoolValue(key, getFilterMap(opts...), defaultValue)
+		filters := getFilterMap(opts...)
+		val, err := c.client.GetBoolValue(key, filters, defaultValue)
 		if err != nil {
 			c.logNoValue(key, err)
 		}
 		c.logValue(key, val, defaultValue)
+		c.recordValue(key, filters, defaultValue)
 		return val
 	}
 }
 
 // GetStringProperty gets property and asserts that it's an string
 func (c *Collection) GetStringProperty(key Key, defaultValue string) StringPropertyFn {
+	c.registerKeyType(key, defaultValue)
 	return func(opts ...FilterOption) string {
-		val, err := c.client.GetStringValue(key, getFilterMap(opts...), defaultValue)
+		filters := getFilterMap(opts...)
+		val, err := c.client.GetStringValue(key, filters, defaultValue)
 		if err != nil {
 			c.logNoValue(key, err)
 		}
 		c.logValue(key, val, defaultValue)
+		c.recordValue(key, filters, defaultValue)
 		return val
 	}
 }
 
 // GetMapProperty gets property and asserts that it's a map
 func (c *Collection) GetMapProperty(key Key, defaultValue map[string]interface{}) MapPropertyFn {
+	c.registerKeyType(key, defaultValue)
 	return func(opts ...FilterOption) map[string]interface{} {
-		val, err := c.client.GetMapValue(key, getFilterMap(opts...), defaultValue)
+		filters := getFilterMap(opts...)
+		val, err := c.client.GetMapValue(key, filters, defaultValue)
 		if err != nil {
 			c.logNoValue(key, err)
 		}
 		c.logValue(key, mapToString(val), defaultValue)
+		c.recordValue(key, filters, defaultValue)
 		return val
 	}
 }
@@ -275,40 +303,45 @@ func mapToString(inputMap map[string]interface{}) string {
 
 // GetStringPropertyFnWithDomainFilter gets property with domain filter and asserts that its domain
 func (c *Collection) GetStringPropertyFnWithDomainFilter(key Key, defaultValue string) StringPropertyFnWithDomainFilter {
+	c.registerKeyType(key, defaultValue)
 	return func(domain string) string {
-		val, err := c.client.GetStringValue(key, getFilterMap(DomainFilter(domain)), defaultValue)
+		filters := getFilterMap(DomainFilter(domain))
+		val, err := c.client.GetStringValue(key, filters, defaultValue)
 		if err != nil {
 			c.logNoValue(key, err)
 		}
 		c.logValue(key, val, defaultValue)
+		c.recordValue(key, filters, defaultValue)
 		return val
 	}
 }
 
 // GetBoolPropertyFnWithDomainFilter gets property with domain filter and asserts that its domain
 func (c *Collection) GetBoolPropertyFnWithDomainFilter(key Key, defaultValue bool) BoolPropertyFnWithDomainFilter {
+	c.registerKeyType(key, defaultValue)
 	return func(domain string) bool {
-		val, err := c.client.GetBoolValue(key, getFilterMap(DomainFilter(domain)), defaultValue)
+		filters := getFilterMap(DomainFilter(domain))
+		val, err := c.client.GetBoolValue(key, filters, defaultValue)
 		if err != nil {
 			c.logNoValue(key, err)
 		}
 		c.logValue(key, val, defaultValue)
+		c.recordValue(key, filters, defaultValue)
 		return val
 	}
 }
 
 // GetBoolPropertyFilteredByTaskListInfo gets property with taskListInfo as filters and asserts that it's an bool
 func (c *Collection) GetBoolPropertyFilteredByTaskListInfo(key Key, defaultValue bool) BoolPropertyFnWithTaskListInfoFilters {
+	c.registerKeyType(key, defaultValue)
 	return func(domain string, taskList string, taskType int) bool {
-		val, err := c.client.GetBoolValue(
-			key,
-			getFilterMap(DomainFilter(domain), TaskListFilter(taskList), TaskTypeFilter(taskType)),
-			defaultValue,
-		)
+		filters := getFilterMap(DomainFilter(domain), TaskListFilter(taskList), TaskTypeFilter(taskType))
+		val, err := c.client.GetBoolValue(key, filters, defaultValue)
 		if err != nil {
 			c.logNoValue(key, err)
 		}
 		c.logValue(key, val, defaultValue)
+		c.recordValue(key, filters, defaultValue)
 		return val
 	}
 }
@@ -54,6 +54,11 @@ func GetBoolPropertyFnFilteredByDomain(value bool) func(domain string) bool {
 	return func(domain string) bool { return value }
 }
 
+// GetBoolPropertyFnFilteredByTaskListInfo returns value as BoolPropertyFnWithTaskListInfoFilters
+func GetBoolPropertyFnFilteredByTaskListInfo(value bool) func(domain string, taskList string, taskType int) bool {
+	return func(domain string, taskList string, taskType int) bool { return value }
+}
+
 // GetDurationPropertyFnFilteredByDomain returns value as DurationPropertyFnFilteredByDomain
 func GetDurationPropertyFnFilteredByDomain(value time.Duration) func(domain string) time.Duration {
 	return func(domain string) time.Duration { return value }
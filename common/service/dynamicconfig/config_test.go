@@ -21,7 +21,6 @@
 package dynamicconfig
 
 import (
-	"errors"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -53,7 +52,7 @@ func (mc *inMemoryClient) GetValue(key Key, defaultValue interface{}) (interface
 	if val, ok := v[key]; ok {
 		return val, nil
 	}
-	return defaultValue, errors.New("unable to find key")
+	return defaultValue, errKeyNotFound
 }
 
 func (mc *inMemoryClient) GetValueWithFilters(
@@ -67,7 +66,7 @@ func (mc *inMemoryClient) GetIntValue(name Key, filters map[Filter]interface{},
 	if val, ok := v[name]; ok {
 		return val.(int), nil
 	}
-	return defaultValue, errors.New("unable to find key")
+	return defaultValue, errKeyNotFound
 }
 
 func (mc *inMemoryClient) GetFloatValue(name Key, filters map[Filter]interface{}, defaultValue float64) (float64, error) {
@@ -75,7 +74,7 @@ func (mc *inMemoryClient) GetFloatValue(name Key, filters map[Filter]interface{}
 	if val, ok := v[name]; ok {
 		return val.(float64), nil
 	}
-	return defaultValue, errors.New("unable to find key")
+	return defaultValue, errKeyNotFound
 }
 
 func (mc *inMemoryClient) GetBoolValue(name Key, filters map[Filter]interface{}, defaultValue bool) (bool, error) {
@@ -83,7 +82,7 @@ func (mc *inMemoryClient) GetBoolValue(name Key, filters map[Filter]interface{},
 	if val, ok := v[name]; ok {
 		return val.(bool), nil
 	}
-	return defaultValue, errors.New("unable to find key")
+	return defaultValue, errKeyNotFound
 }
 
 func (mc *inMemoryClient) GetStringValue(name Key, filters map[Filter]interface{}, defaultValue string) (string, error) {
@@ -91,7 +90,7 @@ func (mc *inMemoryClient) GetStringValue(name Key, filters map[Filter]interface{
 	if val, ok := v[name]; ok {
 		return val.(string), nil
 	}
-	return defaultValue, errors.New("unable to find key")
+	return defaultValue, errKeyNotFound
 }
 
 func (mc *inMemoryClient) GetMapValue(
@@ -101,7 +100,7 @@ func (mc *inMemoryClient) GetMapValue(
 	if val, ok := v[name]; ok {
 		return val.(map[string]interface{}), nil
 	}
-	return defaultValue, errors.New("unable to find key")
+	return defaultValue, errKeyNotFound
 }
 
 func (mc *inMemoryClient) GetDurationValue(
@@ -111,7 +110,7 @@ func (mc *inMemoryClient) GetDurationValue(
 	if val, ok := v[name]; ok {
 		return val.(time.Duration), nil
 	}
-	return defaultValue, errors.New("unable to find key")
+	return defaultValue, errKeyNotFound
 }
 
 func (mc *inMemoryClient) UpdateValue(key Key, value interface{}) error {
@@ -50,34 +50,49 @@ var keys = map[Key]string{
 	testGetBoolPropertyFilteredByTaskListInfoKey:     "testGetBoolPropertyFilteredByTaskListInfoKey",
 
 	// system settings
-	EnableGlobalDomain:                  "system.enableGlobalDomain",
-	EnableNDC:                           "system.enableNDC",
-	EnableNewKafkaClient:                "system.enableNewKafkaClient",
-	EnableVisibilitySampling:            "system.enableVisibilitySampling",
-	EnableReadFromClosedExecutionV2:     "system.enableReadFromClosedExecutionV2",
-	AdvancedVisibilityWritingMode:       "system.advancedVisibilityWritingMode",
-	EnableReadVisibilityFromES:          "system.enableReadVisibilityFromES",
-	HistoryArchivalStatus:               "system.historyArchivalStatus",
-	EnableReadFromHistoryArchival:       "system.enableReadFromHistoryArchival",
-	VisibilityArchivalStatus:            "system.visibilityArchivalStatus",
-	EnableReadFromVisibilityArchival:    "system.enableReadFromVisibilityArchival",
-	EnableDomainNotActiveAutoForwarding: "system.enableDomainNotActiveAutoForwarding",
-	TransactionSizeLimit:                "system.transactionSizeLimit",
-	MinRetentionDays:                    "system.minRetentionDays",
-	MaxDecisionStartToCloseSeconds:      "system.maxDecisionStartToCloseSeconds",
-	DisallowQuery:                       "system.disallowQuery",
-	EnableBatcher:                       "worker.enableBatcher",
-	EnableParentClosePolicyWorker:       "system.enableParentClosePolicyWorker",
-	EnableStickyQuery:                   "system.enableStickyQuery",
+	EnableGlobalDomain:                   "system.enableGlobalDomain",
+	EnableNDC:                            "system.enableNDC",
+	EnableNewKafkaClient:                 "system.enableNewKafkaClient",
+	EnableVisibilitySampling:             "system.enableVisibilitySampling",
+	EnableReadFromClosedExecutionV2:      "system.enableReadFromClosedExecutionV2",
+	AdvancedVisibilityWritingMode:        "system.advancedVisibilityWritingMode",
+	EnableReadVisibilityFromES:           "system.enableReadVisibilityFromES",
+	HistoryArchivalStatus:                "system.historyArchivalStatus",
+	EnableReadFromHistoryArchival:        "system.enableReadFromHistoryArchival",
+	VisibilityArchivalStatus:             "system.visibilityArchivalStatus",
+	EnableReadFromVisibilityArchival:     "system.enableReadFromVisibilityArchival",
+	EnableDomainNotActiveAutoForwarding:  "system.enableDomainNotActiveAutoForwarding",
+	TransactionSizeLimit:                 "system.transactionSizeLimit",
+	PersistenceFaultInjectionEnabled:     "system.persistenceFaultInjectionEnabled",
+	PersistenceFaultInjectionSeed:        "system.persistenceFaultInjectionSeed",
+	PersistenceFaultInjectionErrorRate:   "system.persistenceFaultInjectionErrorRate",
+	PersistenceFaultInjectionErrorType:   "system.persistenceFaultInjectionErrorType",
+	PersistenceFaultInjectionLatencyRate: "system.persistenceFaultInjectionLatencyRate",
+	PersistenceFaultInjectionLatency:     "system.persistenceFaultInjectionLatency",
+	HistoryPayloadOffloadThreshold:       "system.historyPayloadOffloadThreshold",
+	MinRetentionDays:                     "system.minRetentionDays",
+	MaxDecisionStartToCloseSeconds:       "system.maxDecisionStartToCloseSeconds",
+	DisallowQuery:                        "system.disallowQuery",
+	EnableBatcher:                        "worker.enableBatcher",
+	EnableParentClosePolicyWorker:        "system.enableParentClosePolicyWorker",
+	EnableStickyQuery:                    "system.enableStickyQuery",
 
 	// size limit
-	BlobSizeLimitError:     "limit.blobSize.error",
-	BlobSizeLimitWarn:      "limit.blobSize.warn",
-	HistorySizeLimitError:  "limit.historySize.error",
-	HistorySizeLimitWarn:   "limit.historySize.warn",
-	HistoryCountLimitError: "limit.historyCount.error",
-	HistoryCountLimitWarn:  "limit.historyCount.warn",
-	MaxIDLengthLimit:       "limit.maxIDLength",
+	BlobSizeLimitError:          "limit.blobSize.error",
+	BlobSizeLimitWarn:           "limit.blobSize.warn",
+	OffloadedBlobSizeLimitError: "limit.offloadedBlobSize.error",
+	HistorySizeLimitError:       "limit.historySize.error",
+	HistorySizeLimitWarn:        "limit.historySize.warn",
+	HistoryCountLimitError:      "limit.historyCount.error",
+	HistoryCountLimitWarn:       "limit.historyCount.warn",
+	MaxIDLengthLimit:            "limit.maxIDLength",
+
+	// pending and open workflow limit
+	PendingActivitiesLimit:      "limit.pendingActivities",
+	PendingTimersLimit:          "limit.pendingTimers",
+	PendingChildExecutionsLimit: "limit.pendingChildExecutions",
+	PendingSignalsLimit:         "limit.pendingSignals",
+	OpenWorkflowsLimit:          "limit.openWorkflows",
 
 	// frontend settings
 	FrontendPersistenceMaxQPS:             "frontend.persistenceMaxQPS",
@@ -121,6 +136,13 @@ var keys = map[Key]string{
 	MatchingForwarderMaxOutstandingTasks:    "matching.forwarderMaxOutstandingTasks",
 	MatchingForwarderMaxRatePerSecond:       "matching.forwarderMaxRatePerSecond",
 	MatchingForwarderMaxChildrenPerNode:     "matching.forwarderMaxChildrenPerNode",
+	MatchingBuildIDSetsCacheTTL:             "matching.buildIDSetsCacheTTL",
+	MatchingEnablePartitionAutoScaling:      "matching.enablePartitionAutoScaling",
+	MatchingPartitionAutoScalingInterval:    "matching.partitionAutoScalingInterval",
+	MatchingPartitionAutoScalingTargetRPS:   "matching.partitionAutoScalingTargetRPS",
+	MatchingMaxAutoScaledPartitions:         "matching.maxAutoScaledPartitions",
+	MatchingAutoScalingBacklogThreshold:     "matching.autoScalingBacklogThreshold",
+	MatchingPartitionCountsCacheTTL:         "matching.partitionCountsCacheTTL",
 
 	// history settings
 	HistoryRPS:                                            "history.rps",
@@ -169,6 +191,9 @@ var keys = map[Key]string{
 	TransferProcessorUpdateAckIntervalJitterCoefficient:   "history.transferProcessorUpdateAckIntervalJitterCoefficient",
 	TransferProcessorCompleteTransferInterval:             "history.transferProcessorCompleteTransferInterval",
 	TransferProcessorVisibilityArchivalTimeLimit:          "history.transferProcessorVisibilityArchivalTimeLimit",
+	TaskSchedulerDomainWeight:                             "history.taskSchedulerDomainWeight",
+	TaskSchedulerDomainMaxInflight:                        "history.taskSchedulerDomainMaxInflight",
+	TaskDLQMaxAttempts:                                    "history.taskDLQMaxAttempts",
 	ReplicatorTaskBatchSize:                               "history.replicatorTaskBatchSize",
 	ReplicatorTaskWorkerCount:                             "history.replicatorTaskWorkerCount",
 	ReplicatorTaskMaxRetryCount:                           "history.replicatorTaskMaxRetryCount",
@@ -235,8 +260,13 @@ var keys = map[Key]string{
 	WorkerDeterministicConstructionCheckProbability: "worker.DeterministicConstructionCheckProbability",
 	WorkerBlobIntegrityCheckProbability:             "worker.BlobIntegrityCheckProbability",
 	WorkerTimeLimitPerArchivalIteration:             "worker.TimeLimitPerArchivalIteration",
+	WorkerArchivalVerificationSampleRate:            "worker.ArchivalVerificationSampleRate",
 	WorkerThrottledLogRPS:                           "worker.throttledLogRPS",
 	ScannerPersistenceMaxQPS:                        "worker.scannerPersistenceMaxQPS",
+	ExecutionsScannerEnabled:                        "worker.executionsScannerEnabled",
+	ExecutionsScannerFixEnabled:                     "worker.executionsScannerFixEnabled",
+	EnableDomainDeleter:                             "worker.enableDomainDeleter",
+	DomainDeleterPersistenceMaxQPS:                  "worker.domainDeleterPersistenceMaxQPS",
 }
 
 const (
@@ -287,6 +317,23 @@ const (
 	EnableDomainNotActiveAutoForwarding
 	// TransactionSizeLimit is the largest allowed transaction size to persistence
 	TransactionSizeLimit
+	// PersistenceFaultInjectionEnabled is whether the persistence clients inject faults, it is read once at startup
+	PersistenceFaultInjectionEnabled
+	// PersistenceFaultInjectionSeed is the seed of the injected faults, 0 means a random seed
+	PersistenceFaultInjectionSeed
+	// PersistenceFaultInjectionErrorRate is the rate of persistence calls failing with an injected error,
+	// it can be constrained by persistenceAPI
+	PersistenceFaultInjectionErrorRate
+	// PersistenceFaultInjectionErrorType is the type of the injected error, one of timeout, conditionFailed,
+	// shardOwnershipLost, serviceBusy or internal, it can be constrained by persistenceAPI
+	PersistenceFaultInjectionErrorType
+	// PersistenceFaultInjectionLatencyRate is the rate of persistence calls delayed by the injected latency,
+	// it can be constrained by persistenceAPI
+	PersistenceFaultInjectionLatencyRate
+	// PersistenceFaultInjectionLatency is the injected latency, it can be constrained by persistenceAPI
+	PersistenceFaultInjectionLatency
+	// HistoryPayloadOffloadThreshold is the size above which history event payloads are offloaded to the history payload store
+	HistoryPayloadOffloadThreshold
 	// MinRetentionDays is the minimal allowed retention days for domain
 	MinRetentionDays
 	// MaxDecisionStartToCloseSeconds is the minimal allowed decision start to close timeout in seconds
@@ -298,6 +345,8 @@ const (
 	BlobSizeLimitError
 	// BlobSizeLimitWarn is the per event blob size limit for warning
 	BlobSizeLimitWarn
+	// OffloadedBlobSizeLimitError is the per event blob size limit of payloads offloaded to the history payload store
+	OffloadedBlobSizeLimitError
 	// HistorySizeLimitError is the per workflow execution history size limit
 	HistorySizeLimitError
 	// HistorySizeLimitWarn is the per workflow execution history size limit for warning
@@ -307,6 +356,18 @@ const (
 	// HistoryCountLimitWarn is the per workflow execution history event count limit for warning
 	HistoryCountLimitWarn
 
+	// PendingActivitiesLimit is the per workflow execution limit of pending activities, 0 means no limit
+	PendingActivitiesLimit
+	// PendingTimersLimit is the per workflow execution limit of pending timers, 0 means no limit
+	PendingTimersLimit
+	// PendingChildExecutionsLimit is the per workflow execution limit of pending child workflows, 0 means no limit
+	PendingChildExecutionsLimit
+	// PendingSignalsLimit is the per workflow execution limit of pending signals to external workflows, 0 means no limit
+	PendingSignalsLimit
+	// OpenWorkflowsLimit is the per domain limit of open workflow executions, 0 means no limit.
+	// It is enforced by each history shard on its share of the limit.
+	OpenWorkflowsLimit
+
 	// MaxIDLengthLimit is the length limit for various IDs, including: Domain, TaskList, WorkflowID, ActivityID, TimerID,
 	// WorkflowType, ActivityType, SignalName, MarkerName, ErrorReason/FailureReason/CancelCause, Identity, RequestID
 	MaxIDLengthLimit
@@ -392,6 +453,21 @@ const (
 	MatchingForwarderMaxRatePerSecond
 	// MatchingForwarderMaxChildrenPerNode is the max number of children per node in the task list partition tree
 	MatchingForwarderMaxChildrenPerNode
+	// MatchingBuildIDSetsCacheTTL is how long task list partitions cache the compatible build ID sets read from the root partition
+	MatchingBuildIDSetsCacheTTL
+	// MatchingEnablePartitionAutoScaling enables scaling the number of task list partitions with the load of the task list
+	MatchingEnablePartitionAutoScaling
+	// MatchingPartitionAutoScalingInterval is how often the root partition reevaluates the number of partitions, it is
+	// raised to twice MatchingPartitionCountsCacheTTL so that every step is seen by all callers before the next one
+	MatchingPartitionAutoScalingInterval
+	// MatchingPartitionAutoScalingTargetRPS is the add or dispatch rate that a single partition is scaled for
+	MatchingPartitionAutoScalingTargetRPS
+	// MatchingMaxAutoScaledPartitions is the max number of partitions chosen by partition auto scaling
+	MatchingMaxAutoScaledPartitions
+	// MatchingAutoScalingBacklogThreshold is the backlog of the root partition above which partitions are not removed
+	MatchingAutoScalingBacklogThreshold
+	// MatchingPartitionCountsCacheTTL is how long the partition counts chosen by partition auto scaling are cached by callers
+	MatchingPartitionCountsCacheTTL
 
 	// key for history
 
@@ -487,6 +563,14 @@ const (
 	TransferProcessorCompleteTransferInterval
 	// TransferProcessorVisibilityArchivalTimeLimit is the upper time limit for archiving visibility records
 	TransferProcessorVisibilityArchivalTimeLimit
+	// TaskSchedulerDomainWeight is the weight of a domain when scheduling transfer and timer tasks of a shard
+	TaskSchedulerDomainWeight
+	// TaskSchedulerDomainMaxInflight is the max number of transfer or timer tasks of a domain processed concurrently
+	// by a shard, zero means no limit
+	TaskSchedulerDomainMaxInflight
+	// TaskDLQMaxAttempts is the number of attempts after which a failing transfer or timer task is moved
+	// to the DLQ of its shard, zero means never
+	TaskDLQMaxAttempts
 	// ReplicatorTaskBatchSize is batch size for ReplicatorProcessor
 	ReplicatorTaskBatchSize
 	// ReplicatorTaskWorkerCount is number of worker for ReplicatorProcessor
@@ -594,10 +678,20 @@ const (
 	WorkerBlobIntegrityCheckProbability
 	// WorkerTimeLimitPerArchivalIteration controls the time limit of each iteration of archival workflow
 	WorkerTimeLimitPerArchivalIteration
+	// WorkerArchivalVerificationSampleRate controls the fraction of archived histories which are re-read and verified
+	WorkerArchivalVerificationSampleRate
 	// WorkerThrottledLogRPS is the rate limit on number of log messages emitted per second for throttled logger
 	WorkerThrottledLogRPS
 	// ScannerPersistenceMaxQPS is the maximum rate of persistence calls from worker.Scanner
 	ScannerPersistenceMaxQPS
+	// ExecutionsScannerEnabled decides whether the executions scanner is started in our worker
+	ExecutionsScannerEnabled
+	// ExecutionsScannerFixEnabled decides whether the executions scanner deletes the corrupted executions it finds
+	ExecutionsScannerFixEnabled
+	// EnableDomainDeleter decides whether the domain deleter, which purges the data of deleted domains, is started in our worker
+	EnableDomainDeleter
+	// DomainDeleterPersistenceMaxQPS is the maximum rate of persistence calls from worker.domaindeleter
+	DomainDeleterPersistenceMaxQPS
 	// EnableBatcher decides whether start batcher in our worker
 	EnableBatcher
 	// EnableParentClosePolicyWorker decides whether or not enable system workers for processing parent close policy task
@@ -644,7 +738,7 @@ const (
 type Filter int
 
 func (f Filter) String() string {
-	if f <= unknownFilter || f > TaskType {
+	if f <= unknownFilter || f > PersistenceAPI {
 		return filters[unknownFilter]
 	}
 	return filters[f]
@@ -655,6 +749,7 @@ var filters = []string{
 	"domainName",
 	"taskListName",
 	"taskType",
+	"persistenceAPI",
 }
 
 const (
@@ -665,6 +760,8 @@ const (
 	TaskListName
 	// TaskType is the task type (0:Decision, 1:Activity)
 	TaskType
+	// PersistenceAPI is the name of the persistence manager method
+	PersistenceAPI
 
 	// lastFilterTypeForTest must be the last one in this const group for testing purpose
 	lastFilterTypeForTest
@@ -693,3 +790,10 @@ func TaskTypeFilter(taskType int) FilterOption {
 		filterMap[TaskType] = taskType
 	}
 }
+
+// PersistenceAPIFilter filters by persistence API
+func PersistenceAPIFilter(api string) FilterOption {
+	return func(filterMap map[Filter]interface{}) {
+		filterMap[PersistenceAPI] = api
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -35,6 +36,9 @@ import (
 )
 
 var _ Client = (*fileBasedClient)(nil)
+var _ keyValidator = (*fileBasedClient)(nil)
+
+var errKeyNotFound = errors.New("unable to find key")
 
 const (
 	minPollInterval = time.Second * 5
@@ -54,12 +58,21 @@ type FileBasedClientConfig struct {
 	PollInterval time.Duration `yaml:"pollInterval"`
 }
 
+// keyValidator is implemented by clients which can check the value they hold for a key
+// against the type of the default value the key is read with
+type keyValidator interface {
+	validateKey(key Key, defaultValue interface{})
+}
+
 type fileBasedClient struct {
 	values          atomic.Value
 	lastUpdatedTime time.Time
 	config          *FileBasedClientConfig
 	doneCh          chan struct{}
 	logger          log.Logger
+	// keyTypes holds the type of the default value each key has been registered with,
+	// it is used to validate the values in the config file
+	keyTypes sync.Map
 }
 
 // NewFileBasedClient creates a file based client.
@@ -254,11 +267,101 @@ func (fc *fileBasedClient) storeValues(newValues map[string][]*constrainedValue)
 		}
 	}
 
+	fc.validateValues(newValues)
 	fc.values.Store(newValues)
 	fc.logger.Info("Updated dynamic config")
 	return nil
 }
 
+// validateValues logs a warning for every key or constraint in the config file which
+// is not known to the server, those entries would otherwise be silently ignored
+func (fc *fileBasedClient) validateValues(newValues map[string][]*constrainedValue) {
+	for keyName, s := range newValues {
+		if _, ok := keyNames[keyName]; !ok {
+			fc.logger.Warn("Unknown dynamic config key", tag.Key(keyName))
+			continue
+		}
+		for _, cv := range s {
+			if cv.Value == nil {
+				fc.logger.Warn("Dynamic config key has no value", tag.Key(keyName))
+			}
+			for constraint := range cv.Constraints {
+				if _, ok := filterNames[constraint]; !ok {
+					fc.logger.Warn("Unknown dynamic config constraint",
+						tag.Key(keyName), tag.Name(constraint))
+				}
+			}
+		}
+		if defaultValue, ok := fc.keyTypes.Load(keyNames[keyName]); ok {
+			fc.validateValueTypes(keyName, s, defaultValue)
+		}
+	}
+}
+
+// validateKey records the type of a key which has just been registered and checks the values
+// currently loaded for it, keys are registered by the service config after the config file
+// has been loaded first
+func (fc *fileBasedClient) validateKey(key Key, defaultValue interface{}) {
+	fc.keyTypes.Store(key, defaultValue)
+	values, ok := fc.values.Load().(map[string][]*constrainedValue)
+	if !ok {
+		return
+	}
+	if s, ok := values[key.String()]; ok {
+		fc.validateValueTypes(key.String(), s, defaultValue)
+	}
+}
+
+func (fc *fileBasedClient) validateValueTypes(keyName string, s []*constrainedValue, defaultValue interface{}) {
+	for _, cv := range s {
+		if cv.Value == nil {
+			continue
+		}
+		if err := checkValueType(cv.Value, defaultValue); err != nil {
+			fc.logger.Warn("Invalid dynamic config value type",
+				tag.Key(keyName), tag.Value(cv.Value), tag.Error(err))
+		}
+	}
+}
+
+// checkValueType returns an error if the value can't be read as the type of the default value,
+// it follows the conversions done by the typed getters of the client
+func checkValueType(value interface{}, defaultValue interface{}) error {
+	switch defaultValue.(type) {
+	case int:
+		if _, ok := value.(int); !ok {
+			return errors.New("value type is not int")
+		}
+	case float64:
+		_, isFloat := value.(float64)
+		_, isInt := value.(int)
+		if !isFloat && !isInt {
+			return errors.New("value type is not float64")
+		}
+	case bool:
+		if _, ok := value.(bool); !ok {
+			return errors.New("value type is not bool")
+		}
+	case string:
+		if _, ok := value.(string); !ok {
+			return errors.New("value type is not string")
+		}
+	case map[string]interface{}:
+		if _, ok := value.(map[string]interface{}); !ok {
+			return errors.New("value type is not map")
+		}
+	case time.Duration:
+		durationString, ok := value.(string)
+		if !ok {
+			return errors.New("value type is not string")
+		}
+		if _, err := time.ParseDuration(durationString); err != nil {
+			return fmt.Errorf("failed to parse duration: %v", err)
+		}
+	}
+	return nil
+}
+
 func (fc *fileBasedClient) getValueWithFilters(key Key, filters map[Filter]interface{}, defaultValue interface{}) (interface{}, error) {
 	keyName := keys[key]
 	values := fc.values.Load().(map[string][]*constrainedValue)
@@ -275,7 +378,7 @@ func (fc *fileBasedClient) getValueWithFilters(key Key, filters map[Filter]inter
 		}
 	}
 	if !found {
-		return defaultValue, errors.New("unable to find key")
+		return defaultValue, errKeyNotFound
 	}
 	return defaultValue, nil
 }
@@ -237,6 +237,25 @@ func (s *fileBasedClientSuite) TestValidateConfig_ShortPollInterval() {
 	s.Error(err)
 }
 
+func (s *fileBasedClientSuite) TestCheckValueType() {
+	s.NoError(checkValueType(1, 0))
+	s.Error(checkValueType(1.5, 0))
+	s.NoError(checkValueType(1, 0.5))
+	s.NoError(checkValueType(1.5, 0.5))
+	s.Error(checkValueType("1.5", 0.5))
+	s.NoError(checkValueType(true, false))
+	s.Error(checkValueType("true", false))
+	s.NoError(checkValueType("abc", ""))
+	s.Error(checkValueType(1, ""))
+	s.NoError(checkValueType(map[string]interface{}{"key": 1}, map[string]interface{}(nil)))
+	s.Error(checkValueType("1", map[string]interface{}(nil)))
+	s.NoError(checkValueType("1m", time.Second))
+	s.Error(checkValueType(2, time.Second))
+	s.Error(checkValueType("wrong duration string", time.Second))
+	// values of keys with an unknown type are not checked
+	s.NoError(checkValueType(1, nil))
+}
+
 func (s *fileBasedClientSuite) TestMatch() {
 	testCases := []struct {
 		v       *constrainedValue
@@ -0,0 +1,260 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// KeyInfo describes a dynamic config key which has been read through a Collection,
+	// the filters it was read with and the value which is currently in effect
+	KeyInfo struct {
+		Name         string            `json:"name"`
+		Filters      map[string]string `json:"filters,omitempty"`
+		DefaultValue string            `json:"defaultValue"`
+		Value        string            `json:"value"`
+		// FromDefault is true if no value in the config source matched the filters
+		FromDefault  bool      `json:"fromDefault"`
+		Error        string    `json:"error,omitempty"`
+		LastReadTime time.Time `json:"lastReadTime"`
+	}
+
+	// keyUsageSet holds the key and filter combinations read through the collections of one client.
+	// The first read of every combination is recorded, the read time of a known combination is only
+	// updated on sampled reads, and the number of combinations is bounded, so recording stays cheap
+	// on the hot path and keys filtered by an unbounded set of values can't grow it forever.
+	keyUsageSet struct {
+		size    int32
+		entries sync.Map
+		// types holds the type of the default value each key has been registered with
+		types sync.Map
+	}
+
+	keyUsage struct {
+		key          Key
+		filters      map[Filter]interface{}
+		defaultValue interface{}
+		reads        uint32
+		lastReadTime int64
+	}
+)
+
+const (
+	// DebugPath is the http path on which the dynamic config debug page is served
+	DebugPath = "/debug/dynamicconfig"
+
+	// keyUsageSampleRate is the number of reads of a key and filter combination per update
+	// of its read time
+	keyUsageSampleRate = 64
+	// maxKeyUsageEntries is the max number of key and filter combinations recorded per client
+	maxKeyUsageEntries = 10000
+)
+
+var (
+	// collections created on top of the same client share key usage, so keys read by
+	// the service config and by common components of a service are reported together
+	keyUsageByClient sync.Map
+
+	debugCollections         sync.Map
+	registerDebugHandlerOnce sync.Once
+
+	keyNames    = reverseKeys()
+	filterNames = reverseFilters()
+)
+
+func reverseKeys() map[string]Key {
+	names := make(map[string]Key, len(keys))
+	for key, name := range keys {
+		names[name] = key
+	}
+	return names
+}
+
+func reverseFilters() map[string]Filter {
+	names := make(map[string]Filter, len(filters))
+	for filter, name := range filters {
+		if Filter(filter) != unknownFilter {
+			names[name] = Filter(filter)
+		}
+	}
+	return names
+}
+
+func getKeyUsage(client Client) *keyUsageSet {
+	if client == nil || !reflect.TypeOf(client).Comparable() {
+		return &keyUsageSet{}
+	}
+	usage, _ := keyUsageByClient.LoadOrStore(client, &keyUsageSet{})
+	return usage.(*keyUsageSet)
+}
+
+// registerKeyType records the type a key is read as and lets the client validate
+// the value it currently holds for the key, the first time the key is registered
+// with the client
+func (c *Collection) registerKeyType(key Key, defaultValue interface{}) {
+	if _, loaded := c.usage.types.LoadOrStore(key, defaultValue); loaded {
+		return
+	}
+	if validator, ok := c.client.(keyValidator); ok {
+		validator.validateKey(key, defaultValue)
+	}
+}
+
+func (c *Collection) recordValue(
+	key Key,
+	filters map[Filter]interface{},
+	defaultValue interface{},
+) {
+	usageKey := key.String()
+	if len(filters) != 0 {
+		usageKey = fmt.Sprintf("%s-%v", usageKey, filters)
+	}
+	if usage, ok := c.usage.entries.Load(usageKey); ok {
+		u := usage.(*keyUsage)
+		if atomic.AddUint32(&u.reads, 1)%keyUsageSampleRate == 0 {
+			atomic.StoreInt64(&u.lastReadTime, time.Now().UnixNano())
+		}
+		return
+	}
+	if atomic.LoadInt32(&c.usage.size) >= maxKeyUsageEntries {
+		return
+	}
+	if _, loaded := c.usage.entries.LoadOrStore(usageKey, &keyUsage{
+		key:          key,
+		filters:      filters,
+		defaultValue: defaultValue,
+		lastReadTime: time.Now().UnixNano(),
+	}); !loaded {
+		atomic.AddInt32(&c.usage.size, 1)
+	}
+}
+
+// Keys returns all keys which have been read through collections sharing the client of
+// this collection, together with their defaults, filters and effective values. The read
+// time of a key and filter combination is sampled, so it may lag behind its latest read.
+func (c *Collection) Keys() []*KeyInfo {
+	var result []*KeyInfo
+	c.usage.entries.Range(func(_, value interface{}) bool {
+		result = append(result, c.toKeyInfo(value.(*keyUsage)))
+		return true
+	})
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return fmt.Sprint(result[i].Filters) < fmt.Sprint(result[j].Filters)
+	})
+	return result
+}
+
+func (c *Collection) toKeyInfo(u *keyUsage) *KeyInfo {
+	value, err := c.getValue(u.key, u.filters, u.defaultValue)
+	info := &KeyInfo{
+		Name:         u.key.String(),
+		DefaultValue: valueToString(u.defaultValue),
+		Value:        valueToString(value),
+		FromDefault:  err != nil,
+		LastReadTime: time.Unix(0, atomic.LoadInt64(&u.lastReadTime)),
+	}
+	if len(u.filters) != 0 {
+		info.Filters = make(map[string]string, len(u.filters))
+		for filter, value := range u.filters {
+			info.Filters[filter.String()] = fmt.Sprintf("%v", value)
+		}
+	}
+	if err != nil && err != errKeyNotFound {
+		info.Error = err.Error()
+	}
+	return info
+}
+
+// getValue resolves the value currently in effect for the key the same way as
+// the property function the key was read with
+func (c *Collection) getValue(
+	key Key,
+	filters map[Filter]interface{},
+	defaultValue interface{},
+) (interface{}, error) {
+	switch v := defaultValue.(type) {
+	case int:
+		return c.client.GetIntValue(key, filters, v)
+	case float64:
+		return c.client.GetFloatValue(key, filters, v)
+	case bool:
+		return c.client.GetBoolValue(key, filters, v)
+	case string:
+		return c.client.GetStringValue(key, filters, v)
+	case time.Duration:
+		return c.client.GetDurationValue(key, filters, v)
+	case map[string]interface{}:
+		return c.client.GetMapValue(key, filters, v)
+	default:
+		if len(filters) == 0 {
+			return c.client.GetValue(key, v)
+		}
+		return c.client.GetValueWithFilters(key, filters, v)
+	}
+}
+
+func valueToString(value interface{}) string {
+	if m, ok := value.(map[string]interface{}); ok {
+		return mapToString(m)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// RegisterDebugHandler exposes the keys read through the collection as JSON on DebugPath of
+// the default http mux, which is served by the pprof listener. Calling it again with the same
+// service name replaces the previously registered collection.
+func RegisterDebugHandler(serviceName string, collection *Collection) {
+	debugCollections.Store(serviceName, collection)
+	registerDebugHandlerOnce.Do(func() {
+		http.HandleFunc(DebugPath, serveDebugPage)
+	})
+}
+
+// serveDebugPage writes the keys of all registered services, or of the service
+// given by the "service" query parameter only
+func serveDebugPage(w http.ResponseWriter, r *http.Request) {
+	serviceName := r.URL.Query().Get("service")
+	result := make(map[string][]*KeyInfo)
+	debugCollections.Range(func(key, value interface{}) bool {
+		if serviceName == "" || serviceName == key.(string) {
+			result[key.(string)] = value.(*Collection).Keys()
+		}
+		return true
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicconfig
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temporalio/temporal/common/log"
+)
+
+func TestCollectionKeys(t *testing.T) {
+	client := newInMemoryClient()
+	cln := NewCollection(client, log.NewNoop())
+	require.Empty(t, cln.Keys())
+
+	cln.GetIntProperty(testGetIntPropertyKey, 10)()
+	cln.GetDurationPropertyFilteredByDomain(testGetDurationPropertyFilteredByDomainKey, time.Second)("testDomain")
+	client.SetValue(testGetBoolPropertyKey, false)
+	cln.GetBoolProperty(testGetBoolPropertyKey, true)()
+
+	// collections sharing a client report keys read through any of them
+	keys := NewCollection(client, log.NewNoop()).Keys()
+	require.Len(t, keys, 3)
+
+	require.Equal(t, "testGetBoolPropertyKey", keys[0].Name)
+	require.Equal(t, "true", keys[0].DefaultValue)
+	require.Equal(t, "false", keys[0].Value)
+	require.False(t, keys[0].FromDefault)
+
+	require.Equal(t, "testGetDurationPropertyFilteredByDomainKey", keys[1].Name)
+	require.Equal(t, map[string]string{"domainName": "testDomain"}, keys[1].Filters)
+	require.Equal(t, "1s", keys[1].Value)
+	require.True(t, keys[1].FromDefault)
+	require.Empty(t, keys[1].Error)
+
+	require.Equal(t, "testGetIntPropertyKey", keys[2].Name)
+	require.Equal(t, "10", keys[2].Value)
+	require.True(t, keys[2].FromDefault)
+}
+
+func TestCollectionKeys_InvalidValue(t *testing.T) {
+	client, err := NewFileBasedClient(&FileBasedClientConfig{
+		Filepath:     "config/testConfig.yaml",
+		PollInterval: time.Second * 5,
+	}, log.NewNoop(), make(chan struct{}))
+	require.NoError(t, err)
+	cln := NewCollection(client, log.NewNoop())
+
+	cln.GetFloat64Property(testGetFloat64PropertyKey, 0.5)(DomainFilter("samples-domain"))
+	keys := cln.Keys()
+	require.Len(t, keys, 1)
+	require.Equal(t, "0.5", keys[0].Value)
+	require.True(t, keys[0].FromDefault)
+	require.NotEmpty(t, keys[0].Error)
+}
+
+func TestCollectionKeys_Sampled(t *testing.T) {
+	client := newInMemoryClient()
+	cln := NewCollection(client, log.NewNoop())
+	property := cln.GetIntPropertyFilteredByDomain(testGetIntPropertyFilteredByDomainKey, 10)
+
+	// the first read of every filter combination is recorded
+	property("domain-1")
+	property("domain-2")
+	keys := cln.Keys()
+	require.Len(t, keys, 2)
+	require.Equal(t, map[string]string{"domainName": "domain-1"}, keys[0].Filters)
+	require.Equal(t, map[string]string{"domainName": "domain-2"}, keys[1].Filters)
+
+	// the effective value is resolved when the keys are listed
+	client.SetValue(testGetIntPropertyFilteredByDomainKey, 20)
+	require.Equal(t, "20", cln.Keys()[0].Value)
+
+	// the read time is only updated on sampled reads
+	lastReadTime := keys[1].LastReadTime
+	for i := 0; i < keyUsageSampleRate-1; i++ {
+		property("domain-2")
+	}
+	require.Equal(t, lastReadTime, cln.Keys()[1].LastReadTime)
+	time.Sleep(time.Millisecond)
+	property("domain-2")
+	require.True(t, cln.Keys()[1].LastReadTime.After(lastReadTime))
+}
+
+func TestCollectionKeys_KeyTypesPerClient(t *testing.T) {
+	newClient := func() *fileBasedClient {
+		client, err := NewFileBasedClient(&FileBasedClientConfig{
+			Filepath:     "config/testConfig.yaml",
+			PollInterval: time.Second * 5,
+		}, log.NewNoop(), make(chan struct{}))
+		require.NoError(t, err)
+		return client.(*fileBasedClient)
+	}
+
+	client1 := newClient()
+	NewCollection(client1, log.NewNoop()).GetIntProperty(testGetIntPropertyKey, 10)
+	_, ok := client1.keyTypes.Load(testGetIntPropertyKey)
+	require.True(t, ok)
+
+	// keys registered with another client are validated by that client again
+	client2 := newClient()
+	_, ok = client2.keyTypes.Load(testGetIntPropertyKey)
+	require.False(t, ok)
+	NewCollection(client2, log.NewNoop()).GetIntProperty(testGetIntPropertyKey, 10)
+	_, ok = client2.keyTypes.Load(testGetIntPropertyKey)
+	require.True(t, ok)
+}
+
+func TestCollectionKeys_Bounded(t *testing.T) {
+	cln := NewCollection(newInMemoryClient(), log.NewNoop())
+	cln.usage.size = maxKeyUsageEntries - 1
+
+	cln.GetIntProperty(testGetIntPropertyKey, 10)()
+	cln.GetBoolProperty(testGetBoolPropertyKey, true)()
+	require.Len(t, cln.Keys(), 1)
+	require.Equal(t, int32(maxKeyUsageEntries), cln.usage.size)
+}
+
+func TestServeDebugPage(t *testing.T) {
+	client := newInMemoryClient()
+	cln := NewCollection(client, log.NewNoop())
+	cln.GetStringProperty(testGetStringPropertyKey, "abc")()
+	RegisterDebugHandler("test-service", cln)
+
+	recorder := httptest.NewRecorder()
+	serveDebugPage(recorder, httptest.NewRequest("GET", DebugPath+"?service=test-service", nil))
+
+	var result map[string][]*KeyInfo
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+	require.Len(t, result, 1)
+	require.Len(t, result["test-service"], 1)
+	require.Equal(t, "testGetStringPropertyKey", result["test-service"][0].Name)
+	require.Equal(t, "abc", result["test-service"][0].Value)
+}
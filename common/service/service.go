@@ -30,6 +30,7 @@ import (
 	"github.com/uber-go/tally"
 	"go.temporal.io/temporal-proto/workflowservice"
 	"go.uber.org/yarpc"
+	"google.golang.org/grpc"
 
 	"github.com/temporalio/temporal/client"
 	"github.com/temporalio/temporal/common"
@@ -45,6 +46,7 @@ import (
 	"github.com/temporalio/temporal/common/messaging"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
+	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
 	"github.com/temporalio/temporal/common/service/config"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
@@ -76,6 +78,12 @@ type (
 		ArchivalMetadata    archiver.ArchivalMetadata
 		ArchiverProvider    provider.ArchiverProvider
 		Authorizer          authorization.Authorizer
+		AuditConfig         config.Audit
+		HealthConfig        config.Health
+		// PersistenceFactoryProvider overrides the persistence factory built from PersistenceConfig when set
+		PersistenceFactoryProvider persistenceClient.FactoryProvider
+		// Interceptors are added after the built-in interceptors of the service gRPC server
+		Interceptors []grpc.UnaryServerInterceptor
 	}
 
 	// MembershipMonitorFactory provides a bootstrapped membership monitor
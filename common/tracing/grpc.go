@@ -0,0 +1,136 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const grpcComponentName = "grpc"
+
+// metadataCarrier adapts gRPC metadata to the opentracing TextMap carrier interfaces
+type metadataCarrier metadata.MD
+
+var _ opentracing.TextMapReader = metadataCarrier{}
+var _ opentracing.TextMapWriter = metadataCarrier{}
+
+func (c metadataCarrier) Set(key, value string) {
+	key = strings.ToLower(key)
+	c[key] = append(c[key], value)
+}
+
+func (c metadataCarrier) ForeachKey(handler func(key, value string) error) error {
+	for key, values := range c {
+		for _, value := range values {
+			if err := handler(key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// NewServerInterceptor creates a gRPC interceptor which starts a server span for every unary call,
+// continuing the trace propagated by the caller in the request metadata
+func NewServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		tracer := opentracing.GlobalTracer()
+		var parent opentracing.SpanContext
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			parent, _ = tracer.Extract(opentracing.TextMap, metadataCarrier(md))
+		}
+
+		span := tracer.StartSpan(
+			info.FullMethod,
+			ext.RPCServerOption(parent),
+			opentracing.Tag{Key: string(ext.Component), Value: grpcComponentName},
+		)
+		defer span.Finish()
+
+		resp, err := handler(opentracing.ContextWithSpan(ctx, span), req)
+		SetError(span, err)
+		return resp, err
+	}
+}
+
+// NewClientInterceptor creates a gRPC interceptor which starts a client span for every unary call
+// and propagates it to the server in the request metadata
+func NewClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req interface{},
+		reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		tracer := opentracing.GlobalTracer()
+		var parent opentracing.SpanContext
+		if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
+			parent = parentSpan.Context()
+		}
+
+		span := tracer.StartSpan(
+			method,
+			opentracing.ChildOf(parent),
+			ext.SpanKindRPCClient,
+			opentracing.Tag{Key: string(ext.Component), Value: grpcComponentName},
+			opentracing.Tag{Key: string(ext.PeerAddress), Value: cc.Target()},
+		)
+		defer span.Finish()
+
+		md, ok := metadata.FromOutgoingContext(ctx)
+		if ok {
+			md = md.Copy()
+		} else {
+			md = metadata.MD{}
+		}
+		if err := tracer.Inject(span.Context(), opentracing.TextMap, metadataCarrier(md)); err == nil {
+			ctx = metadata.NewOutgoingContext(ctx, md)
+		}
+
+		err := invoker(opentracing.ContextWithSpan(ctx, span), method, req, reply, cc, opts...)
+		SetError(span, err)
+		return err
+	}
+}
+
+// SetError marks the span as failed if err is not nil
+func SetError(span opentracing.Span, err error) {
+	if err == nil {
+		return
+	}
+	ext.Error.Set(span, true)
+	span.LogFields(otlog.Error(err))
+}
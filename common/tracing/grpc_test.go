@@ -0,0 +1,100 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+)
+
+type (
+	grpcSuite struct {
+		suite.Suite
+
+		globalTracer opentracing.Tracer
+	}
+)
+
+func TestGRPCSuite(t *testing.T) {
+	suite.Run(t, new(grpcSuite))
+}
+
+func (s *grpcSuite) SetupTest() {
+	s.globalTracer = opentracing.GlobalTracer()
+	opentracing.SetGlobalTracer(NewLogTracer(loggerimpl.NewNopLogger()))
+}
+
+func (s *grpcSuite) TearDownTest() {
+	opentracing.SetGlobalTracer(s.globalTracer)
+}
+
+func (s *grpcSuite) TestPropagation() {
+	connection, err := grpc.Dial("127.0.0.1:0", grpc.WithInsecure())
+	s.NoError(err)
+	defer connection.Close()
+
+	parent := opentracing.StartSpan("parent")
+	defer parent.Finish()
+
+	var outgoing metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+	err = NewClientInterceptor()(
+		opentracing.ContextWithSpan(context.Background(), parent),
+		"/service/Method",
+		nil,
+		nil,
+		connection,
+		invoker,
+	)
+	s.NoError(err)
+	s.NotEmpty(outgoing)
+
+	var serverSpan opentracing.Span
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		serverSpan = opentracing.SpanFromContext(ctx)
+		return nil, errors.New("handler error")
+	}
+	_, err = NewServerInterceptor()(
+		metadata.NewIncomingContext(context.Background(), outgoing),
+		nil,
+		&grpc.UnaryServerInfo{FullMethod: "/service/Method"},
+		handler,
+	)
+	s.Error(err)
+	s.NotNil(serverSpan)
+
+	parentContext := parent.Context().(logSpanContext)
+	serverContext := serverSpan.Context().(logSpanContext)
+	s.Equal(parentContext.traceID, serverContext.traceID)
+	s.Equal(true, serverSpan.(*logSpan).tags[string(ext.Error)])
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tracing
+
+import (
+	"encoding/json"
+
+	"github.com/opentracing/opentracing-go"
+)
+
+// TracerHeaderKey is the workflow header field which carries the span context.
+// It matches the key used by the tracing context propagator of the client SDKs,
+// so that worker spans can be linked to the server spans that started a workflow.
+const TracerHeaderKey = "_tracer-data"
+
+// InjectIntoHeaderFields adds the context of the span to the workflow header fields and returns the
+// updated fields. Fields which already carry a span context set by the caller are returned unchanged.
+func InjectIntoHeaderFields(span opentracing.Span, fields map[string][]byte) (map[string][]byte, error) {
+	if _, ok := fields[TracerHeaderKey]; ok {
+		return fields, nil
+	}
+
+	carrier := opentracing.TextMapCarrier{}
+	if err := span.Tracer().Inject(span.Context(), opentracing.TextMap, carrier); err != nil {
+		return fields, err
+	}
+	if len(carrier) == 0 {
+		// noop tracer does not propagate anything
+		return fields, nil
+	}
+	data, err := json.Marshal(carrier)
+	if err != nil {
+		return fields, err
+	}
+
+	if fields == nil {
+		fields = make(map[string][]byte, 1)
+	}
+	fields[TracerHeaderKey] = data
+	return fields, nil
+}
+
+// ExtractFromHeaderFields reads the span context propagated in the workflow header fields
+func ExtractFromHeaderFields(tracer opentracing.Tracer, fields map[string][]byte) (opentracing.SpanContext, error) {
+	data, ok := fields[TracerHeaderKey]
+	if !ok {
+		return nil, opentracing.ErrSpanContextNotFound
+	}
+
+	carrier := opentracing.TextMapCarrier{}
+	if err := json.Unmarshal(data, &carrier); err != nil {
+		return nil, opentracing.ErrSpanContextCorrupted
+	}
+	return tracer.Extract(opentracing.TextMap, carrier)
+}
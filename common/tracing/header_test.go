@@ -0,0 +1,73 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+)
+
+func TestHeaderFields_InjectExtract(t *testing.T) {
+	tracer := NewLogTracer(loggerimpl.NewNopLogger())
+	span := tracer.StartSpan("test")
+	defer span.Finish()
+
+	fields, err := InjectIntoHeaderFields(span, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, fields, TracerHeaderKey)
+
+	extracted, err := ExtractFromHeaderFields(tracer, fields)
+	assert.NoError(t, err)
+	assert.Equal(t, span.Context(), extracted)
+}
+
+func TestHeaderFields_KeepCallerContext(t *testing.T) {
+	tracer := NewLogTracer(loggerimpl.NewNopLogger())
+	span := tracer.StartSpan("test")
+	defer span.Finish()
+
+	callerData := []byte(`{"ot-tracer-traceid":"1","ot-tracer-spanid":"2"}`)
+	fields, err := InjectIntoHeaderFields(span, map[string][]byte{TracerHeaderKey: callerData})
+	assert.NoError(t, err)
+	assert.Equal(t, callerData, fields[TracerHeaderKey])
+}
+
+func TestHeaderFields_NoopTracer(t *testing.T) {
+	span := opentracing.NoopTracer{}.StartSpan("test")
+	defer span.Finish()
+
+	fields, err := InjectIntoHeaderFields(span, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, fields)
+}
+
+func TestHeaderFields_ExtractNotFound(t *testing.T) {
+	tracer := NewLogTracer(loggerimpl.NewNopLogger())
+	_, err := ExtractFromHeaderFields(tracer, map[string][]byte{})
+	assert.Equal(t, opentracing.ErrSpanContextNotFound, err)
+
+	_, err = ExtractFromHeaderFields(tracer, map[string][]byte{TracerHeaderKey: []byte("{")})
+	assert.Equal(t, opentracing.ErrSpanContextCorrupted, err)
+}
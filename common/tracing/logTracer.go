@@ -0,0 +1,297 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tracing
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+)
+
+const (
+	traceIDKey     = "ot-tracer-traceid"
+	spanIDKey      = "ot-tracer-spanid"
+	baggagePrefix  = "ot-baggage-"
+	idEncodingBase = 16
+)
+
+type (
+	// logTracer is an opentracing.Tracer which writes every finished span to a logger,
+	// it is meant to be used for local debugging and tests instead of a real collector
+	logTracer struct {
+		logger log.Logger
+
+		sync.Mutex
+		rand *rand.Rand
+	}
+
+	logSpanContext struct {
+		traceID uint64
+		spanID  uint64
+		baggage map[string]string
+	}
+
+	logSpan struct {
+		tracer    *logTracer
+		context   logSpanContext
+		parentID  uint64
+		startTime time.Time
+
+		sync.Mutex
+		operationName string
+		tags          map[string]interface{}
+		logs          []string
+	}
+)
+
+var _ opentracing.Tracer = (*logTracer)(nil)
+var _ opentracing.Span = (*logSpan)(nil)
+
+// NewLogTracer creates a tracer which writes every finished span to the given logger
+func NewLogTracer(logger log.Logger) opentracing.Tracer {
+	return &logTracer{
+		logger: logger,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (t *logTracer) randomID() uint64 {
+	t.Lock()
+	defer t.Unlock()
+	return uint64(t.rand.Int63())
+}
+
+// StartSpan creates a new span, which is a child of the first ChildOf or FollowsFrom reference if there is one
+func (t *logTracer) StartSpan(operationName string, opts ...opentracing.StartSpanOption) opentracing.Span {
+	options := opentracing.StartSpanOptions{}
+	for _, opt := range opts {
+		opt.Apply(&options)
+	}
+
+	span := &logSpan{
+		tracer:        t,
+		operationName: operationName,
+		startTime:     options.StartTime,
+		tags:          make(map[string]interface{}, len(options.Tags)),
+	}
+	if span.startTime.IsZero() {
+		span.startTime = time.Now()
+	}
+	for k, v := range options.Tags {
+		span.tags[k] = v
+	}
+
+	span.context.spanID = t.randomID()
+	for _, ref := range options.References {
+		parent, ok := ref.ReferencedContext.(logSpanContext)
+		if !ok {
+			continue
+		}
+		span.parentID = parent.spanID
+		span.context.traceID = parent.traceID
+		span.context.baggage = copyBaggage(parent.baggage)
+		break
+	}
+	if span.context.traceID == 0 {
+		span.context.traceID = t.randomID()
+	}
+	return span
+}
+
+// Inject writes the span context into a TextMap or HTTPHeaders carrier
+func (t *logTracer) Inject(sc opentracing.SpanContext, format interface{}, carrier interface{}) error {
+	context, ok := sc.(logSpanContext)
+	if !ok {
+		return opentracing.ErrInvalidSpanContext
+	}
+	writer, ok := carrier.(opentracing.TextMapWriter)
+	if !ok || (format != opentracing.TextMap && format != opentracing.HTTPHeaders) {
+		return opentracing.ErrUnsupportedFormat
+	}
+
+	writer.Set(traceIDKey, strconv.FormatUint(context.traceID, idEncodingBase))
+	writer.Set(spanIDKey, strconv.FormatUint(context.spanID, idEncodingBase))
+	for k, v := range context.baggage {
+		writer.Set(baggagePrefix+k, v)
+	}
+	return nil
+}
+
+// Extract reads a span context from a TextMap or HTTPHeaders carrier
+func (t *logTracer) Extract(format interface{}, carrier interface{}) (opentracing.SpanContext, error) {
+	reader, ok := carrier.(opentracing.TextMapReader)
+	if !ok || (format != opentracing.TextMap && format != opentracing.HTTPHeaders) {
+		return nil, opentracing.ErrUnsupportedFormat
+	}
+
+	context := logSpanContext{}
+	err := reader.ForeachKey(func(key, value string) error {
+		var err error
+		switch lowerKey := strings.ToLower(key); {
+		case lowerKey == traceIDKey:
+			context.traceID, err = strconv.ParseUint(value, idEncodingBase, 64)
+		case lowerKey == spanIDKey:
+			context.spanID, err = strconv.ParseUint(value, idEncodingBase, 64)
+		case strings.HasPrefix(lowerKey, baggagePrefix):
+			if context.baggage == nil {
+				context.baggage = make(map[string]string)
+			}
+			context.baggage[strings.TrimPrefix(lowerKey, baggagePrefix)] = value
+		}
+		return err
+	})
+	if err != nil {
+		return nil, opentracing.ErrSpanContextCorrupted
+	}
+	if context.traceID == 0 || context.spanID == 0 {
+		return nil, opentracing.ErrSpanContextNotFound
+	}
+	return context, nil
+}
+
+func (c logSpanContext) ForeachBaggageItem(handler func(k, v string) bool) {
+	for k, v := range c.baggage {
+		if !handler(k, v) {
+			return
+		}
+	}
+}
+
+func (s *logSpan) Finish() {
+	s.FinishWithOptions(opentracing.FinishOptions{})
+}
+
+func (s *logSpan) FinishWithOptions(opts opentracing.FinishOptions) {
+	finishTime := opts.FinishTime
+	if finishTime.IsZero() {
+		finishTime = time.Now()
+	}
+	for _, record := range opts.LogRecords {
+		s.LogFields(record.Fields...)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	s.tracer.logger.Info("Span finished",
+		tag.SpanOperation(s.operationName),
+		tag.TraceID(strconv.FormatUint(s.context.traceID, idEncodingBase)),
+		tag.SpanID(strconv.FormatUint(s.context.spanID, idEncodingBase)),
+		tag.ParentSpanID(strconv.FormatUint(s.parentID, idEncodingBase)),
+		tag.Timestamp(s.startTime),
+		tag.SpanDuration(finishTime.Sub(s.startTime)),
+		tag.SpanTags(s.tags),
+		tag.Value(s.logs),
+	)
+}
+
+func (s *logSpan) Context() opentracing.SpanContext {
+	s.Lock()
+	defer s.Unlock()
+	return logSpanContext{
+		traceID: s.context.traceID,
+		spanID:  s.context.spanID,
+		baggage: copyBaggage(s.context.baggage),
+	}
+}
+
+func (s *logSpan) SetOperationName(operationName string) opentracing.Span {
+	s.Lock()
+	defer s.Unlock()
+	s.operationName = operationName
+	return s
+}
+
+func (s *logSpan) SetTag(key string, value interface{}) opentracing.Span {
+	s.Lock()
+	defer s.Unlock()
+	s.tags[key] = value
+	return s
+}
+
+func (s *logSpan) LogFields(fields ...otlog.Field) {
+	s.Lock()
+	defer s.Unlock()
+	for _, field := range fields {
+		s.logs = append(s.logs, field.String())
+	}
+}
+
+func (s *logSpan) LogKV(alternatingKeyValues ...interface{}) {
+	fields, err := otlog.InterleavedKVToFields(alternatingKeyValues...)
+	if err != nil {
+		s.LogFields(otlog.Error(err))
+		return
+	}
+	s.LogFields(fields...)
+}
+
+func (s *logSpan) SetBaggageItem(restrictedKey, value string) opentracing.Span {
+	s.Lock()
+	defer s.Unlock()
+	if s.context.baggage == nil {
+		s.context.baggage = make(map[string]string)
+	}
+	s.context.baggage[restrictedKey] = value
+	return s
+}
+
+func (s *logSpan) BaggageItem(restrictedKey string) string {
+	s.Lock()
+	defer s.Unlock()
+	return s.context.baggage[restrictedKey]
+}
+
+func (s *logSpan) Tracer() opentracing.Tracer {
+	return s.tracer
+}
+
+func (s *logSpan) LogEvent(event string) {
+	s.LogFields(otlog.String("event", event))
+}
+
+func (s *logSpan) LogEventWithPayload(event string, payload interface{}) {
+	s.LogFields(otlog.String("event", event), otlog.String("payload", fmt.Sprintf("%v", payload)))
+}
+
+func (s *logSpan) Log(data opentracing.LogData) {
+	record := data.ToLogRecord()
+	s.LogFields(record.Fields...)
+}
+
+func copyBaggage(baggage map[string]string) map[string]string {
+	if len(baggage) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(baggage))
+	for k, v := range baggage {
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+)
+
+type (
+	logTracerSuite struct {
+		suite.Suite
+
+		tracer opentracing.Tracer
+	}
+)
+
+func TestLogTracerSuite(t *testing.T) {
+	suite.Run(t, new(logTracerSuite))
+}
+
+func (s *logTracerSuite) SetupTest() {
+	s.tracer = NewLogTracer(loggerimpl.NewNopLogger())
+}
+
+func (s *logTracerSuite) TestChildSpan() {
+	parent := s.tracer.StartSpan("parent")
+	parent.SetBaggageItem("key", "value")
+	child := s.tracer.StartSpan("child", opentracing.ChildOf(parent.Context()))
+	defer parent.Finish()
+	defer child.Finish()
+
+	parentContext := parent.Context().(logSpanContext)
+	childContext := child.Context().(logSpanContext)
+	s.Equal(parentContext.traceID, childContext.traceID)
+	s.NotEqual(parentContext.spanID, childContext.spanID)
+	s.Equal(parentContext.spanID, child.(*logSpan).parentID)
+	s.Equal("value", child.BaggageItem("key"))
+}
+
+func (s *logTracerSuite) TestInjectExtract() {
+	span := s.tracer.StartSpan("test")
+	span.SetBaggageItem("key", "value")
+	defer span.Finish()
+
+	carrier := opentracing.TextMapCarrier{}
+	s.NoError(s.tracer.Inject(span.Context(), opentracing.TextMap, carrier))
+
+	extracted, err := s.tracer.Extract(opentracing.TextMap, carrier)
+	s.NoError(err)
+	s.Equal(span.Context(), extracted)
+}
+
+func (s *logTracerSuite) TestInjectExtract_HTTPHeaders() {
+	span := s.tracer.StartSpan("test")
+	defer span.Finish()
+
+	carrier := opentracing.HTTPHeadersCarrier{}
+	s.NoError(s.tracer.Inject(span.Context(), opentracing.HTTPHeaders, carrier))
+
+	extracted, err := s.tracer.Extract(opentracing.HTTPHeaders, carrier)
+	s.NoError(err)
+	s.Equal(span.Context(), extracted)
+}
+
+func (s *logTracerSuite) TestInject_UnsupportedFormat() {
+	span := s.tracer.StartSpan("test")
+	defer span.Finish()
+
+	err := s.tracer.Inject(span.Context(), opentracing.Binary, opentracing.TextMapCarrier{})
+	s.Equal(opentracing.ErrUnsupportedFormat, err)
+}
+
+func (s *logTracerSuite) TestExtract_NotFound() {
+	_, err := s.tracer.Extract(opentracing.TextMap, opentracing.TextMapCarrier{})
+	s.Equal(opentracing.ErrSpanContextNotFound, err)
+}
+
+func (s *logTracerSuite) TestExtract_Corrupted() {
+	carrier := opentracing.TextMapCarrier{
+		traceIDKey: "not-a-number",
+		spanIDKey:  "1",
+	}
+	_, err := s.tracer.Extract(opentracing.TextMap, carrier)
+	s.Equal(opentracing.ErrSpanContextCorrupted, err)
+}
@@ -450,17 +450,20 @@ func CreateHistoryStartWorkflowRequest(
 	domainID string,
 	startRequest *workflow.StartWorkflowExecutionRequest,
 ) *h.StartWorkflowExecutionRequest {
-	now := time.Now()
+	// a delayed start shifts every deadline of the workflow by the start delay
+	delayStartSeconds := startRequest.GetDelayStartSeconds()
+	startTime := time.Now().Add(time.Second * time.Duration(delayStartSeconds))
 	histRequest := &h.StartWorkflowExecutionRequest{
 		DomainUUID:   StringPtr(domainID),
 		StartRequest: startRequest,
 	}
 	if startRequest.RetryPolicy != nil && startRequest.RetryPolicy.GetExpirationIntervalInSeconds() > 0 {
 		expirationInSeconds := startRequest.RetryPolicy.GetExpirationIntervalInSeconds()
-		deadline := now.Add(time.Second * time.Duration(expirationInSeconds))
+		deadline := startTime.Add(time.Second * time.Duration(expirationInSeconds))
 		histRequest.ExpirationTimestamp = Int64Ptr(deadline.Round(time.Millisecond).UnixNano())
 	}
-	histRequest.FirstDecisionTaskBackoffSeconds = Int32Ptr(backoff.GetBackoffForNextScheduleInSeconds(startRequest.GetCronSchedule(), now, now))
+	cronBackoffSeconds := backoff.GetBackoffForNextScheduleInSeconds(startRequest.GetCronSchedule(), startTime, startTime)
+	histRequest.FirstDecisionTaskBackoffSeconds = Int32Ptr(delayStartSeconds + cronBackoffSeconds)
 	return histRequest
 }
 
@@ -63,6 +63,7 @@ import (
 	"github.com/temporalio/temporal/service/matching"
 	"github.com/temporalio/temporal/service/worker"
 	"github.com/temporalio/temporal/service/worker/archiver"
+	"github.com/temporalio/temporal/service/worker/domaindeleter"
 	"github.com/temporalio/temporal/service/worker/indexer"
 	"github.com/temporalio/temporal/service/worker/replicator"
 )
@@ -752,6 +753,7 @@ func (c *cadenceImpl) startWorkerReplicator(params *service.BootstrapParams, ser
 		service.GetHostInfo(),
 		serviceResolver,
 		c.domainReplicationQueue,
+		domaindeleter.NewClient(params.PublicClient, c.historyConfig.NumHistoryShards),
 	)
 	if err := c.replicator.Start(); err != nil {
 		c.replicator.Stop()
@@ -80,6 +80,8 @@ type (
 		ESConfig              *elasticsearch.Config
 		WorkerConfig          *WorkerConfig
 		MockAdminClient       map[string]adminClient.Client
+		// FaultInjection injects faults into the persistence calls of the services when set
+		FaultInjection *config.FaultInjectionConfig
 	}
 
 	// MessagingClientConfig is the config for messaging config
@@ -167,6 +169,7 @@ func NewCluster(options *TestClusterConfig, logger log.Logger) (*TestCluster, er
 
 	pConfig := testBase.Config()
 	pConfig.NumHistoryShards = options.HistoryConfig.NumHistoryShards
+	pConfig.FaultInjection = options.FaultInjection
 	cadenceParams := &CadenceParams{
 		ClusterMetadata:        clusterMetadata,
 		PersistenceConfig:      pConfig,
@@ -63,6 +63,7 @@ struct AddDecisionTaskRequest {
   40: optional i64 (js.type = "Long") scheduleId
   50: optional i32 scheduleToStartTimeoutSeconds
   60: optional string forwardedFrom
+  70: optional string buildId
 }
 
 struct AddActivityTaskRequest {
@@ -80,6 +81,7 @@ struct QueryWorkflowRequest {
   20: optional shared.TaskList taskList
   30: optional shared.QueryWorkflowRequest queryRequest
   40: optional string forwardedFrom
+  50: optional string buildId
 }
 
 struct RespondQueryTaskCompletedRequest {
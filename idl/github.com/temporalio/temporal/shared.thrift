@@ -230,6 +230,7 @@ enum DecisionTaskFailedCause {
   BAD_BINARY,
   SCHEDULE_ACTIVITY_DUPLICATE_ID,
   BAD_SEARCH_ATTRIBUTES,
+  PENDING_LIMIT_EXCEEDED,
 }
 
 enum CancelExternalWorkflowExecutionFailedCause {
@@ -979,6 +980,18 @@ struct DomainConfiguration {
   90: optional string historyArchivalURI
   100: optional ArchivalStatus visibilityArchivalStatus
   110: optional string visibilityArchivalURI
+  120: optional DomainWorkflowDefaults workflowDefaults
+}
+
+struct DomainWorkflowDefaults {
+  10: optional i32 defaultExecutionStartToCloseTimeoutSeconds
+  20: optional i32 maxExecutionStartToCloseTimeoutSeconds
+  30: optional i32 defaultTaskStartToCloseTimeoutSeconds
+  40: optional i32 defaultActivityScheduleToCloseTimeoutSeconds
+  50: optional i32 maxActivityScheduleToCloseTimeoutSeconds
+  60: optional WorkflowIdReusePolicy defaultWorkflowIdReusePolicy
+  70: optional RetryPolicy defaultWorkflowRetryPolicy
+  80: optional RetryPolicy defaultActivityRetryPolicy
 }
 
 struct BadBinaries{
@@ -1087,6 +1100,7 @@ struct StartWorkflowExecutionRequest {
   140: optional Memo memo
   141: optional SearchAttributes searchAttributes
   150: optional Header header
+  160: optional i32 delayStartSeconds
 }
 
 struct StartWorkflowExecutionResponse {
@@ -1289,6 +1303,7 @@ struct SignalWithStartWorkflowExecutionRequest {
   160: optional Memo memo
   161: optional SearchAttributes searchAttributes
   170: optional Header header
+  180: optional i32 delayStartSeconds
 }
 
 struct TerminateWorkflowExecutionRequest {
@@ -33,6 +33,7 @@ struct ShardInfo {
   36: optional map<string, i64> clusterTimerAckLevel
   38: optional string owner
   40: optional map<string, i64> clusterReplicationLevel
+  42: optional map<string, i64> openWorkflowCounts // domainID -> open workflows of the shard
 }
 
 struct DomainInfo {
@@ -57,6 +58,9 @@ struct DomainInfo {
   44: optional string historyArchivalURI
   46: optional i16 visibilityArchivalStatus
   48: optional string visibilityArchivalURI
+  50: optional map<string, string> searchAttributes // domain level search attribute name to cluster search attribute
+  52: optional binary workflowDefaults
+  54: optional string workflowDefaultsEncoding
 }
 
 struct HistoryTreeInfo {
@@ -131,6 +135,7 @@ struct WorkflowExecutionInfo {
   120: optional map<string, binary> memo
   122: optional binary versionHistories
   124: optional string versionHistoriesEncoding
+  126: optional string startingBuildID // build ID of the worker that completed the first decision
 }
 
 struct ActivityInfo {
@@ -221,6 +226,9 @@ struct TaskListInfo {
   12: optional i64 (js.type = "Long") ackLevel
   14: optional i64 (js.type = "Long") expiryTimeNanos
   16: optional i64 (js.type = "Long") lastUpdatedNanos
+  18: optional list<string> buildIdSets // each entry is one compatible set of comma separated build IDs
+  20: optional i32 numWritePartitions
+  22: optional i32 numReadPartitions
 }
 
 struct TransferTaskInfo {
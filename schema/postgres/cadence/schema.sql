@@ -33,6 +33,15 @@ CREATE TABLE transfer_tasks(
   PRIMARY KEY (shard_id, task_id)
 );
 
+CREATE TABLE transfer_tasks_dlq (
+  shard_id INTEGER NOT NULL,
+  task_id BIGINT NOT NULL,
+  --
+  data BYTEA NOT NULL,
+  data_encoding VARCHAR(16) NOT NULL,
+  PRIMARY KEY (shard_id, task_id)
+);
+
 CREATE TABLE executions(
   shard_id INTEGER NOT NULL,
   domain_id BYTEA NOT NULL,
@@ -126,6 +135,16 @@ CREATE TABLE timer_tasks (
   PRIMARY KEY (shard_id, visibility_timestamp, task_id)
 );
 
+CREATE TABLE timer_tasks_dlq (
+  shard_id INTEGER NOT NULL,
+  visibility_timestamp TIMESTAMP NOT NULL,
+  task_id BIGINT NOT NULL,
+  --
+  data BYTEA NOT NULL,
+  data_encoding VARCHAR(16) NOT NULL,
+  PRIMARY KEY (shard_id, visibility_timestamp, task_id)
+);
+
 CREATE TABLE activity_info_maps (
 -- each row corresponds to one key of one map<string, ActivityInfo>
   shard_id INTEGER NOT NULL,
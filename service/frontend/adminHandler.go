@@ -26,6 +26,8 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gogo/status"
@@ -33,22 +35,30 @@ import (
 	"github.com/pborman/uuid"
 	commonproto "go.temporal.io/temporal-proto/common"
 	"go.temporal.io/temporal-proto/enums"
+	"go.temporal.io/temporal-proto/workflowservice"
 
 	"github.com/temporalio/temporal/.gen/go/shared"
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
+	"github.com/temporalio/temporal/.gen/proto/matchingservice"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/adapter"
+	"github.com/temporalio/temporal/common/audit"
+	"github.com/temporalio/temporal/common/backoff"
 	"github.com/temporalio/temporal/common/client"
 	"github.com/temporalio/temporal/common/definition"
+	"github.com/temporalio/temporal/common/domain"
+	"github.com/temporalio/temporal/common/elasticsearch/validator"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/messaging"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/resource"
 	"github.com/temporalio/temporal/common/service"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 	"github.com/temporalio/temporal/service/history"
+	"github.com/temporalio/temporal/service/worker/domaindeleter"
 )
 
 var _ adminservice.AdminServiceServer = (*AdminHandler)(nil)
@@ -66,6 +76,12 @@ type (
 		numberOfHistoryShards int
 		params                *service.BootstrapParams
 		config                *Config
+		dynamicCollection     *dynamicconfig.Collection
+		auditSink             audit.Sink
+		domainHandler         domain.Handler
+		domainDeleterClient   domaindeleter.Client
+
+		searchAttributesValidator *validator.SearchAttributesValidator
 	}
 
 	getWorkflowRawHistoryV2Token struct {
@@ -86,12 +102,36 @@ func NewAdminHandler(
 	resource resource.Resource,
 	params *service.BootstrapParams,
 	config *Config,
+	auditSink audit.Sink,
+	replicationMessageSink messaging.Producer,
 ) *AdminHandler {
 	return &AdminHandler{
 		Resource:              resource,
 		numberOfHistoryShards: params.PersistenceConfig.NumHistoryShards,
 		params:                params,
 		config:                config,
+		dynamicCollection:     dynamicconfig.NewCollection(params.DynamicConfig, resource.GetLogger()),
+		auditSink:             auditSink,
+		domainHandler: domain.NewHandler(
+			config.MinRetentionDays(),
+			config.MaxBadBinaries,
+			config.ValidSearchAttributes,
+			resource.GetLogger(),
+			resource.GetMetadataManager(),
+			resource.GetClusterMetadata(),
+			domain.NewDomainReplicator(replicationMessageSink, resource.GetLogger()),
+			resource.GetArchivalMetadata(),
+			resource.GetArchiverProvider(),
+		),
+		domainDeleterClient: domaindeleter.NewClient(resource.GetSDKClient(), params.PersistenceConfig.NumHistoryShards),
+		searchAttributesValidator: validator.NewSearchAttributesValidator(
+			resource.GetLogger(),
+			config.ValidSearchAttributes,
+			validator.NewDomainSearchAttributesFn(resource.GetDomainCache()),
+			config.SearchAttributesNumberOfKeysLimit,
+			config.SearchAttributesSizeOfValueLimit,
+			config.SearchAttributesTotalSizeLimit,
+		),
 	}
 }
 
@@ -245,6 +285,118 @@ func (adh *AdminHandler) CloseShard(ctx context.Context, request *adminservice.C
 	return &adminservice.CloseShardResponse{}, err
 }
 
+// ListShardTasks lists the pending transfer, timer or replication tasks of a shard
+func (adh *AdminHandler) ListShardTasks(ctx context.Context, request *adminservice.ListShardTasksRequest) (_ *adminservice.ListShardTasksResponse, retError error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminListShardTasksScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	var domainID string
+	if request.GetDomain() != "" {
+		var err error
+		domainID, err = adh.GetDomainCache().GetDomainID(request.GetDomain())
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+	}
+
+	resp, err := adh.GetHistoryClientGRPC().ListShardTasks(ctx, &historyservice.ListShardTasksRequest{
+		ShardID:                request.GetShardID(),
+		Category:               request.GetCategory(),
+		TaskTypes:              request.GetTaskTypes(),
+		DomainId:               domainID,
+		WorkflowId:             request.GetWorkflowId(),
+		MinVisibilityTimestamp: request.GetMinVisibilityTimestamp(),
+		MaxVisibilityTimestamp: request.GetMaxVisibilityTimestamp(),
+		PageSize:               request.GetPageSize(),
+		NextPageToken:          request.GetNextPageToken(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.ListShardTasksResponse{
+		Tasks:            resp.GetTasks(),
+		NextPageToken:    resp.GetNextPageToken(),
+		AckLevel:         resp.GetAckLevel(),
+		ReadLevel:        resp.GetReadLevel(),
+		ClusterAckLevels: resp.GetClusterAckLevels(),
+	}, nil
+}
+
+// ListShardDLQTasks lists the transfer or timer tasks in the DLQ of a shard
+func (adh *AdminHandler) ListShardDLQTasks(ctx context.Context, request *adminservice.ListShardDLQTasksRequest) (_ *adminservice.ListShardDLQTasksResponse, retError error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminListShardDLQTasksScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	resp, err := adh.GetHistoryClientGRPC().ListShardDLQTasks(ctx, &historyservice.ListShardDLQTasksRequest{
+		ShardID:       request.GetShardID(),
+		Type:          request.GetType(),
+		PageSize:      request.GetPageSize(),
+		NextPageToken: request.GetNextPageToken(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.ListShardDLQTasksResponse{
+		Tasks:         resp.GetTasks(),
+		NextPageToken: resp.GetNextPageToken(),
+	}, nil
+}
+
+// RetryShardDLQTasks re-processes the transfer or timer tasks in the DLQ of a shard
+func (adh *AdminHandler) RetryShardDLQTasks(ctx context.Context, request *adminservice.RetryShardDLQTasksRequest) (_ *adminservice.RetryShardDLQTasksResponse, retError error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminRetryShardDLQTasksScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	resp, err := adh.GetHistoryClientGRPC().RetryShardDLQTasks(ctx, &historyservice.RetryShardDLQTasksRequest{
+		ShardID: request.GetShardID(),
+		Type:    request.GetType(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.RetryShardDLQTasksResponse{
+		SucceededCount: resp.GetSucceededCount(),
+		FailedCount:    resp.GetFailedCount(),
+	}, nil
+}
+
+// PurgeShardDLQTasks deletes all transfer or timer tasks in the DLQ of a shard
+func (adh *AdminHandler) PurgeShardDLQTasks(ctx context.Context, request *adminservice.PurgeShardDLQTasksRequest) (_ *adminservice.PurgeShardDLQTasksResponse, retError error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminPurgeShardDLQTasksScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	resp, err := adh.GetHistoryClientGRPC().PurgeShardDLQTasks(ctx, &historyservice.PurgeShardDLQTasksRequest{
+		ShardID: request.GetShardID(),
+		Type:    request.GetType(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.PurgeShardDLQTasksResponse{
+		PurgedCount: resp.GetPurgedCount(),
+	}, nil
+}
+
 // DescribeHistoryHost returns information about the internal states of a history host
 func (adh *AdminHandler) DescribeHistoryHost(ctx context.Context, request *adminservice.DescribeHistoryHostRequest) (_ *adminservice.DescribeHistoryHostResponse, retError error) {
 	defer log.CapturePanicGRPC(adh.GetLogger(), &retError)
@@ -625,6 +777,258 @@ func (adh *AdminHandler) DescribeCluster(ctx context.Context, _ *adminservice.De
 	}, nil
 }
 
+// DescribeDynamicConfig returns the dynamic config keys read by this host and their effective values
+func (adh *AdminHandler) DescribeDynamicConfig(ctx context.Context, request *adminservice.DescribeDynamicConfigRequest) (_ *adminservice.DescribeDynamicConfigResponse, retError error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &retError)
+
+	_, sw := adh.startRequestProfile(metrics.AdminDescribeDynamicConfigScope)
+	defer sw.Stop()
+
+	var keys []*adminservice.DynamicConfigKeyInfo
+	for _, info := range adh.dynamicCollection.Keys() {
+		if !strings.HasPrefix(info.Name, request.GetNamePrefix()) {
+			continue
+		}
+		keys = append(keys, &adminservice.DynamicConfigKeyInfo{
+			Name:         info.Name,
+			Filters:      info.Filters,
+			DefaultValue: info.DefaultValue,
+			Value:        info.Value,
+			FromDefault:  info.FromDefault,
+			Error:        info.Error,
+			LastReadTime: info.LastReadTime.UnixNano(),
+		})
+	}
+
+	return &adminservice.DescribeDynamicConfigResponse{
+		HostAddress: adh.GetHostInfo().GetAddress(),
+		Keys:        keys,
+	}, nil
+}
+
+// ListAuditRecords lists the audit records of mutating API calls. With the file sink only the
+// records of calls served by this host are returned.
+func (adh *AdminHandler) ListAuditRecords(ctx context.Context, request *adminservice.ListAuditRecordsRequest) (_ *adminservice.ListAuditRecordsResponse, retError error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminListAuditRecordsScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+
+	listRequest := &audit.ListRequest{
+		Domain:        request.GetDomain(),
+		Identity:      request.GetIdentity(),
+		PageSize:      int(request.GetPageSize()),
+		NextPageToken: request.GetNextPageToken(),
+	}
+	if request.GetStartTime() > 0 {
+		listRequest.StartTime = time.Unix(0, request.GetStartTime())
+	}
+	if request.GetEndTime() > 0 {
+		listRequest.EndTime = time.Unix(0, request.GetEndTime())
+	}
+	resp, err := adh.auditSink.List(listRequest)
+	if err == audit.ErrInvalidNextPageToken {
+		return nil, adh.error(&shared.BadRequestError{Message: err.Error()}, scope)
+	}
+	if err != nil {
+		return nil, adh.error(&shared.InternalServiceError{Message: err.Error()}, scope)
+	}
+
+	records := make([]*adminservice.AuditRecord, 0, len(resp.Records))
+	for _, record := range resp.Records {
+		records = append(records, &adminservice.AuditRecord{
+			Timestamp:     record.Timestamp.UnixNano(),
+			Service:       record.Service,
+			Api:           record.API,
+			Identity:      record.Identity,
+			RemoteAddress: record.RemoteAddress,
+			Domain:        record.Domain,
+			WorkflowId:    record.WorkflowID,
+			RunId:         record.RunID,
+			Reason:        record.Reason,
+			Outcome:       record.Outcome,
+			Error:         record.Error,
+		})
+	}
+	return &adminservice.ListAuditRecordsResponse{
+		Records:       records,
+		NextPageToken: resp.NextPageToken,
+	}, nil
+}
+
+// UpdateTaskListBuildIDs replaces the compatible worker build ID sets of a decision task list
+func (adh *AdminHandler) UpdateTaskListBuildIDs(ctx context.Context, request *adminservice.UpdateTaskListBuildIDsRequest) (_ *adminservice.UpdateTaskListBuildIDsResponse, retError error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminUpdateTaskListBuildIDsScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet, scope)
+	}
+	if request.GetTaskList().GetName() == "" {
+		return nil, adh.error(errTaskListNotSet, scope)
+	}
+	domainID, err := adh.GetDomainCache().GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	_, err = adh.GetMatchingClient().UpdateTaskListBuildIDs(ctx, &matchingservice.UpdateTaskListBuildIDsRequest{
+		DomainUUID:  domainID,
+		TaskList:    request.TaskList,
+		BuildIdSets: request.BuildIdSets,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.UpdateTaskListBuildIDsResponse{}, nil
+}
+
+// GetTaskListBuildIDs returns the compatible worker build ID sets of a decision task list
+func (adh *AdminHandler) GetTaskListBuildIDs(ctx context.Context, request *adminservice.GetTaskListBuildIDsRequest) (_ *adminservice.GetTaskListBuildIDsResponse, retError error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminGetTaskListBuildIDsScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet, scope)
+	}
+	if request.GetTaskList().GetName() == "" {
+		return nil, adh.error(errTaskListNotSet, scope)
+	}
+	domainID, err := adh.GetDomainCache().GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	resp, err := adh.GetMatchingClient().GetTaskListBuildIDs(ctx, &matchingservice.GetTaskListBuildIDsRequest{
+		DomainUUID: domainID,
+		TaskList:   request.TaskList,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.GetTaskListBuildIDsResponse{BuildIdSets: resp.GetBuildIdSets()}, nil
+}
+
+// DescribeTaskList returns the pollers, backlog and add and dispatch rates of a task list. When partitions are
+// aggregated, every partition listed by ListTaskListPartitions is described by its owner and the stats are summed up
+func (adh *AdminHandler) DescribeTaskList(ctx context.Context, request *adminservice.DescribeTaskListRequest) (_ *adminservice.DescribeTaskListResponse, retError error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminDescribeTaskListScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet, scope)
+	}
+	if request.GetTaskList().GetName() == "" {
+		return nil, adh.error(errTaskListNotSet, scope)
+	}
+	domainID, err := adh.GetDomainCache().GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	partitions := []*commonproto.TaskListPartitionMetadata{{Key: request.TaskList.GetName()}}
+	if request.GetAggregatePartitions() {
+		resp, err := adh.GetMatchingClient().ListTaskListPartitions(ctx, &matchingservice.ListTaskListPartitionsRequest{
+			Domain:   request.GetDomain(),
+			TaskList: request.TaskList,
+		})
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		partitions = resp.GetDecisionTaskListPartitions()
+		if request.GetTaskListType() == enums.TaskListTypeActivity {
+			partitions = resp.GetActivityTaskListPartitions()
+		}
+	}
+
+	// every partition is described by its owner, partitions are queried in parallel and retried on transient errors
+	matchingResponses := make([]*matchingservice.DescribeTaskListResponse, len(partitions))
+	errs := make([]error, len(partitions))
+	var wg sync.WaitGroup
+	for i, partition := range partitions {
+		wg.Add(1)
+		go func(i int, partition *commonproto.TaskListPartitionMetadata) {
+			defer wg.Done()
+			matchingResponses[i], errs[i] = adh.describeTaskListPartition(ctx, &matchingservice.DescribeTaskListRequest{
+				DomainUUID: domainID,
+				DescRequest: &workflowservice.DescribeTaskListRequest{
+					TaskList: &commonproto.TaskList{
+						Name: partition.GetKey(),
+						Kind: request.TaskList.GetKind(),
+					},
+					TaskListType:          request.GetTaskListType(),
+					IncludeTaskListStatus: true,
+				},
+			})
+		}(i, partition)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+	}
+
+	response := &adminservice.DescribeTaskListResponse{}
+	pollers := make(map[string]*commonproto.PollerInfo)
+	var stats []*adminservice.TaskListStats
+	for i, partition := range partitions {
+		resp := matchingResponses[i]
+		// workers poll all partitions of a task list, report each of them once
+		for _, poller := range resp.GetPollers() {
+			if existing, ok := pollers[poller.GetIdentity()]; !ok || existing.GetLastAccessTime() < poller.GetLastAccessTime() {
+				pollers[poller.GetIdentity()] = poller
+			}
+		}
+		stats = append(stats, resp.GetStats())
+		response.Partitions = append(response.Partitions, &adminservice.TaskListPartitionStats{
+			Key:            partition.GetKey(),
+			OwnerHostName:  partition.GetOwnerHostName(),
+			TaskListStatus: resp.GetTaskListStatus(),
+			Stats:          resp.GetStats(),
+		})
+	}
+	for _, poller := range pollers {
+		response.Pollers = append(response.Pollers, poller)
+	}
+	response.Stats = aggregateTaskListStats(stats)
+	return response, nil
+}
+
+func (adh *AdminHandler) describeTaskListPartition(
+	ctx context.Context,
+	request *matchingservice.DescribeTaskListRequest,
+) (*matchingservice.DescribeTaskListResponse, error) {
+
+	var resp *matchingservice.DescribeTaskListResponse
+	op := func() error {
+		var err error
+		resp, err = adh.GetMatchingClient().DescribeTaskList(ctx, request)
+		return err
+	}
+	err := backoff.Retry(op, frontendServiceRetryPolicy, common.IsServiceTransientErrorGRPC)
+	return resp, err
+}
+
 // GetReplicationMessages returns new replication tasks since the read level provided in the token.
 func (adh *AdminHandler) GetReplicationMessages(ctx context.Context, request *adminservice.GetReplicationMessagesRequest) (_ *adminservice.GetReplicationMessagesResponse, retError error) {
 	defer log.CapturePanicGRPC(adh.GetLogger(), &retError)
@@ -754,6 +1158,259 @@ func (adh *AdminHandler) ReapplyEvents(ctx context.Context, request *adminservic
 	return nil, nil
 }
 
+// DeleteWorkflowExecution deletes a closed workflow execution together with its history and visibility record
+func (adh *AdminHandler) DeleteWorkflowExecution(ctx context.Context, request *adminservice.DeleteWorkflowExecutionRequest) (_ *adminservice.DeleteWorkflowExecutionResponse, retError error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminDeleteWorkflowExecutionScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet, scope)
+	}
+	if err := adh.validateExecution(request.WorkflowExecution); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	domainID, err := adh.GetDomainCache().GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	_, err = adh.GetHistoryClientGRPC().DeleteWorkflowExecution(ctx, &historyservice.DeleteWorkflowExecutionRequest{
+		DomainUUID:        domainID,
+		WorkflowExecution: request.WorkflowExecution,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.DeleteWorkflowExecutionResponse{}, nil
+}
+
+// UpsertWorkflowSearchAttributes updates the search attributes of a running workflow execution
+func (adh *AdminHandler) UpsertWorkflowSearchAttributes(ctx context.Context, request *adminservice.UpsertWorkflowSearchAttributesRequest) (_ *adminservice.UpsertWorkflowSearchAttributesResponse, retError error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminUpsertWorkflowSearchAttributesScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet, scope)
+	}
+	if err := adh.validateExecution(request.WorkflowExecution); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	if len(request.GetSearchAttributes().GetIndexedFields()) == 0 {
+		return nil, adh.error(errSearchAttributesNotSet, scope)
+	}
+	searchAttributes := adapter.ToThriftSearchAttributes(request.GetSearchAttributes())
+	if err := adh.searchAttributesValidator.ValidateSearchAttributes(searchAttributes, request.GetDomain()); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	searchAttributes, err := adh.searchAttributesValidator.ResolveSearchAttributes(searchAttributes, request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	domainID, err := adh.GetDomainCache().GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	_, err = adh.GetHistoryClientGRPC().UpsertWorkflowSearchAttributes(ctx, &historyservice.UpsertWorkflowSearchAttributesRequest{
+		DomainUUID:        domainID,
+		WorkflowExecution: request.WorkflowExecution,
+		SearchAttributes:  adapter.ToProtoSearchAttributes(searchAttributes),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.UpsertWorkflowSearchAttributesResponse{}, nil
+}
+
+// UnarchiveWorkflowExecution restores an archived workflow execution into persistence as a closed workflow execution
+func (adh *AdminHandler) UnarchiveWorkflowExecution(ctx context.Context, request *adminservice.UnarchiveWorkflowExecutionRequest) (_ *adminservice.UnarchiveWorkflowExecutionResponse, retError error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminUnarchiveWorkflowExecutionScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet, scope)
+	}
+	if err := adh.validateExecution(request.WorkflowExecution); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	if request.WorkflowExecution.GetRunId() == "" {
+		return nil, adh.error(errInvalidRunID, scope)
+	}
+	domainID, err := adh.GetDomainCache().GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	_, err = adh.GetHistoryClientGRPC().UnarchiveWorkflowExecution(ctx, &historyservice.UnarchiveWorkflowExecutionRequest{
+		DomainUUID:        domainID,
+		WorkflowExecution: request.WorkflowExecution,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.UnarchiveWorkflowExecutionResponse{}, nil
+}
+
+// AddSearchAttributes adds search attributes to the domain, each one stored in a cluster search attribute
+func (adh *AdminHandler) AddSearchAttributes(ctx context.Context, request *adminservice.AddSearchAttributesRequest) (_ *adminservice.AddSearchAttributesResponse, retError error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminAddSearchAttributesScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if err := adh.checkPermission(adh.config, request.SecurityToken); err != nil {
+		return nil, adh.error(errNoPermission, scope)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet, scope)
+	}
+	if err := adh.validateConfigForAdvanceVisibility(); err != nil {
+		return nil, adh.error(&shared.BadRequestError{Message: "AdvancedVisibilityStore is not configured for this Cadence Cluster"}, scope)
+	}
+
+	resp, err := adh.domainHandler.AddSearchAttributes(ctx, request)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return resp, nil
+}
+
+// RemoveSearchAttributes removes search attributes from the domain
+func (adh *AdminHandler) RemoveSearchAttributes(ctx context.Context, request *adminservice.RemoveSearchAttributesRequest) (_ *adminservice.RemoveSearchAttributesResponse, retError error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminRemoveSearchAttributesScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if err := adh.checkPermission(adh.config, request.SecurityToken); err != nil {
+		return nil, adh.error(errNoPermission, scope)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet, scope)
+	}
+
+	resp, err := adh.domainHandler.RemoveSearchAttributes(ctx, request)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return resp, nil
+}
+
+// ListSearchAttributes lists the search attributes of the domain
+func (adh *AdminHandler) ListSearchAttributes(ctx context.Context, request *adminservice.ListSearchAttributesRequest) (_ *adminservice.ListSearchAttributesResponse, retError error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminListSearchAttributesScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet, scope)
+	}
+
+	resp, err := adh.domainHandler.ListSearchAttributes(ctx, request)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return resp, nil
+}
+
+// DeleteDomain marks the domain deleted and starts the domain deleter workflow that purges its data,
+// deleting a domain already marked deleted resumes the purge
+func (adh *AdminHandler) DeleteDomain(ctx context.Context, request *adminservice.DeleteDomainRequest) (_ *adminservice.DeleteDomainResponse, retError error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminDeleteDomainScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if err := adh.checkPermission(adh.config, request.SecurityToken); err != nil {
+		return nil, adh.error(errNoPermission, scope)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet, scope)
+	}
+
+	resp, err := adh.domainHandler.DeleteDomain(ctx, request)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	runID, err := adh.domainDeleterClient.DeleteDomain(ctx, resp.GetDomainId(), request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	resp.WorkflowId = domaindeleter.WorkflowID(resp.GetDomainId())
+	resp.RunId = runID
+	return resp, nil
+}
+
+// UpdateDomainWorkflowDefaults replaces the workflow defaults of the domain
+func (adh *AdminHandler) UpdateDomainWorkflowDefaults(ctx context.Context, request *adminservice.UpdateDomainWorkflowDefaultsRequest) (_ *adminservice.UpdateDomainWorkflowDefaultsResponse, retError error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminUpdateDomainWorkflowDefaultsScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if err := adh.checkPermission(adh.config, request.SecurityToken); err != nil {
+		return nil, adh.error(errNoPermission, scope)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet, scope)
+	}
+
+	resp, err := adh.domainHandler.UpdateDomainWorkflowDefaults(ctx, request)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return resp, nil
+}
+
+// DescribeDomainWorkflowDefaults returns the workflow defaults of the domain
+func (adh *AdminHandler) DescribeDomainWorkflowDefaults(ctx context.Context, request *adminservice.DescribeDomainWorkflowDefaultsRequest) (_ *adminservice.DescribeDomainWorkflowDefaultsResponse, retError error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminDescribeDomainWorkflowDefaultsScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet, scope)
+	}
+
+	resp, err := adh.domainHandler.DescribeDomainWorkflowDefaults(ctx, request)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return resp, nil
+}
+
 //===================================================================
 func (adh *AdminHandler) validateGetWorkflowExecutionRawHistoryV2Request(
 	request *adminservice.GetWorkflowExecutionRawHistoryV2Request,
@@ -918,6 +1575,28 @@ func (adh *AdminHandler) validatePaginationToken(
 	return nil
 }
 
+// aggregateTaskListStats sums up the stats of task list partitions. The rates exclude forwarded tasks and polls,
+// so a task matched on a parent partition is only counted once. The backlog age is the one of the oldest backlog
+func aggregateTaskListStats(partitions []*adminservice.TaskListStats) *adminservice.TaskListStats {
+	total := &adminservice.TaskListStats{}
+	var syncMatchRate float64
+	for _, p := range partitions {
+		total.BacklogCountHint += p.GetBacklogCountHint()
+		if p.GetBacklogAgeMillis() > total.BacklogAgeMillis {
+			total.BacklogAgeMillis = p.GetBacklogAgeMillis()
+		}
+		total.AddRatePerSecond += p.GetAddRatePerSecond()
+		total.DispatchRatePerSecond += p.GetDispatchRatePerSecond()
+		total.ForwardedAddCount += p.GetForwardedAddCount()
+		total.ForwardedDispatchCount += p.GetForwardedDispatchCount()
+		syncMatchRate += p.GetSyncMatchRatio() * p.GetAddRatePerSecond()
+	}
+	if total.AddRatePerSecond > 0 {
+		total.SyncMatchRatio = syncMatchRate / total.AddRatePerSecond
+	}
+	return total
+}
+
 // startRequestProfile initiates recording of request metrics
 func (adh *AdminHandler) startRequestProfile(scope int) (metrics.Scope, metrics.Stopwatch) {
 	metricsScope := adh.GetMetricsClient().Scope(scope)
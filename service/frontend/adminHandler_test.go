@@ -40,6 +40,7 @@ import (
 	"github.com/temporalio/temporal/.gen/proto/historyservicemock"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/adapter"
+	"github.com/temporalio/temporal/common/audit"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/elasticsearch"
@@ -96,8 +97,9 @@ func (s *adminHandlerSuite) SetupTest() {
 	}
 	config := &Config{
 		EnableAdminProtection: dynamicconfig.GetBoolPropertyFn(false),
+		MinRetentionDays:      dynamicconfig.GetIntPropertyFn(1),
 	}
-	s.handler = NewAdminHandler(s.mockResource, params, config)
+	s.handler = NewAdminHandler(s.mockResource, params, config, audit.NewNoopSink(), nil)
 	s.handler.Start()
 }
 
@@ -599,3 +601,33 @@ func (s *adminHandlerSuite) Test_AddSearchAttribute_Permission() {
 		s.Nil(resp)
 	}
 }
+
+func (s *adminHandlerSuite) Test_AggregateTaskListStats() {
+	stats := aggregateTaskListStats([]*adminservice.TaskListStats{
+		{
+			BacklogCountHint:      10,
+			BacklogAgeMillis:      2000,
+			AddRatePerSecond:      30,
+			DispatchRatePerSecond: 20,
+			SyncMatchRatio:        1,
+			ForwardedAddCount:     5,
+		},
+		{
+			BacklogCountHint:       5,
+			BacklogAgeMillis:       5000,
+			AddRatePerSecond:       10,
+			DispatchRatePerSecond:  15,
+			ForwardedDispatchCount: 3,
+		},
+	})
+	s.Equal(&adminservice.TaskListStats{
+		BacklogCountHint:       15,
+		BacklogAgeMillis:       5000,
+		AddRatePerSecond:       40,
+		DispatchRatePerSecond:  35,
+		SyncMatchRatio:         0.75,
+		ForwardedAddCount:      5,
+		ForwardedDispatchCount: 3,
+	}, stats)
+	s.Equal(&adminservice.TaskListStats{}, aggregateTaskListStats(nil))
+}
@@ -157,3 +157,165 @@ func (adh *AdminNilCheckHandler) ReapplyEvents(ctx context.Context, request *adm
 	}
 	return resp, err
 }
+
+// DescribeDynamicConfig ...
+func (adh *AdminNilCheckHandler) DescribeDynamicConfig(ctx context.Context, request *adminservice.DescribeDynamicConfigRequest) (_ *adminservice.DescribeDynamicConfigResponse, retError error) {
+	resp, err := adh.parentHandler.DescribeDynamicConfig(ctx, request)
+	if resp == nil && err == nil {
+		return &adminservice.DescribeDynamicConfigResponse{}, err
+	}
+	return resp, err
+}
+
+// ListAuditRecords ...
+func (adh *AdminNilCheckHandler) ListAuditRecords(ctx context.Context, request *adminservice.ListAuditRecordsRequest) (_ *adminservice.ListAuditRecordsResponse, retError error) {
+	resp, err := adh.parentHandler.ListAuditRecords(ctx, request)
+	if resp == nil && err == nil {
+		return &adminservice.ListAuditRecordsResponse{}, err
+	}
+	return resp, err
+}
+
+// UpdateTaskListBuildIDs ...
+func (adh *AdminNilCheckHandler) UpdateTaskListBuildIDs(ctx context.Context, request *adminservice.UpdateTaskListBuildIDsRequest) (_ *adminservice.UpdateTaskListBuildIDsResponse, retError error) {
+	resp, err := adh.parentHandler.UpdateTaskListBuildIDs(ctx, request)
+	if resp == nil && err == nil {
+		return &adminservice.UpdateTaskListBuildIDsResponse{}, err
+	}
+	return resp, err
+}
+
+// GetTaskListBuildIDs ...
+func (adh *AdminNilCheckHandler) GetTaskListBuildIDs(ctx context.Context, request *adminservice.GetTaskListBuildIDsRequest) (_ *adminservice.GetTaskListBuildIDsResponse, retError error) {
+	resp, err := adh.parentHandler.GetTaskListBuildIDs(ctx, request)
+	if resp == nil && err == nil {
+		return &adminservice.GetTaskListBuildIDsResponse{}, err
+	}
+	return resp, err
+}
+
+// ListShardDLQTasks ...
+func (adh *AdminNilCheckHandler) ListShardDLQTasks(ctx context.Context, request *adminservice.ListShardDLQTasksRequest) (_ *adminservice.ListShardDLQTasksResponse, retError error) {
+	resp, err := adh.parentHandler.ListShardDLQTasks(ctx, request)
+	if resp == nil && err == nil {
+		return &adminservice.ListShardDLQTasksResponse{}, err
+	}
+	return resp, err
+}
+
+// RetryShardDLQTasks ...
+func (adh *AdminNilCheckHandler) RetryShardDLQTasks(ctx context.Context, request *adminservice.RetryShardDLQTasksRequest) (_ *adminservice.RetryShardDLQTasksResponse, retError error) {
+	resp, err := adh.parentHandler.RetryShardDLQTasks(ctx, request)
+	if resp == nil && err == nil {
+		return &adminservice.RetryShardDLQTasksResponse{}, err
+	}
+	return resp, err
+}
+
+// PurgeShardDLQTasks ...
+func (adh *AdminNilCheckHandler) PurgeShardDLQTasks(ctx context.Context, request *adminservice.PurgeShardDLQTasksRequest) (_ *adminservice.PurgeShardDLQTasksResponse, retError error) {
+	resp, err := adh.parentHandler.PurgeShardDLQTasks(ctx, request)
+	if resp == nil && err == nil {
+		return &adminservice.PurgeShardDLQTasksResponse{}, err
+	}
+	return resp, err
+}
+
+// ListShardTasks ...
+func (adh *AdminNilCheckHandler) ListShardTasks(ctx context.Context, request *adminservice.ListShardTasksRequest) (_ *adminservice.ListShardTasksResponse, retError error) {
+	resp, err := adh.parentHandler.ListShardTasks(ctx, request)
+	if resp == nil && err == nil {
+		return &adminservice.ListShardTasksResponse{}, err
+	}
+	return resp, err
+}
+
+// DescribeTaskList ...
+func (adh *AdminNilCheckHandler) DescribeTaskList(ctx context.Context, request *adminservice.DescribeTaskListRequest) (_ *adminservice.DescribeTaskListResponse, retError error) {
+	resp, err := adh.parentHandler.DescribeTaskList(ctx, request)
+	if resp == nil && err == nil {
+		return &adminservice.DescribeTaskListResponse{}, err
+	}
+	return resp, err
+}
+
+// DeleteWorkflowExecution ...
+func (adh *AdminNilCheckHandler) DeleteWorkflowExecution(ctx context.Context, request *adminservice.DeleteWorkflowExecutionRequest) (_ *adminservice.DeleteWorkflowExecutionResponse, retError error) {
+	resp, err := adh.parentHandler.DeleteWorkflowExecution(ctx, request)
+	if resp == nil && err == nil {
+		return &adminservice.DeleteWorkflowExecutionResponse{}, err
+	}
+	return resp, err
+}
+
+// UpsertWorkflowSearchAttributes ...
+func (adh *AdminNilCheckHandler) UpsertWorkflowSearchAttributes(ctx context.Context, request *adminservice.UpsertWorkflowSearchAttributesRequest) (_ *adminservice.UpsertWorkflowSearchAttributesResponse, retError error) {
+	resp, err := adh.parentHandler.UpsertWorkflowSearchAttributes(ctx, request)
+	if resp == nil && err == nil {
+		return &adminservice.UpsertWorkflowSearchAttributesResponse{}, err
+	}
+	return resp, err
+}
+
+// UnarchiveWorkflowExecution ...
+func (adh *AdminNilCheckHandler) UnarchiveWorkflowExecution(ctx context.Context, request *adminservice.UnarchiveWorkflowExecutionRequest) (_ *adminservice.UnarchiveWorkflowExecutionResponse, retError error) {
+	resp, err := adh.parentHandler.UnarchiveWorkflowExecution(ctx, request)
+	if resp == nil && err == nil {
+		return &adminservice.UnarchiveWorkflowExecutionResponse{}, err
+	}
+	return resp, err
+}
+
+// AddSearchAttributes ...
+func (adh *AdminNilCheckHandler) AddSearchAttributes(ctx context.Context, request *adminservice.AddSearchAttributesRequest) (_ *adminservice.AddSearchAttributesResponse, retError error) {
+	resp, err := adh.parentHandler.AddSearchAttributes(ctx, request)
+	if resp == nil && err == nil {
+		return &adminservice.AddSearchAttributesResponse{}, err
+	}
+	return resp, err
+}
+
+// RemoveSearchAttributes ...
+func (adh *AdminNilCheckHandler) RemoveSearchAttributes(ctx context.Context, request *adminservice.RemoveSearchAttributesRequest) (_ *adminservice.RemoveSearchAttributesResponse, retError error) {
+	resp, err := adh.parentHandler.RemoveSearchAttributes(ctx, request)
+	if resp == nil && err == nil {
+		return &adminservice.RemoveSearchAttributesResponse{}, err
+	}
+	return resp, err
+}
+
+// ListSearchAttributes ...
+func (adh *AdminNilCheckHandler) ListSearchAttributes(ctx context.Context, request *adminservice.ListSearchAttributesRequest) (_ *adminservice.ListSearchAttributesResponse, retError error) {
+	resp, err := adh.parentHandler.ListSearchAttributes(ctx, request)
+	if resp == nil && err == nil {
+		return &adminservice.ListSearchAttributesResponse{}, err
+	}
+	return resp, err
+}
+
+// DeleteDomain ...
+func (adh *AdminNilCheckHandler) DeleteDomain(ctx context.Context, request *adminservice.DeleteDomainRequest) (_ *adminservice.DeleteDomainResponse, retError error) {
+	resp, err := adh.parentHandler.DeleteDomain(ctx, request)
+	if resp == nil && err == nil {
+		return &adminservice.DeleteDomainResponse{}, err
+	}
+	return resp, err
+}
+
+// UpdateDomainWorkflowDefaults ...
+func (adh *AdminNilCheckHandler) UpdateDomainWorkflowDefaults(ctx context.Context, request *adminservice.UpdateDomainWorkflowDefaultsRequest) (_ *adminservice.UpdateDomainWorkflowDefaultsResponse, retError error) {
+	resp, err := adh.parentHandler.UpdateDomainWorkflowDefaults(ctx, request)
+	if resp == nil && err == nil {
+		return &adminservice.UpdateDomainWorkflowDefaultsResponse{}, err
+	}
+	return resp, err
+}
+
+// DescribeDomainWorkflowDefaults ...
+func (adh *AdminNilCheckHandler) DescribeDomainWorkflowDefaults(ctx context.Context, request *adminservice.DescribeDomainWorkflowDefaultsRequest) (_ *adminservice.DescribeDomainWorkflowDefaultsResponse, retError error) {
+	resp, err := adh.parentHandler.DescribeDomainWorkflowDefaults(ctx, request)
+	if resp == nil && err == nil {
+		return &adminservice.DescribeDomainWorkflowDefaultsResponse{}, err
+	}
+	return resp, err
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	commonproto "go.temporal.io/temporal-proto/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"github.com/temporalio/temporal/common/audit"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+)
+
+type (
+	domainGetter interface {
+		GetDomain() string
+	}
+
+	domainNameGetter interface {
+		GetDomainName() string
+	}
+
+	// domain APIs carry the domain in the name field
+	nameGetter interface {
+		GetName() string
+	}
+
+	workflowIDGetter interface {
+		GetWorkflowId() string
+	}
+
+	workflowExecutionGetter interface {
+		GetWorkflowExecution() *commonproto.WorkflowExecution
+	}
+
+	runIDGetter interface {
+		GetRunId() string
+	}
+
+	identityGetter interface {
+		GetIdentity() string
+	}
+
+	reasonGetter interface {
+		GetReason() string
+	}
+)
+
+// auditedAPIs are the mutating APIs issued by operators and starters of workflows,
+// task completion APIs called by workers are not audited
+var auditedAPIs = map[string]struct{}{
+	// WorkflowService
+	"RegisterDomain":                   {},
+	"UpdateDomain":                     {},
+	"DeprecateDomain":                  {},
+	"StartWorkflowExecution":           {},
+	"SignalWorkflowExecution":          {},
+	"SignalWithStartWorkflowExecution": {},
+	"RequestCancelWorkflowExecution":   {},
+	"TerminateWorkflowExecution":       {},
+	"ResetWorkflowExecution":           {},
+	"ResetStickyTaskList":              {},
+	// AdminService
+	"AddSearchAttribute":             {},
+	"CloseShard":                     {},
+	"RemoveTask":                     {},
+	"RetryShardDLQTasks":             {},
+	"PurgeShardDLQTasks":             {},
+	"ReapplyEvents":                  {},
+	"UpdateTaskListBuildIDs":         {},
+	"DeleteWorkflowExecution":        {},
+	"UpsertWorkflowSearchAttributes": {},
+	"UnarchiveWorkflowExecution":     {},
+	"AddSearchAttributes":            {},
+	"RemoveSearchAttributes":         {},
+	"DeleteDomain":                   {},
+	"UpdateDomainWorkflowDefaults":   {},
+}
+
+// NewAuditInterceptor creates a gRPC interceptor which writes an audit record for every mutating API call
+func NewAuditInterceptor(sink audit.Sink, logger log.Logger) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		serviceName, api := splitMethodName(info.FullMethod)
+		if _, ok := auditedAPIs[api]; !ok {
+			return handler(ctx, req)
+		}
+
+		startTime := time.Now()
+		resp, err := handler(ctx, req)
+
+		record := newAuditRecord(ctx, serviceName, api, req, resp, err)
+		record.Timestamp = startTime.UTC()
+		if writeErr := sink.Write(record); writeErr != nil {
+			// a failure to audit must not fail the call, which has already been executed
+			logger.Error("Failed to write audit record.", tag.Error(writeErr), tag.Value(record))
+		}
+		return resp, err
+	}
+}
+
+func newAuditRecord(
+	ctx context.Context,
+	serviceName string,
+	api string,
+	req interface{},
+	resp interface{},
+	err error,
+) *audit.Record {
+
+	record := &audit.Record{
+		Service: serviceName,
+		API:     api,
+		Outcome: audit.OutcomeSuccess,
+	}
+	if err != nil {
+		record.Outcome = audit.OutcomeFailure
+		record.Error = err.Error()
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		record.RemoteAddress = p.Addr.String()
+	}
+
+	switch r := req.(type) {
+	case domainGetter:
+		record.Domain = r.GetDomain()
+	case domainNameGetter:
+		record.Domain = r.GetDomainName()
+	case nameGetter:
+		record.Domain = r.GetName()
+	}
+	if r, ok := req.(workflowExecutionGetter); ok {
+		record.WorkflowID = r.GetWorkflowExecution().GetWorkflowId()
+		record.RunID = r.GetWorkflowExecution().GetRunId()
+	} else if r, ok := req.(workflowIDGetter); ok {
+		record.WorkflowID = r.GetWorkflowId()
+	}
+	if record.RunID == "" {
+		// the run ID of started workflows is only known once the call succeeds
+		if r, ok := resp.(runIDGetter); ok {
+			record.RunID = r.GetRunId()
+		}
+	}
+	if r, ok := req.(identityGetter); ok {
+		record.Identity = r.GetIdentity()
+	}
+	if r, ok := req.(reasonGetter); ok {
+		record.Reason = r.GetReason()
+	}
+	return record
+}
+
+// splitMethodName splits gRPC full method name "/package.Service/Method" into service and method names
+func splitMethodName(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	index := strings.LastIndex(fullMethod, "/")
+	if index < 0 {
+		return "", fullMethod
+	}
+	serviceName := fullMethod[:index]
+	if dot := strings.LastIndex(serviceName, "."); dot >= 0 {
+		serviceName = serviceName[dot+1:]
+	}
+	return serviceName, fullMethod[index+1:]
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonproto "go.temporal.io/temporal-proto/common"
+	"go.temporal.io/temporal-proto/workflowservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	"github.com/temporalio/temporal/common/audit"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+)
+
+type (
+	auditInterceptorSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		sink        *recordingAuditSink
+		interceptor grpc.UnaryServerInterceptor
+	}
+
+	recordingAuditSink struct {
+		audit.Sink
+		records []*audit.Record
+	}
+)
+
+func TestAuditInterceptorSuite(t *testing.T) {
+	suite.Run(t, new(auditInterceptorSuite))
+}
+
+func (s *recordingAuditSink) Write(record *audit.Record) error {
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *auditInterceptorSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.sink = &recordingAuditSink{}
+	s.interceptor = NewAuditInterceptor(s.sink, loggerimpl.NewNopLogger())
+}
+
+func (s *auditInterceptorSuite) TestTerminateWorkflowExecution() {
+	request := &workflowservice.TerminateWorkflowExecutionRequest{
+		Domain: "test-domain",
+		WorkflowExecution: &commonproto.WorkflowExecution{
+			WorkflowId: "test-workflow-id",
+			RunId:      "test-run-id",
+		},
+		Reason:   "test-reason",
+		Identity: "test-identity",
+	}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 7233}})
+	handlerErr := errors.New("test-error")
+	_, err := s.interceptor(
+		ctx,
+		request,
+		&grpc.UnaryServerInfo{FullMethod: "/workflowservice.WorkflowService/TerminateWorkflowExecution"},
+		func(ctx context.Context, req interface{}) (interface{}, error) { return nil, handlerErr },
+	)
+	s.Equal(handlerErr, err)
+
+	s.Len(s.sink.records, 1)
+	record := s.sink.records[0]
+	s.False(record.Timestamp.IsZero())
+	s.Equal(&audit.Record{
+		Timestamp:     record.Timestamp,
+		Service:       "WorkflowService",
+		API:           "TerminateWorkflowExecution",
+		Identity:      "test-identity",
+		RemoteAddress: "127.0.0.1:7233",
+		Domain:        "test-domain",
+		WorkflowID:    "test-workflow-id",
+		RunID:         "test-run-id",
+		Reason:        "test-reason",
+		Outcome:       audit.OutcomeFailure,
+		Error:         "test-error",
+	}, record)
+}
+
+func (s *auditInterceptorSuite) TestStartWorkflowExecution() {
+	request := &workflowservice.StartWorkflowExecutionRequest{
+		Domain:     "test-domain",
+		WorkflowId: "test-workflow-id",
+		Identity:   "test-identity",
+	}
+	_, err := s.interceptor(
+		context.Background(),
+		request,
+		&grpc.UnaryServerInfo{FullMethod: "/workflowservice.WorkflowService/StartWorkflowExecution"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &workflowservice.StartWorkflowExecutionResponse{RunId: "test-run-id"}, nil
+		},
+	)
+	s.NoError(err)
+
+	s.Len(s.sink.records, 1)
+	record := s.sink.records[0]
+	s.Equal("test-domain", record.Domain)
+	s.Equal("test-workflow-id", record.WorkflowID)
+	s.Equal("test-run-id", record.RunID)
+	s.Equal(audit.OutcomeSuccess, record.Outcome)
+	s.Empty(record.Error)
+}
+
+func (s *auditInterceptorSuite) TestAdminAPI() {
+	_, err := s.interceptor(
+		context.Background(),
+		&adminservice.CloseShardRequest{ShardID: 1},
+		&grpc.UnaryServerInfo{FullMethod: "/adminservice.AdminService/CloseShard"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &adminservice.CloseShardResponse{}, nil
+		},
+	)
+	s.NoError(err)
+
+	s.Len(s.sink.records, 1)
+	s.Equal("AdminService", s.sink.records[0].Service)
+	s.Equal("CloseShard", s.sink.records[0].API)
+}
+
+func (s *auditInterceptorSuite) TestReadAPINotAudited() {
+	_, err := s.interceptor(
+		context.Background(),
+		&workflowservice.DescribeWorkflowExecutionRequest{Domain: "test-domain"},
+		&grpc.UnaryServerInfo{FullMethod: "/workflowservice.WorkflowService/DescribeWorkflowExecution"},
+		func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil },
+	)
+	s.NoError(err)
+	s.Empty(s.sink.records)
+}
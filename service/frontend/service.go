@@ -21,19 +21,24 @@
 package frontend
 
 import (
+	"context"
 	"sync/atomic"
 
 	"github.com/stretchr/testify/mock"
 	"go.temporal.io/temporal-proto/workflowservice"
 	"google.golang.org/grpc"
 
+	"github.com/temporalio/temporal/.gen/go/shared"
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	"github.com/temporalio/temporal/.gen/proto/healthservice"
 	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/audit"
 	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/domain"
+	"github.com/temporalio/temporal/common/health"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/membership"
 	"github.com/temporalio/temporal/common/messaging"
 	"github.com/temporalio/temporal/common/mocks"
 	"github.com/temporalio/temporal/common/persistence"
@@ -43,6 +48,7 @@ import (
 	"github.com/temporalio/temporal/common/service"
 	"github.com/temporalio/temporal/common/service/config"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
+	"github.com/temporalio/temporal/common/tracing"
 )
 
 // Config represents configuration for cadence-frontend service
@@ -77,6 +83,10 @@ type Config struct {
 	// size limit system protection
 	BlobSizeLimitError dynamicconfig.IntPropertyFnWithDomainFilter
 	BlobSizeLimitWarn  dynamicconfig.IntPropertyFnWithDomainFilter
+	// EventBlobSizeLimitError is the blob size limit of payloads recorded in history events, which may be offloaded
+	EventBlobSizeLimitError dynamicconfig.IntPropertyFnWithDomainFilter
+	// OffloadedBlobSizeLimitError is the blob size limit of payloads offloaded to the history payload store
+	OffloadedBlobSizeLimitError dynamicconfig.IntPropertyFnWithDomainFilter
 
 	ThrottledLogRPS dynamicconfig.IntPropertyFn
 
@@ -116,6 +126,8 @@ func NewConfig(dc *dynamicconfig.Collection, numHistoryShards int, enableReadFro
 		DisableListVisibilityByFilter:       dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.DisableListVisibilityByFilter, false),
 		BlobSizeLimitError:                  dc.GetIntPropertyFilteredByDomain(dynamicconfig.BlobSizeLimitError, 2*1024*1024),
 		BlobSizeLimitWarn:                   dc.GetIntPropertyFilteredByDomain(dynamicconfig.BlobSizeLimitWarn, 256*1024),
+		EventBlobSizeLimitError:             dc.GetIntPropertyFilteredByDomain(dynamicconfig.BlobSizeLimitError, 2*1024*1024),
+		OffloadedBlobSizeLimitError:         dc.GetIntPropertyFilteredByDomain(dynamicconfig.OffloadedBlobSizeLimitError, 16*1024*1024),
 		ThrottledLogRPS:                     dc.GetIntProperty(dynamicconfig.FrontendThrottledLogRPS, 20),
 		EnableDomainNotActiveAutoForwarding: dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.EnableDomainNotActiveAutoForwarding, true),
 		EnableClientVersionCheck:            dc.GetBoolProperty(dynamicconfig.EnableClientVersionCheck, false),
@@ -138,6 +150,8 @@ type Service struct {
 	params *service.BootstrapParams
 
 	adminHandler *AdminHandler
+	auditSink    audit.Sink
+	healthServer *health.Server
 	server       *grpc.Server
 }
 
@@ -149,6 +163,12 @@ func NewService(
 	isAdvancedVisExistInConfig := len(params.PersistenceConfig.AdvancedVisibilityStore) != 0
 	serviceConfig := NewConfig(dynamicconfig.NewCollection(params.DynamicConfig, params.Logger), params.PersistenceConfig.NumHistoryShards, isAdvancedVisExistInConfig)
 
+	serviceConfig.EventBlobSizeLimitError = persistence.NewBlobSizeLimitWithOffloading(
+		serviceConfig.EventBlobSizeLimitError,
+		serviceConfig.OffloadedBlobSizeLimitError,
+		params.PersistenceConfig.GetHistoryPayloadOffloadThreshold(),
+	)
+
 	params.PersistenceConfig.HistoryMaxConns = serviceConfig.HistoryMgrNumConns()
 	params.PersistenceConfig.SetMaxQPS(params.PersistenceConfig.DefaultStore, serviceConfig.PersistenceMaxQPS())
 	params.PersistenceConfig.VisibilityConfig = &config.VisibilityConfig{
@@ -193,11 +213,25 @@ func NewService(
 		return nil, err
 	}
 
+	auditSink, err := audit.NewSink(&params.AuditConfig, func() (persistence.Queue, error) {
+		return persistenceClient.NewFactory(
+			&params.PersistenceConfig,
+			params.ClusterMetadata.GetCurrentClusterName(),
+			params.MetricsClient,
+			params.Logger,
+		).NewAuditLogQueue()
+	}, params.MetricsClient, params.Logger)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Service{
-		Resource: serviceResource,
-		status:   common.DaemonStatusInitialized,
-		config:   serviceConfig,
-		params:   params,
+		Resource:     serviceResource,
+		status:       common.DaemonStatusInitialized,
+		config:       serviceConfig,
+		params:       params,
+		auditSink:    auditSink,
+		healthServer: health.NewServer("workflowservice.WorkflowService", params.HealthConfig, serviceResource.GetLogger()),
 	}, nil
 }
 
@@ -229,7 +263,11 @@ func (s *Service) Start() {
 		replicationMessageSink.(*mocks.KafkaProducer).On("Publish", mock.Anything).Return(nil)
 	}
 
-	s.server = grpc.NewServer()
+	interceptors := append([]grpc.UnaryServerInterceptor{
+		tracing.NewServerInterceptor(),
+		NewAuditInterceptor(s.auditSink, logger),
+	}, s.params.Interceptors...)
+	s.server = grpc.NewServer(grpc.UnaryInterceptor(common.ChainUnaryServerInterceptors(interceptors...)))
 
 	wfHandler := NewWorkflowHandler(s, s.config, replicationMessageSink)
 	wfHandlerGRPC := NewWorkflowHandlerGRPC(s, wfHandler, s.config, replicationMessageSink)
@@ -240,15 +278,26 @@ func (s *Service) Start() {
 	workflowservice.RegisterWorkflowServiceServer(s.server, workflowNilCheckHandler)
 	healthservice.RegisterMetaServer(s.server, accessControlledWorkflowHandler)
 
-	s.adminHandler = NewAdminHandler(s, s.params, s.config)
+	s.adminHandler = NewAdminHandler(s, s.params, s.config, s.auditSink, replicationMessageSink)
 	adminNilCheckHandler := NewAdminNilCheckHandler(s.adminHandler)
 
 	adminservice.RegisterAdminServiceServer(s.server, adminNilCheckHandler)
+	s.healthServer.RegisterGRPC(s.server)
 
 	// must start resource first
 	s.Resource.Start()
 	s.adminHandler.Start()
 
+	// frontend forwards most requests to history and matching
+	s.healthServer.AddCheck("history", health.ServiceCheck(s.GetHistoryServiceResolver(), common.HistoryServiceName, s.probeHistoryHost))
+	s.healthServer.AddCheck("matching", health.ServiceCheck(
+		s.GetMatchingServiceResolver(),
+		common.MatchingServiceName,
+		health.GRPCProbe(s.params.RPCFactory.CreateGRPCConnection, "matchingservice.MatchingService"),
+	))
+	s.healthServer.AddCheck("persistence", health.PersistenceCheck(s.GetMetadataManager()))
+	s.healthServer.Start()
+
 	listener := s.GetGRPCListener()
 	logger.Info("Starting to serve on frontend listener")
 	if err := s.server.Serve(listener); err != nil {
@@ -264,10 +313,20 @@ func (s *Service) Stop() {
 		return
 	}
 
+	s.healthServer.Stop()
 	s.server.GracefulStop()
 
 	s.adminHandler.Stop()
+	s.auditSink.Close()
 	s.Resource.Stop()
 
 	s.params.Logger.Info("frontend stopped")
 }
+
+// probeHistoryHost checks that the history client can reach the given history host
+func (s *Service) probeHistoryHost(ctx context.Context, host *membership.HostInfo) error {
+	_, err := s.GetHistoryRawClient().DescribeHistoryHost(ctx, &shared.DescribeHistoryHostRequest{
+		HostAddress: common.StringPtr(host.GetAddress()),
+	})
+	return err
+}
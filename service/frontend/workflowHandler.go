@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/opentracing/opentracing-go"
 	"github.com/pborman/uuid"
 	"go.uber.org/yarpc"
 	"go.uber.org/yarpc/yarpcerrors"
@@ -50,6 +51,7 @@ import (
 	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/quotas"
 	"github.com/temporalio/temporal/common/resource"
+	"github.com/temporalio/temporal/common/tracing"
 )
 
 var _ workflowserviceserver.Interface = (*WorkflowHandler)(nil)
@@ -105,9 +107,12 @@ var (
 	errInvalidRetention                           = &gen.BadRequestError{Message: "RetentionDays is invalid."}
 	errInvalidExecutionStartToCloseTimeoutSeconds = &gen.BadRequestError{Message: "A valid ExecutionStartToCloseTimeoutSeconds is not set on request."}
 	errInvalidTaskStartToCloseTimeoutSeconds      = &gen.BadRequestError{Message: "A valid TaskStartToCloseTimeoutSeconds is not set on request."}
+	errInvalidDelayStartSeconds                   = &gen.BadRequestError{Message: "DelayStartSeconds cannot be less than 0."}
 	errQueryDisallowedForDomain                   = &gen.BadRequestError{Message: "Domain is not allowed to query, please contact cadence team to re-enable queries."}
 	errClusterNameNotSet                          = &gen.BadRequestError{Message: "Cluster name is not set."}
+	errSearchAttributesNotSet                     = &gen.BadRequestError{Message: "SearchAttributes is not set on request."}
 	errEmptyReplicationInfo                       = &gen.BadRequestError{Message: "Replication task info is not set."}
+	errDomainDeleted                              = &gen.BadRequestError{Message: "Domain is deleted."}
 
 	// err for archival
 	errHistoryNotFound = &gen.BadRequestError{Message: "Requested workflow history not found, may have passed retention period."}
@@ -146,6 +151,7 @@ func NewWorkflowHandler(
 		domainHandler: domain.NewHandler(
 			config.MinRetentionDays(),
 			config.MaxBadBinaries,
+			config.ValidSearchAttributes,
 			resource.GetLogger(),
 			resource.GetMetadataManager(),
 			resource.GetClusterMetadata(),
@@ -153,10 +159,14 @@ func NewWorkflowHandler(
 			resource.GetArchivalMetadata(),
 			resource.GetArchiverProvider(),
 		),
-		visibilityQueryValidator: validator.NewQueryValidator(config.ValidSearchAttributes),
+		visibilityQueryValidator: validator.NewQueryValidator(
+			config.ValidSearchAttributes,
+			validator.NewDomainSearchAttributesFn(resource.GetDomainCache()),
+		),
 		searchAttributesValidator: validator.NewSearchAttributesValidator(
 			resource.GetLogger(),
 			config.ValidSearchAttributes,
+			validator.NewDomainSearchAttributesFn(resource.GetDomainCache()),
 			config.SearchAttributesNumberOfKeysLimit,
 			config.SearchAttributesSizeOfValueLimit,
 			config.SearchAttributesTotalSizeLimit,
@@ -479,7 +489,7 @@ func (wh *WorkflowHandler) RespondActivityTaskCompleted(
 	)
 	defer sw.Stop()
 
-	sizeLimitError := wh.config.BlobSizeLimitError(domainEntry.GetInfo().Name)
+	sizeLimitError := wh.config.EventBlobSizeLimitError(domainEntry.GetInfo().Name)
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainEntry.GetInfo().Name)
 
 	if err := common.CheckEventBlobSizeLimit(
@@ -582,7 +592,7 @@ func (wh *WorkflowHandler) RespondActivityTaskCompletedByID(
 	// add domain tag to scope, so further metrics will have the domain tag
 	scope = scope.Tagged(metrics.DomainTag(domainEntry.GetInfo().Name))
 
-	sizeLimitError := wh.config.BlobSizeLimitError(domainEntry.GetInfo().Name)
+	sizeLimitError := wh.config.EventBlobSizeLimitError(domainEntry.GetInfo().Name)
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainEntry.GetInfo().Name)
 
 	if err := common.CheckEventBlobSizeLimit(
@@ -675,7 +685,7 @@ func (wh *WorkflowHandler) RespondActivityTaskFailed(
 		return wh.error(errIdentityTooLong, scope)
 	}
 
-	sizeLimitError := wh.config.BlobSizeLimitError(domainEntry.GetInfo().Name)
+	sizeLimitError := wh.config.EventBlobSizeLimitError(domainEntry.GetInfo().Name)
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainEntry.GetInfo().Name)
 
 	if err := common.CheckEventBlobSizeLimit(
@@ -765,7 +775,7 @@ func (wh *WorkflowHandler) RespondActivityTaskFailedByID(
 	// add domain tag to scope, so further metrics will have the domain tag
 	scope = scope.Tagged(metrics.DomainTag(domainEntry.GetInfo().Name))
 
-	sizeLimitError := wh.config.BlobSizeLimitError(domainEntry.GetInfo().Name)
+	sizeLimitError := wh.config.EventBlobSizeLimitError(domainEntry.GetInfo().Name)
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainEntry.GetInfo().Name)
 
 	if err := common.CheckEventBlobSizeLimit(
@@ -847,7 +857,7 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceled(
 		return wh.error(errIdentityTooLong, scope)
 	}
 
-	sizeLimitError := wh.config.BlobSizeLimitError(domainEntry.GetInfo().Name)
+	sizeLimitError := wh.config.EventBlobSizeLimitError(domainEntry.GetInfo().Name)
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainEntry.GetInfo().Name)
 
 	if err := common.CheckEventBlobSizeLimit(
@@ -949,7 +959,7 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceledByID(
 	// add domain tag to scope, so further metrics will have the domain tag
 	scope = scope.Tagged(metrics.DomainTag(domainEntry.GetInfo().Name))
 
-	sizeLimitError := wh.config.BlobSizeLimitError(domainEntry.GetInfo().Name)
+	sizeLimitError := wh.config.EventBlobSizeLimitError(domainEntry.GetInfo().Name)
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainEntry.GetInfo().Name)
 
 	if err := common.CheckEventBlobSizeLimit(
@@ -1124,7 +1134,7 @@ func (wh *WorkflowHandler) RespondDecisionTaskFailed(
 		return wh.error(errIdentityTooLong, scope)
 	}
 
-	sizeLimitError := wh.config.BlobSizeLimitError(domainEntry.GetInfo().Name)
+	sizeLimitError := wh.config.EventBlobSizeLimitError(domainEntry.GetInfo().Name)
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainEntry.GetInfo().Name)
 
 	if err := common.CheckEventBlobSizeLimit(
@@ -1232,6 +1242,10 @@ func (wh *WorkflowHandler) StartWorkflowExecution(
 		return nil, wh.error(err, scope)
 	}
 
+	if startRequest.GetDelayStartSeconds() < 0 {
+		return nil, wh.error(errInvalidDelayStartSeconds, scope)
+	}
+
 	wh.GetLogger().Debug(
 		"Received StartWorkflowExecution. WorkflowID",
 		tag.WorkflowID(startRequest.GetWorkflowId()))
@@ -1248,14 +1262,6 @@ func (wh *WorkflowHandler) StartWorkflowExecution(
 		return nil, err
 	}
 
-	if startRequest.GetExecutionStartToCloseTimeoutSeconds() <= 0 {
-		return nil, wh.error(errInvalidExecutionStartToCloseTimeoutSeconds, scope)
-	}
-
-	if startRequest.GetTaskStartToCloseTimeoutSeconds() <= 0 {
-		return nil, wh.error(errInvalidTaskStartToCloseTimeoutSeconds, scope)
-	}
-
 	if startRequest.GetRequestId() == "" {
 		return nil, wh.error(errRequestIDNotSet, scope)
 	}
@@ -1264,12 +1270,35 @@ func (wh *WorkflowHandler) StartWorkflowExecution(
 		return nil, wh.error(errRequestIDTooLong, scope)
 	}
 
-	if err := wh.searchAttributesValidator.ValidateSearchAttributes(startRequest.SearchAttributes, domainName); err != nil {
+	wh.GetLogger().Debug("Start workflow execution request domain", tag.WorkflowDomainName(domainName))
+	domainEntry, err := wh.getDomainEntryToStart(domainName)
+	if err != nil {
 		return nil, wh.error(err, scope)
 	}
+	domainID := domainEntry.GetInfo().ID
 
-	wh.GetLogger().Debug("Start workflow execution request domain", tag.WorkflowDomainName(domainName))
-	domainID, err := wh.GetDomainCache().GetDomainID(domainName)
+	defaults := domainEntry.GetConfig().WorkflowDefaults
+	startRequest.ExecutionStartToCloseTimeoutSeconds = common.Int32Ptr(
+		defaults.ExecutionStartToCloseTimeout(startRequest.GetExecutionStartToCloseTimeoutSeconds()),
+	)
+	startRequest.TaskStartToCloseTimeoutSeconds = common.Int32Ptr(
+		defaults.TaskStartToCloseTimeout(startRequest.GetTaskStartToCloseTimeoutSeconds()),
+	)
+	startRequest.WorkflowIdReusePolicy = defaults.WorkflowIDReusePolicy(startRequest.WorkflowIdReusePolicy)
+	startRequest.RetryPolicy = defaults.WorkflowRetryPolicy(startRequest.RetryPolicy)
+
+	if startRequest.GetExecutionStartToCloseTimeoutSeconds() <= 0 {
+		return nil, wh.error(errInvalidExecutionStartToCloseTimeoutSeconds, scope)
+	}
+
+	if startRequest.GetTaskStartToCloseTimeoutSeconds() <= 0 {
+		return nil, wh.error(errInvalidTaskStartToCloseTimeoutSeconds, scope)
+	}
+
+	if err := wh.searchAttributesValidator.ValidateSearchAttributes(startRequest.SearchAttributes, domainName); err != nil {
+		return nil, wh.error(err, scope)
+	}
+	startRequest.SearchAttributes, err = wh.searchAttributesValidator.ResolveSearchAttributes(startRequest.SearchAttributes, domainName)
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
@@ -1277,14 +1306,10 @@ func (wh *WorkflowHandler) StartWorkflowExecution(
 	// add domain tag to scope, so further metrics will have the domain tag
 	scope = scope.Tagged(metrics.DomainTag(domainName))
 
-	sizeLimitError := wh.config.BlobSizeLimitError(domainName)
+	sizeLimitError := wh.config.EventBlobSizeLimitError(domainName)
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName)
-	actualSize := len(startRequest.Input)
-	if startRequest.Memo != nil {
-		actualSize += common.GetSizeOfMapStringToByteArray(startRequest.Memo.GetFields())
-	}
 	if err := common.CheckEventBlobSizeLimit(
-		actualSize,
+		len(startRequest.Input),
 		sizeLimitWarn,
 		sizeLimitError,
 		domainID,
@@ -1295,8 +1320,22 @@ func (wh *WorkflowHandler) StartWorkflowExecution(
 	); err != nil {
 		return nil, wh.error(err, scope)
 	}
+	// memo and header are never offloaded, they are checked against the blob size limit
+	if err := common.CheckEventBlobSizeLimit(
+		common.GetSizeOfMapStringToByteArray(startRequest.Memo.GetFields())+common.GetSizeOfMapStringToByteArray(startRequest.Header.GetFields()),
+		sizeLimitWarn,
+		wh.config.BlobSizeLimitError(domainName),
+		domainID,
+		startRequest.GetWorkflowId(),
+		"",
+		scope,
+		wh.GetThrottledLogger(),
+	); err != nil {
+		return nil, wh.error(err, scope)
+	}
 
 	wh.GetLogger().Debug("Start workflow execution request domainID", tag.WorkflowDomainID(domainID))
+	startRequest.Header = wh.injectTraceIntoHeader(ctx, startRequest.Header)
 	resp, err = wh.GetHistoryClient().StartWorkflowExecution(ctx, common.CreateHistoryStartWorkflowRequest(domainID, startRequest), versionHeaders(ctx)...)
 
 	if err != nil {
@@ -1718,7 +1757,7 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(
 		return wh.error(err, scope)
 	}
 
-	sizeLimitError := wh.config.BlobSizeLimitError(signalRequest.GetDomain())
+	sizeLimitError := wh.config.EventBlobSizeLimitError(signalRequest.GetDomain())
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(signalRequest.GetDomain())
 	if err := common.CheckEventBlobSizeLimit(
 		len(signalRequest.Input),
@@ -1811,6 +1850,22 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(
 		return nil, wh.error(errRequestIDTooLong, scope)
 	}
 
+	domainEntry, err := wh.getDomainEntryToStart(domainName)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+	domainID := domainEntry.GetInfo().ID
+
+	defaults := domainEntry.GetConfig().WorkflowDefaults
+	signalWithStartRequest.ExecutionStartToCloseTimeoutSeconds = common.Int32Ptr(
+		defaults.ExecutionStartToCloseTimeout(signalWithStartRequest.GetExecutionStartToCloseTimeoutSeconds()),
+	)
+	signalWithStartRequest.TaskStartToCloseTimeoutSeconds = common.Int32Ptr(
+		defaults.TaskStartToCloseTimeout(signalWithStartRequest.GetTaskStartToCloseTimeoutSeconds()),
+	)
+	signalWithStartRequest.WorkflowIdReusePolicy = defaults.WorkflowIDReusePolicy(signalWithStartRequest.WorkflowIdReusePolicy)
+	signalWithStartRequest.RetryPolicy = defaults.WorkflowRetryPolicy(signalWithStartRequest.RetryPolicy)
+
 	if signalWithStartRequest.GetExecutionStartToCloseTimeoutSeconds() <= 0 {
 		return nil, wh.error(&gen.BadRequestError{
 			Message: "A valid ExecutionStartToCloseTimeoutSeconds is not set on request."}, scope)
@@ -1829,16 +1884,19 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(
 		return nil, wh.error(err, scope)
 	}
 
+	if signalWithStartRequest.GetDelayStartSeconds() < 0 {
+		return nil, wh.error(errInvalidDelayStartSeconds, scope)
+	}
+
 	if err := wh.searchAttributesValidator.ValidateSearchAttributes(signalWithStartRequest.SearchAttributes, domainName); err != nil {
 		return nil, wh.error(err, scope)
 	}
-
-	domainID, err := wh.GetDomainCache().GetDomainID(domainName)
+	signalWithStartRequest.SearchAttributes, err = wh.searchAttributesValidator.ResolveSearchAttributes(signalWithStartRequest.SearchAttributes, domainName)
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
 
-	sizeLimitError := wh.config.BlobSizeLimitError(domainName)
+	sizeLimitError := wh.config.EventBlobSizeLimitError(domainName)
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(domainName)
 	if err := common.CheckEventBlobSizeLimit(
 		len(signalWithStartRequest.SignalInput),
@@ -1852,9 +1910,8 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(
 	); err != nil {
 		return nil, wh.error(err, scope)
 	}
-	actualSize := len(signalWithStartRequest.Input) + common.GetSizeOfMapStringToByteArray(signalWithStartRequest.Memo.GetFields())
 	if err := common.CheckEventBlobSizeLimit(
-		actualSize,
+		len(signalWithStartRequest.Input),
 		sizeLimitWarn,
 		sizeLimitError,
 		domainID,
@@ -1865,7 +1922,21 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(
 	); err != nil {
 		return nil, wh.error(err, scope)
 	}
+	// memo and header are never offloaded, they are checked against the blob size limit
+	if err := common.CheckEventBlobSizeLimit(
+		common.GetSizeOfMapStringToByteArray(signalWithStartRequest.Memo.GetFields())+common.GetSizeOfMapStringToByteArray(signalWithStartRequest.Header.GetFields()),
+		sizeLimitWarn,
+		wh.config.BlobSizeLimitError(domainName),
+		domainID,
+		signalWithStartRequest.GetWorkflowId(),
+		"",
+		scope,
+		wh.GetThrottledLogger(),
+	); err != nil {
+		return nil, wh.error(err, scope)
+	}
 
+	signalWithStartRequest.Header = wh.injectTraceIntoHeader(ctx, signalWithStartRequest.Header)
 	op := func() error {
 		var err error
 		resp, err = wh.GetHistoryClient().SignalWithStartWorkflowExecution(ctx, &h.SignalWithStartWorkflowExecutionRequest{
@@ -2125,6 +2196,9 @@ func (wh *WorkflowHandler) ListOpenWorkflowExecutions(
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
+	if err := wh.toDomainSearchAttributes(domain, persistenceResp.Executions); err != nil {
+		return nil, wh.error(err, scope)
+	}
 
 	resp = &gen.ListOpenWorkflowExecutionsResponse{}
 	resp.Executions = persistenceResp.Executions
@@ -2207,7 +2281,7 @@ func (wh *WorkflowHandler) ListArchivedWorkflowExecutions(
 		return nil, wh.error(err, scope)
 	}
 
-	// special handling of ExecutionTime for cron or retry
+	// special handling of ExecutionTime for cron, retry or delayed start
 	for _, execution := range archiverResponse.Executions {
 		if execution.GetExecutionTime() == 0 {
 			execution.ExecutionTime = common.Int64Ptr(execution.GetStartTime())
@@ -2341,6 +2415,9 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
+	if err := wh.toDomainSearchAttributes(domain, persistenceResp.Executions); err != nil {
+		return nil, wh.error(err, scope)
+	}
 
 	resp = &gen.ListClosedWorkflowExecutionsResponse{}
 	resp.Executions = persistenceResp.Executions
@@ -2404,6 +2481,9 @@ func (wh *WorkflowHandler) ListWorkflowExecutions(
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
+	if err := wh.toDomainSearchAttributes(domain, persistenceResp.Executions); err != nil {
+		return nil, wh.error(err, scope)
+	}
 
 	resp = &gen.ListWorkflowExecutionsResponse{}
 	resp.Executions = persistenceResp.Executions
@@ -2467,6 +2547,9 @@ func (wh *WorkflowHandler) ScanWorkflowExecutions(
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
+	if err := wh.toDomainSearchAttributes(domain, persistenceResp.Executions); err != nil {
+		return nil, wh.error(err, scope)
+	}
 
 	resp = &gen.ListWorkflowExecutionsResponse{}
 	resp.Executions = persistenceResp.Executions
@@ -3254,6 +3337,40 @@ func (wh *WorkflowHandler) convertIndexedKeyToThrift(keys map[string]interface{}
 	return converted
 }
 
+// toDomainSearchAttributes replaces the cluster search attributes of the executions
+// by the names they are registered under on the domain
+func (wh *WorkflowHandler) toDomainSearchAttributes(domain string, executions []*gen.WorkflowExecutionInfo) error {
+	domainEntry, err := wh.GetDomainCache().GetDomain(domain)
+	if err != nil {
+		return err
+	}
+	domainSearchAttributes := domainEntry.GetConfig().SearchAttributes
+	if len(domainSearchAttributes) == 0 {
+		return nil
+	}
+	for _, execution := range executions {
+		if execution.SearchAttributes != nil {
+			execution.SearchAttributes.IndexedFields = validator.ToDomainSearchAttributes(
+				execution.SearchAttributes.IndexedFields,
+				domainSearchAttributes,
+			)
+		}
+	}
+	return nil
+}
+
+// getDomainEntryToStart returns the domain to start a workflow in, workflows can't be started in deleted domains
+func (wh *WorkflowHandler) getDomainEntryToStart(domainName string) (*cache.DomainCacheEntry, error) {
+	domainEntry, err := wh.GetDomainCache().GetDomain(domainName)
+	if err != nil {
+		return nil, err
+	}
+	if domainEntry.GetInfo().Status == persistence.DomainStatusDeleted {
+		return nil, errDomainDeleted
+	}
+	return domainEntry, nil
+}
+
 func (wh *WorkflowHandler) isListRequestPageSizeTooLarge(pageSize int32, domain string) bool {
 	return wh.config.EnableReadVisibilityFromES(domain) &&
 		pageSize > int32(wh.config.ESIndexMaxResultWindow())
@@ -3304,3 +3421,23 @@ func versionHeaders(ctx context.Context) []yarpc.CallOption {
 		yarpc.WithHeader(common.ClientImplHeaderName, headers[2]),
 	}
 }
+
+// injectTraceIntoHeader propagates the span of the request to the workflow header,
+// so that workers can link their spans to the request which started the workflow
+func (wh *WorkflowHandler) injectTraceIntoHeader(ctx context.Context, header *gen.Header) *gen.Header {
+	span := opentracing.SpanFromContext(ctx)
+	if span == nil {
+		return header
+	}
+
+	if header == nil {
+		header = &gen.Header{}
+	}
+	fields, err := tracing.InjectIntoHeaderFields(span, header.Fields)
+	if err != nil {
+		wh.GetLogger().Warn("Unable to propagate span context to workflow header.", tag.Error(err))
+		return header
+	}
+	header.Fields = fields
+	return header
+}
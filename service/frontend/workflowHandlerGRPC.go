@@ -24,6 +24,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/gogo/status"
@@ -109,6 +110,7 @@ var (
 	stQueryDisallowedForDomain                   = status.New(codes.InvalidArgument, "Domain is not allowed to query, please contact cadence team to re-enable queries.")
 	stClusterNameNotSet                          = status.New(codes.InvalidArgument, "Cluster name is not set.")
 	stEmptyReplicationInfo                       = status.New(codes.InvalidArgument, "Replication task info is not set.")
+	stDomainDeleted                              = status.New(codes.InvalidArgument, "Domain is deleted.")
 	stHistoryNotFound                            = status.New(codes.InvalidArgument, "Requested workflow history not found, may have passed retention period.")
 	stDomainTooLong                              = status.New(codes.InvalidArgument, "Domain length exceeds limit.")
 	stWorkflowTypeTooLong                        = status.New(codes.InvalidArgument, "WorkflowType length exceeds limit.")
@@ -143,6 +145,7 @@ func NewWorkflowHandlerGRPC(
 		domainHandler: domain.NewHandler(
 			config.MinRetentionDays(),
 			config.MaxBadBinaries,
+			config.ValidSearchAttributes,
 			resource.GetLogger(),
 			resource.GetMetadataManager(),
 			resource.GetClusterMetadata(),
@@ -150,10 +153,14 @@ func NewWorkflowHandlerGRPC(
 			resource.GetArchivalMetadata(),
 			resource.GetArchiverProvider(),
 		),
-		visibilityQueryValidator: validator.NewQueryValidator(config.ValidSearchAttributes),
+		visibilityQueryValidator: validator.NewQueryValidator(
+			config.ValidSearchAttributes,
+			validator.NewDomainSearchAttributesFn(resource.GetDomainCache()),
+		),
 		searchAttributesValidator: validator.NewSearchAttributesValidator(
 			resource.GetLogger(),
 			config.ValidSearchAttributes,
+			validator.NewDomainSearchAttributesFn(resource.GetDomainCache()),
 			config.SearchAttributesNumberOfKeysLimit,
 			config.SearchAttributesSizeOfValueLimit,
 			config.SearchAttributesTotalSizeLimit,
@@ -321,7 +328,14 @@ func (wh *WorkflowHandlerGRPC) DeprecateDomain(ctx context.Context, request *wor
 func (wh *WorkflowHandlerGRPC) StartWorkflowExecution(ctx context.Context, request *workflowservice.StartWorkflowExecutionRequest) (_ *workflowservice.StartWorkflowExecutionResponse, retError error) {
 	defer log.CapturePanicGRPC(wh.workflowHandlerThrift.GetLogger(), &retError)
 
-	response, err := wh.workflowHandlerThrift.StartWorkflowExecution(ctx, adapter.ToThriftStartWorkflowExecutionRequest(request))
+	startRequest := adapter.ToThriftStartWorkflowExecutionRequest(request)
+	delayStartSeconds, err := delayStartSecondsFromHeader(ctx)
+	if err != nil {
+		return nil, adapter.ToProtoError(err)
+	}
+	startRequest.DelayStartSeconds = delayStartSeconds
+
+	response, err := wh.workflowHandlerThrift.StartWorkflowExecution(ctx, startRequest)
 	if err != nil {
 		return nil, adapter.ToProtoError(err)
 	}
@@ -731,7 +745,14 @@ func (wh *WorkflowHandlerGRPC) SignalWorkflowExecution(ctx context.Context, requ
 func (wh *WorkflowHandlerGRPC) SignalWithStartWorkflowExecution(ctx context.Context, request *workflowservice.SignalWithStartWorkflowExecutionRequest) (_ *workflowservice.SignalWithStartWorkflowExecutionResponse, retError error) {
 	defer log.CapturePanicGRPC(wh.workflowHandlerThrift.GetLogger(), &retError)
 
-	response, err := wh.workflowHandlerThrift.SignalWithStartWorkflowExecution(ctx, adapter.ToThriftSignalWithStartWorkflowExecutionRequest(request))
+	signalWithStartRequest := adapter.ToThriftSignalWithStartWorkflowExecutionRequest(request)
+	delayStartSeconds, err := delayStartSecondsFromHeader(ctx)
+	if err != nil {
+		return nil, adapter.ToProtoError(err)
+	}
+	signalWithStartRequest.DelayStartSeconds = delayStartSeconds
+
+	response, err := wh.workflowHandlerThrift.SignalWithStartWorkflowExecution(ctx, signalWithStartRequest)
 	if err != nil {
 		return nil, adapter.ToProtoError(err)
 	}
@@ -1673,3 +1694,16 @@ func (wh *WorkflowHandlerGRPC) checkBadBinary(domainEntry *cache.DomainCacheEntr
 	}
 	return nil
 }
+
+// delayStartSecondsFromHeader reads the optional workflow start delay, gRPC clients pass it as a request header
+func delayStartSecondsFromHeader(ctx context.Context) (*int32, error) {
+	value := client.GetHeadersValue(ctx, common.DelayStartSecondsHeaderName)[0]
+	if value == "" {
+		return nil, nil
+	}
+	delayStartSeconds, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return nil, errInvalidDelayStartSeconds
+	}
+	return common.Int32Ptr(int32(delayStartSeconds)), nil
+}
@@ -34,7 +34,9 @@ import (
 	commonproto "go.temporal.io/temporal-proto/common"
 	"go.temporal.io/temporal-proto/enums"
 	"go.temporal.io/temporal-proto/workflowservice"
+	"go.uber.org/yarpc"
 
+	h "github.com/temporalio/temporal/.gen/go/history"
 	"github.com/temporalio/temporal/.gen/go/history/historyservicetest"
 	"github.com/temporalio/temporal/.gen/go/shared"
 	"github.com/temporalio/temporal/common"
@@ -251,6 +253,36 @@ func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_RequestIdNotSet
 	s.Equal(stRequestIDNotSet.Err(), err)
 }
 
+func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_DomainDeleted() {
+	config := s.newConfig()
+	config.RPS = dc.GetIntPropertyFn(10)
+	wh := s.getWorkflowHandler(config)
+
+	s.mockDomainCache.EXPECT().GetDomain("test-domain").Return(cache.NewLocalDomainCacheEntryForTest(
+		&persistence.DomainInfo{ID: s.testDomainID, Name: "test-domain", Status: persistence.DomainStatusDeleted},
+		&persistence.DomainConfig{},
+		"",
+		nil,
+	), nil)
+
+	startWorkflowExecutionRequest := &workflowservice.StartWorkflowExecutionRequest{
+		Domain:     "test-domain",
+		WorkflowId: "workflow-id",
+		WorkflowType: &commonproto.WorkflowType{
+			Name: "workflow-type",
+		},
+		TaskList: &commonproto.TaskList{
+			Name: "task-list",
+		},
+		ExecutionStartToCloseTimeoutSeconds: 1,
+		TaskStartToCloseTimeoutSeconds:      1,
+		RequestId:                           uuid.New(),
+	}
+	_, err := wh.StartWorkflowExecution(context.Background(), startWorkflowExecutionRequest)
+	s.Error(err)
+	s.Equal(stDomainDeleted.Err(), err)
+}
+
 func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_StartRequestNotSet() {
 	config := s.newConfig()
 	config.RPS = dc.GetIntPropertyFn(10)
@@ -384,6 +416,13 @@ func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_InvalidExecutio
 	config.RPS = dc.GetIntPropertyFn(10)
 	wh := s.getWorkflowHandler(config)
 
+	s.mockDomainCache.EXPECT().GetDomain("test-domain").Return(cache.NewLocalDomainCacheEntryForTest(
+		&persistence.DomainInfo{ID: s.testDomainID, Name: "test-domain"},
+		&persistence.DomainConfig{},
+		"",
+		nil,
+	), nil)
+
 	startWorkflowExecutionRequest := &workflowservice.StartWorkflowExecutionRequest{
 		Domain:     "test-domain",
 		WorkflowId: "workflow-id",
@@ -414,6 +453,13 @@ func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_InvalidTaskStar
 	config.RPS = dc.GetIntPropertyFn(10)
 	wh := s.getWorkflowHandler(config)
 
+	s.mockDomainCache.EXPECT().GetDomain("test-domain").Return(cache.NewLocalDomainCacheEntryForTest(
+		&persistence.DomainInfo{ID: s.testDomainID, Name: "test-domain"},
+		&persistence.DomainConfig{},
+		"",
+		nil,
+	), nil)
+
 	startWorkflowExecutionRequest := &workflowservice.StartWorkflowExecutionRequest{
 		Domain:     "test-domain",
 		WorkflowId: "workflow-id",
@@ -439,6 +485,56 @@ func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_InvalidTaskStar
 	s.Equal(stInvalidTaskStartToCloseTimeoutSeconds.Err(), err)
 }
 
+func (s *workflowHandlerSuite) TestStartWorkflowExecution_WorkflowDefaults() {
+	config := s.newConfig()
+	config.RPS = dc.GetIntPropertyFn(10)
+	wh := s.getWorkflowHandler(config)
+
+	s.mockDomainCache.EXPECT().GetDomain("test-domain").Return(cache.NewLocalDomainCacheEntryForTest(
+		&persistence.DomainInfo{ID: s.testDomainID, Name: "test-domain"},
+		&persistence.DomainConfig{
+			WorkflowDefaults: &persistence.DomainWorkflowDefaults{
+				MaxExecutionStartToCloseTimeoutSeconds: 86400,
+				DefaultTaskStartToCloseTimeoutSeconds:  10,
+				DefaultWorkflowIDReusePolicy:           shared.WorkflowIdReusePolicyRejectDuplicate.Ptr(),
+				DefaultWorkflowRetryPolicy: &shared.RetryPolicy{
+					InitialIntervalInSeconds: common.Int32Ptr(1),
+					BackoffCoefficient:       common.Float64Ptr(2),
+				},
+			},
+		},
+		"",
+		nil,
+	), nil)
+	s.mockHistoryClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *h.StartWorkflowExecutionRequest, _ ...yarpc.CallOption) (*shared.StartWorkflowExecutionResponse, error) {
+			startRequest := request.StartRequest
+			s.Equal(int32(86400), startRequest.GetExecutionStartToCloseTimeoutSeconds())
+			s.Equal(int32(10), startRequest.GetTaskStartToCloseTimeoutSeconds())
+			// the reuse policy of the request is set, the domain default doesn't apply
+			s.Equal(shared.WorkflowIdReusePolicyAllowDuplicateFailedOnly, startRequest.GetWorkflowIdReusePolicy())
+			s.Equal(float64(2), startRequest.RetryPolicy.GetBackoffCoefficient())
+			return &shared.StartWorkflowExecutionResponse{RunId: common.StringPtr(testRunID)}, nil
+		},
+	)
+
+	startWorkflowExecutionRequest := &workflowservice.StartWorkflowExecutionRequest{
+		Domain:     "test-domain",
+		WorkflowId: "workflow-id",
+		WorkflowType: &commonproto.WorkflowType{
+			Name: "workflow-type",
+		},
+		TaskList: &commonproto.TaskList{
+			Name: "task-list",
+		},
+		ExecutionStartToCloseTimeoutSeconds: 864000,
+		RequestId:                           uuid.New(),
+	}
+	resp, err := wh.StartWorkflowExecution(context.Background(), startWorkflowExecutionRequest)
+	s.NoError(err)
+	s.Equal(testRunID, resp.GetRunId())
+}
+
 func (s *workflowHandlerSuite) TestRegisterDomain_Failure_InvalidArchivalURI() {
 	s.mockClusterMetadata.EXPECT().IsGlobalDomainEnabled().Return(false)
 	s.mockArchivalMetadata.On("GetHistoryConfig").Return(archiver.NewArchivalConfig("enabled", dc.GetStringPropertyFn("enabled"), dc.GetBoolPropertyFn(true), "disabled", "random URI"))
@@ -1098,6 +1194,12 @@ func (s *workflowHandlerSuite) TestListWorkflowExecutions() {
 	wh := s.getWorkflowHandler(config)
 
 	s.mockDomainCache.EXPECT().GetDomainID(gomock.Any()).Return(s.testDomainID, nil).AnyTimes()
+	s.mockDomainCache.EXPECT().GetDomain(gomock.Any()).Return(cache.NewLocalDomainCacheEntryForTest(
+		nil,
+		&persistence.DomainConfig{},
+		"",
+		nil,
+	), nil).AnyTimes()
 	s.mockVisibilityMgr.On("ListWorkflowExecutions", mock.Anything).Return(&persistence.ListWorkflowExecutionsResponse{}, nil).Once()
 
 	listRequest := &workflowservice.ListWorkflowExecutionsRequest{
@@ -1127,6 +1229,12 @@ func (s *workflowHandlerSuite) TestScantWorkflowExecutions() {
 	wh := s.getWorkflowHandler(config)
 
 	s.mockDomainCache.EXPECT().GetDomainID(gomock.Any()).Return(s.testDomainID, nil).AnyTimes()
+	s.mockDomainCache.EXPECT().GetDomain(gomock.Any()).Return(cache.NewLocalDomainCacheEntryForTest(
+		nil,
+		&persistence.DomainConfig{},
+		"",
+		nil,
+	), nil).AnyTimes()
 	s.mockVisibilityMgr.On("ScanWorkflowExecutions", mock.Anything).Return(&persistence.ListWorkflowExecutionsResponse{}, nil).Once()
 
 	scanRequest := &workflowservice.ScanWorkflowExecutionsRequest{
@@ -1159,6 +1267,12 @@ func (s *workflowHandlerSuite) TestCountWorkflowExecutions() {
 	wh := s.getWorkflowHandler(s.newConfig())
 
 	s.mockDomainCache.EXPECT().GetDomainID(gomock.Any()).Return(s.testDomainID, nil).AnyTimes()
+	s.mockDomainCache.EXPECT().GetDomain(gomock.Any()).Return(cache.NewLocalDomainCacheEntryForTest(
+		nil,
+		&persistence.DomainConfig{},
+		"",
+		nil,
+	), nil).AnyTimes()
 	s.mockVisibilityMgr.On("CountWorkflowExecutions", mock.Anything).Return(&persistence.CountWorkflowExecutionsResponse{}, nil).Once()
 
 	countRequest := &workflowservice.CountWorkflowExecutionsRequest{
@@ -21,19 +21,16 @@
 package history
 
 import (
-	"fmt"
-
-	checksumgen "github.com/temporalio/temporal/.gen/go/checksum"
-	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/checksum"
+	"github.com/temporalio/temporal/common/persistence"
 )
 
 const (
-	mutableStateChecksumPayloadV1 = 1
+	mutableStateChecksumPayloadV1 = persistence.MutableStateChecksumPayloadV1
 )
 
 func generateMutableStateChecksum(ms mutableState) (checksum.Checksum, error) {
-	payload := newMutableStateChecksumPayload(ms)
+	payload := persistence.NewMutableStateChecksumPayload(newMutableStateChecksumState(ms))
 	csum, err := checksum.GenerateCRC32(payload, mutableStateChecksumPayloadV1)
 	if err != nil {
 		return checksum.Checksum{}, err
@@ -45,75 +42,19 @@ func verifyMutableStateChecksum(
 	ms mutableState,
 	csum checksum.Checksum,
 ) error {
-	if csum.Version != mutableStateChecksumPayloadV1 {
-		return fmt.Errorf("invalid checksum payload version %v", csum.Version)
-	}
-	payload := newMutableStateChecksumPayload(ms)
-	return checksum.Verify(payload, csum)
+	return persistence.VerifyMutableStateChecksum(newMutableStateChecksumState(ms), csum)
 }
 
-func newMutableStateChecksumPayload(ms mutableState) *checksumgen.MutableStateChecksumPayload {
-	executionInfo := ms.GetExecutionInfo()
-	replicationState := ms.GetReplicationState()
-	payload := &checksumgen.MutableStateChecksumPayload{
-		CancelRequested:      common.BoolPtr(executionInfo.CancelRequested),
-		State:                common.Int16Ptr(int16(executionInfo.State)),
-		LastFirstEventID:     common.Int64Ptr(executionInfo.LastFirstEventID),
-		NextEventID:          common.Int64Ptr(executionInfo.NextEventID),
-		LastProcessedEventID: common.Int64Ptr(executionInfo.LastProcessedEvent),
-		SignalCount:          common.Int64Ptr(int64(executionInfo.SignalCount)),
-		DecisionAttempt:      common.Int32Ptr(int32(executionInfo.DecisionAttempt)),
-		DecisionScheduledID:  common.Int64Ptr(executionInfo.DecisionScheduleID),
-		DecisionStartedID:    common.Int64Ptr(executionInfo.DecisionStartedID),
-		DecisionVersion:      common.Int64Ptr(executionInfo.DecisionVersion),
-		StickyTaskListName:   common.StringPtr(executionInfo.StickyTaskList),
-	}
-
-	if replicationState != nil {
-		payload.LastWriteVersion = common.Int64Ptr(replicationState.LastWriteVersion)
-		payload.LastWriteEventID = common.Int64Ptr(replicationState.LastWriteEventID)
-	}
-
-	versionHistories := ms.GetVersionHistories()
-	if versionHistories != nil {
-		payload.VersionHistories = versionHistories.ToThrift()
-	}
-
-	// for each of the pendingXXX ids below, sorting is needed to guarantee that
-	// same serialized bytes can be generated during verification
-	pendingTimerIDs := make([]int64, 0, len(ms.GetPendingTimerInfos()))
-	for _, ti := range ms.GetPendingTimerInfos() {
-		pendingTimerIDs = append(pendingTimerIDs, ti.StartedID)
-	}
-	common.SortInt64Slice(pendingTimerIDs)
-	payload.PendingTimerStartedIDs = pendingTimerIDs
-
-	pendingActivityIDs := make([]int64, 0, len(ms.GetPendingActivityInfos()))
-	for id := range ms.GetPendingActivityInfos() {
-		pendingActivityIDs = append(pendingActivityIDs, id)
-	}
-	common.SortInt64Slice(pendingActivityIDs)
-	payload.PendingActivityScheduledIDs = pendingActivityIDs
-
-	pendingChildIDs := make([]int64, 0, len(ms.GetPendingChildExecutionInfos()))
-	for id := range ms.GetPendingChildExecutionInfos() {
-		pendingChildIDs = append(pendingChildIDs, id)
-	}
-	common.SortInt64Slice(pendingChildIDs)
-	payload.PendingChildInitiatedIDs = pendingChildIDs
-
-	signalIDs := make([]int64, 0, len(ms.GetPendingSignalExternalInfos()))
-	for id := range ms.GetPendingSignalExternalInfos() {
-		signalIDs = append(signalIDs, id)
-	}
-	common.SortInt64Slice(signalIDs)
-	payload.PendingSignalInitiatedIDs = signalIDs
-
-	requestCancelIDs := make([]int64, 0, len(ms.GetPendingRequestCancelExternalInfos()))
-	for id := range ms.GetPendingRequestCancelExternalInfos() {
-		requestCancelIDs = append(requestCancelIDs, id)
+// newMutableStateChecksumState returns the part of the mutable state covered by the checksum
+func newMutableStateChecksumState(ms mutableState) *persistence.WorkflowMutableState {
+	return &persistence.WorkflowMutableState{
+		ExecutionInfo:       ms.GetExecutionInfo(),
+		ReplicationState:    ms.GetReplicationState(),
+		VersionHistories:    ms.GetVersionHistories(),
+		TimerInfos:          ms.GetPendingTimerInfos(),
+		ActivityInfos:       ms.GetPendingActivityInfos(),
+		ChildExecutionInfos: ms.GetPendingChildExecutionInfos(),
+		SignalInfos:         ms.GetPendingSignalExternalInfos(),
+		RequestCancelInfos:  ms.GetPendingRequestCancelExternalInfos(),
 	}
-	common.SortInt64Slice(requestCancelIDs)
-	payload.PendingReqCancelInitiatedIDs = requestCancelIDs
-	return payload
 }
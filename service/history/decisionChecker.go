@@ -45,8 +45,9 @@ type (
 	}
 
 	workflowSizeChecker struct {
-		blobSizeLimitWarn  int
-		blobSizeLimitError int
+		blobSizeLimitWarn       int
+		blobSizeLimitError      int
+		eventBlobSizeLimitError int
 
 		historySizeLimitWarn  int
 		historySizeLimitError int
@@ -54,6 +55,11 @@ type (
 		historyCountLimitWarn  int
 		historyCountLimitError int
 
+		pendingActivitiesLimit      int
+		pendingTimersLimit          int
+		pendingChildExecutionsLimit int
+		pendingSignalsLimit         int
+
 		completedID    int64
 		mutableState   mutableState
 		executionStats *persistence.ExecutionStats
@@ -77,6 +83,7 @@ func newDecisionAttrValidator(
 		searchAttributesValidator: validator.NewSearchAttributesValidator(
 			logger,
 			config.ValidSearchAttributes,
+			validator.NewDomainSearchAttributesFn(domainCache),
 			config.SearchAttributesNumberOfKeysLimit,
 			config.SearchAttributesSizeOfValueLimit,
 			config.SearchAttributesTotalSizeLimit,
@@ -87,10 +94,15 @@ func newDecisionAttrValidator(
 func newWorkflowSizeChecker(
 	blobSizeLimitWarn int,
 	blobSizeLimitError int,
+	eventBlobSizeLimitError int,
 	historySizeLimitWarn int,
 	historySizeLimitError int,
 	historyCountLimitWarn int,
 	historyCountLimitError int,
+	pendingActivitiesLimit int,
+	pendingTimersLimit int,
+	pendingChildExecutionsLimit int,
+	pendingSignalsLimit int,
 	completedID int64,
 	mutableState mutableState,
 	executionStats *persistence.ExecutionStats,
@@ -98,30 +110,95 @@ func newWorkflowSizeChecker(
 	logger log.Logger,
 ) *workflowSizeChecker {
 	return &workflowSizeChecker{
-		blobSizeLimitWarn:      blobSizeLimitWarn,
-		blobSizeLimitError:     blobSizeLimitError,
-		historySizeLimitWarn:   historySizeLimitWarn,
-		historySizeLimitError:  historySizeLimitError,
-		historyCountLimitWarn:  historyCountLimitWarn,
-		historyCountLimitError: historyCountLimitError,
-		completedID:            completedID,
-		mutableState:           mutableState,
-		executionStats:         executionStats,
-		metricsClient:          metricsClient,
-		logger:                 logger,
+		blobSizeLimitWarn:       blobSizeLimitWarn,
+		blobSizeLimitError:      blobSizeLimitError,
+		eventBlobSizeLimitError: eventBlobSizeLimitError,
+		historySizeLimitWarn:    historySizeLimitWarn,
+		historySizeLimitError:   historySizeLimitError,
+		historyCountLimitWarn:   historyCountLimitWarn,
+		historyCountLimitError:  historyCountLimitError,
+
+		pendingActivitiesLimit:      pendingActivitiesLimit,
+		pendingTimersLimit:          pendingTimersLimit,
+		pendingChildExecutionsLimit: pendingChildExecutionsLimit,
+		pendingSignalsLimit:         pendingSignalsLimit,
+
+		completedID:    completedID,
+		mutableState:   mutableState,
+		executionStats: executionStats,
+		metricsClient:  metricsClient,
+		logger:         logger,
 	}
 }
 
+func (c *workflowSizeChecker) checkPendingActivitiesLimit() error {
+	return c.checkPendingLimit(len(c.mutableState.GetPendingActivityInfos()), c.pendingActivitiesLimit, "activities")
+}
+
+func (c *workflowSizeChecker) checkPendingTimersLimit() error {
+	return c.checkPendingLimit(len(c.mutableState.GetPendingTimerInfos()), c.pendingTimersLimit, "timers")
+}
+
+func (c *workflowSizeChecker) checkPendingChildExecutionsLimit() error {
+	return c.checkPendingLimit(len(c.mutableState.GetPendingChildExecutionInfos()), c.pendingChildExecutionsLimit, "child workflows")
+}
+
+func (c *workflowSizeChecker) checkPendingSignalsLimit() error {
+	return c.checkPendingLimit(len(c.mutableState.GetPendingSignalExternalInfos()), c.pendingSignalsLimit, "signals to external workflows")
+}
+
+// checkPendingLimit returns a bad request error if the workflow execution can't have one more pending item,
+// the pending items include the ones added by the decisions handled so far
+func (c *workflowSizeChecker) checkPendingLimit(
+	pending int,
+	limit int,
+	kind string,
+) error {
+
+	if limit <= 0 || pending < limit {
+		return nil
+	}
+
+	executionInfo := c.mutableState.GetExecutionInfo()
+	c.logger.Warn("pending limit exceeded.",
+		tag.WorkflowDomainID(executionInfo.DomainID),
+		tag.WorkflowID(executionInfo.WorkflowID),
+		tag.WorkflowRunID(executionInfo.RunID),
+		tag.Counter(pending))
+	c.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope, metrics.PendingLimitExceededCounter)
+	return &workflow.BadRequestError{
+		Message: fmt.Sprintf("Workflow execution already has %v pending %v, the limit is %v.", pending, kind, limit),
+	}
+}
+
+// failWorkflowIfBlobSizeExceedsLimit checks a payload of the event attributes, which may be offloaded
 func (c *workflowSizeChecker) failWorkflowIfBlobSizeExceedsLimit(
 	blob []byte,
 	message string,
 ) (bool, error) {
+	return c.failWorkflowIfSizeExceedsLimit(len(blob), c.eventBlobSizeLimitError, message)
+}
+
+// failWorkflowIfNestedBlobSizeExceedsLimit checks the size of nested payloads such as memo, headers and
+// search attributes, which are never offloaded
+func (c *workflowSizeChecker) failWorkflowIfNestedBlobSizeExceedsLimit(
+	size int,
+	message string,
+) (bool, error) {
+	return c.failWorkflowIfSizeExceedsLimit(size, c.blobSizeLimitError, message)
+}
+
+func (c *workflowSizeChecker) failWorkflowIfSizeExceedsLimit(
+	size int,
+	errorLimit int,
+	message string,
+) (bool, error) {
 
 	executionInfo := c.mutableState.GetExecutionInfo()
 	err := common.CheckEventBlobSizeLimit(
-		len(blob),
+		size,
 		c.blobSizeLimitWarn,
-		c.blobSizeLimitError,
+		errorLimit,
 		executionInfo.DomainID,
 		executionInfo.WorkflowID,
 		executionInfo.RunID,
@@ -235,18 +312,33 @@ func (v *decisionAttrValidator) validateActivityScheduleAttributes(
 		return &workflow.BadRequestError{Message: "A valid timeout may not be negative."}
 	}
 
-	// ensure activity timeout never larger than workflow timeout
-	if attributes.GetScheduleToCloseTimeoutSeconds() > wfTimeout {
-		attributes.ScheduleToCloseTimeoutSeconds = common.Int32Ptr(wfTimeout)
+	targetDomainEntry, err := v.domainCache.GetDomainByID(targetDomainID)
+	if err != nil {
+		return err
+	}
+	defaults := targetDomainEntry.GetConfig().WorkflowDefaults
+	attributes.RetryPolicy = defaults.ActivityRetryPolicy(attributes.RetryPolicy)
+
+	// use the domain default when the timeouts can't be deduced from the decision
+	if attributes.GetScheduleToCloseTimeoutSeconds() == 0 &&
+		(attributes.GetScheduleToStartTimeoutSeconds() == 0 || attributes.GetStartToCloseTimeoutSeconds() == 0) &&
+		defaults.ActivityScheduleToCloseTimeout() > 0 {
+		attributes.ScheduleToCloseTimeoutSeconds = common.Int32Ptr(defaults.ActivityScheduleToCloseTimeout())
 	}
-	if attributes.GetScheduleToStartTimeoutSeconds() > wfTimeout {
-		attributes.ScheduleToStartTimeoutSeconds = common.Int32Ptr(wfTimeout)
+
+	// ensure activity timeout never larger than workflow timeout and the domain maximum
+	maxTimeout := defaults.MaxActivityTimeout(wfTimeout)
+	if attributes.GetScheduleToCloseTimeoutSeconds() > maxTimeout {
+		attributes.ScheduleToCloseTimeoutSeconds = common.Int32Ptr(maxTimeout)
+	}
+	if attributes.GetScheduleToStartTimeoutSeconds() > maxTimeout {
+		attributes.ScheduleToStartTimeoutSeconds = common.Int32Ptr(maxTimeout)
 	}
-	if attributes.GetStartToCloseTimeoutSeconds() > wfTimeout {
-		attributes.StartToCloseTimeoutSeconds = common.Int32Ptr(wfTimeout)
+	if attributes.GetStartToCloseTimeoutSeconds() > maxTimeout {
+		attributes.StartToCloseTimeoutSeconds = common.Int32Ptr(maxTimeout)
 	}
-	if attributes.GetHeartbeatTimeoutSeconds() > wfTimeout {
-		attributes.HeartbeatTimeoutSeconds = common.Int32Ptr(wfTimeout)
+	if attributes.GetHeartbeatTimeoutSeconds() > maxTimeout {
+		attributes.HeartbeatTimeoutSeconds = common.Int32Ptr(maxTimeout)
 	}
 
 	validScheduleToClose := attributes.GetScheduleToCloseTimeoutSeconds() > 0
@@ -262,8 +354,8 @@ func (v *decisionAttrValidator) validateActivityScheduleAttributes(
 		}
 	} else if validScheduleToStart && validStartToClose {
 		attributes.ScheduleToCloseTimeoutSeconds = common.Int32Ptr(attributes.GetScheduleToStartTimeoutSeconds() + attributes.GetStartToCloseTimeoutSeconds())
-		if attributes.GetScheduleToCloseTimeoutSeconds() > wfTimeout {
-			attributes.ScheduleToCloseTimeoutSeconds = common.Int32Ptr(wfTimeout)
+		if attributes.GetScheduleToCloseTimeoutSeconds() > maxTimeout {
+			attributes.ScheduleToCloseTimeoutSeconds = common.Int32Ptr(maxTimeout)
 		}
 	} else {
 		// Deduction failed as there's not enough information to fill in missing timeouts.
@@ -273,8 +365,8 @@ func (v *decisionAttrValidator) validateActivityScheduleAttributes(
 	p := attributes.RetryPolicy
 	if p != nil {
 		expiration := p.GetExpirationIntervalInSeconds()
-		if expiration == 0 {
-			expiration = wfTimeout
+		if expiration == 0 || expiration > maxTimeout {
+			expiration = maxTimeout
 		}
 		if attributes.GetScheduleToStartTimeoutSeconds() < expiration {
 			attributes.ScheduleToStartTimeoutSeconds = common.Int32Ptr(expiration)
@@ -480,7 +572,7 @@ func (v *decisionAttrValidator) validateUpsertWorkflowSearchAttributes(
 		return &workflow.BadRequestError{Message: "IndexedFields is empty on decision."}
 	}
 
-	return v.searchAttributesValidator.ValidateSearchAttributes(attributes.GetSearchAttributes(), domainName)
+	return v.resolveSearchAttributes(&attributes.SearchAttributes, domainName)
 }
 
 func (v *decisionAttrValidator) validateContinueAsNewWorkflowExecutionAttributes(
@@ -508,10 +600,19 @@ func (v *decisionAttrValidator) validateContinueAsNewWorkflowExecutionAttributes
 	}
 	attributes.TaskList = taskList
 
+	domainEntry, err := v.domainCache.GetDomainByID(executionInfo.DomainID)
+	if err != nil {
+		return err
+	}
+	defaults := domainEntry.GetConfig().WorkflowDefaults
+
 	// Inherit workflow timeout from previous execution if not provided on decision
 	if attributes.GetExecutionStartToCloseTimeoutSeconds() <= 0 {
 		attributes.ExecutionStartToCloseTimeoutSeconds = common.Int32Ptr(executionInfo.WorkflowTimeout)
 	}
+	attributes.ExecutionStartToCloseTimeoutSeconds = common.Int32Ptr(
+		defaults.ExecutionStartToCloseTimeout(attributes.GetExecutionStartToCloseTimeoutSeconds()),
+	)
 
 	// Inherit decision task timeout from previous execution if not provided on decision
 	if attributes.GetTaskStartToCloseTimeoutSeconds() <= 0 {
@@ -523,11 +624,25 @@ func (v *decisionAttrValidator) validateContinueAsNewWorkflowExecutionAttributes
 		return &workflow.BadRequestError{Message: "BackoffStartInterval is less than 0."}
 	}
 
-	domainEntry, err := v.domainCache.GetDomainByID(executionInfo.DomainID)
+	return v.resolveSearchAttributes(&attributes.SearchAttributes, domainEntry.GetInfo().Name)
+}
+
+// resolveSearchAttributes validates the search attributes of a decision and replaces them by a copy
+// storing the search attributes registered on the domain in their cluster search attributes
+func (v *decisionAttrValidator) resolveSearchAttributes(
+	searchAttributes **workflow.SearchAttributes,
+	domainName string,
+) error {
+
+	if err := v.searchAttributesValidator.ValidateSearchAttributes(*searchAttributes, domainName); err != nil {
+		return err
+	}
+	resolved, err := v.searchAttributesValidator.ResolveSearchAttributes(*searchAttributes, domainName)
 	if err != nil {
 		return err
 	}
-	return v.searchAttributesValidator.ValidateSearchAttributes(attributes.GetSearchAttributes(), domainEntry.GetInfo().Name)
+	*searchAttributes = resolved
+	return nil
 }
 
 func (v *decisionAttrValidator) validateStartChildExecutionAttributes(
@@ -583,12 +698,23 @@ func (v *decisionAttrValidator) validateStartChildExecutionAttributes(
 	}
 	attributes.TaskList = taskList
 
-	// Inherit workflow timeout from parent workflow execution if not provided on decision
-	if attributes.GetExecutionStartToCloseTimeoutSeconds() <= 0 {
-		attributes.ExecutionStartToCloseTimeoutSeconds = common.Int32Ptr(parentInfo.WorkflowTimeout)
+	targetDomainEntry, err := v.domainCache.GetDomainByID(targetDomainID)
+	if err != nil {
+		return err
+	}
+	defaults := targetDomainEntry.GetConfig().WorkflowDefaults
+	attributes.WorkflowIdReusePolicy = defaults.WorkflowIDReusePolicy(attributes.WorkflowIdReusePolicy)
+	attributes.RetryPolicy = defaults.WorkflowRetryPolicy(attributes.RetryPolicy)
+
+	// Use the domain default or inherit workflow timeout from parent workflow execution if not provided on decision
+	executionTimeout := defaults.ExecutionStartToCloseTimeout(attributes.GetExecutionStartToCloseTimeoutSeconds())
+	if executionTimeout <= 0 {
+		executionTimeout = defaults.ExecutionStartToCloseTimeout(parentInfo.WorkflowTimeout)
 	}
+	attributes.ExecutionStartToCloseTimeoutSeconds = common.Int32Ptr(executionTimeout)
 
-	// Inherit decision task timeout from parent workflow execution if not provided on decision
+	// Use the domain default or inherit decision task timeout from parent workflow execution if not provided on decision
+	attributes.TaskStartToCloseTimeoutSeconds = common.Int32Ptr(defaults.TaskStartToCloseTimeout(attributes.GetTaskStartToCloseTimeoutSeconds()))
 	if attributes.GetTaskStartToCloseTimeoutSeconds() <= 0 {
 		attributes.TaskStartToCloseTimeoutSeconds = common.Int32Ptr(parentInfo.DecisionStartToCloseTimeout)
 	}
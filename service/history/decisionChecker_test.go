@@ -26,6 +26,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
 
 	workflow "github.com/temporalio/temporal/.gen/go/shared"
 	"github.com/temporalio/temporal/common"
@@ -33,6 +34,7 @@ import (
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
@@ -148,9 +150,166 @@ func (s *decisionAttrValidatorSuite) TestValidateUpsertWorkflowSearchAttributes(
 	err = s.validator.validateUpsertWorkflowSearchAttributes(domainName, attributes)
 	s.EqualError(err, "BadRequestError{Message: IndexedFields is empty on decision.}")
 
+	domainEntry := cache.NewLocalDomainCacheEntryForTest(
+		&persistence.DomainInfo{Name: domainName},
+		&persistence.DomainConfig{SearchAttributes: map[string]string{"OrderID": "CustomStringField"}},
+		cluster.TestCurrentClusterName,
+		nil,
+	)
+	s.mockDomainCache.EXPECT().GetDomain(domainName).Return(domainEntry, nil).AnyTimes()
+
 	attributes.SearchAttributes.IndexedFields = map[string][]byte{"CustomKeywordField": []byte(`bytes`)}
 	err = s.validator.validateUpsertWorkflowSearchAttributes(domainName, attributes)
 	s.Nil(err)
+
+	attributes.SearchAttributes.IndexedFields = map[string][]byte{"OrderID": []byte(`"order"`)}
+	err = s.validator.validateUpsertWorkflowSearchAttributes(domainName, attributes)
+	s.Nil(err)
+	s.Equal(map[string][]byte{"CustomStringField": []byte(`"order"`)}, attributes.SearchAttributes.IndexedFields)
+}
+
+func (s *decisionAttrValidatorSuite) TestValidateActivityScheduleAttributes_WorkflowDefaults() {
+	domainEntry := cache.NewLocalDomainCacheEntryForTest(
+		&persistence.DomainInfo{ID: s.testDomainID, Name: s.testDomainID},
+		&persistence.DomainConfig{
+			WorkflowDefaults: &persistence.DomainWorkflowDefaults{
+				DefaultActivityScheduleToCloseTimeoutSeconds: 60,
+				MaxActivityScheduleToCloseTimeoutSeconds:     300,
+				DefaultActivityRetryPolicy: &workflow.RetryPolicy{
+					InitialIntervalInSeconds:    common.Int32Ptr(1),
+					BackoffCoefficient:          common.Float64Ptr(2),
+					ExpirationIntervalInSeconds: common.Int32Ptr(600),
+				},
+			},
+		},
+		cluster.TestCurrentClusterName,
+		nil,
+	)
+	s.mockDomainCache.EXPECT().GetDomainByID(s.testDomainID).Return(domainEntry, nil).AnyTimes()
+
+	attributes := &workflow.ScheduleActivityTaskDecisionAttributes{
+		ActivityId:   common.StringPtr("activity-id"),
+		ActivityType: &workflow.ActivityType{Name: common.StringPtr("activity-type")},
+		TaskList:     &workflow.TaskList{Name: common.StringPtr("task-list")},
+	}
+	err := s.validator.validateActivityScheduleAttributes(s.testDomainID, s.testDomainID, attributes, 3600)
+	s.Nil(err)
+	s.Equal(float64(2), attributes.RetryPolicy.GetBackoffCoefficient())
+	// the retry policy expiration is capped by the domain maximum
+	s.Equal(int32(300), attributes.GetScheduleToCloseTimeoutSeconds())
+	s.Equal(int32(300), attributes.GetScheduleToStartTimeoutSeconds())
+	s.Equal(int32(60), attributes.GetStartToCloseTimeoutSeconds())
+
+	attributes = &workflow.ScheduleActivityTaskDecisionAttributes{
+		ActivityId:                    common.StringPtr("activity-id"),
+		ActivityType:                  &workflow.ActivityType{Name: common.StringPtr("activity-type")},
+		TaskList:                      &workflow.TaskList{Name: common.StringPtr("task-list")},
+		ScheduleToStartTimeoutSeconds: common.Int32Ptr(200),
+		StartToCloseTimeoutSeconds:    common.Int32Ptr(1000),
+		HeartbeatTimeoutSeconds:       common.Int32Ptr(10),
+		RetryPolicy: &workflow.RetryPolicy{
+			InitialIntervalInSeconds:    common.Int32Ptr(1),
+			BackoffCoefficient:          common.Float64Ptr(1),
+			ExpirationIntervalInSeconds: common.Int32Ptr(100),
+		},
+	}
+	err = s.validator.validateActivityScheduleAttributes(s.testDomainID, s.testDomainID, attributes, 3600)
+	s.Nil(err)
+	s.Equal(float64(1), attributes.RetryPolicy.GetBackoffCoefficient())
+	s.Equal(int32(300), attributes.GetScheduleToCloseTimeoutSeconds())
+	s.Equal(int32(200), attributes.GetScheduleToStartTimeoutSeconds())
+	s.Equal(int32(300), attributes.GetStartToCloseTimeoutSeconds())
+	s.Equal(int32(10), attributes.GetHeartbeatTimeoutSeconds())
+}
+
+func (s *decisionAttrValidatorSuite) TestValidateStartChildExecutionAttributes_WorkflowDefaults() {
+	domainEntry := cache.NewLocalDomainCacheEntryForTest(
+		&persistence.DomainInfo{ID: s.testDomainID, Name: s.testDomainID},
+		&persistence.DomainConfig{
+			WorkflowDefaults: &persistence.DomainWorkflowDefaults{
+				MaxExecutionStartToCloseTimeoutSeconds: 3600,
+				DefaultTaskStartToCloseTimeoutSeconds:  5,
+				DefaultWorkflowIDReusePolicy:           workflow.WorkflowIdReusePolicyRejectDuplicate.Ptr(),
+				DefaultWorkflowRetryPolicy: &workflow.RetryPolicy{
+					InitialIntervalInSeconds: common.Int32Ptr(1),
+					BackoffCoefficient:       common.Float64Ptr(2),
+				},
+			},
+		},
+		cluster.TestCurrentClusterName,
+		nil,
+	)
+	s.mockDomainCache.EXPECT().GetDomainByID(s.testDomainID).Return(domainEntry, nil).AnyTimes()
+
+	parentInfo := &persistence.WorkflowExecutionInfo{
+		DomainID:                    s.testDomainID,
+		TaskList:                    "task-list",
+		WorkflowTimeout:             86400,
+		DecisionStartToCloseTimeout: 10,
+	}
+	attributes := &workflow.StartChildWorkflowExecutionDecisionAttributes{
+		WorkflowId:   common.StringPtr("workflow-id"),
+		WorkflowType: &workflow.WorkflowType{Name: common.StringPtr("workflow-type")},
+	}
+	err := s.validator.validateStartChildExecutionAttributes(s.testDomainID, s.testDomainID, attributes, parentInfo)
+	s.Nil(err)
+	s.Equal(int32(3600), attributes.GetExecutionStartToCloseTimeoutSeconds())
+	s.Equal(int32(5), attributes.GetTaskStartToCloseTimeoutSeconds())
+	s.Equal(workflow.WorkflowIdReusePolicyRejectDuplicate, attributes.GetWorkflowIdReusePolicy())
+	s.Equal(float64(2), attributes.RetryPolicy.GetBackoffCoefficient())
+
+	attributes = &workflow.StartChildWorkflowExecutionDecisionAttributes{
+		WorkflowId:                          common.StringPtr("workflow-id"),
+		WorkflowType:                        &workflow.WorkflowType{Name: common.StringPtr("workflow-type")},
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(100),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(20),
+		WorkflowIdReusePolicy:               workflow.WorkflowIdReusePolicyAllowDuplicate.Ptr(),
+	}
+	err = s.validator.validateStartChildExecutionAttributes(s.testDomainID, s.testDomainID, attributes, parentInfo)
+	s.Nil(err)
+	s.Equal(int32(100), attributes.GetExecutionStartToCloseTimeoutSeconds())
+	s.Equal(int32(20), attributes.GetTaskStartToCloseTimeoutSeconds())
+	s.Equal(workflow.WorkflowIdReusePolicyAllowDuplicate, attributes.GetWorkflowIdReusePolicy())
+}
+
+func (s *decisionAttrValidatorSuite) TestWorkflowSizeChecker_PendingLimits() {
+	mutableState := NewMockmutableState(s.controller)
+	mutableState.EXPECT().GetExecutionInfo().Return(&persistence.WorkflowExecutionInfo{
+		DomainID:   s.testDomainID,
+		WorkflowID: "workflow-id",
+		RunID:      "run-id",
+	}).AnyTimes()
+	mutableState.EXPECT().GetPendingActivityInfos().Return(map[int64]*persistence.ActivityInfo{
+		5: {ScheduleID: 5},
+		6: {ScheduleID: 6},
+	}).AnyTimes()
+	mutableState.EXPECT().GetPendingTimerInfos().Return(map[string]*persistence.TimerInfo{
+		"timer-id": {TimerID: "timer-id"},
+	}).AnyTimes()
+	mutableState.EXPECT().GetPendingChildExecutionInfos().Return(map[int64]*persistence.ChildExecutionInfo{}).AnyTimes()
+	mutableState.EXPECT().GetPendingSignalExternalInfos().Return(map[int64]*persistence.SignalInfo{
+		7: {InitiatedID: 7},
+	}).AnyTimes()
+
+	checker := newWorkflowSizeChecker(
+		0, 0, 0, 0, 0, 0, 0,
+		2, 2, 1, 0,
+		common.EmptyEventID,
+		mutableState,
+		&persistence.ExecutionStats{},
+		metrics.NewClient(tally.NoopScope, metrics.History),
+		log.NewNoop(),
+	)
+
+	err := checker.checkPendingActivitiesLimit()
+	s.IsType(&workflow.BadRequestError{}, err)
+	err = checker.checkPendingTimersLimit()
+	s.NoError(err)
+	err = checker.checkPendingChildExecutionsLimit()
+	s.NoError(err)
+	// no limit
+	err = checker.checkPendingSignalsLimit()
+	s.NoError(err)
 }
 
 func (s *decisionAttrValidatorSuite) TestValidateCrossDomainCall_LocalToLocal() {
@@ -393,10 +393,15 @@ Update_History_Loop:
 			workflowSizeChecker := newWorkflowSizeChecker(
 				handler.config.BlobSizeLimitWarn(domainName),
 				handler.config.BlobSizeLimitError(domainName),
+				handler.config.EventBlobSizeLimitError(domainName),
 				handler.config.HistorySizeLimitWarn(domainName),
 				handler.config.HistorySizeLimitError(domainName),
 				handler.config.HistoryCountLimitWarn(domainName),
 				handler.config.HistoryCountLimitError(domainName),
+				handler.config.PendingActivitiesLimit(domainName),
+				handler.config.PendingTimersLimit(domainName),
+				handler.config.PendingChildExecutionsLimit(domainName),
+				handler.config.PendingSignalsLimit(domainName),
 				completedEvent.GetEventId(),
 				msBuilder,
 				executionStats,
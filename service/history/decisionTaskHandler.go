@@ -216,6 +216,22 @@ func (handler *decisionTaskHandlerImpl) handleDecisionScheduleActivity(
 		return err
 	}
 
+	failWorkflow, err = handler.sizeLimitChecker.failWorkflowIfNestedBlobSizeExceedsLimit(
+		common.GetSizeOfMapStringToByteArray(attr.GetHeader().GetFields()),
+		"ScheduleActivityTaskDecisionAttributes.Header exceeds size limit.",
+	)
+	if err != nil || failWorkflow {
+		handler.stopProcessing = true
+		return err
+	}
+
+	if err := handler.validateDecisionAttr(
+		handler.sizeLimitChecker.checkPendingActivitiesLimit,
+		workflow.DecisionTaskFailedCausePendingLimitExceeded,
+	); err != nil || handler.stopProcessing {
+		return err
+	}
+
 	_, _, err = handler.mutableState.AddActivityTaskScheduledEvent(handler.decisionTaskCompletedID, attr)
 	switch err.(type) {
 	case nil:
@@ -301,6 +317,13 @@ func (handler *decisionTaskHandlerImpl) handleDecisionStartTimer(
 		return err
 	}
 
+	if err := handler.validateDecisionAttr(
+		handler.sizeLimitChecker.checkPendingTimersLimit,
+		workflow.DecisionTaskFailedCausePendingLimitExceeded,
+	); err != nil || handler.stopProcessing {
+		return err
+	}
+
 	_, _, err := handler.mutableState.AddTimerStartedEvent(handler.decisionTaskCompletedID, attr)
 	switch err.(type) {
 	case nil:
@@ -661,6 +684,15 @@ func (handler *decisionTaskHandlerImpl) handleDecisionContinueAsNewWorkflow(
 		return err
 	}
 
+	failWorkflow, err = handler.sizeLimitChecker.failWorkflowIfNestedBlobSizeExceedsLimit(
+		common.GetSizeOfMapStringToByteArray(attr.GetMemo().GetFields())+common.GetSizeOfMapStringToByteArray(attr.GetHeader().GetFields()),
+		"ContinueAsNewWorkflowExecutionDecisionAttributes.Memo and Header exceed size limit.",
+	)
+	if err != nil || failWorkflow {
+		handler.stopProcessing = true
+		return err
+	}
+
 	// If the decision has more than one completion event than just pick the first one
 	if !handler.mutableState.IsWorkflowExecutionRunning() {
 		handler.metricsClient.IncCounter(
@@ -745,6 +777,22 @@ func (handler *decisionTaskHandlerImpl) handleDecisionStartChildWorkflow(
 		return err
 	}
 
+	failWorkflow, err = handler.sizeLimitChecker.failWorkflowIfNestedBlobSizeExceedsLimit(
+		common.GetSizeOfMapStringToByteArray(attr.GetMemo().GetFields())+common.GetSizeOfMapStringToByteArray(attr.GetHeader().GetFields()),
+		"StartChildWorkflowExecutionDecisionAttributes.Memo and Header exceed size limit.",
+	)
+	if err != nil || failWorkflow {
+		handler.stopProcessing = true
+		return err
+	}
+
+	if err := handler.validateDecisionAttr(
+		handler.sizeLimitChecker.checkPendingChildExecutionsLimit,
+		workflow.DecisionTaskFailedCausePendingLimitExceeded,
+	); err != nil || handler.stopProcessing {
+		return err
+	}
+
 	enabled := handler.config.EnableParentClosePolicy(handler.domainEntry.GetInfo().Name)
 	if attr.ParentClosePolicy == nil {
 		// for old clients, this field is empty. If they enable the feature, make default as terminate
@@ -811,6 +859,13 @@ func (handler *decisionTaskHandlerImpl) handleDecisionSignalExternalWorkflow(
 		return err
 	}
 
+	if err := handler.validateDecisionAttr(
+		handler.sizeLimitChecker.checkPendingSignalsLimit,
+		workflow.DecisionTaskFailedCausePendingLimitExceeded,
+	); err != nil || handler.stopProcessing {
+		return err
+	}
+
 	signalRequestID := uuid.New() // for deduplicate
 	_, _, err = handler.mutableState.AddSignalExternalWorkflowExecutionInitiatedEvent(
 		handler.decisionTaskCompletedID, signalRequestID, attr,
@@ -852,8 +907,8 @@ func (handler *decisionTaskHandlerImpl) handleDecisionUpsertWorkflowSearchAttrib
 	}
 
 	// blob size limit check
-	failWorkflow, err := handler.sizeLimitChecker.failWorkflowIfBlobSizeExceedsLimit(
-		convertSearchAttributesToByteArray(attr.GetSearchAttributes().GetIndexedFields()),
+	failWorkflow, err := handler.sizeLimitChecker.failWorkflowIfNestedBlobSizeExceedsLimit(
+		len(convertSearchAttributesToByteArray(attr.GetSearchAttributes().GetIndexedFields())),
 		"UpsertWorkflowSearchAttributesDecisionAttributes exceeds size limit.",
 	)
 	if err != nil || failWorkflow {
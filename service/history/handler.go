@@ -37,6 +37,7 @@ import (
 	"github.com/temporalio/temporal/.gen/go/history/historyserviceserver"
 	r "github.com/temporalio/temporal/.gen/go/replicator"
 	gen "github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/adapter"
 	"github.com/temporalio/temporal/common/definition"
@@ -78,6 +79,18 @@ var (
 	errShardIDNotSet           = &gen.BadRequestError{Message: "Shard ID not set on request."}
 	errTimestampNotSet         = &gen.BadRequestError{Message: "Timestamp not set on request."}
 	errHistoryHostThrottle     = &gen.ServiceBusyError{Message: "History host rps exceeded"}
+	errInvalidShardDLQType     = &gen.BadRequestError{Message: "DLQ type id is not one of 2 (transfer task), 3 (timer task)."}
+	errInvalidShardTaskType    = &gen.BadRequestError{Message: "Task category is not one of 2 (transfer task), 3 (timer task), 4 (replication task)."}
+)
+
+const (
+	// shard task categories use the same ids as RemoveTask
+	shardTaskCategoryTransfer    = 2
+	shardTaskCategoryTimer       = 3
+	shardTaskCategoryReplication = 4
+
+	defaultShardDLQPageSize   = 100
+	defaultShardTasksPageSize = 100
 )
 
 // NewHandler creates a thrift handler for the history service
@@ -670,6 +683,197 @@ func (h *Handler) CloseShard(
 	return nil
 }
 
+// ListShardTasks returns the pending transfer, timer or replication tasks of a shard
+func (h *Handler) ListShardTasks(
+	ctx context.Context,
+	request *historyservice.ListShardTasksRequest,
+) (_ *historyservice.ListShardTasksResponse, retError error) {
+
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	engine, err := h.controller.getEngineForShard(int(request.GetShardID()))
+	if err != nil {
+		return nil, err
+	}
+	return engine.ListShardTasks(ctx, request)
+}
+
+// ListShardDLQTasks returns the transfer or timer tasks in the DLQ of a shard
+func (h *Handler) ListShardDLQTasks(
+	ctx context.Context,
+	request *historyservice.ListShardDLQTasksRequest,
+) (_ *historyservice.ListShardDLQTasksResponse, retError error) {
+
+	defer log.CapturePanic(h.GetLogger(), &retError)
+
+	executionMgr, err := h.GetExecutionManager(int(request.GetShardID()))
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(request.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultShardDLQPageSize
+	}
+	tasks, nextPageToken, err := h.getShardDLQTasks(executionMgr, int(request.GetType()), pageSize, request.GetNextPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &historyservice.ListShardDLQTasksResponse{
+		NextPageToken: nextPageToken,
+	}
+	for _, task := range tasks {
+		response.Tasks = append(response.Tasks, toShardTask(task))
+	}
+	return response, nil
+}
+
+// RetryShardDLQTasks processes the transfer or timer tasks in the DLQ of a shard once,
+// tasks processed successfully are removed from the DLQ
+func (h *Handler) RetryShardDLQTasks(
+	ctx context.Context,
+	request *historyservice.RetryShardDLQTasksRequest,
+) (_ *historyservice.RetryShardDLQTasksResponse, retError error) {
+
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	shardID := int(request.GetShardID())
+	engine, err := h.controller.getEngineForShard(shardID)
+	if err != nil {
+		return nil, err
+	}
+	executionMgr, err := h.GetExecutionManager(shardID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &historyservice.RetryShardDLQTasksResponse{}
+	var nextPageToken []byte
+	for {
+		var tasks []queueTaskInfo
+		tasks, nextPageToken, err = h.getShardDLQTasks(executionMgr, int(request.GetType()), defaultShardDLQPageSize, nextPageToken)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, task := range tasks {
+			if err := engine.ProcessDLQTask(ctx, task); err != nil {
+				h.GetLogger().Warn("Failed to process task from DLQ.",
+					tag.ShardID(shardID),
+					tag.TaskID(task.GetTaskID()),
+					tag.Error(err),
+				)
+				response.FailedCount++
+				continue
+			}
+			if err := h.deleteShardDLQTask(executionMgr, task); err != nil {
+				return nil, err
+			}
+			response.SucceededCount++
+		}
+
+		if len(nextPageToken) == 0 {
+			return response, nil
+		}
+	}
+}
+
+// PurgeShardDLQTasks deletes all transfer or timer tasks in the DLQ of a shard
+func (h *Handler) PurgeShardDLQTasks(
+	ctx context.Context,
+	request *historyservice.PurgeShardDLQTasksRequest,
+) (_ *historyservice.PurgeShardDLQTasksResponse, retError error) {
+
+	defer log.CapturePanic(h.GetLogger(), &retError)
+
+	executionMgr, err := h.GetExecutionManager(int(request.GetShardID()))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &historyservice.PurgeShardDLQTasksResponse{}
+	for {
+		// tasks are deleted as they are read, so every page starts from the beginning of the DLQ
+		tasks, _, err := h.getShardDLQTasks(executionMgr, int(request.GetType()), defaultShardDLQPageSize, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(tasks) == 0 {
+			return response, nil
+		}
+
+		for _, task := range tasks {
+			if err := h.deleteShardDLQTask(executionMgr, task); err != nil {
+				return nil, err
+			}
+			response.PurgedCount++
+		}
+	}
+}
+
+func (h *Handler) getShardDLQTasks(
+	executionMgr persistence.ExecutionManager,
+	taskType int,
+	pageSize int,
+	pageToken []byte,
+) ([]queueTaskInfo, []byte, error) {
+
+	var tasks []queueTaskInfo
+	switch taskType {
+	case shardTaskCategoryTransfer:
+		response, err := executionMgr.GetTransferTasksFromDLQ(&persistence.GetTransferTasksFromDLQRequest{
+			BatchSize:     pageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, task := range response.Tasks {
+			tasks = append(tasks, task)
+		}
+		return tasks, response.NextPageToken, nil
+
+	case shardTaskCategoryTimer:
+		response, err := executionMgr.GetTimerTasksFromDLQ(&persistence.GetTimerTasksFromDLQRequest{
+			BatchSize:     pageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, task := range response.Timers {
+			tasks = append(tasks, task)
+		}
+		return tasks, response.NextPageToken, nil
+
+	default:
+		return nil, nil, errInvalidShardDLQType
+	}
+}
+
+func (h *Handler) deleteShardDLQTask(
+	executionMgr persistence.ExecutionManager,
+	task queueTaskInfo,
+) error {
+
+	switch task := task.(type) {
+	case *persistence.TransferTaskInfo:
+		return executionMgr.DeleteTransferTaskFromDLQ(&persistence.DeleteTransferTaskFromDLQRequest{
+			TaskID: task.TaskID,
+		})
+	case *persistence.TimerTaskInfo:
+		return executionMgr.DeleteTimerTaskFromDLQ(&persistence.DeleteTimerTaskFromDLQRequest{
+			VisibilityTimestamp: task.VisibilityTimestamp,
+			TaskID:              task.TaskID,
+		})
+	default:
+		return errUnexpectedQueueTask
+	}
+}
+
 // DescribeMutableState - returns the internal analysis of workflow execution state
 func (h *Handler) DescribeMutableState(
 	ctx context.Context,
@@ -978,6 +1182,114 @@ func (h *Handler) RemoveSignalMutableState(
 	return nil
 }
 
+// DeleteWorkflowExecution deletes a closed workflow execution together with its history and visibility record
+func (h *Handler) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.DeleteWorkflowExecutionRequest,
+) (_ *historyservice.DeleteWorkflowExecutionResponse, retError error) {
+
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryDeleteWorkflowExecutionScope
+	h.GetMetricsClient().IncCounter(scope, metrics.CadenceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	domainID := request.GetDomainUUID()
+	if domainID == "" {
+		return nil, h.error(errDomainNotSet, scope, domainID, "")
+	}
+
+	if ok := h.rateLimiter.Allow(); !ok {
+		return nil, h.error(errHistoryHostThrottle, scope, domainID, "")
+	}
+
+	workflowID := request.WorkflowExecution.GetWorkflowId()
+	engine, err := h.controller.GetEngine(workflowID)
+	if err != nil {
+		return nil, h.error(err, scope, domainID, workflowID)
+	}
+
+	resp, err := engine.DeleteWorkflowExecution(ctx, request)
+	if err != nil {
+		return nil, h.error(err, scope, domainID, workflowID)
+	}
+	return resp, nil
+}
+
+// UpsertWorkflowSearchAttributes records an UpsertWorkflowSearchAttributes event which is not initiated by a decision
+func (h *Handler) UpsertWorkflowSearchAttributes(
+	ctx context.Context,
+	request *historyservice.UpsertWorkflowSearchAttributesRequest,
+) (_ *historyservice.UpsertWorkflowSearchAttributesResponse, retError error) {
+
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryUpsertWorkflowSearchAttributesScope
+	h.GetMetricsClient().IncCounter(scope, metrics.CadenceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	domainID := request.GetDomainUUID()
+	if domainID == "" {
+		return nil, h.error(errDomainNotSet, scope, domainID, "")
+	}
+
+	if ok := h.rateLimiter.Allow(); !ok {
+		return nil, h.error(errHistoryHostThrottle, scope, domainID, "")
+	}
+
+	workflowID := request.WorkflowExecution.GetWorkflowId()
+	engine, err := h.controller.GetEngine(workflowID)
+	if err != nil {
+		return nil, h.error(err, scope, domainID, workflowID)
+	}
+
+	resp, err := engine.UpsertWorkflowSearchAttributes(ctx, request)
+	if err != nil {
+		return nil, h.error(err, scope, domainID, workflowID)
+	}
+	return resp, nil
+}
+
+// UnarchiveWorkflowExecution restores an archived workflow execution into persistence as a closed workflow execution
+func (h *Handler) UnarchiveWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.UnarchiveWorkflowExecutionRequest,
+) (_ *historyservice.UnarchiveWorkflowExecutionResponse, retError error) {
+
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryUnarchiveWorkflowExecutionScope
+	h.GetMetricsClient().IncCounter(scope, metrics.CadenceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	domainID := request.GetDomainUUID()
+	if domainID == "" {
+		return nil, h.error(errDomainNotSet, scope, domainID, "")
+	}
+
+	if ok := h.rateLimiter.Allow(); !ok {
+		return nil, h.error(errHistoryHostThrottle, scope, domainID, "")
+	}
+
+	workflowID := request.WorkflowExecution.GetWorkflowId()
+	engine, err := h.controller.GetEngine(workflowID)
+	if err != nil {
+		return nil, h.error(err, scope, domainID, workflowID)
+	}
+
+	resp, err := engine.UnarchiveWorkflowExecution(ctx, request)
+	if err != nil {
+		return nil, h.error(err, scope, domainID, workflowID)
+	}
+	return resp, nil
+}
+
 // TerminateWorkflowExecution terminates an existing workflow execution by recording WorkflowExecutionTerminated event
 // in the history and immediately terminating the execution instance.
 func (h *Handler) TerminateWorkflowExecution(
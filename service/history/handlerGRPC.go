@@ -210,6 +210,36 @@ func (h *HandlerGRPC) RemoveSignalMutableState(ctx context.Context, request *his
 	return &historyservice.RemoveSignalMutableStateResponse{}, nil
 }
 
+func (h *HandlerGRPC) UnarchiveWorkflowExecution(ctx context.Context, request *historyservice.UnarchiveWorkflowExecutionRequest) (_ *historyservice.UnarchiveWorkflowExecutionResponse, retError error) {
+	defer log.CapturePanicGRPC(h.handlerThrift.GetLogger(), &retError)
+
+	resp, err := h.handlerThrift.UnarchiveWorkflowExecution(ctx, request)
+	if err != nil {
+		return nil, adapter.ToProtoError(err)
+	}
+	return resp, nil
+}
+
+func (h *HandlerGRPC) UpsertWorkflowSearchAttributes(ctx context.Context, request *historyservice.UpsertWorkflowSearchAttributesRequest) (_ *historyservice.UpsertWorkflowSearchAttributesResponse, retError error) {
+	defer log.CapturePanicGRPC(h.handlerThrift.GetLogger(), &retError)
+
+	resp, err := h.handlerThrift.UpsertWorkflowSearchAttributes(ctx, request)
+	if err != nil {
+		return nil, adapter.ToProtoError(err)
+	}
+	return resp, nil
+}
+
+func (h *HandlerGRPC) DeleteWorkflowExecution(ctx context.Context, request *historyservice.DeleteWorkflowExecutionRequest) (_ *historyservice.DeleteWorkflowExecutionResponse, retError error) {
+	defer log.CapturePanicGRPC(h.handlerThrift.GetLogger(), &retError)
+
+	resp, err := h.handlerThrift.DeleteWorkflowExecution(ctx, request)
+	if err != nil {
+		return nil, adapter.ToProtoError(err)
+	}
+	return resp, nil
+}
+
 func (h *HandlerGRPC) TerminateWorkflowExecution(ctx context.Context, request *historyservice.TerminateWorkflowExecutionRequest) (_ *historyservice.TerminateWorkflowExecutionResponse, retError error) {
 	defer log.CapturePanicGRPC(h.handlerThrift.GetLogger(), &retError)
 
@@ -360,6 +390,46 @@ func (h *HandlerGRPC) RemoveTask(ctx context.Context, request *historyservice.Re
 	return &historyservice.RemoveTaskResponse{}, nil
 }
 
+func (h *HandlerGRPC) ListShardTasks(ctx context.Context, request *historyservice.ListShardTasksRequest) (_ *historyservice.ListShardTasksResponse, retError error) {
+	defer log.CapturePanicGRPC(h.handlerThrift.GetLogger(), &retError)
+
+	resp, err := h.handlerThrift.ListShardTasks(ctx, request)
+	if err != nil {
+		return nil, adapter.ToProtoError(err)
+	}
+	return resp, nil
+}
+
+func (h *HandlerGRPC) ListShardDLQTasks(ctx context.Context, request *historyservice.ListShardDLQTasksRequest) (_ *historyservice.ListShardDLQTasksResponse, retError error) {
+	defer log.CapturePanicGRPC(h.handlerThrift.GetLogger(), &retError)
+
+	resp, err := h.handlerThrift.ListShardDLQTasks(ctx, request)
+	if err != nil {
+		return nil, adapter.ToProtoError(err)
+	}
+	return resp, nil
+}
+
+func (h *HandlerGRPC) RetryShardDLQTasks(ctx context.Context, request *historyservice.RetryShardDLQTasksRequest) (_ *historyservice.RetryShardDLQTasksResponse, retError error) {
+	defer log.CapturePanicGRPC(h.handlerThrift.GetLogger(), &retError)
+
+	resp, err := h.handlerThrift.RetryShardDLQTasks(ctx, request)
+	if err != nil {
+		return nil, adapter.ToProtoError(err)
+	}
+	return resp, nil
+}
+
+func (h *HandlerGRPC) PurgeShardDLQTasks(ctx context.Context, request *historyservice.PurgeShardDLQTasksRequest) (_ *historyservice.PurgeShardDLQTasksResponse, retError error) {
+	defer log.CapturePanicGRPC(h.handlerThrift.GetLogger(), &retError)
+
+	resp, err := h.handlerThrift.PurgeShardDLQTasks(ctx, request)
+	if err != nil {
+		return nil, adapter.ToProtoError(err)
+	}
+	return resp, nil
+}
+
 func (h *HandlerGRPC) GetReplicationMessages(ctx context.Context, request *historyservice.GetReplicationMessagesRequest) (_ *historyservice.GetReplicationMessagesResponse, retError error) {
 	defer log.CapturePanicGRPC(h.handlerThrift.GetLogger(), &retError)
 
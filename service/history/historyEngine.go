@@ -28,6 +28,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/gogo/status"
@@ -39,11 +40,15 @@ import (
 	h "github.com/temporalio/temporal/.gen/go/history"
 	r "github.com/temporalio/temporal/.gen/go/replicator"
 	workflow "github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/.gen/proto/matchingservice"
 	hc "github.com/temporalio/temporal/client/history"
 	"github.com/temporalio/temporal/client/matching"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/adapter"
+	"github.com/temporalio/temporal/common/archiver"
+	"github.com/temporalio/temporal/common/backoff"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/client"
 	"github.com/temporalio/temporal/common/clock"
@@ -91,6 +96,9 @@ type (
 		SignalWorkflowExecution(ctx ctx.Context, request *h.SignalWorkflowExecutionRequest) error
 		SignalWithStartWorkflowExecution(ctx ctx.Context, request *h.SignalWithStartWorkflowExecutionRequest) (*workflow.StartWorkflowExecutionResponse, error)
 		RemoveSignalMutableState(ctx ctx.Context, request *h.RemoveSignalMutableStateRequest) error
+		DeleteWorkflowExecution(ctx ctx.Context, request *historyservice.DeleteWorkflowExecutionRequest) (*historyservice.DeleteWorkflowExecutionResponse, error)
+		UpsertWorkflowSearchAttributes(ctx ctx.Context, request *historyservice.UpsertWorkflowSearchAttributesRequest) (*historyservice.UpsertWorkflowSearchAttributesResponse, error)
+		UnarchiveWorkflowExecution(ctx ctx.Context, request *historyservice.UnarchiveWorkflowExecutionRequest) (*historyservice.UnarchiveWorkflowExecutionResponse, error)
 		TerminateWorkflowExecution(ctx ctx.Context, request *h.TerminateWorkflowExecutionRequest) error
 		ResetWorkflowExecution(ctx ctx.Context, request *h.ResetWorkflowExecutionRequest) (*workflow.ResetWorkflowExecutionResponse, error)
 		ScheduleDecisionTask(ctx ctx.Context, request *h.ScheduleDecisionTaskRequest) error
@@ -104,6 +112,8 @@ type (
 		GetDLQReplicationMessages(ctx ctx.Context, taskInfos []*r.ReplicationTaskInfo) ([]*r.ReplicationTask, error)
 		QueryWorkflow(ctx ctx.Context, request *h.QueryWorkflowRequest) (*h.QueryWorkflowResponse, error)
 		ReapplyEvents(ctx ctx.Context, domainUUID string, workflowID string, runID string, events []*workflow.HistoryEvent) error
+		ProcessDLQTask(ctx ctx.Context, task queueTaskInfo) error
+		ListShardTasks(ctx ctx.Context, request *historyservice.ListShardTasksRequest) (*historyservice.ListShardTasksResponse, error)
 
 		NotifyNewHistoryEvent(event *historyEventNotification)
 		NotifyNewTransferTasks(tasks []persistence.Task)
@@ -174,6 +184,8 @@ var (
 	ErrCancellationAlreadyRequested = &workflow.CancellationAlreadyRequestedError{Message: "cancellation already requested for this workflow execution"}
 	// ErrSignalsLimitExceeded is the error indicating limit reached for maximum number of signal events
 	ErrSignalsLimitExceeded = &workflow.LimitExceededError{Message: "exceeded workflow execution limit for signal events"}
+	// ErrOpenWorkflowsLimitExceeded is the error indicating limit reached for maximum number of open workflows of the domain
+	ErrOpenWorkflowsLimitExceeded = &workflow.LimitExceededError{Message: "exceeded domain limit for open workflow executions"}
 	// ErrEventsAterWorkflowFinish is the error indicating server error trying to write events after workflow finish event
 	ErrEventsAterWorkflowFinish = &workflow.InternalServiceError{Message: "error validating last event being workflow finish event"}
 	// ErrQueryEnteredInvalidState is error indicating query entered invalid state
@@ -182,6 +194,12 @@ var (
 	ErrQueryWorkflowBeforeFirstDecision = &workflow.BadRequestError{Message: "workflow must handle at least one decision task before it can be queried"}
 	// ErrConsistentQueryNotEnabled is error indicating that consistent query was requested but either cluster or domain does not enable consistent query
 	ErrConsistentQueryNotEnabled = &workflow.BadRequestError{Message: "cluster or domain does not enable strongly consistent query but strongly consistent query was requested"}
+	// ErrWorkflowNotClosed is error indicating workflow execution must be closed before it can be deleted
+	ErrWorkflowNotClosed = &workflow.BadRequestError{Message: "workflow execution must be closed before it can be deleted"}
+	// ErrWorkflowNotArchived is error indicating the archived history of workflow execution is not found
+	ErrWorkflowNotArchived = &workflow.EntityNotExistsError{Message: "archived history of workflow execution not found"}
+	// ErrWorkflowExecutionExists is error indicating workflow execution to unarchive still exists in persistence
+	ErrWorkflowExecutionExists = &workflow.BadRequestError{Message: "workflow execution already exists"}
 	// ErrConsistentQueryBufferExceeded is error indicating that too many consistent queries have been buffered and until buffered queries are finished new consistent queries cannot be buffered
 	ErrConsistentQueryBufferExceeded = &workflow.InternalServiceError{Message: "consistent query buffer is full, cannot accept new consistent queries"}
 
@@ -504,6 +522,9 @@ func (e *historyEngineImpl) StartWorkflowExecution(
 		return nil, err
 	}
 	e.overrideStartWorkflowExecutionRequest(domainEntry, request, metrics.HistoryStartWorkflowExecutionScope)
+	if err := e.checkOpenWorkflowsLimit(domainEntry); err != nil {
+		return nil, err
+	}
 
 	workflowID := request.GetWorkflowId()
 	// grab the current context as a lock, nothing more
@@ -813,6 +834,7 @@ func (e *historyEngineImpl) QueryWorkflow(
 	if err != nil {
 		return nil, err
 	}
+	buildID := mutableState.GetExecutionInfo().StartingBuildID
 
 	// There are two ways in which queries get dispatched to decider. First, queries can be dispatched on decision tasks.
 	// These decision tasks potentially contain new events and queries. The events are treated as coming before the query in time.
@@ -836,7 +858,7 @@ func (e *historyEngineImpl) QueryWorkflow(
 			return nil, err
 		}
 		req.Execution.RunId = msResp.Execution.RunId
-		return e.queryDirectlyThroughMatching(ctx, msResp, request.GetDomainUUID(), req, scope)
+		return e.queryDirectlyThroughMatching(ctx, msResp, request.GetDomainUUID(), buildID, req, scope)
 	}
 
 	// If we get here it means query could not be dispatched through matching directly, so it must block
@@ -880,7 +902,7 @@ func (e *historyEngineImpl) QueryWorkflow(
 				return nil, err
 			}
 			req.Execution.RunId = msResp.Execution.RunId
-			return e.queryDirectlyThroughMatching(ctx, msResp, request.GetDomainUUID(), req, scope)
+			return e.queryDirectlyThroughMatching(ctx, msResp, request.GetDomainUUID(), buildID, req, scope)
 		case queryTerminationTypeFailed:
 			return nil, state.failure
 		default:
@@ -897,6 +919,7 @@ func (e *historyEngineImpl) queryDirectlyThroughMatching(
 	ctx ctx.Context,
 	msResp *h.GetMutableStateResponse,
 	domainID string,
+	buildID string,
 	queryRequest *workflow.QueryWorkflowRequest,
 	scope metrics.Scope,
 ) (*h.QueryWorkflowResponse, error) {
@@ -979,6 +1002,7 @@ func (e *historyEngineImpl) queryDirectlyThroughMatching(
 		DomainUUID:   domainID,
 		QueryRequest: adapter.ToProtoQueryWorkflowRequest(queryRequest),
 		TaskList:     adapter.ToProtoTaskList(msResp.TaskList),
+		BuildId:      buildID,
 	}
 
 	nonStickyStopWatch := scope.StartTimer(metrics.DirectQueryDispatchNonStickyLatency)
@@ -1717,10 +1741,27 @@ func (e *historyEngineImpl) RequestCancelWorkflowExecution(
 				return nil, ErrCancellationAlreadyRequested
 			}
 
+			startDelayed, err := isStartDelayed(mutableState)
+			if err != nil {
+				return nil, err
+			}
+
+			eventBatchFirstEventID := mutableState.GetNextEventID()
 			if _, err := mutableState.AddWorkflowExecutionCancelRequestedEvent("", req); err != nil {
 				return nil, &workflow.InternalServiceError{Message: "Unable to cancel workflow execution."}
 			}
 
+			if startDelayed {
+				// workflow has not been started yet, there is no decider to handle the cancellation
+				if _, err := mutableState.AddWorkflowExecutionCanceledEvent(
+					eventBatchFirstEventID,
+					&workflow.CancelWorkflowExecutionDecisionAttributes{},
+				); err != nil {
+					return nil, &workflow.InternalServiceError{Message: "Unable to cancel workflow execution."}
+				}
+				return updateWorkflowWithoutDecision, nil
+			}
+
 			return updateWorkflowWithNewDecision, nil
 		})
 }
@@ -1750,19 +1791,19 @@ func (e *historyEngineImpl) SignalWorkflowExecution(
 		execution,
 		func(context workflowExecutionContext, mutableState mutableState) (*updateWorkflowAction, error) {
 			executionInfo := mutableState.GetExecutionInfo()
-			createDecisionTask := true
-			// Do not create decision task when the workflow is cron and the cron has not been started yet
-			if mutableState.GetExecutionInfo().CronSchedule != "" && !mutableState.HasProcessedOrPendingDecision() {
-				createDecisionTask = false
-			}
-			postActions := &updateWorkflowAction{
-				createDecision: createDecisionTask,
-			}
-
 			if !mutableState.IsWorkflowExecutionRunning() {
 				return nil, ErrWorkflowCompleted
 			}
 
+			// Do not create decision task when the workflow is cron or delayed and has not been started yet
+			firstDecisionDelayed, err := isFirstDecisionDelayed(mutableState)
+			if err != nil {
+				return nil, err
+			}
+			postActions := &updateWorkflowAction{
+				createDecision: !firstDecisionDelayed,
+			}
+
 			maxAllowedSignals := e.config.MaximumSignalsPerExecution(domainEntry.GetInfo().Name)
 			if maxAllowedSignals > 0 && int(executionInfo.SignalCount) >= maxAllowedSignals {
 				e.logger.Info("Execution limit reached for maximum signals", tag.WorkflowSignalCount(executionInfo.SignalCount),
@@ -1856,8 +1897,13 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(
 				return nil, &workflow.InternalServiceError{Message: "Unable to signal workflow execution."}
 			}
 
-			// Create a transfer task to schedule a decision task
-			if !mutableState.HasPendingDecision() {
+			// Create a transfer task to schedule a decision task,
+			// unless the workflow is cron or delayed and has not been started yet
+			firstDecisionDelayed, err := isFirstDecisionDelayed(mutableState)
+			if err != nil {
+				return nil, err
+			}
+			if !firstDecisionDelayed && !mutableState.HasPendingDecision() {
 				_, err := mutableState.AddDecisionTaskScheduledEvent(false)
 				if err != nil {
 					return nil, &workflow.InternalServiceError{Message: "Failed to add decision scheduled event."}
@@ -1892,6 +1938,9 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(
 		return nil, err
 	}
 	e.overrideStartWorkflowExecutionRequest(domainEntry, request, metrics.HistorySignalWorkflowExecutionScope)
+	if err := e.checkOpenWorkflowsLimit(domainEntry); err != nil {
+		return nil, err
+	}
 
 	workflowID := request.GetWorkflowId()
 	// grab the current context as a lock, nothing more
@@ -2044,6 +2093,377 @@ func (e *historyEngineImpl) RemoveSignalMutableState(
 		})
 }
 
+// DeleteWorkflowExecution deletes the mutable state, history and visibility record of a closed workflow execution
+func (e *historyEngineImpl) DeleteWorkflowExecution(
+	ctx ctx.Context,
+	request *historyservice.DeleteWorkflowExecutionRequest,
+) (retResp *historyservice.DeleteWorkflowExecutionResponse, retError error) {
+
+	domainID, err := validateDomainUUID(common.StringPtr(request.GetDomainUUID()))
+	if err != nil {
+		return nil, err
+	}
+	domainEntry, err := e.shard.GetDomainCache().GetDomainByID(domainID)
+	if err != nil {
+		return nil, err
+	}
+	// the closed executions of a deleted domain are also deleted in its standby clusters,
+	// which purge their copy of the domain data once the deletion is replicated
+	if domainEntry.GetInfo().Status != persistence.DomainStatusDeleted {
+		if err := domainEntry.GetDomainNotActiveErr(); err != nil {
+			return nil, err
+		}
+	}
+
+	workflowContext, err := e.loadWorkflow(
+		ctx,
+		domainID,
+		request.WorkflowExecution.GetWorkflowId(),
+		request.WorkflowExecution.GetRunId(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { workflowContext.getReleaseFn()(retError) }()
+
+	mutableState := workflowContext.getMutableState()
+	if mutableState.IsWorkflowExecutionRunning() {
+		return nil, ErrWorkflowNotClosed
+	}
+
+	executionInfo := mutableState.GetExecutionInfo()
+	task := &persistence.TimerTaskInfo{
+		DomainID:   domainID,
+		WorkflowID: executionInfo.WorkflowID,
+		RunID:      executionInfo.RunID,
+	}
+
+	op := func() error {
+		return e.executionManager.DeleteCurrentWorkflowExecution(&persistence.DeleteCurrentWorkflowExecutionRequest{
+			DomainID:   task.DomainID,
+			WorkflowID: task.WorkflowID,
+			RunID:      task.RunID,
+		})
+	}
+	if err := backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError); err != nil {
+		return nil, err
+	}
+
+	op = func() error {
+		return e.executionManager.DeleteWorkflowExecution(&persistence.DeleteWorkflowExecutionRequest{
+			DomainID:   task.DomainID,
+			WorkflowID: task.WorkflowID,
+			RunID:      task.RunID,
+		})
+	}
+	if err := backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError); err != nil {
+		return nil, err
+	}
+
+	op = func() error {
+		branchToken, err := mutableState.GetCurrentBranchToken()
+		if err != nil {
+			return err
+		}
+		return e.historyV2Mgr.DeleteHistoryBranch(&persistence.DeleteHistoryBranchRequest{
+			BranchToken: branchToken,
+			ShardID:     common.IntPtr(e.shard.GetShardID()),
+		})
+	}
+	if err := backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError); err != nil {
+		return nil, err
+	}
+
+	op = func() error {
+		return e.DeleteExecutionFromVisibility(task)
+	}
+	if err := backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError); err != nil {
+		return nil, err
+	}
+
+	// calling clear here to force accesses of mutable state to read database
+	// if this is not called then callers will get mutable state even though its been removed from database
+	workflowContext.getContext().clear()
+	return &historyservice.DeleteWorkflowExecutionResponse{}, nil
+}
+
+// UpsertWorkflowSearchAttributes records an UpsertWorkflowSearchAttributes event
+// on behalf of an operator instead of a decision of the workflow
+func (e *historyEngineImpl) UpsertWorkflowSearchAttributes(
+	ctx ctx.Context,
+	request *historyservice.UpsertWorkflowSearchAttributesRequest,
+) (*historyservice.UpsertWorkflowSearchAttributesResponse, error) {
+
+	domainEntry, err := e.getActiveDomainEntry(common.StringPtr(request.GetDomainUUID()))
+	if err != nil {
+		return nil, err
+	}
+	domainID := domainEntry.GetInfo().ID
+
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(request.WorkflowExecution.GetWorkflowId()),
+		RunId:      common.StringPtr(request.WorkflowExecution.GetRunId()),
+	}
+
+	err = e.updateWorkflow(
+		ctx,
+		domainID,
+		execution,
+		func(context workflowExecutionContext, mutableState mutableState) (*updateWorkflowAction, error) {
+			if !mutableState.IsWorkflowExecutionRunning() {
+				return nil, ErrWorkflowCompleted
+			}
+
+			_, err := mutableState.AddUpsertWorkflowSearchAttributesEvent(
+				common.EmptyEventID,
+				&workflow.UpsertWorkflowSearchAttributesDecisionAttributes{
+					SearchAttributes: adapter.ToThriftSearchAttributes(request.GetSearchAttributes()),
+				},
+			)
+			if err != nil {
+				return nil, err
+			}
+			return updateWorkflowWithoutDecision, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &historyservice.UpsertWorkflowSearchAttributesResponse{}, nil
+}
+
+// UnarchiveWorkflowExecution reads the archived history of a workflow execution, which has passed
+// retention, and rebuilds its history and mutable state in persistence as a closed workflow execution.
+// The unarchived workflow execution is deleted again once the retention of the domain passes, counted
+// from the time of unarchival. The close tasks of the workflow execution are not generated again, so
+// the workflow execution is neither re-archived to visibility nor reported to its parent again.
+func (e *historyEngineImpl) UnarchiveWorkflowExecution(
+	ctx ctx.Context,
+	request *historyservice.UnarchiveWorkflowExecutionRequest,
+) (retResp *historyservice.UnarchiveWorkflowExecutionResponse, retError error) {
+
+	domainEntry, err := e.getActiveDomainEntry(common.StringPtr(request.GetDomainUUID()))
+	if err != nil {
+		return nil, err
+	}
+	domainID := domainEntry.GetInfo().ID
+	workflowID := request.WorkflowExecution.GetWorkflowId()
+	runID := request.WorkflowExecution.GetRunId()
+	if runID == "" {
+		return nil, &workflow.BadRequestError{Message: "RunID is not set on request."}
+	}
+
+	historyBatches, err := e.getArchivedHistory(ctx, domainEntry, workflowID, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(workflowID),
+		RunId:      common.StringPtr(runID),
+	}
+	context, release, err := e.historyCache.getOrCreateWorkflowExecution(ctx, domainID, execution)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(retError) }()
+
+	switch _, err := context.loadWorkflowExecution(); err.(type) {
+	case nil:
+		return nil, ErrWorkflowExecutionExists
+	case *workflow.EntityNotExistsError:
+		// expected, the workflow execution has been deleted
+	default:
+		return nil, err
+	}
+
+	branchToken, err := persistence.NewHistoryBranchToken(runID)
+	if err != nil {
+		return nil, err
+	}
+	var historySize int64
+	for i, batch := range historyBatches {
+		workflowEvents := &persistence.WorkflowEvents{
+			DomainID:    domainID,
+			WorkflowID:  workflowID,
+			RunID:       runID,
+			BranchToken: branchToken,
+			Events:      batch.Events,
+		}
+		var size int64
+		if i == 0 {
+			size, err = context.persistFirstWorkflowEvents(workflowEvents)
+		} else {
+			size, err = context.persistNonFirstWorkflowEvents(workflowEvents)
+		}
+		if err != nil {
+			return nil, err
+		}
+		historySize += size
+	}
+
+	firstEvent := historyBatches[0].Events[0]
+	lastBatch := historyBatches[len(historyBatches)-1].Events
+	lastEvent := lastBatch[len(lastBatch)-1]
+	workflowIdentifier := definition.NewWorkflowIdentifier(domainID, workflowID, runID)
+	now := e.timeSource.Now()
+	rebuiltMutableState, _, err := newNDCStateRebuilder(e.shard, e.logger).rebuild(
+		ctx,
+		now,
+		workflowIdentifier,
+		branchToken,
+		lastEvent.GetEventId(),
+		lastEvent.GetVersion(),
+		workflowIdentifier,
+		branchToken,
+		uuid.New(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	rebuiltMutableState.GetExecutionInfo().StartTimestamp = time.Unix(0, firstEvent.GetTimestamp())
+	if rebuiltMutableState.IsWorkflowExecutionRunning() {
+		return nil, &workflow.InternalServiceError{Message: "archived history does not end with a workflow close event"}
+	}
+
+	// persistence does not allow creating a closed workflow execution, so the workflow execution is
+	// created as running, or as zombie if there is a current run of the workflow, and then closed by an update
+	createMode := persistence.CreateWorkflowModeZombie
+	createState := persistence.WorkflowStateZombie
+	updateMode := persistence.UpdateWorkflowModeBypassCurrent
+	_, err = e.executionManager.GetCurrentExecution(&persistence.GetCurrentExecutionRequest{
+		DomainID:   domainID,
+		WorkflowID: workflowID,
+	})
+	switch err.(type) {
+	case nil:
+	case *workflow.EntityNotExistsError:
+		createMode = persistence.CreateWorkflowModeBrandNew
+		createState = persistence.WorkflowStateRunning
+		updateMode = persistence.UpdateWorkflowModeUpdateCurrent
+	default:
+		return nil, err
+	}
+
+	snapshot, _, err := rebuiltMutableState.CloseTransactionAsSnapshot(now, transactionPolicyPassive)
+	if err != nil {
+		return nil, err
+	}
+	closeState := snapshot.ExecutionInfo.State
+	closeStatus := snapshot.ExecutionInfo.CloseStatus
+	executionInfo := *snapshot.ExecutionInfo
+	executionInfo.State = createState
+	executionInfo.CloseStatus = persistence.WorkflowCloseStatusNone
+	snapshot.ExecutionInfo = &executionInfo
+	// tasks generated by the rebuild are based on the original close time of the workflow execution,
+	// the only task needed by the unarchived workflow execution is added by the update closing it
+	snapshot.TransferTasks = nil
+	snapshot.TimerTasks = nil
+	snapshot.ReplicationTasks = nil
+
+	if err := context.createWorkflowExecution(
+		snapshot,
+		historySize,
+		now,
+		createMode,
+		"",
+		0,
+	); err != nil {
+		return nil, err
+	}
+
+	mutableState, err := context.loadWorkflowExecution()
+	if err != nil {
+		return nil, err
+	}
+	if err := mutableState.UpdateWorkflowStateCloseStatus(closeState, closeStatus); err != nil {
+		return nil, err
+	}
+	if err := addUnarchivedWorkflowDeletionTask(mutableState, domainEntry, now); err != nil {
+		return nil, err
+	}
+	if err := context.updateWorkflowExecutionWithNew(
+		now,
+		updateMode,
+		nil,
+		nil,
+		transactionPolicyPassive,
+		nil,
+	); err != nil {
+		return nil, err
+	}
+	return &historyservice.UnarchiveWorkflowExecutionResponse{}, nil
+}
+
+// addUnarchivedWorkflowDeletionTask schedules the deletion of an unarchived workflow execution once
+// the retention of the domain has passed since now.
+func addUnarchivedWorkflowDeletionTask(
+	mutableState mutableState,
+	domainEntry *cache.DomainCacheEntry,
+	now time.Time,
+) error {
+
+	lastWriteVersion, err := mutableState.GetLastWriteVersion()
+	if err != nil {
+		return err
+	}
+	retentionInDays := domainEntry.GetRetentionDays(mutableState.GetExecutionInfo().WorkflowID)
+	mutableState.AddTimerTasks(&persistence.DeleteHistoryEventTask{
+		// TaskID is set by shard
+		VisibilityTimestamp: now.Add(time.Duration(retentionInDays) * time.Hour * 24),
+		Version:             lastWriteVersion,
+	})
+	return nil
+}
+
+func (e *historyEngineImpl) getArchivedHistory(
+	ctx ctx.Context,
+	domainEntry *cache.DomainCacheEntry,
+	workflowID string,
+	runID string,
+) ([]*workflow.History, error) {
+
+	URIString := domainEntry.GetConfig().HistoryArchivalURI
+	if URIString == "" {
+		// the domain has never enabled archival
+		return nil, ErrWorkflowNotArchived
+	}
+	URI, err := archiver.NewURI(URIString)
+	if err != nil {
+		return nil, err
+	}
+	historyArchiver, err := e.shard.GetService().GetArchiverProvider().GetHistoryArchiver(URI.Scheme(), common.HistoryServiceName)
+	if err != nil {
+		return nil, err
+	}
+
+	var historyBatches []*workflow.History
+	request := &archiver.GetHistoryRequest{
+		DomainID:   domainEntry.GetInfo().ID,
+		WorkflowID: workflowID,
+		RunID:      runID,
+		PageSize:   nDCDefaultPageSize,
+	}
+	for {
+		resp, err := historyArchiver.Get(ctx, URI, request)
+		if err != nil {
+			return nil, err
+		}
+		for _, batch := range resp.HistoryBatches {
+			if len(batch.Events) > 0 {
+				historyBatches = append(historyBatches, batch)
+			}
+		}
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		request.NextPageToken = resp.NextPageToken
+	}
+	if len(historyBatches) == 0 {
+		return nil, ErrWorkflowNotArchived
+	}
+	return historyBatches, nil
+}
+
 func (e *historyEngineImpl) TerminateWorkflowExecution(
 	ctx ctx.Context,
 	terminateRequest *h.TerminateWorkflowExecutionRequest,
@@ -2583,6 +3003,37 @@ func (e *historyEngineImpl) overrideStartWorkflowExecutionRequest(
 	}
 }
 
+// checkOpenWorkflowsLimit rejects the start of a workflow when the shard already has its share
+// of the open workflows limit of the domain
+func (e *historyEngineImpl) checkOpenWorkflowsLimit(
+	domainEntry *cache.DomainCacheEntry,
+) error {
+
+	domainName := domainEntry.GetInfo().Name
+	limit := e.config.OpenWorkflowsLimit(domainName)
+	if limit <= 0 {
+		return nil
+	}
+
+	// workflows are spread evenly over the shards, so each shard allows its share of the limit
+	shardLimit := (limit + e.config.NumberOfShards - 1) / e.config.NumberOfShards
+	openWorkflows := e.shard.GetOpenWorkflowCount(domainEntry.GetInfo().ID)
+	if openWorkflows < shardLimit {
+		return nil
+	}
+
+	e.logger.Info("Shard limit reached for open workflows of domain",
+		tag.WorkflowDomainName(domainName),
+		tag.ShardID(e.shard.GetShardID()),
+		tag.Counter(openWorkflows),
+	)
+	e.metricsClient.Scope(
+		metrics.HistoryStartWorkflowExecutionScope,
+		metrics.DomainTag(domainName),
+	).IncCounter(metrics.OpenWorkflowsLimitExceededCounter)
+	return ErrOpenWorkflowsLimitExceeded
+}
+
 func validateDomainUUID(
 	domainUUID *string,
 ) (string, error) {
@@ -2658,6 +3109,7 @@ func getStartRequest(
 		Memo:                                request.Memo,
 		SearchAttributes:                    request.SearchAttributes,
 		Header:                              request.Header,
+		DelayStartSeconds:                   request.DelayStartSeconds,
 	}
 
 	startRequest := common.CreateHistoryStartWorkflowRequest(domainID, req)
@@ -2888,12 +3340,13 @@ func (e *historyEngineImpl) ReapplyEvents(
 				}, nil
 			}
 
-			postActions := &updateWorkflowAction{
-				createDecision: true,
+			// Do not create decision task when the workflow is cron or delayed and has not been started yet
+			firstDecisionDelayed, err := isFirstDecisionDelayed(mutableState)
+			if err != nil {
+				return nil, err
 			}
-			// Do not create decision task when the workflow is cron and the cron has not been started yet
-			if mutableState.GetExecutionInfo().CronSchedule != "" && !mutableState.HasProcessedOrPendingDecision() {
-				postActions.createDecision = false
+			postActions := &updateWorkflowAction{
+				createDecision: !firstDecisionDelayed,
 			}
 			reappliedEvents, err := e.eventsReapplier.reapplyEvents(
 				ctx,
@@ -2914,6 +3367,151 @@ func (e *historyEngineImpl) ReapplyEvents(
 		})
 }
 
+// ListShardTasks returns a page of the pending transfer, timer or replication tasks of the shard,
+// filters are applied to the tasks read for the page so a page can hold less tasks than the page size
+func (e *historyEngineImpl) ListShardTasks(
+	ctx ctx.Context,
+	request *historyservice.ListShardTasksRequest,
+) (*historyservice.ListShardTasksResponse, error) {
+
+	pageSize := int(request.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultShardTasksPageSize
+	}
+	minVisibilityTime := time.Unix(0, request.GetMinVisibilityTimestamp())
+	maxVisibilityTime := time.Unix(0, math.MaxInt64)
+	if request.GetMaxVisibilityTimestamp() > 0 {
+		maxVisibilityTime = time.Unix(0, request.GetMaxVisibilityTimestamp())
+	}
+
+	response := &historyservice.ListShardTasksResponse{
+		ClusterAckLevels: make(map[string]int64),
+	}
+	var tasks []queueTaskInfo
+	switch request.GetCategory() {
+	case shardTaskCategoryTransfer:
+		response.AckLevel = e.shard.GetTransferAckLevel()
+		response.ReadLevel = e.shard.GetTransferMaxReadLevel()
+		for clusterName := range e.clusterMetadata.GetAllClusterInfo() {
+			response.ClusterAckLevels[clusterName] = e.shard.GetTransferClusterAckLevel(clusterName)
+		}
+		resp, err := e.executionManager.GetTransferTasks(&persistence.GetTransferTasksRequest{
+			ReadLevel:     response.AckLevel,
+			MaxReadLevel:  response.ReadLevel,
+			BatchSize:     pageSize,
+			NextPageToken: request.GetNextPageToken(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range resp.Tasks {
+			tasks = append(tasks, task)
+		}
+		response.NextPageToken = resp.NextPageToken
+
+	case shardTaskCategoryTimer:
+		ackLevel := e.shard.GetTimerAckLevel()
+		response.AckLevel = ackLevel.UnixNano()
+		response.ReadLevel = e.shard.GetTimerMaxReadLevel(e.currentClusterName).UnixNano()
+		for clusterName := range e.clusterMetadata.GetAllClusterInfo() {
+			response.ClusterAckLevels[clusterName] = e.shard.GetTimerClusterAckLevel(clusterName).UnixNano()
+		}
+		minTimestamp := ackLevel
+		if minVisibilityTime.After(minTimestamp) {
+			minTimestamp = minVisibilityTime
+		}
+		resp, err := e.executionManager.GetTimerIndexTasks(&persistence.GetTimerIndexTasksRequest{
+			MinTimestamp:  minTimestamp,
+			MaxTimestamp:  maxVisibilityTime,
+			BatchSize:     pageSize,
+			NextPageToken: request.GetNextPageToken(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range resp.Timers {
+			tasks = append(tasks, task)
+		}
+		response.NextPageToken = resp.NextPageToken
+
+	case shardTaskCategoryReplication:
+		response.AckLevel = e.shard.GetReplicatorAckLevel()
+		response.ReadLevel = e.shard.GetTransferMaxReadLevel()
+		for clusterName := range e.clusterMetadata.GetAllClusterInfo() {
+			response.ClusterAckLevels[clusterName] = e.shard.GetClusterReplicationLevel(clusterName)
+		}
+		resp, err := e.executionManager.GetReplicationTasks(&persistence.GetReplicationTasksRequest{
+			ReadLevel:     response.AckLevel,
+			MaxReadLevel:  response.ReadLevel,
+			BatchSize:     pageSize,
+			NextPageToken: request.GetNextPageToken(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range resp.Tasks {
+			tasks = append(tasks, task)
+		}
+		response.NextPageToken = resp.NextPageToken
+
+	default:
+		return nil, errInvalidShardTaskType
+	}
+
+	taskTypes := make(map[int]struct{})
+	for _, taskType := range request.GetTaskTypes() {
+		taskTypes[int(taskType)] = struct{}{}
+	}
+	for _, task := range tasks {
+		if _, ok := taskTypes[task.GetTaskType()]; len(taskTypes) > 0 && !ok {
+			continue
+		}
+		if request.GetDomainId() != "" && task.GetDomainID() != request.GetDomainId() {
+			continue
+		}
+		if request.GetWorkflowId() != "" && task.GetWorkflowID() != request.GetWorkflowId() {
+			continue
+		}
+		visibilityTime := task.GetVisibilityTimestamp()
+		if visibilityTime.Before(minVisibilityTime) || !visibilityTime.Before(maxVisibilityTime) {
+			continue
+		}
+		response.Tasks = append(response.Tasks, toShardTask(task))
+	}
+	return response, nil
+}
+
+func toShardTask(
+	task queueTaskInfo,
+) *adminservice.ShardTask {
+
+	return &adminservice.ShardTask{
+		DomainId:            task.GetDomainID(),
+		WorkflowId:          task.GetWorkflowID(),
+		RunId:               task.GetRunID(),
+		TaskId:              task.GetTaskID(),
+		TaskType:            int32(task.GetTaskType()),
+		VisibilityTimestamp: task.GetVisibilityTimestamp().UnixNano(),
+		Version:             task.GetVersion(),
+	}
+}
+
+// ProcessDLQTask processes a transfer or timer task from the DLQ of the shard once
+func (e *historyEngineImpl) ProcessDLQTask(
+	ctx ctx.Context,
+	task queueTaskInfo,
+) error {
+
+	switch task := task.(type) {
+	case *persistence.TransferTaskInfo:
+		return e.txProcessor.ProcessDLQTask(task)
+	case *persistence.TimerTaskInfo:
+		return e.timerProcessor.ProcessDLQTask(task)
+	default:
+		return errUnexpectedQueueTask
+	}
+}
+
 func (e *historyEngineImpl) loadWorkflowOnce(
 	ctx ctx.Context,
 	domainID string,
@@ -36,6 +36,7 @@ import (
 	history "github.com/temporalio/temporal/.gen/go/history"
 	replicator "github.com/temporalio/temporal/.gen/go/replicator"
 	shared "github.com/temporalio/temporal/.gen/go/shared"
+	historyservice "github.com/temporalio/temporal/.gen/proto/historyservice"
 	persistence "github.com/temporalio/temporal/common/persistence"
 )
 
@@ -349,6 +350,51 @@ func (mr *MockEngineMockRecorder) RemoveSignalMutableState(ctx, request interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSignalMutableState", reflect.TypeOf((*MockEngine)(nil).RemoveSignalMutableState), ctx, request)
 }
 
+// UnarchiveWorkflowExecution mocks base method
+func (m *MockEngine) UnarchiveWorkflowExecution(ctx context.Context, request *historyservice.UnarchiveWorkflowExecutionRequest) (*historyservice.UnarchiveWorkflowExecutionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnarchiveWorkflowExecution", ctx, request)
+	ret0, _ := ret[0].(*historyservice.UnarchiveWorkflowExecutionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnarchiveWorkflowExecution indicates an expected call of UnarchiveWorkflowExecution
+func (mr *MockEngineMockRecorder) UnarchiveWorkflowExecution(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnarchiveWorkflowExecution", reflect.TypeOf((*MockEngine)(nil).UnarchiveWorkflowExecution), ctx, request)
+}
+
+// UpsertWorkflowSearchAttributes mocks base method
+func (m *MockEngine) UpsertWorkflowSearchAttributes(ctx context.Context, request *historyservice.UpsertWorkflowSearchAttributesRequest) (*historyservice.UpsertWorkflowSearchAttributesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertWorkflowSearchAttributes", ctx, request)
+	ret0, _ := ret[0].(*historyservice.UpsertWorkflowSearchAttributesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertWorkflowSearchAttributes indicates an expected call of UpsertWorkflowSearchAttributes
+func (mr *MockEngineMockRecorder) UpsertWorkflowSearchAttributes(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertWorkflowSearchAttributes", reflect.TypeOf((*MockEngine)(nil).UpsertWorkflowSearchAttributes), ctx, request)
+}
+
+// DeleteWorkflowExecution mocks base method
+func (m *MockEngine) DeleteWorkflowExecution(ctx context.Context, request *historyservice.DeleteWorkflowExecutionRequest) (*historyservice.DeleteWorkflowExecutionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkflowExecution", ctx, request)
+	ret0, _ := ret[0].(*historyservice.DeleteWorkflowExecutionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWorkflowExecution indicates an expected call of DeleteWorkflowExecution
+func (mr *MockEngineMockRecorder) DeleteWorkflowExecution(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkflowExecution", reflect.TypeOf((*MockEngine)(nil).DeleteWorkflowExecution), ctx, request)
+}
+
 // TerminateWorkflowExecution mocks base method
 func (m *MockEngine) TerminateWorkflowExecution(ctx context.Context, request *history.TerminateWorkflowExecutionRequest) error {
 	m.ctrl.T.Helper()
@@ -535,6 +581,35 @@ func (mr *MockEngineMockRecorder) ReapplyEvents(ctx, domainUUID, workflowID, run
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReapplyEvents", reflect.TypeOf((*MockEngine)(nil).ReapplyEvents), ctx, domainUUID, workflowID, runID, events)
 }
 
+// ProcessDLQTask mocks base method
+func (m *MockEngine) ProcessDLQTask(ctx context.Context, task queueTaskInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessDLQTask", ctx, task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessDLQTask indicates an expected call of ProcessDLQTask
+func (mr *MockEngineMockRecorder) ProcessDLQTask(ctx, task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessDLQTask", reflect.TypeOf((*MockEngine)(nil).ProcessDLQTask), ctx, task)
+}
+
+// ListShardTasks mocks base method
+func (m *MockEngine) ListShardTasks(ctx context.Context, request *historyservice.ListShardTasksRequest) (*historyservice.ListShardTasksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShardTasks", ctx, request)
+	ret0, _ := ret[0].(*historyservice.ListShardTasksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShardTasks indicates an expected call of ListShardTasks
+func (mr *MockEngineMockRecorder) ListShardTasks(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShardTasks", reflect.TypeOf((*MockEngine)(nil).ListShardTasks), ctx, request)
+}
+
 // NotifyNewHistoryEvent mocks base method
 func (m *MockEngine) NotifyNewHistoryEvent(event *historyEventNotification) {
 	m.ctrl.T.Helper()
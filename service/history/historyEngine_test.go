@@ -39,6 +39,7 @@ import (
 	"github.com/temporalio/temporal/.gen/go/history"
 	"github.com/temporalio/temporal/.gen/go/history/historyservicetest"
 	workflow "github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/.gen/proto/matchingservice"
 	"github.com/temporalio/temporal/.gen/proto/matchingservicemock"
 	"github.com/temporalio/temporal/common"
@@ -4840,6 +4841,107 @@ func (s *engineSuite) TestRemoveSignalMutableState() {
 	s.Nil(err)
 }
 
+func (s *engineSuite) TestListShardTasks_Transfer() {
+	now := time.Unix(0, time.Now().UnixNano())
+	tasks := []*persistence.TransferTaskInfo{
+		{DomainID: testDomainID, WorkflowID: "wid1", TaskID: 1, TaskType: persistence.TransferTaskTypeDecisionTask, VisibilityTimestamp: now},
+		{DomainID: testDomainID, WorkflowID: "wid2", TaskID: 2, TaskType: persistence.TransferTaskTypeDecisionTask, VisibilityTimestamp: now},
+		{DomainID: testDomainID, WorkflowID: "wid1", TaskID: 3, TaskType: persistence.TransferTaskTypeActivityTask, VisibilityTimestamp: now},
+		{DomainID: "other domain", WorkflowID: "wid1", TaskID: 4, TaskType: persistence.TransferTaskTypeDecisionTask, VisibilityTimestamp: now},
+	}
+	s.mockExecutionMgr.On("GetTransferTasks", &persistence.GetTransferTasksRequest{
+		ReadLevel:     s.mockShard.GetTransferAckLevel(),
+		MaxReadLevel:  s.mockShard.GetTransferMaxReadLevel(),
+		BatchSize:     10,
+		NextPageToken: []byte("token"),
+	}).Return(&persistence.GetTransferTasksResponse{Tasks: tasks, NextPageToken: []byte("next token")}, nil).Once()
+
+	resp, err := s.mockHistoryEngine.ListShardTasks(context.Background(), &historyservice.ListShardTasksRequest{
+		Category:      shardTaskCategoryTransfer,
+		TaskTypes:     []int32{persistence.TransferTaskTypeDecisionTask},
+		DomainId:      testDomainID,
+		WorkflowId:    "wid1",
+		PageSize:      10,
+		NextPageToken: []byte("token"),
+	})
+	s.NoError(err)
+	s.Equal([]byte("next token"), resp.NextPageToken)
+	s.Len(resp.Tasks, 1)
+	s.Equal(int64(1), resp.Tasks[0].TaskId)
+	s.Equal(now.UnixNano(), resp.Tasks[0].VisibilityTimestamp)
+	s.Equal(s.mockShard.GetTransferAckLevel(), resp.AckLevel)
+	s.Equal(s.mockShard.GetTransferMaxReadLevel(), resp.ReadLevel)
+	s.Contains(resp.ClusterAckLevels, cluster.TestCurrentClusterName)
+}
+
+func (s *engineSuite) TestListShardTasks_Timer() {
+	now := time.Unix(0, time.Now().UnixNano())
+	tasks := []*persistence.TimerTaskInfo{
+		{DomainID: testDomainID, TaskID: 1, VisibilityTimestamp: now},
+		{DomainID: testDomainID, TaskID: 2, VisibilityTimestamp: now.Add(time.Hour)},
+	}
+	s.mockExecutionMgr.On("GetTimerIndexTasks", &persistence.GetTimerIndexTasksRequest{
+		MinTimestamp: now,
+		MaxTimestamp: now.Add(time.Minute),
+		BatchSize:    defaultShardTasksPageSize,
+	}).Return(&persistence.GetTimerIndexTasksResponse{Timers: tasks}, nil).Once()
+
+	resp, err := s.mockHistoryEngine.ListShardTasks(context.Background(), &historyservice.ListShardTasksRequest{
+		Category:               shardTaskCategoryTimer,
+		MinVisibilityTimestamp: now.UnixNano(),
+		MaxVisibilityTimestamp: now.Add(time.Minute).UnixNano(),
+	})
+	s.NoError(err)
+	s.Len(resp.Tasks, 1)
+	s.Equal(int64(1), resp.Tasks[0].TaskId)
+	s.Equal(s.mockShard.GetTimerAckLevel().UnixNano(), resp.AckLevel)
+}
+
+func (s *engineSuite) TestListShardTasks_InvalidCategory() {
+	_, err := s.mockHistoryEngine.ListShardTasks(context.Background(), &historyservice.ListShardTasksRequest{
+		Category: 1,
+	})
+	s.Equal(errInvalidShardTaskType, err)
+}
+
+func (s *engineSuite) TestAddUnarchivedWorkflowDeletionTask() {
+	now := time.Now()
+	mutableState := NewMockmutableState(s.controller)
+	mutableState.EXPECT().GetLastWriteVersion().Return(int64(123), nil).Times(1)
+	mutableState.EXPECT().GetExecutionInfo().Return(&persistence.WorkflowExecutionInfo{
+		DomainID:   testDomainID,
+		WorkflowID: "wId",
+		RunID:      testRunID,
+	}).AnyTimes()
+	mutableState.EXPECT().AddTransferTasks(gomock.Any()).Times(0)
+	mutableState.EXPECT().AddTimerTasks(&persistence.DeleteHistoryEventTask{
+		VisibilityTimestamp: now.Add(24 * time.Hour),
+		Version:             123,
+	}).Times(1)
+
+	err := addUnarchivedWorkflowDeletionTask(mutableState, testLocalDomainEntry, now)
+	s.NoError(err)
+}
+
+func (s *engineSuite) TestCheckOpenWorkflowsLimit() {
+	engine := s.mockHistoryEngine
+	s.NoError(engine.checkOpenWorkflowsLimit(testLocalDomainEntry))
+
+	engine.config.OpenWorkflowsLimit = dynamicconfig.GetIntPropertyFilteredByDomain(2)
+	s.NoError(engine.checkOpenWorkflowsLimit(testLocalDomainEntry))
+	s.mockShard.shardInfo.OpenWorkflowCounts = map[string]int64{testDomainID: 1}
+	s.NoError(engine.checkOpenWorkflowsLimit(testLocalDomainEntry))
+	s.mockShard.shardInfo.OpenWorkflowCounts[testDomainID] = 2
+	s.Equal(ErrOpenWorkflowsLimitExceeded, engine.checkOpenWorkflowsLimit(testLocalDomainEntry))
+
+	// each shard allows its share of the limit, rounded up
+	engine.config.NumberOfShards = 4
+	engine.config.OpenWorkflowsLimit = dynamicconfig.GetIntPropertyFilteredByDomain(5)
+	s.Equal(ErrOpenWorkflowsLimitExceeded, engine.checkOpenWorkflowsLimit(testLocalDomainEntry))
+	s.mockShard.shardInfo.OpenWorkflowCounts[testDomainID] = 1
+	s.NoError(engine.checkOpenWorkflowsLimit(testLocalDomainEntry))
+}
+
 func (s *engineSuite) getBuilder(testDomainID string, we workflow.WorkflowExecution) mutableState {
 	context, release, err := s.mockHistoryEngine.historyCache.getOrCreateWorkflowExecutionForBackground(testDomainID, we)
 	if err != nil {
@@ -131,6 +131,39 @@ func (s *mutableStateSuite) TestTransientDecisionCompletionFirstBatchReplicated_
 	s.Equal(0, len(s.msBuilder.GetHistoryBuilder().history))
 }
 
+func (s *mutableStateSuite) TestStartingBuildID() {
+	version := int64(12)
+	runID := uuid.New()
+	s.msBuilder = newMutableStateBuilderWithReplicationStateWithEventV2(
+		s.mockShard,
+		s.mockEventsCache,
+		s.logger,
+		version,
+		runID,
+	)
+
+	newDecisionScheduleEvent, newDecisionStartedEvent := s.prepareTransientDecisionCompletionFirstBatchReplicated(version, runID)
+
+	for i, binaryChecksum := range []string{"", "1.0", "2.0"} {
+		newDecisionCompletedEvent := &shared.HistoryEvent{
+			Version:   common.Int64Ptr(version),
+			EventId:   common.Int64Ptr(newDecisionStartedEvent.GetEventId() + 1 + int64(i)),
+			Timestamp: common.Int64Ptr(time.Now().UnixNano()),
+			EventType: shared.EventTypeDecisionTaskCompleted.Ptr(),
+			DecisionTaskCompletedEventAttributes: &shared.DecisionTaskCompletedEventAttributes{
+				ScheduledEventId: common.Int64Ptr(newDecisionScheduleEvent.GetEventId()),
+				StartedEventId:   common.Int64Ptr(newDecisionStartedEvent.GetEventId()),
+				BinaryChecksum:   common.StringPtr(binaryChecksum),
+			},
+		}
+		err := s.msBuilder.ReplicateDecisionTaskCompletedEvent(newDecisionCompletedEvent)
+		s.NoError(err)
+	}
+	// auto reset points rotate out and are copied on continue as new, the starting build ID does not depend on them
+	s.msBuilder.GetExecutionInfo().AutoResetPoints = nil
+	s.Equal("1.0", s.msBuilder.GetExecutionInfo().StartingBuildID)
+}
+
 func (s *mutableStateSuite) TestTransientDecisionCompletionFirstBatchReplicated_FailoverDecisionTimeout() {
 	version := int64(12)
 	runID := uuid.New()
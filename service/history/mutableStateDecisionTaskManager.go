@@ -726,5 +726,9 @@ func (m *mutableStateDecisionTaskManagerImpl) afterAddDecisionTaskCompletedEvent
 	maxResetPoints int,
 ) error {
 	m.msb.executionInfo.LastProcessedEvent = event.GetDecisionTaskCompletedEventAttributes().GetStartedEventId()
+	if m.msb.executionInfo.StartingBuildID == "" {
+		// later decision tasks are routed to the build ID set of the worker which completed the first decision
+		m.msb.executionInfo.StartingBuildID = event.GetDecisionTaskCompletedEventAttributes().GetBinaryChecksum()
+	}
 	return m.msb.addBinaryCheckSumIfNotExists(event, maxResetPoints)
 }
@@ -116,6 +116,7 @@ type (
 	pushDecisionToMatchingInfo struct {
 		decisionScheduleToStartTimeout int32
 		tasklist                       shared.TaskList
+		buildID                        string
 	}
 )
 
@@ -152,11 +153,13 @@ func newPushActivityToMatchingInfo(
 func newPushDecisionToMatchingInfo(
 	decisionScheduleToStartTimeout int32,
 	tasklist shared.TaskList,
+	buildID string,
 ) *pushDecisionToMatchingInfo {
 
 	return &pushDecisionToMatchingInfo{
 		decisionScheduleToStartTimeout: decisionScheduleToStartTimeout,
 		tasklist:                       tasklist,
+		buildID:                        buildID,
 	}
 }
 
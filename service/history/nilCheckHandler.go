@@ -169,6 +169,30 @@ func (h *NilCheckHandler) RemoveSignalMutableState(ctx context.Context, request
 	return resp, err
 }
 
+func (h *NilCheckHandler) UnarchiveWorkflowExecution(ctx context.Context, request *historyservice.UnarchiveWorkflowExecutionRequest) (_ *historyservice.UnarchiveWorkflowExecutionResponse, retError error) {
+	resp, err := h.parentHandler.UnarchiveWorkflowExecution(ctx, request)
+	if resp == nil && err == nil {
+		return &historyservice.UnarchiveWorkflowExecutionResponse{}, err
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) UpsertWorkflowSearchAttributes(ctx context.Context, request *historyservice.UpsertWorkflowSearchAttributesRequest) (_ *historyservice.UpsertWorkflowSearchAttributesResponse, retError error) {
+	resp, err := h.parentHandler.UpsertWorkflowSearchAttributes(ctx, request)
+	if resp == nil && err == nil {
+		return &historyservice.UpsertWorkflowSearchAttributesResponse{}, err
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) DeleteWorkflowExecution(ctx context.Context, request *historyservice.DeleteWorkflowExecutionRequest) (_ *historyservice.DeleteWorkflowExecutionResponse, retError error) {
+	resp, err := h.parentHandler.DeleteWorkflowExecution(ctx, request)
+	if resp == nil && err == nil {
+		return &historyservice.DeleteWorkflowExecutionResponse{}, err
+	}
+	return resp, err
+}
+
 func (h *NilCheckHandler) TerminateWorkflowExecution(ctx context.Context, request *historyservice.TerminateWorkflowExecutionRequest) (_ *historyservice.TerminateWorkflowExecutionResponse, retError error) {
 	resp, err := h.parentHandler.TerminateWorkflowExecution(ctx, request)
 	if resp == nil && err == nil {
@@ -289,6 +313,38 @@ func (h *NilCheckHandler) RemoveTask(ctx context.Context, request *historyservic
 	return resp, err
 }
 
+func (h *NilCheckHandler) ListShardTasks(ctx context.Context, request *historyservice.ListShardTasksRequest) (_ *historyservice.ListShardTasksResponse, retError error) {
+	resp, err := h.parentHandler.ListShardTasks(ctx, request)
+	if resp == nil && err == nil {
+		return &historyservice.ListShardTasksResponse{}, err
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) ListShardDLQTasks(ctx context.Context, request *historyservice.ListShardDLQTasksRequest) (_ *historyservice.ListShardDLQTasksResponse, retError error) {
+	resp, err := h.parentHandler.ListShardDLQTasks(ctx, request)
+	if resp == nil && err == nil {
+		return &historyservice.ListShardDLQTasksResponse{}, err
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) RetryShardDLQTasks(ctx context.Context, request *historyservice.RetryShardDLQTasksRequest) (_ *historyservice.RetryShardDLQTasksResponse, retError error) {
+	resp, err := h.parentHandler.RetryShardDLQTasks(ctx, request)
+	if resp == nil && err == nil {
+		return &historyservice.RetryShardDLQTasksResponse{}, err
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) PurgeShardDLQTasks(ctx context.Context, request *historyservice.PurgeShardDLQTasksRequest) (_ *historyservice.PurgeShardDLQTasksResponse, retError error) {
+	resp, err := h.parentHandler.PurgeShardDLQTasks(ctx, request)
+	if resp == nil && err == nil {
+		return &historyservice.PurgeShardDLQTasksResponse{}, err
+	}
+	return resp, err
+}
+
 func (h *NilCheckHandler) GetReplicationMessages(ctx context.Context, request *historyservice.GetReplicationMessagesRequest) (_ *historyservice.GetReplicationMessagesResponse, retError error) {
 	resp, err := h.parentHandler.GetReplicationMessages(ctx, request)
 	if resp == nil && err == nil {
@@ -89,8 +89,9 @@ func newQueueProcessorBase(
 ) *queueProcessorBase {
 
 	taskProcessorOptions := taskProcessorOptions{
-		queueSize:   options.BatchSize(),
-		workerCount: options.WorkerCount(),
+		queueSize:    options.BatchSize(),
+		workerCount:  options.WorkerCount(),
+		metricsScope: options.MetricScope,
 	}
 	taskProcessor := newTaskProcessor(taskProcessorOptions, shard, historyCache, logger)
 	p := &queueProcessorBase{
@@ -30,6 +30,7 @@ import (
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/definition"
+	"github.com/temporalio/temporal/common/health"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/persistence"
@@ -39,6 +40,7 @@ import (
 	"github.com/temporalio/temporal/common/service"
 	"github.com/temporalio/temporal/common/service/config"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
+	"github.com/temporalio/temporal/common/tracing"
 )
 
 // Config represents configuration for cadence-history service
@@ -112,6 +114,11 @@ type Config struct {
 	TransferProcessorCompleteTransferInterval           dynamicconfig.DurationPropertyFn
 	TransferProcessorVisibilityArchivalTimeLimit        dynamicconfig.DurationPropertyFn
 
+	// TaskScheduler settings, shared by transfer and timer queue processors
+	TaskSchedulerDomainWeight      dynamicconfig.IntPropertyFnWithDomainFilter
+	TaskSchedulerDomainMaxInflight dynamicconfig.IntPropertyFnWithDomainFilter
+	TaskDLQMaxAttempts             dynamicconfig.IntPropertyFn
+
 	// ReplicatorQueueProcessor settings
 	ReplicatorTaskBatchSize                               dynamicconfig.IntPropertyFn
 	ReplicatorTaskWorkerCount                             dynamicconfig.IntPropertyFn
@@ -164,6 +171,17 @@ type Config struct {
 	HistorySizeLimitWarn   dynamicconfig.IntPropertyFnWithDomainFilter
 	HistoryCountLimitError dynamicconfig.IntPropertyFnWithDomainFilter
 	HistoryCountLimitWarn  dynamicconfig.IntPropertyFnWithDomainFilter
+	// EventBlobSizeLimitError is the blob size limit of payloads recorded in history events, which may be offloaded
+	EventBlobSizeLimitError dynamicconfig.IntPropertyFnWithDomainFilter
+	// OffloadedBlobSizeLimitError is the blob size limit of payloads offloaded to the history payload store
+	OffloadedBlobSizeLimitError dynamicconfig.IntPropertyFnWithDomainFilter
+
+	// Pending and open workflow limit related settings
+	PendingActivitiesLimit      dynamicconfig.IntPropertyFnWithDomainFilter
+	PendingTimersLimit          dynamicconfig.IntPropertyFnWithDomainFilter
+	PendingChildExecutionsLimit dynamicconfig.IntPropertyFnWithDomainFilter
+	PendingSignalsLimit         dynamicconfig.IntPropertyFnWithDomainFilter
+	OpenWorkflowsLimit          dynamicconfig.IntPropertyFnWithDomainFilter
 
 	// ValidSearchAttributes is legal indexed keys that can be used in list APIs
 	ValidSearchAttributes             dynamicconfig.MapPropertyFn
@@ -262,6 +280,9 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		TransferProcessorUpdateAckIntervalJitterCoefficient:   dc.GetFloat64Property(dynamicconfig.TransferProcessorUpdateAckIntervalJitterCoefficient, 0.15),
 		TransferProcessorCompleteTransferInterval:             dc.GetDurationProperty(dynamicconfig.TransferProcessorCompleteTransferInterval, 60*time.Second),
 		TransferProcessorVisibilityArchivalTimeLimit:          dc.GetDurationProperty(dynamicconfig.TransferProcessorVisibilityArchivalTimeLimit, 200*time.Millisecond),
+		TaskSchedulerDomainWeight:                             dc.GetIntPropertyFilteredByDomain(dynamicconfig.TaskSchedulerDomainWeight, 1),
+		TaskSchedulerDomainMaxInflight:                        dc.GetIntPropertyFilteredByDomain(dynamicconfig.TaskSchedulerDomainMaxInflight, 0),
+		TaskDLQMaxAttempts:                                    dc.GetIntProperty(dynamicconfig.TaskDLQMaxAttempts, 0),
 		ReplicatorTaskBatchSize:                               dc.GetIntProperty(dynamicconfig.ReplicatorTaskBatchSize, 100),
 		ReplicatorTaskWorkerCount:                             dc.GetIntProperty(dynamicconfig.ReplicatorTaskWorkerCount, 10),
 		ReplicatorTaskMaxRetryCount:                           dc.GetIntProperty(dynamicconfig.ReplicatorTaskMaxRetryCount, 100),
@@ -296,6 +317,15 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		HistorySizeLimitWarn:   dc.GetIntPropertyFilteredByDomain(dynamicconfig.HistorySizeLimitWarn, 50*1024*1024),
 		HistoryCountLimitError: dc.GetIntPropertyFilteredByDomain(dynamicconfig.HistoryCountLimitError, 200*1024),
 		HistoryCountLimitWarn:  dc.GetIntPropertyFilteredByDomain(dynamicconfig.HistoryCountLimitWarn, 50*1024),
+		// same key as BlobSizeLimitError, NewService raises it to OffloadedBlobSizeLimitError when large payloads are offloaded
+		EventBlobSizeLimitError:     dc.GetIntPropertyFilteredByDomain(dynamicconfig.BlobSizeLimitError, 2*1024*1024),
+		OffloadedBlobSizeLimitError: dc.GetIntPropertyFilteredByDomain(dynamicconfig.OffloadedBlobSizeLimitError, 16*1024*1024),
+
+		PendingActivitiesLimit:      dc.GetIntPropertyFilteredByDomain(dynamicconfig.PendingActivitiesLimit, 0),
+		PendingTimersLimit:          dc.GetIntPropertyFilteredByDomain(dynamicconfig.PendingTimersLimit, 0),
+		PendingChildExecutionsLimit: dc.GetIntPropertyFilteredByDomain(dynamicconfig.PendingChildExecutionsLimit, 0),
+		PendingSignalsLimit:         dc.GetIntPropertyFilteredByDomain(dynamicconfig.PendingSignalsLimit, 0),
+		OpenWorkflowsLimit:          dc.GetIntPropertyFilteredByDomain(dynamicconfig.OpenWorkflowsLimit, 0),
 
 		ThrottledLogRPS:   dc.GetIntProperty(dynamicconfig.HistoryThrottledLogRPS, 4),
 		EnableStickyQuery: dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.EnableStickyQuery, true),
@@ -337,10 +367,11 @@ func (config *Config) GetShardID(workflowID string) int {
 type Service struct {
 	resource.Resource
 
-	status  int32
-	handler *Handler
-	params  *service.BootstrapParams
-	config  *Config
+	status       int32
+	handler      *Handler
+	params       *service.BootstrapParams
+	config       *Config
+	healthServer *health.Server
 
 	server *grpc.Server
 }
@@ -354,6 +385,12 @@ func NewService(
 		params.PersistenceConfig.DefaultStoreType(),
 		params.PersistenceConfig.IsAdvancedVisibilityConfigExist())
 
+	serviceConfig.EventBlobSizeLimitError = persistence.NewBlobSizeLimitWithOffloading(
+		serviceConfig.EventBlobSizeLimitError,
+		serviceConfig.OffloadedBlobSizeLimitError,
+		params.PersistenceConfig.GetHistoryPayloadOffloadThreshold(),
+	)
+
 	params.PersistenceConfig.HistoryMaxConns = serviceConfig.HistoryMgrNumConns()
 	params.PersistenceConfig.SetMaxQPS(params.PersistenceConfig.DefaultStore, serviceConfig.PersistenceMaxQPS())
 	params.PersistenceConfig.VisibilityConfig = &config.VisibilityConfig{
@@ -397,10 +434,11 @@ func NewService(
 	}
 
 	return &Service{
-		Resource: serviceResource,
-		status:   common.DaemonStatusInitialized,
-		params:   params,
-		config:   serviceConfig,
+		Resource:     serviceResource,
+		status:       common.DaemonStatusInitialized,
+		params:       params,
+		config:       serviceConfig,
+		healthServer: health.NewServer("historyservice.HistoryService", params.HealthConfig, serviceResource.GetLogger()),
 	}, nil
 }
 
@@ -421,11 +459,17 @@ func (s *Service) Start() {
 	s.Resource.Start()
 	s.handler.Start()
 
-	s.server = grpc.NewServer()
+	interceptors := append([]grpc.UnaryServerInterceptor{tracing.NewServerInterceptor()}, s.params.Interceptors...)
+	s.server = grpc.NewServer(grpc.UnaryInterceptor(common.ChainUnaryServerInterceptors(interceptors...)))
 	handlerGRPC := NewHandlerGRPC(s.handler)
 	nilCheckHandler := NewNilCheckHandler(handlerGRPC)
 	historyservice.RegisterHistoryServiceServer(s.server, nilCheckHandler)
 	healthservice.RegisterMetaServer(s.server, handlerGRPC)
+	s.healthServer.RegisterGRPC(s.server)
+
+	s.healthServer.AddCheck("shards", s.handler.controller.checkOwnedShardsLoaded)
+	s.healthServer.AddCheck("persistence", health.PersistenceCheck(s.GetMetadataManager()))
+	s.healthServer.Start()
 
 	listener := s.GetGRPCListener()
 	logger.Info("Starting to serve on history listener")
@@ -440,6 +484,7 @@ func (s *Service) Stop() {
 		return
 	}
 
+	s.healthServer.Stop()
 	s.server.GracefulStop()
 
 	s.handler.Stop()
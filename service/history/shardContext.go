@@ -21,6 +21,7 @@
 package history
 
 import (
+	"context"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -96,11 +97,13 @@ type (
 		GetDomainNotificationVersion() int64
 		UpdateDomainNotificationVersion(domainNotificationVersion int64) error
 
-		CreateWorkflowExecution(request *persistence.CreateWorkflowExecutionRequest) (*persistence.CreateWorkflowExecutionResponse, error)
-		UpdateWorkflowExecution(request *persistence.UpdateWorkflowExecutionRequest) (*persistence.UpdateWorkflowExecutionResponse, error)
-		ConflictResolveWorkflowExecution(request *persistence.ConflictResolveWorkflowExecutionRequest) error
-		ResetWorkflowExecution(request *persistence.ResetWorkflowExecutionRequest) error
-		AppendHistoryV2Events(request *persistence.AppendHistoryNodesRequest, domainID string, execution shared.WorkflowExecution) (int, error)
+		GetOpenWorkflowCount(domainID string) int
+
+		CreateWorkflowExecution(ctx context.Context, request *persistence.CreateWorkflowExecutionRequest) (*persistence.CreateWorkflowExecutionResponse, error)
+		UpdateWorkflowExecution(ctx context.Context, request *persistence.UpdateWorkflowExecutionRequest) (*persistence.UpdateWorkflowExecutionResponse, error)
+		ConflictResolveWorkflowExecution(ctx context.Context, request *persistence.ConflictResolveWorkflowExecutionRequest) error
+		ResetWorkflowExecution(ctx context.Context, request *persistence.ResetWorkflowExecutionRequest) error
+		AppendHistoryV2Events(ctx context.Context, request *persistence.AppendHistoryNodesRequest, domainID string, execution shared.WorkflowExecution) (int, error)
 	}
 
 	shardContextImpl struct {
@@ -378,6 +381,13 @@ func (s *shardContextImpl) UpdateDomainNotificationVersion(domainNotificationVer
 	return s.updateShardInfoLocked()
 }
 
+func (s *shardContextImpl) GetOpenWorkflowCount(domainID string) int {
+	s.RLock()
+	defer s.RUnlock()
+
+	return int(s.shardInfo.OpenWorkflowCounts[domainID])
+}
+
 func (s *shardContextImpl) GetTimerMaxReadLevel(cluster string) time.Time {
 	s.RLock()
 	defer s.RUnlock()
@@ -399,6 +409,7 @@ func (s *shardContextImpl) UpdateTimerMaxReadLevel(cluster string) time.Time {
 }
 
 func (s *shardContextImpl) CreateWorkflowExecution(
+	ctx context.Context,
 	request *persistence.CreateWorkflowExecutionRequest,
 ) (*persistence.CreateWorkflowExecutionResponse, error) {
 
@@ -432,8 +443,10 @@ Create_Loop:
 		currentRangeID := s.getRangeID()
 		request.RangeID = currentRangeID
 
-		response, err := s.executionManager.CreateWorkflowExecution(request)
-		if err != nil {
+		response, err := persistence.ExecutionManagerWithContext(s.executionManager, ctx).CreateWorkflowExecution(request)
+		if err == nil {
+			s.countOpenedWorkflowLocked(&request.NewWorkflowSnapshot)
+		} else {
 			switch err.(type) {
 			case *shared.WorkflowExecutionAlreadyStartedError,
 				*persistence.WorkflowExecutionAlreadyStartedError,
@@ -475,11 +488,53 @@ Create_Loop:
 	return nil, ErrMaxAttemptsExceeded
 }
 
+// countOpenedWorkflowLocked counts the run created by a successful write if it is open.
+// Zombie runs are open but not current, they are closed by replication like any other run.
+func (s *shardContextImpl) countOpenedWorkflowLocked(
+	newWorkflow *persistence.WorkflowSnapshot,
+) {
+
+	if newWorkflow == nil {
+		return
+	}
+	switch newWorkflow.ExecutionInfo.State {
+	case persistence.WorkflowStateCreated,
+		persistence.WorkflowStateRunning,
+		persistence.WorkflowStateZombie:
+		if s.shardInfo.OpenWorkflowCounts == nil {
+			s.shardInfo.OpenWorkflowCounts = make(map[string]int64)
+		}
+		s.shardInfo.OpenWorkflowCounts[newWorkflow.ExecutionInfo.DomainID]++
+	}
+}
+
+// countClosedWorkflowLocked counts the run closed by a successful write. The close execution
+// task is generated when the run is closed, so the tasks of the write tell if it closed the run.
+func (s *shardContextImpl) countClosedWorkflowLocked(
+	executionInfo *persistence.WorkflowExecutionInfo,
+	transferTasks []persistence.Task,
+) {
+
+	for _, task := range transferTasks {
+		if task.GetType() != persistence.TransferTaskTypeCloseExecution {
+			continue
+		}
+		domainID := executionInfo.DomainID
+		if s.shardInfo.OpenWorkflowCounts[domainID] > 1 {
+			s.shardInfo.OpenWorkflowCounts[domainID]--
+		} else {
+			delete(s.shardInfo.OpenWorkflowCounts, domainID)
+		}
+		return
+	}
+}
+
 func (s *shardContextImpl) getDefaultEncoding(domainEntry *cache.DomainCacheEntry) common.EncodingType {
 	return common.EncodingType(s.config.EventEncodingType(domainEntry.GetInfo().Name))
 }
 
 func (s *shardContextImpl) UpdateWorkflowExecution(
+	ctx context.Context,
 	request *persistence.UpdateWorkflowExecutionRequest,
 ) (*persistence.UpdateWorkflowExecutionResponse, error) {
 
@@ -525,8 +580,11 @@ Update_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
 		currentRangeID := s.getRangeID()
 		request.RangeID = currentRangeID
-		resp, err := s.executionManager.UpdateWorkflowExecution(request)
-		if err != nil {
+		resp, err := persistence.ExecutionManagerWithContext(s.executionManager, ctx).UpdateWorkflowExecution(request)
+		if err == nil {
+			s.countClosedWorkflowLocked(request.UpdateWorkflowMutation.ExecutionInfo, request.UpdateWorkflowMutation.TransferTasks)
+			s.countOpenedWorkflowLocked(request.NewWorkflowSnapshot)
+		} else {
 			switch err.(type) {
 			case *persistence.ConditionFailedError,
 				*shared.ServiceBusyError,
@@ -566,7 +624,10 @@ Update_Loop:
 	return nil, ErrMaxAttemptsExceeded
 }
 
-func (s *shardContextImpl) ResetWorkflowExecution(request *persistence.ResetWorkflowExecutionRequest) error {
+func (s *shardContextImpl) ResetWorkflowExecution(
+	ctx context.Context,
+	request *persistence.ResetWorkflowExecutionRequest,
+) error {
 
 	domainID := request.NewWorkflowSnapshot.ExecutionInfo.DomainID
 	workflowID := request.NewWorkflowSnapshot.ExecutionInfo.WorkflowID
@@ -610,8 +671,16 @@ Reset_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
 		currentRangeID := s.getRangeID()
 		request.RangeID = currentRangeID
-		err := s.executionManager.ResetWorkflowExecution(request)
-		if err != nil {
+		err := persistence.ExecutionManagerWithContext(s.executionManager, ctx).ResetWorkflowExecution(request)
+		if err == nil {
+			if request.CurrentWorkflowMutation != nil {
+				s.countClosedWorkflowLocked(
+					request.CurrentWorkflowMutation.ExecutionInfo,
+					request.CurrentWorkflowMutation.TransferTasks,
+				)
+			}
+			s.countOpenedWorkflowLocked(&request.NewWorkflowSnapshot)
+		} else {
 			switch err.(type) {
 			case *persistence.ConditionFailedError,
 				*shared.ServiceBusyError,
@@ -653,6 +722,7 @@ Reset_Loop:
 }
 
 func (s *shardContextImpl) ConflictResolveWorkflowExecution(
+	ctx context.Context,
 	request *persistence.ConflictResolveWorkflowExecutionRequest,
 ) error {
 
@@ -710,8 +780,21 @@ Reset_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
 		currentRangeID := s.getRangeID()
 		request.RangeID = currentRangeID
-		err := s.executionManager.ConflictResolveWorkflowExecution(request)
-		if err != nil {
+		err := persistence.ExecutionManagerWithContext(s.executionManager, ctx).ConflictResolveWorkflowExecution(request)
+		if err == nil {
+			// the reset run replaces an existing run which was counted when it was created
+			s.countClosedWorkflowLocked(
+				request.ResetWorkflowSnapshot.ExecutionInfo,
+				request.ResetWorkflowSnapshot.TransferTasks,
+			)
+			if request.CurrentWorkflowMutation != nil {
+				s.countClosedWorkflowLocked(
+					request.CurrentWorkflowMutation.ExecutionInfo,
+					request.CurrentWorkflowMutation.TransferTasks,
+				)
+			}
+			s.countOpenedWorkflowLocked(request.NewWorkflowSnapshot)
+		} else {
 			switch err.(type) {
 			case *persistence.ConditionFailedError,
 				*shared.ServiceBusyError,
@@ -752,7 +835,11 @@ Reset_Loop:
 }
 
 func (s *shardContextImpl) AppendHistoryV2Events(
-	request *persistence.AppendHistoryNodesRequest, domainID string, execution shared.WorkflowExecution) (int, error) {
+	ctx context.Context,
+	request *persistence.AppendHistoryNodesRequest,
+	domainID string,
+	execution shared.WorkflowExecution,
+) (int, error) {
 
 	domainEntry, err := s.GetDomainCache().GetDomainByID(domainID)
 	if err != nil {
@@ -786,7 +873,7 @@ func (s *shardContextImpl) AppendHistoryV2Events(
 				tag.WorkflowHistorySizeBytes(size))
 		}
 	}()
-	resp, err0 := s.GetHistoryManager().AppendHistoryNodes(request)
+	resp, err0 := persistence.HistoryManagerWithContext(s.GetHistoryManager(), ctx).AppendHistoryNodes(request)
 	if resp != nil {
 		size = resp.Size
 	}
@@ -1223,6 +1310,10 @@ func copyShardInfo(shardInfo *persistence.ShardInfo) *persistence.ShardInfo {
 	for k, v := range shardInfo.ClusterReplicationLevel {
 		clusterReplicationLevel[k] = v
 	}
+	openWorkflowCounts := make(map[string]int64)
+	for k, v := range shardInfo.OpenWorkflowCounts {
+		openWorkflowCounts[k] = v
+	}
 	shardInfoCopy := &persistence.ShardInfo{
 		ShardID:                   shardInfo.ShardID,
 		Owner:                     shardInfo.Owner,
@@ -1237,6 +1328,7 @@ func copyShardInfo(shardInfo *persistence.ShardInfo) *persistence.ShardInfo {
 		ClusterTimerAckLevel:      clusterTimerAckLevel,
 		DomainNotificationVersion: shardInfo.DomainNotificationVersion,
 		ClusterReplicationLevel:   clusterReplicationLevel,
+		OpenWorkflowCounts:        openWorkflowCounts,
 		UpdatedAt:                 shardInfo.UpdatedAt,
 	}
 
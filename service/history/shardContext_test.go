@@ -0,0 +1,218 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	shardContextSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		controller *gomock.Controller
+		mockShard  *shardContextTest
+
+		domainID string
+	}
+)
+
+func TestShardContextSuite(t *testing.T) {
+	s := new(shardContextSuite)
+	suite.Run(t, s)
+}
+
+func (s *shardContextSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.controller = gomock.NewController(s.T())
+	s.mockShard = newTestShardContext(
+		s.controller,
+		&persistence.ShardInfo{
+			ShardID:            0,
+			RangeID:            1,
+			TransferAckLevel:   0,
+			OpenWorkflowCounts: map[string]int64{},
+		},
+		NewDynamicConfigForTest(),
+	)
+
+	s.domainID = "shard-context-test-domain-id"
+	s.mockShard.resource.DomainCache.EXPECT().GetDomainByID(s.domainID).Return(cache.NewLocalDomainCacheEntryForTest(
+		&persistence.DomainInfo{ID: s.domainID, Name: "shard-context-test-domain"}, &persistence.DomainConfig{}, "", nil,
+	), nil).AnyTimes()
+}
+
+func (s *shardContextSuite) TearDownTest() {
+	s.controller.Finish()
+	s.mockShard.Finish(s.T())
+}
+
+func (s *shardContextSuite) TestCreateWorkflowExecution_CountsOpenWorkflow() {
+	s.mockShard.resource.ExecutionMgr.On("CreateWorkflowExecution", mock.Anything).Return(&persistence.CreateWorkflowExecutionResponse{}, nil)
+
+	_, err := s.mockShard.CreateWorkflowExecution(context.Background(), &persistence.CreateWorkflowExecutionRequest{
+		NewWorkflowSnapshot: *s.newSnapshot(persistence.WorkflowStateRunning),
+	})
+	s.NoError(err)
+	s.Equal(1, s.mockShard.GetOpenWorkflowCount(s.domainID))
+
+	_, err = s.mockShard.CreateWorkflowExecution(context.Background(), &persistence.CreateWorkflowExecutionRequest{
+		NewWorkflowSnapshot: *s.newSnapshot(persistence.WorkflowStateZombie),
+	})
+	s.NoError(err)
+	s.Equal(2, s.mockShard.GetOpenWorkflowCount(s.domainID))
+
+	_, err = s.mockShard.CreateWorkflowExecution(context.Background(), &persistence.CreateWorkflowExecutionRequest{
+		NewWorkflowSnapshot: *s.newSnapshot(persistence.WorkflowStateCompleted),
+	})
+	s.NoError(err)
+	s.Equal(2, s.mockShard.GetOpenWorkflowCount(s.domainID))
+}
+
+func (s *shardContextSuite) TestCreateWorkflowExecution_FailedWriteNotCounted() {
+	s.mockShard.resource.ExecutionMgr.On("CreateWorkflowExecution", mock.Anything).Return(nil, &persistence.WorkflowExecutionAlreadyStartedError{})
+
+	_, err := s.mockShard.CreateWorkflowExecution(context.Background(), &persistence.CreateWorkflowExecutionRequest{
+		NewWorkflowSnapshot: *s.newSnapshot(persistence.WorkflowStateRunning),
+	})
+	s.Error(err)
+	s.Equal(0, s.mockShard.GetOpenWorkflowCount(s.domainID))
+}
+
+func (s *shardContextSuite) TestUpdateWorkflowExecution_CountsClosedAndContinuedWorkflow() {
+	s.mockShard.shardInfo.OpenWorkflowCounts[s.domainID] = 2
+	s.mockShard.resource.ExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&persistence.UpdateWorkflowExecutionResponse{}, nil)
+
+	_, err := s.mockShard.UpdateWorkflowExecution(context.Background(), &persistence.UpdateWorkflowExecutionRequest{
+		UpdateWorkflowMutation: *s.newMutation(&persistence.DecisionTask{}),
+	})
+	s.NoError(err)
+	s.Equal(2, s.mockShard.GetOpenWorkflowCount(s.domainID))
+
+	_, err = s.mockShard.UpdateWorkflowExecution(context.Background(), &persistence.UpdateWorkflowExecutionRequest{
+		UpdateWorkflowMutation: *s.newMutation(&persistence.CloseExecutionTask{}),
+	})
+	s.NoError(err)
+	s.Equal(1, s.mockShard.GetOpenWorkflowCount(s.domainID))
+
+	// continue as new closes the current run and opens the new one
+	_, err = s.mockShard.UpdateWorkflowExecution(context.Background(), &persistence.UpdateWorkflowExecutionRequest{
+		UpdateWorkflowMutation: *s.newMutation(&persistence.CloseExecutionTask{}),
+		NewWorkflowSnapshot:    s.newSnapshot(persistence.WorkflowStateRunning),
+	})
+	s.NoError(err)
+	s.Equal(1, s.mockShard.GetOpenWorkflowCount(s.domainID))
+
+	_, err = s.mockShard.UpdateWorkflowExecution(context.Background(), &persistence.UpdateWorkflowExecutionRequest{
+		UpdateWorkflowMutation: *s.newMutation(&persistence.CloseExecutionTask{}),
+	})
+	s.NoError(err)
+	s.Equal(0, s.mockShard.GetOpenWorkflowCount(s.domainID))
+	s.NotContains(s.mockShard.shardInfo.OpenWorkflowCounts, s.domainID)
+}
+
+func (s *shardContextSuite) TestResetWorkflowExecution_CountsClosedAndResetWorkflow() {
+	s.mockShard.shardInfo.OpenWorkflowCounts[s.domainID] = 1
+	s.mockShard.resource.ExecutionMgr.On("ResetWorkflowExecution", mock.Anything).Return(nil)
+
+	err := s.mockShard.ResetWorkflowExecution(context.Background(), &persistence.ResetWorkflowExecutionRequest{
+		CurrentWorkflowMutation: s.newMutation(&persistence.CloseExecutionTask{}),
+		NewWorkflowSnapshot:     *s.newSnapshot(persistence.WorkflowStateRunning),
+	})
+	s.NoError(err)
+	s.Equal(1, s.mockShard.GetOpenWorkflowCount(s.domainID))
+
+	// the current run is already closed
+	err = s.mockShard.ResetWorkflowExecution(context.Background(), &persistence.ResetWorkflowExecutionRequest{
+		NewWorkflowSnapshot: *s.newSnapshot(persistence.WorkflowStateRunning),
+	})
+	s.NoError(err)
+	s.Equal(2, s.mockShard.GetOpenWorkflowCount(s.domainID))
+}
+
+func (s *shardContextSuite) TestConflictResolveWorkflowExecution_CountsClosedResetWorkflow() {
+	s.mockShard.shardInfo.OpenWorkflowCounts[s.domainID] = 2
+	s.mockShard.resource.ExecutionMgr.On("ConflictResolveWorkflowExecution", mock.Anything).Return(nil)
+
+	resetWorkflowSnapshot := s.newSnapshot(persistence.WorkflowStateCompleted)
+	resetWorkflowSnapshot.TransferTasks = []persistence.Task{&persistence.CloseExecutionTask{}}
+	err := s.mockShard.ConflictResolveWorkflowExecution(context.Background(), &persistence.ConflictResolveWorkflowExecutionRequest{
+		ResetWorkflowSnapshot: *resetWorkflowSnapshot,
+	})
+	s.NoError(err)
+	s.Equal(1, s.mockShard.GetOpenWorkflowCount(s.domainID))
+
+	err = s.mockShard.ConflictResolveWorkflowExecution(context.Background(), &persistence.ConflictResolveWorkflowExecutionRequest{
+		ResetWorkflowSnapshot:   *s.newSnapshot(persistence.WorkflowStateZombie),
+		CurrentWorkflowMutation: s.newMutation(&persistence.CloseExecutionTask{}),
+		NewWorkflowSnapshot:     s.newSnapshot(persistence.WorkflowStateRunning),
+	})
+	s.NoError(err)
+	s.Equal(1, s.mockShard.GetOpenWorkflowCount(s.domainID))
+}
+
+func (s *shardContextSuite) TestCopyShardInfo_CopiesOpenWorkflowCounts() {
+	s.mockShard.shardInfo.OpenWorkflowCounts[s.domainID] = 3
+
+	shardInfo := copyShardInfo(s.mockShard.shardInfo)
+	s.Equal(s.mockShard.shardInfo.OpenWorkflowCounts, shardInfo.OpenWorkflowCounts)
+
+	shardInfo.OpenWorkflowCounts[s.domainID] = 4
+	s.Equal(3, s.mockShard.GetOpenWorkflowCount(s.domainID))
+}
+
+func (s *shardContextSuite) newSnapshot(
+	state int,
+) *persistence.WorkflowSnapshot {
+
+	return &persistence.WorkflowSnapshot{
+		ExecutionInfo: &persistence.WorkflowExecutionInfo{
+			DomainID:   s.domainID,
+			WorkflowID: "shard-context-test-workflow-id",
+			State:      state,
+		},
+	}
+}
+
+func (s *shardContextSuite) newMutation(
+	transferTasks ...persistence.Task,
+) *persistence.WorkflowMutation {
+
+	return &persistence.WorkflowMutation{
+		ExecutionInfo: &persistence.WorkflowExecutionInfo{
+			DomainID:   s.domainID,
+			WorkflowID: "shard-context-test-workflow-id",
+			State:      persistence.WorkflowStateRunning,
+		},
+		TransferTasks: transferTasks,
+	}
+}
@@ -21,6 +21,7 @@
 package history
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -39,6 +40,8 @@ const (
 	shardControllerMembershipUpdateListenerName = "ShardController"
 )
 
+var errShardControllerNotStarted = errors.New("shard controller is not started")
+
 type (
 	shardController struct {
 		resource.Resource
@@ -377,6 +380,36 @@ func (c *shardController) shardIDs() []int32 {
 	return ids
 }
 
+// checkOwnedShardsLoaded returns an error when some of the shards owned by this host according to membership
+// are not loaded yet, which makes the host unable to serve the requests of those shards
+func (c *shardController) checkOwnedShardsLoaded() error {
+	if atomic.LoadInt32(&c.status) != common.DaemonStatusStarted {
+		return errShardControllerNotStarted
+	}
+
+	owned, notLoaded := 0, 0
+	for shardID := 0; shardID < c.config.NumberOfShards; shardID++ {
+		info, err := c.GetHistoryServiceResolver().Lookup(string(shardID))
+		if err != nil {
+			return err
+		}
+		if info.Identity() != c.GetHostInfo().Identity() {
+			continue
+		}
+		owned++
+		c.RLock()
+		item, ok := c.historyShards[shardID]
+		c.RUnlock()
+		if !ok || !item.isLoaded() {
+			notLoaded++
+		}
+	}
+	if notLoaded > 0 {
+		return fmt.Errorf("%v of %v owned shards are not loaded", notLoaded, owned)
+	}
+	return nil
+}
+
 func (i *historyShardsItem) getOrCreateEngine(shardClosedCh chan<- int) (Engine, error) {
 	i.RLock()
 	if i.status == historyShardsItemStatusStarted {
@@ -446,6 +479,13 @@ func (i *historyShardsItem) isValid() bool {
 	}
 }
 
+func (i *historyShardsItem) isLoaded() bool {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.status == historyShardsItemStatusStarted
+}
+
 func (i *historyShardsItem) logInvalidStatus() string {
 	msg := fmt.Sprintf("Host '%v' encounter invalid status %v for shard item for shardID '%v'.",
 		i.GetHostInfo().Identity(), i.status, i.shardID)
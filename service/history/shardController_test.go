@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
@@ -130,6 +132,7 @@ func (s *shardControllerSuite) TestAcquireShardSuccess() {
 							cluster.TestAlternativeClusterName: alternativeClusterTimerAck,
 						},
 						ClusterReplicationLevel: map[string]int64{},
+						OpenWorkflowCounts:      map[string]int64{},
 					},
 				}, nil).Once()
 			s.mockShardManager.On("UpdateShard", &persistence.UpdateShardRequest{
@@ -152,6 +155,7 @@ func (s *shardControllerSuite) TestAcquireShardSuccess() {
 					TransferFailoverLevels:  map[string]persistence.TransferFailoverLevel{},
 					TimerFailoverLevels:     map[string]persistence.TimerFailoverLevel{},
 					ClusterReplicationLevel: map[string]int64{},
+					OpenWorkflowCounts:      map[string]int64{},
 				},
 				PreviousRangeID: 5,
 			}).Return(nil).Once()
@@ -212,6 +216,7 @@ func (s *shardControllerSuite) TestAcquireShardsConcurrently() {
 							cluster.TestAlternativeClusterName: alternativeClusterTimerAck,
 						},
 						ClusterReplicationLevel: map[string]int64{},
+						OpenWorkflowCounts:      map[string]int64{},
 					},
 				}, nil).Once()
 			s.mockShardManager.On("UpdateShard", &persistence.UpdateShardRequest{
@@ -234,6 +239,7 @@ func (s *shardControllerSuite) TestAcquireShardsConcurrently() {
 					TransferFailoverLevels:  map[string]persistence.TransferFailoverLevel{},
 					TimerFailoverLevels:     map[string]persistence.TimerFailoverLevel{},
 					ClusterReplicationLevel: map[string]int64{},
+					OpenWorkflowCounts:      map[string]int64{},
 				},
 				PreviousRangeID: 5,
 			}).Return(nil).Once()
@@ -301,6 +307,7 @@ func (s *shardControllerSuite) TestAcquireShardRenewSuccess() {
 						cluster.TestAlternativeClusterName: alternativeClusterTimerAck,
 					},
 					ClusterReplicationLevel: map[string]int64{},
+					OpenWorkflowCounts:      map[string]int64{},
 				},
 			}, nil).Once()
 		s.mockShardManager.On("UpdateShard", &persistence.UpdateShardRequest{
@@ -323,6 +330,7 @@ func (s *shardControllerSuite) TestAcquireShardRenewSuccess() {
 				TransferFailoverLevels:  map[string]persistence.TransferFailoverLevel{},
 				TimerFailoverLevels:     map[string]persistence.TimerFailoverLevel{},
 				ClusterReplicationLevel: map[string]int64{},
+				OpenWorkflowCounts:      map[string]int64{},
 			},
 			PreviousRangeID: 5,
 		}).Return(nil).Once()
@@ -375,6 +383,7 @@ func (s *shardControllerSuite) TestAcquireShardRenewLookupFailed() {
 						cluster.TestAlternativeClusterName: alternativeClusterTimerAck,
 					},
 					ClusterReplicationLevel: map[string]int64{},
+					OpenWorkflowCounts:      map[string]int64{},
 				},
 			}, nil).Once()
 		s.mockShardManager.On("UpdateShard", &persistence.UpdateShardRequest{
@@ -397,6 +406,7 @@ func (s *shardControllerSuite) TestAcquireShardRenewLookupFailed() {
 				TransferFailoverLevels:  map[string]persistence.TransferFailoverLevel{},
 				TimerFailoverLevels:     map[string]persistence.TimerFailoverLevel{},
 				ClusterReplicationLevel: map[string]int64{},
+				OpenWorkflowCounts:      map[string]int64{},
 			},
 			PreviousRangeID: 5,
 		}).Return(nil).Once()
@@ -627,6 +637,43 @@ func (s *shardControllerSuite) TestShardControllerClosed() {
 	workerWG.Wait()
 }
 
+func (s *shardControllerSuite) TestCheckOwnedShardsLoaded() {
+	numShards := 4
+	s.config.NumberOfShards = numShards
+	otherHost := membership.NewHostInfo("test-check-owned-shards-host", nil)
+	for shardID := 0; shardID < numShards; shardID++ {
+		owner := s.hostInfo
+		if shardID%2 == 1 {
+			owner = otherHost
+		}
+		s.mockServiceResolver.EXPECT().Lookup(string(shardID)).Return(owner, nil).AnyTimes()
+	}
+
+	s.Equal(errShardControllerNotStarted, s.shardController.checkOwnedShardsLoaded())
+
+	atomic.StoreInt32(&s.shardController.status, common.DaemonStatusStarted)
+	s.EqualError(s.shardController.checkOwnedShardsLoaded(), "2 of 2 owned shards are not loaded")
+
+	s.shardController.historyShards[0] = &historyShardsItem{shardID: 0, status: historyShardsItemStatusStarted}
+	s.shardController.historyShards[2] = &historyShardsItem{shardID: 2, status: historyShardsItemStatusInitialized}
+	s.EqualError(s.shardController.checkOwnedShardsLoaded(), "1 of 2 owned shards are not loaded")
+
+	// shards 1 and 3 are owned by the other host and do not need to be loaded
+	s.shardController.historyShards[2].status = historyShardsItemStatusStarted
+	s.NoError(s.shardController.checkOwnedShardsLoaded())
+
+	s.shardController.historyShards[0].status = historyShardsItemStatusStopped
+	s.EqualError(s.shardController.checkOwnedShardsLoaded(), "1 of 2 owned shards are not loaded")
+}
+
+func (s *shardControllerSuite) TestCheckOwnedShardsLoaded_LookupFailure() {
+	s.config.NumberOfShards = 2
+	atomic.StoreInt32(&s.shardController.status, common.DaemonStatusStarted)
+	s.mockServiceResolver.EXPECT().Lookup(string(0)).Return(nil, errors.New("ring is not ready")).Times(1)
+
+	s.EqualError(s.shardController.checkOwnedShardsLoaded(), "ring is not ready")
+}
+
 func (s *shardControllerSuite) setupMocksForAcquireShard(shardID int, mockEngine *MockEngine, currentRangeID,
 	newRangeID int64) {
 
@@ -658,6 +705,7 @@ func (s *shardControllerSuite) setupMocksForAcquireShard(shardID int, mockEngine
 					cluster.TestAlternativeClusterName: alternativeClusterTimerAck,
 				},
 				ClusterReplicationLevel: map[string]int64{},
+				OpenWorkflowCounts:      map[string]int64{},
 			},
 		}, nil).Once()
 	s.mockShardManager.On("UpdateShard", &persistence.UpdateShardRequest{
@@ -680,6 +728,7 @@ func (s *shardControllerSuite) setupMocksForAcquireShard(shardID int, mockEngine
 			TransferFailoverLevels:  map[string]persistence.TransferFailoverLevel{},
 			TimerFailoverLevels:     map[string]persistence.TimerFailoverLevel{},
 			ClusterReplicationLevel: map[string]int64{},
+			OpenWorkflowCounts:      map[string]int64{},
 		},
 		PreviousRangeID: currentRangeID,
 	}).Return(nil).Once()
@@ -21,9 +21,12 @@
 package history
 
 import (
+	"context"
 	"sync"
 	"time"
 
+	"github.com/opentracing/opentracing-go"
+
 	workflow "github.com/temporalio/temporal/.gen/go/shared"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/backoff"
@@ -33,17 +36,25 @@ import (
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/tracing"
+)
+
+const (
+	taskProcessingSpanName = "history.processTask"
 )
 
 type (
 	taskProcessorOptions struct {
-		queueSize   int
-		workerCount int
+		queueSize    int
+		workerCount  int
+		metricsScope int
 	}
 
 	taskInfo struct {
 		processor taskExecutor
 		task      queueTaskInfo
+		// ctx carries the span of the current processing attempt
+		ctx context.Context
 
 		attempt   int
 		startTime time.Time
@@ -58,7 +69,7 @@ type (
 		shard         ShardContext
 		cache         *historyCache
 		shutdownCh    chan struct{}
-		tasksCh       chan *taskInfo
+		scheduler     *taskScheduler
 		config        *Config
 		logger        log.Logger
 		metricsClient metrics.Client
@@ -81,6 +92,7 @@ func newTaskInfo(
 	return &taskInfo{
 		processor:         processor,
 		task:              task,
+		ctx:               context.Background(),
 		attempt:           0,
 		startTime:         time.Now(), // used for metrics
 		logger:            logger,
@@ -102,12 +114,33 @@ func newTaskProcessor(
 		workerNotificationChans = append(workerNotificationChans, make(chan struct{}, 1))
 	}
 
+	config := shard.GetConfig()
+	domainCache := shard.GetDomainCache()
+	scheduler := newTaskScheduler(
+		taskSchedulerOptions{
+			queueSize:         options.queueSize,
+			metricsScope:      options.metricsScope,
+			domainWeight:      config.TaskSchedulerDomainWeight,
+			domainMaxInflight: config.TaskSchedulerDomainMaxInflight,
+		},
+		func(domainID string) string {
+			domainName, err := domainCache.GetDomainName(domainID)
+			if err != nil {
+				// fall back to default weight and limit of unknown domains
+				return ""
+			}
+			return domainName
+		},
+		shard.GetMetricsClient(),
+		shard.GetTimeSource(),
+	)
+
 	base := &taskProcessor{
 		shard:                   shard,
 		cache:                   historyCache,
 		shutdownCh:              make(chan struct{}),
-		tasksCh:                 make(chan *taskInfo, options.queueSize),
-		config:                  shard.GetConfig(),
+		scheduler:               scheduler,
+		config:                  config,
 		logger:                  log,
 		metricsClient:           shard.GetMetricsClient(),
 		timeSource:              shard.GetTimeSource(),
@@ -130,6 +163,7 @@ func (t *taskProcessor) start() {
 
 func (t *taskProcessor) stop() {
 	close(t.shutdownCh)
+	t.scheduler.stop()
 	if success := common.AwaitWaitGroup(&t.workerWG, time.Minute); !success {
 		t.logger.Warn("Timer queue task processor timedout on shutdown.")
 	}
@@ -142,15 +176,12 @@ func (t *taskProcessor) taskWorker(
 	defer t.workerWG.Done()
 
 	for {
-		select {
-		case <-t.shutdownCh:
+		task, ok := t.scheduler.dequeue()
+		if !ok {
 			return
-		case task, ok := <-t.tasksCh:
-			if !ok {
-				return
-			}
-			t.processTaskAndAck(notificationChan, task)
 		}
+		t.processTaskAndAck(notificationChan, task)
+		t.scheduler.done(task)
 	}
 }
 
@@ -167,12 +198,7 @@ func (t *taskProcessor) addTask(
 	task *taskInfo,
 ) bool {
 	// We have a timer to fire.
-	select {
-	case t.tasksCh <- task:
-	case <-t.shutdownCh:
-		return true
-	}
-	return false
+	return t.scheduler.enqueue(task)
 }
 
 func (t *taskProcessor) processTaskAndAck(
@@ -203,6 +229,9 @@ FilterLoop:
 		err := t.handleTaskError(scope, task, notificationChan, err)
 		if err != nil {
 			task.attempt++
+			if t.shouldMoveTaskToDLQ(task, err) {
+				return t.moveTaskToDLQ(scope, task, err)
+			}
 			if task.attempt >= t.config.TimerTaskMaxRetryCount() {
 				t.metricsClient.RecordTimer(scope, metrics.TaskAttemptTimer, time.Duration(task.attempt))
 				task.logger.Error("Critical error processing timer task, retrying.", tag.Error(err), tag.OperationCritical)
@@ -244,8 +273,20 @@ func (t *taskProcessor) processTaskOnce(
 	default:
 	}
 
+	span := opentracing.StartSpan(
+		taskProcessingSpanName,
+		opentracing.Tag{Key: "task-id", Value: task.task.GetTaskID()},
+		opentracing.Tag{Key: "task-type", Value: task.task.GetTaskType()},
+		opentracing.Tag{Key: "domain-id", Value: task.task.GetDomainID()},
+		opentracing.Tag{Key: "workflow-id", Value: task.task.GetWorkflowID()},
+		opentracing.Tag{Key: "run-id", Value: task.task.GetRunID()},
+		opentracing.Tag{Key: "attempt", Value: task.attempt},
+	)
+	task.ctx = opentracing.ContextWithSpan(context.Background(), span)
 	startTime := t.timeSource.Now()
 	scope, err := task.processor.process(task)
+	tracing.SetError(span, err)
+	span.Finish()
 	if task.shouldProcessTask {
 		t.metricsClient.IncCounter(scope, metrics.TaskRequests)
 		t.metricsClient.RecordTimer(scope, metrics.TaskProcessingLatency, time.Since(startTime))
@@ -304,6 +345,91 @@ func (t *taskProcessor) handleTaskError(
 	return err
 }
 
+func (t *taskProcessor) shouldMoveTaskToDLQ(
+	task *taskInfo,
+	err error,
+) bool {
+
+	maxAttempts := t.config.TaskDLQMaxAttempts()
+	if maxAttempts <= 0 || task.attempt < maxAttempts {
+		return false
+	}
+
+	// transient errors are never moved to DLQ
+	if err == ErrTaskRetry || common.IsPersistenceTransientError(err) {
+		return false
+	}
+	switch err.(type) {
+	case *workflow.DomainNotActiveError,
+		*workflow.ServiceBusyError,
+		*persistence.ShardOwnershipLostError:
+		return false
+	}
+	return true
+}
+
+// moveTaskToDLQ persists the task into the DLQ of the shard,
+// a nil return value means the task can be acked
+func (t *taskProcessor) moveTaskToDLQ(
+	scope int,
+	task *taskInfo,
+	taskErr error,
+) error {
+
+	var err error
+	executionManager := t.shard.GetExecutionManager()
+	switch info := task.task.(type) {
+	case *persistence.TransferTaskInfo:
+		err = executionManager.PutTransferTaskToDLQ(&persistence.PutTransferTaskToDLQRequest{TaskInfo: info})
+	case *persistence.TimerTaskInfo:
+		err = executionManager.PutTimerTaskToDLQ(&persistence.PutTimerTaskToDLQRequest{TaskInfo: info})
+	default:
+		// only transfer and timer tasks have a DLQ
+		return taskErr
+	}
+	if err != nil {
+		t.metricsClient.IncCounter(scope, metrics.TaskDLQFailures)
+		task.logger.Error("Fail to move task to DLQ.", tag.Error(err))
+		return taskErr
+	}
+
+	t.metricsClient.IncCounter(scope, metrics.TaskDLQCounter)
+	task.logger.Error(
+		"Task moved to DLQ after too many attempts.",
+		tag.Error(taskErr),
+		tag.Attempt(int32(task.attempt)),
+		tag.OperationCritical,
+	)
+	return nil
+}
+
+// processDLQTask processes a task from the DLQ of the shard once with the first executor accepting it,
+// a task accepted by none of the executors needs no processing
+func processDLQTask(
+	task queueTaskInfo,
+	logger log.Logger,
+	executors ...taskExecutor,
+) error {
+
+	for _, executor := range executors {
+		info := newTaskInfo(executor, task, logger)
+		shouldProcessTask, err := executor.getTaskFilter()(info)
+		if err != nil {
+			return err
+		}
+		if !shouldProcessTask {
+			continue
+		}
+
+		_, err = executor.process(info)
+		if _, ok := err.(*workflow.EntityNotExistsError); ok || err == ErrTaskDiscarded {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
 func (t *taskProcessor) ackTaskOnce(
 	scope int,
 	task *taskInfo,
@@ -26,6 +26,9 @@ import (
 	"time"
 
 	gomock "github.com/golang/mock/gomock"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
@@ -34,6 +37,7 @@ import (
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
 type (
@@ -184,6 +188,88 @@ func (s *taskProcessorSuite) TestProcessTaskAndAck_DomainTrue_ProcessErrNoErr()
 	)
 }
 
+func (s *taskProcessorSuite) TestProcessTaskOnce_TraceContext() {
+	globalTracer := opentracing.GlobalTracer()
+	defer opentracing.SetGlobalTracer(globalTracer)
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+
+	task := newTaskInfo(s.mockProcessor, &persistence.TimerTaskInfo{TaskID: 12345, VisibilityTimestamp: time.Now()}, s.logger)
+	var span opentracing.Span
+	s.mockProcessor.On("process", task).Return(s.scope, nil).Run(func(args mock.Arguments) {
+		span = opentracing.SpanFromContext(args.Get(0).(*taskInfo).ctx)
+	}).Once()
+
+	_, err := s.taskProcessor.processTaskOnce(s.notificationChan, task)
+	s.NoError(err)
+	s.NotNil(span)
+	s.Len(tracer.FinishedSpans(), 1)
+	s.Equal(taskProcessingSpanName, tracer.FinishedSpans()[0].OperationName)
+}
+
+func (s *taskProcessorSuite) TestProcessTaskAndAck_MoveTransferTaskToDLQ() {
+	err := errors.New("some random err")
+	s.mockShard.GetConfig().TaskDLQMaxAttempts = dynamicconfig.GetIntPropertyFn(2)
+	transferTask := &persistence.TransferTaskInfo{TaskID: 12345, VisibilityTimestamp: time.Now()}
+	task := newTaskInfo(s.mockProcessor, transferTask, s.logger)
+	var taskFilter taskFilter = func(task *taskInfo) (bool, error) {
+		return true, nil
+	}
+	s.mockProcessor.On("getTaskFilter").Return(taskFilter).Once()
+	s.mockProcessor.On("process", task).Return(s.scope, err).Twice()
+	s.mockShard.resource.ExecutionMgr.On("PutTransferTaskToDLQ", &persistence.PutTransferTaskToDLQRequest{
+		TaskInfo: transferTask,
+	}).Return(nil).Once()
+	s.mockProcessor.On("complete", task).Once()
+	s.taskProcessor.processTaskAndAck(
+		s.notificationChan,
+		task,
+	)
+	s.Equal(2, task.attempt)
+}
+
+func (s *taskProcessorSuite) TestProcessTaskAndAck_MoveTimerTaskToDLQ_PersistenceErr() {
+	err := errors.New("some random err")
+	s.mockShard.GetConfig().TaskDLQMaxAttempts = dynamicconfig.GetIntPropertyFn(1)
+	timerTask := &persistence.TimerTaskInfo{TaskID: 12345, VisibilityTimestamp: time.Now()}
+	task := newTaskInfo(s.mockProcessor, timerTask, s.logger)
+	var taskFilter taskFilter = func(task *taskInfo) (bool, error) {
+		return true, nil
+	}
+	s.mockProcessor.On("getTaskFilter").Return(taskFilter).Once()
+	s.mockProcessor.On("process", task).Return(s.scope, err).Twice()
+	s.mockShard.resource.ExecutionMgr.On("PutTimerTaskToDLQ", &persistence.PutTimerTaskToDLQRequest{
+		TaskInfo: timerTask,
+	}).Return(errors.New("some persistence err")).Once()
+	s.mockShard.resource.ExecutionMgr.On("PutTimerTaskToDLQ", &persistence.PutTimerTaskToDLQRequest{
+		TaskInfo: timerTask,
+	}).Return(nil).Once()
+	s.mockProcessor.On("complete", task).Once()
+	s.taskProcessor.processTaskAndAck(
+		s.notificationChan,
+		task,
+	)
+}
+
+func (s *taskProcessorSuite) TestShouldMoveTaskToDLQ() {
+	err := errors.New("some random err")
+	taskInfo := newTaskInfo(s.mockProcessor, nil, s.logger)
+	taskInfo.attempt = 10
+
+	s.False(s.taskProcessor.shouldMoveTaskToDLQ(taskInfo, err))
+
+	s.mockShard.GetConfig().TaskDLQMaxAttempts = dynamicconfig.GetIntPropertyFn(10)
+	s.True(s.taskProcessor.shouldMoveTaskToDLQ(taskInfo, err))
+	s.False(s.taskProcessor.shouldMoveTaskToDLQ(taskInfo, ErrTaskRetry))
+	s.False(s.taskProcessor.shouldMoveTaskToDLQ(taskInfo, &workflow.DomainNotActiveError{}))
+	s.False(s.taskProcessor.shouldMoveTaskToDLQ(taskInfo, &workflow.InternalServiceError{}))
+	s.False(s.taskProcessor.shouldMoveTaskToDLQ(taskInfo, &workflow.ServiceBusyError{}))
+	s.False(s.taskProcessor.shouldMoveTaskToDLQ(taskInfo, &persistence.ShardOwnershipLostError{}))
+
+	taskInfo.attempt = 9
+	s.False(s.taskProcessor.shouldMoveTaskToDLQ(taskInfo, err))
+}
+
 func (s *taskProcessorSuite) TestHandleTaskError_EntityNotExists() {
 	err := &workflow.EntityNotExistsError{}
 
@@ -0,0 +1,333 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/temporalio/temporal/common/clock"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	taskSchedulerOptions struct {
+		queueSize         int
+		metricsScope      int
+		domainWeight      dynamicconfig.IntPropertyFnWithDomainFilter
+		domainMaxInflight dynamicconfig.IntPropertyFnWithDomainFilter
+	}
+
+	// taskScheduler sits between the queue processors and the task workers,
+	// it dispatches pending tasks across domains using weighted round robin,
+	// so a single busy domain cannot starve the rest of the shard. Tasks of a
+	// domain at its in-flight limit are parked in the queue of the domain and
+	// don't count towards the queue size, so they don't block the queue processor
+	// from reading the tasks of other domains. A parked domain holds at most
+	// queue size tasks, beyond that enqueue blocks until the domain is unparked,
+	// so a hot domain cannot make the queue processor read its tasks without bound
+	taskScheduler struct {
+		sync.Mutex
+		notEmpty *sync.Cond
+		notFull  *sync.Cond
+
+		options       taskSchedulerOptions
+		domainNameFn  func(domainID string) string
+		metricsClient metrics.Client
+		timeSource    clock.TimeSource
+
+		size     int // number of tasks in the queues of domains that are not parked
+		stopped  bool
+		domains  map[string]*list.Element
+		ring     *list.List
+		position *list.Element
+	}
+
+	domainTaskQueue struct {
+		domainID   string
+		domainName string
+		// remaining number of tasks this domain can dispatch in current round
+		credits  int
+		inflight int
+		size     int
+		// the domain is at its in-flight limit
+		parked bool
+
+		// tasks of the same domain are round robin across task types
+		taskTypes     []int
+		taskTypeIndex int
+		tasks         map[int]*list.List
+	}
+
+	scheduledTask struct {
+		task        *taskInfo
+		enqueueTime time.Time
+	}
+)
+
+func newTaskScheduler(
+	options taskSchedulerOptions,
+	domainNameFn func(domainID string) string,
+	metricsClient metrics.Client,
+	timeSource clock.TimeSource,
+) *taskScheduler {
+
+	s := &taskScheduler{
+		options:       options,
+		domainNameFn:  domainNameFn,
+		metricsClient: metricsClient,
+		timeSource:    timeSource,
+		domains:       make(map[string]*list.Element),
+		ring:          list.New(),
+	}
+	s.notEmpty = sync.NewCond(s)
+	s.notFull = sync.NewCond(s)
+	return s
+}
+
+// enqueue adds the task to the queue of its domain, blocking while the scheduler is full,
+// or while the queue of the domain is full if the domain is parked,
+// return true if the scheduler is stopped
+func (s *taskScheduler) enqueue(
+	task *taskInfo,
+) bool {
+
+	domainID := task.task.GetDomainID()
+	// resolve the name outside of the lock, domain cache may need to go to persistence
+	domainName := s.domainNameFn(domainID)
+
+	s.Lock()
+	defer s.Unlock()
+
+	var queue *domainTaskQueue
+	for {
+		if s.stopped {
+			return true
+		}
+		// the queue may be removed while waiting, if it becomes idle
+		queue = s.getOrCreateQueueLocked(domainID, domainName)
+		s.updateParkedLocked(queue)
+		if queue.parked && queue.size < s.options.queueSize {
+			break
+		}
+		if !queue.parked && s.size < s.options.queueSize {
+			break
+		}
+		s.notFull.Wait()
+	}
+
+	queue.push(task, s.timeSource.Now())
+	if !queue.parked {
+		s.size++
+		s.notEmpty.Signal()
+	}
+	return false
+}
+
+// dequeue blocks until a task can be dispatched to a worker,
+// return false if the scheduler is stopped
+func (s *taskScheduler) dequeue() (*taskInfo, bool) {
+
+	s.Lock()
+	var next *scheduledTask
+	var domainName string
+	for next == nil {
+		if s.stopped {
+			s.Unlock()
+			return nil, false
+		}
+		next, domainName = s.nextLocked()
+		if next == nil {
+			s.notEmpty.Wait()
+		}
+	}
+	// waiters of parked and of other domains wait on different conditions
+	s.notFull.Broadcast()
+	s.Unlock()
+
+	s.metricsClient.Scope(
+		s.options.metricsScope,
+		metrics.DomainTag(domainName),
+	).RecordTimer(metrics.TaskSchedulerQueueLatency, s.timeSource.Now().Sub(next.enqueueTime))
+	return next.task, true
+}
+
+// done must be called once a task returned by dequeue is no longer being processed
+func (s *taskScheduler) done(
+	task *taskInfo,
+) {
+
+	s.Lock()
+	defer s.Unlock()
+
+	element, ok := s.domains[task.task.GetDomainID()]
+	if !ok {
+		return
+	}
+	queue := element.Value.(*domainTaskQueue)
+	queue.inflight--
+	s.updateParkedLocked(queue)
+	s.removeIfIdleLocked(element)
+	// the domain may have been blocked by its in-flight limit
+	s.notEmpty.Signal()
+}
+
+func (s *taskScheduler) stop() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.stopped = true
+	s.notEmpty.Broadcast()
+	s.notFull.Broadcast()
+}
+
+func (s *taskScheduler) nextLocked() (*scheduledTask, string) {
+
+	for i := s.ring.Len(); i > 0; i-- {
+		if s.position == nil {
+			s.position = s.ring.Front()
+		}
+		element := s.position
+		queue := element.Value.(*domainTaskQueue)
+		if queue.credits <= 0 {
+			// start of a new round for this domain
+			queue.credits = s.weight(queue.domainName)
+		}
+
+		s.updateParkedLocked(queue)
+		if queue.size > 0 && !queue.parked {
+			next := queue.pop()
+			s.size--
+			queue.inflight++
+			s.updateParkedLocked(queue)
+			queue.credits--
+			if queue.credits <= 0 || queue.size == 0 {
+				queue.credits = 0
+				s.position = element.Next()
+			}
+			return next, queue.domainName
+		}
+
+		queue.credits = 0
+		s.position = element.Next()
+		s.removeIfIdleLocked(element)
+	}
+	return nil, ""
+}
+
+func (s *taskScheduler) getOrCreateQueueLocked(
+	domainID string,
+	domainName string,
+) *domainTaskQueue {
+
+	element, ok := s.domains[domainID]
+	if !ok {
+		element = s.ring.PushBack(&domainTaskQueue{
+			domainID:   domainID,
+			domainName: domainName,
+			tasks:      make(map[int]*list.List),
+		})
+		s.domains[domainID] = element
+	}
+	return element.Value.(*domainTaskQueue)
+}
+
+// updateParkedLocked parks the queue of a domain at its in-flight limit, tasks of a
+// parked domain are not counted towards the queue size until the domain is unparked
+func (s *taskScheduler) updateParkedLocked(
+	queue *domainTaskQueue,
+) {
+
+	maxInflight := s.options.domainMaxInflight(queue.domainName)
+	parked := maxInflight > 0 && queue.inflight >= maxInflight
+	if parked == queue.parked {
+		return
+	}
+	queue.parked = parked
+	if parked {
+		s.size -= queue.size
+		s.notFull.Broadcast()
+	} else {
+		s.size += queue.size
+	}
+}
+
+func (s *taskScheduler) weight(
+	domainName string,
+) int {
+
+	weight := s.options.domainWeight(domainName)
+	if weight <= 0 {
+		return 1
+	}
+	return weight
+}
+
+func (s *taskScheduler) removeIfIdleLocked(
+	element *list.Element,
+) {
+
+	queue := element.Value.(*domainTaskQueue)
+	if queue.size > 0 || queue.inflight > 0 {
+		return
+	}
+	if s.position == element {
+		s.position = element.Next()
+	}
+	s.ring.Remove(element)
+	delete(s.domains, queue.domainID)
+}
+
+func (q *domainTaskQueue) push(
+	task *taskInfo,
+	now time.Time,
+) {
+
+	taskType := task.task.GetTaskType()
+	tasks, ok := q.tasks[taskType]
+	if !ok {
+		tasks = list.New()
+		q.tasks[taskType] = tasks
+		q.taskTypes = append(q.taskTypes, taskType)
+	}
+	tasks.PushBack(&scheduledTask{
+		task:        task,
+		enqueueTime: now,
+	})
+	q.size++
+}
+
+func (q *domainTaskQueue) pop() *scheduledTask {
+
+	for i := 0; i < len(q.taskTypes); i++ {
+		index := (q.taskTypeIndex + i) % len(q.taskTypes)
+		tasks := q.tasks[q.taskTypes[index]]
+		if tasks.Len() == 0 {
+			continue
+		}
+		q.taskTypeIndex = (index + 1) % len(q.taskTypes)
+		q.size--
+		return tasks.Remove(tasks.Front()).(*scheduledTask)
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+
+	"github.com/temporalio/temporal/common/clock"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	taskSchedulerSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		weights     map[string]int
+		maxInflight map[string]int
+		scheduler   *taskScheduler
+	}
+)
+
+func TestTaskSchedulerSuite(t *testing.T) {
+	s := new(taskSchedulerSuite)
+	suite.Run(t, s)
+}
+
+func (s *taskSchedulerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.weights = make(map[string]int)
+	s.maxInflight = make(map[string]int)
+	s.scheduler = newTaskScheduler(
+		taskSchedulerOptions{
+			queueSize:    10,
+			metricsScope: metrics.TransferActiveQueueProcessorScope,
+			domainWeight: func(domain string) int {
+				return s.weights[domain]
+			},
+			domainMaxInflight: func(domain string) int {
+				return s.maxInflight[domain]
+			},
+		},
+		func(domainID string) string {
+			return domainID + "-name"
+		},
+		metrics.NewClient(tally.NoopScope, metrics.History),
+		clock.NewRealTimeSource(),
+	)
+}
+
+func (s *taskSchedulerSuite) TearDownTest() {
+	s.scheduler.stop()
+}
+
+func (s *taskSchedulerSuite) TestDequeue_WeightedRoundRobin() {
+	s.weights["domain-a-name"] = 3
+
+	for i := 0; i < 4; i++ {
+		s.False(s.scheduler.enqueue(s.newTask("domain-a", 0)))
+	}
+	for i := 0; i < 4; i++ {
+		s.False(s.scheduler.enqueue(s.newTask("domain-b", 0)))
+	}
+
+	expected := []string{"domain-a", "domain-a", "domain-a", "domain-b", "domain-a", "domain-b", "domain-b", "domain-b"}
+	for _, domainID := range expected {
+		task, ok := s.scheduler.dequeue()
+		s.True(ok)
+		s.Equal(domainID, task.task.GetDomainID())
+	}
+}
+
+func (s *taskSchedulerSuite) TestDequeue_RoundRobinTaskTypes() {
+	s.weights["domain-a-name"] = 10
+
+	s.False(s.scheduler.enqueue(s.newTask("domain-a", persistence.TransferTaskTypeDecisionTask)))
+	s.False(s.scheduler.enqueue(s.newTask("domain-a", persistence.TransferTaskTypeDecisionTask)))
+	s.False(s.scheduler.enqueue(s.newTask("domain-a", persistence.TransferTaskTypeActivityTask)))
+
+	expected := []int{persistence.TransferTaskTypeDecisionTask, persistence.TransferTaskTypeActivityTask, persistence.TransferTaskTypeDecisionTask}
+	for _, taskType := range expected {
+		task, ok := s.scheduler.dequeue()
+		s.True(ok)
+		s.Equal(taskType, task.task.GetTaskType())
+	}
+}
+
+func (s *taskSchedulerSuite) TestDequeue_MaxInflight() {
+	s.maxInflight["domain-a-name"] = 1
+
+	s.False(s.scheduler.enqueue(s.newTask("domain-a", 0)))
+	s.False(s.scheduler.enqueue(s.newTask("domain-a", 0)))
+	s.False(s.scheduler.enqueue(s.newTask("domain-b", 0)))
+
+	first, ok := s.scheduler.dequeue()
+	s.True(ok)
+	s.Equal("domain-a", first.task.GetDomainID())
+	task, ok := s.scheduler.dequeue()
+	s.True(ok)
+	s.Equal("domain-b", task.task.GetDomainID())
+
+	dequeued := make(chan *taskInfo, 1)
+	go func() {
+		task, _ := s.scheduler.dequeue()
+		dequeued <- task
+	}()
+
+	select {
+	case <-dequeued:
+		s.Fail("domain-a should be blocked by its in-flight limit")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	s.scheduler.done(first)
+	select {
+	case task := <-dequeued:
+		s.Equal("domain-a", task.task.GetDomainID())
+	case <-time.After(time.Second):
+		s.Fail("domain-a should be unblocked once in-flight task is done")
+	}
+}
+
+func (s *taskSchedulerSuite) TestEnqueue_ParkedDomainDoesNotBlockOtherDomains() {
+	s.maxInflight["domain-a-name"] = 1
+
+	s.False(s.scheduler.enqueue(s.newTask("domain-a", 0)))
+	first, ok := s.scheduler.dequeue()
+	s.True(ok)
+
+	// domain-a is at its in-flight limit, its tasks don't fill up the scheduler
+	for i := 0; i < 10; i++ {
+		s.False(s.scheduler.enqueue(s.newTask("domain-a", 0)))
+	}
+	for i := 0; i < 10; i++ {
+		s.False(s.scheduler.enqueue(s.newTask("domain-b", 0)))
+	}
+
+	enqueued := make(chan bool, 1)
+	go func() {
+		enqueued <- s.scheduler.enqueue(s.newTask("domain-b", 0))
+	}()
+	select {
+	case <-enqueued:
+		s.Fail("enqueue should block once the tasks of domain-b fill up the scheduler")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	task, ok := s.scheduler.dequeue()
+	s.True(ok)
+	s.Equal("domain-b", task.task.GetDomainID())
+	select {
+	case shutdown := <-enqueued:
+		s.False(shutdown)
+	case <-time.After(time.Second):
+		s.Fail("enqueue should be unblocked once a task of domain-b is dequeued")
+	}
+
+	// once unparked, the tasks of domain-a are dispatched again
+	s.scheduler.done(first)
+	s.scheduler.done(task)
+	domains := make(map[string]int)
+	for i := 0; i < 4; i++ {
+		task, ok := s.scheduler.dequeue()
+		s.True(ok)
+		domains[task.task.GetDomainID()]++
+		if task.task.GetDomainID() == "domain-a" {
+			s.scheduler.done(task)
+		}
+	}
+	s.Equal(2, domains["domain-a"])
+}
+
+func (s *taskSchedulerSuite) TestEnqueue_ParkedDomainIsBounded() {
+	s.maxInflight["domain-a-name"] = 1
+
+	s.False(s.scheduler.enqueue(s.newTask("domain-a", 0)))
+	first, ok := s.scheduler.dequeue()
+	s.True(ok)
+	for i := 0; i < 10; i++ {
+		s.False(s.scheduler.enqueue(s.newTask("domain-a", 0)))
+	}
+
+	enqueued := make(chan bool, 1)
+	go func() {
+		enqueued <- s.scheduler.enqueue(s.newTask("domain-a", 0))
+	}()
+	select {
+	case <-enqueued:
+		s.Fail("enqueue should block once the queue of parked domain-a is full")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// tasks of other domains are still accepted
+	s.False(s.scheduler.enqueue(s.newTask("domain-b", 0)))
+
+	s.scheduler.done(first)
+	for i := 0; i < 2; i++ {
+		task, ok := s.scheduler.dequeue()
+		s.True(ok)
+		if task.task.GetDomainID() == "domain-a" {
+			s.scheduler.done(task)
+		}
+	}
+	select {
+	case shutdown := <-enqueued:
+		s.False(shutdown)
+	case <-time.After(time.Second):
+		s.Fail("enqueue should be unblocked once a task of domain-a is dequeued")
+	}
+}
+
+func (s *taskSchedulerSuite) TestStop_UnblocksWaiters() {
+	for i := 0; i < 10; i++ {
+		s.False(s.scheduler.enqueue(s.newTask("domain-a", 0)))
+	}
+
+	enqueued := make(chan bool, 1)
+	go func() {
+		enqueued <- s.scheduler.enqueue(s.newTask("domain-a", 0))
+	}()
+
+	s.scheduler.stop()
+	select {
+	case shutdown := <-enqueued:
+		s.True(shutdown)
+	case <-time.After(time.Second):
+		s.Fail("enqueue should return once scheduler is stopped")
+	}
+
+	_, ok := s.scheduler.dequeue()
+	s.False(ok)
+}
+
+func (s *taskSchedulerSuite) newTask(
+	domainID string,
+	taskType int,
+) *taskInfo {
+
+	return newTaskInfo(nil, &persistence.TransferTaskInfo{
+		DomainID: domainID,
+		TaskType: taskType,
+	}, nil)
+}
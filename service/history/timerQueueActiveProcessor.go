@@ -253,43 +253,43 @@ func (t *timerQueueActiveProcessorImpl) process(
 	switch timerTask.TaskType {
 	case persistence.TaskTypeUserTimer:
 		if taskInfo.shouldProcessTask {
-			err = t.processUserTimerTimeout(timerTask)
+			err = t.processUserTimerTimeout(taskInfo.ctx, timerTask)
 		}
 		return metrics.TimerActiveTaskUserTimerScope, err
 
 	case persistence.TaskTypeActivityTimeout:
 		if taskInfo.shouldProcessTask {
-			err = t.processActivityTimeout(timerTask)
+			err = t.processActivityTimeout(taskInfo.ctx, timerTask)
 		}
 		return metrics.TimerActiveTaskActivityTimeoutScope, err
 
 	case persistence.TaskTypeDecisionTimeout:
 		if taskInfo.shouldProcessTask {
-			err = t.processDecisionTimeout(timerTask)
+			err = t.processDecisionTimeout(taskInfo.ctx, timerTask)
 		}
 		return metrics.TimerActiveTaskDecisionTimeoutScope, err
 
 	case persistence.TaskTypeWorkflowTimeout:
 		if taskInfo.shouldProcessTask {
-			err = t.processWorkflowTimeout(timerTask)
+			err = t.processWorkflowTimeout(taskInfo.ctx, timerTask)
 		}
 		return metrics.TimerActiveTaskWorkflowTimeoutScope, err
 
 	case persistence.TaskTypeActivityRetryTimer:
 		if taskInfo.shouldProcessTask {
-			err = t.processActivityRetryTimer(timerTask)
+			err = t.processActivityRetryTimer(taskInfo.ctx, timerTask)
 		}
 		return metrics.TimerActiveTaskActivityRetryTimerScope, err
 
 	case persistence.TaskTypeWorkflowBackoffTimer:
 		if taskInfo.shouldProcessTask {
-			err = t.processWorkflowBackoffTimer(timerTask)
+			err = t.processWorkflowBackoffTimer(taskInfo.ctx, timerTask)
 		}
 		return metrics.TimerActiveTaskWorkflowBackoffTimerScope, err
 
 	case persistence.TaskTypeDeleteHistoryEvent:
 		if taskInfo.shouldProcessTask {
-			err = t.timerQueueProcessorBase.processDeleteHistoryEvent(timerTask)
+			err = t.timerQueueProcessorBase.processDeleteHistoryEvent(taskInfo.ctx, timerTask)
 		}
 		return metrics.TimerActiveTaskDeleteHistoryEventScope, err
 
@@ -299,12 +299,12 @@ func (t *timerQueueActiveProcessorImpl) process(
 }
 
 func (t *timerQueueActiveProcessorImpl) processUserTimerTimeout(
+	ctx context.Context,
 	task *persistence.TimerTaskInfo,
 ) (retError error) {
 
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task),
-	)
+	domainID, execution := t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task)
+	context, release, err := t.cache.getOrCreateWorkflowExecution(ctx, domainID, execution)
 	if err != nil {
 		return err
 	}
@@ -351,12 +351,12 @@ Loop:
 }
 
 func (t *timerQueueActiveProcessorImpl) processActivityTimeout(
+	ctx context.Context,
 	task *persistence.TimerTaskInfo,
 ) (retError error) {
 
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task),
-	)
+	domainID, execution := t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task)
+	context, release, err := t.cache.getOrCreateWorkflowExecution(ctx, domainID, execution)
 	if err != nil {
 		return err
 	}
@@ -443,12 +443,12 @@ Loop:
 }
 
 func (t *timerQueueActiveProcessorImpl) processDecisionTimeout(
+	ctx context.Context,
 	task *persistence.TimerTaskInfo,
 ) (retError error) {
 
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task),
-	)
+	domainID, execution := t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task)
+	context, release, err := t.cache.getOrCreateWorkflowExecution(ctx, domainID, execution)
 	if err != nil {
 		return err
 	}
@@ -515,12 +515,12 @@ func (t *timerQueueActiveProcessorImpl) processDecisionTimeout(
 }
 
 func (t *timerQueueActiveProcessorImpl) processWorkflowBackoffTimer(
+	ctx context.Context,
 	task *persistence.TimerTaskInfo,
 ) (retError error) {
 
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task),
-	)
+	domainID, execution := t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task)
+	context, release, err := t.cache.getOrCreateWorkflowExecution(ctx, domainID, execution)
 	if err != nil {
 		return err
 	}
@@ -550,12 +550,12 @@ func (t *timerQueueActiveProcessorImpl) processWorkflowBackoffTimer(
 }
 
 func (t *timerQueueActiveProcessorImpl) processActivityRetryTimer(
+	ctx context.Context,
 	task *persistence.TimerTaskInfo,
 ) (retError error) {
 
-	weCtx, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task),
-	)
+	taskDomainID, taskExecution := t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task)
+	weCtx, release, err := t.cache.getOrCreateWorkflowExecution(ctx, taskDomainID, taskExecution)
 	if err != nil {
 		return err
 	}
@@ -636,12 +636,12 @@ func (t *timerQueueActiveProcessorImpl) processActivityRetryTimer(
 }
 
 func (t *timerQueueActiveProcessorImpl) processWorkflowTimeout(
+	ctx context.Context,
 	task *persistence.TimerTaskInfo,
 ) (retError error) {
 
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task),
-	)
+	domainID, execution := t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task)
+	context, release, err := t.cache.getOrCreateWorkflowExecution(ctx, domainID, execution)
 	if err != nil {
 		return err
 	}
@@ -50,6 +50,7 @@ type (
 		NotifyNewTimers(clusterName string, timerTask []persistence.Task)
 		LockTaskProcessing()
 		UnlockTaskProcessing()
+		ProcessDLQTask(task *persistence.TimerTaskInfo) error
 	}
 
 	timeNow                 func() time.Time
@@ -245,6 +246,18 @@ func (t *timerQueueProcessorImpl) UnlockTaskProcessing() {
 	t.taskAllocator.unlock()
 }
 
+// ProcessDLQTask processes a timer task from the DLQ of the shard once
+func (t *timerQueueProcessorImpl) ProcessDLQTask(
+	task *persistence.TimerTaskInfo,
+) error {
+
+	executors := []taskExecutor{t.activeTimerProcessor}
+	for _, standbyTimerProcessor := range t.standbyTimerProcessors {
+		executors = append(executors, standbyTimerProcessor)
+	}
+	return processDLQTask(task, t.logger, executors...)
+}
+
 func (t *timerQueueProcessorImpl) completeTimersLoop() {
 	timer := time.NewTimer(t.config.TimerProcessorCompleteTimerInterval())
 	defer timer.Stop()
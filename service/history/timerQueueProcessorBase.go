@@ -89,8 +89,9 @@ func newTimerQueueProcessorBase(
 
 	log := logger.WithTags(tag.ComponentTimerQueue)
 	options := taskProcessorOptions{
-		workerCount: shard.GetConfig().TimerTaskWorkerCount(),
-		queueSize:   shard.GetConfig().TimerTaskWorkerCount() * shard.GetConfig().TimerTaskBatchSize(),
+		workerCount:  shard.GetConfig().TimerTaskWorkerCount(),
+		queueSize:    shard.GetConfig().TimerTaskWorkerCount() * shard.GetConfig().TimerTaskBatchSize(),
+		metricsScope: scope,
 	}
 	taskProcessor := newTaskProcessor(options, shard, historyService.historyCache, logger)
 	base := &timerQueueProcessorBase{
@@ -396,10 +397,12 @@ func (t *timerQueueProcessorBase) getDomainIDAndWorkflowExecution(
 }
 
 func (t *timerQueueProcessorBase) processDeleteHistoryEvent(
+	taskCtx ctx.Context,
 	task *persistence.TimerTaskInfo,
 ) (retError error) {
 
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(t.getDomainIDAndWorkflowExecution(task))
+	domainID, execution := t.getDomainIDAndWorkflowExecution(task)
+	context, release, err := t.cache.getOrCreateWorkflowExecution(taskCtx, domainID, execution)
 	if err != nil {
 		return err
 	}
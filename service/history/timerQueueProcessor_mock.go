@@ -129,3 +129,17 @@ func (mr *MocktimerQueueProcessorMockRecorder) UnlockTaskProcessing() *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockTaskProcessing", reflect.TypeOf((*MocktimerQueueProcessor)(nil).UnlockTaskProcessing))
 }
+
+// ProcessDLQTask mocks base method
+func (m *MocktimerQueueProcessor) ProcessDLQTask(task *persistence.TimerTaskInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessDLQTask", task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessDLQTask indicates an expected call of ProcessDLQTask
+func (mr *MocktimerQueueProcessorMockRecorder) ProcessDLQTask(task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessDLQTask", reflect.TypeOf((*MocktimerQueueProcessor)(nil).ProcessDLQTask), task)
+}
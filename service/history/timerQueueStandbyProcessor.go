@@ -223,7 +223,7 @@ func (t *timerQueueStandbyProcessorImpl) process(
 
 	case persistence.TaskTypeDeleteHistoryEvent:
 		// guarantee the processing of workflow execution history deletion
-		return metrics.TimerStandbyTaskDeleteHistoryEventScope, t.timerQueueProcessorBase.processDeleteHistoryEvent(timerTask)
+		return metrics.TimerStandbyTaskDeleteHistoryEventScope, t.timerQueueProcessorBase.processDeleteHistoryEvent(taskInfo.ctx, timerTask)
 
 	default:
 		return metrics.TimerStandbyQueueProcessorScope, errUnknownTimerTask
@@ -514,9 +514,8 @@ func (t *timerQueueStandbyProcessorImpl) processTimer(
 ) (retError error) {
 
 	timerTask := taskInfo.task.(*persistence.TimerTaskInfo)
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(timerTask),
-	)
+	domainID, execution := t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(timerTask)
+	context, release, err := t.cache.getOrCreateWorkflowExecution(ctx, domainID, execution)
 	if err != nil {
 		return err
 	}
@@ -268,55 +268,55 @@ func (t *transferQueueActiveProcessorImpl) process(
 	switch task.TaskType {
 	case persistence.TransferTaskTypeActivityTask:
 		if taskInfo.shouldProcessTask {
-			err = t.processActivityTask(task)
+			err = t.processActivityTask(taskInfo.ctx, task)
 		}
 		return metrics.TransferActiveTaskActivityScope, err
 
 	case persistence.TransferTaskTypeDecisionTask:
 		if taskInfo.shouldProcessTask {
-			err = t.processDecisionTask(task)
+			err = t.processDecisionTask(taskInfo.ctx, task)
 		}
 		return metrics.TransferActiveTaskDecisionScope, err
 
 	case persistence.TransferTaskTypeCloseExecution:
 		if taskInfo.shouldProcessTask {
-			err = t.processCloseExecution(task)
+			err = t.processCloseExecution(taskInfo.ctx, task)
 		}
 		return metrics.TransferActiveTaskCloseExecutionScope, err
 
 	case persistence.TransferTaskTypeCancelExecution:
 		if taskInfo.shouldProcessTask {
-			err = t.processCancelExecution(task)
+			err = t.processCancelExecution(taskInfo.ctx, task)
 		}
 		return metrics.TransferActiveTaskCancelExecutionScope, err
 
 	case persistence.TransferTaskTypeSignalExecution:
 		if taskInfo.shouldProcessTask {
-			err = t.processSignalExecution(task)
+			err = t.processSignalExecution(taskInfo.ctx, task)
 		}
 		return metrics.TransferActiveTaskSignalExecutionScope, err
 
 	case persistence.TransferTaskTypeStartChildExecution:
 		if taskInfo.shouldProcessTask {
-			err = t.processStartChildExecution(task)
+			err = t.processStartChildExecution(taskInfo.ctx, task)
 		}
 		return metrics.TransferActiveTaskStartChildExecutionScope, err
 
 	case persistence.TransferTaskTypeRecordWorkflowStarted:
 		if taskInfo.shouldProcessTask {
-			err = t.processRecordWorkflowStarted(task)
+			err = t.processRecordWorkflowStarted(taskInfo.ctx, task)
 		}
 		return metrics.TransferActiveTaskRecordWorkflowStartedScope, err
 
 	case persistence.TransferTaskTypeResetWorkflow:
 		if taskInfo.shouldProcessTask {
-			err = t.processResetWorkflow(task)
+			err = t.processResetWorkflow(taskInfo.ctx, task)
 		}
 		return metrics.TransferActiveTaskResetWorkflowScope, err
 
 	case persistence.TransferTaskTypeUpsertWorkflowSearchAttributes:
 		if taskInfo.shouldProcessTask {
-			err = t.processUpsertWorkflowSearchAttributes(task)
+			err = t.processUpsertWorkflowSearchAttributes(taskInfo.ctx, task)
 		}
 		return metrics.TransferActiveTaskUpsertWorkflowSearchAttributesScope, err
 
@@ -326,12 +326,12 @@ func (t *transferQueueActiveProcessorImpl) process(
 }
 
 func (t *transferQueueActiveProcessorImpl) processActivityTask(
+	taskCtx ctx.Context,
 	task *persistence.TransferTaskInfo,
 ) (retError error) {
 
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getDomainIDAndWorkflowExecution(task),
-	)
+	domainID, execution := t.getDomainIDAndWorkflowExecution(task)
+	context, release, err := t.cache.getOrCreateWorkflowExecution(taskCtx, domainID, execution)
 	if err != nil {
 		return err
 	}
@@ -363,12 +363,12 @@ func (t *transferQueueActiveProcessorImpl) processActivityTask(
 }
 
 func (t *transferQueueActiveProcessorImpl) processDecisionTask(
+	taskCtx ctx.Context,
 	task *persistence.TransferTaskInfo,
 ) (retError error) {
 
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getDomainIDAndWorkflowExecution(task),
-	)
+	domainID, execution := t.getDomainIDAndWorkflowExecution(task)
+	context, release, err := t.cache.getOrCreateWorkflowExecution(taskCtx, domainID, execution)
 	if err != nil {
 		return err
 	}
@@ -411,19 +411,21 @@ func (t *transferQueueActiveProcessorImpl) processDecisionTask(
 		decisionTimeout = executionInfo.StickyScheduleToStartTimeout
 	}
 
+	buildID := executionInfo.StartingBuildID
+
 	// release the context lock since we no longer need mutable state builder and
 	// the rest of logic is making RPC call, which takes time.
 	release(nil)
-	return t.pushDecision(task, taskList, decisionTimeout)
+	return t.pushDecision(task, taskList, decisionTimeout, buildID)
 }
 
 func (t *transferQueueActiveProcessorImpl) processCloseExecution(
+	taskCtx ctx.Context,
 	task *persistence.TransferTaskInfo,
 ) (retError error) {
 
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getDomainIDAndWorkflowExecution(task),
-	)
+	domainID, execution := t.getDomainIDAndWorkflowExecution(task)
+	context, release, err := t.cache.getOrCreateWorkflowExecution(taskCtx, domainID, execution)
 	if err != nil {
 		return err
 	}
@@ -498,7 +500,7 @@ func (t *transferQueueActiveProcessorImpl) processCloseExecution(
 
 	// Communicate the result to parent execution if this is Child Workflow execution
 	if replyToParentWorkflow {
-		ctx, cancel := ctx.WithTimeout(ctx.Background(), transferActiveTaskDefaultTimeout)
+		ctx, cancel := ctx.WithTimeout(taskCtx, transferActiveTaskDefaultTimeout)
 		defer cancel()
 		err = t.historyClient.RecordChildExecutionCompleted(ctx, &h.RecordChildExecutionCompletedRequest{
 			DomainUUID: common.StringPtr(parentDomainID),
@@ -529,12 +531,12 @@ func (t *transferQueueActiveProcessorImpl) processCloseExecution(
 }
 
 func (t *transferQueueActiveProcessorImpl) processCancelExecution(
+	taskCtx ctx.Context,
 	task *persistence.TransferTaskInfo,
 ) (retError error) {
 
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getDomainIDAndWorkflowExecution(task),
-	)
+	domainID, execution := t.getDomainIDAndWorkflowExecution(task)
+	context, release, err := t.cache.getOrCreateWorkflowExecution(taskCtx, domainID, execution)
 	if err != nil {
 		return err
 	}
@@ -613,12 +615,12 @@ func (t *transferQueueActiveProcessorImpl) processCancelExecution(
 }
 
 func (t *transferQueueActiveProcessorImpl) processSignalExecution(
+	taskCtx ctx.Context,
 	task *persistence.TransferTaskInfo,
 ) (retError error) {
 
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getDomainIDAndWorkflowExecution(task),
-	)
+	domainID, execution := t.getDomainIDAndWorkflowExecution(task)
+	context, release, err := t.cache.getOrCreateWorkflowExecution(taskCtx, domainID, execution)
 	if err != nil {
 		return err
 	}
@@ -708,7 +710,7 @@ func (t *transferQueueActiveProcessorImpl) processSignalExecution(
 	// the rest of logic is making RPC call, which takes time.
 	release(retError)
 	// remove signalRequestedID from target workflow, after Signal detail is removed from source workflow
-	ctx, cancel := ctx.WithTimeout(ctx.Background(), transferActiveTaskDefaultTimeout)
+	ctx, cancel := ctx.WithTimeout(taskCtx, transferActiveTaskDefaultTimeout)
 	defer cancel()
 	return t.historyClient.RemoveSignalMutableState(ctx, &h.RemoveSignalMutableStateRequest{
 		DomainUUID: common.StringPtr(task.TargetDomainID),
@@ -721,12 +723,12 @@ func (t *transferQueueActiveProcessorImpl) processSignalExecution(
 }
 
 func (t *transferQueueActiveProcessorImpl) processStartChildExecution(
+	taskCtx ctx.Context,
 	task *persistence.TransferTaskInfo,
 ) (retError error) {
 
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getDomainIDAndWorkflowExecution(task),
-	)
+	domainID, execution := t.getDomainIDAndWorkflowExecution(task)
+	context, release, err := t.cache.getOrCreateWorkflowExecution(taskCtx, domainID, execution)
 	if err != nil {
 		return err
 	}
@@ -825,27 +827,29 @@ func (t *transferQueueActiveProcessorImpl) processStartChildExecution(
 }
 
 func (t *transferQueueActiveProcessorImpl) processRecordWorkflowStarted(
+	taskCtx ctx.Context,
 	task *persistence.TransferTaskInfo,
 ) (retError error) {
 
-	return t.processRecordWorkflowStartedOrUpsertHelper(task, true)
+	return t.processRecordWorkflowStartedOrUpsertHelper(taskCtx, task, true)
 }
 
 func (t *transferQueueActiveProcessorImpl) processUpsertWorkflowSearchAttributes(
+	taskCtx ctx.Context,
 	task *persistence.TransferTaskInfo,
 ) (retError error) {
 
-	return t.processRecordWorkflowStartedOrUpsertHelper(task, false)
+	return t.processRecordWorkflowStartedOrUpsertHelper(taskCtx, task, false)
 }
 
 func (t *transferQueueActiveProcessorImpl) processRecordWorkflowStartedOrUpsertHelper(
+	taskCtx ctx.Context,
 	task *persistence.TransferTaskInfo,
 	recordStart bool,
 ) (retError error) {
 
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getDomainIDAndWorkflowExecution(task),
-	)
+	domainID, execution := t.getDomainIDAndWorkflowExecution(task)
+	context, release, err := t.cache.getOrCreateWorkflowExecution(taskCtx, domainID, execution)
 	if err != nil {
 		return err
 	}
@@ -917,12 +921,12 @@ func (t *transferQueueActiveProcessorImpl) processRecordWorkflowStartedOrUpsertH
 }
 
 func (t *transferQueueActiveProcessorImpl) processResetWorkflow(
+	taskCtx ctx.Context,
 	task *persistence.TransferTaskInfo,
 ) (retError error) {
 
-	currentContext, currentRelease, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getDomainIDAndWorkflowExecution(task),
-	)
+	domainID, execution := t.getDomainIDAndWorkflowExecution(task)
+	currentContext, currentRelease, err := t.cache.getOrCreateWorkflowExecution(taskCtx, domainID, execution)
 	if err != nil {
 		return err
 	}
@@ -1002,7 +1006,7 @@ func (t *transferQueueActiveProcessorImpl) processResetWorkflow(
 			WorkflowId: common.StringPtr(task.WorkflowID),
 			RunId:      common.StringPtr(resetPoint.GetRunId()),
 		}
-		baseContext, baseRelease, err = t.cache.getOrCreateWorkflowExecutionForBackground(task.DomainID, baseExecution)
+		baseContext, baseRelease, err = t.cache.getOrCreateWorkflowExecution(taskCtx, task.DomainID, baseExecution)
 		if err != nil {
 			return err
 		}
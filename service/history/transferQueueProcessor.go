@@ -51,6 +51,7 @@ type (
 		NotifyNewTask(clusterName string, transferTasks []persistence.Task)
 		LockTaskProcessing()
 		UnlockTaskPrrocessing()
+		ProcessDLQTask(task *persistence.TransferTaskInfo) error
 	}
 
 	taskFilter func(task *taskInfo) (bool, error)
@@ -266,6 +267,18 @@ func (t *transferQueueProcessorImpl) UnlockTaskPrrocessing() {
 	t.taskAllocator.unlock()
 }
 
+// ProcessDLQTask processes a transfer task from the DLQ of the shard once
+func (t *transferQueueProcessorImpl) ProcessDLQTask(
+	task *persistence.TransferTaskInfo,
+) error {
+
+	executors := []taskExecutor{t.activeTaskProcessor}
+	for _, standbyTaskProcessor := range t.standbyTaskProcessors {
+		executors = append(executors, standbyTaskProcessor)
+	}
+	return processDLQTask(task, t.logger, executors...)
+}
+
 func (t *transferQueueProcessorImpl) completeTransferLoop() {
 	timer := time.NewTimer(t.config.TransferProcessorCompleteTransferInterval())
 	defer timer.Stop()
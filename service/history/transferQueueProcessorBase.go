@@ -166,6 +166,7 @@ func (t *transferQueueProcessorBase) pushDecision(
 	task *persistence.TransferTaskInfo,
 	tasklist *workflow.TaskList,
 	decisionScheduleToStartTimeout int32,
+	buildID string,
 ) error {
 
 	ctx, cancel := ctx.WithTimeout(ctx.Background(), transferActiveTaskDefaultTimeout)
@@ -184,6 +185,7 @@ func (t *transferQueueProcessorBase) pushDecision(
 		TaskList:                      adapter.ToProtoTaskList(tasklist),
 		ScheduleId:                    task.ScheduleID,
 		ScheduleToStartTimeoutSeconds: decisionScheduleToStartTimeout,
+		BuildId:                       buildID,
 	})
 	return err
 }
@@ -129,3 +129,17 @@ func (mr *MocktransferQueueProcessorMockRecorder) UnlockTaskPrrocessing() *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockTaskPrrocessing", reflect.TypeOf((*MocktransferQueueProcessor)(nil).UnlockTaskPrrocessing))
 }
+
+// ProcessDLQTask mocks base method
+func (m *MocktransferQueueProcessor) ProcessDLQTask(task *persistence.TransferTaskInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessDLQTask", task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessDLQTask indicates an expected call of ProcessDLQTask
+func (mr *MocktransferQueueProcessorMockRecorder) ProcessDLQTask(task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessDLQTask", reflect.TypeOf((*MocktransferQueueProcessor)(nil).ProcessDLQTask), task)
+}
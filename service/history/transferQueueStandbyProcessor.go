@@ -284,6 +284,7 @@ func (t *transferQueueStandbyProcessorImpl) processDecisionTask(
 			return newPushDecisionToMatchingInfo(
 				decisionTimeout,
 				workflow.TaskList{Name: &transferTask.TaskList},
+				executionInfo.StartingBuildID,
 			), nil
 		}
 
@@ -592,9 +593,8 @@ func (t *transferQueueStandbyProcessorImpl) processTransfer(
 ) (retError error) {
 
 	transferTask := taskInfo.task.(*persistence.TransferTaskInfo)
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getDomainIDAndWorkflowExecution(transferTask),
-	)
+	domainID, execution := t.getDomainIDAndWorkflowExecution(transferTask)
+	context, release, err := t.cache.getOrCreateWorkflowExecution(ctx, domainID, execution)
 	if err != nil {
 		return err
 	}
@@ -659,6 +659,7 @@ func (t *transferQueueStandbyProcessorImpl) pushDecision(
 		task.task.(*persistence.TransferTaskInfo),
 		&pushDecisionInfo.tasklist,
 		timeout,
+		pushDecisionInfo.buildID,
 	)
 }
 
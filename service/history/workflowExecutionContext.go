@@ -147,6 +147,9 @@ type (
 		mutableState    mutableState
 		stats           *persistence.ExecutionStats
 		updateCondition int64
+		// ctx is the context the lock is held with, persistence operations
+		// are traced as part of the request or task which holds the lock
+		ctx context.Context
 	}
 )
 
@@ -176,14 +179,20 @@ func newWorkflowExecutionContext(
 		stats: &persistence.ExecutionStats{
 			HistorySize: 0,
 		},
+		ctx: context.Background(),
 	}
 }
 
 func (c *workflowExecutionContextImpl) lock(ctx context.Context) error {
-	return c.mutex.Lock(ctx)
+	if err := c.mutex.Lock(ctx); err != nil {
+		return err
+	}
+	c.ctx = ctx
+	return nil
 }
 
 func (c *workflowExecutionContextImpl) unlock() {
+	c.ctx = context.Background()
 	c.mutex.Unlock()
 }
 
@@ -442,7 +451,7 @@ func (c *workflowExecutionContextImpl) conflictResolveWorkflowExecution(
 		return err
 	}
 
-	if err := c.shard.ConflictResolveWorkflowExecution(&persistence.ConflictResolveWorkflowExecutionRequest{
+	if err := c.shard.ConflictResolveWorkflowExecution(c.ctx, &persistence.ConflictResolveWorkflowExecutionRequest{
 		// RangeID , this is set by shard context
 		Mode: conflictResolveMode,
 
@@ -843,7 +852,7 @@ func (c *workflowExecutionContextImpl) appendHistoryV2EventsWithRetry(
 	resp := 0
 	op := func() error {
 		var err error
-		resp, err = c.shard.AppendHistoryV2Events(request, domainID, execution)
+		resp, err = c.shard.AppendHistoryV2Events(c.ctx, request, domainID, execution)
 		return err
 	}
 
@@ -862,7 +871,7 @@ func (c *workflowExecutionContextImpl) createWorkflowExecutionWithRetry(
 	var resp *persistence.CreateWorkflowExecutionResponse
 	op := func() error {
 		var err error
-		resp, err = c.shard.CreateWorkflowExecution(request)
+		resp, err = c.shard.CreateWorkflowExecution(c.ctx, request)
 		return err
 	}
 
@@ -898,7 +907,7 @@ func (c *workflowExecutionContextImpl) getWorkflowExecutionWithRetry(
 	var resp *persistence.GetWorkflowExecutionResponse
 	op := func() error {
 		var err error
-		resp, err = c.executionManager.GetWorkflowExecution(request)
+		resp, err = persistence.ExecutionManagerWithContext(c.executionManager, c.ctx).GetWorkflowExecution(request)
 
 		return err
 	}
@@ -934,7 +943,7 @@ func (c *workflowExecutionContextImpl) updateWorkflowExecutionWithRetry(
 	var resp *persistence.UpdateWorkflowExecutionResponse
 	op := func() error {
 		var err error
-		resp, err = c.shard.UpdateWorkflowExecution(request)
+		resp, err = c.shard.UpdateWorkflowExecution(c.ctx, request)
 		return err
 	}
 
@@ -1118,7 +1127,7 @@ func (c *workflowExecutionContextImpl) resetWorkflowExecution(
 		}
 	}
 
-	err = c.shard.ResetWorkflowExecution(resetWFReq)
+	err = c.shard.ResetWorkflowExecution(c.ctx, resetWFReq)
 	if err != nil {
 		return err
 	}
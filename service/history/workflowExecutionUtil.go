@@ -213,3 +213,45 @@ func terminateWorkflow(
 	)
 	return err
 }
+
+// isFirstDecisionDelayed returns true if the workflow is still waiting for its first decision
+// to be scheduled by the workflow backoff timer, i.e. a cron schedule or a delayed start.
+// Events received during that period must not schedule a decision on their own.
+func isFirstDecisionDelayed(
+	mutableState mutableState,
+) (bool, error) {
+
+	if mutableState.HasProcessedOrPendingDecision() {
+		return false, nil
+	}
+	if mutableState.GetExecutionInfo().CronSchedule != "" {
+		return true, nil
+	}
+
+	startEvent, err := mutableState.GetStartEvent()
+	if err != nil {
+		return false, err
+	}
+	startAttr := startEvent.WorkflowExecutionStartedEventAttributes
+	return startAttr.GetFirstDecisionTaskBackoffSeconds() > 0 &&
+		startAttr.GetInitiator() != workflow.ContinueAsNewInitiatorRetryPolicy, nil
+}
+
+// isStartDelayed returns true if the workflow was started with a start delay and is still
+// waiting for its first decision. Unlike a cron or retry backoff, nothing has run yet which
+// could handle a cancellation, so the workflow can be closed right away.
+func isStartDelayed(
+	mutableState mutableState,
+) (bool, error) {
+
+	if mutableState.HasProcessedOrPendingDecision() || mutableState.GetExecutionInfo().CronSchedule != "" {
+		return false, nil
+	}
+
+	startEvent, err := mutableState.GetStartEvent()
+	if err != nil {
+		return false, err
+	}
+	startAttr := startEvent.WorkflowExecutionStartedEventAttributes
+	return startAttr.GetFirstDecisionTaskBackoffSeconds() > 0 && startAttr.Initiator == nil, nil
+}
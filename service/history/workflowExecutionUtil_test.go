@@ -0,0 +1,144 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+func TestIsFirstDecisionDelayed(t *testing.T) {
+	startEvent := func(backoffSeconds int32, initiator *shared.ContinueAsNewInitiator) *shared.HistoryEvent {
+		return &shared.HistoryEvent{
+			EventId:   common.Int64Ptr(common.FirstEventID),
+			EventType: common.EventTypePtr(shared.EventTypeWorkflowExecutionStarted),
+			WorkflowExecutionStartedEventAttributes: &shared.WorkflowExecutionStartedEventAttributes{
+				FirstDecisionTaskBackoffSeconds: common.Int32Ptr(backoffSeconds),
+				Initiator:                       initiator,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name                  string
+		hasDecision           bool
+		cronSchedule          string
+		startEvent            *shared.HistoryEvent
+		expectedDelayed       bool
+		expectStartEventLoads bool
+	}{
+		{name: "decision processed", hasDecision: true, expectedDelayed: false},
+		{name: "cron not started", cronSchedule: "* * * * *", expectedDelayed: true},
+		{name: "no backoff", startEvent: startEvent(0, nil), expectedDelayed: false, expectStartEventLoads: true},
+		{name: "delayed start", startEvent: startEvent(60, nil), expectedDelayed: true, expectStartEventLoads: true},
+		{
+			name:                  "retry backoff",
+			startEvent:            startEvent(60, shared.ContinueAsNewInitiatorRetryPolicy.Ptr()),
+			expectedDelayed:       false,
+			expectStartEventLoads: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mutableState := NewMockmutableState(controller)
+			mutableState.EXPECT().HasProcessedOrPendingDecision().Return(tc.hasDecision).AnyTimes()
+			mutableState.EXPECT().GetExecutionInfo().Return(&persistence.WorkflowExecutionInfo{
+				CronSchedule: tc.cronSchedule,
+			}).AnyTimes()
+			if tc.expectStartEventLoads {
+				mutableState.EXPECT().GetStartEvent().Return(tc.startEvent, nil).Times(1)
+			}
+
+			delayed, err := isFirstDecisionDelayed(mutableState)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedDelayed, delayed)
+		})
+	}
+}
+
+func TestIsStartDelayed(t *testing.T) {
+	startEvent := func(backoffSeconds int32, initiator *shared.ContinueAsNewInitiator) *shared.HistoryEvent {
+		return &shared.HistoryEvent{
+			EventId:   common.Int64Ptr(common.FirstEventID),
+			EventType: common.EventTypePtr(shared.EventTypeWorkflowExecutionStarted),
+			WorkflowExecutionStartedEventAttributes: &shared.WorkflowExecutionStartedEventAttributes{
+				FirstDecisionTaskBackoffSeconds: common.Int32Ptr(backoffSeconds),
+				Initiator:                       initiator,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name                  string
+		hasDecision           bool
+		cronSchedule          string
+		startEvent            *shared.HistoryEvent
+		expectedDelayed       bool
+		expectStartEventLoads bool
+	}{
+		{name: "decision processed", hasDecision: true, expectedDelayed: false},
+		{name: "cron not started", cronSchedule: "* * * * *", expectedDelayed: false},
+		{name: "no backoff", startEvent: startEvent(0, nil), expectedDelayed: false, expectStartEventLoads: true},
+		{name: "delayed start", startEvent: startEvent(60, nil), expectedDelayed: true, expectStartEventLoads: true},
+		{
+			name:                  "retry backoff",
+			startEvent:            startEvent(60, shared.ContinueAsNewInitiatorRetryPolicy.Ptr()),
+			expectedDelayed:       false,
+			expectStartEventLoads: true,
+		},
+		{
+			name:                  "continue as new backoff",
+			startEvent:            startEvent(60, shared.ContinueAsNewInitiatorDecider.Ptr()),
+			expectedDelayed:       false,
+			expectStartEventLoads: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			mutableState := NewMockmutableState(controller)
+			mutableState.EXPECT().HasProcessedOrPendingDecision().Return(tc.hasDecision).AnyTimes()
+			mutableState.EXPECT().GetExecutionInfo().Return(&persistence.WorkflowExecutionInfo{
+				CronSchedule: tc.cronSchedule,
+			}).AnyTimes()
+			if tc.expectStartEventLoads {
+				mutableState.EXPECT().GetStartEvent().Return(tc.startEvent, nil).Times(1)
+			}
+
+			delayed, err := isStartDelayed(mutableState)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedDelayed, delayed)
+		})
+	}
+}
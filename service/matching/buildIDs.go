@@ -0,0 +1,96 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"fmt"
+	"strings"
+
+	workflow "github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+)
+
+const (
+	// buildIDSeparator is not allowed in build IDs as persistence uses it to encode a set
+	buildIDSeparator = ","
+	// buildIDSetsCacheMaxSize is the max number of root partitions whose build ID sets are cached by a host
+	buildIDSetsCacheMaxSize = 10000
+)
+
+// validateBuildIDSets checks the given compatible build ID sets and converts them to their persistence form.
+// Every set must be non-empty and a build ID can only appear once across all sets.
+func validateBuildIDSets(sets []*adminservice.BuildIdSet) ([][]string, error) {
+	seen := make(map[string]struct{})
+	result := make([][]string, 0, len(sets))
+	for _, set := range sets {
+		if len(set.GetBuildIds()) == 0 {
+			return nil, &workflow.BadRequestError{Message: "Build ID set is empty."}
+		}
+		for _, buildID := range set.GetBuildIds() {
+			if buildID == "" {
+				return nil, &workflow.BadRequestError{Message: "Build ID is not set on request."}
+			}
+			if strings.Contains(buildID, buildIDSeparator) {
+				return nil, &workflow.BadRequestError{Message: fmt.Sprintf("Build ID %v contains %q.", buildID, buildIDSeparator)}
+			}
+			if _, ok := seen[buildID]; ok {
+				return nil, &workflow.BadRequestError{Message: fmt.Sprintf("Build ID %v appears in more than one set.", buildID)}
+			}
+			seen[buildID] = struct{}{}
+		}
+		result = append(result, set.GetBuildIds())
+	}
+	return result, nil
+}
+
+func toProtoBuildIDSets(sets [][]string) []*adminservice.BuildIdSet {
+	if len(sets) == 0 {
+		return nil
+	}
+	result := make([]*adminservice.BuildIdSet, 0, len(sets))
+	for _, set := range sets {
+		result = append(result, &adminservice.BuildIdSet{BuildIds: set})
+	}
+	return result
+}
+
+// findBuildIDSet returns the name of the versioned task list serving the given build ID, which is
+// the first build ID of its compatible set. An empty build ID maps to the latest set when
+// useLatestForEmpty is true. Returns false if the build ID is not part of any set.
+func findBuildIDSet(sets [][]string, buildID string, useLatestForEmpty bool) (string, bool) {
+	if len(sets) == 0 {
+		return "", false
+	}
+	if buildID == "" {
+		if !useLatestForEmpty {
+			return "", false
+		}
+		return sets[len(sets)-1][0], true
+	}
+	for _, set := range sets {
+		for _, id := range set {
+			if id == buildID {
+				return set[0], true
+			}
+		}
+	}
+	return "", false
+}
@@ -47,6 +47,15 @@ type (
 		ForwarderMaxOutstandingTasks dynamicconfig.IntPropertyFnWithTaskListInfoFilters
 		ForwarderMaxRatePerSecond    dynamicconfig.IntPropertyFnWithTaskListInfoFilters
 		ForwarderMaxChildrenPerNode  dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+		BuildIDSetsCacheTTL          dynamicconfig.DurationPropertyFn
+
+		// partition auto scaling configuration
+		EnablePartitionAutoScaling    dynamicconfig.BoolPropertyFnWithTaskListInfoFilters
+		PartitionAutoScalingInterval  dynamicconfig.DurationPropertyFnWithTaskListInfoFilters
+		PartitionAutoScalingTargetRPS dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+		MaxAutoScaledPartitions       dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+		AutoScalingBacklogThreshold   dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+		PartitionCountsCacheTTL       dynamicconfig.DurationPropertyFn
 
 		// Time to hold a poll request before returning an empty response if there are no tasks
 		LongPollExpirationInterval dynamicconfig.DurationPropertyFnWithTaskListInfoFilters
@@ -84,6 +93,12 @@ type (
 		MaxTaskBatchSize                func() int
 		NumWritePartitions              func() int
 		NumReadPartitions               func() int
+		// partition auto scaling configuration, filtered by the name of the root partition
+		EnablePartitionAutoScaling    func() bool
+		PartitionAutoScalingInterval  func() time.Duration
+		PartitionAutoScalingTargetRPS func() int
+		MaxAutoScaledPartitions       func() int
+		AutoScalingBacklogThreshold   func() int
 	}
 )
 
@@ -110,6 +125,13 @@ func NewConfig(dc *dynamicconfig.Collection) *Config {
 		ForwarderMaxOutstandingTasks:    dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingForwarderMaxOutstandingTasks, 1),
 		ForwarderMaxRatePerSecond:       dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingForwarderMaxRatePerSecond, 10),
 		ForwarderMaxChildrenPerNode:     dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingForwarderMaxChildrenPerNode, 20),
+		BuildIDSetsCacheTTL:             dc.GetDurationProperty(dynamicconfig.MatchingBuildIDSetsCacheTTL, 10*time.Second),
+		EnablePartitionAutoScaling:      dc.GetBoolPropertyFilteredByTaskListInfo(dynamicconfig.MatchingEnablePartitionAutoScaling, false),
+		PartitionAutoScalingInterval:    dc.GetDurationPropertyFilteredByTaskListInfo(dynamicconfig.MatchingPartitionAutoScalingInterval, time.Minute),
+		PartitionAutoScalingTargetRPS:   dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingPartitionAutoScalingTargetRPS, 500),
+		MaxAutoScaledPartitions:         dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingMaxAutoScaledPartitions, 16),
+		AutoScalingBacklogThreshold:     dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingAutoScalingBacklogThreshold, 1000),
+		PartitionCountsCacheTTL:         dc.GetDurationProperty(dynamicconfig.MatchingPartitionCountsCacheTTL, 10*time.Second),
 	}
 }
 
@@ -122,6 +144,7 @@ func newTaskListConfig(id *taskListID, config *Config, domainCache cache.DomainC
 	domain := domainEntry.GetInfo().Name
 	taskListName := id.name
 	taskType := id.taskType
+	rootName := id.GetUnversionedRoot()
 	return &taskListConfig{
 		RangeSize: config.RangeSize,
 		GetTasksBatchSize: func() int {
@@ -160,6 +183,21 @@ func newTaskListConfig(id *taskListID, config *Config, domainCache cache.DomainC
 		NumReadPartitions: func() int {
 			return common.MaxInt(1, config.NumTasklistReadPartitions(domain, taskListName, taskType))
 		},
+		EnablePartitionAutoScaling: func() bool {
+			return config.EnablePartitionAutoScaling(domain, rootName, taskType)
+		},
+		PartitionAutoScalingInterval: func() time.Duration {
+			return config.PartitionAutoScalingInterval(domain, rootName, taskType)
+		},
+		PartitionAutoScalingTargetRPS: func() int {
+			return common.MaxInt(1, config.PartitionAutoScalingTargetRPS(domain, rootName, taskType))
+		},
+		MaxAutoScaledPartitions: func() int {
+			return common.MaxInt(1, config.MaxAutoScaledPartitions(domain, rootName, taskType))
+		},
+		AutoScalingBacklogThreshold: func() int {
+			return config.AutoScalingBacklogThreshold(domain, rootName, taskType)
+		},
 		forwarderConfig: forwarderConfig{
 			ForwarderMaxOutstandingPolls: func() int {
 				return config.ForwarderMaxOutstandingPolls(domain, taskListName, taskType)
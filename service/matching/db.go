@@ -32,14 +32,17 @@ import (
 type (
 	taskListDB struct {
 		sync.Mutex
-		domainID     string
-		taskListName string
-		taskListKind int
-		taskType     int
-		rangeID      int64
-		ackLevel     int64
-		store        persistence.TaskManager
-		logger       log.Logger
+		domainID           string
+		taskListName       string
+		taskListKind       int
+		taskType           int
+		rangeID            int64
+		ackLevel           int64
+		buildIDSets        [][]string
+		numReadPartitions  int
+		numWritePartitions int
+		store              persistence.TaskManager
+		logger             log.Logger
 	}
 	taskListState struct {
 		rangeID  int64
@@ -92,6 +95,9 @@ func (db *taskListDB) RenewLease() (taskListState, error) {
 	}
 	db.ackLevel = resp.TaskListInfo.AckLevel
 	db.rangeID = resp.TaskListInfo.RangeID
+	db.buildIDSets = resp.TaskListInfo.BuildIDSets
+	db.numReadPartitions = resp.TaskListInfo.NumReadPartitions
+	db.numWritePartitions = resp.TaskListInfo.NumWritePartitions
 	return taskListState{rangeID: db.rangeID, ackLevel: db.ackLevel}, nil
 }
 
@@ -101,12 +107,15 @@ func (db *taskListDB) UpdateState(ackLevel int64) error {
 	defer db.Unlock()
 	_, err := db.store.UpdateTaskList(&persistence.UpdateTaskListRequest{
 		TaskListInfo: &persistence.TaskListInfo{
-			DomainID: db.domainID,
-			Name:     db.taskListName,
-			TaskType: db.taskType,
-			AckLevel: ackLevel,
-			RangeID:  db.rangeID,
-			Kind:     db.taskListKind,
+			DomainID:           db.domainID,
+			Name:               db.taskListName,
+			TaskType:           db.taskType,
+			AckLevel:           ackLevel,
+			RangeID:            db.rangeID,
+			Kind:               db.taskListKind,
+			BuildIDSets:        db.buildIDSets,
+			NumReadPartitions:  db.numReadPartitions,
+			NumWritePartitions: db.numWritePartitions,
 		},
 	})
 	if err == nil {
@@ -115,18 +124,82 @@ func (db *taskListDB) UpdateState(ackLevel int64) error {
 	return err
 }
 
+// BuildIDSets returns the current persistence view of the compatible build ID sets
+func (db *taskListDB) BuildIDSets() [][]string {
+	db.Lock()
+	defer db.Unlock()
+	return db.buildIDSets
+}
+
+// UpdateBuildIDSets replaces the compatible build ID sets of the taskList
+func (db *taskListDB) UpdateBuildIDSets(buildIDSets [][]string) error {
+	db.Lock()
+	defer db.Unlock()
+	_, err := db.store.UpdateTaskList(&persistence.UpdateTaskListRequest{
+		TaskListInfo: &persistence.TaskListInfo{
+			DomainID:           db.domainID,
+			Name:               db.taskListName,
+			TaskType:           db.taskType,
+			AckLevel:           db.ackLevel,
+			RangeID:            db.rangeID,
+			Kind:               db.taskListKind,
+			BuildIDSets:        buildIDSets,
+			NumReadPartitions:  db.numReadPartitions,
+			NumWritePartitions: db.numWritePartitions,
+		},
+	})
+	if err == nil {
+		db.buildIDSets = buildIDSets
+	}
+	return err
+}
+
+// PartitionCounts returns the current persistence view of the partition counts chosen by partition auto scaling
+func (db *taskListDB) PartitionCounts() (numRead int, numWrite int) {
+	db.Lock()
+	defer db.Unlock()
+	return db.numReadPartitions, db.numWritePartitions
+}
+
+// UpdatePartitionCounts updates the partition counts chosen by partition auto scaling
+func (db *taskListDB) UpdatePartitionCounts(numRead int, numWrite int) error {
+	db.Lock()
+	defer db.Unlock()
+	_, err := db.store.UpdateTaskList(&persistence.UpdateTaskListRequest{
+		TaskListInfo: &persistence.TaskListInfo{
+			DomainID:           db.domainID,
+			Name:               db.taskListName,
+			TaskType:           db.taskType,
+			AckLevel:           db.ackLevel,
+			RangeID:            db.rangeID,
+			Kind:               db.taskListKind,
+			BuildIDSets:        db.buildIDSets,
+			NumReadPartitions:  numRead,
+			NumWritePartitions: numWrite,
+		},
+	})
+	if err == nil {
+		db.numReadPartitions = numRead
+		db.numWritePartitions = numWrite
+	}
+	return err
+}
+
 // CreateTasks creates a batch of given tasks for this task list
 func (db *taskListDB) CreateTasks(tasks []*persistence.CreateTaskInfo) (*persistence.CreateTasksResponse, error) {
 	db.Lock()
 	defer db.Unlock()
 	return db.store.CreateTasks(&persistence.CreateTasksRequest{
 		TaskListInfo: &persistence.TaskListInfo{
-			DomainID: db.domainID,
-			Name:     db.taskListName,
-			TaskType: db.taskType,
-			AckLevel: db.ackLevel,
-			RangeID:  db.rangeID,
-			Kind:     db.taskListKind,
+			DomainID:           db.domainID,
+			Name:               db.taskListName,
+			TaskType:           db.taskType,
+			AckLevel:           db.ackLevel,
+			RangeID:            db.rangeID,
+			Kind:               db.taskListKind,
+			BuildIDSets:        db.buildIDSets,
+			NumReadPartitions:  db.numReadPartitions,
+			NumWritePartitions: db.numWritePartitions,
 		},
 		Tasks: tasks,
 	})
@@ -32,6 +32,8 @@ import (
 	m "github.com/temporalio/temporal/.gen/go/matching"
 	"github.com/temporalio/temporal/.gen/go/matching/matchingserviceserver"
 	gen "github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	"github.com/temporalio/temporal/.gen/proto/matchingservice"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/metrics"
@@ -281,6 +283,15 @@ func (h *Handler) DescribeTaskList(ctx context.Context, request *m.DescribeTaskL
 	return response, h.handleErr(err, scope)
 }
 
+// GetTaskListStats returns the backlog and the add and dispatch rates of a task list partition, it is
+// reported as part of DescribeTaskList
+func (h *Handler) GetTaskListStats(ctx context.Context, request *matchingservice.DescribeTaskListRequest) (resp *adminservice.TaskListStats, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+
+	response, err := h.engine.GetTaskListStats(ctx, request)
+	return response, h.handleErr(err, metrics.MatchingDescribeTaskListScope)
+}
+
 // ListTaskListPartitions returns information about partitions for a taskList
 func (h *Handler) ListTaskListPartitions(ctx context.Context, request *m.ListTaskListPartitionsRequest) (resp *gen.ListTaskListPartitionsResponse, retError error) {
 	defer log.CapturePanic(h.GetLogger(), &retError)
@@ -296,6 +307,51 @@ func (h *Handler) ListTaskListPartitions(ctx context.Context, request *m.ListTas
 	return response, h.handleErr(err, scope)
 }
 
+// UpdateTaskListBuildIDs replaces the compatible build ID sets of a decision task list
+func (h *Handler) UpdateTaskListBuildIDs(ctx context.Context, request *matchingservice.UpdateTaskListBuildIDsRequest) (retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	scope := metrics.MatchingUpdateTaskListBuildIDsScope
+	sw := h.startRequestProfile("UpdateTaskListBuildIDs", scope)
+	defer sw.Stop()
+
+	if ok := h.rateLimiter.Allow(); !ok {
+		return h.handleErr(errMatchingHostThrottle, scope)
+	}
+
+	err := h.engine.UpdateTaskListBuildIDs(ctx, request)
+	return h.handleErr(err, scope)
+}
+
+// GetTaskListBuildIDs returns the compatible build ID sets of a decision task list
+func (h *Handler) GetTaskListBuildIDs(ctx context.Context, request *matchingservice.GetTaskListBuildIDsRequest) (resp *matchingservice.GetTaskListBuildIDsResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	scope := metrics.MatchingGetTaskListBuildIDsScope
+	sw := h.startRequestProfile("GetTaskListBuildIDs", scope)
+	defer sw.Stop()
+
+	if ok := h.rateLimiter.Allow(); !ok {
+		return nil, h.handleErr(errMatchingHostThrottle, scope)
+	}
+
+	response, err := h.engine.GetTaskListBuildIDs(ctx, request)
+	return response, h.handleErr(err, scope)
+}
+
+// GetTaskListPartitionCounts returns the number of read and write partitions of a task list
+func (h *Handler) GetTaskListPartitionCounts(ctx context.Context, request *matchingservice.GetTaskListPartitionCountsRequest) (resp *matchingservice.GetTaskListPartitionCountsResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	scope := metrics.MatchingGetTaskListPartitionCountsScope
+	sw := h.startRequestProfile("GetTaskListPartitionCounts", scope)
+	defer sw.Stop()
+
+	if ok := h.rateLimiter.Allow(); !ok {
+		return nil, h.handleErr(errMatchingHostThrottle, scope)
+	}
+
+	response, err := h.engine.GetTaskListPartitionCounts(ctx, request)
+	return response, h.handleErr(err, scope)
+}
+
 func (h *Handler) handleErr(err error, scope int) error {
 
 	if err == nil {
@@ -23,6 +23,8 @@ package matching
 import (
 	"context"
 
+	"go.temporal.io/temporal-proto/enums"
+
 	"github.com/temporalio/temporal/.gen/proto/healthservice"
 	"github.com/temporalio/temporal/.gen/proto/matchingservice"
 	"github.com/temporalio/temporal/common/adapter"
@@ -137,7 +139,25 @@ func (h *HandlerGRPC) DescribeTaskList(ctx context.Context, request *matchingser
 	if err != nil {
 		return nil, adapter.ToProtoError(err)
 	}
-	return adapter.ToProtoMatchingDescribeTaskListResponse(resp), nil
+	response := adapter.ToProtoMatchingDescribeTaskListResponse(resp)
+	if request.DescRequest.GetTaskListType() == enums.TaskListTypeDecision && request.DescRequest.TaskList.GetKind() != enums.TaskListKindSticky {
+		buildIDs, err := h.handlerThrift.GetTaskListBuildIDs(ctx, &matchingservice.GetTaskListBuildIDsRequest{
+			DomainUUID: request.GetDomainUUID(),
+			TaskList:   request.DescRequest.TaskList,
+		})
+		if err != nil {
+			return nil, adapter.ToProtoError(err)
+		}
+		response.BuildIdSets = buildIDs.GetBuildIdSets()
+	}
+	if request.DescRequest.GetIncludeTaskListStatus() {
+		stats, err := h.handlerThrift.GetTaskListStats(ctx, request)
+		if err != nil {
+			return nil, adapter.ToProtoError(err)
+		}
+		response.Stats = stats
+	}
+	return response, nil
 }
 
 func (h *HandlerGRPC) ListTaskListPartitions(ctx context.Context, request *matchingservice.ListTaskListPartitionsRequest) (_ *matchingservice.ListTaskListPartitionsResponse, retError error) {
@@ -149,3 +169,33 @@ func (h *HandlerGRPC) ListTaskListPartitions(ctx context.Context, request *match
 	}
 	return adapter.ToProtoMatchingListTaskListPartitionsResponse(resp), nil
 }
+
+func (h *HandlerGRPC) UpdateTaskListBuildIDs(ctx context.Context, request *matchingservice.UpdateTaskListBuildIDsRequest) (_ *matchingservice.UpdateTaskListBuildIDsResponse, retError error) {
+	defer log.CapturePanicGRPC(h.handlerThrift.GetLogger(), &retError)
+
+	err := h.handlerThrift.UpdateTaskListBuildIDs(ctx, request)
+	if err != nil {
+		return nil, adapter.ToProtoError(err)
+	}
+	return &matchingservice.UpdateTaskListBuildIDsResponse{}, nil
+}
+
+func (h *HandlerGRPC) GetTaskListBuildIDs(ctx context.Context, request *matchingservice.GetTaskListBuildIDsRequest) (_ *matchingservice.GetTaskListBuildIDsResponse, retError error) {
+	defer log.CapturePanicGRPC(h.handlerThrift.GetLogger(), &retError)
+
+	resp, err := h.handlerThrift.GetTaskListBuildIDs(ctx, request)
+	if err != nil {
+		return nil, adapter.ToProtoError(err)
+	}
+	return resp, nil
+}
+
+func (h *HandlerGRPC) GetTaskListPartitionCounts(ctx context.Context, request *matchingservice.GetTaskListPartitionCountsRequest) (_ *matchingservice.GetTaskListPartitionCountsResponse, retError error) {
+	defer log.CapturePanicGRPC(h.handlerThrift.GetLogger(), &retError)
+
+	resp, err := h.handlerThrift.GetTaskListPartitionCounts(ctx, request)
+	if err != nil {
+		return nil, adapter.ToProtoError(err)
+	}
+	return resp, nil
+}
@@ -32,10 +32,14 @@ import (
 	"github.com/temporalio/temporal/common/membership"
 
 	"github.com/pborman/uuid"
+	commonproto "go.temporal.io/temporal-proto/common"
+	"go.temporal.io/temporal-proto/enums"
 
 	h "github.com/temporalio/temporal/.gen/go/history"
 	m "github.com/temporalio/temporal/.gen/go/matching"
 	workflow "github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	"github.com/temporalio/temporal/.gen/proto/matchingservice"
 	"github.com/temporalio/temporal/client/history"
 	"github.com/temporalio/temporal/client/matching"
 	"github.com/temporalio/temporal/common"
@@ -52,8 +56,9 @@ import (
 // TODO: Switch implementation from lock/channel based to a partitioned agent
 // to simplify code and reduce possibility of synchronization errors.
 type (
-	pollerIDCtxKey string
-	identityCtxKey string
+	pollerIDCtxKey      string
+	identityCtxKey      string
+	forwardedFromCtxKey string
 
 	// lockableQueryTaskMap maps query TaskID (which is a UUID generated in QueryWorkflow() call) to a channel
 	// that QueryWorkflow() will block on. The channel is unblocked either by worker sending response through
@@ -78,6 +83,10 @@ type (
 		domainCache          cache.DomainCache
 		versionChecker       client.VersionChecker
 		keyResolver          membership.ServiceResolver
+		buildIDSetsCache     cache.Cache // build ID sets of root partitions owned by other hosts
+		partitionCountsCache cache.Cache // partition counts of root partitions owned by other hosts
+		// partitionCountsLookups are the root partitions whose counts are being fetched in the background
+		partitionCountsLookups sync.Map
 	}
 )
 
@@ -93,8 +102,9 @@ var (
 	ErrNoTasks    = errors.New("No tasks")
 	errPumpClosed = errors.New("Task list pump closed its channel")
 
-	pollerIDKey pollerIDCtxKey = "pollerID"
-	identityKey identityCtxKey = "identity"
+	pollerIDKey      pollerIDCtxKey      = "pollerID"
+	identityKey      identityCtxKey      = "identity"
+	forwardedFromKey forwardedFromCtxKey = "forwardedFrom"
 )
 
 var _ Engine = (*matchingEngineImpl)(nil) // Asserts that interface is indeed implemented
@@ -123,6 +133,8 @@ func NewEngine(taskManager persistence.TaskManager,
 		domainCache:          domainCache,
 		versionChecker:       client.NewVersionChecker(),
 		keyResolver:          resolver,
+		buildIDSetsCache:     cache.New(buildIDSetsCacheMaxSize, &cache.Options{TTL: config.BuildIDSetsCacheTTL()}),
+		partitionCountsCache: cache.New(partitionCountsCacheMaxSize, &cache.Options{TTL: config.PartitionCountsCacheTTL()}),
 	}
 }
 
@@ -227,6 +239,12 @@ func (e *matchingEngineImpl) AddDecisionTask(ctx context.Context, addRequest *m.
 	if err != nil {
 		return false, err
 	}
+	if addRequest.GetForwardedFrom() == "" {
+		taskList, err = e.getVersionedTaskList(ctx, taskList, taskListKind, addRequest.GetBuildId(), true)
+		if err != nil {
+			return false, err
+		}
+	}
 
 	tlMgr, err := e.getTaskListManager(taskList, taskListKind)
 	if err != nil {
@@ -304,11 +322,18 @@ pollLoop:
 		// long-poll when frontend calls CancelOutstandingPoll API
 		pollerCtx := context.WithValue(ctx, pollerIDKey, pollerID)
 		pollerCtx = context.WithValue(pollerCtx, identityKey, request.GetIdentity())
+		pollerCtx = context.WithValue(pollerCtx, forwardedFromKey, req.GetForwardedFrom())
 		taskList, err := newTaskListID(domainID, taskListName, persistence.TaskListTypeDecision)
 		if err != nil {
 			return nil, err
 		}
 		taskListKind := common.TaskListKindPtr(request.TaskList.GetKind())
+		if req.GetForwardedFrom() == "" {
+			taskList, err = e.getVersionedTaskList(ctx, taskList, taskListKind, request.GetBinaryChecksum(), false)
+			if err != nil {
+				return nil, err
+			}
+		}
 		task, err := e.getTask(pollerCtx, taskList, nil, taskListKind)
 		if err != nil {
 			// TODO: Is empty poll the best reply for errPumpClosed?
@@ -404,6 +429,7 @@ pollLoop:
 		// long-poll when frontend calls CancelOutstandingPoll API
 		pollerCtx := context.WithValue(ctx, pollerIDKey, pollerID)
 		pollerCtx = context.WithValue(pollerCtx, identityKey, request.GetIdentity())
+		pollerCtx = context.WithValue(pollerCtx, forwardedFromKey, req.GetForwardedFrom())
 		taskListKind := common.TaskListKindPtr(request.TaskList.GetKind())
 		task, err := e.getTask(pollerCtx, taskList, maxDispatch, taskListKind)
 		if err != nil {
@@ -453,6 +479,12 @@ func (e *matchingEngineImpl) QueryWorkflow(ctx context.Context, queryRequest *m.
 	if err != nil {
 		return nil, err
 	}
+	if queryRequest.GetForwardedFrom() == "" {
+		taskList, err = e.getVersionedTaskList(ctx, taskList, taskListKind, queryRequest.GetBuildId(), true)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	tlMgr, err := e.getTaskListManager(taskList, taskListKind)
 	if err != nil {
@@ -560,12 +592,32 @@ func (e *matchingEngineImpl) DescribeTaskList(ctx context.Context, request *m.De
 	return tlMgr.DescribeTaskList(request.DescRequest.GetIncludeTaskListStatus()), nil
 }
 
+// GetTaskListStats returns the backlog and the add and dispatch rates of a task list partition
+func (e *matchingEngineImpl) GetTaskListStats(
+	ctx context.Context,
+	request *matchingservice.DescribeTaskListRequest,
+) (*adminservice.TaskListStats, error) {
+	taskListType := persistence.TaskListTypeDecision
+	if request.DescRequest.GetTaskListType() == enums.TaskListTypeActivity {
+		taskListType = persistence.TaskListTypeActivity
+	}
+	taskList, err := newTaskListID(request.GetDomainUUID(), request.DescRequest.TaskList.GetName(), taskListType)
+	if err != nil {
+		return nil, err
+	}
+	tlMgr, err := e.getTaskListManager(taskList, common.TaskListKindPtr(workflow.TaskListKind(request.DescRequest.TaskList.GetKind())))
+	if err != nil {
+		return nil, err
+	}
+	return tlMgr.GetStats(), nil
+}
+
 func (e *matchingEngineImpl) ListTaskListPartitions(ctx context.Context, request *m.ListTaskListPartitionsRequest) (*workflow.ListTaskListPartitionsResponse, error) {
-	activityTaskListInfo, err := e.listTaskListPartitions(request, persistence.TaskListTypeActivity)
+	activityTaskListInfo, err := e.listTaskListPartitions(ctx, request, persistence.TaskListTypeActivity)
 	if err != nil {
 		return nil, err
 	}
-	decisionTaskListInfo, err := e.listTaskListPartitions(request, persistence.TaskListTypeDecision)
+	decisionTaskListInfo, err := e.listTaskListPartitions(ctx, request, persistence.TaskListTypeDecision)
 	if err != nil {
 		return nil, err
 	}
@@ -576,8 +628,221 @@ func (e *matchingEngineImpl) ListTaskListPartitions(ctx context.Context, request
 	return &resp, nil
 }
 
-func (e *matchingEngineImpl) listTaskListPartitions(request *m.ListTaskListPartitionsRequest, taskListType int) ([]*workflow.TaskListPartitionMetadata, error) {
+// UpdateTaskListBuildIDs replaces the compatible build ID sets of a decision task list. Decision tasks of every
+// set are dispatched from a separate versioned task list, and only to workers running a build ID of that set.
+func (e *matchingEngineImpl) UpdateTaskListBuildIDs(
+	ctx context.Context,
+	request *matchingservice.UpdateTaskListBuildIDsRequest,
+) error {
+	taskList, err := newBuildIDsTaskListID(request.GetDomainUUID(), request.GetTaskList())
+	if err != nil {
+		return err
+	}
+	if !taskList.IsRoot() || taskList.IsVersioned() {
+		return &workflow.BadRequestError{Message: "Build IDs can only be updated on the task list name specified by the user."}
+	}
+	buildIDSets, err := validateBuildIDSets(request.GetBuildIdSets())
+	if err != nil {
+		return err
+	}
+
+	tlMgr, err := e.getTaskListManager(taskList, common.TaskListKindPtr(workflow.TaskListKindNormal))
+	if err != nil {
+		return err
+	}
+	if err := tlMgr.UpdateBuildIDSets(buildIDSets); err != nil {
+		return err
+	}
+	e.buildIDSetsCache.Delete(*taskList)
+	return nil
+}
+
+// GetTaskListBuildIDs returns the compatible build ID sets of a decision task list
+func (e *matchingEngineImpl) GetTaskListBuildIDs(
+	ctx context.Context,
+	request *matchingservice.GetTaskListBuildIDsRequest,
+) (*matchingservice.GetTaskListBuildIDsResponse, error) {
+	taskList, err := newBuildIDsTaskListID(request.GetDomainUUID(), request.GetTaskList())
+	if err != nil {
+		return nil, err
+	}
+	buildIDSets, err := e.getBuildIDSets(ctx, taskList)
+	if err != nil {
+		return nil, err
+	}
+	return &matchingservice.GetTaskListBuildIDsResponse{BuildIdSets: toProtoBuildIDSets(buildIDSets)}, nil
+}
+
+// getVersionedTaskList returns the task list holding the decision tasks of the given worker build ID.
+// Sticky and already versioned task lists, as well as build IDs outside of all sets, keep the given task list.
+func (e *matchingEngineImpl) getVersionedTaskList(
+	ctx context.Context,
+	taskList *taskListID,
+	taskListKind *workflow.TaskListKind,
+	buildID string,
+	useLatestForEmpty bool,
+) (*taskListID, error) {
+	if taskListKind != nil && *taskListKind == workflow.TaskListKindSticky || taskList.IsVersioned() {
+		return taskList, nil
+	}
+	buildIDSets, err := e.getBuildIDSets(ctx, taskList)
+	if err != nil {
+		return nil, err
+	}
+	buildIDSet, ok := findBuildIDSet(buildIDSets, buildID, useLatestForEmpty)
+	if !ok {
+		return taskList, nil
+	}
+	return newTaskListID(taskList.domainID, taskList.WithBuildIDSet(buildIDSet), persistence.TaskListTypeDecision)
+}
+
+// getBuildIDSets returns the compatible build ID sets stored on the root partition of the unversioned task list.
+// Other partitions ask the host owning the root partition and cache the result.
+func (e *matchingEngineImpl) getBuildIDSets(ctx context.Context, taskList *taskListID) ([][]string, error) {
+	root, err := newTaskListID(taskList.domainID, taskList.GetUnversionedRoot(), persistence.TaskListTypeDecision)
+	if err != nil {
+		return nil, err
+	}
+	if *root == *taskList {
+		tlMgr, err := e.getTaskListManager(root, common.TaskListKindPtr(workflow.TaskListKindNormal))
+		if err != nil {
+			return nil, err
+		}
+		return tlMgr.GetBuildIDSets(), nil
+	}
+
+	if buildIDSets, ok := e.buildIDSetsCache.Get(*root).([][]string); ok {
+		return buildIDSets, nil
+	}
+	resp, err := e.matchingClient.GetTaskListBuildIDs(ctx, &matchingservice.GetTaskListBuildIDsRequest{
+		DomainUUID: root.domainID,
+		TaskList: &commonproto.TaskList{
+			Name: root.name,
+			Kind: enums.TaskListKindNormal,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	buildIDSets := make([][]string, 0, len(resp.GetBuildIdSets()))
+	for _, set := range resp.GetBuildIdSets() {
+		buildIDSets = append(buildIDSets, set.GetBuildIds())
+	}
+	e.buildIDSetsCache.Put(*root, buildIDSets)
+	return buildIDSets, nil
+}
+
+// GetTaskListPartitionCounts returns the number of read and write partitions of a task list
+func (e *matchingEngineImpl) GetTaskListPartitionCounts(
+	ctx context.Context,
+	request *matchingservice.GetTaskListPartitionCountsRequest,
+) (*matchingservice.GetTaskListPartitionCountsResponse, error) {
+	taskListType := int(request.GetTaskListType())
+	if taskListType != persistence.TaskListTypeDecision && taskListType != persistence.TaskListTypeActivity {
+		return nil, &workflow.BadRequestError{Message: "Invalid task list type."}
+	}
+	if request.TaskList.GetKind() == enums.TaskListKindSticky {
+		return nil, &workflow.BadRequestError{Message: "Sticky task lists are not partitioned."}
+	}
+	taskList, err := newTaskListID(request.GetDomainUUID(), request.TaskList.GetName(), taskListType)
+	if err != nil {
+		return nil, &workflow.BadRequestError{Message: err.Error()}
+	}
+	numRead, numWrite, err := e.getPartitionCounts(ctx, taskList)
+	if err != nil {
+		return nil, err
+	}
+	return &matchingservice.GetTaskListPartitionCountsResponse{
+		NumReadPartitions:  int32(numRead),
+		NumWritePartitions: int32(numWrite),
+	}, nil
+}
+
+// getPartitionCounts returns the number of read and write partitions of a task list, which are stored on the root
+// partition of the unversioned task list. Other partitions ask the host owning the root partition and cache the
+// result. Failures are cached as well, so that the owner of the root partition isn't called on every lookup.
+func (e *matchingEngineImpl) getPartitionCounts(ctx context.Context, taskList *taskListID) (int, int, error) {
+	root, err := newTaskListID(taskList.domainID, taskList.GetUnversionedRoot(), taskList.taskType)
+	if err != nil {
+		return 0, 0, err
+	}
+	if *root == *taskList {
+		tlMgr, err := e.getTaskListManager(root, common.TaskListKindPtr(workflow.TaskListKindNormal))
+		if err != nil {
+			return 0, 0, err
+		}
+		numRead, numWrite := tlMgr.GetPartitionCounts()
+		return numRead, numWrite, nil
+	}
+
+	if counts, ok := e.partitionCountsCache.Get(*root).(partitionCounts); ok {
+		return counts.numRead, counts.numWrite, counts.err
+	}
+	counts := e.fetchPartitionCounts(ctx, root)
+	return counts.numRead, counts.numWrite, counts.err
+}
+
+// lookupPartitionCounts returns the cached partition counts of a task list without blocking. On a cache miss the
+// counts are fetched from the host owning the root partition in the background and false is returned until then.
+func (e *matchingEngineImpl) lookupPartitionCounts(taskList *taskListID) (int, int, bool) {
+	root, err := newTaskListID(taskList.domainID, taskList.GetUnversionedRoot(), taskList.taskType)
+	if err != nil {
+		return 0, 0, false
+	}
+	if counts, ok := e.partitionCountsCache.Get(*root).(partitionCounts); ok {
+		return counts.numRead, counts.numWrite, counts.err == nil
+	}
+
+	if _, loaded := e.partitionCountsLookups.LoadOrStore(*root, struct{}{}); !loaded {
+		go func() {
+			defer e.partitionCountsLookups.Delete(*root)
+			ctx, cancel := context.WithTimeout(context.Background(), partitionCountsLookupTimeout)
+			defer cancel()
+			if counts := e.fetchPartitionCounts(ctx, root); counts.err != nil {
+				e.logger.Warn("Failed to get task list partition counts",
+					tag.WorkflowTaskListName(root.name), tag.Error(counts.err))
+			}
+		}()
+	}
+	return 0, 0, false
+}
+
+// fetchPartitionCounts asks the host owning the root partition for its partition counts and caches the result
+func (e *matchingEngineImpl) fetchPartitionCounts(ctx context.Context, root *taskListID) partitionCounts {
+	resp, err := e.matchingClient.GetTaskListPartitionCounts(ctx, &matchingservice.GetTaskListPartitionCountsRequest{
+		DomainUUID: root.domainID,
+		TaskList: &commonproto.TaskList{
+			Name: root.name,
+			Kind: enums.TaskListKindNormal,
+		},
+		TaskListType: int32(root.taskType),
+	})
+	counts := partitionCounts{err: err}
+	if err == nil {
+		counts = partitionCounts{numRead: int(resp.GetNumReadPartitions()), numWrite: int(resp.GetNumWritePartitions())}
+	}
+	e.partitionCountsCache.Put(*root, counts)
+	return counts
+}
+
+func newBuildIDsTaskListID(domainID string, taskList *commonproto.TaskList) (*taskListID, error) {
+	if taskList.GetKind() == enums.TaskListKindSticky {
+		return nil, &workflow.BadRequestError{Message: "Build IDs are not supported on sticky task lists."}
+	}
+	id, err := newTaskListID(domainID, taskList.GetName(), persistence.TaskListTypeDecision)
+	if err != nil {
+		return nil, &workflow.BadRequestError{Message: err.Error()}
+	}
+	return id, nil
+}
+
+func (e *matchingEngineImpl) listTaskListPartitions(
+	ctx context.Context,
+	request *m.ListTaskListPartitionsRequest,
+	taskListType int,
+) ([]*workflow.TaskListPartitionMetadata, error) {
 	partitions, err := e.getAllPartitions(
+		ctx,
 		request.GetDomain(),
 		*request.TaskList,
 		taskListType,
@@ -585,19 +850,18 @@ func (e *matchingEngineImpl) listTaskListPartitions(request *m.ListTaskListParti
 	if err != nil {
 		return nil, err
 	}
-	partitionHostInfo := make([]*workflow.TaskListPartitionMetadata, len(partitions))
 
-	if err != nil {
-		return nil, err
-	}
+	partitionHostInfo := make([]*workflow.TaskListPartitionMetadata, 0, len(partitions))
 	for _, partition := range partitions {
-		if host, err := e.getHostInfo(partition); err != nil {
-			partitionHostInfo = append(partitionHostInfo,
-				&workflow.TaskListPartitionMetadata{
-					Key:           common.StringPtr(partition),
-					OwnerHostName: common.StringPtr(host),
-				})
+		host, err := e.getHostInfo(partition)
+		if err != nil {
+			return nil, err
 		}
+		partitionHostInfo = append(partitionHostInfo,
+			&workflow.TaskListPartitionMetadata{
+				Key:           common.StringPtr(partition),
+				OwnerHostName: common.StringPtr(host),
+			})
 	}
 	return partitionHostInfo, nil
 }
@@ -610,7 +874,10 @@ func (e *matchingEngineImpl) getHostInfo(partitionKey string) (string, error) {
 	return host.GetAddress(), nil
 }
 
+// getAllPartitions returns the names of all partitions of a task list that are polled, which
+// include the partitions that are no longer written to but not drained yet
 func (e *matchingEngineImpl) getAllPartitions(
+	ctx context.Context,
 	domain string,
 	taskList workflow.TaskList,
 	taskListType int,
@@ -620,13 +887,20 @@ func (e *matchingEngineImpl) getAllPartitions(
 	if err != nil {
 		return partitionKeys, err
 	}
-	taskListID, err := newTaskListID(domainID, taskList.GetName(), persistence.TaskListTypeDecision)
+	taskListID, err := newTaskListID(domainID, taskList.GetName(), taskListType)
+	if err != nil {
+		return partitionKeys, &workflow.BadRequestError{Message: err.Error()}
+	}
 	rootPartition := taskListID.GetRoot()
 
 	partitionKeys = append(partitionKeys, rootPartition)
 
-	nWritePartitions := e.config.GetTasksBatchSize
-	n := nWritePartitions(domain, rootPartition, taskListType)
+	n := e.config.NumTasklistReadPartitions(domain, rootPartition, taskListType)
+	if e.config.EnablePartitionAutoScaling(domain, rootPartition, taskListType) {
+		if n, _, err = e.getPartitionCounts(ctx, taskListID); err != nil {
+			return nil, err
+		}
+	}
 	if n <= 0 {
 		return partitionKeys, nil
 	}
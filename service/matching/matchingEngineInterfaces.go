@@ -25,6 +25,8 @@ import (
 
 	m "github.com/temporalio/temporal/.gen/go/matching"
 	workflow "github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	"github.com/temporalio/temporal/.gen/proto/matchingservice"
 )
 
 type (
@@ -39,6 +41,10 @@ type (
 		RespondQueryTaskCompleted(ctx context.Context, request *m.RespondQueryTaskCompletedRequest) error
 		CancelOutstandingPoll(ctx context.Context, request *m.CancelOutstandingPollRequest) error
 		DescribeTaskList(ctx context.Context, request *m.DescribeTaskListRequest) (*workflow.DescribeTaskListResponse, error)
+		GetTaskListStats(ctx context.Context, request *matchingservice.DescribeTaskListRequest) (*adminservice.TaskListStats, error)
 		ListTaskListPartitions(ctx context.Context, request *m.ListTaskListPartitionsRequest) (*workflow.ListTaskListPartitionsResponse, error)
+		UpdateTaskListBuildIDs(ctx context.Context, request *matchingservice.UpdateTaskListBuildIDsRequest) error
+		GetTaskListBuildIDs(ctx context.Context, request *matchingservice.GetTaskListBuildIDsRequest) (*matchingservice.GetTaskListBuildIDsResponse, error)
+		GetTaskListPartitionCounts(ctx context.Context, request *matchingservice.GetTaskListPartitionCountsRequest) (*matchingservice.GetTaskListPartitionCountsResponse, error)
 	}
 )
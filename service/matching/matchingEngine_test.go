@@ -31,11 +31,17 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	commonproto "go.temporal.io/temporal-proto/common"
+	"go.temporal.io/temporal-proto/enums"
+	"google.golang.org/grpc"
 
 	gohistory "github.com/temporalio/temporal/.gen/go/history"
 	"github.com/temporalio/temporal/.gen/go/history/historyservicetest"
 	"github.com/temporalio/temporal/.gen/go/matching"
 	workflow "github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	"github.com/temporalio/temporal/.gen/proto/matchingservice"
+	"github.com/temporalio/temporal/.gen/proto/matchingservicemock"
 	"github.com/temporalio/temporal/client/history"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/log"
@@ -136,14 +142,16 @@ func newMatchingEngine(
 	logger log.Logger, mockDomainCache cache.DomainCache,
 ) *matchingEngineImpl {
 	return &matchingEngineImpl{
-		taskManager:     taskMgr,
-		historyService:  mockHistoryClient,
-		taskLists:       make(map[taskListID]taskListManager),
-		logger:          logger,
-		metricsClient:   metrics.NewClient(tally.NoopScope, metrics.Matching),
-		tokenSerializer: common.NewJSONTaskTokenSerializer(),
-		config:          config,
-		domainCache:     mockDomainCache,
+		taskManager:          taskMgr,
+		historyService:       mockHistoryClient,
+		taskLists:            make(map[taskListID]taskListManager),
+		logger:               logger,
+		metricsClient:        metrics.NewClient(tally.NoopScope, metrics.Matching),
+		tokenSerializer:      common.NewJSONTaskTokenSerializer(),
+		config:               config,
+		domainCache:          mockDomainCache,
+		buildIDSetsCache:     cache.New(buildIDSetsCacheMaxSize, &cache.Options{TTL: config.BuildIDSetsCacheTTL()}),
+		partitionCountsCache: cache.New(partitionCountsCacheMaxSize, &cache.Options{TTL: config.PartitionCountsCacheTTL()}),
 	}
 }
 
@@ -420,6 +428,151 @@ func (s *matchingEngineSuite) AddTasksTest(taskType int) {
 	s.EqualValues(taskCount, s.taskManager.getTaskCount(newTestTaskListID(domainID, tl, taskType)))
 }
 
+func (s *matchingEngineSuite) TestGetTaskListPartitionCounts() {
+	s.matchingEngine.config.EnablePartitionAutoScaling = dynamicconfig.GetBoolPropertyFnFilteredByTaskListInfo(true)
+	domainID := "domainId"
+	tl := "makeToast"
+	tlID := newTestTaskListID(domainID, tl, persistence.TaskListTypeActivity)
+	request := &matchingservice.GetTaskListPartitionCountsRequest{
+		DomainUUID:   domainID,
+		TaskList:     &commonproto.TaskList{Name: tl},
+		TaskListType: persistence.TaskListTypeActivity,
+	}
+
+	// counts from dynamic config are used until partitions are scaled
+	resp, err := s.matchingEngine.GetTaskListPartitionCounts(context.Background(), request)
+	s.NoError(err)
+	s.EqualValues(1, resp.GetNumReadPartitions())
+	s.EqualValues(1, resp.GetNumWritePartitions())
+
+	tlMgr, err := s.matchingEngine.getTaskListManager(tlID, common.TaskListKindPtr(workflow.TaskListKindNormal))
+	s.NoError(err)
+	s.NoError(tlMgr.(*taskListManagerImpl).updatePartitionCounts(4, 2))
+
+	resp, err = s.matchingEngine.GetTaskListPartitionCounts(context.Background(), request)
+	s.NoError(err)
+	s.EqualValues(4, resp.GetNumReadPartitions())
+	s.EqualValues(2, resp.GetNumWritePartitions())
+	s.Equal(4, s.taskManager.getTaskListManager(tlID).numReadPartitions)
+	s.Equal(2, s.taskManager.getTaskListManager(tlID).numWritePartitions)
+
+	// partition counts are ignored when auto scaling is disabled
+	s.matchingEngine.config.EnablePartitionAutoScaling = dynamicconfig.GetBoolPropertyFnFilteredByTaskListInfo(false)
+	resp, err = s.matchingEngine.GetTaskListPartitionCounts(context.Background(), request)
+	s.NoError(err)
+	s.EqualValues(1, resp.GetNumReadPartitions())
+	s.EqualValues(1, resp.GetNumWritePartitions())
+
+	request.TaskList.Kind = enums.TaskListKindSticky
+	_, err = s.matchingEngine.GetTaskListPartitionCounts(context.Background(), request)
+	s.IsType(&workflow.BadRequestError{}, err)
+}
+
+func (s *matchingEngineSuite) TestPartitionCountsOfChildPartitions() {
+	s.matchingEngine.config.EnablePartitionAutoScaling = dynamicconfig.GetBoolPropertyFnFilteredByTaskListInfo(true)
+	mockMatchingClient := matchingservicemock.NewMockMatchingServiceClient(s.controller)
+	s.matchingEngine.matchingClient = mockMatchingClient
+	tlID := newTestTaskListID("domainId", taskListPartitionPrefix+"makeToast/1", persistence.TaskListTypeActivity)
+	rootID := newTestTaskListID("domainId", "makeToast", persistence.TaskListTypeActivity)
+
+	// failed lookups are cached, so that the owner of the root partition isn't called every time
+	mockMatchingClient.EXPECT().GetTaskListPartitionCounts(gomock.Any(), gomock.Any()).
+		Return(nil, &workflow.InternalServiceError{Message: "unavailable"}).Times(1)
+	for i := 0; i < 2; i++ {
+		_, _, err := s.matchingEngine.getPartitionCounts(context.Background(), tlID)
+		s.IsType(&workflow.InternalServiceError{}, err)
+		_, _, ok := s.matchingEngine.lookupPartitionCounts(tlID)
+		s.False(ok)
+	}
+
+	// lookups don't block and fetch the counts in the background
+	s.matchingEngine.partitionCountsCache.Delete(*rootID)
+	fetched := make(chan struct{})
+	mockMatchingClient.EXPECT().GetTaskListPartitionCounts(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, request *matchingservice.GetTaskListPartitionCountsRequest, opts ...grpc.CallOption) (*matchingservice.GetTaskListPartitionCountsResponse, error) {
+			defer close(fetched)
+			s.Equal("makeToast", request.TaskList.GetName())
+			return &matchingservice.GetTaskListPartitionCountsResponse{NumReadPartitions: 4, NumWritePartitions: 2}, nil
+		}).Times(1)
+	_, _, ok := s.matchingEngine.lookupPartitionCounts(tlID)
+	s.False(ok)
+	<-fetched
+	s.Eventually(func() bool {
+		numRead, numWrite, ok := s.matchingEngine.lookupPartitionCounts(tlID)
+		return ok && numRead == 4 && numWrite == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func (s *matchingEngineSuite) TestAddDecisionTasksWithBuildIDSets() {
+	domainID := "domainId"
+	tl := "makeToast"
+	taskList := &workflow.TaskList{Name: &tl}
+
+	err := s.matchingEngine.UpdateTaskListBuildIDs(context.Background(), &matchingservice.UpdateTaskListBuildIDsRequest{
+		DomainUUID: domainID,
+		TaskList:   &commonproto.TaskList{Name: tl},
+		BuildIdSets: []*adminservice.BuildIdSet{
+			{BuildIds: []string{"1.0", "1.1"}},
+			{BuildIds: []string{"2.0"}},
+		},
+	})
+	s.NoError(err)
+
+	resp, err := s.matchingEngine.GetTaskListBuildIDs(context.Background(), &matchingservice.GetTaskListBuildIDsRequest{
+		DomainUUID: domainID,
+		TaskList:   &commonproto.TaskList{Name: tl},
+	})
+	s.NoError(err)
+	s.Equal(2, len(resp.GetBuildIdSets()))
+	s.Equal([]string{"1.0", "1.1"}, resp.GetBuildIdSets()[0].GetBuildIds())
+	s.Equal([]string{"2.0"}, resp.GetBuildIdSets()[1].GetBuildIds())
+
+	runID := "run1"
+	workflowID := "workflow1"
+	execution := workflow.WorkflowExecution{RunId: &runID, WorkflowId: &workflowID}
+	for i, buildID := range []string{"", "1.1", "1.0", "0.9"} {
+		_, err = s.matchingEngine.AddDecisionTask(context.Background(), &matching.AddDecisionTaskRequest{
+			DomainUUID:                    common.StringPtr(domainID),
+			Execution:                     &execution,
+			ScheduleId:                    common.Int64Ptr(int64(i)),
+			TaskList:                      taskList,
+			ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
+			BuildId:                       common.StringPtr(buildID),
+		})
+		s.NoError(err)
+	}
+
+	s.EqualValues(1, s.taskManager.getTaskCount(newTestTaskListID(domainID, tl+"/__build/2.0", persistence.TaskListTypeDecision)))
+	s.EqualValues(2, s.taskManager.getTaskCount(newTestTaskListID(domainID, tl+"/__build/1.0", persistence.TaskListTypeDecision)))
+	s.EqualValues(1, s.taskManager.getTaskCount(newTestTaskListID(domainID, tl, persistence.TaskListTypeDecision)))
+}
+
+func (s *matchingEngineSuite) TestUpdateTaskListBuildIDsValidation() {
+	domainID := "domainId"
+	tl := "makeToast"
+
+	testCases := []struct {
+		taskList *commonproto.TaskList
+		sets     []*adminservice.BuildIdSet
+	}{
+		{&commonproto.TaskList{Name: tl, Kind: enums.TaskListKindSticky}, nil},
+		{&commonproto.TaskList{Name: tl + "/__build/1.0"}, nil},
+		{&commonproto.TaskList{Name: "/__cadence_sys/" + tl + "/1"}, nil},
+		{&commonproto.TaskList{Name: tl}, []*adminservice.BuildIdSet{{}}},
+		{&commonproto.TaskList{Name: tl}, []*adminservice.BuildIdSet{{BuildIds: []string{""}}}},
+		{&commonproto.TaskList{Name: tl}, []*adminservice.BuildIdSet{{BuildIds: []string{"1.0,1.1"}}}},
+		{&commonproto.TaskList{Name: tl}, []*adminservice.BuildIdSet{{BuildIds: []string{"1.0"}}, {BuildIds: []string{"1.0"}}}},
+	}
+	for _, tc := range testCases {
+		err := s.matchingEngine.UpdateTaskListBuildIDs(context.Background(), &matchingservice.UpdateTaskListBuildIDsRequest{
+			DomainUUID:  domainID,
+			TaskList:    tc.taskList,
+			BuildIdSets: tc.sets,
+		})
+		s.IsType(&workflow.BadRequestError{}, err)
+	}
+}
+
 func (s *matchingEngineSuite) TestTaskWriterShutdown() {
 	s.matchingEngine.config.RangeSize = 300 // override to low number for the test
 
@@ -1707,10 +1860,13 @@ func (m *testTaskManager) getTaskListManager(id *taskListID) *testTaskListManage
 
 type testTaskListManager struct {
 	sync.Mutex
-	rangeID         int64
-	ackLevel        int64
-	createTaskCount int
-	tasks           *treemap.Map
+	rangeID            int64
+	ackLevel           int64
+	createTaskCount    int
+	buildIDSets        [][]string
+	numReadPartitions  int
+	numWritePartitions int
+	tasks              *treemap.Map
 }
 
 func Int64Comparator(a, b interface{}) int {
@@ -1748,12 +1904,15 @@ func (m *testTaskManager) LeaseTaskList(request *persistence.LeaseTaskListReques
 
 	return &persistence.LeaseTaskListResponse{
 		TaskListInfo: &persistence.TaskListInfo{
-			AckLevel: tlm.ackLevel,
-			DomainID: request.DomainID,
-			Name:     request.TaskList,
-			TaskType: request.TaskType,
-			RangeID:  tlm.rangeID,
-			Kind:     request.TaskListKind,
+			AckLevel:           tlm.ackLevel,
+			DomainID:           request.DomainID,
+			Name:               request.TaskList,
+			TaskType:           request.TaskType,
+			RangeID:            tlm.rangeID,
+			Kind:               request.TaskListKind,
+			BuildIDSets:        tlm.buildIDSets,
+			NumReadPartitions:  tlm.numReadPartitions,
+			NumWritePartitions: tlm.numWritePartitions,
 		},
 	}, nil
 }
@@ -1773,6 +1932,9 @@ func (m *testTaskManager) UpdateTaskList(request *persistence.UpdateTaskListRequ
 		}
 	}
 	tlm.ackLevel = tli.AckLevel
+	tlm.buildIDSets = tli.BuildIDSets
+	tlm.numReadPartitions = tli.NumReadPartitions
+	tlm.numWritePartitions = tli.NumWritePartitions
 	return &persistence.UpdateTaskListResponse{}, nil
 }
 
@@ -120,3 +120,27 @@ func (h *NilCheckHandler) ListTaskListPartitions(ctx context.Context, request *m
 	}
 	return resp, err
 }
+
+func (h *NilCheckHandler) UpdateTaskListBuildIDs(ctx context.Context, request *matchingservice.UpdateTaskListBuildIDsRequest) (*matchingservice.UpdateTaskListBuildIDsResponse, error) {
+	resp, err := h.parentHandler.UpdateTaskListBuildIDs(ctx, request)
+	if resp == nil && err == nil {
+		return &matchingservice.UpdateTaskListBuildIDsResponse{}, err
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) GetTaskListBuildIDs(ctx context.Context, request *matchingservice.GetTaskListBuildIDsRequest) (*matchingservice.GetTaskListBuildIDsResponse, error) {
+	resp, err := h.parentHandler.GetTaskListBuildIDs(ctx, request)
+	if resp == nil && err == nil {
+		return &matchingservice.GetTaskListBuildIDsResponse{}, err
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) GetTaskListPartitionCounts(ctx context.Context, request *matchingservice.GetTaskListPartitionCountsRequest) (*matchingservice.GetTaskListPartitionCountsResponse, error) {
+	resp, err := h.parentHandler.GetTaskListPartitionCounts(ctx, request)
+	if resp == nil && err == nil {
+		return &matchingservice.GetTaskListPartitionCountsResponse{}, err
+	}
+	return resp, err
+}
@@ -0,0 +1,270 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+
+	commonproto "go.temporal.io/temporal-proto/common"
+	"go.temporal.io/temporal-proto/enums"
+	"go.temporal.io/temporal-proto/workflowservice"
+
+	"github.com/temporalio/temporal/.gen/proto/matchingservice"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	// partitionScaler runs on the root partition of a task list and scales the number
+	// of partitions with the add and dispatch rate of the task list. The counts are
+	// persisted with the root partition and picked up by the matching load balancer
+	partitionScaler struct {
+		tlMgr         *taskListManagerImpl
+		addCount      int64 // tasks added by callers since the last evaluation
+		dispatchCount int64 // tasks dispatched to pollers since the last evaluation
+		lastEvalTime  time.Time
+		// writeDecreaseTime is when write partitions were last removed, callers may write to them until their cache expires
+		writeDecreaseTime time.Time
+	}
+
+	// partitionCounts are the number of read and write partitions of a task list, or the error of looking them up
+	partitionCounts struct {
+		numRead  int
+		numWrite int
+		err      error
+	}
+)
+
+const (
+	partitionDrainCheckTimeout  = 5 * time.Second
+	partitionCountsCacheMaxSize = 10000
+)
+
+func newPartitionScaler(tlMgr *taskListManagerImpl) *partitionScaler {
+	return &partitionScaler{
+		tlMgr:        tlMgr,
+		lastEvalTime: time.Now(),
+	}
+}
+
+func (ps *partitionScaler) Start() {
+	go ps.scaleLoop()
+}
+
+// recordAdd records a task added to the root partition by a caller, as opposed to a task forwarded by a child partition
+func (ps *partitionScaler) recordAdd() {
+	atomic.AddInt64(&ps.addCount, 1)
+}
+
+// recordDispatch records a task dispatched to a poller of the root partition, as opposed to a poll forwarded by a child partition
+func (ps *partitionScaler) recordDispatch() {
+	atomic.AddInt64(&ps.dispatchCount, 1)
+}
+
+func (ps *partitionScaler) scaleLoop() {
+	timer := time.NewTimer(ps.interval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ps.tlMgr.shutdownCh:
+			return
+		case <-timer.C:
+			if err := ps.scale(); err != nil {
+				ps.tlMgr.logger.Warn("Failed to scale task list partitions", tag.Error(err))
+			}
+			timer.Reset(ps.interval())
+		}
+	}
+}
+
+// interval returns how often the partition counts are reevaluated. Every step has to be seen by all callers
+// before the next one, so the interval is at least twice as long as callers cache the counts.
+func (ps *partitionScaler) interval() time.Duration {
+	interval := ps.tlMgr.config.PartitionAutoScalingInterval()
+	if minInterval := 2 * ps.tlMgr.engine.config.PartitionCountsCacheTTL(); interval < minInterval {
+		return minInterval
+	}
+	return interval
+}
+
+// scale moves the partition counts one step towards the number of partitions needed for the current load
+func (ps *partitionScaler) scale() error {
+	now := time.Now()
+	elapsed := now.Sub(ps.lastEvalTime).Seconds()
+	ps.lastEvalTime = now
+	adds := atomic.SwapInt64(&ps.addCount, 0)
+	dispatches := atomic.SwapInt64(&ps.dispatchCount, 0)
+	if !ps.tlMgr.config.EnablePartitionAutoScaling() {
+		return ps.retire(now)
+	}
+	if elapsed <= 0 {
+		return nil
+	}
+
+	numRead, numWrite := ps.tlMgr.GetPartitionCounts()
+	// load balancing spreads adds evenly across write partitions and polls evenly across read partitions
+	rate := math.Max(float64(adds)*float64(numWrite), float64(dispatches)*float64(numRead)) / elapsed
+	desired := desiredPartitionCount(rate, ps.tlMgr.config.PartitionAutoScalingTargetRPS(), ps.tlMgr.config.MaxAutoScaledPartitions())
+	if desired < numWrite && ps.tlMgr.taskAckManager.getBacklogCountHint() > int64(ps.tlMgr.config.AutoScalingBacklogThreshold()) {
+		// keep the partitions while the backlog is being dispatched
+		desired = numWrite
+	}
+
+	drained := false
+	if desired <= numWrite && numRead > numWrite && now.Sub(ps.writeDecreaseTime) > ps.tlMgr.engine.config.PartitionCountsCacheTTL() {
+		var err error
+		if drained, err = ps.isDrained(numWrite, numRead); err != nil {
+			return err
+		}
+	}
+
+	newRead, newWrite := nextPartitionCounts(numRead, numWrite, desired, drained)
+	if newRead == numRead && newWrite == numWrite {
+		return nil
+	}
+	ps.tlMgr.logger.Info(fmt.Sprintf("Scaling task list partitions, read partitions %v -> %v, write partitions %v -> %v",
+		numRead, newRead, numWrite, newWrite))
+	if err := ps.tlMgr.updatePartitionCounts(newRead, newWrite); err != nil {
+		return err
+	}
+	if newWrite < numWrite {
+		ps.writeDecreaseTime = now
+	}
+	return nil
+}
+
+// retire clears the persisted partition counts once partition auto scaling is disabled. Callers go back to the
+// counts from dynamic config, so the persisted read partitions beyond those are no longer polled. Their tasks are
+// forwarded to the root partition and the counts are only cleared once those partitions are drained.
+func (ps *partitionScaler) retire(now time.Time) error {
+	ps.tlMgr.startWG.Wait()
+	persistedRead, persistedWrite := ps.tlMgr.db.PartitionCounts()
+	if persistedWrite <= 0 {
+		ps.writeDecreaseTime = time.Time{}
+		return nil
+	}
+	if ps.writeDecreaseTime.IsZero() {
+		// callers may still write to the persisted partitions until their cache expires
+		ps.writeDecreaseTime = now
+		return nil
+	}
+	if now.Sub(ps.writeDecreaseTime) <= ps.tlMgr.engine.config.PartitionCountsCacheTTL() {
+		return nil
+	}
+
+	if numRead := ps.tlMgr.config.NumReadPartitions(); persistedRead > numRead {
+		drained, err := ps.isDrained(numRead, persistedRead)
+		if err != nil || !drained {
+			return err
+		}
+	}
+	ps.tlMgr.logger.Info(fmt.Sprintf("Partition auto scaling is disabled, clearing partition counts, read partitions %v, write partitions %v",
+		persistedRead, persistedWrite))
+	if err := ps.tlMgr.updatePartitionCounts(0, 0); err != nil {
+		return err
+	}
+	ps.writeDecreaseTime = time.Time{}
+	return nil
+}
+
+// isDrained returns true if the partitions in [from, to) have no tasks left. Those partitions no longer
+// receive tasks, but are still polled until the read partition count is lowered. Describing a partition
+// also loads it, so that its backlog is dispatched or forwarded to the root partition.
+func (ps *partitionScaler) isDrained(from int, to int) (bool, error) {
+	id := ps.tlMgr.taskListID
+	taskListType := enums.TaskListTypeDecision
+	if id.taskType == persistence.TaskListTypeActivity {
+		taskListType = enums.TaskListTypeActivity
+	}
+
+	for p := from; p < to; p++ {
+		name := id.mkName(p)
+		ctx, cancel := context.WithTimeout(context.Background(), partitionDrainCheckTimeout)
+		resp, err := ps.tlMgr.engine.matchingClient.DescribeTaskList(ctx, &matchingservice.DescribeTaskListRequest{
+			DomainUUID: id.domainID,
+			DescRequest: &workflowservice.DescribeTaskListRequest{
+				TaskList: &commonproto.TaskList{
+					Name: name,
+					Kind: enums.TaskListKindNormal,
+				},
+				TaskListType:          taskListType,
+				IncludeTaskListStatus: true,
+			},
+		})
+		cancel()
+		if err != nil {
+			return false, err
+		}
+
+		// tasks above the ack level of the partition are either not read yet or not completed yet
+		tasks, err := ps.tlMgr.engine.taskManager.GetTasks(&persistence.GetTasksRequest{
+			DomainID:     id.domainID,
+			TaskList:     name,
+			TaskType:     id.taskType,
+			ReadLevel:    resp.GetTaskListStatus().GetAckLevel(),
+			MaxReadLevel: common.Int64Ptr(math.MaxInt64),
+			BatchSize:    1,
+		})
+		if err != nil {
+			return false, err
+		}
+		if len(tasks.Tasks) > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// desiredPartitionCount returns the number of partitions needed to handle the given rate
+func desiredPartitionCount(rate float64, targetRPS int, maxPartitions int) int {
+	desired := int(math.Ceil(rate / float64(targetRPS)))
+	return common.MaxInt(1, common.MinInt(desired, maxPartitions))
+}
+
+// nextPartitionCounts returns the partition counts to move to in one step towards the desired number of write
+// partitions. Read partitions are added before write partitions and write partitions are removed one at a time
+// before read partitions, so that every partition receiving tasks has pollers. Read partitions are only removed
+// once the partitions that are no longer written to are drained.
+func nextPartitionCounts(numRead int, numWrite int, desired int, drained bool) (int, int) {
+	if numRead < numWrite {
+		return numWrite, numWrite
+	}
+
+	switch {
+	case desired > numWrite:
+		if numRead < desired {
+			return desired, numWrite
+		}
+		return numRead, desired
+	case desired < numWrite:
+		return numRead, numWrite - 1
+	case numRead > numWrite && drained:
+		return numWrite, numWrite
+	default:
+		return numRead, numWrite
+	}
+}
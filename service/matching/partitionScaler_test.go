@@ -0,0 +1,77 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDesiredPartitionCount(t *testing.T) {
+	testCases := []struct {
+		rate          float64
+		targetRPS     int
+		maxPartitions int
+		output        int
+	}{
+		{0, 100, 10, 1},
+		{50, 100, 10, 1},
+		{100, 100, 10, 1},
+		{101, 100, 10, 2},
+		{450, 100, 10, 5},
+		{5000, 100, 10, 10},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.output, desiredPartitionCount(tc.rate, tc.targetRPS, tc.maxPartitions), "%+v", tc)
+	}
+}
+
+func TestNextPartitionCounts(t *testing.T) {
+	testCases := []struct {
+		name      string
+		numRead   int
+		numWrite  int
+		desired   int
+		drained   bool
+		nextRead  int
+		nextWrite int
+	}{
+		{"unchanged", 2, 2, 2, false, 2, 2},
+		{"read partitions lower than write partitions", 1, 3, 3, false, 3, 3},
+		{"scale up adds read partitions first", 2, 2, 4, false, 4, 2},
+		{"scale up adds write partitions to added read partitions", 4, 2, 4, false, 4, 4},
+		{"scale up while draining reuses read partitions", 4, 2, 3, false, 4, 3},
+		{"scale down removes one write partition", 4, 4, 1, false, 4, 3},
+		{"scale down waits for drain", 4, 2, 2, false, 4, 2},
+		{"scale down removes drained read partitions", 4, 2, 2, true, 2, 2},
+		{"scale down removes write partitions before drained read partitions", 4, 2, 1, true, 4, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nextRead, nextWrite := nextPartitionCounts(tc.numRead, tc.numWrite, tc.desired, tc.drained)
+			require.Equal(t, tc.nextRead, nextRead)
+			require.Equal(t, tc.nextWrite, nextWrite)
+		})
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/temporalio/temporal/.gen/proto/healthservice"
 	"github.com/temporalio/temporal/.gen/proto/matchingservice"
 	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/health"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/persistence"
@@ -35,15 +36,18 @@ import (
 	"github.com/temporalio/temporal/common/resource"
 	"github.com/temporalio/temporal/common/service"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
+	"github.com/temporalio/temporal/common/tracing"
 )
 
 // Service represents the cadence-matching service
 type Service struct {
 	resource.Resource
 
-	status  int32
-	handler *Handler
-	config  *Config
+	status       int32
+	handler      *Handler
+	params       *service.BootstrapParams
+	config       *Config
+	healthServer *health.Server
 
 	server *grpc.Server
 }
@@ -74,9 +78,11 @@ func NewService(
 	}
 
 	return &Service{
-		Resource: serviceResource,
-		status:   common.DaemonStatusInitialized,
-		config:   serviceConfig,
+		Resource:     serviceResource,
+		status:       common.DaemonStatusInitialized,
+		params:       params,
+		config:       serviceConfig,
+		healthServer: health.NewServer("matchingservice.MatchingService", params.HealthConfig, serviceResource.GetLogger()),
 	}, nil
 }
 
@@ -95,11 +101,18 @@ func (s *Service) Start() {
 	s.Resource.Start()
 	s.handler.Start()
 
-	s.server = grpc.NewServer()
+	interceptors := append([]grpc.UnaryServerInterceptor{tracing.NewServerInterceptor()}, s.params.Interceptors...)
+	s.server = grpc.NewServer(grpc.UnaryInterceptor(common.ChainUnaryServerInterceptors(interceptors...)))
 	handlerGRPC := NewHandlerGRPC(s.handler)
 	nilCheckHandler := NewNilCheckHandler(handlerGRPC)
 	matchingservice.RegisterMatchingServiceServer(s.server, nilCheckHandler)
 	healthservice.RegisterMetaServer(s.server, handlerGRPC)
+	s.healthServer.RegisterGRPC(s.server)
+
+	// matching is ready once it owns task lists, which requires to be part of the membership ring
+	s.healthServer.AddCheck("membership", health.MembershipCheck(s.GetMembershipMonitor(), common.MatchingServiceName))
+	s.healthServer.AddCheck("persistence", health.PersistenceCheck(s.GetMetadataManager()))
+	s.healthServer.Start()
 
 	listener := s.GetGRPCListener()
 	logger.Info("Starting to serve on matching listener")
@@ -114,6 +127,7 @@ func (s *Service) Stop() {
 		return
 	}
 
+	s.healthServer.Stop()
 	s.server.GracefulStop()
 
 	s.handler.Stop()
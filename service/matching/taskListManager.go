@@ -30,6 +30,7 @@ import (
 
 	"github.com/temporalio/temporal/.gen/go/matching"
 	s "github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/backoff"
 	"github.com/temporalio/temporal/common/cache"
@@ -73,6 +74,14 @@ type (
 		GetAllPollerInfo() []*s.PollerInfo
 		// DescribeTaskList returns information about the target tasklist
 		DescribeTaskList(includeTaskListStatus bool) *s.DescribeTaskListResponse
+		// GetStats returns the backlog and the add and dispatch rates of the tasklist
+		GetStats() *adminservice.TaskListStats
+		// GetBuildIDSets returns the compatible build ID sets of the tasklist, ordered from oldest to latest
+		GetBuildIDSets() [][]string
+		// UpdateBuildIDSets replaces the compatible build ID sets of the tasklist
+		UpdateBuildIDSets(buildIDSets [][]string) error
+		// GetPartitionCounts returns the number of read and write partitions of the tasklist
+		GetPartitionCounts() (numRead int, numWrite int)
 		String() string
 	}
 
@@ -86,8 +95,10 @@ type (
 		taskWriter       *taskWriter
 		taskReader       *taskReader // reads tasks from db and async matches it with poller
 		taskGC           *taskGC
-		taskAckManager   ackManager   // tracks ackLevel for delivered messages
-		matcher          *TaskMatcher // for matching a task producer with a poller
+		taskAckManager   ackManager       // tracks ackLevel for delivered messages
+		matcher          *TaskMatcher     // for matching a task producer with a poller
+		scaler           *partitionScaler // scales the number of partitions, only set on root partitions
+		stats            *taskListStats   // add and dispatch rates reported by DescribeTaskList
 		domainCache      cache.DomainCache
 		logger           log.Logger
 		metricsClient    metrics.Client
@@ -112,6 +123,8 @@ type (
 const (
 	// maxSyncMatchWaitTime is the max amount of time that we are willing to wait for a sync match to happen
 	maxSyncMatchWaitTime = 200 * time.Millisecond
+	// partitionCountsLookupTimeout is the max amount of time that partitions wait for the counts of the root partition
+	partitionCountsLookupTimeout = time.Second
 )
 
 var _ taskListManager = (*taskListManagerImpl)(nil)
@@ -146,6 +159,7 @@ func newTaskListManager(
 		taskGC:              newTaskGC(db, taskListConfig),
 		config:              taskListConfig,
 		pollerHistory:       newPollerHistory(),
+		stats:               newTaskListStats(time.Now()),
 		outstandingPollsMap: make(map[string]context.CancelFunc),
 		taskListKind:        int(*taskListKind),
 	}
@@ -159,6 +173,10 @@ func newTaskListManager(
 		fwdr = newForwarder(&taskListConfig.forwarderConfig, taskList, *taskListKind, e.matchingClient, tlMgr.domainScope)
 	}
 	tlMgr.matcher = newTaskMatcher(taskListConfig, fwdr, tlMgr.domainScope)
+	tlMgr.matcher.numPartitions = tlMgr.numReadPartitions
+	if tlMgr.isPartitionAutoScalingRoot() {
+		tlMgr.scaler = newPartitionScaler(tlMgr)
+	}
 	tlMgr.startWG.Add(1)
 	return tlMgr, nil
 }
@@ -178,6 +196,9 @@ func (c *taskListManagerImpl) Start() error {
 	c.taskAckManager.setAckLevel(state.ackLevel)
 	c.taskWriter.Start(c.rangeIDToTaskIDBlock(state.rangeID))
 	c.taskReader.Start()
+	if c.scaler != nil {
+		c.scaler.Start()
+	}
 
 	return nil
 }
@@ -225,6 +246,14 @@ func (c *taskListManagerImpl) AddTask(ctx context.Context, params addTaskParams)
 	})
 	if err == nil {
 		c.taskReader.Signal()
+		if params.forwardedFrom != "" {
+			c.stats.recordForwardedAdd()
+		} else {
+			c.stats.recordAdd(time.Now(), syncMatch)
+			if c.scaler != nil {
+				c.scaler.recordAdd()
+			}
+		}
 	}
 	return syncMatch, err
 }
@@ -262,6 +291,14 @@ func (c *taskListManagerImpl) GetTask(
 	}
 	task.domainName = c.domainName()
 	task.backlogCountHint = c.taskAckManager.getBacklogCountHint()
+	if forwardedFrom, _ := ctx.Value(forwardedFromKey).(string); forwardedFrom != "" {
+		c.stats.recordForwardedDispatch()
+	} else {
+		c.stats.recordDispatch(time.Now())
+		if c.scaler != nil {
+			c.scaler.recordDispatch()
+		}
+	}
 	return task, nil
 }
 
@@ -350,6 +387,73 @@ func (c *taskListManagerImpl) DescribeTaskList(includeTaskListStatus bool) *s.De
 	return response
 }
 
+// GetStats returns the backlog of the tasklist and its add and dispatch rates over the last minute
+func (c *taskListManagerImpl) GetStats() *adminservice.TaskListStats {
+	now := time.Now()
+	stats := c.stats.snapshot(now)
+	stats.BacklogCountHint = c.taskAckManager.getBacklogCountHint()
+	stats.BacklogAgeMillis = c.taskReader.backlogAge(now).Nanoseconds() / int64(time.Millisecond)
+	return stats
+}
+
+// GetBuildIDSets returns the compatible build ID sets of the tasklist, ordered from oldest to latest
+func (c *taskListManagerImpl) GetBuildIDSets() [][]string {
+	c.startWG.Wait()
+	return c.db.BuildIDSets()
+}
+
+// UpdateBuildIDSets replaces the compatible build ID sets of the tasklist
+func (c *taskListManagerImpl) UpdateBuildIDSets(buildIDSets [][]string) error {
+	c.startWG.Wait()
+	_, err := c.executeWithRetry(func() (interface{}, error) {
+		return nil, c.db.UpdateBuildIDSets(buildIDSets)
+	})
+	return err
+}
+
+// GetPartitionCounts returns the number of read and write partitions of the tasklist. With partition
+// auto scaling, the counts are stored on the root partition, otherwise they come from dynamic config
+func (c *taskListManagerImpl) GetPartitionCounts() (int, int) {
+	numRead, numWrite := c.config.NumReadPartitions(), c.config.NumWritePartitions()
+	if c.taskListKind == persistence.TaskListKindSticky || !c.config.EnablePartitionAutoScaling() {
+		return numRead, numWrite
+	}
+
+	if !c.isPartitionAutoScalingRoot() {
+		// this is called on the dispatch path of the matcher, so the counts are looked up without blocking
+		if read, write, ok := c.engine.lookupPartitionCounts(c.taskListID); ok && write > 0 {
+			return read, write
+		}
+		return numRead, numWrite
+	}
+
+	c.startWG.Wait()
+	if read, write := c.db.PartitionCounts(); write > 0 {
+		return read, write
+	}
+	return numRead, numWrite
+}
+
+func (c *taskListManagerImpl) updatePartitionCounts(numRead int, numWrite int) error {
+	_, err := c.executeWithRetry(func() (interface{}, error) {
+		return nil, c.db.UpdatePartitionCounts(numRead, numWrite)
+	})
+	if err == nil {
+		c.engine.partitionCountsCache.Delete(*c.taskListID)
+	}
+	return err
+}
+
+func (c *taskListManagerImpl) numReadPartitions() int {
+	numRead, _ := c.GetPartitionCounts()
+	return numRead
+}
+
+// isPartitionAutoScalingRoot returns true if the partition counts of the tasklist are stored on this partition
+func (c *taskListManagerImpl) isPartitionAutoScalingRoot() bool {
+	return c.taskListID.IsRoot() && !c.taskListID.IsVersioned() && c.taskListKind != persistence.TaskListKindSticky
+}
+
 func (c *taskListManagerImpl) String() string {
 	buf := new(bytes.Buffer)
 	if c.taskListID.taskType == persistence.TaskListTypeActivity {
@@ -0,0 +1,135 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+)
+
+type (
+	// taskListStats tracks the add and dispatch rates of a task list partition over a sliding window.
+	// Tasks and polls forwarded by child partitions are counted separately, so that the rates of all
+	// partitions of a task list can be summed up without counting a task twice
+	taskListStats struct {
+		sync.Mutex
+		buckets             [taskListStatsBuckets]taskListStatsBucket
+		headTime            time.Time // start of the current bucket
+		startTime           time.Time // time the stats started to be tracked, bounds the window until it is filled
+		forwardedAdds       int64     // tasks forwarded by child partitions since the partition was loaded
+		forwardedDispatches int64     // tasks dispatched to polls forwarded by child partitions since the partition was loaded
+	}
+
+	taskListStatsBucket struct {
+		adds        int64
+		syncMatches int64
+		dispatches  int64
+	}
+)
+
+const (
+	taskListStatsWindow         = time.Minute
+	taskListStatsBuckets        = 12
+	taskListStatsBucketInterval = taskListStatsWindow / taskListStatsBuckets
+)
+
+func newTaskListStats(now time.Time) *taskListStats {
+	return &taskListStats{
+		headTime:  now.Truncate(taskListStatsBucketInterval),
+		startTime: now,
+	}
+}
+
+// recordAdd records a task added by a caller, as opposed to a task forwarded by a child partition
+func (ts *taskListStats) recordAdd(now time.Time, syncMatch bool) {
+	ts.Lock()
+	defer ts.Unlock()
+	b := ts.bucket(now)
+	b.adds++
+	if syncMatch {
+		b.syncMatches++
+	}
+}
+
+// recordDispatch records a task dispatched to a poller of this partition, as opposed to a poll forwarded by a child partition
+func (ts *taskListStats) recordDispatch(now time.Time) {
+	ts.Lock()
+	defer ts.Unlock()
+	ts.bucket(now).dispatches++
+}
+
+func (ts *taskListStats) recordForwardedAdd() {
+	atomic.AddInt64(&ts.forwardedAdds, 1)
+}
+
+func (ts *taskListStats) recordForwardedDispatch() {
+	atomic.AddInt64(&ts.forwardedDispatches, 1)
+}
+
+// snapshot returns the rates over the last window, the backlog fields are left to the caller
+func (ts *taskListStats) snapshot(now time.Time) *adminservice.TaskListStats {
+	ts.Lock()
+	ts.bucket(now)
+	var total taskListStatsBucket
+	for _, b := range ts.buckets {
+		total.adds += b.adds
+		total.syncMatches += b.syncMatches
+		total.dispatches += b.dispatches
+	}
+	elapsed := now.Sub(ts.startTime)
+	ts.Unlock()
+
+	if elapsed > taskListStatsWindow {
+		elapsed = taskListStatsWindow
+	}
+	stats := &adminservice.TaskListStats{
+		ForwardedAddCount:      atomic.LoadInt64(&ts.forwardedAdds),
+		ForwardedDispatchCount: atomic.LoadInt64(&ts.forwardedDispatches),
+	}
+	if elapsed > 0 {
+		stats.AddRatePerSecond = float64(total.adds) / elapsed.Seconds()
+		stats.DispatchRatePerSecond = float64(total.dispatches) / elapsed.Seconds()
+	}
+	if total.adds > 0 {
+		stats.SyncMatchRatio = float64(total.syncMatches) / float64(total.adds)
+	}
+	return stats
+}
+
+// bucket advances the window to the given time and returns its bucket, caller must hold the lock
+func (ts *taskListStats) bucket(now time.Time) *taskListStatsBucket {
+	head := now.Truncate(taskListStatsBucketInterval)
+	for i := 0; head.After(ts.headTime) && i < taskListStatsBuckets; i++ {
+		ts.headTime = ts.headTime.Add(taskListStatsBucketInterval)
+		ts.buckets[ts.index(ts.headTime)] = taskListStatsBucket{}
+	}
+	if head.After(ts.headTime) {
+		ts.headTime = head
+	}
+	return &ts.buckets[ts.index(ts.headTime)]
+}
+
+func (ts *taskListStats) index(t time.Time) int {
+	return int(t.UnixNano()/int64(taskListStatsBucketInterval)) % taskListStatsBuckets
+}
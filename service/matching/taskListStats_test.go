@@ -0,0 +1,74 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskListStats_Rates(t *testing.T) {
+	now := time.Unix(1000, 0)
+	stats := newTaskListStats(now.Add(-2 * taskListStatsWindow))
+	for i := 0; i < 60; i++ {
+		stats.recordAdd(now, i%3 == 0)
+		stats.recordDispatch(now)
+	}
+	stats.recordDispatch(now)
+	stats.recordForwardedAdd()
+	stats.recordForwardedDispatch()
+	stats.recordForwardedDispatch()
+
+	snapshot := stats.snapshot(now)
+	require.Equal(t, float64(1), snapshot.GetAddRatePerSecond())
+	require.Equal(t, float64(61)/60, snapshot.GetDispatchRatePerSecond())
+	require.InDelta(t, float64(1)/3, snapshot.GetSyncMatchRatio(), 0.001)
+	require.Equal(t, int64(1), snapshot.GetForwardedAddCount())
+	require.Equal(t, int64(2), snapshot.GetForwardedDispatchCount())
+
+	// the counts move out of the window, the forwarded counts are kept
+	snapshot = stats.snapshot(now.Add(taskListStatsWindow))
+	require.Zero(t, snapshot.GetAddRatePerSecond())
+	require.Zero(t, snapshot.GetDispatchRatePerSecond())
+	require.Zero(t, snapshot.GetSyncMatchRatio())
+	require.Equal(t, int64(1), snapshot.GetForwardedAddCount())
+}
+
+func TestTaskListStats_PartialWindow(t *testing.T) {
+	now := time.Unix(1000, 0)
+	stats := newTaskListStats(now)
+	for i := 0; i < 10; i++ {
+		stats.recordAdd(now.Add(5*time.Second), true)
+	}
+	snapshot := stats.snapshot(now.Add(10 * time.Second))
+	require.Equal(t, float64(1), snapshot.GetAddRatePerSecond())
+	require.Equal(t, float64(1), snapshot.GetSyncMatchRatio())
+}
+
+func TestTaskReader_BacklogAge(t *testing.T) {
+	now := time.Now()
+	tr := &taskReader{}
+	require.Zero(t, tr.backlogAge(now))
+	tr.headCreatedTime = now.Add(-time.Minute).UnixNano()
+	require.Equal(t, time.Minute, tr.backlogAge(now))
+}
@@ -23,6 +23,7 @@ package matching
 import (
 	"context"
 	"runtime"
+	"sync/atomic"
 	"time"
 
 	"github.com/temporalio/temporal/common/log"
@@ -48,6 +49,10 @@ type (
 		// separate shutdownC needed for dispatchTasks go routine to allow
 		// getTasksPump to be stopped without stopping dispatchTasks in unit tests
 		dispatcherShutdownC chan struct{}
+		// headCreatedTime is the creation time in unix nanos of the task being dispatched, which is the
+		// oldest task not dispatched yet. It is only reset once the buffer runs empty, so that the age
+		// of a backlog dispatched without waiting for pollers is still reported
+		headCreatedTime int64
 	}
 )
 
@@ -76,6 +81,15 @@ func (tr *taskReader) Stop() {
 	close(tr.dispatcherShutdownC)
 }
 
+// backlogAge returns how long the oldest task not dispatched yet has been waiting
+func (tr *taskReader) backlogAge(now time.Time) time.Duration {
+	createdTime := atomic.LoadInt64(&tr.headCreatedTime)
+	if createdTime == 0 {
+		return 0
+	}
+	return now.Sub(time.Unix(0, createdTime))
+}
+
 func (tr *taskReader) Signal() {
 	var event struct{}
 	select {
@@ -93,9 +107,14 @@ dispatchLoop:
 				break dispatchLoop
 			}
 			task := newInternalTask(taskInfo, tr.tlMgr.completeTask, "", false)
+			atomic.StoreInt64(&tr.headCreatedTime, taskInfo.CreatedTime.UnixNano())
 			for {
 				err := tr.tlMgr.DispatchTask(tr.cancelCtx, task)
 				if err == nil {
+					// the buffered tasks are newer, the next one becomes the head as soon as it is read
+					if len(tr.taskBuffer) == 0 {
+						atomic.StoreInt64(&tr.headCreatedTime, 0)
+					}
 					break
 				}
 				if err == context.Canceled {
@@ -38,15 +38,19 @@ type (
 	}
 	// qualifiedTaskListName refers to the fully qualified task list name
 	qualifiedTaskListName struct {
-		name      string // internal name of the tasks list
-		baseName  string // original name of the task list as specified by user
-		partition int    // partitionID of task list
+		name       string // internal name of the tasks list
+		baseName   string // original name of the task list as specified by user
+		partition  int    // partitionID of task list
+		buildIDSet string // first build ID of the compatible set served by this task list, empty if unversioned
 	}
 )
 
 const (
 	// taskListPartitionPrefix is the required naming prefix for any task list partition other than partition 0
 	taskListPartitionPrefix = "/__cadence_sys/"
+	// taskListBuildIDSetDelimiter separates the user specified name from the build ID set in the name of a
+	// task list that only dispatches to workers of one compatible build ID set
+	taskListBuildIDSetDelimiter = "/__build/"
 )
 
 // newTaskListName returns a fully qualified task list name.
@@ -64,6 +68,13 @@ const (
 // optimization to allow for partitioned task lists to dispatch tasks with low latency when
 // throughput is low - See https://github.com/temporalio/temporal/issues/2098
 //
+// Decision task lists with compatible build ID sets keep the tasks of every set in a
+// separate versioned task list named
+//
+//     [original-name]/__build/[first-build-id-of-set]
+//
+// which is partitioned the same way as the original task list.
+//
 // Returns error if the given name is non-compliant with the required format
 // for task list names
 func newTaskListName(name string) (qualifiedTaskListName, error) {
@@ -87,6 +98,27 @@ func (tn *qualifiedTaskListName) GetRoot() string {
 	return tn.baseName
 }
 
+// IsVersioned returns true if this task list only holds tasks of one compatible build ID set
+func (tn *qualifiedTaskListName) IsVersioned() bool {
+	return tn.buildIDSet != ""
+}
+
+// GetUnversionedRoot returns the name of the root partition of the task list as specified
+// by the user, which is where the compatible build ID sets are stored
+func (tn *qualifiedTaskListName) GetUnversionedRoot() string {
+	if !tn.IsVersioned() {
+		return tn.baseName
+	}
+	return tn.baseName[:len(tn.baseName)-len(taskListBuildIDSetDelimiter)-len(tn.buildIDSet)]
+}
+
+// WithBuildIDSet returns the name of the same partition in the versioned
+// task list of the given compatible build ID set
+func (tn *qualifiedTaskListName) WithBuildIDSet(buildIDSet string) string {
+	versioned := qualifiedTaskListName{baseName: tn.GetUnversionedRoot() + taskListBuildIDSetDelimiter + buildIDSet}
+	return versioned.mkName(tn.partition)
+}
+
 // Parent returns the name of the parent task list
 // input:
 //   degree: Number of children at each level of the tree
@@ -108,6 +140,7 @@ func (tn *qualifiedTaskListName) mkName(partition int) string {
 
 func (tn *qualifiedTaskListName) init() error {
 	if !strings.HasPrefix(tn.name, taskListPartitionPrefix) {
+		tn.initBuildIDSet()
 		return nil
 	}
 
@@ -123,9 +156,16 @@ func (tn *qualifiedTaskListName) init() error {
 
 	tn.partition = p
 	tn.baseName = tn.name[len(taskListPartitionPrefix):suffixOff]
+	tn.initBuildIDSet()
 	return nil
 }
 
+func (tn *qualifiedTaskListName) initBuildIDSet() {
+	if off := strings.LastIndex(tn.baseName, taskListBuildIDSetDelimiter); off > 0 {
+		tn.buildIDSet = tn.baseName[off+len(taskListBuildIDSetDelimiter):]
+	}
+}
+
 // newTaskListID returns taskListID which uniquely identfies as task list
 func newTaskListID(domainID, taskListName string, taskType int) (*taskListID, error) {
 	name, err := newTaskListName(taskListName)
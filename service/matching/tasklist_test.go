@@ -98,6 +98,40 @@ func TestTaskListParentName(t *testing.T) {
 	}
 }
 
+func TestVersionedTaskListNames(t *testing.T) {
+	testCases := []struct {
+		name            string
+		buildIDSet      string
+		unversionedRoot string
+		versionedName   string
+		versionedRoot   string
+	}{
+		{"list0", "", "list0", "list0/__build/1.0", "list0/__build/1.0"},
+		{"/__cadence_sys/list0/3", "", "list0", "/__cadence_sys/list0/__build/1.0/3", "list0/__build/1.0"},
+		{"list0/__build/2.0", "2.0", "list0", "list0/__build/1.0", "list0/__build/1.0"},
+		{"/__cadence_sys/list0/__build/2.0/3", "2.0", "list0", "/__cadence_sys/list0/__build/1.0/3", "list0/__build/1.0"},
+		{"/__cadence_sys/list0/__build/v/2/3", "v/2", "list0", "/__cadence_sys/list0/__build/1.0/3", "list0/__build/1.0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tn, err := newTaskListName(tc.name)
+			require.NoError(t, err)
+			require.Equal(t, tc.buildIDSet != "", tn.IsVersioned())
+			require.Equal(t, tc.buildIDSet, tn.buildIDSet)
+			require.Equal(t, tc.unversionedRoot, tn.GetUnversionedRoot())
+
+			versioned, err := newTaskListName(tn.WithBuildIDSet("1.0"))
+			require.NoError(t, err)
+			require.Equal(t, tc.versionedName, versioned.name)
+			require.Equal(t, tc.versionedRoot, versioned.GetRoot())
+			require.Equal(t, "1.0", versioned.buildIDSet)
+			require.Equal(t, tn.partition, versioned.partition)
+			require.Equal(t, tc.unversionedRoot, versioned.GetUnversionedRoot())
+		})
+	}
+}
+
 func TestInvalidTasklistNames(t *testing.T) {
 	inputs := []string{
 		"/__cadence_sys/",
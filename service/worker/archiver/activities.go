@@ -84,6 +84,7 @@ func uploadHistoryActivity(ctx context.Context, request ArchiveRequest) (err err
 		CloseFailoverVersion: request.CloseFailoverVersion,
 	}, carchiver.GetHeartbeatArchiveOption(), carchiver.GetNonRetriableErrorOption(errUploadNonRetriable))
 	if err == nil {
+		sampleForVerification(ctx, container, &request, scope, logger)
 		return nil
 	}
 	if err.Error() == errUploadNonRetriable.Error() {
@@ -40,6 +40,7 @@ import (
 	"github.com/temporalio/temporal/common/metrics"
 	mmocks "github.com/temporalio/temporal/common/metrics/mocks"
 	"github.com/temporalio/temporal/common/mocks"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
 const (
@@ -211,6 +212,9 @@ func (s *activitiesSuite) TestUploadHistory_Success() {
 		Logger:           s.logger,
 		MetricsClient:    s.metricsClient,
 		ArchiverProvider: s.archiverProvider,
+		Config: &Config{
+			VerificationSampleRate: dynamicconfig.GetFloatPropertyFn(0),
+		},
 	}
 	env := s.NewTestActivityEnvironment()
 	env.SetWorkerOptions(worker.Options{
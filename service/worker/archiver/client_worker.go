@@ -67,6 +67,7 @@ type (
 		ArchiverConcurrency           dynamicconfig.IntPropertyFn
 		ArchivalsPerIteration         dynamicconfig.IntPropertyFn
 		TimeLimitPerArchivalIteration dynamicconfig.DurationPropertyFn
+		VerificationSampleRate        dynamicconfig.FloatPropertyFn
 	}
 
 	contextKey int
@@ -95,6 +96,8 @@ func init() {
 	activity.RegisterWithOptions(uploadHistoryActivity, activity.RegisterOptions{Name: uploadHistoryActivityFnName})
 	activity.RegisterWithOptions(deleteHistoryActivity, activity.RegisterOptions{Name: deleteHistoryActivityFnName})
 	activity.RegisterWithOptions(archiveVisibilityActivity, activity.RegisterOptions{Name: archiveVisibilityActivityFnName})
+	workflow.RegisterWithOptions(verificationWorkflow, workflow.RegisterOptions{Name: verificationWorkflowFnName})
+	activity.RegisterWithOptions(verifyHistoryActivity, activity.RegisterOptions{Name: verifyHistoryActivityFnName})
 }
 
 // NewClientWorker returns a new ClientWorker
@@ -0,0 +1,277 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package archiver
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"go.temporal.io/temporal"
+	"go.temporal.io/temporal/activity"
+	cclient "go.temporal.io/temporal/client"
+	"go.temporal.io/temporal/workflow"
+
+	"github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/common"
+	carchiver "github.com/temporalio/temporal/common/archiver"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+)
+
+const (
+	verificationWorkflowID      = "cadence-archival-verification"
+	verificationSignalName      = "cadence-archival-verification-signal"
+	verificationWorkflowFnName  = "archivalVerificationWorkflow"
+	verifyHistoryActivityFnName = "verifyHistoryActivity"
+
+	verificationsPerIteration = 1000
+	verificationIdleTimeout   = time.Hour
+	verificationPageSize      = 1000
+)
+
+var (
+	errVerifyNonRetriable             = errors.New("verify non-retriable error")
+	errHistoryVerificationFailed      = errors.New("archived history failed verification")
+	errArchivedHistoryIncomplete      = errors.New("archived history does not cover the closed workflow execution")
+	errArchivedHistoryVersionMismatch = errors.New("archived history close failover version does not match")
+
+	verifyHistoryActivityNonRetryableErrors = []string{"cadenceInternal:Panic", errVerifyNonRetriable.Error(), errHistoryVerificationFailed.Error()}
+)
+
+// sampleForVerification sends a configured fraction of the successfully archived histories
+// to the verification workflow, failing to do so does not fail the archival
+func sampleForVerification(ctx context.Context, container *BootstrapContainer, request *ArchiveRequest, scope metrics.Scope, logger log.Logger) {
+	if rand.Float64() >= container.Config.VerificationSampleRate() {
+		return
+	}
+
+	scope.IncCounter(metrics.ArchiverVerificationSampledCount)
+	cadenceClient := cclient.NewClient(container.PublicClient, common.SystemLocalDomainName, &cclient.Options{})
+	workflowOptions := cclient.StartWorkflowOptions{
+		ID:                              verificationWorkflowID,
+		TaskList:                        decisionTaskList,
+		ExecutionStartToCloseTimeout:    workflowStartToCloseTimeout,
+		DecisionTaskStartToCloseTimeout: workflowTaskStartToCloseTimeout,
+		WorkflowIDReusePolicy:           cclient.WorkflowIDReusePolicyAllowDuplicate,
+	}
+	signalCtx, cancel := context.WithTimeout(ctx, signalTimeout)
+	defer cancel()
+	_, err := cadenceClient.SignalWithStartWorkflow(signalCtx, verificationWorkflowID, verificationSignalName, *request, workflowOptions, verificationWorkflowFnName, nil)
+	if err != nil {
+		logger.Error("failed to send signal to archival verification workflow", tag.WorkflowID(verificationWorkflowID), tag.Error(err))
+		scope.IncCounter(metrics.ArchiverVerificationSignalFailureCount)
+	}
+}
+
+func verificationWorkflow(ctx workflow.Context, carryover []ArchiveRequest) error {
+	return verificationWorkflowHelper(ctx, globalLogger, globalMetricsClient, carryover)
+}
+
+// verificationWorkflowHelper verifies the sampled archived histories one at a time,
+// it continues as new after verificationsPerIteration requests and stops once no request
+// is received within verificationIdleTimeout, the next sampled request starts it again
+func verificationWorkflowHelper(
+	ctx workflow.Context,
+	logger log.Logger,
+	metricsClient metrics.Client,
+	carryover []ArchiveRequest,
+) error {
+	metricsClient = NewReplayMetricsClient(metricsClient, ctx)
+	workflowInfo := workflow.GetInfo(ctx)
+	logger = logger.WithTags(
+		tag.WorkflowID(workflowInfo.WorkflowExecution.ID),
+		tag.WorkflowRunID(workflowInfo.WorkflowExecution.RunID),
+		tag.WorkflowTaskListName(workflowInfo.TaskListName),
+		tag.WorkflowType(workflowInfo.WorkflowType.Name))
+	logger = loggerimpl.NewReplayLogger(logger, ctx, false)
+
+	ao := workflow.ActivityOptions{
+		ScheduleToStartTimeout: 1 * time.Minute,
+		StartToCloseTimeout:    5 * time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:          time.Second,
+			BackoffCoefficient:       2.0,
+			ExpirationInterval:       10 * time.Minute,
+			NonRetriableErrorReasons: verifyHistoryActivityNonRetryableErrors,
+		},
+	}
+	actCtx := workflow.WithActivityOptions(ctx, ao)
+	signalCh := workflow.GetSignalChannel(ctx, verificationSignalName)
+	pending := carryover
+	for verified := 0; verified < verificationsPerIteration; verified++ {
+		var request ArchiveRequest
+		if len(pending) != 0 {
+			request, pending = pending[0], pending[1:]
+		} else {
+			received := false
+			timerCtx, cancelTimer := workflow.WithCancel(ctx)
+			selector := workflow.NewSelector(ctx)
+			selector.AddReceive(signalCh, func(ch workflow.Channel, more bool) {
+				ch.Receive(ctx, &request)
+				received = true
+			})
+			selector.AddFuture(workflow.NewTimer(timerCtx, verificationIdleTimeout), func(workflow.Future) {})
+			selector.Select(ctx)
+			cancelTimer()
+			if !received {
+				logger.Info("archival verification workflow stopping because no requests were received within timeout threshold")
+				return nil
+			}
+		}
+
+		err := workflow.ExecuteActivity(actCtx, verifyHistoryActivityFnName, request).Get(actCtx, nil)
+		switch {
+		case err == nil:
+			metricsClient.IncCounter(metrics.ArchiverVerificationWorkflowScope, metrics.ArchiverVerificationSuccessCount)
+		case err.Error() == errHistoryVerificationFailed.Error():
+			tagLoggerWithHistoryRequest(logger, &request).Error("archived history failed verification")
+			metricsClient.IncCounter(metrics.ArchiverVerificationWorkflowScope, metrics.ArchiverVerificationFailedCount)
+		default:
+			tagLoggerWithHistoryRequest(logger, &request).Error("failed to verify archived history", tag.Error(err))
+			metricsClient.IncCounter(metrics.ArchiverVerificationWorkflowScope, metrics.ArchiverVerificationErrorCount)
+		}
+	}
+
+	for {
+		var request ArchiveRequest
+		if ok := signalCh.ReceiveAsync(&request); !ok {
+			break
+		}
+		pending = append(pending, request)
+	}
+	logger.Info("archival verification workflow continue as new")
+	ctx = workflow.WithExecutionStartToCloseTimeout(ctx, workflowStartToCloseTimeout)
+	ctx = workflow.WithWorkflowTaskStartToCloseTimeout(ctx, workflowTaskStartToCloseTimeout)
+	return workflow.NewContinueAsNewError(ctx, verificationWorkflowFnName, pending)
+}
+
+func verifyHistoryActivity(ctx context.Context, request ArchiveRequest) (err error) {
+	container := ctx.Value(bootstrapContainerKey).(*BootstrapContainer)
+	scope := container.MetricsClient.Scope(metrics.ArchiverVerifyHistoryActivityScope, metrics.DomainTag(request.DomainName))
+	sw := scope.StartTimer(metrics.CadenceLatency)
+	defer func() {
+		sw.Stop()
+		if err != nil {
+			if err.Error() == errVerifyNonRetriable.Error() {
+				scope.IncCounter(metrics.ArchiverNonRetryableErrorCount)
+			}
+			err = temporal.NewCustomError(err.Error())
+		}
+	}()
+	logger := tagLoggerWithHistoryRequest(tagLoggerWithActivityInfo(container.Logger, activity.GetInfo(ctx)), &request)
+	URI, err := carchiver.NewURI(request.URI)
+	if err != nil {
+		logger.Error("failed to get history archival uri", tag.Error(err))
+		return errVerifyNonRetriable
+	}
+	historyArchiver, err := container.ArchiverProvider.GetHistoryArchiver(URI.Scheme(), common.WorkerServiceName)
+	if err != nil {
+		logger.Error("failed to get history archiver", tag.Error(err))
+		return errVerifyNonRetriable
+	}
+
+	historyBatches, integrity, err := getArchivedHistory(ctx, historyArchiver, URI, &request)
+	switch err.(type) {
+	case nil:
+		err = verifyArchivedHistory(&request, historyBatches, integrity)
+	case *shared.EntityNotExistsError, *shared.BadRequestError:
+		// the history was archived successfully, so it is expected to be readable
+	default:
+		logger.Error("failed to read archived history", tag.Error(err))
+		return err
+	}
+	if err != nil {
+		logger.Error("archived history failed verification", tag.Error(err))
+		scope.IncCounter(metrics.ArchiverVerificationFailedCount)
+		return errHistoryVerificationFailed
+	}
+	return nil
+}
+
+func getArchivedHistory(
+	ctx context.Context,
+	historyArchiver carchiver.HistoryArchiver,
+	URI carchiver.URI,
+	request *ArchiveRequest,
+) ([]*shared.History, *carchiver.HistoryIntegrity, error) {
+	getRequest := &carchiver.GetHistoryRequest{
+		DomainID:             request.DomainID,
+		WorkflowID:           request.WorkflowID,
+		RunID:                request.RunID,
+		CloseFailoverVersion: common.Int64Ptr(request.CloseFailoverVersion),
+		PageSize:             verificationPageSize,
+	}
+	var historyBatches []*shared.History
+	var integrity *carchiver.HistoryIntegrity
+	for {
+		response, err := historyArchiver.Get(ctx, URI, getRequest)
+		if err != nil {
+			return nil, nil, err
+		}
+		historyBatches = append(historyBatches, response.HistoryBatches...)
+		if integrity == nil {
+			integrity = response.Integrity
+		}
+		if len(response.NextPageToken) == 0 {
+			return historyBatches, integrity, nil
+		}
+		getRequest.NextPageToken = response.NextPageToken
+	}
+}
+
+// verifyArchivedHistory checks the archived history against its integrity metadata and the archive request
+func verifyArchivedHistory(
+	request *ArchiveRequest,
+	historyBatches []*shared.History,
+	integrity *carchiver.HistoryIntegrity,
+) error {
+	if err := carchiver.ValidateHistoryEventIDs(historyBatches); err != nil {
+		return err
+	}
+	if integrity == nil {
+		// histories archived without integrity metadata can only be checked against the archive request
+		computed, err := carchiver.NewHistoryIntegrity(historyBatches)
+		if err != nil {
+			return err
+		}
+		integrity = computed
+	} else if err := integrity.Verify(historyBatches); err != nil {
+		return err
+	}
+
+	if integrity.EventCount == 0 || integrity.FirstEventID != common.FirstEventID || integrity.LastEventID != request.NextEventID-1 {
+		return errArchivedHistoryIncomplete
+	}
+	var lastEvent *shared.HistoryEvent
+	for _, batch := range historyBatches {
+		if len(batch.Events) != 0 {
+			lastEvent = batch.Events[len(batch.Events)-1]
+		}
+	}
+	if lastEvent.GetVersion() != request.CloseFailoverVersion {
+		return errArchivedHistoryVersionMismatch
+	}
+	return nil
+}
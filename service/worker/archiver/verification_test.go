@@ -0,0 +1,227 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package archiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"go.temporal.io/temporal"
+	"go.temporal.io/temporal/testsuite"
+	"go.temporal.io/temporal/worker"
+	"go.temporal.io/temporal/workflow"
+
+	"github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/common"
+	carchiver "github.com/temporalio/temporal/common/archiver"
+	"github.com/temporalio/temporal/common/archiver/provider"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/metrics"
+	mmocks "github.com/temporalio/temporal/common/metrics/mocks"
+)
+
+var (
+	verificationTestMetrics *mmocks.Client
+	verificationTestLogger  log.Logger
+)
+
+type verificationSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	logger           log.Logger
+	metricsClient    *mmocks.Client
+	metricsScope     *mmocks.Scope
+	archiverProvider *provider.MockArchiverProvider
+	historyArchiver  *carchiver.HistoryArchiverMock
+}
+
+func TestVerificationSuite(t *testing.T) {
+	suite.Run(t, new(verificationSuite))
+}
+
+func (s *verificationSuite) SetupSuite() {
+	workflow.Register(verificationWorkflowTest)
+}
+
+func (s *verificationSuite) SetupTest() {
+	s.logger = loggerimpl.NewLogger(zap.NewNop())
+	s.metricsClient = &mmocks.Client{}
+	s.metricsScope = &mmocks.Scope{}
+	s.archiverProvider = &provider.MockArchiverProvider{}
+	s.historyArchiver = &carchiver.HistoryArchiverMock{}
+	s.metricsScope.On("StartTimer", metrics.CadenceLatency).Return(metrics.NewTestStopwatch()).Maybe()
+	s.metricsScope.On("RecordTimer", mock.Anything, mock.Anything).Maybe()
+	verificationTestMetrics = &mmocks.Client{}
+	verificationTestLogger = s.logger
+}
+
+func (s *verificationSuite) TearDownTest() {
+	s.metricsClient.AssertExpectations(s.T())
+	s.metricsScope.AssertExpectations(s.T())
+	s.archiverProvider.AssertExpectations(s.T())
+	s.historyArchiver.AssertExpectations(s.T())
+	verificationTestMetrics.AssertExpectations(s.T())
+}
+
+func (s *verificationSuite) TestVerifyHistoryActivity_Success() {
+	historyBatches := s.newHistoryBatches(testNextEventID-1, testCloseFailoverVersion)
+	integrity, err := carchiver.NewHistoryIntegrity(historyBatches)
+	s.NoError(err)
+	s.metricsClient.On("Scope", metrics.ArchiverVerifyHistoryActivityScope, []metrics.Tag{metrics.DomainTag(testDomainName)}).Return(s.metricsScope).Once()
+	s.archiverProvider.On("GetHistoryArchiver", mock.Anything, common.WorkerServiceName).Return(s.historyArchiver, nil)
+	s.historyArchiver.On("Get", mock.Anything, mock.Anything, mock.MatchedBy(func(request *carchiver.GetHistoryRequest) bool {
+		return request.NextPageToken == nil && *request.CloseFailoverVersion == testCloseFailoverVersion
+	})).Return(&carchiver.GetHistoryResponse{
+		HistoryBatches: historyBatches[:1],
+		NextPageToken:  []byte{1},
+		Integrity:      integrity,
+	}, nil).Once()
+	s.historyArchiver.On("Get", mock.Anything, mock.Anything, mock.MatchedBy(func(request *carchiver.GetHistoryRequest) bool {
+		return request.NextPageToken != nil
+	})).Return(&carchiver.GetHistoryResponse{
+		HistoryBatches: historyBatches[1:],
+		Integrity:      integrity,
+	}, nil).Once()
+
+	_, err = s.newTestActivityEnvironment().ExecuteActivity(verifyHistoryActivity, s.newArchiveRequest())
+	s.NoError(err)
+}
+
+func (s *verificationSuite) TestVerifyHistoryActivity_Fail_HistoryNotExist() {
+	s.metricsClient.On("Scope", metrics.ArchiverVerifyHistoryActivityScope, []metrics.Tag{metrics.DomainTag(testDomainName)}).Return(s.metricsScope).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverVerificationFailedCount).Once()
+	s.archiverProvider.On("GetHistoryArchiver", mock.Anything, common.WorkerServiceName).Return(s.historyArchiver, nil)
+	s.historyArchiver.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, &shared.EntityNotExistsError{Message: carchiver.ErrHistoryNotExist.Error()}).Once()
+
+	_, err := s.newTestActivityEnvironment().ExecuteActivity(verifyHistoryActivity, s.newArchiveRequest())
+	s.Equal(errHistoryVerificationFailed.Error(), err.Error())
+}
+
+func (s *verificationSuite) TestVerifyHistoryActivity_Fail_Corrupted() {
+	historyBatches := s.newHistoryBatches(testNextEventID-1, testCloseFailoverVersion)
+	integrity, err := carchiver.NewHistoryIntegrity(historyBatches)
+	s.NoError(err)
+	historyBatches[0].Events[0].Timestamp = common.Int64Ptr(1)
+	s.metricsClient.On("Scope", metrics.ArchiverVerifyHistoryActivityScope, []metrics.Tag{metrics.DomainTag(testDomainName)}).Return(s.metricsScope).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverVerificationFailedCount).Once()
+	s.archiverProvider.On("GetHistoryArchiver", mock.Anything, common.WorkerServiceName).Return(s.historyArchiver, nil)
+	s.historyArchiver.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&carchiver.GetHistoryResponse{
+		HistoryBatches: historyBatches,
+		Integrity:      integrity,
+	}, nil).Once()
+
+	_, err = s.newTestActivityEnvironment().ExecuteActivity(verifyHistoryActivity, s.newArchiveRequest())
+	s.Equal(errHistoryVerificationFailed.Error(), err.Error())
+}
+
+func (s *verificationSuite) TestVerifyHistoryActivity_Fail_ReadError() {
+	s.metricsClient.On("Scope", metrics.ArchiverVerifyHistoryActivityScope, []metrics.Tag{metrics.DomainTag(testDomainName)}).Return(s.metricsScope).Once()
+	s.archiverProvider.On("GetHistoryArchiver", mock.Anything, common.WorkerServiceName).Return(s.historyArchiver, nil)
+	s.historyArchiver.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("some random error")).Once()
+
+	_, err := s.newTestActivityEnvironment().ExecuteActivity(verifyHistoryActivity, s.newArchiveRequest())
+	s.Equal("some random error", err.Error())
+}
+
+func (s *verificationSuite) TestVerifyArchivedHistory() {
+	request := s.newArchiveRequest()
+	historyBatches := s.newHistoryBatches(testNextEventID-1, testCloseFailoverVersion)
+	integrity, err := carchiver.NewHistoryIntegrity(historyBatches)
+	s.NoError(err)
+
+	s.NoError(verifyArchivedHistory(&request, historyBatches, integrity))
+	s.NoError(verifyArchivedHistory(&request, historyBatches, nil))
+	s.Equal(carchiver.ErrHistoryEventCountMismatch, verifyArchivedHistory(&request, historyBatches[:1], integrity))
+	s.Equal(errArchivedHistoryIncomplete, verifyArchivedHistory(&request, historyBatches[:1], nil))
+	s.Equal(errArchivedHistoryIncomplete, verifyArchivedHistory(&request, nil, nil))
+	s.Equal(errArchivedHistoryVersionMismatch, verifyArchivedHistory(&request, s.newHistoryBatches(testNextEventID-1, testCloseFailoverVersion+1), nil))
+
+	historyBatches[1].Events[0].EventId = common.Int64Ptr(testNextEventID)
+	s.Equal(carchiver.ErrHistoryEventIDsNotContiguous, verifyArchivedHistory(&request, historyBatches, integrity))
+}
+
+func (s *verificationSuite) TestVerificationWorkflow_Exit_TimeoutWithoutSignals() {
+	verificationTestMetrics.On("IncCounter", metrics.ArchiverVerificationWorkflowScope, metrics.ArchiverVerificationSuccessCount).Once()
+	verificationTestMetrics.On("IncCounter", metrics.ArchiverVerificationWorkflowScope, metrics.ArchiverVerificationFailedCount).Once()
+	verificationTestMetrics.On("IncCounter", metrics.ArchiverVerificationWorkflowScope, metrics.ArchiverVerificationErrorCount).Once()
+
+	env := s.NewTestWorkflowEnvironment()
+	env.OnActivity(verifyHistoryActivityFnName, mock.Anything, mock.Anything).Return(nil).Once()
+	env.OnActivity(verifyHistoryActivityFnName, mock.Anything, mock.Anything).Return(temporal.NewCustomError(errHistoryVerificationFailed.Error())).Once()
+	env.OnActivity(verifyHistoryActivityFnName, mock.Anything, mock.Anything).Return(temporal.NewCustomError(errVerifyNonRetriable.Error())).Once()
+	env.ExecuteWorkflow(verificationWorkflowTest, []ArchiveRequest{s.newArchiveRequest(), s.newArchiveRequest(), s.newArchiveRequest()})
+
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+	env.AssertExpectations(s.T())
+}
+
+func (s *verificationSuite) newTestActivityEnvironment() *testsuite.TestActivityEnvironment {
+	container := &BootstrapContainer{
+		Logger:           s.logger,
+		MetricsClient:    s.metricsClient,
+		ArchiverProvider: s.archiverProvider,
+	}
+	env := s.NewTestActivityEnvironment()
+	env.SetWorkerOptions(worker.Options{
+		BackgroundActivityContext: context.WithValue(context.Background(), bootstrapContainerKey, container),
+	})
+	return env
+}
+
+func (s *verificationSuite) newArchiveRequest() ArchiveRequest {
+	return ArchiveRequest{
+		DomainID:             testDomainID,
+		DomainName:           testDomainName,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		BranchToken:          testBranchToken,
+		NextEventID:          testNextEventID,
+		CloseFailoverVersion: testCloseFailoverVersion,
+		URI:                  testArchivalURI,
+	}
+}
+
+func (s *verificationSuite) newHistoryBatches(lastEventID int64, version int64) []*shared.History {
+	historyBatches := []*shared.History{{}, {}}
+	for eventID := int64(common.FirstEventID); eventID <= lastEventID; eventID++ {
+		batch := historyBatches[0]
+		if eventID > lastEventID/2 {
+			batch = historyBatches[1]
+		}
+		batch.Events = append(batch.Events, &shared.HistoryEvent{
+			EventId: common.Int64Ptr(eventID),
+			Version: common.Int64Ptr(version),
+		})
+	}
+	return historyBatches
+}
+
+func verificationWorkflowTest(ctx workflow.Context, carryover []ArchiveRequest) error {
+	return verificationWorkflowHelper(ctx, verificationTestLogger, verificationTestMetrics, carryover)
+}
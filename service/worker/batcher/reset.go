@@ -0,0 +1,303 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package batcher
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	commonproto "go.temporal.io/temporal-proto/common"
+	"go.temporal.io/temporal-proto/enums"
+	"go.temporal.io/temporal-proto/workflowservice"
+
+	"github.com/temporalio/temporal/client/frontend"
+)
+
+const (
+	// ResetTypeFirstDecisionCompleted resets to the first DecisionTaskCompleted event of the run
+	ResetTypeFirstDecisionCompleted = "FirstDecisionCompleted"
+	// ResetTypeLastDecisionCompleted resets to the last DecisionTaskCompleted event of the run
+	ResetTypeLastDecisionCompleted = "LastDecisionCompleted"
+	// ResetTypeLastContinuedAsNew resets to the last DecisionTaskCompleted event of the run that continued as new to this run
+	ResetTypeLastContinuedAsNew = "LastContinuedAsNew"
+	// ResetTypeBadBinary resets to the auto reset point created by the first decision of a bad binary
+	ResetTypeBadBinary = "BadBinary"
+)
+
+// AllResetTypes is the reset types supported by BatchTypeReset
+var AllResetTypes = []string{ResetTypeFirstDecisionCompleted, ResetTypeLastDecisionCompleted, ResetTypeLastContinuedAsNew, ResetTypeBadBinary}
+
+var errNoDecisionFinishID = fmt.Errorf("no DecisionFinishID")
+
+func validateResetParams(params ResetParams) error {
+	switch params.ResetType {
+	case ResetTypeFirstDecisionCompleted, ResetTypeLastDecisionCompleted, ResetTypeLastContinuedAsNew:
+		return nil
+	case ResetTypeBadBinary:
+		if params.BadBinaryChecksum == "" {
+			return fmt.Errorf("must provide bad binary checksum for reset type %v", ResetTypeBadBinary)
+		}
+		return nil
+	default:
+		return fmt.Errorf("not supported reset type: %v", params.ResetType)
+	}
+}
+
+// resetWorkflow resets a single workflow according to the reset params,
+// workflows which are filtered out by the params are skipped without an error.
+// The request ID dedupes the reset when the workflow is processed again
+func resetWorkflow(
+	ctx context.Context,
+	client frontend.Client,
+	batchParams BatchParams,
+	workflowID string,
+	runID string,
+	requestID string,
+) error {
+	params := batchParams.ResetParams
+	domain := batchParams.DomainName
+
+	resp, err := client.DescribeWorkflowExecution(ctx, &workflowservice.DescribeWorkflowExecutionRequest{
+		Domain: domain,
+		Execution: &commonproto.WorkflowExecution{
+			WorkflowId: workflowID,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	currentRunID := resp.WorkflowExecutionInfo.Execution.GetRunId()
+	if currentRunID != runID && params.SkipBaseIsNotCurrent {
+		return nil
+	}
+	if runID == "" {
+		runID = currentRunID
+	}
+
+	if resp.WorkflowExecutionInfo.CloseStatus == enums.WorkflowExecutionCloseStatusRunning || resp.WorkflowExecutionInfo.CloseTime == 0 {
+		if params.SkipCurrentOpen {
+			return nil
+		}
+	}
+
+	if params.NonDeterministicOnly {
+		isLDN, err := isLastEventDecisionTaskFailedWithNonDeterminism(ctx, client, domain, workflowID, runID)
+		if err != nil {
+			return err
+		}
+		if !isLDN {
+			return nil
+		}
+	}
+
+	resetBaseRunID, decisionFinishID, err := getResetEventIDByType(ctx, client, params, domain, workflowID, runID)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.ResetWorkflowExecution(ctx, &workflowservice.ResetWorkflowExecutionRequest{
+		Domain: domain,
+		WorkflowExecution: &commonproto.WorkflowExecution{
+			WorkflowId: workflowID,
+			RunId:      resetBaseRunID,
+		},
+		DecisionFinishEventId: decisionFinishID,
+		RequestId:             requestID,
+		Reason:                batchParams.Reason,
+	})
+	return err
+}
+
+func isLastEventDecisionTaskFailedWithNonDeterminism(
+	ctx context.Context,
+	client frontend.Client,
+	domain string,
+	workflowID string,
+	runID string,
+) (bool, error) {
+
+	var decisionFailed *commonproto.HistoryEvent
+	err := iterateHistory(ctx, client, domain, workflowID, runID, func(e *commonproto.HistoryEvent) bool {
+		if e.GetEventType() == enums.EventTypeDecisionTaskFailed {
+			decisionFailed = e
+		} else if e.GetEventType() == enums.EventTypeDecisionTaskCompleted {
+			decisionFailed = nil
+		}
+		return true
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if decisionFailed != nil {
+		attr := decisionFailed.GetDecisionTaskFailedEventAttributes()
+		if attr.GetCause() == enums.DecisionTaskFailedCauseWorkflowWorkerUnhandledFailure ||
+			strings.Contains(string(attr.GetDetails()), "nondeterministic") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func getResetEventIDByType(
+	ctx context.Context,
+	client frontend.Client,
+	params ResetParams,
+	domain string,
+	workflowID string,
+	runID string,
+) (resetBaseRunID string, decisionFinishID int64, err error) {
+
+	resetBaseRunID = runID
+	switch params.ResetType {
+	case ResetTypeLastDecisionCompleted:
+		err = iterateHistory(ctx, client, domain, workflowID, runID, func(e *commonproto.HistoryEvent) bool {
+			if e.GetEventType() == enums.EventTypeDecisionTaskCompleted {
+				decisionFinishID = e.GetEventId()
+			}
+			return true
+		})
+	case ResetTypeFirstDecisionCompleted:
+		err = iterateHistory(ctx, client, domain, workflowID, runID, func(e *commonproto.HistoryEvent) bool {
+			if e.GetEventType() == enums.EventTypeDecisionTaskCompleted {
+				decisionFinishID = e.GetEventId()
+				return false
+			}
+			return true
+		})
+	case ResetTypeLastContinuedAsNew:
+		resetBaseRunID, err = getContinuedExecutionRunID(ctx, client, domain, workflowID, runID)
+		if err != nil {
+			return "", 0, err
+		}
+		err = iterateHistory(ctx, client, domain, workflowID, resetBaseRunID, func(e *commonproto.HistoryEvent) bool {
+			if e.GetEventType() == enums.EventTypeDecisionTaskCompleted {
+				decisionFinishID = e.GetEventId()
+			}
+			return true
+		})
+	case ResetTypeBadBinary:
+		decisionFinishID, err = getBadDecisionCompletedID(ctx, client, domain, workflowID, runID, params.BadBinaryChecksum)
+	default:
+		err = fmt.Errorf("not supported reset type: %v", params.ResetType)
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	if decisionFinishID == 0 {
+		return "", 0, errNoDecisionFinishID
+	}
+	return resetBaseRunID, decisionFinishID, nil
+}
+
+func getContinuedExecutionRunID(
+	ctx context.Context,
+	client frontend.Client,
+	domain string,
+	workflowID string,
+	runID string,
+) (string, error) {
+
+	resp, err := client.GetWorkflowExecutionHistory(ctx, &workflowservice.GetWorkflowExecutionHistoryRequest{
+		Domain: domain,
+		Execution: &commonproto.WorkflowExecution{
+			WorkflowId: workflowID,
+			RunId:      runID,
+		},
+		MaximumPageSize: 1,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(resp.GetHistory().GetEvents()) == 0 {
+		return "", fmt.Errorf("cannot get resetBaseRunID")
+	}
+	resetBaseRunID := resp.History.Events[0].GetWorkflowExecutionStartedEventAttributes().GetContinuedExecutionRunId()
+	if resetBaseRunID == "" {
+		return "", fmt.Errorf("cannot get resetBaseRunID")
+	}
+	return resetBaseRunID, nil
+}
+
+func getBadDecisionCompletedID(
+	ctx context.Context,
+	client frontend.Client,
+	domain string,
+	workflowID string,
+	runID string,
+	binaryChecksum string,
+) (int64, error) {
+
+	resp, err := client.DescribeWorkflowExecution(ctx, &workflowservice.DescribeWorkflowExecutionRequest{
+		Domain: domain,
+		Execution: &commonproto.WorkflowExecution{
+			WorkflowId: workflowID,
+			RunId:      runID,
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, p := range resp.WorkflowExecutionInfo.GetAutoResetPoints().GetPoints() {
+		if p.GetBinaryChecksum() == binaryChecksum && p.GetResettable() {
+			return p.GetFirstDecisionCompletedId(), nil
+		}
+	}
+	return 0, nil
+}
+
+// iterateHistory calls fn on every event of the run until fn returns false
+func iterateHistory(
+	ctx context.Context,
+	client frontend.Client,
+	domain string,
+	workflowID string,
+	runID string,
+	fn func(*commonproto.HistoryEvent) bool,
+) error {
+
+	req := &workflowservice.GetWorkflowExecutionHistoryRequest{
+		Domain: domain,
+		Execution: &commonproto.WorkflowExecution{
+			WorkflowId: workflowID,
+			RunId:      runID,
+		},
+		MaximumPageSize: pageSize,
+	}
+	for {
+		resp, err := client.GetWorkflowExecutionHistory(ctx, req)
+		if err != nil {
+			return err
+		}
+		for _, e := range resp.GetHistory().GetEvents() {
+			if !fn(e) {
+				return nil
+			}
+		}
+		if len(resp.NextPageToken) == 0 {
+			return nil
+		}
+		req.NextPageToken = resp.NextPageToken
+	}
+}
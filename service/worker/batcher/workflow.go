@@ -22,7 +22,9 @@ package batcher
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/gogo/status"
@@ -36,6 +38,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	"github.com/temporalio/temporal/client/frontend"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/log"
@@ -71,10 +74,28 @@ const (
 	BatchTypeCancel = "cancel"
 	// BatchTypeSignal is batch type for signaling workflows
 	BatchTypeSignal = "signal"
+	// BatchTypeReset is batch type for resetting workflows
+	BatchTypeReset = "reset"
+	// BatchTypeDelete is batch type for deleting workflows, open workflows are terminated before they are deleted
+	BatchTypeDelete = "delete"
+	// BatchTypeUpsertSearchAttributes is batch type for updating search attributes of workflows
+	BatchTypeUpsertSearchAttributes = "upsertSearchAttributes"
+)
+
+const (
+	// PauseSignalName is the signal to pause a running batch job
+	PauseSignalName = "pause"
+	// ResumeSignalName is the signal to resume a paused batch job
+	ResumeSignalName = "resume"
+	// StateQueryType is the query type to get the BatchState of a batch job
+	StateQueryType = "batch_state"
+
+	// maxFailuresReported caps the number of failed workflows kept in HeartBeatDetails
+	maxFailuresReported = 1000
 )
 
 // AllBatchTypes is the batch types we supported
-var AllBatchTypes = []string{BatchTypeTerminate, BatchTypeCancel, BatchTypeSignal}
+var AllBatchTypes = []string{BatchTypeTerminate, BatchTypeCancel, BatchTypeSignal, BatchTypeReset, BatchTypeDelete, BatchTypeUpsertSearchAttributes}
 
 type (
 	// TerminateParams is the parameters for terminating workflow
@@ -99,6 +120,26 @@ type (
 		Input      string
 	}
 
+	// ResetParams is the parameters for resetting workflow, they are the same options as `tctl workflow reset-batch`
+	ResetParams struct {
+		// one of AllResetTypes
+		ResetType string
+		// binary checksum for ResetTypeBadBinary
+		BadBinaryChecksum string
+		// skip the workflow if its current run is open
+		SkipCurrentOpen bool
+		// skip the workflow if the base run is not the current run
+		SkipBaseIsNotCurrent bool
+		// only reset the workflow if its last event is a DecisionTaskFailed caused by non-determinism
+		NonDeterministicOnly bool
+	}
+
+	// UpsertSearchAttributesParams is the parameters for updating search attributes of workflow
+	UpsertSearchAttributesParams struct {
+		// values are JSON encoded into the indexed fields of the search attributes
+		SearchAttributes map[string]interface{}
+	}
+
 	// BatchParams is the parameters for batch operation workflow
 	BatchParams struct {
 		// Target domain to execute batch operation
@@ -107,7 +148,7 @@ type (
 		Query string
 		// Reason for the operation
 		Reason string
-		// Supporting: reset,terminate
+		// One of AllBatchTypes
 		BatchType string
 
 		// Below are all optional
@@ -117,6 +158,10 @@ type (
 		CancelParams CancelParams
 		// SignalParams is params only for BatchTypeSignal
 		SignalParams SignalParams
+		// ResetParams is params only for BatchTypeReset
+		ResetParams ResetParams
+		// UpsertSearchAttributesParams is params only for BatchTypeUpsertSearchAttributes
+		UpsertSearchAttributesParams UpsertSearchAttributesParams
 		// RPS of processing. Default to DefaultRPS
 		// TODO we will implement smarter way than this static rate limiter: https://github.com/temporalio/temporal/issues/2138
 		RPS int
@@ -142,14 +187,44 @@ type (
 		SuccessCount int
 		// Number of workflows that give up due to errors.
 		ErrorCount int
+		// The workflows that give up due to errors, capped at maxFailuresReported
+		Failures []WorkflowFailure
+		// The workflows of the current page that are not processed yet. The page token is already
+		// the token of the next page, so these workflows are processed before the next page is scanned
+		PendingExecutions []commonproto.WorkflowExecution
+	}
+
+	// WorkflowFailure is the failure of processing a workflow in a batch job
+	WorkflowFailure struct {
+		WorkflowID string
+		RunID      string
+		Error      string
+	}
+
+	// BatchState is the result of StateQueryType
+	BatchState struct {
+		Paused   bool
+		Progress HeartBeatDetails
 	}
 
 	taskDetail struct {
 		execution commonproto.WorkflowExecution
 		attempts  int
-		// passing along the current heartbeat details to make heartbeat within a task so that it won't timeout
+		// passing along the progress of the activity to make heartbeat within a task so that it won't timeout
+		progress *batchProgress
+	}
+
+	// batchProgress is the progress of a batch activity, it is updated by the activity as the workflows
+	// are processed and heartbeat by the task processors while they process a workflow
+	batchProgress struct {
+		sync.Mutex
 		hbd HeartBeatDetails
 	}
+
+	taskResult struct {
+		execution commonproto.WorkflowExecution
+		err       error
+	}
 )
 
 var (
@@ -164,6 +239,8 @@ var (
 		ScheduleToStartTimeout: 5 * time.Minute,
 		StartToCloseTimeout:    InfiniteDuration,
 		RetryPolicy:            &batchActivityRetryPolicy,
+		// so that a paused activity can hand its progress back to the workflow
+		WaitForCancellation: true,
 	}
 )
 
@@ -180,10 +257,64 @@ func BatchWorkflow(ctx workflow.Context, batchParams BatchParams) (HeartBeatDeta
 		return HeartBeatDetails{}, err
 	}
 	batchActivityOptions.HeartbeatTimeout = batchParams.ActivityHeartBeatTimeout
-	opt := workflow.WithActivityOptions(ctx, batchActivityOptions)
-	var result HeartBeatDetails
-	err = workflow.ExecuteActivity(opt, batchActivityName, batchParams).Get(ctx, &result)
-	return result, err
+
+	state := BatchState{}
+	err = workflow.SetQueryHandler(ctx, StateQueryType, func() (BatchState, error) {
+		return state, nil
+	})
+	if err != nil {
+		return HeartBeatDetails{}, err
+	}
+	pauseCh := workflow.GetSignalChannel(ctx, PauseSignalName)
+	resumeCh := workflow.GetSignalChannel(ctx, ResumeSignalName)
+
+	for {
+		activityCtx, cancel := workflow.WithCancel(workflow.WithActivityOptions(ctx, batchActivityOptions))
+		future := workflow.ExecuteActivity(activityCtx, batchActivityName, batchParams, state.Progress)
+
+		done := false
+		paused := false
+		selector := workflow.NewSelector(ctx)
+		selector.AddFuture(future, func(f workflow.Future) {
+			done = true
+		})
+		selector.AddReceive(pauseCh, func(c workflow.Channel, more bool) {
+			c.Receive(ctx, nil)
+			paused = true
+		})
+		selector.AddReceive(resumeCh, func(c workflow.Channel, more bool) {
+			// the job is not paused, nothing to resume
+			c.Receive(ctx, nil)
+		})
+		for !done && !paused {
+			selector.Select(ctx)
+		}
+		if paused {
+			cancel()
+		}
+
+		var result HeartBeatDetails
+		err = future.Get(ctx, &result)
+		if err == nil {
+			return result, nil
+		}
+		canceledErr, ok := err.(*temporal.CanceledError)
+		if !ok || !paused {
+			return HeartBeatDetails{}, err
+		}
+		if canceledErr.HasDetails() {
+			if err := canceledErr.Details(&state.Progress); err != nil {
+				return HeartBeatDetails{}, err
+			}
+		}
+
+		state.Paused = true
+		resumeCh.Receive(ctx, nil)
+		state.Paused = false
+		// drop the pause signals received while the job was paused
+		for pauseCh.ReceiveAsync(nil) {
+		}
+	}
 }
 
 func validateParams(params BatchParams) error {
@@ -199,9 +330,14 @@ func validateParams(params BatchParams) error {
 			return fmt.Errorf("must provide signal name")
 		}
 		return nil
-	case BatchTypeCancel:
-		fallthrough
-	case BatchTypeTerminate:
+	case BatchTypeReset:
+		return validateResetParams(params.ResetParams)
+	case BatchTypeUpsertSearchAttributes:
+		if len(params.UpsertSearchAttributesParams.SearchAttributes) == 0 {
+			return fmt.Errorf("must provide search attributes")
+		}
+		return nil
+	case BatchTypeCancel, BatchTypeTerminate, BatchTypeDelete:
 		return nil
 	default:
 		return fmt.Errorf("not supported batch type: %v", params.BatchType)
@@ -233,13 +369,16 @@ func setDefaultParams(params BatchParams) BatchParams {
 	return params
 }
 
-// BatchActivity is activity for processing batch operation
-func BatchActivity(ctx context.Context, batchParams BatchParams) (HeartBeatDetails, error) {
+// BatchActivity is activity for processing batch operation, progress is the progress
+// reported by the previous activity of the job when the job was paused
+func BatchActivity(ctx context.Context, batchParams BatchParams, progress HeartBeatDetails) (HeartBeatDetails, error) {
 	batcher := ctx.Value(batcherContextKey).(*Batcher)
 	client := batcher.clientBean.GetFrontendClient()
+	// the non retryable errors are not part of the serialized params
+	batchParams = setDefaultParams(batchParams)
 
-	hbd := HeartBeatDetails{}
-	startOver := true
+	hbd := progress
+	startOver := progress.CurrentPage == 0 && len(progress.PendingExecutions) == 0
 	if activity.HasHeartbeatDetails(ctx) {
 		if err := activity.GetHeartbeatDetails(ctx, &hbd); err == nil {
 			startOver = false
@@ -262,81 +401,147 @@ func BatchActivity(ctx context.Context, batchParams BatchParams) (HeartBeatDetai
 	}
 	rateLimiter := rate.NewLimiter(rate.Limit(batchParams.RPS), batchParams.RPS)
 	taskCh := make(chan taskDetail, pageSize)
-	respCh := make(chan error, pageSize)
+	respCh := make(chan taskResult, pageSize)
 	for i := 0; i < batchParams.Concurrency; i++ {
 		go startTaskProcessor(ctx, batchParams, taskCh, respCh, rateLimiter, client)
 	}
 
+	current := &batchProgress{hbd: hbd}
 	for {
-		// TODO https://github.com/uber/cadence/issues/2154
-		//  Need to improve scan concurrency because it will hold an ES resource until the workflow finishes.
-		//  And we can't use list API because terminate / reset will mutate the result.
-		resp, err := client.ScanWorkflowExecutions(ctx, &workflowservice.ScanWorkflowExecutionsRequest{
-			Domain:        batchParams.DomainName,
-			PageSize:      int32(pageSize),
-			NextPageToken: hbd.PageToken,
-			Query:         batchParams.Query,
-		})
-		if err != nil {
-			return HeartBeatDetails{}, err
-		}
-		batchCount := len(resp.Executions)
-		if batchCount <= 0 {
-			break
+		if len(hbd.PendingExecutions) == 0 {
+			if !startOver && len(hbd.PageToken) == 0 {
+				// the last page was processed by the previous activity
+				break
+			}
+			startOver = false
+
+			// TODO https://github.com/uber/cadence/issues/2154
+			//  Need to improve scan concurrency because it will hold an ES resource until the workflow finishes.
+			//  And we can't use list API because terminate / reset will mutate the result.
+			resp, err := client.ScanWorkflowExecutions(ctx, &workflowservice.ScanWorkflowExecutionsRequest{
+				Domain:        batchParams.DomainName,
+				PageSize:      int32(pageSize),
+				NextPageToken: hbd.PageToken,
+				Query:         batchParams.Query,
+			})
+			if err != nil {
+				return HeartBeatDetails{}, err
+			}
+			if len(resp.Executions) <= 0 {
+				break
+			}
+			pending := make([]commonproto.WorkflowExecution, 0, len(resp.Executions))
+			for _, wf := range resp.Executions {
+				pending = append(pending, *wf.Execution)
+			}
+			current.update(ctx, func(hbd *HeartBeatDetails) {
+				hbd.PageToken = resp.NextPageToken
+				hbd.PendingExecutions = pending
+			})
+			hbd = current.details()
 		}
 
 		// send all tasks
-		for _, wf := range resp.Executions {
+		for _, execution := range hbd.PendingExecutions {
 			taskCh <- taskDetail{
-				execution: *wf.Execution,
+				execution: execution,
 				attempts:  0,
-				hbd:       hbd,
+				progress:  current,
 			}
 		}
 
-		succCount := 0
-		errCount := 0
-		// wait for counters indicate this batch is done
-	Loop:
-		for {
+		// wait for the results of the page, the progress is recorded for every workflow so that
+		// a paused or retried activity does not process the workflows of the page again
+		for remaining := len(hbd.PendingExecutions); remaining > 0; remaining-- {
 			select {
-			case err := <-respCh:
-				if err == nil {
-					succCount++
-				} else {
-					errCount++
-				}
-				if succCount+errCount == batchCount {
-					break Loop
-				}
+			case result := <-respCh:
+				current.update(ctx, func(hbd *HeartBeatDetails) {
+					hbd.addResult(result)
+				})
 			case <-ctx.Done():
-				return HeartBeatDetails{}, ctx.Err()
+				// record the workflows processed before the activity was canceled, they are not processed again
+				for drained := false; !drained; {
+					select {
+					case result := <-respCh:
+						current.update(ctx, func(hbd *HeartBeatDetails) {
+							hbd.addResult(result)
+						})
+					default:
+						drained = true
+					}
+				}
+				return HeartBeatDetails{}, temporal.NewCanceledError(current.details())
 			}
 		}
 
-		hbd.CurrentPage++
-		hbd.PageToken = resp.NextPageToken
-		hbd.SuccessCount += succCount
-		hbd.ErrorCount += errCount
-		activity.RecordHeartbeat(ctx, hbd)
-
+		current.update(ctx, func(hbd *HeartBeatDetails) {
+			hbd.CurrentPage++
+		})
+		hbd = current.details()
 		if len(hbd.PageToken) == 0 {
 			break
 		}
 	}
 
-	return hbd, nil
+	return current.details(), nil
+}
+
+func (hbd *HeartBeatDetails) addResult(result taskResult) {
+	if result.err == nil {
+		hbd.SuccessCount++
+	} else {
+		hbd.ErrorCount++
+		if len(hbd.Failures) < maxFailuresReported {
+			hbd.Failures = append(hbd.Failures, WorkflowFailure{
+				WorkflowID: result.execution.GetWorkflowId(),
+				RunID:      result.execution.GetRunId(),
+				Error:      result.err.Error(),
+			})
+		}
+	}
+
+	// the pending executions are copied, the details returned earlier may still be using the previous slice
+	pending := make([]commonproto.WorkflowExecution, 0, len(hbd.PendingExecutions))
+	for _, execution := range hbd.PendingExecutions {
+		if execution.GetWorkflowId() != result.execution.GetWorkflowId() || execution.GetRunId() != result.execution.GetRunId() {
+			pending = append(pending, execution)
+		}
+	}
+	hbd.PendingExecutions = pending
+}
+
+func (p *batchProgress) update(ctx context.Context, fn func(hbd *HeartBeatDetails)) {
+	p.Lock()
+	defer p.Unlock()
+
+	fn(&p.hbd)
+	activity.RecordHeartbeat(ctx, p.hbd)
+}
+
+func (p *batchProgress) heartbeat(ctx context.Context) {
+	p.Lock()
+	defer p.Unlock()
+
+	activity.RecordHeartbeat(ctx, p.hbd)
+}
+
+func (p *batchProgress) details() HeartBeatDetails {
+	p.Lock()
+	defer p.Unlock()
+
+	return p.hbd
 }
 
 func startTaskProcessor(
 	ctx context.Context,
 	batchParams BatchParams,
 	taskCh chan taskDetail,
-	respCh chan error,
+	respCh chan taskResult,
 	limiter *rate.Limiter,
 	client frontend.Client,
 ) {
 	batcher := ctx.Value(batcherContextKey).(*Batcher)
+	adminClient := batcher.clientBean.GetRemoteAdminClient(batcher.cfg.ClusterMetadata.GetCurrentClusterName())
 	for {
 		select {
 		case <-ctx.Done():
@@ -346,7 +551,7 @@ func startTaskProcessor(
 				return
 			}
 			var err error
-			requestID := uuid.New().String()
+			requestID := getRequestID(ctx, task.execution)
 			ctx = metadata.AppendToOutgoingContext(ctx, common.EnforceDCRedirection, "true")
 
 			switch batchParams.BatchType {
@@ -396,6 +601,55 @@ func startTaskProcessor(
 						})
 						return err
 					})
+			case BatchTypeReset:
+				err = processTask(ctx, limiter, task, batchParams, client, common.BoolPtr(false),
+					func(workflowID, runID string) error {
+						return resetWorkflow(ctx, client, batchParams, workflowID, runID, requestID)
+					})
+			case BatchTypeDelete:
+				err = processTask(ctx, limiter, task, batchParams, client, common.BoolPtr(false),
+					func(workflowID, runID string) error {
+						execution := &commonproto.WorkflowExecution{
+							WorkflowId: workflowID,
+							RunId:      runID,
+						}
+						_, err := client.TerminateWorkflowExecution(ctx, &workflowservice.TerminateWorkflowExecutionRequest{
+							Domain:            batchParams.DomainName,
+							WorkflowExecution: execution,
+							Reason:            batchParams.Reason,
+							Identity:          BatchWFTypeName,
+						})
+						// NotFound means wf is not running
+						if err != nil && status.Code(err) != codes.NotFound {
+							return err
+						}
+						_, err = adminClient.DeleteWorkflowExecution(ctx, &adminservice.DeleteWorkflowExecutionRequest{
+							Domain:            batchParams.DomainName,
+							WorkflowExecution: execution,
+							Identity:          BatchWFTypeName,
+							Reason:            batchParams.Reason,
+						})
+						return err
+					})
+			case BatchTypeUpsertSearchAttributes:
+				err = processTask(ctx, limiter, task, batchParams, client, common.BoolPtr(false),
+					func(workflowID, runID string) error {
+						searchAttributes, err := encodeSearchAttributes(batchParams.UpsertSearchAttributesParams.SearchAttributes)
+						if err != nil {
+							return err
+						}
+						_, err = adminClient.UpsertWorkflowSearchAttributes(ctx, &adminservice.UpsertWorkflowSearchAttributesRequest{
+							Domain: batchParams.DomainName,
+							WorkflowExecution: &commonproto.WorkflowExecution{
+								WorkflowId: workflowID,
+								RunId:      runID,
+							},
+							SearchAttributes: searchAttributes,
+							Identity:         BatchWFTypeName,
+							Reason:           batchParams.Reason,
+						})
+						return err
+					})
 			}
 			if err != nil {
 				batcher.metricsClient.IncCounter(metrics.BatcherScope, metrics.BatcherProcessorFailures)
@@ -403,7 +657,7 @@ func startTaskProcessor(
 
 				_, ok := batchParams._nonRetryableErrors[err.Error()]
 				if ok || task.attempts >= batchParams.AttemptsOnRetryableError {
-					respCh <- err
+					respCh <- taskResult{execution: task.execution, err: err}
 				} else {
 					// put back to the channel if less than attemptsOnError
 					task.attempts++
@@ -411,7 +665,7 @@ func startTaskProcessor(
 				}
 			} else {
 				batcher.metricsClient.IncCounter(metrics.BatcherScope, metrics.BatcherProcessorSuccess)
-				respCh <- nil
+				respCh <- taskResult{execution: task.execution}
 			}
 		}
 	}
@@ -434,7 +688,7 @@ func processTask(
 		if err != nil {
 			return err
 		}
-		activity.RecordHeartbeat(ctx, task.hbd)
+		task.progress.heartbeat(ctx)
 
 		err = procFn(wf.GetWorkflowId(), wf.GetRunId())
 		if err != nil {
@@ -475,6 +729,26 @@ func processTask(
 	return nil
 }
 
+func encodeSearchAttributes(attributes map[string]interface{}) (*commonproto.SearchAttributes, error) {
+	indexedFields := make(map[string][]byte, len(attributes))
+	for key, value := range attributes {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		indexedFields[key] = data
+	}
+	return &commonproto.SearchAttributes{IndexedFields: indexedFields}, nil
+}
+
+// getRequestID returns the request ID of the operation of the batch job on the workflow, the ID is the same
+// every time the workflow is processed so that processing it again after a retry of the activity is deduped
+func getRequestID(ctx context.Context, execution commonproto.WorkflowExecution) string {
+	job := activity.GetInfo(ctx).WorkflowExecution
+	name := fmt.Sprintf("%v/%v/%v/%v", job.ID, job.RunID, execution.GetWorkflowId(), execution.GetRunId())
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(name)).String()
+}
+
 func isDone(ctx context.Context) bool {
 	select {
 	case <-ctx.Done():
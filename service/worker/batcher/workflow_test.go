@@ -0,0 +1,297 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package batcher
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gogo/status"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	"go.temporal.io/temporal"
+	commonproto "go.temporal.io/temporal-proto/common"
+	"go.temporal.io/temporal-proto/enums"
+	"go.temporal.io/temporal-proto/workflowservice"
+	"go.temporal.io/temporal-proto/workflowservicemock"
+	"go.temporal.io/temporal/testsuite"
+	"go.temporal.io/temporal/worker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	"github.com/temporalio/temporal/.gen/proto/adminservicemock"
+	"github.com/temporalio/temporal/client"
+	"github.com/temporalio/temporal/common/cluster"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/metrics"
+)
+
+type workflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	controller     *gomock.Controller
+	frontendClient *workflowservicemock.MockWorkflowServiceClient
+	adminClient    *adminservicemock.MockAdminServiceClient
+	batcher        *Batcher
+}
+
+var testExecution = commonproto.WorkflowExecution{
+	WorkflowId: "test-workflow-id",
+	RunId:      "test-run-id",
+}
+
+func TestWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(workflowTestSuite))
+}
+
+func (s *workflowTestSuite) SetupTest() {
+	s.controller = gomock.NewController(s.T())
+	s.frontendClient = workflowservicemock.NewMockWorkflowServiceClient(s.controller)
+	s.adminClient = adminservicemock.NewMockAdminServiceClient(s.controller)
+	clientBean := client.NewMockBean(s.controller)
+	clientBean.EXPECT().GetFrontendClient().Return(s.frontendClient).AnyTimes()
+	clientBean.EXPECT().GetRemoteAdminClient(cluster.TestCurrentClusterName).Return(s.adminClient).AnyTimes()
+	clusterMetadata := cluster.NewMockMetadata(s.controller)
+	clusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+
+	s.batcher = &Batcher{
+		cfg:           Config{ClusterMetadata: clusterMetadata},
+		clientBean:    clientBean,
+		metricsClient: metrics.NewClient(tally.NoopScope, metrics.Worker),
+		logger:        loggerimpl.NewNopLogger(),
+	}
+}
+
+func (s *workflowTestSuite) TearDownTest() {
+	s.controller.Finish()
+}
+
+func (s *workflowTestSuite) newParams(batchType string) BatchParams {
+	return BatchParams{
+		DomainName:  "test-domain",
+		Query:       "WorkflowType = 'test-workflow-type'",
+		Reason:      "test-reason",
+		BatchType:   batchType,
+		Concurrency: 1,
+	}
+}
+
+func (s *workflowTestSuite) expectScan(executions ...commonproto.WorkflowExecution) {
+	s.frontendClient.EXPECT().CountWorkflowExecutions(gomock.Any(), gomock.Any()).
+		Return(&workflowservice.CountWorkflowExecutionsResponse{Count: int64(len(executions))}, nil).Times(1)
+	var infos []*commonproto.WorkflowExecutionInfo
+	for i := range executions {
+		infos = append(infos, &commonproto.WorkflowExecutionInfo{Execution: &executions[i]})
+	}
+	s.frontendClient.EXPECT().ScanWorkflowExecutions(gomock.Any(), gomock.Any()).
+		Return(&workflowservice.ScanWorkflowExecutionsResponse{Executions: infos}, nil).Times(1)
+}
+
+func (s *workflowTestSuite) expectDescribe(closeStatus enums.WorkflowExecutionCloseStatus) {
+	s.frontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).
+		Return(&workflowservice.DescribeWorkflowExecutionResponse{
+			WorkflowExecutionInfo: &commonproto.WorkflowExecutionInfo{
+				Execution:   &testExecution,
+				CloseStatus: closeStatus,
+				CloseTime:   time.Now().UnixNano(),
+			},
+		}, nil).AnyTimes()
+}
+
+func (s *workflowTestSuite) executeWorkflow(params BatchParams) HeartBeatDetails {
+	env := s.NewTestWorkflowEnvironment()
+	env.SetWorkerOptions(worker.Options{
+		BackgroundActivityContext: context.WithValue(context.Background(), batcherContextKey, s.batcher),
+	})
+	env.ExecuteWorkflow(BatchWFTypeName, params)
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+
+	var result HeartBeatDetails
+	s.NoError(env.GetWorkflowResult(&result))
+	return result
+}
+
+func (s *workflowTestSuite) TestReset() {
+	s.expectScan(testExecution)
+	s.expectDescribe(enums.WorkflowExecutionCloseStatusCompleted)
+	s.frontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).
+		Return(&workflowservice.GetWorkflowExecutionHistoryResponse{
+			History: &commonproto.History{Events: []*commonproto.HistoryEvent{
+				{EventId: 4, EventType: enums.EventTypeDecisionTaskCompleted},
+				{EventId: 8, EventType: enums.EventTypeDecisionTaskCompleted},
+				{EventId: 9, EventType: enums.EventTypeWorkflowExecutionCompleted},
+			}},
+		}, nil).Times(1)
+	var requestID string
+	s.frontendClient.EXPECT().ResetWorkflowExecution(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, request *workflowservice.ResetWorkflowExecutionRequest, _ ...grpc.CallOption) (*workflowservice.ResetWorkflowExecutionResponse, error) {
+			s.Equal("test-domain", request.GetDomain())
+			s.Equal(testExecution, *request.GetWorkflowExecution())
+			s.Equal(int64(8), request.GetDecisionFinishEventId())
+			s.Equal("test-reason", request.GetReason())
+			requestID = request.GetRequestId()
+			return &workflowservice.ResetWorkflowExecutionResponse{RunId: "new-run-id"}, nil
+		}).Times(1)
+
+	params := s.newParams(BatchTypeReset)
+	params.ResetParams.ResetType = ResetTypeLastDecisionCompleted
+	result := s.executeWorkflow(params)
+	s.Equal(1, result.SuccessCount)
+	s.Equal(0, result.ErrorCount)
+	s.Empty(result.PendingExecutions)
+	s.NotEmpty(requestID)
+}
+
+func (s *workflowTestSuite) TestReset_SkipCurrentOpen() {
+	s.expectScan(testExecution)
+	s.expectDescribe(enums.WorkflowExecutionCloseStatusRunning)
+
+	params := s.newParams(BatchTypeReset)
+	params.ResetParams.ResetType = ResetTypeLastDecisionCompleted
+	params.ResetParams.SkipCurrentOpen = true
+	result := s.executeWorkflow(params)
+	s.Equal(1, result.SuccessCount)
+}
+
+func (s *workflowTestSuite) TestDelete() {
+	s.expectScan(testExecution)
+	// the workflow is already closed, it is deleted without being terminated
+	s.frontendClient.EXPECT().TerminateWorkflowExecution(gomock.Any(), gomock.Any()).
+		Return(nil, status.New(codes.NotFound, "workflow execution already completed").Err()).Times(1)
+	s.adminClient.EXPECT().DeleteWorkflowExecution(gomock.Any(), &adminservice.DeleteWorkflowExecutionRequest{
+		Domain:            "test-domain",
+		WorkflowExecution: &testExecution,
+		Identity:          BatchWFTypeName,
+		Reason:            "test-reason",
+	}).Return(&adminservice.DeleteWorkflowExecutionResponse{}, nil).Times(1)
+	s.frontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).
+		Return(nil, status.New(codes.NotFound, "workflow execution not found").Err()).Times(1)
+
+	result := s.executeWorkflow(s.newParams(BatchTypeDelete))
+	s.Equal(1, result.SuccessCount)
+	s.Equal(0, result.ErrorCount)
+}
+
+func (s *workflowTestSuite) TestUpsertSearchAttributes() {
+	s.expectScan(testExecution)
+	s.adminClient.EXPECT().UpsertWorkflowSearchAttributes(gomock.Any(), &adminservice.UpsertWorkflowSearchAttributesRequest{
+		Domain:            "test-domain",
+		WorkflowExecution: &testExecution,
+		SearchAttributes: &commonproto.SearchAttributes{IndexedFields: map[string][]byte{
+			"CustomKeywordField": []byte(`"keyword"`),
+			"CustomIntField":     []byte(`1`),
+		}},
+		Identity: BatchWFTypeName,
+		Reason:   "test-reason",
+	}).Return(nil, status.New(codes.InvalidArgument, "CustomIntField is not a valid search attribute").Err()).Times(1)
+	s.expectDescribe(enums.WorkflowExecutionCloseStatusRunning)
+
+	params := s.newParams(BatchTypeUpsertSearchAttributes)
+	params.UpsertSearchAttributesParams.SearchAttributes = map[string]interface{}{
+		"CustomKeywordField": "keyword",
+		"CustomIntField":     1,
+	}
+	params.NonRetryableErrors = []string{"rpc error: code = InvalidArgument desc = CustomIntField is not a valid search attribute"}
+	result := s.executeWorkflow(params)
+	s.Equal(0, result.SuccessCount)
+	s.Equal(1, result.ErrorCount)
+	s.Equal([]WorkflowFailure{{
+		WorkflowID: testExecution.WorkflowId,
+		RunID:      testExecution.RunId,
+		Error:      "rpc error: code = InvalidArgument desc = CustomIntField is not a valid search attribute",
+	}}, result.Failures)
+}
+
+func (s *workflowTestSuite) TestPauseAndResume() {
+	env := s.NewTestWorkflowEnvironment()
+	pausedCh := make(chan struct{})
+	var attempts int32
+	env.OnActivity(batchActivityName, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, params BatchParams, progress HeartBeatDetails) (HeartBeatDetails, error) {
+			if atomic.AddInt32(&attempts, 1) == 1 {
+				// the first activity runs until it is canceled by the pause
+				<-pausedCh
+				return HeartBeatDetails{}, temporal.NewCanceledError()
+			}
+			progress.SuccessCount += 2
+			return progress, nil
+		})
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(PauseSignalName, nil)
+		close(pausedCh)
+	}, 0)
+	env.RegisterDelayedCallback(func() {
+		value, err := env.QueryWorkflow(StateQueryType)
+		s.NoError(err)
+		var state BatchState
+		s.NoError(value.Get(&state))
+		s.True(state.Paused)
+		s.Equal(int32(1), atomic.LoadInt32(&attempts))
+		env.SignalWorkflow(ResumeSignalName, nil)
+	}, time.Minute)
+
+	env.ExecuteWorkflow(BatchWFTypeName, s.newParams(BatchTypeTerminate))
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+	var result HeartBeatDetails
+	s.NoError(env.GetWorkflowResult(&result))
+	s.Equal(2, result.SuccessCount)
+	s.Equal(int32(2), atomic.LoadInt32(&attempts))
+}
+
+func (s *workflowTestSuite) TestBatchActivity_CanceledKeepsPendingExecutions() {
+	secondExecution := commonproto.WorkflowExecution{WorkflowId: "second-workflow-id", RunId: "second-run-id"}
+	s.expectScan(testExecution, secondExecution)
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), batcherContextKey, s.batcher))
+	defer cancel()
+	s.frontendClient.EXPECT().SignalWorkflowExecution(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, request *workflowservice.SignalWorkflowExecutionRequest, _ ...grpc.CallOption) (*workflowservice.SignalWorkflowExecutionResponse, error) {
+			if request.GetWorkflowExecution().GetWorkflowId() == secondExecution.WorkflowId {
+				// the job is paused while the second workflow is processed
+				cancel()
+				return nil, context.Canceled
+			}
+			return &workflowservice.SignalWorkflowExecutionResponse{}, nil
+		}).Times(2)
+	s.expectDescribe(enums.WorkflowExecutionCloseStatusRunning)
+
+	env := s.NewTestActivityEnvironment()
+	env.SetWorkerOptions(worker.Options{BackgroundActivityContext: ctx})
+	params := s.newParams(BatchTypeSignal)
+	params.SignalParams.SignalName = "test-signal"
+	_, err := env.ExecuteActivity(batchActivityName, setDefaultParams(params), HeartBeatDetails{})
+
+	canceledErr, ok := err.(*temporal.CanceledError)
+	s.True(ok)
+	var progress HeartBeatDetails
+	s.NoError(canceledErr.Details(&progress))
+	s.Equal(1, progress.SuccessCount)
+	s.Equal(0, progress.CurrentPage)
+	s.Equal([]commonproto.WorkflowExecution{secondExecution}, progress.PendingExecutions)
+}
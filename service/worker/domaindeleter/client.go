@@ -0,0 +1,100 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package domaindeleter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogo/status"
+	"go.temporal.io/temporal-proto/errordetails"
+	"go.temporal.io/temporal-proto/workflowservice"
+	cclient "go.temporal.io/temporal/client"
+
+	"github.com/temporalio/temporal/common"
+)
+
+type (
+	// Client is used to start the domain deleter workflow of a deleted domain
+	Client interface {
+		// DeleteDomain starts the workflow that purges the data of the domain, or returns
+		// the run ID of the workflow already purging it
+		DeleteDomain(ctx context.Context, domainID string, domainName string) (string, error)
+	}
+
+	clientImpl struct {
+		cadenceClient cclient.Client
+		numShards     int
+	}
+)
+
+var _ Client = (*clientImpl)(nil)
+
+const (
+	startTimeout = 10 * time.Second
+)
+
+// NewClient creates a new Client
+func NewClient(
+	publicClient workflowservice.WorkflowServiceClient,
+	numShards int,
+) Client {
+	return &clientImpl{
+		cadenceClient: cclient.NewClient(publicClient, common.SystemLocalDomainName, &cclient.Options{}),
+		numShards:     numShards,
+	}
+}
+
+// WorkflowID returns the ID of the domain deleter workflow of the domain
+func WorkflowID(domainID string) string {
+	return fmt.Sprintf("%v-%v", workflowIDPrefix, domainID)
+}
+
+func (c *clientImpl) DeleteDomain(
+	ctx context.Context,
+	domainID string,
+	domainName string,
+) (string, error) {
+
+	workflowOptions := cclient.StartWorkflowOptions{
+		ID:                              WorkflowID(domainID),
+		TaskList:                        taskListName,
+		ExecutionStartToCloseTimeout:    infiniteDuration,
+		DecisionTaskStartToCloseTimeout: time.Minute,
+		WorkflowIDReusePolicy:           cclient.WorkflowIDReusePolicyAllowDuplicate,
+	}
+	params := WorkflowParams{
+		DomainID:   domainID,
+		DomainName: domainName,
+		NumShards:  c.numShards,
+	}
+	startCtx, cancel := context.WithTimeout(ctx, startTimeout)
+	defer cancel()
+	execution, err := c.cadenceClient.StartWorkflow(startCtx, workflowOptions, workflowTypeName, params)
+	if err != nil {
+		if failure, ok := errordetails.GetWorkflowExecutionAlreadyStartedFailure(status.Convert(err)); ok {
+			return failure.GetRunId(), nil
+		}
+		return "", err
+	}
+	return execution.RunID, nil
+}
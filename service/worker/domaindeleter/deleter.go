@@ -0,0 +1,96 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package domaindeleter
+
+import (
+	"context"
+
+	"github.com/uber-go/tally"
+	"go.temporal.io/temporal/worker"
+	"go.uber.org/zap"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/resource"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	// Config defines the configuration for domain deleter
+	Config struct {
+		// PersistenceMaxQPS the max rate of calls to persistence
+		PersistenceMaxQPS dynamicconfig.IntPropertyFn
+	}
+
+	// BootstrapParams contains the set of params needed to bootstrap
+	// the domain deleter sub-system
+	BootstrapParams struct {
+		// Config contains the configuration for domain deleter
+		Config Config
+		// TallyScope is an instance of tally metrics scope
+		TallyScope tally.Scope
+	}
+
+	// deleterContext is the context object that gets
+	// passed around within the domain deleter activities
+	deleterContext struct {
+		resource.Resource
+		cfg Config
+	}
+
+	// Deleter is the background sub-system that purges the
+	// workflow data of the deleted domains
+	Deleter struct {
+		context    deleterContext
+		tallyScope tally.Scope
+		zapLogger  *zap.Logger
+	}
+)
+
+// New returns a new instance of domain deleter
+func New(
+	resource resource.Resource,
+	params *BootstrapParams,
+) *Deleter {
+
+	zapLogger, err := zap.NewProduction()
+	if err != nil {
+		resource.GetLogger().Fatal("failed to initialize zap logger", tag.Error(err))
+	}
+	return &Deleter{
+		context: deleterContext{
+			Resource: resource,
+			cfg:      params.Config,
+		},
+		tallyScope: params.TallyScope,
+		zapLogger:  zapLogger,
+	}
+}
+
+// Start starts the domain deleter worker, the deleter workflows are started by DeleteDomain
+func (d *Deleter) Start() error {
+	workerOpts := worker.Options{
+		Logger:                    d.zapLogger,
+		MetricsScope:              d.tallyScope,
+		BackgroundActivityContext: context.WithValue(context.Background(), deleterContextKey, d.context),
+	}
+	return worker.New(d.context.GetSDKClient(), common.SystemLocalDomainName, taskListName, workerOpts).Start()
+}
@@ -0,0 +1,548 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package domaindeleter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gogo/status"
+	"go.temporal.io/temporal"
+	commonproto "go.temporal.io/temporal-proto/common"
+	"go.temporal.io/temporal-proto/workflowservice"
+	"go.temporal.io/temporal/activity"
+	"go.temporal.io/temporal/workflow"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+
+	"github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/.gen/proto/historyservice"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+	p "github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	contextKey int
+)
+
+const (
+	deleterContextKey = contextKey(0)
+
+	workflowIDPrefix = "cadence-sys-domain-deleter"
+	workflowTypeName = "cadence-sys-domain-deleter-workflow"
+	taskListName     = "cadence-sys-domain-deleter-tasklist-0"
+
+	checkDomainActivityName      = "cadence-sys-domain-deleter-check-domain-activity"
+	deleteExecutionsActivityName = "cadence-sys-domain-deleter-executions-activity"
+	deleteTaskListsActivityName  = "cadence-sys-domain-deleter-task-lists-activity"
+	deleteDomainActivityName     = "cadence-sys-domain-deleter-domain-activity"
+
+	shardBatchSize   = 16
+	pageSize         = 100
+	maxPasses        = 5
+	infiniteDuration = 20 * 365 * 24 * time.Hour
+	terminateReason  = "domain deleted"
+
+	// passRetryInterval is the wait before another pass over the shards when executions failed
+	// to be deleted, e.g. standby executions whose termination is not replicated yet
+	passRetryInterval = time.Minute
+
+	// ProgressQuery is the query type that returns the progress of a domain deleter workflow
+	ProgressQuery = "domain_deleter_progress"
+
+	// PhaseCheckingDomain is the phase that verifies the domain is marked deleted
+	PhaseCheckingDomain = "CheckingDomain"
+	// PhaseDeletingExecutions is the phase that terminates and deletes the executions of the domain
+	PhaseDeletingExecutions = "DeletingExecutions"
+	// PhaseDeletingTaskLists is the phase that deletes the task lists of the domain
+	PhaseDeletingTaskLists = "DeletingTaskLists"
+	// PhaseDeletingDomain is the phase that removes the domain record
+	PhaseDeletingDomain = "DeletingDomain"
+	// PhaseCompleted is the phase of a domain deleter workflow that deleted the domain
+	PhaseCompleted = "Completed"
+)
+
+type (
+	// WorkflowParams are the params of the domain deleter workflow
+	WorkflowParams struct {
+		DomainID   string
+		DomainName string
+		NumShards  int
+	}
+
+	// Progress is the progress of a domain deleter workflow, the counts add up over all passes
+	Progress struct {
+		DomainID   string
+		DomainName string
+		Phase      string
+		// Pass is the current pass over the shards, the executions are deleted again as long as
+		// a pass terminated open executions, which may have started new ones, or failed to delete some
+		Pass                 int
+		NumShards            int
+		ShardsCompleted      int
+		ExecutionsTerminated int64
+		ExecutionsDeleted    int64
+		ExecutionErrors      int64
+		TaskListsDeleted     int64
+		StartTime            time.Time
+		CloseTime            time.Time
+	}
+
+	deleteExecutionsActivityParams struct {
+		DomainID     string
+		DomainName   string
+		StartShardID int
+		EndShardID   int
+	}
+
+	deleteExecutionsResult struct {
+		ExecutionsTerminated int64
+		ExecutionsDeleted    int64
+		ExecutionErrors      int64
+	}
+
+	deleteExecutionsHeartbeatDetails struct {
+		NextShardID int
+		Result      deleteExecutionsResult
+	}
+)
+
+var (
+	errDomainNotDeleted = errors.New("domain is not marked deleted")
+	errDomainNotFound   = errors.New("domain does not exist")
+
+	activityRetryPolicy = temporal.RetryPolicy{
+		InitialInterval:          10 * time.Second,
+		BackoffCoefficient:       1.7,
+		MaximumInterval:          5 * time.Minute,
+		ExpirationInterval:       infiniteDuration,
+		NonRetriableErrorReasons: []string{errDomainNotDeleted.Error(), errDomainNotFound.Error()},
+	}
+	activityOptions = workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    infiniteDuration,
+		HeartbeatTimeout:       5 * time.Minute,
+		RetryPolicy:            &activityRetryPolicy,
+	}
+)
+
+func init() {
+	workflow.RegisterWithOptions(DomainDeleterWorkflow, workflow.RegisterOptions{Name: workflowTypeName})
+	activity.RegisterWithOptions(CheckDomainActivity, activity.RegisterOptions{Name: checkDomainActivityName})
+	activity.RegisterWithOptions(DeleteExecutionsActivity, activity.RegisterOptions{Name: deleteExecutionsActivityName})
+	activity.RegisterWithOptions(DeleteTaskListsActivity, activity.RegisterOptions{Name: deleteTaskListsActivityName})
+	activity.RegisterWithOptions(DeleteDomainActivity, activity.RegisterOptions{Name: deleteDomainActivityName})
+}
+
+// DomainDeleterWorkflow is the workflow that purges the data of a domain marked deleted: it terminates
+// and deletes the executions of the domain on all the shards, deletes its task lists and finally
+// removes the domain record
+func DomainDeleterWorkflow(
+	ctx workflow.Context,
+	params WorkflowParams,
+) (*Progress, error) {
+
+	progress := &Progress{
+		DomainID:   params.DomainID,
+		DomainName: params.DomainName,
+		Phase:      PhaseCheckingDomain,
+		NumShards:  params.NumShards,
+		StartTime:  workflow.Now(ctx),
+	}
+	err := workflow.SetQueryHandler(ctx, ProgressQuery, func() (*Progress, error) {
+		return progress, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	activityCtx := workflow.WithActivityOptions(ctx, activityOptions)
+	if err := workflow.ExecuteActivity(activityCtx, checkDomainActivityName, params.DomainID).Get(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	progress.Phase = PhaseDeletingExecutions
+	for {
+		progress.Pass++
+		progress.ShardsCompleted = 0
+		var passResult deleteExecutionsResult
+		for startShardID := 0; startShardID < params.NumShards; startShardID += shardBatchSize {
+			endShardID := startShardID + shardBatchSize
+			if endShardID > params.NumShards {
+				endShardID = params.NumShards
+			}
+
+			var batchResult deleteExecutionsResult
+			future := workflow.ExecuteActivity(
+				activityCtx,
+				deleteExecutionsActivityName,
+				deleteExecutionsActivityParams{
+					DomainID:     params.DomainID,
+					DomainName:   params.DomainName,
+					StartShardID: startShardID,
+					EndShardID:   endShardID,
+				},
+			)
+			if err := future.Get(ctx, &batchResult); err != nil {
+				return nil, err
+			}
+			passResult.merge(&batchResult)
+			progress.ExecutionsTerminated += batchResult.ExecutionsTerminated
+			progress.ExecutionsDeleted += batchResult.ExecutionsDeleted
+			progress.ExecutionErrors += batchResult.ExecutionErrors
+			progress.ShardsCompleted = endShardID
+		}
+
+		if passResult.ExecutionsTerminated == 0 && passResult.ExecutionErrors == 0 {
+			break
+		}
+		if progress.Pass >= maxPasses {
+			// the domain is left marked deleted, deleting it again restarts the purge
+			return nil, fmt.Errorf("executions of domain %v are left after %v passes, %v of the last pass failed to be deleted",
+				params.DomainName, progress.Pass, passResult.ExecutionErrors)
+		}
+		if passResult.ExecutionErrors > 0 {
+			if err := workflow.Sleep(ctx, passRetryInterval); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	progress.Phase = PhaseDeletingTaskLists
+	if err := workflow.ExecuteActivity(activityCtx, deleteTaskListsActivityName, params.DomainID).Get(ctx, &progress.TaskListsDeleted); err != nil {
+		return nil, err
+	}
+
+	progress.Phase = PhaseDeletingDomain
+	if err := workflow.ExecuteActivity(activityCtx, deleteDomainActivityName, params.DomainID).Get(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	progress.Phase = PhaseCompleted
+	progress.CloseTime = workflow.Now(ctx)
+	return progress, nil
+}
+
+// CheckDomainActivity is the activity that verifies the domain exists and is marked deleted
+func CheckDomainActivity(
+	activityCtx context.Context,
+	domainID string,
+) error {
+
+	ctx := activityCtx.Value(deleterContextKey).(deleterContext)
+	resp, err := ctx.GetMetadataManager().GetDomain(&p.GetDomainRequest{ID: domainID})
+	if err != nil {
+		if _, ok := err.(*shared.EntityNotExistsError); ok {
+			return temporal.NewCustomError(errDomainNotFound.Error())
+		}
+		return err
+	}
+	if resp.Info.Status != p.DomainStatusDeleted {
+		return temporal.NewCustomError(errDomainNotDeleted.Error())
+	}
+	return nil
+}
+
+// DeleteExecutionsActivity is the activity that terminates and deletes the executions of the domain on a range of shards
+func DeleteExecutionsActivity(
+	activityCtx context.Context,
+	params deleteExecutionsActivityParams,
+) (*deleteExecutionsResult, error) {
+
+	ctx := activityCtx.Value(deleterContextKey).(deleterContext)
+
+	hbd := deleteExecutionsHeartbeatDetails{NextShardID: params.StartShardID}
+	if activity.HasHeartbeatDetails(activityCtx) {
+		if err := activity.GetHeartbeatDetails(activityCtx, &hbd); err != nil {
+			ctx.GetLogger().Error("Failed to recover from last heartbeat, start over from beginning", tag.Error(err))
+			hbd = deleteExecutionsHeartbeatDetails{NextShardID: params.StartShardID}
+		}
+	}
+
+	domainEntry, err := ctx.GetDomainCache().GetDomainByID(params.DomainID)
+	if err != nil {
+		return nil, err
+	}
+	// executions of a standby domain are terminated in the active cluster, which replicates
+	// the terminations, the standby cluster only deletes the closed executions
+	terminate := domainEntry.IsDomainActive()
+
+	limiter := rate.NewLimiter(rate.Limit(ctx.cfg.PersistenceMaxQPS()), ctx.cfg.PersistenceMaxQPS())
+	for ; hbd.NextShardID < params.EndShardID; hbd.NextShardID++ {
+		// the shard in progress is processed again from the beginning on retry, the executions
+		// deleted before the retry are no longer listed so only the completed shards are recorded
+		var shardResult deleteExecutionsResult
+		err := deleteShardExecutions(activityCtx, ctx, limiter, params, terminate, hbd.NextShardID, &shardResult, func() {
+			activity.RecordHeartbeat(activityCtx, hbd)
+		})
+		if err != nil {
+			return nil, err
+		}
+		hbd.Result.merge(&shardResult)
+		activity.RecordHeartbeat(activityCtx, deleteExecutionsHeartbeatDetails{
+			NextShardID: hbd.NextShardID + 1,
+			Result:      hbd.Result,
+		})
+	}
+	return &hbd.Result, nil
+}
+
+// DeleteTaskListsActivity is the activity that deletes the task lists of the domain and their tasks
+func DeleteTaskListsActivity(
+	activityCtx context.Context,
+	domainID string,
+) (int64, error) {
+
+	ctx := activityCtx.Value(deleterContextKey).(deleterContext)
+	var deleted int64
+	if activity.HasHeartbeatDetails(activityCtx) {
+		if err := activity.GetHeartbeatDetails(activityCtx, &deleted); err != nil {
+			ctx.GetLogger().Error("Failed to recover from last heartbeat, start over from beginning", tag.Error(err))
+			deleted = 0
+		}
+	}
+
+	limiter := rate.NewLimiter(rate.Limit(ctx.cfg.PersistenceMaxQPS()), ctx.cfg.PersistenceMaxQPS())
+	taskManager := ctx.GetTaskManager()
+	var pageToken []byte
+	for {
+		if err := limiter.Wait(activityCtx); err != nil {
+			return deleted, err
+		}
+		resp, err := taskManager.ListTaskList(&p.ListTaskListRequest{
+			PageSize:  pageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return deleted, err
+		}
+
+		for _, taskList := range resp.Items {
+			if taskList.DomainID != domainID {
+				continue
+			}
+			if err := deleteTaskList(activityCtx, taskManager, limiter, &taskList); err != nil {
+				ctx.GetMetricsClient().IncCounter(metrics.DomainDeleterScope, metrics.DomainDeleterErrorCount)
+				ctx.GetLogger().Error("unable to delete task list of deleted domain",
+					tag.WorkflowDomainID(domainID), tag.WorkflowTaskListName(taskList.Name), tag.Error(err))
+				return deleted, err
+			}
+			deleted++
+			ctx.GetMetricsClient().IncCounter(metrics.DomainDeleterScope, metrics.DomainDeleterTaskListsDeletedCount)
+		}
+		activity.RecordHeartbeat(activityCtx, deleted)
+
+		pageToken = resp.NextPageToken
+		if len(pageToken) == 0 {
+			break
+		}
+	}
+	return deleted, nil
+}
+
+// DeleteDomainActivity is the activity that removes the record of the domain
+func DeleteDomainActivity(
+	activityCtx context.Context,
+	domainID string,
+) error {
+
+	ctx := activityCtx.Value(deleterContextKey).(deleterContext)
+	metadataManager := ctx.GetMetadataManager()
+	resp, err := metadataManager.GetDomain(&p.GetDomainRequest{ID: domainID})
+	if err != nil {
+		if _, ok := err.(*shared.EntityNotExistsError); ok {
+			// removed by a previous attempt
+			return nil
+		}
+		return err
+	}
+	if resp.Info.Status != p.DomainStatusDeleted {
+		return temporal.NewCustomError(errDomainNotDeleted.Error())
+	}
+	if err := metadataManager.DeleteDomain(&p.DeleteDomainRequest{ID: domainID}); err != nil {
+		return err
+	}
+	ctx.GetLogger().Info("deleted domain", tag.WorkflowDomainID(domainID), tag.WorkflowDomainName(resp.Info.Name))
+	return nil
+}
+
+func deleteShardExecutions(
+	activityCtx context.Context,
+	ctx deleterContext,
+	limiter *rate.Limiter,
+	params deleteExecutionsActivityParams,
+	terminate bool,
+	shardID int,
+	result *deleteExecutionsResult,
+	onPage func(),
+) error {
+
+	executionManager, err := ctx.GetExecutionManager(shardID)
+	if err != nil {
+		return err
+	}
+
+	var pageToken []byte
+	for {
+		if err := limiter.Wait(activityCtx); err != nil {
+			return err
+		}
+		resp, err := executionManager.ListConcreteExecutions(&p.ListConcreteExecutionsRequest{
+			PageSize:  pageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, execution := range resp.Executions {
+			if execution.DomainID != params.DomainID {
+				continue
+			}
+			terminated, err := deleteExecution(activityCtx, ctx, limiter, params, terminate, execution)
+			if err != nil {
+				if err == context.Canceled || err == context.DeadlineExceeded {
+					return err
+				}
+				result.ExecutionErrors++
+				ctx.GetMetricsClient().IncCounter(metrics.DomainDeleterScope, metrics.DomainDeleterErrorCount)
+				ctx.GetLogger().Error("unable to delete workflow execution of deleted domain",
+					tag.ShardID(shardID),
+					tag.WorkflowDomainID(execution.DomainID),
+					tag.WorkflowID(execution.WorkflowID),
+					tag.WorkflowRunID(execution.RunID),
+					tag.Error(err),
+				)
+				continue
+			}
+			if terminated {
+				result.ExecutionsTerminated++
+			}
+			result.ExecutionsDeleted++
+			ctx.GetMetricsClient().IncCounter(metrics.DomainDeleterScope, metrics.DomainDeleterExecutionsDeletedCount)
+		}
+		onPage()
+
+		pageToken = resp.PageToken
+		if len(pageToken) == 0 {
+			break
+		}
+	}
+	return nil
+}
+
+// deleteExecution terminates the execution when it is open and deletes it through the history service,
+// which removes its mutable state, history and visibility records
+func deleteExecution(
+	activityCtx context.Context,
+	ctx deleterContext,
+	limiter *rate.Limiter,
+	params deleteExecutionsActivityParams,
+	terminate bool,
+	execution *p.ConcreteExecution,
+) (bool, error) {
+
+	workflowExecution := &commonproto.WorkflowExecution{
+		WorkflowId: execution.WorkflowID,
+		RunId:      execution.RunID,
+	}
+	historyClient := ctx.GetHistoryClientGRPC()
+
+	terminated := false
+	if terminate {
+		if err := limiter.Wait(activityCtx); err != nil {
+			return false, err
+		}
+		_, err := historyClient.TerminateWorkflowExecution(activityCtx, &historyservice.TerminateWorkflowExecutionRequest{
+			DomainUUID: params.DomainID,
+			TerminateRequest: &workflowservice.TerminateWorkflowExecutionRequest{
+				Domain:            params.DomainName,
+				WorkflowExecution: workflowExecution,
+				Reason:            terminateReason,
+				Identity:          workflowTypeName,
+			},
+		})
+		// NotFound means the workflow is not running
+		if err != nil && status.Code(err) != codes.NotFound {
+			return false, err
+		}
+		terminated = err == nil
+	}
+
+	if err := limiter.Wait(activityCtx); err != nil {
+		return terminated, err
+	}
+	_, err := historyClient.DeleteWorkflowExecution(activityCtx, &historyservice.DeleteWorkflowExecutionRequest{
+		DomainUUID:        params.DomainID,
+		WorkflowExecution: workflowExecution,
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		return terminated, err
+	}
+	return terminated, nil
+}
+
+func deleteTaskList(
+	activityCtx context.Context,
+	taskManager p.TaskManager,
+	limiter *rate.Limiter,
+	taskList *p.TaskListInfo,
+) error {
+
+	for {
+		if err := limiter.Wait(activityCtx); err != nil {
+			return err
+		}
+		n, err := taskManager.CompleteTasksLessThan(&p.CompleteTasksLessThanRequest{
+			DomainID:     taskList.DomainID,
+			TaskListName: taskList.Name,
+			TaskType:     taskList.TaskType,
+			TaskID:       math.MaxInt64,
+			Limit:        pageSize,
+		})
+		if err != nil {
+			return err
+		}
+		if n < pageSize {
+			break
+		}
+	}
+
+	if err := limiter.Wait(activityCtx); err != nil {
+		return err
+	}
+	return taskManager.DeleteTaskList(&p.DeleteTaskListRequest{
+		DomainID:     taskList.DomainID,
+		TaskListName: taskList.Name,
+		TaskListType: taskList.TaskType,
+		RangeID:      taskList.RangeID,
+	})
+}
+
+func (r *deleteExecutionsResult) merge(other *deleteExecutionsResult) {
+	r.ExecutionsTerminated += other.ExecutionsTerminated
+	r.ExecutionsDeleted += other.ExecutionsDeleted
+	r.ExecutionErrors += other.ExecutionErrors
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package domaindeleter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/status"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/temporal"
+	"go.temporal.io/temporal/testsuite"
+	"go.temporal.io/temporal/worker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/temporalio/temporal/.gen/proto/historyservice"
+	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/cluster"
+	"github.com/temporalio/temporal/common/metrics"
+	p "github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/resource"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type workflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+}
+
+func TestWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(workflowTestSuite))
+}
+
+func (s *workflowTestSuite) TestWorkflow() {
+	env := s.NewTestWorkflowEnvironment()
+	env.OnActivity(checkDomainActivityName, mock.Anything, "domain-id").Return(nil)
+	// the first pass terminates an execution so the shards are processed again
+	env.OnActivity(deleteExecutionsActivityName, mock.Anything, mock.Anything).
+		Return(&deleteExecutionsResult{ExecutionsTerminated: 1, ExecutionsDeleted: 1}, nil).Once()
+	env.OnActivity(deleteExecutionsActivityName, mock.Anything, mock.Anything).
+		Return(&deleteExecutionsResult{}, nil).Times(3)
+	env.OnActivity(deleteTaskListsActivityName, mock.Anything, "domain-id").Return(int64(2), nil)
+	env.OnActivity(deleteDomainActivityName, mock.Anything, "domain-id").Return(nil)
+
+	env.ExecuteWorkflow(workflowTypeName, WorkflowParams{
+		DomainID:   "domain-id",
+		DomainName: "domain-name",
+		NumShards:  20,
+	})
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+	env.AssertExpectations(s.T())
+
+	var progress Progress
+	s.NoError(env.GetWorkflowResult(&progress))
+	s.Equal(PhaseCompleted, progress.Phase)
+	s.Equal(2, progress.Pass)
+	s.Equal(20, progress.ShardsCompleted)
+	s.Equal(int64(1), progress.ExecutionsTerminated)
+	s.Equal(int64(1), progress.ExecutionsDeleted)
+	s.Equal(int64(2), progress.TaskListsDeleted)
+}
+
+func (s *workflowTestSuite) TestWorkflow_DomainNotDeleted() {
+	env := s.NewTestWorkflowEnvironment()
+	env.OnActivity(checkDomainActivityName, mock.Anything, "domain-id").
+		Return(temporal.NewCustomError(errDomainNotDeleted.Error()))
+
+	env.ExecuteWorkflow(workflowTypeName, WorkflowParams{
+		DomainID:   "domain-id",
+		DomainName: "domain-name",
+		NumShards:  20,
+	})
+	s.True(env.IsWorkflowCompleted())
+	s.Error(env.GetWorkflowError())
+}
+
+func (s *workflowTestSuite) TestDeleteExecutionsActivity() {
+	controller := gomock.NewController(s.T())
+	defer controller.Finish()
+	mockResource := resource.NewTest(controller, metrics.Worker)
+	defer mockResource.Finish(s.T())
+
+	mockResource.ExecutionMgr.On("ListConcreteExecutions", mock.Anything).Return(&p.ListConcreteExecutionsResponse{
+		Executions: []*p.ConcreteExecution{
+			{DomainID: "domain-id", WorkflowID: "workflow-1", RunID: "run-1"},
+			{DomainID: "other-domain-id", WorkflowID: "workflow-2", RunID: "run-2"},
+			{DomainID: "domain-id", WorkflowID: "workflow-3", RunID: "run-3"},
+		},
+	}, nil).Once()
+	mockResource.DomainCache.EXPECT().GetDomainByID("domain-id").Return(cache.NewLocalDomainCacheEntryForTest(
+		&p.DomainInfo{ID: "domain-id", Name: "domain-name"}, nil, cluster.TestCurrentClusterName, nil,
+	), nil).Times(1)
+	mockHistoryClient := mockResource.HistoryClientGRPC
+	// workflow-1 is open, workflow-3 is closed
+	mockHistoryClient.EXPECT().TerminateWorkflowExecution(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, request *historyservice.TerminateWorkflowExecutionRequest, _ ...grpc.CallOption) (*historyservice.TerminateWorkflowExecutionResponse, error) {
+			s.Equal("domain-id", request.GetDomainUUID())
+			if request.TerminateRequest.WorkflowExecution.GetWorkflowId() == "workflow-3" {
+				return nil, status.New(codes.NotFound, "workflow execution already completed").Err()
+			}
+			return &historyservice.TerminateWorkflowExecutionResponse{}, nil
+		}).Times(2)
+	mockHistoryClient.EXPECT().DeleteWorkflowExecution(gomock.Any(), gomock.Any()).
+		Return(&historyservice.DeleteWorkflowExecutionResponse{}, nil).Times(2)
+
+	env := s.NewTestActivityEnvironment()
+	env.SetWorkerOptions(worker.Options{
+		BackgroundActivityContext: context.WithValue(context.Background(), deleterContextKey, deleterContext{
+			Resource: mockResource,
+			cfg: Config{
+				PersistenceMaxQPS: dynamicconfig.GetIntPropertyFn(1000),
+			},
+		}),
+	})
+	value, err := env.ExecuteActivity(deleteExecutionsActivityName, deleteExecutionsActivityParams{
+		DomainID:     "domain-id",
+		DomainName:   "domain-name",
+		StartShardID: 0,
+		EndShardID:   1,
+	})
+	s.NoError(err)
+
+	var result deleteExecutionsResult
+	s.NoError(value.Get(&result))
+	s.Equal(deleteExecutionsResult{ExecutionsTerminated: 1, ExecutionsDeleted: 2}, result)
+}
+
+func (s *workflowTestSuite) TestDeleteExecutionsActivity_StandbyDomain() {
+	controller := gomock.NewController(s.T())
+	defer controller.Finish()
+	mockResource := resource.NewTest(controller, metrics.Worker)
+	defer mockResource.Finish(s.T())
+
+	mockResource.ExecutionMgr.On("ListConcreteExecutions", mock.Anything).Return(&p.ListConcreteExecutionsResponse{
+		Executions: []*p.ConcreteExecution{
+			{DomainID: "domain-id", WorkflowID: "workflow-1", RunID: "run-1"},
+			{DomainID: "domain-id", WorkflowID: "workflow-3", RunID: "run-3"},
+		},
+	}, nil).Once()
+	mockResource.DomainCache.EXPECT().GetDomainByID("domain-id").Return(cache.NewGlobalDomainCacheEntryForTest(
+		&p.DomainInfo{ID: "domain-id", Name: "domain-name"},
+		nil,
+		&p.DomainReplicationConfig{
+			ActiveClusterName: cluster.TestAlternativeClusterName,
+			Clusters: []*p.ClusterReplicationConfig{
+				{ClusterName: cluster.TestCurrentClusterName},
+				{ClusterName: cluster.TestAlternativeClusterName},
+			},
+		},
+		1,
+		cluster.GetTestClusterMetadata(true, true),
+	), nil).Times(1)
+	// executions of the standby domain are terminated by the active cluster, workflow-1 is not closed yet
+	mockHistoryClient := mockResource.HistoryClientGRPC
+	mockHistoryClient.EXPECT().DeleteWorkflowExecution(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, request *historyservice.DeleteWorkflowExecutionRequest, _ ...grpc.CallOption) (*historyservice.DeleteWorkflowExecutionResponse, error) {
+			if request.WorkflowExecution.GetWorkflowId() == "workflow-1" {
+				return nil, status.New(codes.InvalidArgument, "workflow execution is not closed").Err()
+			}
+			return &historyservice.DeleteWorkflowExecutionResponse{}, nil
+		}).Times(2)
+
+	env := s.NewTestActivityEnvironment()
+	env.SetWorkerOptions(worker.Options{
+		BackgroundActivityContext: context.WithValue(context.Background(), deleterContextKey, deleterContext{
+			Resource: mockResource,
+			cfg: Config{
+				PersistenceMaxQPS: dynamicconfig.GetIntPropertyFn(1000),
+			},
+		}),
+	})
+	value, err := env.ExecuteActivity(deleteExecutionsActivityName, deleteExecutionsActivityParams{
+		DomainID:     "domain-id",
+		DomainName:   "domain-name",
+		StartShardID: 0,
+		EndShardID:   1,
+	})
+	s.NoError(err)
+
+	var result deleteExecutionsResult
+	s.NoError(value.Get(&result))
+	s.Equal(deleteExecutionsResult{ExecutionsDeleted: 1, ExecutionErrors: 1}, result)
+}
+
+func (s *workflowTestSuite) TestDeleteDomainActivity() {
+	controller := gomock.NewController(s.T())
+	defer controller.Finish()
+	mockResource := resource.NewTest(controller, metrics.Worker)
+	defer mockResource.Finish(s.T())
+
+	mockResource.MetadataMgr.On("GetDomain", &p.GetDomainRequest{ID: "domain-id"}).Return(&p.GetDomainResponse{
+		Info: &p.DomainInfo{ID: "domain-id", Name: "domain-name", Status: p.DomainStatusDeleted},
+	}, nil).Once()
+	mockResource.MetadataMgr.On("DeleteDomain", &p.DeleteDomainRequest{ID: "domain-id"}).Return(nil).Once()
+
+	env := s.NewTestActivityEnvironment()
+	env.SetWorkerOptions(worker.Options{
+		BackgroundActivityContext: context.WithValue(context.Background(), deleterContextKey, deleterContext{
+			Resource: mockResource,
+		}),
+	})
+	_, err := env.ExecuteActivity(deleteDomainActivityName, "domain-id")
+	s.NoError(err)
+}
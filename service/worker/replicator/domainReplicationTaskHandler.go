@@ -21,13 +21,17 @@
 package replicator
 
 import (
+	"context"
+
 	commonproto "go.temporal.io/temporal-proto/common"
 	"go.temporal.io/temporal-proto/enums"
 
 	"github.com/temporalio/temporal/.gen/go/shared"
 	"github.com/temporalio/temporal/common/adapter"
 	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/service/worker/domaindeleter"
 )
 
 var (
@@ -62,16 +66,22 @@ type (
 	}
 
 	domainReplicatorImpl struct {
-		metadataManagerV2 persistence.MetadataManager
-		logger            log.Logger
+		metadataManagerV2   persistence.MetadataManager
+		domainDeleterClient domaindeleter.Client
+		logger              log.Logger
 	}
 )
 
 // NewDomainReplicator create a new instance odf domain replicator
-func NewDomainReplicator(metadataManagerV2 persistence.MetadataManager, logger log.Logger) DomainReplicator {
+func NewDomainReplicator(
+	metadataManagerV2 persistence.MetadataManager,
+	domainDeleterClient domaindeleter.Client,
+	logger log.Logger,
+) DomainReplicator {
 	return &domainReplicatorImpl{
-		metadataManagerV2: metadataManagerV2,
-		logger:            logger,
+		metadataManagerV2:   metadataManagerV2,
+		domainDeleterClient: domainDeleterClient,
+		logger:              logger,
 	}
 }
 
@@ -115,6 +125,9 @@ func (domainReplicator *domainReplicatorImpl) handleDomainCreationReplicationTas
 			HistoryArchivalURI:       task.Config.GetHistoryArchivalURI(),
 			VisibilityArchivalStatus: *adapter.ToThriftArchivalStatus(task.Config.GetVisibilityArchivalStatus()),
 			VisibilityArchivalURI:    task.Config.GetVisibilityArchivalURI(),
+			WorkflowDefaults: persistence.NewDomainWorkflowDefaultsFromThrift(
+				adapter.ToThriftDomainWorkflowDefaults(task.Config.GetWorkflowDefaults()),
+			),
 		},
 		ReplicationConfig: &persistence.DomainReplicationConfig{
 			ActiveClusterName: task.ReplicationConfig.GetActiveClusterName(),
@@ -231,6 +244,11 @@ func (domainReplicator *domainReplicatorImpl) handleDomainUpdateReplicationTask(
 			HistoryArchivalURI:       task.Config.GetHistoryArchivalURI(),
 			VisibilityArchivalStatus: *adapter.ToThriftArchivalStatus(task.Config.GetVisibilityArchivalStatus()),
 			VisibilityArchivalURI:    task.Config.GetVisibilityArchivalURI(),
+			WorkflowDefaults: persistence.NewDomainWorkflowDefaultsFromThrift(
+				adapter.ToThriftDomainWorkflowDefaults(task.Config.GetWorkflowDefaults()),
+			),
+			// domain search attributes are local to the cluster and are not replicated
+			SearchAttributes: resp.Config.SearchAttributes,
 		}
 		if task.Config.GetBadBinaries() != nil {
 			request.Config.BadBinaries = *adapter.ToThriftBadBinaries(task.Config.GetBadBinaries())
@@ -246,10 +264,32 @@ func (domainReplicator *domainReplicatorImpl) handleDomainUpdateReplicationTask(
 	}
 
 	if !recordUpdated {
+		return domainReplicator.purgeIfDeleted(request.Info)
+	}
+
+	if err := domainReplicator.metadataManagerV2.UpdateDomain(request); err != nil {
+		return err
+	}
+	return domainReplicator.purgeIfDeleted(request.Info)
+}
+
+// purgeIfDeleted starts the domain deleter in this cluster once the deletion of a domain is replicated,
+// the deleter purges the standby data of the domain, starting it again while it runs is a no-op
+func (domainReplicator *domainReplicatorImpl) purgeIfDeleted(info *persistence.DomainInfo) error {
+	if info.Status != persistence.DomainStatusDeleted {
 		return nil
 	}
 
-	return domainReplicator.metadataManagerV2.UpdateDomain(request)
+	runID, err := domainReplicator.domainDeleterClient.DeleteDomain(context.Background(), info.ID, info.Name)
+	if err != nil {
+		return err
+	}
+	domainReplicator.logger.Info("Started purge of replicated domain deletion",
+		tag.WorkflowDomainName(info.Name),
+		tag.WorkflowDomainID(info.ID),
+		tag.WorkflowRunID(runID),
+	)
+	return nil
 }
 
 func (domainReplicator *domainReplicatorImpl) validateDomainReplicationTask(task *commonproto.DomainTaskAttributes) error {
@@ -285,6 +325,8 @@ func (domainReplicator *domainReplicatorImpl) convertDomainStatusFromProto(input
 		return persistence.DomainStatusRegistered, nil
 	case enums.DomainStatusDeprecated:
 		return persistence.DomainStatusDeprecated, nil
+	case enums.DomainStatusDeleted:
+		return persistence.DomainStatusDeleted, nil
 	default:
 		return 0, ErrInvalidDomainStatus
 	}
@@ -24,6 +24,7 @@ import (
 	"testing"
 
 	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	commonproto "go.temporal.io/temporal-proto/common"
 	"go.temporal.io/temporal-proto/enums"
@@ -34,13 +35,15 @@ import (
 	"github.com/temporalio/temporal/common/log/loggerimpl"
 	"github.com/temporalio/temporal/common/persistence"
 	persistencetests "github.com/temporalio/temporal/common/persistence/persistence-tests"
+	"github.com/temporalio/temporal/service/worker/domaindeleter"
 )
 
 type (
 	domainReplicatorSuite struct {
 		suite.Suite
 		persistencetests.TestBase
-		domainReplicator *domainReplicatorImpl
+		mockDomainDeleterClient *domaindeleter.MockClient
+		domainReplicator        *domainReplicatorImpl
 	}
 )
 
@@ -62,14 +65,17 @@ func (s *domainReplicatorSuite) SetupTest() {
 	zapLogger, err := zap.NewDevelopment()
 	s.Require().NoError(err)
 	logger := loggerimpl.NewLogger(zapLogger)
+	s.mockDomainDeleterClient = &domaindeleter.MockClient{}
 	s.domainReplicator = NewDomainReplicator(
 		s.MetadataManager,
+		s.mockDomainDeleterClient,
 		logger,
 	).(*domainReplicatorImpl)
 }
 
 func (s *domainReplicatorSuite) TearDownTest() {
 	s.TearDownWorkflowStore()
+	s.mockDomainDeleterClient.AssertExpectations(s.T())
 }
 
 func (s *domainReplicatorSuite) TestHandleReceivingTask_RegisterDomainTask_NameUUIdCollision() {
@@ -831,3 +837,49 @@ func (s *domainReplicatorSuite) TestHandleReceivingTask_UpdateDomainTask_NoUpdat
 	s.Equal(int64(0), resp.FailoverNotificationVersion)
 	s.Equal(notificationVersion, resp.NotificationVersion)
 }
+
+func (s *domainReplicatorSuite) TestHandleReceivingTask_UpdateDomainTask_DeleteDomain() {
+	id := uuid.New()
+	name := "some random domain test name"
+	clusters := []*commonproto.ClusterReplicationConfiguration{
+		{ClusterName: "some random active cluster name"},
+		{ClusterName: "some random standby cluster name"},
+	}
+	task := &commonproto.DomainTaskAttributes{
+		DomainOperation: enums.DomainOperationCreate,
+		Id:              id,
+		Info: &commonproto.DomainInfo{
+			Name:   name,
+			Status: enums.DomainStatusRegistered,
+		},
+		Config: &commonproto.DomainConfiguration{
+			WorkflowExecutionRetentionPeriodInDays: 10,
+			HistoryArchivalStatus:                  enums.ArchivalStatusDisabled,
+			VisibilityArchivalStatus:               enums.ArchivalStatusDisabled,
+		},
+		ReplicationConfig: &commonproto.DomainReplicationConfiguration{
+			ActiveClusterName: "some random active cluster name",
+			Clusters:          clusters,
+		},
+		ConfigVersion:   0,
+		FailoverVersion: 59,
+	}
+	err := s.domainReplicator.HandleReceivingTask(task)
+	s.Nil(err)
+
+	// the replicated deletion starts the purge of the standby data of the domain
+	s.mockDomainDeleterClient.On("DeleteDomain", mock.Anything, id, name).Return("some random run ID", nil).Twice()
+	task.DomainOperation = enums.DomainOperationUpdate
+	task.Info.Status = enums.DomainStatusDeleted
+	task.ConfigVersion = 1
+	err = s.domainReplicator.HandleReceivingTask(task)
+	s.Nil(err)
+
+	resp, err := s.MetadataManager.GetDomain(&persistence.GetDomainRequest{ID: id})
+	s.Nil(err)
+	s.Equal(persistence.DomainStatusDeleted, resp.Info.Status)
+
+	// a duplicate task starts the purge again, which is a no-op while it runs
+	err = s.domainReplicator.HandleReceivingTask(task)
+	s.Nil(err)
+}
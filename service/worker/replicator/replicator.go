@@ -41,6 +41,7 @@ import (
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 	"github.com/temporalio/temporal/common/task"
 	"github.com/temporalio/temporal/common/xdc"
+	"github.com/temporalio/temporal/service/worker/domaindeleter"
 )
 
 type (
@@ -90,6 +91,7 @@ func NewReplicator(
 	hostInfo *membership.HostInfo,
 	serviceResolver membership.ServiceResolver,
 	domainReplicationQueue persistence.DomainReplicationQueue,
+	domainDeleterClient domaindeleter.Client,
 ) *Replicator {
 
 	logger = logger.WithTags(tag.ComponentReplicator)
@@ -98,7 +100,7 @@ func NewReplicator(
 		serviceResolver:        serviceResolver,
 		domainCache:            domainCache,
 		clusterMetadata:        clusterMetadata,
-		domainReplicator:       NewDomainReplicator(metadataManagerV2, logger),
+		domainReplicator:       NewDomainReplicator(metadataManagerV2, domainDeleterClient, logger),
 		clientBean:             clientBean,
 		historyClient:          clientBean.GetHistoryClient(),
 		config:                 config,
@@ -0,0 +1,115 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executions
+
+import (
+	"time"
+)
+
+const (
+	// CorruptionFailedToLoad means the mutable state of the execution could not be deserialized
+	CorruptionFailedToLoad = "failed_to_load"
+	// CorruptionChecksumMismatch means the mutable state does not match its checksum
+	CorruptionChecksumMismatch = "checksum_mismatch"
+	// CorruptionHistoryMissing means the history branch of the execution does not exist
+	CorruptionHistoryMissing = "history_missing"
+	// CorruptionNextEventIDMismatch means the last event of the history is not the one before the next event ID of the mutable state
+	CorruptionNextEventIDMismatch = "next_event_id_mismatch"
+	// CorruptionOpenExecutionNotCurrent means the execution is open but the current execution of the workflow is another run
+	CorruptionOpenExecutionNotCurrent = "open_execution_not_current"
+
+	// maxCorruptedExecutionsInReport caps the corrupted executions listed in a report, they are all counted
+	maxCorruptedExecutionsInReport = 100
+)
+
+type (
+	// Report is the result of a scan of the workflow executions
+	Report struct {
+		StartTime         time.Time
+		EndTime           time.Time
+		Fix               bool
+		ShardsScanned     int
+		ExecutionsScanned int64
+		// Corruptions is the count of corrupted executions by type of corruption
+		Corruptions map[string]int64
+		// FixedCount is the count of corrupted executions deleted by the scan
+		FixedCount int64
+		// ErrorCount is the count of executions that could not be checked
+		ErrorCount          int64
+		CorruptedExecutions []CorruptedExecution
+	}
+
+	// CorruptedExecution is a corrupted execution found by the scan
+	CorruptedExecution struct {
+		ShardID    int
+		DomainID   string
+		WorkflowID string
+		RunID      string
+		Corruption string
+		Detail     string
+		Fixed      bool
+	}
+)
+
+// CorruptedCount returns the count of corrupted executions
+func (r *Report) CorruptedCount() int64 {
+	var count int64
+	for _, c := range r.Corruptions {
+		count += c
+	}
+	return count
+}
+
+// Merge adds the counters and the corrupted executions of the other report to the report
+func (r *Report) Merge(other *Report) {
+	r.Fix = r.Fix || other.Fix
+	r.ShardsScanned += other.ShardsScanned
+	r.ExecutionsScanned += other.ExecutionsScanned
+	r.FixedCount += other.FixedCount
+	r.ErrorCount += other.ErrorCount
+	for corruption, count := range other.Corruptions {
+		r.addCorruptionCount(corruption, count)
+	}
+	for _, execution := range other.CorruptedExecutions {
+		r.addCorruptedExecution(execution)
+	}
+}
+
+func (r *Report) addCorrupted(execution CorruptedExecution) {
+	r.addCorruptionCount(execution.Corruption, 1)
+	if execution.Fixed {
+		r.FixedCount++
+	}
+	r.addCorruptedExecution(execution)
+}
+
+func (r *Report) addCorruptionCount(corruption string, count int64) {
+	if r.Corruptions == nil {
+		r.Corruptions = make(map[string]int64)
+	}
+	r.Corruptions[corruption] += count
+}
+
+func (r *Report) addCorruptedExecution(execution CorruptedExecution) {
+	if len(r.CorruptedExecutions) < maxCorruptedExecutionsInReport {
+		r.CorruptedExecutions = append(r.CorruptedExecutions, execution)
+	}
+}
@@ -0,0 +1,381 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/checksum"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+	p "github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+const (
+	pageSize = 100
+)
+
+type (
+	// ExecutionManagerProvider returns the execution manager of a shard
+	ExecutionManagerProvider func(shardID int) (p.ExecutionManager, error)
+
+	// Scanner checks the workflow executions of the shards for corruptions. For
+	// each execution of a shard, the scanner verifies that
+	//  - its mutable state loads and matches its checksum, unless the checksum was
+	//    written before the checksum invalidation time of the history service
+	//  - its history branch exists and ends with the event before its next event ID
+	//  - it is the current execution of the workflow when it is open
+	// When fix is enabled, the corrupted executions are deleted, except the open executions
+	// whose only corruption is a checksum mismatch which are only reported
+	Scanner struct {
+		executionManagerProvider ExecutionManagerProvider
+		historyManager           p.HistoryManager
+		limiter                  *rate.Limiter
+		fix                      bool
+		checksumInvalidateBefore dynamicconfig.FloatPropertyFn
+		metrics                  metrics.Client
+		logger                   log.Logger
+	}
+)
+
+// NewScanner returns a new instance of executions scanner
+func NewScanner(
+	executionManagerProvider ExecutionManagerProvider,
+	historyManager p.HistoryManager,
+	rps int,
+	fix bool,
+	checksumInvalidateBefore dynamicconfig.FloatPropertyFn,
+	metricsClient metrics.Client,
+	logger log.Logger,
+) *Scanner {
+
+	return &Scanner{
+		executionManagerProvider: executionManagerProvider,
+		historyManager:           historyManager,
+		limiter:                  rate.NewLimiter(rate.Limit(rps), rps),
+		fix:                      fix,
+		checksumInvalidateBefore: checksumInvalidateBefore,
+		metrics:                  metricsClient,
+		logger:                   logger,
+	}
+}
+
+// ScanShard checks all the executions of the shard and adds the results to the report,
+// onPage is called after each page of executions so that callers can record progress
+func (s *Scanner) ScanShard(
+	ctx context.Context,
+	shardID int,
+	report *Report,
+	onPage func(),
+) error {
+
+	executionManager, err := s.executionManagerProvider(shardID)
+	if err != nil {
+		return err
+	}
+
+	report.Fix = s.fix
+	var pageToken []byte
+	for {
+		if err := s.limiter.Wait(ctx); err != nil {
+			return err
+		}
+		resp, err := executionManager.ListConcreteExecutions(&p.ListConcreteExecutionsRequest{
+			PageSize:  pageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, execution := range resp.Executions {
+			if err := s.scanExecution(ctx, executionManager, shardID, execution, report); err != nil {
+				return err
+			}
+		}
+		onPage()
+
+		pageToken = resp.PageToken
+		if len(pageToken) == 0 {
+			break
+		}
+	}
+	report.ShardsScanned++
+	return nil
+}
+
+func (s *Scanner) scanExecution(
+	ctx context.Context,
+	executionManager p.ExecutionManager,
+	shardID int,
+	execution *p.ConcreteExecution,
+	report *Report,
+) error {
+
+	corruption, detail, fixable, err := s.checkExecution(ctx, executionManager, shardID, execution)
+	if err == nil && corruption != "" {
+		// the execution may have been updated or deleted while it was checked, it
+		// is only reported corrupted if the corruption is still found on a second check
+		corruption, detail, fixable, err = s.checkExecution(ctx, executionManager, shardID, execution)
+	}
+	if err != nil {
+		if err == context.Canceled || err == context.DeadlineExceeded {
+			return err
+		}
+		report.ErrorCount++
+		s.metrics.IncCounter(metrics.ExecutionsScannerScope, metrics.ExecutionsScannerErrorCount)
+		s.logger.Error("unable to check workflow execution", getExecutionLoggingTags(err, shardID, execution)...)
+		return nil
+	}
+
+	report.ExecutionsScanned++
+	s.metrics.IncCounter(metrics.ExecutionsScannerScope, metrics.ExecutionsScannerScannedCount)
+	if corruption == "" {
+		return nil
+	}
+
+	s.metrics.IncCounter(metrics.ExecutionsScannerScope, metrics.ExecutionsScannerCorruptedCount)
+	s.logger.Warn("found corrupted workflow execution",
+		append(getExecutionLoggingTags(nil, shardID, execution), tag.Value(corruption), tag.DetailInfo(detail))...)
+	corrupted := CorruptedExecution{
+		ShardID:    shardID,
+		DomainID:   execution.DomainID,
+		WorkflowID: execution.WorkflowID,
+		RunID:      execution.RunID,
+		Corruption: corruption,
+		Detail:     detail,
+	}
+	if s.fix && !fixable {
+		s.logger.Warn("not deleting open workflow execution with checksum mismatch",
+			getExecutionLoggingTags(nil, shardID, execution)...)
+	} else if s.fix {
+		if err := s.deleteExecution(executionManager, execution); err != nil {
+			report.ErrorCount++
+			s.metrics.IncCounter(metrics.ExecutionsScannerScope, metrics.ExecutionsScannerErrorCount)
+			s.logger.Error("unable to delete corrupted workflow execution", getExecutionLoggingTags(err, shardID, execution)...)
+		} else {
+			corrupted.Fixed = true
+			s.metrics.IncCounter(metrics.ExecutionsScannerScope, metrics.ExecutionsScannerFixedCount)
+			s.logger.Info("deleted corrupted workflow execution", getExecutionLoggingTags(nil, shardID, execution)...)
+		}
+	}
+	report.addCorrupted(corrupted)
+	return nil
+}
+
+// checkExecution returns the corruption of the execution, or an empty corruption when the execution
+// is healthy or no longer exists, and whether the execution can be deleted to fix the corruption.
+// An error is returned when the execution could not be checked
+func (s *Scanner) checkExecution(
+	ctx context.Context,
+	executionManager p.ExecutionManager,
+	shardID int,
+	execution *p.ConcreteExecution,
+) (string, string, bool, error) {
+
+	corruption, detail, open, err := s.checkMutableState(ctx, executionManager, shardID, execution)
+	if err != nil || corruption != CorruptionChecksumMismatch {
+		return corruption, detail, true, err
+	}
+	// a checksum mismatch alone does not prove that an open execution is broken,
+	// it may be a bug of the checksum itself, so it is only reported
+	return corruption, detail, !open, nil
+}
+
+// checkMutableState returns the corruption of the execution and whether the execution is open,
+// a checksum mismatch is only returned when no other corruption is found
+func (s *Scanner) checkMutableState(
+	ctx context.Context,
+	executionManager p.ExecutionManager,
+	shardID int,
+	execution *p.ConcreteExecution,
+) (string, string, bool, error) {
+
+	if err := s.limiter.Wait(ctx); err != nil {
+		return "", "", false, err
+	}
+	resp, err := executionManager.GetWorkflowExecution(&p.GetWorkflowExecutionRequest{
+		DomainID: execution.DomainID,
+		Execution: shared.WorkflowExecution{
+			WorkflowId: common.StringPtr(execution.WorkflowID),
+			RunId:      common.StringPtr(execution.RunID),
+		},
+	})
+	switch err.(type) {
+	case nil:
+	case *shared.EntityNotExistsError:
+		return "", "", false, nil
+	case *p.CadenceDeserializationError:
+		return CorruptionFailedToLoad, err.Error(), false, nil
+	default:
+		return "", "", false, err
+	}
+
+	state := resp.State
+	executionInfo := state.ExecutionInfo
+	open := executionInfo.State == p.WorkflowStateCreated || executionInfo.State == p.WorkflowStateRunning
+	if corruption, detail, err := s.checkHistory(ctx, shardID, state); err != nil || corruption != "" {
+		return corruption, detail, open, err
+	}
+
+	if open {
+		if err := s.limiter.Wait(ctx); err != nil {
+			return "", "", open, err
+		}
+		current, err := executionManager.GetCurrentExecution(&p.GetCurrentExecutionRequest{
+			DomainID:   execution.DomainID,
+			WorkflowID: execution.WorkflowID,
+		})
+		switch err.(type) {
+		case nil:
+			if current.RunID != execution.RunID {
+				return CorruptionOpenExecutionNotCurrent, fmt.Sprintf("current run ID is %v", current.RunID), open, nil
+			}
+		case *shared.EntityNotExistsError:
+			return CorruptionOpenExecutionNotCurrent, "workflow has no current execution", open, nil
+		default:
+			return "", "", open, err
+		}
+	}
+
+	if state.Checksum.Flavor != checksum.FlavorUnknown && !s.isChecksumInvalidated(executionInfo) {
+		if err := p.VerifyMutableStateChecksum(state, state.Checksum); err != nil {
+			return CorruptionChecksumMismatch, err.Error(), open, nil
+		}
+	}
+	return "", "", open, nil
+}
+
+// isChecksumInvalidated applies the checksum invalidation of the history service, which
+// discards the checksums of the executions last updated before the invalidation time
+func (s *Scanner) isChecksumInvalidated(executionInfo *p.WorkflowExecutionInfo) bool {
+	invalidateBeforeEpochSecs := int64(s.checksumInvalidateBefore())
+	if invalidateBeforeEpochSecs > 0 {
+		return executionInfo.LastUpdatedTimestamp.Before(time.Unix(invalidateBeforeEpochSecs, 0))
+	}
+	return false
+}
+
+func (s *Scanner) checkHistory(
+	ctx context.Context,
+	shardID int,
+	state *p.WorkflowMutableState,
+) (string, string, error) {
+
+	executionInfo := state.ExecutionInfo
+	branchToken := executionInfo.BranchToken
+	if state.VersionHistories != nil {
+		currentVersionHistory, err := state.VersionHistories.GetCurrentVersionHistory()
+		if err != nil {
+			return CorruptionFailedToLoad, err.Error(), nil
+		}
+		branchToken = currentVersionHistory.GetBranchToken()
+	}
+
+	// the first batch must exist
+	if err := s.limiter.Wait(ctx); err != nil {
+		return "", "", err
+	}
+	_, err := s.historyManager.ReadHistoryBranch(&p.ReadHistoryBranchRequest{
+		BranchToken: branchToken,
+		MinEventID:  common.FirstEventID,
+		MaxEventID:  executionInfo.NextEventID,
+		PageSize:    1,
+		ShardID:     common.IntPtr(shardID),
+	})
+	switch err.(type) {
+	case nil:
+	case *shared.EntityNotExistsError:
+		return CorruptionHistoryMissing, err.Error(), nil
+	default:
+		return "", "", err
+	}
+
+	// and the last batch, which starts at the last first event ID, must end with the event before the next event ID
+	if err := s.limiter.Wait(ctx); err != nil {
+		return "", "", err
+	}
+	resp, err := s.historyManager.ReadHistoryBranch(&p.ReadHistoryBranchRequest{
+		BranchToken: branchToken,
+		MinEventID:  executionInfo.LastFirstEventID,
+		MaxEventID:  executionInfo.NextEventID,
+		PageSize:    1,
+		ShardID:     common.IntPtr(shardID),
+	})
+	switch err.(type) {
+	case nil:
+	case *shared.EntityNotExistsError:
+		return CorruptionNextEventIDMismatch, err.Error(), nil
+	default:
+		return "", "", err
+	}
+	var lastEventID int64
+	if len(resp.HistoryEvents) > 0 {
+		lastEventID = resp.HistoryEvents[len(resp.HistoryEvents)-1].GetEventId()
+	}
+	if lastEventID != executionInfo.NextEventID-1 {
+		return CorruptionNextEventIDMismatch,
+			fmt.Sprintf("last event ID is %v, next event ID is %v", lastEventID, executionInfo.NextEventID), nil
+	}
+	return "", "", nil
+}
+
+// deleteExecution deletes the execution, and the current execution of the workflow when it is the execution.
+// The history branch of the execution is left to the history scavenger
+func (s *Scanner) deleteExecution(
+	executionManager p.ExecutionManager,
+	execution *p.ConcreteExecution,
+) error {
+
+	if err := executionManager.DeleteWorkflowExecution(&p.DeleteWorkflowExecutionRequest{
+		DomainID:   execution.DomainID,
+		WorkflowID: execution.WorkflowID,
+		RunID:      execution.RunID,
+	}); err != nil {
+		return err
+	}
+	return executionManager.DeleteCurrentWorkflowExecution(&p.DeleteCurrentWorkflowExecutionRequest{
+		DomainID:   execution.DomainID,
+		WorkflowID: execution.WorkflowID,
+		RunID:      execution.RunID,
+	})
+}
+
+func getExecutionLoggingTags(err error, shardID int, execution *p.ConcreteExecution) []tag.Tag {
+	tags := []tag.Tag{
+		tag.ShardID(shardID),
+		tag.WorkflowDomainID(execution.DomainID),
+		tag.WorkflowID(execution.WorkflowID),
+		tag.WorkflowRunID(execution.RunID),
+	}
+	if err != nil {
+		tags = append(tags, tag.Error(err))
+	}
+	return tags
+}
@@ -0,0 +1,369 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+
+	"github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/checksum"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/mocks"
+	p "github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	scannerSuite struct {
+		*require.Assertions
+		suite.Suite
+
+		executionManager         *mocks.ExecutionManager
+		historyManager           *mocks.HistoryV2Manager
+		checksumInvalidateBefore float64
+	}
+)
+
+const (
+	testShardID    = 3
+	testDomainID   = "deadbeef-0123-4567-890a-bcdef0123456"
+	testWorkflowID = "test-workflow-id"
+	testRunID      = "c04acb6e-4e1c-4c4d-a8a2-f8d3a6c1e1d0"
+)
+
+var testExecution = &p.ConcreteExecution{
+	DomainID:   testDomainID,
+	WorkflowID: testWorkflowID,
+	RunID:      testRunID,
+}
+
+func TestScannerSuite(t *testing.T) {
+	suite.Run(t, new(scannerSuite))
+}
+
+func (s *scannerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.executionManager = &mocks.ExecutionManager{}
+	s.historyManager = &mocks.HistoryV2Manager{}
+	s.checksumInvalidateBefore = 0
+}
+
+func (s *scannerSuite) TearDownTest() {
+	s.executionManager.AssertExpectations(s.T())
+	s.historyManager.AssertExpectations(s.T())
+}
+
+func (s *scannerSuite) newScanner(fix bool) *Scanner {
+	return NewScanner(
+		func(shardID int) (p.ExecutionManager, error) {
+			s.Equal(testShardID, shardID)
+			return s.executionManager, nil
+		},
+		s.historyManager,
+		10000,
+		fix,
+		func(...dynamicconfig.FilterOption) float64 { return s.checksumInvalidateBefore },
+		metrics.NewClient(tally.NoopScope, metrics.Worker),
+		loggerimpl.NewNopLogger(),
+	)
+}
+
+func (s *scannerSuite) newMutableState(state int) *p.WorkflowMutableState {
+	ms := &p.WorkflowMutableState{
+		ExecutionInfo: &p.WorkflowExecutionInfo{
+			DomainID:         testDomainID,
+			WorkflowID:       testWorkflowID,
+			RunID:            testRunID,
+			State:            state,
+			BranchToken:          []byte("branch-token"),
+			LastFirstEventID:     5,
+			NextEventID:          8,
+			LastUpdatedTimestamp: time.Unix(1000, 0),
+		},
+		ActivityInfos: map[int64]*p.ActivityInfo{6: {ScheduleID: 6}},
+	}
+	csum, err := checksum.GenerateCRC32(p.NewMutableStateChecksumPayload(ms), p.MutableStateChecksumPayloadV1)
+	s.NoError(err)
+	ms.Checksum = csum
+	return ms
+}
+
+func (s *scannerSuite) expectListExecutions(executions ...*p.ConcreteExecution) {
+	s.executionManager.On("ListConcreteExecutions", &p.ListConcreteExecutionsRequest{
+		PageSize: pageSize,
+	}).Return(&p.ListConcreteExecutionsResponse{
+		Executions: executions,
+	}, nil).Once()
+}
+
+func (s *scannerSuite) expectGetExecution(ms *p.WorkflowMutableState, err error) {
+	var resp *p.GetWorkflowExecutionResponse
+	if ms != nil {
+		resp = &p.GetWorkflowExecutionResponse{State: ms}
+	}
+	s.executionManager.On("GetWorkflowExecution", mock.Anything).Return(resp, err)
+}
+
+func (s *scannerSuite) expectReadHistory(lastEventID int64, err error) {
+	firstBatch := &p.ReadHistoryBranchResponse{
+		HistoryEvents: []*shared.HistoryEvent{{EventId: common.Int64Ptr(common.FirstEventID)}},
+	}
+	lastBatch := &p.ReadHistoryBranchResponse{
+		HistoryEvents: []*shared.HistoryEvent{{EventId: common.Int64Ptr(5)}, {EventId: common.Int64Ptr(lastEventID)}},
+	}
+	if err != nil {
+		firstBatch, lastBatch = nil, nil
+	}
+	s.historyManager.On("ReadHistoryBranch", mock.MatchedBy(func(req *p.ReadHistoryBranchRequest) bool {
+		return req.MinEventID == common.FirstEventID
+	})).Return(firstBatch, err)
+	if err == nil {
+		s.historyManager.On("ReadHistoryBranch", mock.MatchedBy(func(req *p.ReadHistoryBranchRequest) bool {
+			return req.MinEventID == 5 && req.MaxEventID == 8 && *req.ShardID == testShardID
+		})).Return(lastBatch, nil)
+	}
+}
+
+func (s *scannerSuite) expectCurrentRunID(runID string) {
+	s.executionManager.On("GetCurrentExecution", &p.GetCurrentExecutionRequest{
+		DomainID:   testDomainID,
+		WorkflowID: testWorkflowID,
+	}).Return(&p.GetCurrentExecutionResponse{RunID: runID}, nil)
+}
+
+func (s *scannerSuite) scan(fix bool) *Report {
+	report := &Report{}
+	pages := 0
+	s.NoError(s.newScanner(fix).ScanShard(context.Background(), testShardID, report, func() { pages++ }))
+	s.Equal(1, pages)
+	s.Equal(1, report.ShardsScanned)
+	return report
+}
+
+func (s *scannerSuite) TestHealthyExecution() {
+	s.expectListExecutions(testExecution)
+	s.expectGetExecution(s.newMutableState(p.WorkflowStateRunning), nil)
+	s.expectReadHistory(7, nil)
+	s.expectCurrentRunID(testRunID)
+
+	report := s.scan(true)
+	s.Equal(int64(1), report.ExecutionsScanned)
+	s.Equal(int64(0), report.CorruptedCount())
+	s.Empty(report.CorruptedExecutions)
+}
+
+func (s *scannerSuite) TestPaging() {
+	s.executionManager.On("ListConcreteExecutions", &p.ListConcreteExecutionsRequest{
+		PageSize: pageSize,
+	}).Return(&p.ListConcreteExecutionsResponse{
+		Executions: []*p.ConcreteExecution{testExecution},
+		PageToken:  []byte("page1"),
+	}, nil).Once()
+	s.executionManager.On("ListConcreteExecutions", &p.ListConcreteExecutionsRequest{
+		PageSize:  pageSize,
+		PageToken: []byte("page1"),
+	}).Return(&p.ListConcreteExecutionsResponse{
+		Executions: []*p.ConcreteExecution{testExecution},
+	}, nil).Once()
+	s.expectGetExecution(s.newMutableState(p.WorkflowStateCompleted), nil)
+	s.expectReadHistory(7, nil)
+
+	report := &Report{}
+	pages := 0
+	s.NoError(s.newScanner(false).ScanShard(context.Background(), testShardID, report, func() { pages++ }))
+	s.Equal(2, pages)
+	s.Equal(int64(2), report.ExecutionsScanned)
+	s.Equal(int64(0), report.CorruptedCount())
+}
+
+func (s *scannerSuite) TestDeletedExecutionIsSkipped() {
+	s.expectListExecutions(testExecution)
+	s.expectGetExecution(nil, &shared.EntityNotExistsError{})
+
+	report := s.scan(true)
+	s.Equal(int64(1), report.ExecutionsScanned)
+	s.Equal(int64(0), report.CorruptedCount())
+}
+
+func (s *scannerSuite) TestTransientErrorIsNotCorruption() {
+	s.expectListExecutions(testExecution)
+	s.expectGetExecution(nil, &shared.ServiceBusyError{})
+
+	report := s.scan(true)
+	s.Equal(int64(0), report.ExecutionsScanned)
+	s.Equal(int64(1), report.ErrorCount)
+	s.Equal(int64(0), report.CorruptedCount())
+}
+
+func (s *scannerSuite) TestFailedToLoad() {
+	s.expectListExecutions(testExecution)
+	s.expectGetExecution(nil, p.NewCadenceDeserializationError("bad blob"))
+
+	report := s.scan(false)
+	s.Equal(int64(1), report.Corruptions[CorruptionFailedToLoad])
+	s.Equal(int64(0), report.FixedCount)
+	s.Len(report.CorruptedExecutions, 1)
+	s.Equal(CorruptedExecution{
+		ShardID:    testShardID,
+		DomainID:   testDomainID,
+		WorkflowID: testWorkflowID,
+		RunID:      testRunID,
+		Corruption: CorruptionFailedToLoad,
+		Detail:     "cadence deserialization error: bad blob",
+	}, report.CorruptedExecutions[0])
+}
+
+func (s *scannerSuite) TestChecksumMismatch() {
+	ms := s.newMutableState(p.WorkflowStateCompleted)
+	ms.ActivityInfos = nil
+	s.expectListExecutions(testExecution)
+	s.expectGetExecution(ms, nil)
+	s.expectReadHistory(7, nil)
+
+	report := s.scan(false)
+	s.Equal(int64(1), report.Corruptions[CorruptionChecksumMismatch])
+}
+
+func (s *scannerSuite) TestChecksumMismatchOfOpenExecutionIsNotFixed() {
+	ms := s.newMutableState(p.WorkflowStateRunning)
+	ms.ActivityInfos = nil
+	s.expectListExecutions(testExecution)
+	s.expectGetExecution(ms, nil)
+	s.expectReadHistory(7, nil)
+	s.expectCurrentRunID(testRunID)
+
+	report := s.scan(true)
+	s.Equal(int64(1), report.Corruptions[CorruptionChecksumMismatch])
+	s.Equal(int64(0), report.FixedCount)
+	s.Equal(int64(0), report.ErrorCount)
+	s.False(report.CorruptedExecutions[0].Fixed)
+}
+
+func (s *scannerSuite) TestOtherCorruptionTakesPrecedenceOverChecksumMismatch() {
+	ms := s.newMutableState(p.WorkflowStateRunning)
+	ms.ActivityInfos = nil
+	s.expectListExecutions(testExecution)
+	s.expectGetExecution(ms, nil)
+	s.expectReadHistory(6, nil)
+	s.executionManager.On("DeleteWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.executionManager.On("DeleteCurrentWorkflowExecution", mock.Anything).Return(nil).Once()
+
+	report := s.scan(true)
+	s.Equal(int64(1), report.Corruptions[CorruptionNextEventIDMismatch])
+	s.Equal(int64(1), report.FixedCount)
+}
+
+func (s *scannerSuite) TestInvalidatedChecksumIsNotVerified() {
+	ms := s.newMutableState(p.WorkflowStateCompleted)
+	ms.ActivityInfos = nil
+	s.checksumInvalidateBefore = float64(ms.ExecutionInfo.LastUpdatedTimestamp.Add(time.Second).Unix())
+	s.expectListExecutions(testExecution)
+	s.expectGetExecution(ms, nil)
+	s.expectReadHistory(7, nil)
+
+	report := s.scan(false)
+	s.Equal(int64(1), report.ExecutionsScanned)
+	s.Equal(int64(0), report.CorruptedCount())
+}
+
+func (s *scannerSuite) TestHistoryMissing() {
+	s.expectListExecutions(testExecution)
+	s.expectGetExecution(s.newMutableState(p.WorkflowStateCompleted), nil)
+	s.expectReadHistory(0, &shared.EntityNotExistsError{})
+
+	report := s.scan(false)
+	s.Equal(int64(1), report.Corruptions[CorruptionHistoryMissing])
+}
+
+func (s *scannerSuite) TestNextEventIDMismatch() {
+	s.expectListExecutions(testExecution)
+	s.expectGetExecution(s.newMutableState(p.WorkflowStateCompleted), nil)
+	s.expectReadHistory(6, nil)
+
+	report := s.scan(false)
+	s.Equal(int64(1), report.Corruptions[CorruptionNextEventIDMismatch])
+	s.Equal("last event ID is 6, next event ID is 8", report.CorruptedExecutions[0].Detail)
+}
+
+func (s *scannerSuite) TestOpenExecutionNotCurrentIsFixed() {
+	s.expectListExecutions(testExecution)
+	s.expectGetExecution(s.newMutableState(p.WorkflowStateRunning), nil)
+	s.expectReadHistory(7, nil)
+	s.expectCurrentRunID("another-run-id")
+	s.executionManager.On("DeleteWorkflowExecution", &p.DeleteWorkflowExecutionRequest{
+		DomainID:   testDomainID,
+		WorkflowID: testWorkflowID,
+		RunID:      testRunID,
+	}).Return(nil).Once()
+	s.executionManager.On("DeleteCurrentWorkflowExecution", &p.DeleteCurrentWorkflowExecutionRequest{
+		DomainID:   testDomainID,
+		WorkflowID: testWorkflowID,
+		RunID:      testRunID,
+	}).Return(nil).Once()
+
+	report := s.scan(true)
+	s.True(report.Fix)
+	s.Equal(int64(1), report.Corruptions[CorruptionOpenExecutionNotCurrent])
+	s.Equal(int64(1), report.FixedCount)
+	s.True(report.CorruptedExecutions[0].Fixed)
+}
+
+func (s *scannerSuite) TestFixFailure() {
+	s.expectListExecutions(testExecution)
+	s.expectGetExecution(nil, p.NewCadenceDeserializationError("bad blob"))
+	s.executionManager.On("DeleteWorkflowExecution", mock.Anything).Return(errors.New("unavailable")).Once()
+
+	report := s.scan(true)
+	s.Equal(int64(1), report.Corruptions[CorruptionFailedToLoad])
+	s.Equal(int64(0), report.FixedCount)
+	s.Equal(int64(1), report.ErrorCount)
+	s.False(report.CorruptedExecutions[0].Fixed)
+}
+
+func (s *scannerSuite) TestReportMerge() {
+	report := &Report{}
+	for i := 0; i < maxCorruptedExecutionsInReport; i++ {
+		report.addCorrupted(CorruptedExecution{Corruption: CorruptionHistoryMissing, Fixed: true})
+	}
+	report.Merge(&Report{
+		ShardsScanned:       2,
+		ExecutionsScanned:   10,
+		ErrorCount:          1,
+		Corruptions:         map[string]int64{CorruptionHistoryMissing: 1, CorruptionChecksumMismatch: 2},
+		CorruptedExecutions: []CorruptedExecution{{Corruption: CorruptionChecksumMismatch}},
+	})
+	s.Equal(2, report.ShardsScanned)
+	s.Equal(int64(10), report.ExecutionsScanned)
+	s.Equal(int64(1), report.ErrorCount)
+	s.Equal(int64(maxCorruptedExecutionsInReport), report.FixedCount)
+	s.Equal(int64(maxCorruptedExecutionsInReport+3), report.CorruptedCount())
+	s.Len(report.CorruptedExecutions, maxCorruptedExecutionsInReport)
+}
@@ -55,6 +55,12 @@ type (
 		Persistence *config.Persistence
 		// ClusterMetadata contains the metadata for this cluster
 		ClusterMetadata cluster.Metadata
+		// ExecutionsScannerEnabled indicates if the executions scanner should be started
+		ExecutionsScannerEnabled dynamicconfig.BoolPropertyFn
+		// ExecutionsScannerFixEnabled indicates if the executions scanner should delete the corrupted executions
+		ExecutionsScannerFixEnabled dynamicconfig.BoolPropertyFn
+		// MutableStateChecksumInvalidateBefore is the epoch timestamp before which mutable state checksums are discarded
+		MutableStateChecksumInvalidateBefore dynamicconfig.FloatPropertyFn
 	}
 
 	// BootstrapParams contains the set of params needed to bootstrap
@@ -128,12 +134,24 @@ func (s *Scanner) Start() error {
 		workerTaskListName = historyScannerTaskListName
 	}
 
-	return worker.New(s.context.GetSDKClient(), common.SystemLocalDomainName, workerTaskListName, workerOpts).Start()
+	if err := worker.New(s.context.GetSDKClient(), common.SystemLocalDomainName, workerTaskListName, workerOpts).Start(); err != nil {
+		return err
+	}
+
+	if s.context.cfg.ExecutionsScannerEnabled() {
+		params := ExecutionsScannerWorkflowParams{
+			NumShards: s.context.cfg.Persistence.NumHistoryShards,
+		}
+		go s.startWorkflowWithRetry(executionsScannerWFStartOptions, executionsScannerWFTypeName, params)
+		return worker.New(s.context.GetSDKClient(), common.SystemLocalDomainName, executionsScannerTaskListName, workerOpts).Start()
+	}
+	return nil
 }
 
 func (s *Scanner) startWorkflowWithRetry(
 	options cclient.StartWorkflowOptions,
 	workflowType string,
+	args ...interface{},
 ) {
 
 	// let history / matching service warm up
@@ -144,7 +162,7 @@ func (s *Scanner) startWorkflowWithRetry(
 	policy.SetMaximumInterval(time.Minute)
 	policy.SetExpirationInterval(backoff.NoInterval)
 	err := backoff.Retry(func() error {
-		return s.startWorkflow(sdkClient, options, workflowType)
+		return s.startWorkflow(sdkClient, options, workflowType, args...)
 	}, policy, func(err error) bool {
 		return true
 	})
@@ -157,10 +175,11 @@ func (s *Scanner) startWorkflow(
 	client cclient.Client,
 	options cclient.StartWorkflowOptions,
 	workflowType string,
+	args ...interface{},
 ) error {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-	_, err := client.StartWorkflow(ctx, options, workflowType)
+	_, err := client.StartWorkflow(ctx, options, workflowType, args...)
 	cancel()
 	if err != nil {
 		st := status.Convert(err)
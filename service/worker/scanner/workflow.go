@@ -28,8 +28,10 @@ import (
 	"go.temporal.io/temporal/activity"
 	cclient "go.temporal.io/temporal/client"
 	"go.temporal.io/temporal/workflow"
+	"go.uber.org/zap"
 
 	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/service/worker/scanner/executions"
 	"github.com/temporalio/temporal/service/worker/scanner/history"
 	"github.com/temporalio/temporal/service/worker/scanner/tasklist"
 )
@@ -54,6 +56,41 @@ const (
 	historyScannerWFTypeName     = "cadence-sys-history-scanner-workflow"
 	historyScannerTaskListName   = "cadence-sys-history-scanner-tasklist-0"
 	historyScavengerActivityName = "cadence-sys-history-scanner-scvg-activity"
+
+	executionsScannerWFTypeName     = "cadence-sys-executions-scanner-workflow"
+	executionsScannerTaskListName   = "cadence-sys-executions-scanner-tasklist-0"
+	executionsScannerActivityName   = "cadence-sys-executions-scanner-activity"
+	executionsScannerShardBatchSize = 16
+
+	// ExecutionsScannerWFID is the workflow ID of the executions scanner
+	ExecutionsScannerWFID = "cadence-sys-executions-scanner"
+	// ExecutionsScannerReportQuery is the query type that returns the executions scanner reports
+	ExecutionsScannerReportQuery = "executions_scanner_report"
+)
+
+type (
+	// ExecutionsScannerWorkflowParams are the params of the executions scanner workflow
+	ExecutionsScannerWorkflowParams struct {
+		NumShards int
+	}
+
+	// ExecutionsScannerQueryResult is the result of the executions scanner report query
+	ExecutionsScannerQueryResult struct {
+		// Current is the report of the scan in progress
+		Current *executions.Report
+		// Last is the report of the last completed scan, if any
+		Last *executions.Report
+	}
+
+	executionsScannerActivityParams struct {
+		StartShardID int
+		EndShardID   int
+	}
+
+	executionsScannerHeartbeatDetails struct {
+		NextShardID int
+		Report      executions.Report
+	}
 )
 
 var (
@@ -85,6 +122,13 @@ var (
 		WorkflowIDReusePolicy:        cclient.WorkflowIDReusePolicyAllowDuplicate,
 		CronSchedule:                 "0 */12 * * *",
 	}
+	executionsScannerWFStartOptions = cclient.StartWorkflowOptions{
+		ID:                           ExecutionsScannerWFID,
+		TaskList:                     executionsScannerTaskListName,
+		ExecutionStartToCloseTimeout: infiniteDuration,
+		WorkflowIDReusePolicy:        cclient.WorkflowIDReusePolicyAllowDuplicate,
+		CronSchedule:                 "0 */12 * * *",
+	}
 )
 
 func init() {
@@ -92,6 +136,8 @@ func init() {
 	workflow.RegisterWithOptions(HistoryScannerWorkflow, workflow.RegisterOptions{Name: historyScannerWFTypeName})
 	activity.RegisterWithOptions(TaskListScavengerActivity, activity.RegisterOptions{Name: taskListScavengerActivityName})
 	activity.RegisterWithOptions(HistoryScavengerActivity, activity.RegisterOptions{Name: historyScavengerActivityName})
+	workflow.RegisterWithOptions(ExecutionsScannerWorkflow, workflow.RegisterOptions{Name: executionsScannerWFTypeName})
+	activity.RegisterWithOptions(ExecutionsScannerActivity, activity.RegisterOptions{Name: executionsScannerActivityName})
 }
 
 // TaskListScannerWorkflow is the workflow that runs the task-list scanner background daemon
@@ -115,6 +161,98 @@ func HistoryScannerWorkflow(
 	return future.Get(ctx, nil)
 }
 
+// ExecutionsScannerWorkflow is the workflow that runs the executions scanner background daemon,
+// the report of the last completed run is carried over to the next cron run
+func ExecutionsScannerWorkflow(
+	ctx workflow.Context,
+	params ExecutionsScannerWorkflowParams,
+) (*executions.Report, error) {
+
+	report := &executions.Report{StartTime: workflow.Now(ctx)}
+	var lastReport *executions.Report
+	if workflow.HasLastCompletionResult(ctx) {
+		if err := workflow.GetLastCompletionResult(ctx, &lastReport); err != nil {
+			workflow.GetLogger(ctx).Warn("failed to load last executions scanner report", zap.Error(err))
+		}
+	}
+
+	err := workflow.SetQueryHandler(ctx, ExecutionsScannerReportQuery, func() (ExecutionsScannerQueryResult, error) {
+		return ExecutionsScannerQueryResult{
+			Current: report,
+			Last:    lastReport,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	activityCtx := workflow.WithActivityOptions(ctx, activityOptions)
+	for startShardID := 0; startShardID < params.NumShards; startShardID += executionsScannerShardBatchSize {
+		endShardID := startShardID + executionsScannerShardBatchSize
+		if endShardID > params.NumShards {
+			endShardID = params.NumShards
+		}
+
+		var batchReport executions.Report
+		future := workflow.ExecuteActivity(
+			activityCtx,
+			executionsScannerActivityName,
+			executionsScannerActivityParams{StartShardID: startShardID, EndShardID: endShardID},
+		)
+		if err := future.Get(ctx, &batchReport); err != nil {
+			return nil, err
+		}
+		report.Merge(&batchReport)
+	}
+
+	report.EndTime = workflow.Now(ctx)
+	return report, nil
+}
+
+// ExecutionsScannerActivity is the activity that scans the executions of a range of shards
+func ExecutionsScannerActivity(
+	activityCtx context.Context,
+	params executionsScannerActivityParams,
+) (*executions.Report, error) {
+
+	ctx := activityCtx.Value(scannerContextKey).(scannerContext)
+
+	hbd := executionsScannerHeartbeatDetails{NextShardID: params.StartShardID}
+	if activity.HasHeartbeatDetails(activityCtx) {
+		if err := activity.GetHeartbeatDetails(activityCtx, &hbd); err != nil {
+			ctx.GetLogger().Error("Failed to recover from last heartbeat, start over from beginning", tag.Error(err))
+			hbd = executionsScannerHeartbeatDetails{NextShardID: params.StartShardID}
+		}
+	}
+
+	scanner := executions.NewScanner(
+		ctx.GetExecutionManager,
+		ctx.GetHistoryManager(),
+		ctx.cfg.PersistenceMaxQPS(),
+		ctx.cfg.ExecutionsScannerFixEnabled(),
+		ctx.cfg.MutableStateChecksumInvalidateBefore,
+		ctx.GetMetricsClient(),
+		ctx.GetLogger(),
+	)
+	for ; hbd.NextShardID < params.EndShardID; hbd.NextShardID++ {
+		// the shard in progress is scanned again from the beginning on retry,
+		// so only the reports of the completed shards are recorded in the heartbeat
+		shardReport := &executions.Report{}
+		err := scanner.ScanShard(activityCtx, hbd.NextShardID, shardReport, func() {
+			activity.RecordHeartbeat(activityCtx, hbd)
+		})
+		if err != nil {
+			return nil, err
+		}
+		hbd.Report.Merge(shardReport)
+		activity.RecordHeartbeat(activityCtx, executionsScannerHeartbeatDetails{
+			NextShardID: hbd.NextShardID + 1,
+			Report:      hbd.Report,
+		})
+	}
+	return &hbd.Report, nil
+}
+
 // HistoryScavengerActivity is the activity that runs history scavenger
 func HistoryScavengerActivity(
 	activityCtx context.Context,
@@ -36,6 +36,7 @@ import (
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 	"github.com/temporalio/temporal/service/worker/archiver"
 	"github.com/temporalio/temporal/service/worker/batcher"
+	"github.com/temporalio/temporal/service/worker/domaindeleter"
 	"github.com/temporalio/temporal/service/worker/indexer"
 	"github.com/temporalio/temporal/service/worker/parentclosepolicy"
 	"github.com/temporalio/temporal/service/worker/replicator"
@@ -63,9 +64,11 @@ type (
 		IndexerCfg                    *indexer.Config
 		ScannerCfg                    *scanner.Config
 		BatcherCfg                    *batcher.Config
+		DomainDeleterCfg              *domaindeleter.Config
 		ThrottledLogRPS               dynamicconfig.IntPropertyFn
 		EnableBatcher                 dynamicconfig.BoolPropertyFn
 		EnableParentClosePolicyWorker dynamicconfig.BoolPropertyFn
+		EnableDomainDeleter           dynamicconfig.BoolPropertyFn
 	}
 )
 
@@ -124,18 +127,26 @@ func NewConfig(params *service.BootstrapParams) *Config {
 			ArchiverConcurrency:           dc.GetIntProperty(dynamicconfig.WorkerArchiverConcurrency, 50),
 			ArchivalsPerIteration:         dc.GetIntProperty(dynamicconfig.WorkerArchivalsPerIteration, 1000),
 			TimeLimitPerArchivalIteration: dc.GetDurationProperty(dynamicconfig.WorkerTimeLimitPerArchivalIteration, archiver.MaxArchivalIterationTimeout()),
+			VerificationSampleRate:        dc.GetFloat64Property(dynamicconfig.WorkerArchivalVerificationSampleRate, 0.01),
 		},
 		ScannerCfg: &scanner.Config{
-			PersistenceMaxQPS: dc.GetIntProperty(dynamicconfig.ScannerPersistenceMaxQPS, 100),
-			Persistence:       &params.PersistenceConfig,
-			ClusterMetadata:   params.ClusterMetadata,
+			PersistenceMaxQPS:                    dc.GetIntProperty(dynamicconfig.ScannerPersistenceMaxQPS, 100),
+			Persistence:                          &params.PersistenceConfig,
+			ClusterMetadata:                      params.ClusterMetadata,
+			ExecutionsScannerEnabled:             dc.GetBoolProperty(dynamicconfig.ExecutionsScannerEnabled, false),
+			ExecutionsScannerFixEnabled:          dc.GetBoolProperty(dynamicconfig.ExecutionsScannerFixEnabled, false),
+			MutableStateChecksumInvalidateBefore: dc.GetFloat64Property(dynamicconfig.MutableStateChecksumInvalidateBefore, 0),
 		},
 		BatcherCfg: &batcher.Config{
 			AdminOperationToken: dc.GetStringProperty(dynamicconfig.AdminOperationToken, common.DefaultAdminOperationToken),
 			ClusterMetadata:     params.ClusterMetadata,
 		},
+		DomainDeleterCfg: &domaindeleter.Config{
+			PersistenceMaxQPS: dc.GetIntProperty(dynamicconfig.DomainDeleterPersistenceMaxQPS, 100),
+		},
 		EnableBatcher:                 dc.GetBoolProperty(dynamicconfig.EnableBatcher, false),
 		EnableParentClosePolicyWorker: dc.GetBoolProperty(dynamicconfig.EnableParentClosePolicyWorker, true),
+		EnableDomainDeleter:           dc.GetBoolProperty(dynamicconfig.EnableDomainDeleter, true),
 		ThrottledLogRPS:               dc.GetIntProperty(dynamicconfig.WorkerThrottledLogRPS, 20),
 	}
 	advancedVisWritingMode := dc.GetStringProperty(
@@ -184,6 +195,9 @@ func (s *Service) Start() {
 	if s.config.EnableParentClosePolicyWorker() {
 		s.startParentClosePolicyProcessor()
 	}
+	if s.config.EnableDomainDeleter() {
+		s.startDomainDeleter()
+	}
 
 	logger.Info("worker started", tag.ComponentWorker)
 	<-s.stopC
@@ -230,6 +244,16 @@ func (s *Service) startBatcher() {
 	}
 }
 
+func (s *Service) startDomainDeleter() {
+	params := &domaindeleter.BootstrapParams{
+		Config:     *s.config.DomainDeleterCfg,
+		TallyScope: s.params.MetricScope,
+	}
+	if err := domaindeleter.New(s.Resource, params).Start(); err != nil {
+		s.GetLogger().Fatal("error starting domain deleter", tag.Error(err))
+	}
+}
+
 func (s *Service) startScanner() {
 	params := &scanner.BootstrapParams{
 		Config:     *s.config.ScannerCfg,
@@ -253,6 +277,7 @@ func (s *Service) startReplicator() {
 		s.GetHostInfo(),
 		s.GetWorkerServiceResolver(),
 		s.GetDomainReplicationQueue(),
+		domaindeleter.NewClient(s.GetSDKClient(), s.params.PersistenceConfig.NumHistoryShards),
 	)
 	if err := msgReplicator.Start(); err != nil {
 		msgReplicator.Stop()
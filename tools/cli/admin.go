@@ -181,6 +181,27 @@ func newAdminWorkflowCommands() []cli.Command {
 				AdminDeleteWorkflow(c)
 			},
 		},
+		{
+			Name:  "unarchive",
+			Usage: "Restore an archived workflow execution as a closed workflow execution, it is deleted again once the retention passes",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowID",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunID",
+				},
+				cli.StringFlag{
+					Name:  FlagReasonWithAlias,
+					Usage: "Reason to unarchive the workflow execution",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminUnarchiveWorkflow(c)
+			},
+		},
 	}
 }
 
@@ -222,6 +243,106 @@ func newAdminShardManagementCommands() []cli.Command {
 				AdminRemoveTask(c)
 			},
 		},
+		{
+			Name:    "list-tasks",
+			Aliases: []string{"lt"},
+			Usage:   "List the pending transfer, timer or replication tasks of a shard",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagShardID,
+					Usage: "ShardID for the cadence cluster to manage",
+				},
+				cli.IntFlag{
+					Name:  FlagRemoveTypeID,
+					Usage: "task category: 2 (transfer task), 3 (timer task), 4 (replication task)",
+				},
+				cli.StringFlag{
+					Name:  FlagTaskTypes,
+					Usage: "Only show tasks of these comma separated task types",
+				},
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "Only show tasks of this WorkflowID",
+				},
+				cli.StringFlag{
+					Name: FlagEarliestTimeWithAlias,
+					Usage: "Only show tasks visible after this time, supported formats are '2006-01-02T15:04:05+07:00', raw UnixNano and " +
+						"time range (N<duration>), where 0 < N < 1000000 and duration (full-notation/short-notation) can be " +
+						"second/s, minute/m, hour/h, day/d, week/w, month/M or year/y. For example, '15minute' or '15m' implies last 15 minutes.",
+				},
+				cli.StringFlag{
+					Name:  FlagLatestTimeWithAlias,
+					Usage: "Only show tasks visible before this time, supports the same formats as --" + FlagEarliestTime,
+				},
+				cli.IntFlag{
+					Name:  FlagPageSizeWithAlias,
+					Value: 100,
+					Usage: "Result page size",
+				},
+				cli.BoolFlag{
+					Name:  FlagMoreWithAlias,
+					Usage: "Show more tasks by pressing Enter",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminListShardTasks(c)
+			},
+		},
+		{
+			Name:        "dlq",
+			Usage:       "Run admin operation on the transfer and timer task DLQ of a shard",
+			Subcommands: newAdminShardDLQCommands(),
+		},
+	}
+}
+
+func newAdminShardDLQCommands() []cli.Command {
+	shardDLQFlags := []cli.Flag{
+		cli.IntFlag{
+			Name:  FlagShardID,
+			Usage: "ShardID for the cadence cluster to manage",
+		},
+		cli.IntFlag{
+			Name:  FlagRemoveTypeID,
+			Usage: "type id of the DLQ: 2 (transfer task), 3 (timer task)",
+		},
+	}
+	return []cli.Command{
+		{
+			Name:    "list",
+			Aliases: []string{"l"},
+			Usage:   "List the tasks in the DLQ of a shard",
+			Flags: append(shardDLQFlags,
+				cli.IntFlag{
+					Name:  FlagPageSizeWithAlias,
+					Value: 100,
+					Usage: "Result page size",
+				},
+				cli.BoolFlag{
+					Name:  FlagMoreWithAlias,
+					Usage: "Show more tasks by pressing Enter",
+				},
+			),
+			Action: func(c *cli.Context) {
+				AdminListShardDLQTasks(c)
+			},
+		},
+		{
+			Name:  "retry",
+			Usage: "Process the tasks in the DLQ of a shard again, tasks processed successfully are removed from the DLQ",
+			Flags: shardDLQFlags,
+			Action: func(c *cli.Context) {
+				AdminRetryShardDLQTasks(c)
+			},
+		},
+		{
+			Name:  "purge",
+			Usage: "Delete all tasks in the DLQ of a shard",
+			Flags: shardDLQFlags,
+			Action: func(c *cli.Context) {
+				AdminPurgeShardDLQTasks(c)
+			},
+		},
 	}
 }
 
@@ -303,6 +424,137 @@ func newAdminDomainCommands() []cli.Command {
 				newDomainCLI(c, true).DescribeDomain(c)
 			},
 		},
+		{
+			Name:    "add-search-attr",
+			Aliases: []string{"asa"},
+			Usage:   "Add search attribute to domain, stored in a whitelisted search attribute of the cluster",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagSearchAttributesKey,
+					Usage: "Search Attribute key used by the domain",
+				},
+				cli.StringFlag{
+					Name:  FlagSearchAttributesField,
+					Usage: "Whitelisted Search Attribute key of the cluster the value is stored in",
+				},
+				cli.StringFlag{
+					Name:  FlagSecurityTokenWithAlias,
+					Usage: "Optional token for security check",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminAddDomainSearchAttribute(c)
+			},
+		},
+		{
+			Name:    "remove-search-attr",
+			Aliases: []string{"rsa"},
+			Usage:   "Remove search attribute from domain",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagSearchAttributesKey,
+					Usage: "Search Attribute key used by the domain",
+				},
+				cli.StringFlag{
+					Name:  FlagSecurityTokenWithAlias,
+					Usage: "Optional token for security check",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminRemoveDomainSearchAttribute(c)
+			},
+		},
+		{
+			Name:    "list-search-attr",
+			Aliases: []string{"lsa"},
+			Usage:   "List search attributes of domain",
+			Action: func(c *cli.Context) {
+				AdminListDomainSearchAttributes(c)
+			},
+		},
+		{
+			Name:    "update-workflow-defaults",
+			Aliases: []string{"uwd"},
+			Usage:   "Update the defaults and maximums applied to the workflows and activities of domain, options not given are left as they are",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagDefaultExecutionTimeout,
+					Usage: "Default workflow execution timeout in seconds, 0 for none",
+				},
+				cli.IntFlag{
+					Name:  FlagMaxExecutionTimeout,
+					Usage: "Maximum workflow execution timeout in seconds, longer timeouts are capped, 0 for none",
+				},
+				cli.IntFlag{
+					Name:  FlagDefaultDecisionTimeout,
+					Usage: "Default decision task timeout in seconds, 0 for none",
+				},
+				cli.IntFlag{
+					Name:  FlagDefaultActivityTimeout,
+					Usage: "Default activity schedule to close timeout in seconds, 0 for none",
+				},
+				cli.IntFlag{
+					Name:  FlagMaxActivityTimeout,
+					Usage: "Maximum activity timeout in seconds, longer timeouts are capped, 0 for none",
+				},
+				cli.IntFlag{
+					Name: FlagDefaultWorkflowIDReusePolicy,
+					Usage: "Default workflow ID reuse policy. " +
+						"Available options: 0: AllowDuplicateFailedOnly (none), 1: AllowDuplicate, 2: RejectDuplicate",
+				},
+				cli.StringFlag{
+					Name:  FlagDefaultWorkflowRetryPolicy,
+					Usage: "Default workflow retry policy in JSON format, empty for none",
+				},
+				cli.StringFlag{
+					Name:  FlagDefaultActivityRetryPolicy,
+					Usage: "Default activity retry policy in JSON format, empty for none",
+				},
+				cli.StringFlag{
+					Name:  FlagSecurityTokenWithAlias,
+					Usage: "Optional token for security check",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminUpdateDomainWorkflowDefaults(c)
+			},
+		},
+		{
+			Name:    "describe-workflow-defaults",
+			Aliases: []string{"dwd"},
+			Usage:   "Describe the defaults and maximums applied to the workflows and activities of domain",
+			Action: func(c *cli.Context) {
+				AdminDescribeDomainWorkflowDefaults(c)
+			},
+		},
+		{
+			Name:    "delete",
+			Aliases: []string{"del"},
+			Usage:   "Delete domain, all its workflow executions, histories, visibility records and task lists are purged",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagSecurityTokenWithAlias,
+					Usage: "Optional token for security check",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDeleteDomain(c)
+			},
+		},
+		{
+			Name:    "describe-deletion",
+			Aliases: []string{"descdel"},
+			Usage:   "Show the progress of the deletion of domain",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagDomainID,
+					Usage: "DomainID, required once the domain record is removed",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDescribeDomainDeletion(c)
+			},
+		},
 		{
 			Name:    "getdomainidorname",
 			Aliases: []string{"getdn"},
@@ -752,11 +1004,48 @@ func newAdminTaskListCommands() []cli.Command {
 					Value: "decision",
 					Usage: "Optional TaskList type [decision|activity]",
 				},
+				cli.BoolFlag{
+					Name:  FlagAllPartitionsWithAlias,
+					Usage: "Describe all partitions of the tasklist and aggregate their stats",
+				},
 			},
 			Action: func(c *cli.Context) {
 				AdminDescribeTaskList(c)
 			},
 		},
+		{
+			Name:    "update-build-ids",
+			Aliases: []string{"ubi"},
+			Usage:   "Replace the compatible worker build ID sets of a decision tasklist",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskListWithAlias,
+					Usage: "TaskList name",
+				},
+				cli.StringFlag{
+					Name: FlagBuildIDSetsWithAlias,
+					Usage: "Compatible build ID sets ordered from oldest to latest, sets are separated by ';' and build IDs " +
+						"within a set by ','. e.g. '1.0,1.1;2.0'. Empty value removes all sets",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminUpdateTaskListBuildIDs(c)
+			},
+		},
+		{
+			Name:    "get-build-ids",
+			Aliases: []string{"gbi"},
+			Usage:   "Show the compatible worker build ID sets of a decision tasklist",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskListWithAlias,
+					Usage: "TaskList name",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminGetTaskListBuildIDs(c)
+			},
+		},
 	}
 }
 
@@ -793,5 +1082,101 @@ func newAdminClusterCommands() []cli.Command {
 				AdminDescribeCluster(c)
 			},
 		},
+		{
+			Name:    "describe-dynamic-config",
+			Aliases: []string{"ddc"},
+			Usage:   "Describe dynamic config keys read by a frontend host and their effective values",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagNameWithAlias,
+					Usage: "Only show keys whose name starts with this prefix",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDescribeDynamicConfig(c)
+			},
+		},
+	}
+}
+
+func newAdminAuditCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:    "list",
+			Aliases: []string{"l"},
+			Usage:   "List audit records of mutating API calls, with the file sink only the calls served by the responding frontend host are listed",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagActorWithAlias,
+					Usage: "Only show calls made with this identity",
+				},
+				cli.StringFlag{
+					Name: FlagEarliestTimeWithAlias,
+					Usage: "Only show calls made after this time, supported formats are '2006-01-02T15:04:05+07:00', raw UnixNano and " +
+						"time range (N<duration>), where 0 < N < 1000000 and duration (full-notation/short-notation) can be " +
+						"second/s, minute/m, hour/h, day/d, week/w, month/M or year/y. For example, '15minute' or '15m' implies last 15 minutes.",
+				},
+				cli.StringFlag{
+					Name:  FlagLatestTimeWithAlias,
+					Usage: "Only show calls made before this time, supports the same formats as --" + FlagEarliestTime,
+				},
+				cli.IntFlag{
+					Name:  FlagPageSizeWithAlias,
+					Value: 100,
+					Usage: "Result page size",
+				},
+				cli.BoolFlag{
+					Name:  FlagMoreWithAlias,
+					Usage: "Show more records by pressing Enter",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminListAuditRecords(c)
+			},
+		},
+	}
+}
+
+func newAdminScanCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:    "executions",
+			Aliases: []string{"ex"},
+			Usage:   "Show the reports of the in progress and the last completed executions scan",
+			Action: func(c *cli.Context) {
+				AdminScanExecutions(c)
+			},
+		},
+	}
+}
+
+func newAdminArchivalCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:    "export-visibility",
+			Aliases: []string{"ev"},
+			Usage:   "Export the visibility records archived by the filestore archiver of a domain into one columnar Parquet file per day",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagVisibilityArchivalURIWithAlias,
+					Usage: "URI of the filestore visibility archival, e.g. file:///tmp/temporal_vis_archival",
+				},
+				cli.StringFlag{
+					Name:  FlagDomainID,
+					Usage: "DomainID",
+				},
+				cli.StringFlag{
+					Name:  FlagOutputDirectoryWithAlias,
+					Usage: "Directory the Parquet files are written to",
+				},
+				cli.BoolFlag{
+					Name:  FlagOverwrite,
+					Usage: "Overwrite the Parquet files of days which were already exported",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminExportColumnarVisibility(c)
+			},
+		},
 	}
 }
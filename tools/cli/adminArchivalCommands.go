@@ -0,0 +1,50 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/temporalio/temporal/common/archiver"
+	"github.com/temporalio/temporal/common/archiver/filestore"
+)
+
+// AdminExportColumnarVisibility exports the visibility records archived by the filestore archiver
+// into columnar Parquet files, it reads the archive directly so it must run on a host which can access it
+func AdminExportColumnarVisibility(c *cli.Context) {
+	URI, err := archiver.NewURI(getRequiredOption(c, FlagVisibilityArchivalURI))
+	if err != nil {
+		ErrorAndExit("Invalid visibility archival URI.", err)
+	}
+	domainID := getRequiredOption(c, FlagDomainID)
+	outputDirectory := getRequiredOption(c, FlagOutputDirectory)
+
+	written, err := filestore.ExportColumnarVisibility(URI, domainID, outputDirectory, c.Bool(FlagOverwrite))
+	for _, filename := range written {
+		fmt.Println(filename)
+	}
+	if err != nil {
+		ErrorAndExit("Export visibility records failed.", err)
+	}
+	fmt.Printf("Exported %v files to %v.\n", len(written), outputDirectory)
+}
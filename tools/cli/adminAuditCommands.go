@@ -0,0 +1,89 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"os"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+)
+
+// AdminListAuditRecords lists the audit records of mutating API calls
+func AdminListAuditRecords(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+
+	request := &adminservice.ListAuditRecordsRequest{
+		Domain:    c.GlobalString(FlagDomain),
+		Identity:  c.String(FlagActor),
+		StartTime: parseTime(c.String(FlagEarliestTime), 0, time.Now()),
+		EndTime:   parseTime(c.String(FlagLatestTime), 0, time.Now()),
+		PageSize:  int32(c.Int(FlagPageSize)),
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	header := []string{"Time", "API", "Actor", "Domain", "Workflow ID", "Run ID", "Reason", "Outcome"}
+	headerColor := make([]tablewriter.Colors, len(header))
+	for i := range headerColor {
+		headerColor[i] = tableHeaderBlue
+	}
+	table.SetHeader(header)
+	table.SetHeaderColor(headerColor...)
+	table.SetHeaderLine(false)
+
+	for {
+		ctx, cancel := newContext(c)
+		response, err := adminClient.ListAuditRecords(ctx, request)
+		cancel()
+		if err != nil {
+			ErrorAndExit("Operation ListAuditRecords failed.", err)
+		}
+
+		for _, record := range response.Records {
+			outcome := record.GetOutcome()
+			if record.GetError() != "" {
+				outcome += ": " + record.GetError()
+			}
+			table.Append([]string{
+				convertTime(record.GetTimestamp(), false),
+				record.GetApi(),
+				record.GetIdentity(),
+				record.GetDomain(),
+				record.GetWorkflowId(),
+				record.GetRunId(),
+				record.GetReason(),
+				outcome,
+			})
+		}
+		table.Render()
+		table.ClearRows()
+
+		if len(response.NextPageToken) == 0 || !c.Bool(FlagMore) || !showNextPage() {
+			return
+		}
+		request.NextPageToken = response.NextPageToken
+	}
+}
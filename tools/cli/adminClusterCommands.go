@@ -82,6 +82,22 @@ func AdminDescribeCluster(c *cli.Context) {
 	prettyPrintJSONObject(response)
 }
 
+// AdminDescribeDynamicConfig is used to dump the dynamic config keys read by a frontend host
+func AdminDescribeDynamicConfig(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.DescribeDynamicConfig(ctx, &adminservice.DescribeDynamicConfigRequest{
+		NamePrefix: c.String(FlagName),
+	})
+	if err != nil {
+		ErrorAndExit("Operation DescribeDynamicConfig failed.", err)
+	}
+
+	prettyPrintJSONObject(response)
+}
+
 func intValTypeToString(valType int) string {
 	switch valType {
 	case 0:
@@ -149,6 +149,32 @@ func AdminDescribeWorkflow(c *cli.Context) {
 	}
 }
 
+// AdminUnarchiveWorkflow restores an archived workflow execution
+func AdminUnarchiveWorkflow(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := getRequiredOption(c, FlagRunID)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	_, err := adminClient.UnarchiveWorkflowExecution(ctx, &adminservice.UnarchiveWorkflowExecutionRequest{
+		Domain: domain,
+		WorkflowExecution: &commonproto.WorkflowExecution{
+			WorkflowId: wid,
+			RunId:      rid,
+		},
+		Identity: getCliIdentity(),
+		Reason:   c.String(FlagReason),
+	})
+	if err != nil {
+		ErrorAndExit("Unarchive workflow execution has failed", err)
+	}
+	fmt.Printf("Workflow execution %v/%v is unarchived.\n", wid, rid)
+}
+
 func describeMutableState(c *cli.Context) *adminservice.DescribeWorkflowExecutionResponse {
 	adminClient := cFactory.AdminClient(c)
 
@@ -0,0 +1,260 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	commonproto "go.temporal.io/temporal-proto/common"
+	"go.temporal.io/temporal-proto/workflowservice"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/service/worker/domaindeleter"
+)
+
+// AdminAddDomainSearchAttribute adds a search attribute to the domain
+func AdminAddDomainSearchAttribute(c *cli.Context) {
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	key := getRequiredOption(c, FlagSearchAttributesKey)
+	field := getRequiredOption(c, FlagSearchAttributesField)
+
+	adminClient := cFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	request := &adminservice.AddSearchAttributesRequest{
+		Domain: domain,
+		SearchAttributes: map[string]string{
+			key: field,
+		},
+		SecurityToken: c.String(FlagSecurityToken),
+	}
+
+	_, err := adminClient.AddSearchAttributes(ctx, request)
+	if err != nil {
+		ErrorAndExit("Add domain search attribute failed.", err)
+	}
+	fmt.Println("Success")
+}
+
+// AdminRemoveDomainSearchAttribute removes a search attribute from the domain
+func AdminRemoveDomainSearchAttribute(c *cli.Context) {
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	key := getRequiredOption(c, FlagSearchAttributesKey)
+
+	adminClient := cFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	request := &adminservice.RemoveSearchAttributesRequest{
+		Domain:           domain,
+		SearchAttributes: []string{key},
+		SecurityToken:    c.String(FlagSecurityToken),
+	}
+
+	_, err := adminClient.RemoveSearchAttributes(ctx, request)
+	if err != nil {
+		ErrorAndExit("Remove domain search attribute failed.", err)
+	}
+	fmt.Println("Success")
+}
+
+// AdminListDomainSearchAttributes lists the search attributes of the domain
+func AdminListDomainSearchAttributes(c *cli.Context) {
+	domain := getRequiredGlobalOption(c, FlagDomain)
+
+	adminClient := cFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.ListSearchAttributes(ctx, &adminservice.ListSearchAttributesRequest{
+		Domain: domain,
+	})
+	if err != nil {
+		ErrorAndExit("Operation ListSearchAttributes failed.", err)
+	}
+
+	keys := make([]string, 0, len(response.SearchAttributes))
+	for key := range response.SearchAttributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	header := []string{"Key", "Cluster Key", "Value type"}
+	headerColor := []tablewriter.Colors{tableHeaderBlue, tableHeaderBlue, tableHeaderBlue}
+	table.SetHeader(header)
+	table.SetHeaderColor(headerColor...)
+	table.SetHeaderLine(false)
+	for _, key := range keys {
+		table.Append([]string{key, response.SearchAttributes[key], response.Keys[key].String()})
+	}
+	table.Render()
+}
+
+// AdminUpdateDomainWorkflowDefaults updates the workflow defaults of the domain
+func AdminUpdateDomainWorkflowDefaults(c *cli.Context) {
+	domain := getRequiredGlobalOption(c, FlagDomain)
+
+	adminClient := cFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.DescribeDomainWorkflowDefaults(ctx, &adminservice.DescribeDomainWorkflowDefaultsRequest{
+		Domain: domain,
+	})
+	if err != nil {
+		ErrorAndExit("Operation DescribeDomainWorkflowDefaults failed.", err)
+	}
+
+	workflowDefaults := response.WorkflowDefaults
+	if workflowDefaults == nil {
+		workflowDefaults = &adminservice.DomainWorkflowDefaults{}
+	}
+	if c.IsSet(FlagDefaultExecutionTimeout) {
+		workflowDefaults.DefaultExecutionStartToCloseTimeoutSeconds = int32(c.Int(FlagDefaultExecutionTimeout))
+	}
+	if c.IsSet(FlagMaxExecutionTimeout) {
+		workflowDefaults.MaxExecutionStartToCloseTimeoutSeconds = int32(c.Int(FlagMaxExecutionTimeout))
+	}
+	if c.IsSet(FlagDefaultDecisionTimeout) {
+		workflowDefaults.DefaultTaskStartToCloseTimeoutSeconds = int32(c.Int(FlagDefaultDecisionTimeout))
+	}
+	if c.IsSet(FlagDefaultActivityTimeout) {
+		workflowDefaults.DefaultActivityScheduleToCloseTimeoutSeconds = int32(c.Int(FlagDefaultActivityTimeout))
+	}
+	if c.IsSet(FlagMaxActivityTimeout) {
+		workflowDefaults.MaxActivityScheduleToCloseTimeoutSeconds = int32(c.Int(FlagMaxActivityTimeout))
+	}
+	if c.IsSet(FlagDefaultWorkflowIDReusePolicy) {
+		workflowDefaults.DefaultWorkflowIdReusePolicy = getWorkflowIDReusePolicy(c.Int(FlagDefaultWorkflowIDReusePolicy))
+	}
+	if c.IsSet(FlagDefaultWorkflowRetryPolicy) {
+		workflowDefaults.DefaultWorkflowRetryPolicy = parseRetryPolicy(c, FlagDefaultWorkflowRetryPolicy)
+	}
+	if c.IsSet(FlagDefaultActivityRetryPolicy) {
+		workflowDefaults.DefaultActivityRetryPolicy = parseRetryPolicy(c, FlagDefaultActivityRetryPolicy)
+	}
+
+	_, err = adminClient.UpdateDomainWorkflowDefaults(ctx, &adminservice.UpdateDomainWorkflowDefaultsRequest{
+		Domain:           domain,
+		WorkflowDefaults: workflowDefaults,
+		SecurityToken:    c.String(FlagSecurityToken),
+	})
+	if err != nil {
+		ErrorAndExit("Update domain workflow defaults failed.", err)
+	}
+	fmt.Println("Success")
+}
+
+// AdminDescribeDomainWorkflowDefaults describes the workflow defaults of the domain
+func AdminDescribeDomainWorkflowDefaults(c *cli.Context) {
+	domain := getRequiredGlobalOption(c, FlagDomain)
+
+	adminClient := cFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.DescribeDomainWorkflowDefaults(ctx, &adminservice.DescribeDomainWorkflowDefaultsRequest{
+		Domain: domain,
+	})
+	if err != nil {
+		ErrorAndExit("Operation DescribeDomainWorkflowDefaults failed.", err)
+	}
+	if response.WorkflowDefaults == nil {
+		fmt.Println("Domain has no workflow defaults")
+		return
+	}
+	prettyPrintJSONObject(response.WorkflowDefaults)
+}
+
+// parseRetryPolicy parses the retry policy given in JSON format, an empty value means no retry policy
+func parseRetryPolicy(c *cli.Context, flag string) *commonproto.RetryPolicy {
+	value := c.String(flag)
+	if value == "" {
+		return nil
+	}
+	var retryPolicy commonproto.RetryPolicy
+	if err := json.Unmarshal([]byte(value), &retryPolicy); err != nil {
+		ErrorAndExit(fmt.Sprintf("Option %v format is invalid.", flag), err)
+	}
+	return &retryPolicy
+}
+
+// AdminDeleteDomain marks the domain deleted and starts the workflow that purges its data
+func AdminDeleteDomain(c *cli.Context) {
+	domain := getRequiredGlobalOption(c, FlagDomain)
+
+	adminClient := cFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.DeleteDomain(ctx, &adminservice.DeleteDomainRequest{
+		Domain:        domain,
+		SecurityToken: c.String(FlagSecurityToken),
+	})
+	if err != nil {
+		ErrorAndExit("Delete domain failed.", err)
+	}
+	fmt.Printf("Domain %v (%v) is marked deleted, its data is purged by workflow %v, run %v.\n",
+		domain, response.GetDomainId(), response.GetWorkflowId(), response.GetRunId())
+}
+
+// AdminDescribeDomainDeletion shows the progress of the deletion of the domain
+func AdminDescribeDomainDeletion(c *cli.Context) {
+	frontendClient := cFactory.FrontendClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	domainID := c.String(FlagDomainID)
+	if domainID == "" {
+		// the domain can only be looked up by name until its record is removed
+		domain := getRequiredGlobalOption(c, FlagDomain)
+		resp, err := frontendClient.DescribeDomain(ctx, &workflowservice.DescribeDomainRequest{
+			Name: domain,
+		})
+		if err != nil {
+			ErrorAndExit("Describe domain failed.", err)
+		}
+		domainID = resp.DomainInfo.GetUuid()
+	}
+
+	response, err := frontendClient.QueryWorkflow(ctx, &workflowservice.QueryWorkflowRequest{
+		Domain: common.SystemLocalDomainName,
+		Execution: &commonproto.WorkflowExecution{
+			WorkflowId: domaindeleter.WorkflowID(domainID),
+		},
+		Query: &commonproto.WorkflowQuery{
+			QueryType: domaindeleter.ProgressQuery,
+		},
+	})
+	if err != nil {
+		ErrorAndExit("Query domain deleter failed.", err)
+	}
+
+	var progress domaindeleter.Progress
+	if err := json.Unmarshal(response.QueryResult, &progress); err != nil {
+		ErrorAndExit("Failed to decode domain deletion progress.", err)
+	}
+	prettyPrintJSONObject(progress)
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"encoding/json"
+
+	"github.com/urfave/cli"
+	commonproto "go.temporal.io/temporal-proto/common"
+	"go.temporal.io/temporal-proto/workflowservice"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/service/worker/scanner"
+)
+
+// AdminScanExecutions shows the reports of the executions scanner
+func AdminScanExecutions(c *cli.Context) {
+	serviceClient := cFactory.FrontendClient(c)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := serviceClient.QueryWorkflow(ctx, &workflowservice.QueryWorkflowRequest{
+		Domain: common.SystemLocalDomainName,
+		Execution: &commonproto.WorkflowExecution{
+			WorkflowId: scanner.ExecutionsScannerWFID,
+		},
+		Query: &commonproto.WorkflowQuery{
+			QueryType: scanner.ExecutionsScannerReportQuery,
+		},
+	})
+	if err != nil {
+		ErrorAndExit("Query executions scanner failed.", err)
+	}
+
+	var result scanner.ExecutionsScannerQueryResult
+	if err := json.Unmarshal(response.QueryResult, &result); err != nil {
+		ErrorAndExit("Failed to decode executions scanner report.", err)
+	}
+	prettyPrintJSONObject(result)
+}
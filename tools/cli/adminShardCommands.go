@@ -0,0 +1,180 @@
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+)
+
+// AdminListShardTasks lists the pending transfer, timer or replication tasks of a shard
+func AdminListShardTasks(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+
+	request := &adminservice.ListShardTasksRequest{
+		ShardID:                int32(getRequiredIntOption(c, FlagShardID)),
+		Category:               int32(getRequiredIntOption(c, FlagRemoveTypeID)),
+		Domain:                 c.GlobalString(FlagDomain),
+		WorkflowId:             c.String(FlagWorkflowID),
+		MinVisibilityTimestamp: parseTime(c.String(FlagEarliestTime), 0, time.Now()),
+		MaxVisibilityTimestamp: parseTime(c.String(FlagLatestTime), 0, time.Now()),
+		PageSize:               int32(c.Int(FlagPageSize)),
+	}
+	if c.IsSet(FlagTaskTypes) {
+		for _, taskType := range strings.Split(c.String(FlagTaskTypes), ",") {
+			value, err := strconv.Atoi(strings.TrimSpace(taskType))
+			if err != nil {
+				ErrorAndExit(fmt.Sprintf("Invalid task type %q.", taskType), err)
+			}
+			request.TaskTypes = append(request.TaskTypes, int32(value))
+		}
+	}
+
+	table := newShardTaskTable()
+	for {
+		ctx, cancel := newContext(c)
+		response, err := adminClient.ListShardTasks(ctx, request)
+		cancel()
+		if err != nil {
+			ErrorAndExit("Operation ListShardTasks failed.", err)
+		}
+
+		if request.NextPageToken == nil {
+			fmt.Printf("Ack level: %v, read level: %v\n", response.GetAckLevel(), response.GetReadLevel())
+			for clusterName, ackLevel := range response.GetClusterAckLevels() {
+				fmt.Printf("Ack level of cluster %v: %v\n", clusterName, ackLevel)
+			}
+		}
+		for _, task := range response.Tasks {
+			appendShardTask(table, task)
+		}
+		table.Render()
+		table.ClearRows()
+
+		if len(response.NextPageToken) == 0 || !c.Bool(FlagMore) || !showNextPage() {
+			return
+		}
+		request.NextPageToken = response.NextPageToken
+	}
+}
+
+// AdminListShardDLQTasks lists the tasks in the DLQ of a shard
+func AdminListShardDLQTasks(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+
+	request := &adminservice.ListShardDLQTasksRequest{
+		ShardID:  int32(getRequiredIntOption(c, FlagShardID)),
+		Type:     int32(getRequiredIntOption(c, FlagRemoveTypeID)),
+		PageSize: int32(c.Int(FlagPageSize)),
+	}
+
+	table := newShardTaskTable()
+	for {
+		ctx, cancel := newContext(c)
+		response, err := adminClient.ListShardDLQTasks(ctx, request)
+		cancel()
+		if err != nil {
+			ErrorAndExit("Operation ListShardDLQTasks failed.", err)
+		}
+
+		for _, task := range response.Tasks {
+			appendShardTask(table, task)
+		}
+		table.Render()
+		table.ClearRows()
+
+		if len(response.NextPageToken) == 0 || !c.Bool(FlagMore) || !showNextPage() {
+			return
+		}
+		request.NextPageToken = response.NextPageToken
+	}
+}
+
+// AdminRetryShardDLQTasks processes the tasks in the DLQ of a shard again
+func AdminRetryShardDLQTasks(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+
+	request := &adminservice.RetryShardDLQTasksRequest{
+		ShardID: int32(getRequiredIntOption(c, FlagShardID)),
+		Type:    int32(getRequiredIntOption(c, FlagRemoveTypeID)),
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.RetryShardDLQTasks(ctx, request)
+	if err != nil {
+		ErrorAndExit("Operation RetryShardDLQTasks failed.", err)
+	}
+	fmt.Printf("Succeeded: %v, failed: %v\n", response.GetSucceededCount(), response.GetFailedCount())
+}
+
+// AdminPurgeShardDLQTasks deletes all tasks in the DLQ of a shard
+func AdminPurgeShardDLQTasks(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+
+	request := &adminservice.PurgeShardDLQTasksRequest{
+		ShardID: int32(getRequiredIntOption(c, FlagShardID)),
+		Type:    int32(getRequiredIntOption(c, FlagRemoveTypeID)),
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.PurgeShardDLQTasks(ctx, request)
+	if err != nil {
+		ErrorAndExit("Operation PurgeShardDLQTasks failed.", err)
+	}
+	fmt.Printf("Purged: %v\n", response.GetPurgedCount())
+}
+
+func newShardTaskTable() *tablewriter.Table {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	header := []string{"Task ID", "Task Type", "Visibility Time", "Domain ID", "Workflow ID", "Run ID", "Version"}
+	headerColor := make([]tablewriter.Colors, len(header))
+	for i := range headerColor {
+		headerColor[i] = tableHeaderBlue
+	}
+	table.SetHeader(header)
+	table.SetHeaderColor(headerColor...)
+	table.SetHeaderLine(false)
+	return table
+}
+
+func appendShardTask(table *tablewriter.Table, task *adminservice.ShardTask) {
+	table.Append([]string{
+		strconv.FormatInt(task.GetTaskId(), 10),
+		strconv.Itoa(int(task.GetTaskType())),
+		convertTime(task.GetVisibilityTimestamp(), false),
+		task.GetDomainId(),
+		task.GetWorkflowId(),
+		task.GetRunId(),
+		strconv.FormatInt(task.GetVersion(), 10),
+	})
+}
@@ -25,17 +25,19 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/urfave/cli"
 	commonproto "go.temporal.io/temporal-proto/common"
 	"go.temporal.io/temporal-proto/enums"
-	"go.temporal.io/temporal-proto/workflowservice"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
 )
 
-// AdminDescribeTaskList displays poller and status information of task list.
+// AdminDescribeTaskList displays poller, status and stats information of task list.
 func AdminDescribeTaskList(c *cli.Context) {
-	frontendClient := cFactory.FrontendClient(c)
+	adminClient := cFactory.AdminClient(c)
 	domain := getRequiredGlobalOption(c, FlagDomain)
 	taskList := getRequiredOption(c, FlagTaskList)
 	taskListType := enums.TaskListTypeDecision
@@ -45,25 +47,45 @@ func AdminDescribeTaskList(c *cli.Context) {
 
 	ctx, cancel := newContext(c)
 	defer cancel()
-	request := &workflowservice.DescribeTaskListRequest{
-		Domain:                domain,
-		TaskList:              &commonproto.TaskList{Name: taskList},
-		TaskListType:          taskListType,
-		IncludeTaskListStatus: true,
+	request := &adminservice.DescribeTaskListRequest{
+		Domain:              domain,
+		TaskList:            &commonproto.TaskList{Name: taskList},
+		TaskListType:        taskListType,
+		AggregatePartitions: c.Bool(FlagAllPartitions),
 	}
 
-	response, err := frontendClient.DescribeTaskList(ctx, request)
+	response, err := adminClient.DescribeTaskList(ctx, request)
 	if err != nil {
 		ErrorAndExit("Operation DescribeTaskList failed.", err)
 	}
 
-	taskListStatus := response.GetTaskListStatus()
-	if taskListStatus == nil {
+	partitions := response.GetPartitions()
+	if len(partitions) == 0 || partitions[0].GetTaskListStatus() == nil {
 		ErrorAndExit(colorMagenta("No tasklist status information."), nil)
 	}
-	printTaskListStatus(taskListStatus)
+	if len(partitions) == 1 {
+		printTaskListStatus(partitions[0].GetTaskListStatus())
+	} else {
+		printTaskListPartitions(partitions)
+	}
+	fmt.Printf("\n")
+	printTaskListStats(response.GetStats())
 	fmt.Printf("\n")
 
+	if taskListType == enums.TaskListTypeDecision {
+		buildIDs, err := adminClient.GetTaskListBuildIDs(ctx, &adminservice.GetTaskListBuildIDsRequest{
+			Domain:   domain,
+			TaskList: &commonproto.TaskList{Name: taskList},
+		})
+		if err != nil {
+			ErrorAndExit("Operation GetTaskListBuildIDs failed.", err)
+		}
+		if len(buildIDs.GetBuildIdSets()) > 0 {
+			printBuildIDSets(buildIDs.GetBuildIdSets())
+			fmt.Printf("\n")
+		}
+	}
+
 	pollers := response.Pollers
 	if len(pollers) == 0 {
 		ErrorAndExit(colorMagenta("No poller for tasklist: "+taskList), nil)
@@ -71,6 +93,78 @@ func AdminDescribeTaskList(c *cli.Context) {
 	printPollerInfo(pollers, taskListType)
 }
 
+// AdminUpdateTaskListBuildIDs replaces the compatible worker build ID sets of a decision task list.
+func AdminUpdateTaskListBuildIDs(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	taskList := getRequiredOption(c, FlagTaskList)
+
+	var buildIDSets []*adminservice.BuildIdSet
+	for _, set := range strings.Split(c.String(FlagBuildIDSets), ";") {
+		set = strings.TrimSpace(set)
+		if set == "" {
+			continue
+		}
+		var buildIDs []string
+		for _, buildID := range strings.Split(set, ",") {
+			buildIDs = append(buildIDs, strings.TrimSpace(buildID))
+		}
+		buildIDSets = append(buildIDSets, &adminservice.BuildIdSet{BuildIds: buildIDs})
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	_, err := adminClient.UpdateTaskListBuildIDs(ctx, &adminservice.UpdateTaskListBuildIDsRequest{
+		Domain:      domain,
+		TaskList:    &commonproto.TaskList{Name: taskList},
+		BuildIdSets: buildIDSets,
+	})
+	if err != nil {
+		ErrorAndExit("Operation UpdateTaskListBuildIDs failed.", err)
+	}
+	fmt.Println("Build IDs of tasklist " + taskList + " updated.")
+}
+
+// AdminGetTaskListBuildIDs displays the compatible worker build ID sets of a decision task list.
+func AdminGetTaskListBuildIDs(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	taskList := getRequiredOption(c, FlagTaskList)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.GetTaskListBuildIDs(ctx, &adminservice.GetTaskListBuildIDsRequest{
+		Domain:   domain,
+		TaskList: &commonproto.TaskList{Name: taskList},
+	})
+	if err != nil {
+		ErrorAndExit("Operation GetTaskListBuildIDs failed.", err)
+	}
+
+	if len(response.GetBuildIdSets()) == 0 {
+		fmt.Println(colorMagenta("No build IDs for tasklist: " + taskList))
+		return
+	}
+	printBuildIDSets(response.GetBuildIdSets())
+}
+
+func printBuildIDSets(buildIDSets []*adminservice.BuildIdSet) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Build ID Set", "Build IDs"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue)
+	for i, set := range buildIDSets {
+		name := strconv.Itoa(i)
+		if i == len(buildIDSets)-1 {
+			name += " (latest)"
+		}
+		table.Append([]string{name, strings.Join(set.GetBuildIds(), ", ")})
+	}
+	table.Render()
+}
+
 func printTaskListStatus(taskListStatus *commonproto.TaskListStatus) {
 	taskIDBlock := taskListStatus.GetTaskIDBlock()
 
@@ -88,6 +182,44 @@ func printTaskListStatus(taskListStatus *commonproto.TaskListStatus) {
 	table.Render()
 }
 
+func printTaskListPartitions(partitions []*adminservice.TaskListPartitionStats) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Partition", "Owner", "Read Level", "Ack Level", "Backlog", "Backlog Age", "Add Rate", "Dispatch Rate"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	for _, partition := range partitions {
+		stats := partition.GetStats()
+		table.Append([]string{partition.GetKey(),
+			partition.GetOwnerHostName(),
+			strconv.FormatInt(partition.GetTaskListStatus().GetReadLevel(), 10),
+			strconv.FormatInt(partition.GetTaskListStatus().GetAckLevel(), 10),
+			strconv.FormatInt(stats.GetBacklogCountHint(), 10),
+			(time.Duration(stats.GetBacklogAgeMillis()) * time.Millisecond).String(),
+			strconv.FormatFloat(stats.GetAddRatePerSecond(), 'f', 2, 64),
+			strconv.FormatFloat(stats.GetDispatchRatePerSecond(), 'f', 2, 64)})
+	}
+	table.Render()
+}
+
+func printTaskListStats(stats *adminservice.TaskListStats) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Backlog", "Backlog Age", "Add Rate", "Dispatch Rate", "Sync Match Ratio", "Forwarded Adds", "Forwarded Dispatches"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	table.Append([]string{strconv.FormatInt(stats.GetBacklogCountHint(), 10),
+		(time.Duration(stats.GetBacklogAgeMillis()) * time.Millisecond).String(),
+		strconv.FormatFloat(stats.GetAddRatePerSecond(), 'f', 2, 64),
+		strconv.FormatFloat(stats.GetDispatchRatePerSecond(), 'f', 2, 64),
+		strconv.FormatFloat(stats.GetSyncMatchRatio(), 'f', 2, 64),
+		strconv.FormatInt(stats.GetForwardedAddCount(), 10),
+		strconv.FormatInt(stats.GetForwardedDispatchCount(), 10)})
+	table.Render()
+}
+
 func printPollerInfo(pollers []*commonproto.PollerInfo, taskListType enums.TaskListType) {
 	table := tablewriter.NewWriter(os.Stdout)
 	table.SetBorder(false)
@@ -132,6 +132,24 @@ func NewCliApp() *cli.App {
 					Usage:       "Run admin operation on cluster",
 					Subcommands: newAdminClusterCommands(),
 				},
+				{
+					Name:        "audit",
+					Aliases:     []string{"au"},
+					Usage:       "Run admin operation on the audit log of mutating API calls",
+					Subcommands: newAdminAuditCommands(),
+				},
+				{
+					Name:        "scan",
+					Aliases:     []string{"sc"},
+					Usage:       "Run admin operation on the background consistency scanners",
+					Subcommands: newAdminScanCommands(),
+				},
+				{
+					Name:        "archival",
+					Aliases:     []string{"arc"},
+					Usage:       "Run admin operation on archived workflow executions",
+					Subcommands: newAdminArchivalCommands(),
+				},
 			},
 		},
 		{
@@ -32,6 +32,7 @@ import (
 	"github.com/temporalio/temporal/common/archiver"
 	"github.com/temporalio/temporal/common/archiver/provider"
 	"github.com/temporalio/temporal/common/cluster"
+	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/domain"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/loggerimpl"
@@ -267,6 +268,7 @@ func initializeDomainHandler(
 	return domain.NewHandler(
 		domain.MinRetentionDays,
 		dynamicconfig.GetIntPropertyFilteredByDomain(domain.MaxBadBinaries),
+		dynamicconfig.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
 		logger,
 		metadataMgr,
 		clusterMetadata,
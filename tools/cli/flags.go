@@ -55,6 +55,7 @@ const (
 	FlagWorkflowIDReusePolicy             = "workflowidreusepolicy"
 	FlagWorkflowIDReusePolicyAlias        = FlagWorkflowIDReusePolicy + ", wrp"
 	FlagCronSchedule                      = "cron"
+	FlagDelayStart                        = "delay_start"
 	FlagWorkflowType                      = "workflow_type"
 	FlagWorkflowTypeWithAlias             = FlagWorkflowType + ", wt"
 	FlagWorkflowStatus                    = "status"
@@ -180,6 +181,15 @@ const (
 	FlagSearchAttributesKey               = "search_attr_key"
 	FlagSearchAttributesVal               = "search_attr_value"
 	FlagSearchAttributesType              = "search_attr_type"
+	FlagSearchAttributesField             = "search_attr_field"
+	FlagDefaultExecutionTimeout           = "default_execution_timeout"
+	FlagMaxExecutionTimeout               = "max_execution_timeout"
+	FlagDefaultDecisionTimeout            = "default_decision_timeout"
+	FlagDefaultActivityTimeout            = "default_activity_timeout"
+	FlagMaxActivityTimeout                = "max_activity_timeout"
+	FlagDefaultWorkflowIDReusePolicy      = "default_workflowidreusepolicy"
+	FlagDefaultWorkflowRetryPolicy        = "default_workflow_retry_policy"
+	FlagDefaultActivityRetryPolicy        = "default_activity_retry_policy"
 	FlagAddBadBinary                      = "add_bad_binary"
 	FlagRemoveBadBinary                   = "remove_bad_binary"
 	FlagResetType                         = "reset_type"
@@ -193,7 +203,9 @@ const (
 	FlagSignalNameWithAlias               = FlagSignalName + ", sig"
 	FlagRemoveTaskID                      = "task_id"
 	FlagRemoveTypeID                      = "type_id"
+	FlagTaskTypes                         = "task_types"
 	FlagRPS                               = "rps"
+	FlagConcurrency                       = "concurrency"
 	FlagJobID                             = "job_id"
 	FlagJobIDWithAlias                    = FlagJobID + ", jid"
 	FlagYes                               = "yes"
@@ -208,6 +220,15 @@ const (
 	FlagTLSKeyPath                        = "tls_key_path"
 	FlagTLSCaPath                         = "tls_ca_path"
 	FlagTLSEnableHostVerification         = "tls_enable_host_verification"
+	FlagActor                             = "actor"
+	FlagActorWithAlias                    = FlagActor + ", ac"
+	FlagBuildIDSets                       = "build_id_sets"
+	FlagBuildIDSetsWithAlias              = FlagBuildIDSets + ", bis"
+	FlagAllPartitions                     = "all_partitions"
+	FlagAllPartitionsWithAlias            = FlagAllPartitions + ", ap"
+	FlagOutputDirectory                   = "output_directory"
+	FlagOutputDirectoryWithAlias          = FlagOutputDirectory + ", od"
+	FlagOverwrite                         = "overwrite"
 )
 
 var flagsForExecution = []cli.Flag{
@@ -297,6 +318,10 @@ func getFlagsForStart() []cli.Flag {
 				"\t│ │ │ │ │ \n" +
 				"\t* * * * *",
 		},
+		cli.IntFlag{
+			Name:  FlagDelayStart,
+			Usage: "Optional delay in seconds before the first decision of the workflow is scheduled",
+		},
 		cli.IntFlag{
 			Name: FlagWorkflowIDReusePolicyAlias,
 			Usage: "Optional input to configure if the same workflow ID is allow to use for new workflow execution. " +
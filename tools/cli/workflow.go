@@ -436,6 +436,32 @@ func newBatchCommands() []cli.Command {
 				TerminateBatchJob(c)
 			},
 		},
+		{
+			Name:  "pause",
+			Usage: "pause a running batch operation job, the job continues from where it was paused when it is resumed",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagJobIDWithAlias,
+					Usage: "Batch Job ID",
+				},
+			},
+			Action: func(c *cli.Context) {
+				PauseBatchJob(c)
+			},
+		},
+		{
+			Name:  "resume",
+			Usage: "resume a paused batch operation job",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagJobIDWithAlias,
+					Usage: "Batch Job ID",
+				},
+			},
+			Action: func(c *cli.Context) {
+				ResumeBatchJob(c)
+			},
+		},
 		{
 			Name:    "list",
 			Aliases: []string{"l"},
@@ -481,6 +507,41 @@ func newBatchCommands() []cli.Command {
 					Value: batcher.DefaultRPS,
 					Usage: "RPS of processing",
 				},
+				cli.IntFlag{
+					Name:  FlagConcurrency,
+					Value: batcher.DefaultConcurrency,
+					Usage: "Number of workflows processed in parallel",
+				},
+				cli.StringFlag{
+					Name:  FlagResetType,
+					Usage: "Required for batch reset, where to reset. Support one of these: " + strings.Join(batcher.AllResetTypes, ","),
+				},
+				cli.StringFlag{
+					Name:  FlagResetBadBinaryChecksum,
+					Usage: "Binary checksum for resetType of BadBinary",
+				},
+				cli.BoolFlag{
+					Name:  FlagSkipCurrentOpen,
+					Usage: "Batch reset skips the workflow if the current run is open for the same workflowID as base.",
+				},
+				cli.BoolFlag{
+					Name:  FlagSkipBaseIsNotCurrent,
+					Usage: "Batch reset skips the workflow if base run is not current run.",
+				},
+				cli.BoolFlag{
+					Name:  FlagNonDeterministicOnly,
+					Usage: "Batch reset only applies onto workflows whose last event is decisionTaskFailed with non deterministic error.",
+				},
+				cli.StringFlag{
+					Name: FlagSearchAttributesKey,
+					Usage: "Required for batch upsertSearchAttributes, the search attributes keys to update. If there are multiple keys, concatenate them and separate by |. " +
+						"Use 'cluster get-search-attr' cmd to list legal keys.",
+				},
+				cli.StringFlag{
+					Name: FlagSearchAttributesVal,
+					Usage: "Required for batch upsertSearchAttributes, the search attributes values to update. If there are multiple values, concatenate them and separate by |. " +
+						"If value is array, use json array like [\"a\",\"b\"], [1,2], [\"true\",\"false\"]",
+				},
 				cli.BoolFlag{
 					Name:  FlagYes,
 					Usage: "Optional flag to disable confirmation prompt",
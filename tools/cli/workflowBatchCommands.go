@@ -55,6 +55,32 @@ func TerminateBatchJob(c *cli.Context) {
 	prettyPrintJSONObject(output)
 }
 
+// PauseBatchJob pauses a running batch job
+func PauseBatchJob(c *cli.Context) {
+	signalBatchJob(c, batcher.PauseSignalName, "batch job is paused")
+}
+
+// ResumeBatchJob resumes a paused batch job
+func ResumeBatchJob(c *cli.Context) {
+	signalBatchJob(c, batcher.ResumeSignalName, "batch job is resumed")
+}
+
+func signalBatchJob(c *cli.Context, signalName string, msg string) {
+	jobID := getRequiredOption(c, FlagJobID)
+	svcClient := cFactory.FrontendClient(c)
+	client := cclient.NewClient(svcClient, common.SystemLocalDomainName, &cclient.Options{})
+	tcCtx, cancel := newContext(c)
+	defer cancel()
+	err := client.SignalWorkflow(tcCtx, jobID, "", signalName, nil)
+	if err != nil {
+		ErrorAndExit("Failed to signal batch job", err)
+	}
+	output := map[string]interface{}{
+		"msg": msg,
+	}
+	prettyPrintJSONObject(output)
+}
+
 // DescribeBatchJob describe the status of the batch job
 func DescribeBatchJob(c *cli.Context) {
 	jobID := getRequiredOption(c, FlagJobID)
@@ -74,10 +100,28 @@ func DescribeBatchJob(c *cli.Context) {
 			output["msg"] = "batch job stopped status: " + wf.WorkflowExecutionInfo.GetCloseStatus().String()
 		} else {
 			output["msg"] = "batch job is finished successfully"
+			hbd := batcher.HeartBeatDetails{}
+			if err := client.GetWorkflow(tcCtx, jobID, "").Get(tcCtx, &hbd); err != nil {
+				ErrorAndExit("Failed to get result of batch job", err)
+			}
+			output["result"] = hbd
 		}
 	} else {
 		output["msg"] = "batch job is running"
-		if len(wf.PendingActivities) > 0 {
+		state := batcher.BatchState{}
+		value, err := client.QueryWorkflow(tcCtx, jobID, "", batcher.StateQueryType)
+		if err == nil {
+			err = value.Get(&state)
+		}
+		if err != nil {
+			// the job may have been started by a batcher without the state query, or no batcher worker
+			// is available to answer the query, the progress is still known from the activity heartbeat
+			output["msg"] = "batch job is running, failed to query whether it is paused: " + err.Error()
+		}
+		if state.Paused {
+			output["msg"] = "batch job is paused"
+			output["progress"] = state.Progress
+		} else if len(wf.PendingActivities) > 0 {
 			hbdBinary := wf.PendingActivities[0].HeartbeatDetails
 			hbd := batcher.HeartBeatDetails{}
 			err := json.Unmarshal(hbdBinary, &hbd)
@@ -142,7 +186,36 @@ func StartBatchJob(c *cli.Context) {
 		sigName = getRequiredOption(c, FlagSignalName)
 		sigVal = getRequiredOption(c, FlagInput)
 	}
+	var resetParams batcher.ResetParams
+	if batchType == batcher.BatchTypeReset {
+		resetParams = batcher.ResetParams{
+			ResetType:            getRequiredOption(c, FlagResetType),
+			BadBinaryChecksum:    c.String(FlagResetBadBinaryChecksum),
+			SkipCurrentOpen:      c.Bool(FlagSkipCurrentOpen),
+			SkipBaseIsNotCurrent: c.Bool(FlagSkipBaseIsNotCurrent),
+			NonDeterministicOnly: c.Bool(FlagNonDeterministicOnly),
+		}
+		if extraForResetType, ok := resetTypesMap[resetParams.ResetType]; !ok {
+			ErrorAndExit("Not supported reset type", nil)
+		} else if len(extraForResetType) > 0 {
+			getRequiredOption(c, extraForResetType)
+		}
+	}
+	searchAttributes := map[string]interface{}{}
+	if batchType == batcher.BatchTypeUpsertSearchAttributes {
+		for key, value := range processSearchAttr(c) {
+			var v interface{}
+			if err := json.Unmarshal(value, &v); err != nil {
+				ErrorAndExit(fmt.Sprintf("Decode value of search attribute %v error", key), err)
+			}
+			searchAttributes[key] = v
+		}
+		if len(searchAttributes) == 0 {
+			ErrorAndExit("Must provide search attributes to upsert", nil)
+		}
+	}
 	rps := c.Int(FlagRPS)
+	concurrency := c.Int(FlagConcurrency)
 
 	svcClient := cFactory.FrontendClient(c)
 	client := cclient.NewClient(svcClient, common.SystemLocalDomainName, &cclient.Options{})
@@ -195,7 +268,12 @@ func StartBatchJob(c *cli.Context) {
 			SignalName: sigName,
 			Input:      sigVal,
 		},
-		RPS: rps,
+		ResetParams: resetParams,
+		UpsertSearchAttributesParams: batcher.UpsertSearchAttributesParams{
+			SearchAttributes: searchAttributes,
+		},
+		RPS:         rps,
+		Concurrency: concurrency,
 	}
 	wf, err := client.StartWorkflow(tcCtx, options, batcher.BatchWFTypeName, params)
 	if err != nil {
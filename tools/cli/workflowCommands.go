@@ -46,8 +46,10 @@ import (
 	"go.temporal.io/temporal-proto/workflowservice"
 	"go.temporal.io/temporal/client"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/temporalio/temporal/.gen/go/shared"
+	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/adapter"
 	"github.com/temporalio/temporal/common/clock"
 	"github.com/temporalio/temporal/service/history"
@@ -166,6 +168,14 @@ func RunWorkflow(c *cli.Context) {
 	startWorkflowHelper(c, true)
 }
 
+// withDelayStart passes the optional start delay to the server, it is sent as a request header
+func withDelayStart(ctx context.Context, c *cli.Context) context.Context {
+	if !c.IsSet(FlagDelayStart) {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, common.DelayStartSecondsHeaderName, strconv.Itoa(c.Int(FlagDelayStart)))
+}
+
 func startWorkflowHelper(c *cli.Context, shouldPrintProgress bool) {
 	serviceClient := cFactory.FrontendClient(c)
 
@@ -220,7 +230,7 @@ func startWorkflowHelper(c *cli.Context, shouldPrintProgress bool) {
 	startFn := func() {
 		tcCtx, cancel := newContext(c)
 		defer cancel()
-		resp, err := serviceClient.StartWorkflowExecution(tcCtx, startRequest)
+		resp, err := serviceClient.StartWorkflowExecution(withDelayStart(tcCtx, c), startRequest)
 
 		if err != nil {
 			ErrorAndExit("Failed to create workflow.", err)
@@ -232,7 +242,7 @@ func startWorkflowHelper(c *cli.Context, shouldPrintProgress bool) {
 	runFn := func() {
 		tcCtx, cancel := newContextForLongPoll(c)
 		defer cancel()
-		resp, err := serviceClient.StartWorkflowExecution(tcCtx, startRequest)
+		resp, err := serviceClient.StartWorkflowExecution(withDelayStart(tcCtx, c), startRequest)
 
 		if err != nil {
 			ErrorAndExit("Failed to run workflow.", err)